@@ -2,9 +2,13 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -14,14 +18,44 @@ import (
 )
 
 func main() {
-	// Create a new MCP server
-	s := server.NewMCPServer("journal-mcp", "1.0.0", server.WithToolCapabilities(true))
+	// Self-update runs before anything touches the data directory
+	if len(os.Args) > 1 && os.Args[1] == "self-update" {
+		runSelfUpdate()
+		return
+	}
+
+	// First-run setup wizard
+	if len(os.Args) > 1 && os.Args[1] == "init" {
+		if err := servers.RunSetupWizard(os.Stdin, os.Stdout, servers.DefaultDataDir()); err != nil {
+			log.Fatal("Setup failed: ", err)
+		}
+		return
+	}
+
+	// Guided demo on a temporary journal seeded with sample data
+	if len(os.Args) > 1 && os.Args[1] == "demo" {
+		runDemo()
+		return
+	}
+
+	// Run one tool and print its output, e.g. journal-mcp call list_tasks format=ndjson | jq
+	if len(os.Args) > 1 && os.Args[1] == "call" {
+		os.Exit(runCall(servers.NewJournalService(), os.Args[2:], os.Stdout, os.Stderr))
+	}
 
 	// Initialize the journal service
 	journalService := servers.NewJournalService()
+	s := newMCPServer(journalService)
+
+	// Background jobs such as the morning snapshot of active tasks
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	servers.NewScheduler(journalService).Start(ctx)
 
-	// Register tools
-	registerTools(s, journalService)
+	// Pick up task files edited by hand or by sync tools (watch.enabled)
+	if err := servers.NewWatcher(journalService).Start(ctx); err != nil {
+		log.Printf("Data directory watcher failed to start: %v", err)
+	}
 
 	// Check if web server should be started
 	if len(os.Args) > 1 && os.Args[1] == "--web" {
@@ -41,6 +75,79 @@ func main() {
 	}
 }
 
+// newMCPServer creates the MCP server with every tool and prompt template registered
+func newMCPServer(journalService *servers.JournalService) *server.MCPServer {
+	s := server.NewMCPServer("journal-mcp", servers.Version,
+		server.WithToolCapabilities(true),
+		server.WithPromptCapabilities(true),
+		server.WithToolHandlerMiddleware(journalService.ProfileFooterMiddleware),
+		server.WithToolHandlerMiddleware(journalService.UsageMiddleware),
+		server.WithToolHandlerMiddleware(journalService.QuotaMiddleware),
+	)
+
+	// Tools turned off in config are never added, so clients do not list them
+	filter := journalService.ToolFilter()
+	registerTools(filteredRegistrar{s, filter}, journalService)
+	for _, pattern := range filter.Unmatched() {
+		log.Printf("tools.disabled: %q matches no tool", pattern)
+	}
+	registerPrompts(s, journalService)
+	journalService.Tools = registeredToolNames(s)
+	return s
+}
+
+// toolRegistrar is the part of the MCP server registerTools uses
+type toolRegistrar interface {
+	AddTool(tool mcp.Tool, handler server.ToolHandlerFunc)
+}
+
+// filteredRegistrar skips the tools a ToolFilter disables
+type filteredRegistrar struct {
+	toolRegistrar
+	filter *servers.ToolFilter
+}
+
+func (r filteredRegistrar) AddTool(tool mcp.Tool, handler server.ToolHandlerFunc) {
+	if r.filter.Enabled(tool.Name) {
+		r.toolRegistrar.AddTool(tool, handler)
+	}
+}
+
+// runDemo serves MCP on stdio against a throwaway journal. ServeStdio returns on
+// SIGINT and SIGTERM, so the journal is removed on those too. Background jobs
+// stay off so the demo never writes outside its directory.
+func runDemo() {
+	journalService, cleanup, err := servers.NewDemoJournalService()
+	if err != nil {
+		log.Fatal("Demo setup failed: ", err)
+	}
+	defer cleanup()
+
+	s := newMCPServer(journalService)
+	s.AddPrompt(mcp.NewPrompt("demo_tour",
+		mcp.WithPromptDescription("Guided tour of the tools on the demo journal's sample data"),
+	), journalService.DemoTourPrompt)
+
+	log.Printf("Demo journal at %s (deleted on exit); use the demo_tour prompt to get started", journalService.DataDir)
+	if err := server.ServeStdio(s); err != nil {
+		log.Printf("MCP server error: %v", err)
+	}
+}
+
+func runSelfUpdate() {
+	checkOnly := len(os.Args) > 2 && os.Args[2] == "--check"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	result, err := servers.SelfUpdate(ctx, checkOnly)
+	if err != nil {
+		log.Fatal("Self-update failed: ", err)
+	}
+
+	fmt.Println(result.Summary)
+}
+
 func startWebMode(journalService *servers.JournalService) {
 	webServer := servers.NewWebServer(journalService, 8080)
 
@@ -96,7 +203,33 @@ func startDualMode(mcpServer *server.MCPServer, journalService *servers.JournalS
 	log.Println("Servers stopped")
 }
 
-func registerTools(s *server.MCPServer, js *servers.JournalService) {
+func registerPrompts(s *server.MCPServer, js *servers.JournalService) {
+	s.AddPrompt(mcp.NewPrompt("daily_standup",
+		mcp.WithPromptDescription("Standup from the previous workday's entries and current active and blocked tasks"),
+		mcp.WithArgument("date",
+			mcp.ArgumentDescription("Standup date in YYYY-MM-DD format (default: today)"),
+		),
+	), js.DailyStandupPrompt)
+
+	s.AddPrompt(mcp.NewPrompt("weekly_review",
+		mcp.WithPromptDescription("Weekly review and mini retro from a week's entries, completions and open tasks"),
+		mcp.WithArgument("week_start",
+			mcp.ArgumentDescription("Week start date in YYYY-MM-DD format (default: this Monday)"),
+		),
+	), js.WeeklyReviewPrompt)
+
+	s.AddPrompt(mcp.NewPrompt("one_on_one_prep",
+		mcp.WithPromptDescription("Talking points from work since the last 1-on-1 and its action items"),
+		mcp.WithArgument("person",
+			mcp.ArgumentDescription("Who the meeting is with"),
+		),
+		mcp.WithArgument("days",
+			mcp.ArgumentDescription("Days of history to include when there is no earlier 1-on-1 (default: 14)"),
+		),
+	), js.OneOnOnePrepPrompt)
+}
+
+func registerTools(s toolRegistrar, js *servers.JournalService) {
 	// Task Management Tools
 	s.AddTool(mcp.NewTool("create_task",
 		mcp.WithDescription("Create a new task with optional issue linking"),
@@ -122,8 +255,56 @@ func registerTools(s *server.MCPServer, js *servers.JournalService) {
 		mcp.WithString("priority",
 			mcp.Description("Priority level: low, medium, high, urgent"),
 		),
+		mcp.WithString("due_date",
+			mcp.Description("Due date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("estimate",
+			mcp.Description("Effort estimate in minutes or as a duration, e.g. 90m, 3h or 1h30m"),
+		),
+		mcp.WithString("someday",
+			mcp.Description("Park the task on the someday/maybe list instead of making it active (true/false)"),
+		),
+		mcp.WithString("assignee",
+			mcp.Description("Team member who owns the task"),
+		),
 	), js.CreateTask)
 
+	s.AddTool(mcp.NewTool("update_task",
+		mcp.WithDescription("Update task metadata such as title, priority, tags or due date"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("Task identifier"),
+		),
+		mcp.WithString("title",
+			mcp.Description("New title"),
+		),
+		mcp.WithString("type",
+			mcp.Description("Task type: work, learning, personal, investigation"),
+		),
+		mcp.WithString("priority",
+			mcp.Description("Priority level: low, medium, high, urgent (none clears it)"),
+		),
+		mcp.WithArray("tags",
+			mcp.Description("Replacement tags"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("issue_url",
+			mcp.Description("Full URL to GitHub issue or Jira ticket"),
+		),
+		mcp.WithString("due_date",
+			mcp.Description("Due date in YYYY-MM-DD format (none clears it)"),
+		),
+		mcp.WithString("estimate",
+			mcp.Description("Effort estimate in minutes or as a duration, e.g. 90m or 3h (none clears it). A new estimate re-arms the re-estimation prompt"),
+		),
+		mcp.WithString("assignee",
+			mcp.Description("Team member who owns the task (none clears it)"),
+		),
+		mcp.WithString("snoozed",
+			mcp.Description("Exempt the task from auto-pause (true/false)"),
+		),
+	), js.UpdateTask)
+
 	s.AddTool(mcp.NewTool("add_task_entry",
 		mcp.WithDescription("Add a timestamped entry to an existing task"),
 		mcp.WithString("task_id",
@@ -137,20 +318,157 @@ func registerTools(s *server.MCPServer, js *servers.JournalService) {
 		mcp.WithString("timestamp",
 			mcp.Description("ISO timestamp (defaults to now)"),
 		),
+		mcp.WithString("entry_type",
+			mcp.Description("Entry type, e.g. decision (default: log)"),
+		),
+		mcp.WithString("mood",
+			mcp.Description("How you feel, from 1 (low) to 5 (high)"),
+		),
+		mcp.WithString("energy",
+			mcp.Description("Your energy, from 1 (low) to 5 (high)"),
+		),
+		mcp.WithArray("tags",
+			mcp.Description("Entry tags, e.g. decision or blocker; hashtags in the content such as #idea are added too"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
 	), js.AddTaskEntry)
 
+	s.AddTool(mcp.NewTool("update_task_entry",
+		mcp.WithDescription("Change an entry's content; the previous content is kept in the entry's edit history"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("Task identifier"),
+		),
+		mcp.WithString("entry_id",
+			mcp.Required(),
+			mcp.Description("Entry identifier (get_task with show_entry_ids=true lists them)"),
+		),
+		mcp.WithString("content",
+			mcp.Required(),
+			mcp.Description("New entry content"),
+		),
+		mcp.WithArray("tags",
+			mcp.Description("Replace the entry's tags (hashtags in the content are kept); without it, tags follow the hashtags added to or removed from the content"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+	), js.UpdateTaskEntry)
+
+	s.AddTool(mcp.NewTool("delete_task_entry",
+		mcp.WithDescription("Remove an entry, leaving a deletion record that keeps its content"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("Task identifier"),
+		),
+		mcp.WithString("entry_id",
+			mcp.Required(),
+			mcp.Description("Entry identifier (get_task with show_entry_ids=true lists them)"),
+		),
+		mcp.WithString("reason",
+			mcp.Description("Why the entry was removed"),
+		),
+	), js.DeleteTaskEntry)
+
+	s.AddTool(mcp.NewTool("move_entry",
+		mcp.WithDescription("Move an entry logged on the wrong task to the right one, keeping its timestamp and noting the move on both tasks"),
+		mcp.WithString("from_task_id",
+			mcp.Required(),
+			mcp.Description("Task the entry is on now"),
+		),
+		mcp.WithString("entry_id",
+			mcp.Required(),
+			mcp.Description("Entry identifier (get_task with show_entry_ids=true lists them)"),
+		),
+		mcp.WithString("to_task_id",
+			mcp.Required(),
+			mcp.Description("Task the entry belongs to"),
+		),
+		mcp.WithString("reason",
+			mcp.Description("Why the entry was moved"),
+		),
+	), js.MoveEntry)
+
+	s.AddTool(mcp.NewTool("split_entry",
+		mcp.WithDescription("Break a long entry into several at separator lines; a separator followed by HH:MM (e.g. '--- 14:30') timestamps the next part"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("Task identifier"),
+		),
+		mcp.WithString("entry_id",
+			mcp.Required(),
+			mcp.Description("Entry identifier (get_task with show_entry_ids=true lists them)"),
+		),
+		mcp.WithString("separator",
+			mcp.Description("Line that marks a split point (default: ---)"),
+		),
+	), js.SplitEntry)
+
+	s.AddTool(mcp.NewTool("merge_entries",
+		mcp.WithDescription("Combine consecutive entries into the first one, keeping its timestamp and the earlier content in its history"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("Task identifier"),
+		),
+		mcp.WithArray("entry_ids",
+			mcp.Required(),
+			mcp.Description("Two or more consecutive entry identifiers"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("separator",
+			mcp.Description("Text placed between the merged contents (default: a blank line)"),
+		),
+	), js.MergeEntries)
+
+	s.AddTool(mcp.NewTool("add_attachment",
+		mcp.WithDescription("Attach a file (screenshot, log, PDF) to an entry, or to a new entry when entry_id is omitted"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("Task identifier"),
+		),
+		mcp.WithString("file_path",
+			mcp.Description("Local file to attach"),
+		),
+		mcp.WithString("content_base64",
+			mcp.Description("File content as base64, instead of file_path"),
+		),
+		mcp.WithString("name",
+			mcp.Description("File name (default: the name of file_path; required with content_base64)"),
+		),
+		mcp.WithString("entry_id",
+			mcp.Description("Entry to attach to (default: a new entry)"),
+		),
+		mcp.WithString("caption",
+			mcp.Description("Content of the new entry (default: \"Attached <name>\")"),
+		),
+		mcp.WithString("media_type",
+			mcp.Description("Media type, e.g. image/png (default: guessed from the name and content)"),
+		),
+	), js.AddAttachment)
+
+	s.AddTool(mcp.NewTool("gc_attachments",
+		mcp.WithDescription("Remove stored attachment files that no live, archived or deleted task refers to any more"),
+		mcp.WithString("dry_run",
+			mcp.Description("Report what would be removed without removing it (true/false, default: false)"),
+		),
+	), js.GCAttachments)
+
 	s.AddTool(mcp.NewTool("get_task",
 		mcp.WithDescription("Retrieve complete task history"),
 		mcp.WithString("task_id",
 			mcp.Required(),
 			mcp.Description("Task identifier"),
 		),
+		mcp.WithString("style",
+			mcp.Description("Markdown style: default, obsidian, confluence or a name from markdown.styles (default: markdown.style in config)"),
+		),
+		mcp.WithString("show_entry_ids",
+			mcp.Description("List entry IDs for update_task_entry and delete_task_entry (true/false, default: false)"),
+		),
 	), js.GetTask)
 
 	s.AddTool(mcp.NewTool("list_tasks",
 		mcp.WithDescription("List tasks with optional filtering and pagination"),
 		mcp.WithString("status",
-			mcp.Description("Filter by status: active, completed, paused"),
+			mcp.Description("Filter by status: active, completed, paused, blocked, someday (someday tasks are hidden unless requested)"),
 		),
 		mcp.WithString("type",
 			mcp.Description("Filter by type: work, learning, personal, investigation"),
@@ -167,164 +485,1409 @@ func registerTools(s *server.MCPServer, js *servers.JournalService) {
 		mcp.WithString("offset",
 			mcp.Description("Number of tasks to skip for pagination (default: 0)"),
 		),
+		mcp.WithString("parent",
+			mcp.Description("Only subtasks of this task ID, or \"none\" for top-level tasks only"),
+		),
+		mcp.WithString("due",
+			mcp.Description("Filter by due date: overdue, due_today, due_this_week"),
+		),
+		mcp.WithString("assignee",
+			mcp.Description("Filter by assignee"),
+		),
+		mcp.WithString("include_archived",
+			mcp.Description("Include tasks moved to the archive (true/false, default: false)"),
+		),
+		mcp.WithString("view",
+			mcp.Description("Output format: list (default) or tree (subtasks nested under parents)"),
+		),
+		mcp.WithString("focus",
+			mcp.Description("Daily planning view: hide snoozed, paused, low-priority and someday tasks and show the top few by triage score (true/false)"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: markdown, or ndjson for one JSON task per line with its entries counted (default: markdown)"),
+		),
 	), js.ListTasks)
 
+	s.AddTool(mcp.NewTool("create_subtask",
+		mcp.WithDescription("Create a subtask under an existing task"),
+		mcp.WithString("parent_id",
+			mcp.Required(),
+			mcp.Description("Parent task identifier"),
+		),
+		mcp.WithString("id",
+			mcp.Required(),
+			mcp.Description("Subtask identifier"),
+		),
+		mcp.WithString("title",
+			mcp.Required(),
+			mcp.Description("Subtask title"),
+		),
+		mcp.WithString("type",
+			mcp.Description("Task type (default: the parent's type)"),
+		),
+		mcp.WithString("priority",
+			mcp.Description("Priority (default: the parent's priority)"),
+		),
+		mcp.WithArray("tags",
+			mcp.Description("Tags for the subtask"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+	), js.CreateSubtask)
+
 	s.AddTool(mcp.NewTool("update_task_status",
-		mcp.WithDescription("Change task status (active/completed/paused/blocked)"),
+		mcp.WithDescription("Change task status (active/completed/paused/blocked/someday)"),
 		mcp.WithString("task_id",
 			mcp.Required(),
 			mcp.Description("Task identifier"),
 		),
 		mcp.WithString("status",
 			mcp.Required(),
-			mcp.Description("New status: active, completed, paused, blocked"),
+			mcp.Description("New status: active, completed, paused, blocked, someday"),
 		),
 		mcp.WithString("reason",
 			mcp.Description("Optional reason for status change"),
 		),
+		mcp.WithString("force",
+			mcp.Description("Complete the task even if it has open dependencies (true/false)"),
+		),
 	), js.UpdateTaskStatus)
 
-	// Daily and Weekly Logs
-	s.AddTool(mcp.NewTool("get_daily_log",
-		mcp.WithDescription("View all activity for a specific date"),
-		mcp.WithString("date",
+	s.AddTool(mcp.NewTool("bulk_update_tasks",
+		mcp.WithDescription("Apply one operation to every task matching a filter (at least one filter is required) and summarize the affected tasks"),
+		mcp.WithString("operation",
 			mcp.Required(),
-			mcp.Description("Date in YYYY-MM-DD format"),
+			mcp.Description("Operation: set_status, add_tag, remove_tag, set_priority or archive"),
 		),
-	), js.GetDailyLog)
-
-	s.AddTool(mcp.NewTool("get_weekly_log",
-		mcp.WithDescription("View activity for a week (aggregate daily logs)"),
-		mcp.WithString("week_start",
-			mcp.Required(),
-			mcp.Description("Week start date in YYYY-MM-DD format"),
+		mcp.WithString("value",
+			mcp.Description("Status, tag or priority (low, medium, high, urgent) for the operation; not used by archive"),
 		),
-	), js.GetWeeklyLog)
-
-	// One-on-One Meeting Tools
-	s.AddTool(mcp.NewTool("create_one_on_one",
-		mcp.WithDescription("Record structured meeting notes"),
-		mcp.WithString("date",
-			mcp.Required(),
-			mcp.Description("Meeting date in YYYY-MM-DD format"),
+		mcp.WithString("status",
+			mcp.Description("Filter by status: active, completed, paused, blocked, someday (someday tasks are only matched when requested)"),
 		),
-		mcp.WithArray("insights",
-			mcp.Description("Key insights from the meeting"),
-			mcp.Items(map[string]any{"type": "string"}),
+		mcp.WithString("type",
+			mcp.Description("Filter by type: work, learning, personal, investigation"),
 		),
-		mcp.WithArray("todos",
-			mcp.Description("Action items and todos"),
+		mcp.WithArray("tags",
+			mcp.Description("Filter to tasks with any of these tags"),
 			mcp.Items(map[string]any{"type": "string"}),
 		),
-		mcp.WithArray("feedback",
-			mcp.Description("Feedback points"),
-			mcp.Items(map[string]any{"type": "string"}),
+		mcp.WithString("date_from",
+			mcp.Description("Filter tasks updated from this date (YYYY-MM-DD format)"),
 		),
-		mcp.WithString("notes",
-			mcp.Description("Additional meeting notes"),
+		mcp.WithString("date_to",
+			mcp.Description("Filter tasks updated until this date (YYYY-MM-DD format)"),
 		),
-	), js.CreateOneOnOne)
-
-	s.AddTool(mcp.NewTool("get_one_on_one_history",
-		mcp.WithDescription("Retrieve meeting history"),
-		mcp.WithString("limit",
-			mcp.Description("Number of meetings to retrieve (default: 10)"),
+		mcp.WithString("dry_run",
+			mcp.Description("List the tasks that would change without changing them (true/false, default: false)"),
 		),
-	), js.GetOneOnOneHistory)
+		mcp.WithString("force",
+			mcp.Description("Complete tasks even if they have open dependencies (true/false)"),
+		),
+	), js.BulkUpdateTasks)
 
-	// Search and Export Tools
-	s.AddTool(mcp.NewTool("search_entries",
-		mcp.WithDescription("Search through all journal content"),
-		mcp.WithString("query",
+	s.AddTool(mcp.NewTool("add_tag_rule",
+		mcp.WithDescription("Add a rule that tags tasks whose title or entries match a pattern; applied to new tasks and entries"),
+		mcp.WithString("pattern",
 			mcp.Required(),
-			mcp.Description("Search query text"),
+			mcp.Description("Regular expression, matched case-insensitively (e.g. 'postgres|pg_dump')"),
 		),
-		mcp.WithString("task_type",
-			mcp.Description("Filter by task type: work, learning, personal, investigation"),
+		mcp.WithString("tag",
+			mcp.Required(),
+			mcp.Description("Tag to add when the pattern matches"),
 		),
-		mcp.WithString("date_from",
-			mcp.Description("Start date filter (YYYY-MM-DD)"),
+		mcp.WithString("field",
+			mcp.Description("What the pattern is matched against: content, title or any (default: any)"),
 		),
-		mcp.WithString("date_to",
-			mcp.Description("End date filter (YYYY-MM-DD)"),
+		mcp.WithString("name",
+			mcp.Description("Rule name (default: the tag)"),
 		),
-	), js.SearchEntries)
+	), js.AddTagRule)
 
-	s.AddTool(mcp.NewTool("export_data",
-		mcp.WithDescription("Export journal data to various formats"),
-		mcp.WithString("format",
+	s.AddTool(mcp.NewTool("list_tag_rules",
+		mcp.WithDescription("List the configured tag rules"),
+	), js.ListTagRules)
+
+	s.AddTool(mcp.NewTool("remove_tag_rule",
+		mcp.WithDescription("Remove a tag rule; tags it already added are kept"),
+		mcp.WithString("name",
 			mcp.Required(),
-			mcp.Description("Export format: json, markdown, csv"),
+			mcp.Description("Rule name"),
 		),
-		mcp.WithString("date_from",
-			mcp.Description("Start date filter (YYYY-MM-DD)"),
+	), js.RemoveTagRule)
+
+	s.AddTool(mcp.NewTool("apply_tag_rules",
+		mcp.WithDescription("Backfill tag rules over existing tasks, matching titles and every entry"),
+		mcp.WithString("rule",
+			mcp.Description("Apply only this rule (default: all rules)"),
 		),
-		mcp.WithString("date_to",
-			mcp.Description("End date filter (YYYY-MM-DD)"),
+		mcp.WithString("dry_run",
+			mcp.Description("Preview the tasks that would be tagged without changing them (true/false, default: false)"),
 		),
-		mcp.WithString("task_filter",
-			mcp.Description("Filter by task type: work, learning, personal, investigation"),
+	), js.ApplyTagRules)
+
+	s.AddTool(mcp.NewTool("archive_task",
+		mcp.WithDescription("Move a task out of the active journal into the archive, hiding it from listings, search and reports"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("Task identifier"),
 		),
-	), js.ExportData)
+		mcp.WithString("reason",
+			mcp.Description("Optional note recorded with the archive entry"),
+		),
+	), js.ArchiveTask)
 
-	// Import and Analytics Tools
-	s.AddTool(mcp.NewTool("import_data",
-		mcp.WithDescription("Import existing diary/journal data from various formats"),
-		mcp.WithString("content",
+	s.AddTool(mcp.NewTool("unarchive_task",
+		mcp.WithDescription("Move an archived task back into the active journal"),
+		mcp.WithString("task_id",
 			mcp.Required(),
-			mcp.Description("File content to import"),
+			mcp.Description("Task identifier"),
 		),
-		mcp.WithString("format",
+	), js.UnarchiveTask)
+
+	s.AddTool(mcp.NewTool("add_task_dependency",
+		mcp.WithDescription("Record that a task depends on another task being completed first"),
+		mcp.WithString("task_id",
 			mcp.Required(),
-			mcp.Description("Input format: txt, markdown, json, csv"),
+			mcp.Description("Task identifier"),
+		),
+		mcp.WithString("depends_on",
+			mcp.Required(),
+			mcp.Description("ID of the task it depends on"),
+		),
+	), js.AddTaskDependency)
+
+	s.AddTool(mcp.NewTool("remove_task_dependency",
+		mcp.WithDescription("Remove a dependency between two tasks"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("Task identifier"),
+		),
+		mcp.WithString("depends_on",
+			mcp.Required(),
+			mcp.Description("ID of the task it no longer depends on"),
+		),
+	), js.RemoveTaskDependency)
+
+	s.AddTool(mcp.NewTool("delete_task",
+		mcp.WithDescription("Delete a task by moving it to the trash (recoverable with restore_task)"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("Task identifier"),
+		),
+		mcp.WithString("reason",
+			mcp.Description("Optional reason for deleting the task"),
+		),
+	), js.DeleteTask)
+
+	s.AddTool(mcp.NewTool("list_deleted_tasks",
+		mcp.WithDescription("List tasks in the trash, most recently deleted first"),
+	), js.ListDeletedTasks)
+
+	s.AddTool(mcp.NewTool("restore_task",
+		mcp.WithDescription("Restore the most recently deleted copy of a task from the trash"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("Task identifier"),
+		),
+	), js.RestoreTask)
+
+	// Time Tracking
+	s.AddTool(mcp.NewTool("start_timer",
+		mcp.WithDescription("Start tracking time on a task (stops a timer running on another task)"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("Task identifier"),
+		),
+	), js.StartTimer)
+
+	s.AddTool(mcp.NewTool("stop_timer",
+		mcp.WithDescription("Stop the running timer and record the time spent as an entry"),
+		mcp.WithString("task_id",
+			mcp.Description("Task identifier (default: the task with the running timer)"),
+		),
+		mcp.WithString("note",
+			mcp.Description("What the time was spent on"),
+		),
+	), js.StopTimer)
+
+	s.AddTool(mcp.NewTool("get_time_report",
+		mcp.WithDescription("Hours tracked per task, task type and day"),
+		mcp.WithString("date_from",
+			mcp.Description("Start date in YYYY-MM-DD format (default: 6 days ago)"),
+		),
+		mcp.WithString("date_to",
+			mcp.Description("End date in YYYY-MM-DD format (default: today)"),
+		),
+	), js.GetTimeReport)
+
+	s.AddTool(mcp.NewTool("start_focus_session",
+		mcp.WithDescription("Start a timed focus session (pomodoro) on a task; it is recorded as a focus entry when it ends, and any running timer is stopped"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("The task to focus on"),
+		),
+		mcp.WithString("minutes",
+			mcp.Description("Session length in minutes, 1 to 180 (default: 25)"),
+		),
+		mcp.WithString("goal",
+			mcp.Description("What the session is for, kept on the focus entry"),
+		),
+	), js.StartFocusSession)
+
+	s.AddTool(mcp.NewTool("end_focus_session",
+		mcp.WithDescription("End the running focus session early and record the time focused so far"),
+		mcp.WithString("note",
+			mcp.Description("Optional note on what got done"),
+		),
+	), js.EndFocusSession)
+
+	s.AddTool(mcp.NewTool("get_focus_report",
+		mcp.WithDescription("Focus sessions and focus time per day or week, beside all tracked time"),
+		mcp.WithString("date_from",
+			mcp.Description("Start date in YYYY-MM-DD format (default: 6 days ago, or the start of the week 3 weeks ago when grouping by week)"),
+		),
+		mcp.WithString("date_to",
+			mcp.Description("End date in YYYY-MM-DD format (default: today)"),
+		),
+		mcp.WithString("group_by",
+			mcp.Description("Period to group by: day or week (default: day)"),
+		),
+	), js.GetFocusReport)
+
+	// Daily and Weekly Logs
+	s.AddTool(mcp.NewTool("get_daily_log",
+		mcp.WithDescription("View all activity for a specific date"),
+		mcp.WithString("date",
+			mcp.Required(),
+			mcp.Description("Date in YYYY-MM-DD format"),
+		),
+		mcp.WithArray("tags",
+			mcp.Description("Only entries with any of these entry tags, e.g. decision"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("style",
+			mcp.Description("Markdown style: default, obsidian, confluence or a name from markdown.styles (default: markdown.style in config)"),
+		),
+		mcp.WithString("timezone",
+			mcp.Description("IANA time zone to bucket entries into days, e.g. America/Denver (default: general.timezone)"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: markdown, or ndjson for one JSON entry per line with its date and task (default: markdown)"),
+		),
+	), js.GetDailyLog)
+
+	s.AddTool(mcp.NewTool("get_weekly_log",
+		mcp.WithDescription("View activity for a week (aggregate daily logs)"),
+		mcp.WithString("week_start",
+			mcp.Required(),
+			mcp.Description("Week start date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("group_by",
+			mcp.Description("Group entries by day, task or type (default: day)"),
+		),
+		mcp.WithString("order",
+			mcp.Description("Order tasks within a group by id, time (first entry) or entries (most first) (default: id)"),
+		),
+		mcp.WithString("collapse_empty_days",
+			mcp.Description("With group_by=day, show a run of days without activity under one heading (true/false, default: false)"),
+		),
+		mcp.WithString("style",
+			mcp.Description("Markdown style: default, obsidian, confluence or a name from markdown.styles (default: markdown.style in config)"),
+		),
+		mcp.WithString("timezone",
+			mcp.Description("IANA time zone to bucket entries into days, e.g. America/Denver (default: general.timezone)"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: markdown, or ndjson for one JSON entry per line with its date and task (default: markdown)"),
+		),
+	), js.GetWeeklyLog)
+
+	s.AddTool(mcp.NewTool("get_current_week_log",
+		mcp.WithDescription("View activity for the current week; the week starts on general.week_start (default Monday) in the configured time zone"),
+		mcp.WithString("group_by",
+			mcp.Description("Group entries by day, task or type (default: day)"),
+		),
+		mcp.WithString("order",
+			mcp.Description("Order tasks within a group by id, time (first entry) or entries (most first) (default: id)"),
+		),
+		mcp.WithString("collapse_empty_days",
+			mcp.Description("With group_by=day, show a run of days without activity under one heading (true/false, default: false)"),
+		),
+		mcp.WithString("style",
+			mcp.Description("Markdown style: default, obsidian, confluence or a name from markdown.styles (default: markdown.style in config)"),
+		),
+		mcp.WithString("timezone",
+			mcp.Description("IANA time zone to bucket entries into days, e.g. America/Denver (default: general.timezone)"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: markdown, or ndjson for one JSON entry per line with its date and task (default: markdown)"),
+		),
+	), js.GetCurrentWeekLog)
+
+	s.AddTool(mcp.NewTool("get_previous_week_log",
+		mcp.WithDescription("View activity for last week; the week starts on general.week_start (default Monday) in the configured time zone"),
+		mcp.WithString("group_by",
+			mcp.Description("Group entries by day, task or type (default: day)"),
+		),
+		mcp.WithString("order",
+			mcp.Description("Order tasks within a group by id, time (first entry) or entries (most first) (default: id)"),
+		),
+		mcp.WithString("collapse_empty_days",
+			mcp.Description("With group_by=day, show a run of days without activity under one heading (true/false, default: false)"),
+		),
+		mcp.WithString("style",
+			mcp.Description("Markdown style: default, obsidian, confluence or a name from markdown.styles (default: markdown.style in config)"),
+		),
+		mcp.WithString("timezone",
+			mcp.Description("IANA time zone to bucket entries into days, e.g. America/Denver (default: general.timezone)"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: markdown, or ndjson for one JSON entry per line with its date and task (default: markdown)"),
+		),
+	), js.GetPreviousWeekLog)
+
+	s.AddTool(mcp.NewTool("rebuild_daily_logs",
+		mcp.WithDescription("Regenerate the daily logs from the task entries, reporting entries that were missing, duplicated, stale or orphaned"),
+		mcp.WithString("dry_run",
+			mcp.Description("Report the discrepancies without rewriting anything (true/false, default: false)"),
+		),
+	), js.RebuildDailyLogs)
+
+	s.AddTool(mcp.NewTool("cleanup_duplicates",
+		mcp.WithDescription("Remove entries logged more than once from the daily logs and exact repeats of entries from tasks"),
+		mcp.WithString("dry_run",
+			mcp.Description("Report the duplicates without removing them (true/false, default: false)"),
+		),
+	), js.CleanupDuplicates)
+
+	s.AddTool(mcp.NewTool("generate_standup",
+		mcp.WithDescription("Yesterday / today / blockers standup from the last working day's entries and the active and blocked tasks"),
+		mcp.WithString("date",
+			mcp.Description("Standup date in YYYY-MM-DD format (default: today)"),
+		),
+		mcp.WithString("lookback",
+			mcp.Description("Working days covered by Yesterday, skipping weekends (default: general.standup_lookback, or 1)"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: markdown or json (default: markdown)"),
+		),
+	), js.GenerateStandup)
+
+	s.AddTool(mcp.NewTool("get_wip_history",
+		mcp.WithDescription("Daily snapshots of active and blocked tasks, for work-in-progress charts"),
+		mcp.WithString("date_from",
+			mcp.Description("Start date in YYYY-MM-DD format (default: 30 days ago)"),
+		),
+		mcp.WithString("date_to",
+			mcp.Description("End date in YYYY-MM-DD format (default: today)"),
+		),
+		mcp.WithString("include_tasks",
+			mcp.Description("Include the task IDs for each day (true/false)"),
+		),
+	), js.GetWIPHistory)
+
+	s.AddTool(mcp.NewTool("get_timeline",
+		mcp.WithDescription("Merged chronological timeline of entries, status changes and GitHub events across tasks"),
+		mcp.WithArray("task_ids",
+			mcp.Description("Tasks to include"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("tags",
+			mcp.Description("Include every task with any of these tags"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("date_from",
+			mcp.Description("Start date in YYYY-MM-DD format (default: 30 days ago)"),
+		),
+		mcp.WithString("date_to",
+			mcp.Description("End date in YYYY-MM-DD format (default: today)"),
+		),
+		mcp.WithArray("kinds",
+			mcp.Description("Only these kinds of events: entry, status, github (default: all)"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: markdown, json (default: markdown)"),
+		),
+		mcp.WithString("style",
+			mcp.Description("Markdown style: default, obsidian, confluence or a name from markdown.styles (default: markdown.style in config)"),
+		),
+	), js.GetTimeline)
+
+	s.AddTool(mcp.NewTool("on_this_day",
+		mcp.WithDescription("Entries and completions from the same date in previous months and years"),
+		mcp.WithString("date",
+			mcp.Description("Date to look back from in YYYY-MM-DD format (default: today)"),
+		),
+		mcp.WithString("period",
+			mcp.Description("months, years or both (default: both)"),
+		),
+		mcp.WithString("months",
+			mcp.Description("How many previous months to check (default: 12)"),
+		),
+		mcp.WithString("style",
+			mcp.Description("Markdown style: default, obsidian, confluence or a name from markdown.styles (default: markdown.style in config)"),
+		),
+	), js.OnThisDay)
+
+	s.AddTool(mcp.NewTool("get_year_in_review",
+		mcp.WithDescription("Yearly retrospective summarizing completions, activity, tracked time, focus areas and decisions by quarter"),
+		mcp.WithString("year",
+			mcp.Description("Year to review (default: this year)"),
+		),
+		mcp.WithString("style",
+			mcp.Description("Markdown style: default, obsidian, confluence or a name from markdown.styles (default: markdown.style in config)"),
+		),
+	), js.GetYearInReview)
+
+	s.AddTool(mcp.NewTool("create_weekly_review",
+		mcp.WithDescription("Record a weekly review: wins, misses, learnings and goals for next week, checking in on last week's goals"),
+		mcp.WithString("week",
+			mcp.Description("Any date in the week reviewed (YYYY-MM-DD, default: this week)"),
+		),
+		mcp.WithArray("wins",
+			mcp.Description("What went well"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("misses",
+			mcp.Description("What did not go to plan"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("learnings",
+			mcp.Description("What you learned"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("goals",
+			mcp.Description("Goals for next week"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("goals_met",
+			mcp.Description("Last week's goals you met, by number or text; the others count as missed"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("notes",
+			mcp.Description("Free-form notes"),
+		),
+	), js.CreateWeeklyReview)
+
+	s.AddTool(mcp.NewTool("get_review_history",
+		mcp.WithDescription("Recent weekly reviews beside each week's tasks completed, entries written and time tracked, with how reviewed and goal-hitting weeks compare"),
+		mcp.WithString("weeks",
+			mcp.Description("Number of weeks back, including this one (default: 8)"),
+		),
+		mcp.WithString("style",
+			mcp.Description("Markdown style: default, obsidian, confluence or a name from markdown.styles (default: markdown.style in config)"),
+		),
+	), js.GetReviewHistory)
+
+	s.AddTool(mcp.NewTool("get_streaks",
+		mcp.WithDescription("Journaling streaks: current and longest runs of days with at least one entry, and days journaled per week"),
+		mcp.WithString("weeks",
+			mcp.Description("Weeks of consistency to report, up to 52 (default: 8)"),
+		),
+	), js.GetStreaks)
+
+	s.AddTool(mcp.NewTool("create_habit",
+		mcp.WithDescription("Define a daily habit to track as a streak. With any match criteria, days with a matching journal entry count as done automatically; otherwise mark days with log_habit"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("The habit, e.g. \"Review PRs daily\"; its ID is the name in lowercase with dashes"),
+		),
+		mcp.WithString("any_entry",
+			mcp.Description("Any written entry completes the day, e.g. for \"Write a journal entry\" (true/false, default: false)"),
+		),
+		mcp.WithArray("entry_types",
+			mcp.Description("Entries of these types complete the day, e.g. review (default: any written entry)"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("task_types",
+			mcp.Description("Only entries on tasks of these types"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("tags",
+			mcp.Description("Only entries on tasks with any of these tags"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("keywords",
+			mcp.Description("Only entries mentioning any of these words, ignoring case"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+	), js.CreateHabit)
+
+	s.AddTool(mcp.NewTool("log_habit",
+		mcp.WithDescription("Mark a day done for a habit, for practices the journal cannot see"),
+		mcp.WithString("habit_id",
+			mcp.Required(),
+			mcp.Description("The habit's ID or name"),
+		),
+		mcp.WithString("date",
+			mcp.Description("Day done (YYYY-MM-DD, default: today)"),
+		),
+		mcp.WithString("undo",
+			mcp.Description("Clear the day instead; days completed by matching entries stay done (true/false, default: false)"),
+		),
+	), js.LogHabit)
+
+	s.AddTool(mcp.NewTool("get_habits",
+		mcp.WithDescription("Every habit's current and longest streaks, whether it is done today and how consistently it was kept"),
+		mcp.WithString("weeks",
+			mcp.Description("Weeks of consistency to report, up to 52 (default: 8)"),
+		),
+	), js.GetHabits)
+
+	s.AddTool(mcp.NewTool("delete_habit",
+		mcp.WithDescription("Stop tracking a habit; journal entries are not touched"),
+		mcp.WithString("habit_id",
+			mcp.Required(),
+			mcp.Description("The habit's ID or name"),
+		),
+	), js.DeleteHabit)
+
+	s.AddTool(mcp.NewTool("log_mood",
+		mcp.WithDescription("Record a mood and energy check-in, on a personal task per month unless task_id is given"),
+		mcp.WithString("mood",
+			mcp.Description("How you feel, from 1 (low) to 5 (high); mood or energy is required"),
+		),
+		mcp.WithString("energy",
+			mcp.Description("Your energy, from 1 (low) to 5 (high)"),
+		),
+		mcp.WithString("note",
+			mcp.Description("What is behind it, in a few words"),
+		),
+		mcp.WithString("task_id",
+			mcp.Description("Record it on this task instead"),
+		),
+		mcp.WithString("timestamp",
+			mcp.Description("RFC3339 time of the check-in (default: now)"),
+		),
+	), js.LogMood)
+
+	s.AddTool(mcp.NewTool("list_notifications",
+		mcp.WithDescription("Messages from background jobs, such as tasks auto-paused for inactivity. Marks them read"),
+		mcp.WithString("include_read",
+			mcp.Description("Include notifications already read (true/false, default: false)"),
+		),
+	), js.ListNotifications)
+
+	s.AddTool(mcp.NewTool("send_digest",
+		mcp.WithDescription("Send the digest of completions, upcoming due dates and blocked tasks to the configured digest channels now, or preview it"),
+		mcp.WithString("period",
+			mcp.Description("daily or weekly (default: digest.frequency, else daily)"),
+		),
+		mcp.WithString("channel",
+			mcp.Description("Only this channel from digest.channels (default: all)"),
+		),
+		mcp.WithString("dry_run",
+			mcp.Description("Return the rendered digest per channel without sending it (true/false, default: false)"),
+		),
+		mcp.WithString("ignore_quiet_hours",
+			mcp.Description("Deliver even during quiet hours (true/false, default: false)"),
+		),
+	), js.SendDigest)
+
+	s.AddTool(mcp.NewTool("run_connectors",
+		mcp.WithDescription("Fetch a day's one-line summaries from the configured connectors (GitHub CI failures, PagerDuty incidents, Jira tickets closed) and add them to the daily log"),
+		mcp.WithString("date",
+			mcp.Description("Day to summarize (YYYY-MM-DD, default: today)"),
+		),
+		mcp.WithArray("connectors",
+			mcp.Description("Only these connectors from connectors.sources, by name (default: all)"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("dry_run",
+			mcp.Description("Return the summaries without saving them (true/false, default: false)"),
+		),
+	), js.RunConnectors)
+
+	s.AddTool(mcp.NewTool("generate_usage_report",
+		mcp.WithDescription("Summarize how you use journal-mcp (tools used, tools never touched, storage growth) to tune your configuration. Built from local statistics only and never transmitted"),
+		mcp.WithString("style",
+			mcp.Description("Markdown style: default, obsidian, confluence or a name from markdown.styles (default: markdown.style in config)"),
+		),
+	), js.GenerateUsageReport)
+
+	// Meeting Tools
+	s.AddTool(mcp.NewTool("create_meeting",
+		mcp.WithDescription("Record notes for a meeting of any type, with attendees, agenda, decisions and action items. One-on-ones are stored as create_one_on_one stores them"),
+		mcp.WithString("type",
+			mcp.Required(),
+			mcp.Description("Meeting type: one_on_one, team, planning, retro, interview"),
+		),
+		mcp.WithString("date",
+			mcp.Required(),
+			mcp.Description("Meeting date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("title",
+			mcp.Description("Meeting title, e.g. 'Sprint 12' or the candidate's name; keeps meetings of one type on the same day apart"),
+		),
+		mcp.WithArray("attendees",
+			mcp.Description("Who attended; names or aliases from team.members are stored under the member's name"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("person",
+			mcp.Description("For one_on_one: who the meeting was with (default: the only attendee)"),
+		),
+		mcp.WithArray("agenda",
+			mcp.Description("Agenda items"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("decisions",
+			mcp.Description("Decisions made"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("insights",
+			mcp.Description("Key insights from the meeting"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("todos",
+			mcp.Description("Action items; those naming or mentioning a task are linked to it"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("feedback",
+			mcp.Description("Feedback points, added to the feedback bank"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("notes",
+			mcp.Description("Additional meeting notes"),
+		),
+	), js.CreateMeeting)
+
+	s.AddTool(mcp.NewTool("get_meetings",
+		mcp.WithDescription("List meeting notes of every type, most recent first, with action item status"),
+		mcp.WithString("type",
+			mcp.Description("Only meetings of this type: one_on_one, team, planning, retro, interview"),
+		),
+		mcp.WithString("attendee",
+			mcp.Description("Only meetings this person attended (name or team alias)"),
+		),
+		mcp.WithString("date_from",
+			mcp.Description("Earliest meeting date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("date_to",
+			mcp.Description("Latest meeting date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("limit",
+			mcp.Description("Number of meetings to retrieve (default: 10)"),
+		),
+	), js.GetMeetings)
+
+	s.AddTool(mcp.NewTool("update_meeting",
+		mcp.WithDescription("Add to an existing meeting: new attendees and list items are appended and notes are added as a paragraph. Each update is recorded in the meeting's changes"),
+		mcp.WithString("type",
+			mcp.Required(),
+			mcp.Description("Meeting type: one_on_one, team, planning, retro, interview"),
+		),
+		mcp.WithString("date",
+			mcp.Required(),
+			mcp.Description("Meeting date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("title",
+			mcp.Description("Meeting title; required when several meetings of the type share the date"),
+		),
+		mcp.WithString("person",
+			mcp.Description("For one_on_one: who the meeting was with; required when several share the date"),
+		),
+		mcp.WithArray("attendees",
+			mcp.Description("Attendees to add"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("agenda",
+			mcp.Description("Agenda items"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("decisions",
+			mcp.Description("Decisions made"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("insights",
+			mcp.Description("Key insights from the meeting"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("todos",
+			mcp.Description("Action items; those naming or mentioning a task are linked to it"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("feedback",
+			mcp.Description("Feedback points, added to the feedback bank"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("notes",
+			mcp.Description("Additional meeting notes"),
+		),
+	), js.UpdateMeeting)
+
+	s.AddTool(mcp.NewTool("delete_meeting",
+		mcp.WithDescription("Delete a meeting: it moves to the trash and its feedback leaves the feedback bank. Action item entries on linked tasks are kept"),
+		mcp.WithString("type",
+			mcp.Required(),
+			mcp.Description("Meeting type: one_on_one, team, planning, retro, interview"),
+		),
+		mcp.WithString("date",
+			mcp.Required(),
+			mcp.Description("Meeting date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("title",
+			mcp.Description("Meeting title; required when several meetings of the type share the date"),
+		),
+		mcp.WithString("person",
+			mcp.Description("For one_on_one: who the meeting was with; required when several share the date"),
+		),
+		mcp.WithString("reason",
+			mcp.Description("Optional reason for the deletion, kept with the trashed meeting"),
+		),
+	), js.DeleteMeeting)
+
+	s.AddTool(mcp.NewTool("get_print_view",
+		mcp.WithDescription("Print-ready HTML of tasks (a page per task) or a week (a page per day), with a condensed header and A4 or letter page CSS"),
+		mcp.WithString("view",
+			mcp.Description("What to print: tasks or week (default: week)"),
+		),
+		mcp.WithArray("task_ids",
+			mcp.Description("Tasks to print, for the tasks view"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("week_start",
+			mcp.Description("First day of the week to print in YYYY-MM-DD format (default: this week)"),
+		),
+		mcp.WithString("paper",
+			mcp.Description("Page size: a4 or letter (default: a4)"),
+		),
+		mcp.WithString("order",
+			mcp.Description("Order of tasks within a day: id, time or entries (default: id)"),
+		),
+		mcp.WithString("timezone",
+			mcp.Description("IANA time zone for days and times (default: general.timezone in config)"),
+		),
+		mcp.WithString("output_path",
+			mcp.Description("Write the HTML to this file instead of returning it"),
+		),
+	), js.GetPrintView)
+
+	// One-on-One Meeting Tools
+	s.AddTool(mcp.NewTool("create_one_on_one",
+		mcp.WithDescription("Record structured meeting notes. Todos naming a task ID or mentioning a task's title are linked to that task; the rest come back with suggested create_task arguments"),
+		mcp.WithString("date",
+			mcp.Required(),
+			mcp.Description("Meeting date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("person",
+			mcp.Description("Who the meeting was with; a name or alias from team.members is stored under the member's name. Meetings with different people on the same day are kept apart"),
+		),
+		mcp.WithArray("insights",
+			mcp.Description("Key insights from the meeting"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("todos",
+			mcp.Description("Action items and todos"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("feedback",
+			mcp.Description("Feedback points"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("notes",
+			mcp.Description("Additional meeting notes"),
+		),
+	), js.CreateOneOnOne)
+
+	s.AddTool(mcp.NewTool("get_one_on_one_history",
+		mcp.WithDescription("Retrieve meeting history"),
+		mcp.WithString("person",
+			mcp.Description("Only meetings with this person (name or team alias)"),
+		),
+		mcp.WithString("limit",
+			mcp.Description("Number of meetings to retrieve (default: 10)"),
+		),
+	), js.GetOneOnOneHistory)
+
+	s.AddTool(mcp.NewTool("update_one_on_one",
+		mcp.WithDescription("Add to an existing one-on-one: new insights, todos and feedback are appended and notes are added as a paragraph. Each update is recorded in the meeting's changes, and new todos are linked to tasks as in create_one_on_one"),
+		mcp.WithString("date",
+			mcp.Required(),
+			mcp.Description("Meeting date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("person",
+			mcp.Description("Who the meeting was with; required when several one-on-ones share the date"),
+		),
+		mcp.WithArray("insights",
+			mcp.Description("Insights to add"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("todos",
+			mcp.Description("Action items to add"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("feedback",
+			mcp.Description("Feedback points to add"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("notes",
+			mcp.Description("Notes to add after the existing notes"),
+		),
+	), js.UpdateOneOnOne)
+
+	s.AddTool(mcp.NewTool("delete_one_on_one",
+		mcp.WithDescription("Delete a one-on-one: the meeting moves to trash/one-on-ones and its feedback leaves the feedback bank. Action item entries on linked tasks are kept"),
+		mcp.WithString("date",
+			mcp.Required(),
+			mcp.Description("Meeting date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("person",
+			mcp.Description("Who the meeting was with; required when several one-on-ones share the date"),
+		),
+		mcp.WithString("reason",
+			mcp.Description("Optional reason for the deletion, kept with the trashed meeting"),
+		),
+	), js.DeleteOneOnOne)
+
+	s.AddTool(mcp.NewTool("convert_one_on_one_todos",
+		mcp.WithDescription("Turn a one-on-one's action items into tracked work: each todo not linked yet gets an action_item entry on the task it names or mentions, or becomes a new task"),
+		mcp.WithString("date",
+			mcp.Required(),
+			mcp.Description("Meeting date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("person",
+			mcp.Description("Who the meeting was with; required when several one-on-ones share the date"),
+		),
+		mcp.WithArray("todos",
+			mcp.Description("Convert only these todos (default: all of the meeting's todos)"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("type",
+			mcp.Description("Type for new tasks (default: general.default_task_type in config)"),
+		),
+		mcp.WithString("dry_run",
+			mcp.Description("Report what would be created and linked without changing anything (true/false, default: false)"),
+		),
+	), js.ConvertOneOnOneTodos)
+
+	s.AddTool(mcp.NewTool("build_one_on_one_agenda",
+		mcp.WithDescription("Propose a 1-on-1 agenda from open action items, blockers, recent wins and feedback since the last meeting"),
+		mcp.WithString("date",
+			mcp.Description("Meeting date in YYYY-MM-DD format (default: today)"),
+		),
+		mcp.WithString("person",
+			mcp.Description("Who the meeting is with; follow-ups come from earlier meetings with them only"),
+		),
+		mcp.WithString("store",
+			mcp.Description("Save the agenda in that day's one-on-one notes with person (true/false, default: false)"),
+		),
+		mcp.WithString("style",
+			mcp.Description("Markdown style: default, obsidian, confluence or a name from markdown.styles (default: markdown.style in config)"),
+		),
+	), js.BuildOneOnOneAgenda)
+
+	s.AddTool(mcp.NewTool("get_team_rollup",
+		mcp.WithDescription("Per-person activity, blocked items and 1-on-1 action-item follow-through for your team"),
+		mcp.WithArray("people",
+			mcp.Description("People to include (default: team.members from config)"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("date_from",
+			mcp.Description("Start date in YYYY-MM-DD format (default: 14 days ago)"),
+		),
+		mcp.WithString("date_to",
+			mcp.Description("End date in YYYY-MM-DD format (default: today)"),
+		),
+		mcp.WithString("redact",
+			mcp.Description("none, content (counts only, no entry text) or names (also replace names with Person A, B, ...); default: team.redact"),
+		),
+	), js.GetTeamRollup)
+
+	s.AddTool(mcp.NewTool("handoff_task",
+		mcp.WithDescription("Reassign a task to a team member with a handoff document (context, decisions, open questions, next steps) drawn from its entries, and notify them on their channels"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("Task to hand off"),
+		),
+		mcp.WithString("to",
+			mcp.Required(),
+			mcp.Description("New owner, by name or alias; must be in team.members when it is set"),
+		),
+		mcp.WithString("from",
+			mcp.Description("Previous owner (default: the task's assignee)"),
+		),
+		mcp.WithString("note",
+			mcp.Description("A word for the new owner, put at the top of the document"),
+		),
+		mcp.WithString("dry_run",
+			mcp.Description("Preview the handoff document without reassigning or notifying (true/false, default: false)"),
+		),
+	), js.HandoffTask)
+
+	s.AddTool(mcp.NewTool("add_feedback",
+		mcp.WithDescription("Record feedback you received or gave in the feedback bank"),
+		mcp.WithString("content",
+			mcp.Required(),
+			mcp.Description("The feedback itself"),
+		),
+		mcp.WithString("direction",
+			mcp.Description("received or given (default: received)"),
+		),
+		mcp.WithString("person",
+			mcp.Description("Who gave or received the feedback"),
+		),
+		mcp.WithArray("themes",
+			mcp.Description("Theme tags such as communication or ownership (default: inferred from the content)"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("date",
+			mcp.Description("Date in YYYY-MM-DD format (default: today)"),
+		),
+	), js.AddFeedback)
+
+	s.AddTool(mcp.NewTool("get_feedback_themes",
+		mcp.WithDescription("Cluster feedback (including one-on-one feedback) into recurring themes by quarter, for performance reviews"),
+		mcp.WithString("date_from",
+			mcp.Description("Start date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("date_to",
+			mcp.Description("End date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("direction",
+			mcp.Description("Only received or only given feedback"),
+		),
+		mcp.WithString("person",
+			mcp.Description("Only feedback from or to this person"),
+		),
+	), js.GetFeedbackThemes)
+
+	s.AddTool(mcp.NewTool("add_brag_item",
+		mcp.WithDescription("Add an accomplishment to your brag document"),
+		mcp.WithString("text",
+			mcp.Required(),
+			mcp.Description("What you accomplished and why it mattered"),
+		),
+		mcp.WithString("category",
+			mcp.Description("completion, milestone, praise or manual (default: manual)"),
+		),
+		mcp.WithString("date",
+			mcp.Description("Date in YYYY-MM-DD format (default: today)"),
+		),
+		mcp.WithString("task_id",
+			mcp.Description("Related task ID"),
+		),
+	), js.AddBragItem)
+
+	s.AddTool(mcp.NewTool("edit_brag_item",
+		mcp.WithDescription("Reword, recategorize, redate or remove a brag document item"),
+		mcp.WithString("id",
+			mcp.Required(),
+			mcp.Description("Brag item ID (shown in get_brag_doc)"),
+		),
+		mcp.WithString("text",
+			mcp.Description("New text"),
+		),
+		mcp.WithString("category",
+			mcp.Description("completion, milestone, praise or manual"),
+		),
+		mcp.WithString("date",
+			mcp.Description("New date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("delete",
+			mcp.Description("Remove the item (true/false, default: false)"),
+		),
+	), js.EditBragItem)
+
+	s.AddTool(mcp.NewTool("get_brag_doc",
+		mcp.WithDescription("Show or export your brag document, grouped by quarter"),
+		mcp.WithString("date_from",
+			mcp.Description("Start date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("date_to",
+			mcp.Description("End date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("format",
+			mcp.Description("markdown, json, pandoc (pandoc JSON AST) or docbook (default: markdown)"),
+		),
+		mcp.WithString("title",
+			mcp.Description("Document title for pandoc and docbook output (default: Brag Document)"),
+		),
+		mcp.WithString("author",
+			mcp.Description("Document author for pandoc and docbook output (default: github.username in config)"),
+		),
+		mcp.WithString("output_path",
+			mcp.Description("Write the document to this file instead of returning it"),
+		),
+		mcp.WithString("style",
+			mcp.Description("Markdown style: default, obsidian, confluence or a name from markdown.styles (default: markdown.style in config)"),
+		),
+	), js.GetBragDoc)
+
+	s.AddTool(mcp.NewTool("log_answer",
+		mcp.WithDescription("Record a question you answered (Stack Overflow, internal Q&A, chat) on the knowledge-sharing task"),
+		mcp.WithString("title",
+			mcp.Required(),
+			mcp.Description("Question title or short summary"),
+		),
+		mcp.WithString("url",
+			mcp.Description("Link to the question or answer; logging the same link again updates the entry"),
+		),
+		mcp.WithString("source",
+			mcp.Description("Where it was answered, e.g. stackoverflow, slack, internal (default: internal)"),
+		),
+		mcp.WithString("accepted",
+			mcp.Description("Whether the answer was accepted (true/false)"),
+		),
+		mcp.WithString("answered_at",
+			mcp.Description("Date answered (YYYY-MM-DD, default: now)"),
+		),
+		mcp.WithString("task_id",
+			mcp.Description("Task to record the answer on (default: knowledge-sharing)"),
+		),
+	), js.LogAnswer)
+
+	s.AddTool(mcp.NewTool("suggest_branch_name",
+		mcp.WithDescription("Suggest a conventional git branch name for a task"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("Task identifier"),
+		),
+		mcp.WithString("prefix",
+			mcp.Description("Branch prefix such as feature, fix, chore (default: derived from task type and tags)"),
+		),
+		mcp.WithString("user",
+			mcp.Description("Optional user namespace, e.g. jdoe gives jdoe/feature/..."),
+		),
+	), js.SuggestBranchName)
+
+	s.AddTool(mcp.NewTool("suggest_commit_message",
+		mcp.WithDescription("Scaffold a Conventional Commits message from a task's title and latest entries"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("Task identifier"),
+		),
+		mcp.WithString("type",
+			mcp.Description("Commit type such as feat, fix, docs (default: derived from task type and tags)"),
+		),
+		mcp.WithString("scope",
+			mcp.Description("Optional commit scope"),
+		),
+		mcp.WithString("entries",
+			mcp.Description("Number of latest entries to include in the body (default: 3)"),
+		),
+		mcp.WithString("closes",
+			mcp.Description("Use a Closes footer for the linked issue even if the task is still open (true/false)"),
+		),
+	), js.SuggestCommitMessage)
+
+	// Search and Export Tools
+	s.AddTool(mcp.NewTool("search_entries",
+		mcp.WithDescription("Search through all journal content, most relevant results first"),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("Search query text"),
+		),
+		mcp.WithString("task_type",
+			mcp.Description("Filter by task type: work, learning, personal, investigation"),
+		),
+		mcp.WithString("date_from",
+			mcp.Description("Start date filter (YYYY-MM-DD)"),
+		),
+		mcp.WithString("date_to",
+			mcp.Description("End date filter (YYYY-MM-DD)"),
+		),
+		mcp.WithString("include_archived",
+			mcp.Description("Also search archived tasks (true/false, default: false)"),
+		),
+		mcp.WithArray("tags",
+			mcp.Description("Only entries with any of these entry tags, e.g. decision; leaves out one-on-ones and meetings"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("person",
+			mcp.Description("Only one-on-ones with this person and tasks assigned to them (name or team alias)"),
+		),
+		mcp.WithString("fuzzy",
+			mcp.Description("Tolerate typos, matching words a few letters off (true/false, default: true); false matches the exact phrase only"),
+		),
+		mcp.WithString("limit",
+			mcp.Description("Maximum number of results per page (default: 50, max: 200); tasks per page with group_by_task"),
+		),
+		mcp.WithString("offset",
+			mcp.Description("Number of results to skip, for paging (default: 0)"),
+		),
+		mcp.WithString("group_by_task",
+			mcp.Description("Group results under their task, ranked by each task's best result (true/false, default: false)"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: markdown, or ndjson for one JSON result per line with its task, context and score (default: markdown)"),
+		),
+	), js.SearchEntries)
+
+	s.AddTool(mcp.NewTool("list_entries_by_tag",
+		mcp.WithDescription("List the entries carrying an entry tag, oldest first, e.g. every decision made this quarter"),
+		mcp.WithString("tag",
+			mcp.Required(),
+			mcp.Description("Entry tag, with or without #; several may be given comma-separated to match any"),
+		),
+		mcp.WithString("timeframe",
+			mcp.Description("A quarter such as 2026-Q4 or a year such as 2026"),
+		),
+		mcp.WithString("date_from",
+			mcp.Description("Start date filter (YYYY-MM-DD), overriding the timeframe's start"),
+		),
+		mcp.WithString("date_to",
+			mcp.Description("End date filter (YYYY-MM-DD), overriding the timeframe's end"),
+		),
+		mcp.WithString("task_type",
+			mcp.Description("Filter by task type: work, learning, personal, investigation"),
+		),
+		mcp.WithString("include_archived",
+			mcp.Description("Also list entries of archived tasks (true/false, default: false)"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Output format: markdown, or ndjson for one JSON entry per line with its date and task (default: markdown)"),
+		),
+	), js.ListEntriesByTag)
+
+	s.AddTool(mcp.NewTool("rebuild_search_index",
+		mcp.WithDescription("Rebuild the full-text search index used by search_entries"),
+	), js.RebuildSearchIndex)
+
+	s.AddTool(mcp.NewTool("export_data",
+		mcp.WithDescription("Export journal data to various formats"),
+		mcp.WithString("format",
+			mcp.Required(),
+			mcp.Description("Export format: json, markdown, csv, pandoc (pandoc JSON AST, e.g. for pandoc -f json -o report.docx) or docbook"),
+		),
+		mcp.WithString("title",
+			mcp.Description("Document title for pandoc and docbook output (default: Journal Export)"),
+		),
+		mcp.WithString("author",
+			mcp.Description("Document author for pandoc and docbook output (default: github.username in config)"),
+		),
+		mcp.WithString("date_from",
+			mcp.Description("Start date filter (YYYY-MM-DD)"),
+		),
+		mcp.WithString("date_to",
+			mcp.Description("End date filter (YYYY-MM-DD)"),
+		),
+		mcp.WithString("task_filter",
+			mcp.Description("Filter by task type: work, learning, personal, investigation"),
+		),
+		mcp.WithArray("task_ids",
+			mcp.Description("Export only these tasks (together with any matching tags); leaves out one-on-ones"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithArray("tags",
+			mcp.Description("Export only tasks with any of these tags (together with any task_ids); leaves out one-on-ones"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("anonymize",
+			mcp.Description("Replace names, URLs, task and issue IDs and emails with stable pseudonyms, e.g. to attach to a bug report (true/false, default: false)"),
+		),
+		mcp.WithArray("names",
+			mcp.Description("Extra names to replace when anonymizing (team members and assignees are always replaced)"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("style",
+			mcp.Description("Markdown style: default, obsidian, confluence or a name from markdown.styles (default: markdown.style in config)"),
+		),
+		mcp.WithString("include_attachments",
+			mcp.Description("JSON only: embed entry attachments as base64, keyed by attachment path (true/false, default: false)"),
+		),
+	), js.ExportData)
+
+	// Import and Analytics Tools
+	s.AddTool(mcp.NewTool("import_data",
+		mcp.WithDescription("Import existing diary/journal data from various formats"),
+		mcp.WithString("content",
+			mcp.Description("File content to import (or pass file_path or content_base64)"),
+		),
+		mcp.WithString("file_path",
+			mcp.Description("Path of a file to import instead of content; UTF-16 and Windows-1252 files are converted"),
+		),
+		mcp.WithString("content_base64",
+			mcp.Description("Base64 of the file bytes to import instead of content; UTF-16 and Windows-1252 files are converted"),
+		),
+		mcp.WithString("format",
+			mcp.Required(),
+			mcp.Description("Input format: txt, markdown, json, csv"),
+		),
+		mcp.WithString("delimiter",
+			mcp.Description("CSV delimiter: , ; | or tab (default: detected)"),
+		),
+		mcp.WithString("date_order",
+			mcp.Description("How to read numeric dates like 03/04/2025: auto, dmy or mdy (default: import.date_order, else auto)"),
 		),
 		mcp.WithString("task_prefix",
 			mcp.Description("Optional prefix for auto-generated task IDs (default: 'IMPORT')"),
 		),
-		mcp.WithString("default_type",
-			mcp.Description("Default task type for imported entries: work, learning, personal, investigation (default: 'personal')"),
+		mcp.WithString("default_type",
+			mcp.Description("Default task type for imported entries: work, learning, personal, investigation (default: 'personal')"),
+		),
+		mcp.WithString("source",
+			mcp.Description("Optional name of the file the content came from, recorded on the imported tasks"),
+		),
+	), js.ImportData)
+
+	s.AddTool(mcp.NewTool("list_imports",
+		mcp.WithDescription("List past imports with their job IDs, source files and task counts"),
+	), js.ListImports)
+
+	s.AddTool(mcp.NewTool("undo_import",
+		mcp.WithDescription("Undo an import: tasks it created go to the trash, tasks it overwrote are restored, and entries added since are kept"),
+		mcp.WithString("job_id",
+			mcp.Required(),
+			mcp.Description("Import job ID, as returned by import_data or list_imports"),
+		),
+		mcp.WithString("dry_run",
+			mcp.Description("Report what would be undone without changing anything (true/false, default: false)"),
+		),
+	), js.UndoImport)
+
+	s.AddTool(mcp.NewTool("get_task_recommendations",
+		mcp.WithDescription("Get AI-assisted task recommendations based on patterns and history"),
+		mcp.WithString("task_type",
+			mcp.Description("Filter recommendations by task type: work, learning, personal, investigation"),
+		),
+		mcp.WithString("focus_area",
+			mcp.Description("Focus area for recommendations: productivity, learning, completion, priority"),
+		),
+		mcp.WithString("limit",
+			mcp.Description("Maximum number of recommendations to return (default: 5, max: 20)"),
+		),
+	), js.GetTaskRecommendations)
+
+	s.AddTool(mcp.NewTool("get_analytics_report",
+		mcp.WithDescription("Generate comprehensive analytics and insights report"),
+		mcp.WithString("report_type",
+			mcp.Description("Type of report: overview, productivity, patterns, trends (default: overview)"),
+		),
+		mcp.WithString("time_period",
+			mcp.Description("Time period for analysis: week, month, quarter, year, all (default: month)"),
+		),
+		mcp.WithString("task_type",
+			mcp.Description("Filter by task type: work, learning, personal, investigation"),
+		),
+	), js.GetAnalyticsReport)
+
+	s.AddTool(mcp.NewTool("set_quota",
+		mcp.WithDescription("Set a soft daily or weekly quota for a task type, e.g. at least 2h of learning a week. get_analytics_report reports balance against it"),
+		mcp.WithString("type",
+			mcp.Required(),
+			mcp.Description("Task type, e.g. learning"),
+		),
+		mcp.WithString("period",
+			mcp.Description("day or week (default: week)"),
+		),
+		mcp.WithString("time",
+			mcp.Description("Tracked time at least, in minutes or as a duration such as 90m or 2h"),
+		),
+		mcp.WithString("entries",
+			mcp.Description("Entries at least"),
+		),
+	), js.SetQuota)
+
+	s.AddTool(mcp.NewTool("remove_quota",
+		mcp.WithDescription("Remove a task type's daily or weekly quota"),
+		mcp.WithString("type",
+			mcp.Required(),
+			mcp.Description("Task type"),
+		),
+		mcp.WithString("period",
+			mcp.Description("day or week (default: week)"),
+		),
+	), js.RemoveQuota)
+
+	s.AddTool(mcp.NewTool("get_raw_analytics",
+		mcp.WithDescription("Dump tidy task-day records (one row per task per day with activity: entries, words, minutes, status changes, completion, age, sentiment) for custom analysis in pandas or a notebook"),
+		mcp.WithString("date_from",
+			mcp.Description("Start date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("date_to",
+			mcp.Description("End date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("task_type",
+			mcp.Description("Filter by task type"),
 		),
-	), js.ImportData)
+		mcp.WithString("format",
+			mcp.Description("json (an array of records, for pandas.DataFrame) or csv (default: json)"),
+		),
+	), js.GetRawAnalytics)
 
-	s.AddTool(mcp.NewTool("get_task_recommendations",
-		mcp.WithDescription("Get AI-assisted task recommendations based on patterns and history"),
-		mcp.WithString("task_type",
-			mcp.Description("Filter recommendations by task type: work, learning, personal, investigation"),
+	s.AddTool(mcp.NewTool("get_project_dashboard",
+		mcp.WithDescription("Status of one project in a single call: open tasks, blockers, burndown, recent activity and decisions"),
+		mcp.WithString("project",
+			mcp.Required(),
+			mcp.Description("Project name: a tag, or a custom field value when field is set"),
 		),
-		mcp.WithString("focus_area",
-			mcp.Description("Focus area for recommendations: productivity, learning, completion, priority"),
+		mcp.WithString("field",
+			mcp.Description("Custom field that holds the project name (default: match tags)"),
 		),
-		mcp.WithString("limit",
-			mcp.Description("Maximum number of recommendations to return (default: 5, max: 20)"),
+		mcp.WithString("days",
+			mcp.Description("Days of activity and burndown to include (default: 14)"),
 		),
-	), js.GetTaskRecommendations)
+	), js.GetProjectDashboard)
 
-	s.AddTool(mcp.NewTool("get_analytics_report",
-		mcp.WithDescription("Generate comprehensive analytics and insights report"),
-		mcp.WithString("report_type",
-			mcp.Description("Type of report: overview, productivity, patterns, trends (default: overview)"),
+	// Goal Tools
+	s.AddTool(mcp.NewTool("create_goal",
+		mcp.WithDescription("Create a quarterly or annual goal (an objective with key results) to link tasks to"),
+		mcp.WithString("title",
+			mcp.Required(),
+			mcp.Description("The objective"),
 		),
-		mcp.WithString("time_period",
-			mcp.Description("Time period for analysis: week, month, quarter, year, all (default: month)"),
+		mcp.WithString("period",
+			mcp.Description("quarterly or annual (default: quarterly)"),
 		),
-		mcp.WithString("task_type",
-			mcp.Description("Filter by task type: work, learning, personal, investigation"),
+		mcp.WithString("timeframe",
+			mcp.Description("Quarter such as 2026-Q4, or year such as 2026 for annual goals (default: the current one)"),
 		),
-	), js.GetAnalyticsReport)
+		mcp.WithArray("key_results",
+			mcp.Description("Key results, numbered KR1, KR2, ... in this order"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("description",
+			mcp.Description("Why the goal matters"),
+		),
+	), js.CreateGoal)
+
+	s.AddTool(mcp.NewTool("link_task_to_goal",
+		mcp.WithDescription("Link tasks to a goal or one of its key results, so their completion counts toward its progress"),
+		mcp.WithString("goal_id",
+			mcp.Required(),
+			mcp.Description("Goal ID from create_goal or get_goal_progress"),
+		),
+		mcp.WithArray("task_ids",
+			mcp.Required(),
+			mcp.Description("Tasks to link"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("key_result",
+			mcp.Description("Key result ID or number, e.g. KR2 or 2 (default: link to the goal itself)"),
+		),
+		mcp.WithString("unlink",
+			mcp.Description("Remove the links instead (true/false, default: false)"),
+		),
+	), js.LinkTaskToGoal)
+
+	s.AddTool(mcp.NewTool("get_goal_progress",
+		mcp.WithDescription("Progress on goals from the completion of linked tasks and the entries logged on them, against the time gone in the quarter or year"),
+		mcp.WithString("goal_id",
+			mcp.Description("One goal (default: all goals)"),
+		),
+		mcp.WithString("timeframe",
+			mcp.Description("Only goals for this quarter or year, e.g. 2026-Q4 or 2026"),
+		),
+		mcp.WithString("format",
+			mcp.Description("markdown or json (default: markdown)"),
+		),
+		mcp.WithString("style",
+			mcp.Description("Markdown style: default, obsidian, confluence or a name from markdown.styles (default: markdown.style in config)"),
+		),
+	), js.GetGoalProgress)
 
 	// GitHub Integration Tools
 	s.AddTool(mcp.NewTool("sync_with_github",
 		mcp.WithDescription("Sync assigned GitHub issues with tasks"),
 		mcp.WithString("github_token",
-			mcp.Required(),
-			mcp.Description("GitHub personal access token"),
+			mcp.Description("Optional GitHub personal access token override (default: github.token, the github_token secret or GITHUB_TOKEN)"),
 		),
 		mcp.WithString("username",
-			mcp.Required(),
-			mcp.Description("GitHub username to sync issues for"),
+			mcp.Description("GitHub username to sync issues for (default: github.username from config)"),
 		),
 		mcp.WithArray("repositories",
-			mcp.Description("Optional list of repositories to sync (format: owner/repo). If empty, syncs all assigned issues."),
+			mcp.Description("Optional list of repositories to sync (format: owner/repo, default: github.repositories from config). If empty, syncs all assigned issues."),
 			mcp.Items(map[string]any{"type": "string"}),
 		),
 		mcp.WithString("create_tasks",
@@ -338,8 +1901,7 @@ func registerTools(s *server.MCPServer, js *servers.JournalService) {
 	s.AddTool(mcp.NewTool("pull_issue_updates",
 		mcp.WithDescription("Pull latest comments and events for tracked GitHub issues"),
 		mcp.WithString("github_token",
-			mcp.Required(),
-			mcp.Description("GitHub personal access token"),
+			mcp.Description("Optional GitHub personal access token override (default: github.token, the github_token secret or GITHUB_TOKEN)"),
 		),
 		mcp.WithString("task_id",
 			mcp.Description("Specific task ID to update (if empty, updates all tasks with GitHub issues)"),
@@ -352,8 +1914,7 @@ func registerTools(s *server.MCPServer, js *servers.JournalService) {
 	s.AddTool(mcp.NewTool("create_task_from_github_issue",
 		mcp.WithDescription("Create a new task from a GitHub issue URL"),
 		mcp.WithString("github_token",
-			mcp.Required(),
-			mcp.Description("GitHub personal access token"),
+			mcp.Description("Optional GitHub personal access token override (default: github.token, the github_token secret or GITHUB_TOKEN)"),
 		),
 		mcp.WithString("issue_url",
 			mcp.Required(),
@@ -367,20 +1928,201 @@ func registerTools(s *server.MCPServer, js *servers.JournalService) {
 		),
 	), js.CreateTaskFromGitHubIssue)
 
+	// GitLab Integration Tools
+	s.AddTool(mcp.NewTool("sync_with_gitlab",
+		mcp.WithDescription("Sync GitLab issues and merge requests assigned to you with tasks"),
+		mcp.WithString("gitlab_token",
+			mcp.Description("GitLab personal access token with read_api scope (default: the gitlab_token secret)"),
+		),
+		mcp.WithArray("projects",
+			mcp.Description("Project paths to sync (format: group/project, default: gitlab.projects from config). If empty, syncs everything assigned to you."),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("include_merge_requests",
+			mcp.Description("Also sync merge requests assigned to you (true/false, default: true)"),
+		),
+		mcp.WithString("create_tasks",
+			mcp.Description("Whether to create new tasks for new issues (true/false, default: true)"),
+		),
+		mcp.WithString("update_existing",
+			mcp.Description("Whether to update existing tasks with issue changes (true/false, default: true)"),
+		),
+	), js.SyncWithGitLab)
+
+	s.AddTool(mcp.NewTool("pull_gitlab_updates",
+		mcp.WithDescription("Pull comments and system notes for tracked GitLab issues and merge requests"),
+		mcp.WithString("gitlab_token",
+			mcp.Description("GitLab personal access token with read_api scope (default: the gitlab_token secret)"),
+		),
+		mcp.WithString("task_id",
+			mcp.Description("Specific task ID to update (if empty, updates all tasks linked to GitLab)"),
+		),
+		mcp.WithString("since",
+			mcp.Description("Only pull updates since this timestamp (ISO 8601 format: 2006-01-02T15:04:05Z; default: since the last pull)"),
+		),
+	), js.PullGitLabUpdates)
+
+	// Jira Integration Tools
+	s.AddTool(mcp.NewTool("sync_with_jira",
+		mcp.WithDescription("Sync assigned Jira issues with tasks"),
+		mcp.WithString("jira_token",
+			mcp.Description("Jira API token or personal access token (default: the jira_token secret)"),
+		),
+		mcp.WithString("jql",
+			mcp.Description("JQL selecting issues to sync (default: jira.jql, or unresolved issues assigned to you)"),
+		),
+		mcp.WithArray("projects",
+			mcp.Description("Project keys to limit the default search to (default: jira.projects from config)"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("create_tasks",
+			mcp.Description("Whether to create new tasks for new issues (true/false, default: true)"),
+		),
+		mcp.WithString("update_existing",
+			mcp.Description("Whether to update existing tasks with issue changes (true/false, default: true)"),
+		),
+	), js.SyncWithJira)
+
+	s.AddTool(mcp.NewTool("pull_jira_updates",
+		mcp.WithDescription("Pull latest comments and status transitions for tracked Jira issues"),
+		mcp.WithString("jira_token",
+			mcp.Description("Jira API token or personal access token (default: the jira_token secret)"),
+		),
+		mcp.WithString("task_id",
+			mcp.Description("Specific task ID to update (if empty, updates all tasks with Jira issues)"),
+		),
+		mcp.WithString("since",
+			mcp.Description("Only pull updates since this timestamp (ISO 8601 format: 2006-01-02T15:04:05Z; default: since the last pull)"),
+		),
+	), js.PullJiraUpdates)
+
+	s.AddTool(mcp.NewTool("create_task_from_jira_issue",
+		mcp.WithDescription("Create a new task from a Jira issue URL or key"),
+		mcp.WithString("jira_token",
+			mcp.Description("Jira API token or personal access token (default: the jira_token secret)"),
+		),
+		mcp.WithString("issue",
+			mcp.Required(),
+			mcp.Description("Jira issue URL or key (e.g., https://yourcompany.atlassian.net/browse/PROJ-123 or PROJ-123)"),
+		),
+		mcp.WithString("type",
+			mcp.Description("Task type: work, learning, personal, investigation (default: work)"),
+		),
+		mcp.WithString("priority",
+			mcp.Description("Task priority: low, medium, high, urgent (default: from the Jira priority)"),
+		),
+	), js.CreateTaskFromJiraIssue)
+
+	// On-call Tools
+	s.AddTool(mcp.NewTool("sync_oncall",
+		mcp.WithDescription("Import your on-call shifts and the incidents or alerts raised during them from PagerDuty or Opsgenie as entries on a task per rotation week"),
+		mcp.WithString("since",
+			mcp.Description("First day to import (YYYY-MM-DD, default: 7 days ago)"),
+		),
+		mcp.WithString("until",
+			mcp.Description("Last day to import (YYYY-MM-DD, default: now)"),
+		),
+	), js.SyncOnCall)
+
+	s.AddTool(mcp.NewTool("sync_github_discussions",
+		mcp.WithDescription("Record GitHub Discussions you started, answered, commented on or were mentioned in as entries on a community task per repository"),
+		mcp.WithString("github_token",
+			mcp.Description("Optional GitHub personal access token override (default: github.token, the github_token secret or GITHUB_TOKEN)"),
+		),
+		mcp.WithString("username",
+			mcp.Description("GitHub username (default: github.username from config)"),
+		),
+		mcp.WithArray("repositories",
+			mcp.Description("Repositories to search (format: owner/repo, default: github.repositories from config)"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("since",
+			mcp.Description("Only include discussions updated since this date (YYYY-MM-DD)"),
+		),
+	), js.SyncGitHubDiscussions)
+
+	s.AddTool(mcp.NewTool("list_review_requests",
+		mcp.WithDescription("List pull requests awaiting your review alongside journal tasks tagged \"review\", by priority then age"),
+		mcp.WithString("github_token",
+			mcp.Description("Optional GitHub personal access token override (default: github.token, the github_token secret or GITHUB_TOKEN)"),
+		),
+		mcp.WithString("username",
+			mcp.Description("GitHub username (default: github.username from config)"),
+		),
+		mcp.WithString("include_github",
+			mcp.Description("Include GitHub review requests (true/false, default: true)"),
+		),
+	), js.ListReviewRequests)
+
+	s.AddTool(mcp.NewTool("log_review",
+		mcp.WithDescription("Record a completed code review as a journal entry and optionally submit it on the pull request"),
+		mcp.WithString("summary",
+			mcp.Required(),
+			mcp.Description("Review summary; also used as the review body on GitHub"),
+		),
+		mcp.WithString("task_id",
+			mcp.Description("Task to log the review on (default: the task linked to pr_url, or code-reviews)"),
+		),
+		mcp.WithString("pr_url",
+			mcp.Description("Pull request URL (default: the task's issue URL)"),
+		),
+		mcp.WithString("outcome",
+			mcp.Description("Review outcome: approved, changes_requested, commented (default: commented)"),
+		),
+		mcp.WithString("submit_review",
+			mcp.Description("Also submit the review on GitHub (true/false, default: false)"),
+		),
+		mcp.WithString("complete_task",
+			mcp.Description("Complete a task tagged review unless changes were requested (true/false, default: true)"),
+		),
+		mcp.WithString("github_token",
+			mcp.Description("Optional GitHub personal access token override (default: github.token, the github_token secret or GITHUB_TOKEN)"),
+		),
+	), js.LogReview)
+
+	s.AddTool(mcp.NewTool("sync_stackoverflow_answers",
+		mcp.WithDescription("Record your Stack Overflow (or other Stack Exchange site) answers as entries on the knowledge-sharing task"),
+		mcp.WithString("user_id",
+			mcp.Description("Stack Exchange user ID (default: stack_exchange.user_id from config)"),
+		),
+		mcp.WithString("site",
+			mcp.Description("Stack Exchange site (default: stackoverflow)"),
+		),
+		mcp.WithString("since",
+			mcp.Description("Only include answers posted since this date (YYYY-MM-DD)"),
+		),
+		mcp.WithString("task_id",
+			mcp.Description("Task to record answers on (default: knowledge-sharing)"),
+		),
+	), js.SyncStackOverflowAnswers)
+
 	// Data Management Tools
 	s.AddTool(mcp.NewTool("create_data_backup",
 		mcp.WithDescription("Create a backup of all journal data"),
 		mcp.WithString("backup_path",
-			mcp.Description("Path for the backup file (defaults to timestamped file in data directory)"),
+			mcp.Description("Path for the backup file (defaults to a timestamped file in the backup directory, pruned to backup.max_backups)"),
 		),
 		mcp.WithString("include_config",
 			mcp.Description("Whether to include configuration in backup (true/false, default: true)"),
 		),
 		mcp.WithString("compression",
-			mcp.Description("Compression level: none, default, maximum (default: default)"),
+			mcp.Description("Compression: none (store), default (deflate), maximum (best deflate) or zstd (default: backup.compression, else default)"),
+		),
+		mcp.WithString("passphrase",
+			mcp.Description("Encrypt the backup with this passphrase (AES-256-GCM); without it, encryption.backups uses the encryption passphrase"),
+		),
+		mcp.WithString("destination",
+			mcp.Description("Also upload the backup to s3://bucket/prefix, gs://bucket/prefix or webdav://host/path (default: backup.destination)"),
 		),
 	), js.CreateDataBackup)
 
+	s.AddTool(mcp.NewTool("test_backup_destination",
+		mcp.WithDescription("Check that a backup destination accepts, returns and deletes a small probe file"),
+		mcp.WithString("destination",
+			mcp.Description("Destination URI (default: backup.destination)"),
+		),
+	), js.TestBackupDestination)
+
 	s.AddTool(mcp.NewTool("restore_data_backup",
 		mcp.WithDescription("Restore journal data from a backup file"),
 		mcp.WithString("backup_path",
@@ -388,25 +2130,83 @@ func registerTools(s *server.MCPServer, js *servers.JournalService) {
 			mcp.Description("Path to the backup ZIP file"),
 		),
 		mcp.WithString("overwrite_existing",
-			mcp.Description("Whether to overwrite existing data (true/false, default: false)"),
+			mcp.Description("Overwrite existing files with the backup's (true/false, default: false: tasks are merged with live data, entries united and conflicting fields marked, and other files restored only where missing)"),
 		),
 		mcp.WithString("restore_config",
 			mcp.Description("Whether to restore configuration (true/false, default: true)"),
 		),
+		mcp.WithString("dry_run",
+			mcp.Description("Report the files that would be created or overwritten without touching disk (true/false, default: false)"),
+		),
+		mcp.WithString("profile",
+			mcp.Description("Restore into this new profile instead of the active one and report how it differs; promote it with promote_profile"),
+		),
+		mcp.WithString("passphrase",
+			mcp.Description("Passphrase of an encrypted backup (default: the encryption passphrase)"),
+		),
 	), js.RestoreDataBackup)
 
+	s.AddTool(mcp.NewTool("promote_profile",
+		mcp.WithDescription("Make a profile (e.g. one restored from a backup) the default profile; the current default data is kept in a new profile"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Profile to promote"),
+		),
+		mcp.WithString("save_previous_as",
+			mcp.Description("New profile to keep the current default data in (default: pre-promote-<timestamp>)"),
+		),
+	), js.PromoteProfile)
+
+	s.AddTool(mcp.NewTool("verify_backup",
+		mcp.WithDescription("Check a backup's ZIP integrity and manifest checksums, compare it with the live data files, and count the tasks, entries, daily logs and 1-on-1s it holds"),
+		mcp.WithString("backup_path",
+			mcp.Description("Path to the backup ZIP file (default: the newest backup in the backup directory)"),
+		),
+		mcp.WithString("compare_live",
+			mcp.Description("Compare the backup's manifest with the current data files (true/false, default: true)"),
+		),
+		mcp.WithString("passphrase",
+			mcp.Description("Passphrase of an encrypted backup (default: the encryption passphrase)"),
+		),
+	), js.VerifyBackup)
+
+	s.AddTool(mcp.NewTool("list_backups",
+		mcp.WithDescription("List backups in the backup directory (backup.backup_location or backups/), newest first"),
+	), js.ListBackups)
+
+	s.AddTool(mcp.NewTool("get_backup_status",
+		mcp.WithDescription("Show automatic backup settings, the last scheduled backup (or its error) and when the next one is due"),
+	), js.GetBackupStatus)
+
+	s.AddTool(mcp.NewTool("apply_encryption",
+		mcp.WithDescription("Rewrite existing tasks, 1-on-1s, daily logs and other journal files to match encryption.at_rest: encrypt plaintext files when it is on, decrypt them when it is off"),
+		mcp.WithString("dry_run",
+			mcp.Description("Only count the files that would change (true/false, default: false)"),
+		),
+	), js.ApplyEncryption)
+
 	s.AddTool(mcp.NewTool("get_configuration",
 		mcp.WithDescription("Get the current journal configuration"),
 	), js.GetConfiguration)
 
 	s.AddTool(mcp.NewTool("update_configuration",
-		mcp.WithDescription("Update the journal configuration"),
+		mcp.WithDescription("Update the journal configuration; unknown keys are reported as warnings"),
 		mcp.WithString("config",
 			mcp.Required(),
-			mcp.Description("Configuration JSON data"),
+			mcp.Description("Configuration as JSON or YAML"),
 		),
 	), js.UpdateConfiguration)
 
+	s.AddTool(mcp.NewTool("validate_config",
+		mcp.WithDescription("Check the configuration for syntax, type and rule errors and unknown keys, with line and column, without saving"),
+		mcp.WithString("config",
+			mcp.Description("Configuration to check (default: config.yaml in the data directory)"),
+		),
+		mcp.WithString("format",
+			mcp.Description("Format of config: yaml or json (default: json when it starts with {)"),
+		),
+	), js.ValidateConfig)
+
 	s.AddTool(mcp.NewTool("migrate_data",
 		mcp.WithDescription("Perform data migration (future SQLite integration preparation)"),
 		mcp.WithString("target_version",
@@ -416,4 +2216,286 @@ func registerTools(s *server.MCPServer, js *servers.JournalService) {
 			mcp.Description("Perform a dry run without making changes (true/false, default: false)"),
 		),
 	), js.MigrateData)
+
+	s.AddTool(mcp.NewTool("resolve_conflicts",
+		mcp.WithDescription("Merge conflicted task copies left by Dropbox or Syncthing back into their tasks"),
+		mcp.WithString("dry_run",
+			mcp.Description("Report what would be merged without changing anything (true/false, default: false)"),
+		),
+	), js.ResolveConflicts)
+
+	s.AddTool(mcp.NewTool("migrate_storage_format",
+		mcp.WithDescription("Rewrite every task file as JSON, markdown with YAML frontmatter (readable in Obsidian or any editor), both, or a JSON file with a two-way synced markdown mirror, and switch storage.format"),
+		mcp.WithString("format",
+			mcp.Required(),
+			mcp.Description("Target format: json, markdown, both (read-only markdown copies) or mirror (markdown edits flow back)"),
+		),
+		mcp.WithString("keep_old_files",
+			mcp.Description("Keep the files of the old format instead of removing them (true/false, default: false)"),
+		),
+		mcp.WithString("dry_run",
+			mcp.Description("Report what would be migrated without changing anything (true/false, default: false)"),
+		),
+	), js.MigrateStorageFormat)
+
+	s.AddTool(mcp.NewTool("sync_markdown_mirror",
+		mcp.WithDescription("Write edits made to markdown task copies in Obsidian or an editor back to the JSON task files, and rewrite missing or stale copies (storage.format mirror)"),
+	), js.SyncMarkdownMirror)
+
+	s.AddTool(mcp.NewTool("export_to_obsidian",
+		mcp.WithDescription("Write tasks, daily notes and 1-on-ones into an Obsidian vault with wiki-links between tasks and days"),
+		mcp.WithString("vault_path",
+			mcp.Description("Vault directory (default: obsidian.vault from the configuration)"),
+		),
+		mcp.WithString("folder",
+			mcp.Description("Folder inside the vault for the journal notes (default: Journal)"),
+		),
+		mcp.WithString("continuous",
+			mcp.Description("Keep the vault in sync every few minutes while the server runs (true/false; omit to leave the setting unchanged)"),
+		),
+	), js.ExportToObsidian)
+
+	s.AddTool(mcp.NewTool("mirror_to_sqlite",
+		mcp.WithDescription("Build a query-friendly SQLite copy of the journal for DuckDB, Metabase, Grafana and similar tools"),
+		mcp.WithString("path",
+			mcp.Description("Database path, relative to the data directory unless absolute (default: journal.sqlite)"),
+		),
+		mcp.WithString("continuous",
+			mcp.Description("Keep the mirror updated on every change (true/false, default: true)"),
+		),
+	), js.MirrorToSQLite)
+
+	s.AddTool(mcp.NewTool("get_sqlite_mirror_status",
+		mcp.WithDescription("Show whether the SQLite mirror is up to date: when it last changed, the last mirror error and the tasks whose changes did not reach it"),
+	), js.GetSQLiteMirrorStatus)
+
+	s.AddTool(mcp.NewTool("export_person_data",
+		mcp.WithDescription("Export all journal content mentioning a person (tasks, meetings, feedback, brag document, weekly reviews, archive, trash and event history)"),
+		mcp.WithString("person",
+			mcp.Required(),
+			mcp.Description("The person's name"),
+		),
+		mcp.WithArray("aliases",
+			mcp.Description("Other names or handles for the person"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+	), js.ExportPersonData)
+
+	s.AddTool(mcp.NewTool("purge_person_data",
+		mcp.WithDescription("Delete all journal content mentioning a person, remove them from the team registry and re-export the Obsidian vault; with event storage the event log is compacted. Call without confirm to preview and get a confirmation code"),
+		mcp.WithString("person",
+			mcp.Required(),
+			mcp.Description("The person's name"),
+		),
+		mcp.WithArray("aliases",
+			mcp.Description("Other names or handles for the person"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("confirm",
+			mcp.Description("Confirmation code from the preview; required to actually delete"),
+		),
+	), js.PurgePersonData)
+
+	s.AddTool(mcp.NewTool("set_secret",
+		mcp.WithDescription("Store an integration credential (e.g. github_token) with the configured secret provider instead of config.yaml"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Secret name, e.g. github_token"),
+		),
+		mcp.WithString("value",
+			mcp.Required(),
+			mcp.Description("Secret value"),
+		),
+	), js.SetSecret)
+
+	s.AddTool(mcp.NewTool("list_secret_names",
+		mcp.WithDescription("List stored secret names (values are never returned)"),
+	), js.ListSecretNames)
+
+	// Profile Tools
+	s.AddTool(mcp.NewTool("list_profiles",
+		mcp.WithDescription("List journal profiles (e.g. work and personal journals)"),
+	), js.ListProfiles)
+
+	s.AddTool(mcp.NewTool("use_profile",
+		mcp.WithDescription("Switch the active journal profile for subsequent tool calls"),
+		mcp.WithString("name",
+			mcp.Required(),
+			mcp.Description("Profile name ('default' is the main journal)"),
+		),
+		mcp.WithString("create",
+			mcp.Description("Create the profile if it does not exist (true/false, default: false)"),
+		),
+	), js.UseProfile)
+
+	s.AddTool(mcp.NewTool("get_aggregate_report",
+		mcp.WithDescription("Generate a combined analytics report across profiles with per-profile breakdowns (read-only)"),
+		mcp.WithArray("profiles",
+			mcp.Description("Profiles to include (default: all profiles)"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("report_type",
+			mcp.Description("Type of report: overview, productivity, patterns, trends (default: overview)"),
+		),
+		mcp.WithString("time_period",
+			mcp.Description("Time period for analysis: week, month, quarter, year, all (default: month)"),
+		),
+		mcp.WithString("task_type",
+			mcp.Description("Filter by task type: work, learning, personal, investigation"),
+		),
+	), js.GetAggregateReport)
+
+	s.AddTool(mcp.NewTool("get_aggregate_log",
+		mcp.WithDescription("View activity across profiles for a date range (read-only)"),
+		mcp.WithString("date_from",
+			mcp.Required(),
+			mcp.Description("Start date in YYYY-MM-DD format"),
+		),
+		mcp.WithString("date_to",
+			mcp.Description("End date in YYYY-MM-DD format (default: date_from)"),
+		),
+		mcp.WithArray("profiles",
+			mcp.Description("Profiles to include (default: all profiles)"),
+			mcp.Items(map[string]any{"type": "string"}),
+		),
+		mcp.WithString("style",
+			mcp.Description("Markdown style: default, obsidian, confluence or a name from markdown.styles (default: markdown.style in config)"),
+		),
+	), js.GetAggregateLog)
+
+	// Event History Tools (storage mode "events")
+	s.AddTool(mcp.NewTool("get_task_as_of",
+		mcp.WithDescription("View a task as it was at a point in time (requires event storage)"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("The task ID to retrieve"),
+		),
+		mcp.WithString("as_of",
+			mcp.Required(),
+			mcp.Description("RFC3339 timestamp, or YYYY-MM-DD for the end of that day"),
+		),
+		mcp.WithString("style",
+			mcp.Description("Markdown style: default, obsidian, confluence or a name from markdown.styles (default: markdown.style in config)"),
+		),
+	), js.GetTaskAsOf)
+
+	s.AddTool(mcp.NewTool("get_task_history",
+		mcp.WithDescription("List every recorded change to a task (requires event storage)"),
+		mcp.WithString("task_id",
+			mcp.Required(),
+			mcp.Description("The task ID to inspect"),
+		),
+	), js.GetTaskHistory)
+
+	s.AddTool(mcp.NewTool("rebuild_projection",
+		mcp.WithDescription("Regenerate task files by replaying the event log, e.g. after syncing events from another machine"),
+	), js.RebuildProjection)
+
+	s.AddTool(mcp.NewTool("version",
+		mcp.WithDescription("Report journal-mcp version and build information"),
+	), js.GetVersion)
+}
+
+// runCall runs one tool against the journal without a server and prints its
+// result, so list, search and log output can be piped into jq, fzf and shell
+// scripts. Arguments are name=value; array parameters take a value per
+// repeat of the name. Returns the exit status.
+func runCall(journalService *servers.JournalService, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "usage: journal-mcp call <tool> [name=value ...]")
+		return 2
+	}
+	s := newMCPServer(journalService)
+	var tool *mcp.Tool
+	for _, registered := range registeredTools(s) {
+		if registered.Name == args[0] {
+			tool = &registered
+			break
+		}
+	}
+	if tool == nil {
+		fmt.Fprintf(stderr, "Unknown tool: %s\n", args[0])
+		return 2
+	}
+	arguments, err := callArguments(*tool, args[1:])
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	message, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0", "id": 1, "method": "tools/call",
+		"params": map[string]any{"name": tool.Name, "arguments": arguments},
+	})
+	switch response := s.HandleMessage(context.Background(), message).(type) {
+	case mcp.JSONRPCResponse:
+		result, ok := response.Result.(mcp.CallToolResult)
+		if !ok || len(result.Content) == 0 {
+			return 0
+		}
+		// Only the tool's own output; the profile footer would break NDJSON
+		text, _ := result.Content[0].(mcp.TextContent)
+		if result.IsError {
+			fmt.Fprintln(stderr, text.Text)
+			return 1
+		}
+		fmt.Fprint(stdout, text.Text)
+		if text.Text != "" && !strings.HasSuffix(text.Text, "\n") {
+			fmt.Fprintln(stdout)
+		}
+		return 0
+	case mcp.JSONRPCError:
+		fmt.Fprintln(stderr, response.Error.Message)
+	}
+	return 1
+}
+
+// callArguments parses name=value arguments for tool
+func callArguments(tool mcp.Tool, args []string) (map[string]any, error) {
+	arguments := make(map[string]any)
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, fmt.Errorf("Invalid argument %q (expected name=value)", arg)
+		}
+		property, known := tool.InputSchema.Properties[name].(map[string]any)
+		if !known {
+			return nil, fmt.Errorf("%s has no argument %s", tool.Name, name)
+		}
+		if property["type"] == "array" {
+			values, _ := arguments[name].([]any)
+			arguments[name] = append(values, value)
+		} else {
+			arguments[name] = value
+		}
+	}
+	return arguments, nil
+}
+
+// registeredToolNames lists the tools registered on s
+func registeredToolNames(s *server.MCPServer) []string {
+	var names []string
+	for _, tool := range registeredTools(s) {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+// registeredTools lists the tools registered on s, via the server's own tools/list handler
+func registeredTools(s *server.MCPServer) []mcp.Tool {
+	response := s.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	rpc, ok := response.(mcp.JSONRPCResponse)
+	if !ok {
+		return nil
+	}
+	var list *mcp.ListToolsResult
+	switch result := rpc.Result.(type) {
+	case mcp.ListToolsResult:
+		list = &result
+	case *mcp.ListToolsResult:
+		list = result
+	default:
+		return nil
+	}
+	return list.Tools
 }
@@ -2,6 +2,10 @@ package main
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/cpuchip/journal-mcp/internal/servers"
@@ -301,3 +305,62 @@ func TestRequestParameterParsing(t *testing.T) {
 		})
 	}
 }
+
+func TestPromptRegistration(t *testing.T) {
+	s := server.NewMCPServer("journal-mcp", "1.0.0", server.WithPromptCapabilities(true))
+	js := servers.NewJournalService()
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("Prompt registration panicked: %v", r)
+		}
+	}()
+
+	registerPrompts(s, js)
+}
+
+func TestDisabledTools(t *testing.T) {
+	js, tempDir := servers.CreateTestJournalService(t)
+	config := "tools:\n  disabled:\n    - \"*github*\"\n    - restore_data_backup\n"
+	if err := os.WriteFile(filepath.Join(tempDir, "config.yaml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	newMCPServer(js)
+	for _, name := range []string{"sync_with_github", "create_task_from_github_issue", "sync_github_discussions", "restore_data_backup"} {
+		if slices.Contains(js.Tools, name) {
+			t.Errorf("Expected %s to be left out of the tool list", name)
+		}
+	}
+	if !slices.Contains(js.Tools, "create_data_backup") || !slices.Contains(js.Tools, "create_task") {
+		t.Errorf("Expected the other tools listed, got %d tools", len(js.Tools))
+	}
+}
+
+func TestRunCall(t *testing.T) {
+	js, _ := servers.CreateTestJournalService(t)
+	var stdout, stderr strings.Builder
+
+	if code := runCall(js, []string{"create_task", "id=CLI-1", "title=From the shell", "type=work", "tags=cli"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Expected create_task to succeed, got %d: %s", code, stderr.String())
+	}
+	stdout.Reset()
+	if code := runCall(js, []string{"list_tasks", "format=ndjson"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Expected list_tasks to succeed, got %d: %s", code, stderr.String())
+	}
+	if !strings.HasPrefix(stdout.String(), `{"id":"CLI-1","title":"From the shell"`) || !strings.Contains(stdout.String(), `"tags":["cli"]`) || strings.Contains(stdout.String(), "Profile") {
+		t.Errorf("Expected one NDJSON task without the profile footer, got %q", stdout.String())
+	}
+
+	for args, expected := range map[string]int{
+		"get_task task_id=NOPE": 1,
+		"no_such_tool":          2,
+		"list_tasks colour=red": 2,
+		"list_tasks status":     2,
+	} {
+		stderr.Reset()
+		if code := runCall(js, strings.Fields(args), &stdout, &stderr); code != expected || stderr.Len() == 0 {
+			t.Errorf("Expected %q to exit %d with a message, got %d", args, expected, code)
+		}
+	}
+}
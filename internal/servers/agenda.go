@@ -0,0 +1,182 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// OneOnOneTodo is an action item from an earlier meeting
+type OneOnOneTodo struct {
+	Text string `json:"text"`
+	Date string `json:"date"` // meeting it came from
+}
+
+// isFeedbackEntry reports whether an entry was flagged as feedback
+func isFeedbackEntry(entry Entry) bool {
+	lower := strings.ToLower(entry.Content)
+	return entry.Type == "feedback" || strings.HasPrefix(lower, "feedback:") || strings.Contains(lower, "#feedback")
+}
+
+// openOneOnOneTodos returns action items from meetings before date that are
+// not closed yet (see todoClosed), oldest first
+func openOneOnOneTodos(meetings []*OneOnOne, tasks []*Task, before string) []OneOnOneTodo {
+	byID := make(map[string]*Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
+	var open []OneOnOneTodo
+	for _, meeting := range meetings {
+		if meeting.Date >= before {
+			continue
+		}
+		for _, todo := range meeting.Todos {
+			if !todoClosed(meeting, todo, byID) {
+				open = append(open, OneOnOneTodo{Text: todo, Date: meeting.Date})
+			}
+		}
+	}
+	return open
+}
+
+// BuildOneOnOneAgenda proposes a 1-on-1 agenda from open action items, blocked
+// tasks, recent wins and feedback flagged since the last meeting
+func (js *JournalService) BuildOneOnOneAgenda(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	date := request.GetString("date", time.Now().Format("2006-01-02"))
+	if validationErr := js.validateDateFormat(date, "date"); validationErr != nil {
+		return mcp.NewToolResultError(validationErr.Error()), nil
+	}
+
+	meetings, err := js.loadOneOnOnes()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load one-on-ones: %v", err)), nil
+	}
+	// With a person, only their meetings carry follow-ups
+	person := request.GetString("person", "")
+	if person != "" {
+		config, _ := js.loadConfiguration()
+		person = teamMemberName(config, person)
+		meetings = slices.DeleteFunc(meetings, func(meeting *OneOnOne) bool { return !meetingWith(meeting, person) })
+	}
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+
+	// Everything since the previous meeting, or the last two weeks
+	meetingDay := js.parseDateSafely(date)
+	since := meetingDay.AddDate(0, 0, -14)
+	lastMeeting := ""
+	for _, meeting := range meetings {
+		if meeting.Date < date {
+			lastMeeting = meeting.Date
+		}
+	}
+	if lastMeeting != "" {
+		since = js.parseDateSafely(lastMeeting)
+	}
+	until := meetingDay.AddDate(0, 0, 1)
+
+	var wins, blocked []*Task
+	var feedback []DashboardEntry
+	for _, task := range tasks {
+		if doneAt, ok := completedAt(task); ok && !doneAt.Before(since) && doneAt.Before(until) {
+			wins = append(wins, task)
+		}
+		if task.Status == "blocked" {
+			blocked = append(blocked, task)
+		}
+		for _, entry := range task.Entries {
+			if isFeedbackEntry(entry) && !entry.Timestamp.Before(since) && entry.Timestamp.Before(until) {
+				feedback = append(feedback, DashboardEntry{TaskID: task.ID, Timestamp: entry.Timestamp, Content: entry.Content})
+			}
+		}
+	}
+	sort.Slice(wins, func(i, j int) bool { return wins[i].ID < wins[j].ID })
+	sort.Slice(blocked, func(i, j int) bool { return blocked[i].ID < blocked[j].ID })
+	sort.Slice(feedback, func(i, j int) bool { return feedback[i].Timestamp.Before(feedback[j].Timestamp) })
+
+	var md strings.Builder
+	if person != "" {
+		md.WriteString(fmt.Sprintf("# 1-on-1 Agenda: %s with %s\n\n", date, person))
+	} else {
+		md.WriteString(fmt.Sprintf("# 1-on-1 Agenda: %s\n\n", date))
+	}
+	if lastMeeting != "" {
+		md.WriteString(fmt.Sprintf("Since last meeting on %s\n\n", lastMeeting))
+	}
+
+	md.WriteString("## Follow-ups from previous meetings\n")
+	todos := openOneOnOneTodos(meetings, tasks, date)
+	if len(todos) == 0 {
+		md.WriteString("- None\n")
+	}
+	for _, todo := range todos {
+		md.WriteString(fmt.Sprintf("- [ ] %s (from %s)\n", todo.Text, todo.Date))
+	}
+
+	md.WriteString("\n## Blockers\n")
+	if len(blocked) == 0 {
+		md.WriteString("- None\n")
+	}
+	for _, task := range blocked {
+		md.WriteString(fmt.Sprintf("- %s: %s\n", task.ID, task.Title))
+	}
+
+	md.WriteString("\n## Recent wins\n")
+	if len(wins) == 0 {
+		md.WriteString("- None\n")
+	}
+	for _, task := range wins {
+		md.WriteString(fmt.Sprintf("- %s: %s\n", task.ID, task.Title))
+	}
+
+	if person != "" {
+		md.WriteString("\n## Handoffs\n")
+		handoffs := agendaHandoffs(tasks, person, since, until)
+		if len(handoffs) == 0 {
+			md.WriteString("- None\n")
+		}
+		for _, line := range handoffs {
+			md.WriteString("- " + line + "\n")
+		}
+	}
+
+	md.WriteString("\n## Feedback to discuss\n")
+	if len(feedback) == 0 {
+		md.WriteString("- None\n")
+	}
+	for _, entry := range feedback {
+		md.WriteString(fmt.Sprintf("- %s (%s, %s)\n", entry.Content, entry.TaskID, entry.Timestamp.Format("2006-01-02")))
+	}
+
+	agenda := md.String()
+
+	if request.GetString("store", "false") == "true" {
+		meeting := &OneOnOne{Date: date, Person: person, Created: time.Now()}
+		for _, existing := range meetings {
+			if existing.Date == date && existing.Person == person {
+				meeting = existing
+			}
+		}
+		if meeting.Notes != "" {
+			meeting.Notes += "\n\n"
+		}
+		meeting.Notes += agenda
+		if err := js.saveOneOnOne(meeting); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to save one-on-one: %v", err)), nil
+		}
+		agenda += fmt.Sprintf("\n_Stored in the notes of the %s one-on-one._\n", date)
+	}
+
+	if agenda, err = js.styleMarkdown(request.GetString("style", ""), agenda); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(agenda), nil
+}
@@ -0,0 +1,50 @@
+package servers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestBuildOneOnOneAgenda(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	lastWeek := time.Now().AddDate(0, 0, -7).Format("2006-01-02")
+
+	js.saveOneOnOne(&OneOnOne{Date: lastWeek, Todos: []string{"Write design doc", "Book training"}})
+
+	createTestTask(t, js, "DOC-1", "Write design doc", "work")
+	js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": "DOC-1", "status": "completed"}))
+
+	createTestTask(t, js, "OPS-1", "Rotate keys", "work")
+	js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": "OPS-1", "status": "blocked"}))
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{
+		"task_id": "OPS-1", "content": "Security team said the runbook was very clear", "entry_type": "feedback",
+	}))
+
+	result, _ := js.BuildOneOnOneAgenda(ctx, CreateMockRequest(map[string]interface{}{"store": "true"}))
+	agenda := result.Content[0].(mcp.TextContent).Text
+
+	for _, want := range []string{
+		"Since last meeting on " + lastWeek,
+		"- [ ] Book training (from " + lastWeek + ")",
+		"## Blockers\n- OPS-1: Rotate keys",
+		"## Recent wins\n- DOC-1: Write design doc",
+		"runbook was very clear",
+	} {
+		if !strings.Contains(agenda, want) {
+			t.Errorf("Expected agenda to contain %q, got:\n%s", want, agenda)
+		}
+	}
+	if strings.Contains(agenda, "- [ ] Write design doc") {
+		t.Errorf("Expected the completed action item to be dropped, got:\n%s", agenda)
+	}
+
+	meetings, _ := js.loadOneOnOnes()
+	if len(meetings) != 2 || !strings.Contains(meetings[1].Notes, "# 1-on-1 Agenda") {
+		t.Errorf("Expected the agenda stored as today's notes, got %+v", meetings)
+	}
+}
@@ -0,0 +1,192 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// AggregateReport combines analytics across several profiles
+type AggregateReport struct {
+	Profiles   []string                   `json:"profiles"`
+	Combined   AnalyticsReport            `json:"combined"`
+	PerProfile map[string]AnalyticsReport `json:"per_profile"`
+	Summary    string                     `json:"summary"`
+}
+
+// GetAggregateReport generates a combined analytics report across profiles
+func (js *JournalService) GetAggregateReport(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	reportType := request.GetString("report_type", "overview")
+	timePeriod := request.GetString("time_period", "month")
+	taskType := request.GetString("task_type", "")
+
+	validReportTypes := map[string]bool{"overview": true, "productivity": true, "patterns": true, "trends": true}
+	if !validReportTypes[reportType] {
+		return mcp.NewToolResultError("report_type must be one of: overview, productivity, patterns, trends"), nil
+	}
+
+	validTimePeriods := map[string]bool{"week": true, "month": true, "quarter": true, "year": true, "all": true}
+	if !validTimePeriods[timePeriod] {
+		return mcp.NewToolResultError("time_period must be one of: week, month, quarter, year, all"), nil
+	}
+
+	tasksByProfile, err := js.loadProfileTasks(request.GetStringSlice("profiles", nil))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	report := AggregateReport{PerProfile: make(map[string]AnalyticsReport)}
+	var allTasks []*Task
+
+	for profile, tasks := range tasksByProfile {
+		filtered := js.filterTasksByTimePeriod(tasks, timePeriod)
+		if taskType != "" {
+			filtered = js.getTasksByType(filtered, taskType)
+		}
+
+		report.Profiles = append(report.Profiles, profile)
+		report.PerProfile[profile] = js.generateAnalyticsReport(filtered, reportType, timePeriod)
+		allTasks = append(allTasks, filtered...)
+	}
+	sort.Strings(report.Profiles)
+
+	report.Combined = js.generateAnalyticsReport(allTasks, reportType, timePeriod)
+	report.Summary = fmt.Sprintf("Combined report across %d profiles (%s): %s",
+		len(report.Profiles), strings.Join(report.Profiles, ", "), report.Combined.Summary)
+
+	resultJSON, _ := json.MarshalIndent(report, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// GetAggregateLog renders activity across profiles for a date range
+func (js *JournalService) GetAggregateLog(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	dateFrom, err := request.RequireString("date_from")
+	if err != nil {
+		return mcp.NewToolResultError("date_from is required (YYYY-MM-DD format)"), nil
+	}
+	if validationErr := js.validateDateFormat(dateFrom, "date_from"); validationErr != nil {
+		return mcp.NewToolResultError(validationErr.Error()), nil
+	}
+
+	dateTo := request.GetString("date_to", dateFrom)
+	if validationErr := js.validateDateFormat(dateTo, "date_to"); validationErr != nil {
+		return mcp.NewToolResultError(validationErr.Error()), nil
+	}
+
+	loc := js.location()
+	fromTime, _ := time.ParseInLocation("2006-01-02", dateFrom, loc)
+	toTime, _ := time.ParseInLocation("2006-01-02", dateTo, loc)
+	toTime = toTime.AddDate(0, 0, 1)
+
+	tasksByProfile, err := js.loadProfileTasks(request.GetStringSlice("profiles", nil))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// date -> profile -> "task: title" -> entries
+	activity := make(map[string]map[string]map[string][]Entry)
+	entryCounts := make(map[string]int)
+
+	for profile, tasks := range tasksByProfile {
+		for _, task := range tasks {
+			for _, entry := range task.Entries {
+				if entry.Timestamp.Before(fromTime) || !entry.Timestamp.Before(toTime) {
+					continue
+				}
+
+				date := entryDate(entry, loc)
+				if activity[date] == nil {
+					activity[date] = make(map[string]map[string][]Entry)
+				}
+				if activity[date][profile] == nil {
+					activity[date][profile] = make(map[string][]Entry)
+				}
+
+				heading := fmt.Sprintf("%s: %s", task.ID, task.Title)
+				activity[date][profile][heading] = append(activity[date][profile][heading], entry)
+				entryCounts[profile]++
+			}
+		}
+	}
+
+	var md strings.Builder
+	md.WriteString(fmt.Sprintf("# Aggregate Log: %s to %s\n\n", dateFrom, dateTo))
+
+	if len(activity) == 0 {
+		md.WriteString("No activity recorded for this period.")
+		return mcp.NewToolResultText(md.String()), nil
+	}
+
+	for _, date := range sortedKeys(activity) {
+		md.WriteString(fmt.Sprintf("## %s\n", date))
+		for _, profile := range sortedKeys(activity[date]) {
+			md.WriteString(fmt.Sprintf("### [%s]\n", profile))
+			for _, heading := range sortedKeys(activity[date][profile]) {
+				md.WriteString(fmt.Sprintf("**%s**\n", heading))
+				entries := activity[date][profile][heading]
+				sort.Slice(entries, func(i, j int) bool {
+					return entries[i].Timestamp.Before(entries[j].Timestamp)
+				})
+				for _, entry := range entries {
+					md.WriteString(fmt.Sprintf("- %s: %s\n", entry.Timestamp.Format("15:04"), entry.Content))
+				}
+			}
+			md.WriteString("\n")
+		}
+	}
+
+	md.WriteString("## Per-Profile Summary\n")
+	for _, profile := range sortedKeys(entryCounts) {
+		md.WriteString(fmt.Sprintf("- **%s:** %d entries\n", profile, entryCounts[profile]))
+	}
+
+	markdown, err := js.styleMarkdown(request.GetString("style", ""), md.String())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(markdown), nil
+}
+
+// loadProfileTasks reads tasks from the named profiles (all profiles when empty)
+// without switching the active profile or writing anything
+func (js *JournalService) loadProfileTasks(names []string) (map[string][]*Task, error) {
+	if len(names) == 0 {
+		profiles, err := js.listProfiles()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to list profiles: %v", err)
+		}
+		for _, profile := range profiles {
+			names = append(names, profile.Name)
+		}
+	}
+
+	tasksByProfile := make(map[string][]*Task)
+	for _, name := range names {
+		if err := validateProfileName(name); err != nil {
+			return nil, err
+		}
+
+		reader := &JournalService{DataDir: js.profileDir(name)}
+		tasks, err := reader.loadAllTasks()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load tasks for profile %s: %v", name, err)
+		}
+		tasksByProfile[name] = tasks
+	}
+
+	return tasksByProfile, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
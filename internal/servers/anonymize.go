@@ -0,0 +1,201 @@
+package servers
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	anonEmailPattern   = regexp.MustCompile(`[\w.+-]+@[\w-]+(?:\.[\w-]+)+`)
+	anonURLPattern     = regexp.MustCompile(`https?://[^\s)\]>"']+`)
+	anonIssuePattern   = regexp.MustCompile(`\b[A-Z][A-Z0-9]+-\d+\b`)
+	anonMentionPattern = regexp.MustCompile(`@[\w.-]+\w`)
+	anonTokenPattern   = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// anonymizer replaces names, URLs, IDs and emails with pseudonyms that stay
+// the same everywhere in one export, so the data keeps its shape for bug reports
+type anonymizer struct {
+	pseudonyms map[string]string // kind + value -> pseudonym
+	counts     map[string]int
+	people     []anonPerson
+	taskIDs    *regexp.Regexp
+}
+
+type anonPerson struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// newAnonymizer learns the people (team registry, assignees and extra names)
+// and task IDs to replace. Task IDs are numbered in sorted order.
+func newAnonymizer(members []TeamMember, extraNames []string, tasks []*Task) *anonymizer {
+	a := &anonymizer{pseudonyms: make(map[string]string), counts: make(map[string]int)}
+
+	known := make(map[string]bool)
+	addPerson := func(name string, aliases []string) {
+		name = strings.TrimSpace(name)
+		if name == "" || known[strings.ToLower(name)] {
+			return
+		}
+		known[strings.ToLower(name)] = true
+		a.people = append(a.people, anonPerson{name: name, pattern: personPattern(name, aliases)})
+	}
+	for _, member := range members {
+		addPerson(member.Name, member.Aliases)
+	}
+	for _, task := range tasks {
+		addPerson(task.Assignee, nil)
+	}
+	for _, name := range extraNames {
+		addPerson(name, nil)
+	}
+	// Longer names first so "Alex Kim" wins over "Alex"
+	sort.SliceStable(a.people, func(i, j int) bool { return len(a.people[i].name) > len(a.people[j].name) })
+
+	var ids []string
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		a.pseudonym("task", id)
+	}
+	sort.SliceStable(ids, func(i, j int) bool { return len(ids[i]) > len(ids[j]) })
+	var quoted []string
+	for _, id := range ids {
+		if id != "" {
+			quoted = append(quoted, regexp.QuoteMeta(id))
+		}
+	}
+	if len(quoted) > 0 {
+		a.taskIDs = regexp.MustCompile(`\b(?:` + strings.Join(quoted, "|") + `)\b`)
+	}
+
+	return a
+}
+
+// pseudonym returns the stable replacement for a value of the given kind
+func (a *anonymizer) pseudonym(kind, value string) string {
+	key := kind + "\x00" + value
+	if pseudonym, ok := a.pseudonyms[key]; ok {
+		return pseudonym
+	}
+	a.counts[kind]++
+	n := a.counts[kind]
+
+	var pseudonym string
+	switch kind {
+	case "name":
+		pseudonym = "Person " + strconv.Itoa(n)
+		if n <= 26 {
+			pseudonym = fmt.Sprintf("Person %c", 'A'+n-1)
+		}
+	case "email":
+		pseudonym = fmt.Sprintf("user%d@example.com", n)
+	case "url":
+		pseudonym = fmt.Sprintf("https://example.com/link-%d", n)
+	case "task":
+		pseudonym = fmt.Sprintf("TASK-%d", n)
+	case "issue":
+		pseudonym = fmt.Sprintf("ISSUE-%d", n)
+	case "mention":
+		pseudonym = fmt.Sprintf("@user%d", n)
+	}
+	a.pseudonyms[key] = pseudonym
+	return pseudonym
+}
+
+// text anonymizes free text. Replacements become placeholders first so one
+// pass never rewrites the output of another (TASK-1 looks like an issue key).
+func (a *anonymizer) text(s string) string {
+	if s == "" {
+		return s
+	}
+	var replaced []string
+	hold := func(pseudonym string) string {
+		replaced = append(replaced, pseudonym)
+		return fmt.Sprintf("\x00%d\x00", len(replaced)-1)
+	}
+
+	s = anonEmailPattern.ReplaceAllStringFunc(s, func(m string) string { return hold(a.pseudonym("email", strings.ToLower(m))) })
+	s = anonURLPattern.ReplaceAllStringFunc(s, func(m string) string { return hold(a.pseudonym("url", m)) })
+	if a.taskIDs != nil {
+		s = a.taskIDs.ReplaceAllStringFunc(s, func(m string) string { return hold(a.pseudonym("task", m)) })
+	}
+	s = anonIssuePattern.ReplaceAllStringFunc(s, func(m string) string { return hold(a.pseudonym("issue", m)) })
+	for _, person := range a.people {
+		s = person.pattern.ReplaceAllStringFunc(s, func(string) string { return hold(a.pseudonym("name", person.name)) })
+	}
+	s = anonMentionPattern.ReplaceAllStringFunc(s, func(m string) string { return hold(a.pseudonym("mention", strings.ToLower(m))) })
+
+	return anonTokenPattern.ReplaceAllStringFunc(s, func(m string) string {
+		index, _ := strconv.Atoi(strings.Trim(m, "\x00"))
+		return replaced[index]
+	})
+}
+
+// id anonymizes a task ID reference
+func (a *anonymizer) id(id string) string {
+	if id == "" {
+		return id
+	}
+	return a.pseudonym("task", id)
+}
+
+// task returns an anonymized copy of a task
+func (a *anonymizer) task(task *Task) *Task {
+	anon := *task
+	anon.ID = a.id(task.ID)
+	anon.ParentID = a.id(task.ParentID)
+	anon.Title = a.text(task.Title)
+	anon.Assignee = a.text(task.Assignee)
+	if task.IssueURL != "" {
+		anon.IssueURL = a.pseudonym("url", task.IssueURL)
+	}
+	if task.IssueID != "" {
+		anon.IssueID = a.pseudonym("issue", task.IssueID)
+	}
+	anon.Clock = nil
+
+	anon.DependsOn = nil
+	for _, dep := range task.DependsOn {
+		anon.DependsOn = append(anon.DependsOn, a.id(dep))
+	}
+	anon.Entries = make([]Entry, len(task.Entries))
+	for i, entry := range task.Entries {
+		entry.Content = a.text(entry.Content)
+		anon.Entries[i] = entry
+	}
+	if task.Fields != nil {
+		anon.Fields = make(map[string]string, len(task.Fields))
+		for name, value := range task.Fields {
+			anon.Fields[name] = a.text(value)
+		}
+	}
+	anon.Checklist = nil
+	for _, item := range task.Checklist {
+		anon.Checklist = append(anon.Checklist, ChecklistItem{Text: a.text(item.Text), Done: item.Done})
+	}
+	return &anon
+}
+
+// oneOnOne returns an anonymized copy of a one-on-one
+func (a *anonymizer) oneOnOne(meeting OneOnOne) OneOnOne {
+	anonList := func(items []string) []string {
+		var out []string
+		for _, item := range items {
+			out = append(out, a.text(item))
+		}
+		return out
+	}
+	meeting.Insights = anonList(meeting.Insights)
+	meeting.Todos = anonList(meeting.Todos)
+	meeting.Feedback = anonList(meeting.Feedback)
+	meeting.Notes = a.text(meeting.Notes)
+	meeting.Person = a.text(meeting.Person)
+	return meeting
+}
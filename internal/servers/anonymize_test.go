@@ -0,0 +1,76 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestAnonymizerText(t *testing.T) {
+	members := []TeamMember{{Name: "Alex Kim", Aliases: []string{"@akim"}}}
+	tasks := []*Task{{ID: "API-1"}, {ID: "migrate-db", Assignee: "Sam"}}
+	anon := newAnonymizer(members, []string{"Globex"}, tasks)
+
+	got := anon.text("Alex Kim (@akim, alex.kim@corp.com) asked Sam about API-1 and PAY-77 for Globex, see https://git.corp.com/pay/77 and @bob")
+	want := "Person A (Person A, user1@example.com) asked Person C about TASK-1 and ISSUE-1 for Person B, see https://example.com/link-1 and @user1"
+	if got != want {
+		t.Errorf("text =\n%s\nwant\n%s", got, want)
+	}
+
+	// Pseudonyms are stable across calls
+	if got := anon.text("migrate-db blocked on PAY-77, ping alex kim"); got != "TASK-2 blocked on ISSUE-1, ping Person A" {
+		t.Errorf("Unexpected second text: %s", got)
+	}
+}
+
+func TestExportDataAnonymized(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	js.CreateTask(ctx, CreateMockRequest(map[string]interface{}{
+		"id": "ACME-42", "title": "Fix billing for Initech", "type": "work", "assignee": "Dana Scully",
+		"issue_url": "https://github.com/acme/billing/issues/42",
+	}))
+	js.CreateTask(ctx, CreateMockRequest(map[string]interface{}{"id": "ACME-43", "title": "Follow up", "type": "work"}))
+	js.AddTaskDependency(ctx, CreateMockRequest(map[string]interface{}{"task_id": "ACME-43", "depends_on": "ACME-42"}))
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "ACME-42", "content": "Dana Scully emailed ops@initech.com"}))
+	js.saveOneOnOne(&OneOnOne{Date: "2026-05-04", Todos: []string{"Dana Scully to review ACME-42"}})
+
+	result, _ := js.ExportData(ctx, CreateMockRequest(map[string]interface{}{"format": "json", "anonymize": "true", "names": []interface{}{"Initech"}}))
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, leak := range []string{"ACME", "Dana", "Scully", "Initech", "initech.com", "github.com/acme"} {
+		if strings.Contains(text, leak) {
+			t.Errorf("Anonymized export still contains %q:\n%s", leak, text)
+		}
+	}
+
+	var export struct {
+		Tasks     []Task     `json:"tasks"`
+		OneOnOnes []OneOnOne `json:"one_on_ones"`
+	}
+	if err := json.Unmarshal([]byte(text), &export); err != nil {
+		t.Fatalf("Failed to parse export: %v", err)
+	}
+	byID := make(map[string]Task)
+	for _, task := range export.Tasks {
+		byID[task.ID] = task
+	}
+	if byID["TASK-1"].Assignee != "Person B" || byID["TASK-1"].IssueURL != "https://example.com/link-1" {
+		t.Errorf("Unexpected anonymized task: %+v", byID["TASK-1"])
+	}
+	if !equalStringSlices(byID["TASK-2"].DependsOn, []string{"TASK-1"}) {
+		t.Errorf("Dependencies should use the same pseudonyms: %v", byID["TASK-2"].DependsOn)
+	}
+	if export.OneOnOnes[0].Todos[0] != "Person B to review TASK-1" {
+		t.Errorf("Unexpected one-on-one todo: %s", export.OneOnOnes[0].Todos[0])
+	}
+
+	// Without the option nothing changes
+	result, _ = js.ExportData(ctx, CreateMockRequest(map[string]interface{}{"format": "csv"}))
+	if !strings.Contains(result.Content[0].(mcp.TextContent).Text, "Dana Scully emailed ops@initech.com") {
+		t.Error("Plain export should keep the original content")
+	}
+}
@@ -0,0 +1,160 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Archived tasks live in archived/, one JSON file per task, outside the task
+// storage that every listing, search and report loads. list_tasks and
+// search_entries include them with include_archived=true.
+
+func (js *JournalService) archivedDir() string {
+	return filepath.Join(js.dataDir(), "archived")
+}
+
+func (js *JournalService) archivedTaskPath(taskID string) string {
+	return filepath.Join(js.archivedDir(), taskID+".json")
+}
+
+// loadArchivedTask reads one archived task
+func (js *JournalService) loadArchivedTask(taskID string) (*Task, error) {
+	data, err := js.readDataFile(js.archivedTaskPath(taskID))
+	if err != nil {
+		return nil, err
+	}
+	var task Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		return nil, err
+	}
+	task.Archived = true
+	return &task, nil
+}
+
+// loadArchivedTasks reads every archived task, sorted by ID
+func (js *JournalService) loadArchivedTasks() ([]*Task, error) {
+	files, err := os.ReadDir(js.archivedDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var tasks []*Task
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		if task, err := js.loadArchivedTask(strings.TrimSuffix(file.Name(), ".json")); err == nil {
+			tasks = append(tasks, task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, nil
+}
+
+// archiveTask records an "archive" entry and moves a task from task storage into archived/
+func (js *JournalService) archiveTask(task *Task, reason string) error {
+	if err := os.MkdirAll(js.archivedDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	content := "Task archived"
+	if reason != "" {
+		content += ": " + reason
+	}
+	entry := Entry{
+		ID:        generateEntryID(),
+		Timestamp: time.Now(),
+		Content:   content,
+		Type:      "archive",
+	}
+	task.Entries = append(task.Entries, entry)
+	task.Updated = entry.Timestamp
+
+	data, err := json.MarshalIndent(task, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize task: %w", err)
+	}
+	archivedPath := js.archivedTaskPath(task.ID)
+	if err := js.writeDataFile(archivedPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write archived task: %w", err)
+	}
+	if err := js.storage().DeleteTask(task.ID); err != nil {
+		os.Remove(archivedPath)
+		return fmt.Errorf("failed to remove task from storage: %w", err)
+	}
+	js.updateDailyLog(task.ID, entry)
+	return nil
+}
+
+// ArchiveTask moves a task out of the active journal into archived/
+func (js *JournalService) ArchiveTask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError("task_id is required"), nil
+	}
+
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		if _, archivedErr := os.Stat(js.archivedTaskPath(taskID)); archivedErr == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Task %s is already archived", taskID)), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskID)), nil
+	}
+
+	if err := js.archiveTask(task, request.GetString("reason", "")); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to archive task: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Archived task %s (%s). It is hidden from listings, search and reports; use unarchive_task to bring it back.", taskID, task.Title)), nil
+}
+
+// UnarchiveTask moves an archived task back into task storage
+func (js *JournalService) UnarchiveTask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError("task_id is required"), nil
+	}
+
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadArchivedTask(taskID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Archived task not found: %s", taskID)), nil
+	}
+	if _, err := js.loadTask(taskID); err == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("A task with ID %s already exists; rename or delete it first", taskID)), nil
+	}
+
+	entry := Entry{
+		ID:        generateEntryID(),
+		Timestamp: time.Now(),
+		Content:   "Task unarchived",
+		Type:      "archive",
+	}
+	task.Archived = false
+	task.Entries = append(task.Entries, entry)
+	task.Updated = entry.Timestamp
+
+	if err := js.saveTask(task); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task: %v", err)), nil
+	}
+	if err := os.Remove(js.archivedTaskPath(taskID)); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Restored task %s but failed to remove the archived copy: %v", taskID, err)), nil
+	}
+	js.updateDailyLog(taskID, entry)
+
+	return mcp.NewToolResultText(fmt.Sprintf("Unarchived task %s (%s) with status %s", taskID, task.Title, task.Status)), nil
+}
@@ -0,0 +1,78 @@
+package servers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestArchiveAndUnarchiveTask(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "OLD-1", "Migrate the billing cron", "work")
+	createTestTask(t, js, "CUR-1", "Current work", "work")
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "OLD-1", "content": "Moved the nightly invoice job to the scheduler"}))
+
+	result, _ := js.ArchiveTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "OLD-1", "reason": "shipped in Q2"}))
+	if result.IsError {
+		t.Fatalf("Archive failed: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	if _, err := js.loadTask("OLD-1"); err == nil {
+		t.Error("Expected the archived task to leave task storage")
+	}
+	if result, _ := js.ArchiveTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "OLD-1"})); !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "already archived") {
+		t.Error("Expected archiving twice to report the task as already archived")
+	}
+
+	archived, err := js.loadArchivedTask("OLD-1")
+	if err != nil || !archived.Archived {
+		t.Fatalf("Expected the task in archived/: %v", err)
+	}
+	last := archived.Entries[len(archived.Entries)-1]
+	if last.Type != "archive" || !strings.Contains(last.Content, "shipped in Q2") {
+		t.Errorf("Expected an archive entry with the reason, got %+v", last)
+	}
+
+	// Default listing and search leave archived tasks out
+	listing, _ := js.ListTasks(ctx, CreateMockRequest(map[string]interface{}{}))
+	if text := listing.Content[0].(mcp.TextContent).Text; strings.Contains(text, "OLD-1") || !strings.Contains(text, "CUR-1") {
+		t.Errorf("Expected only the active task listed, got:\n%s", text)
+	}
+	listing, _ = js.ListTasks(ctx, CreateMockRequest(map[string]interface{}{"include_archived": "true"}))
+	if text := listing.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "OLD-1") || !strings.Contains(text, "(archived)") {
+		t.Errorf("Expected include_archived to list the archived task, got:\n%s", text)
+	}
+
+	search, _ := js.SearchEntries(ctx, CreateMockRequest(map[string]interface{}{"query": "invoice"}))
+	if strings.Contains(search.Content[0].(mcp.TextContent).Text, "OLD-1") {
+		t.Error("Expected search to skip archived tasks by default")
+	}
+	search, _ = js.SearchEntries(ctx, CreateMockRequest(map[string]interface{}{"query": "invoice", "include_archived": "true"}))
+	if !strings.Contains(search.Content[0].(mcp.TextContent).Text, "OLD-1") {
+		t.Errorf("Expected include_archived to search the archived task, got:\n%s", search.Content[0].(mcp.TextContent).Text)
+	}
+
+	// Unarchiving refuses to overwrite a task that reused the ID
+	createTestTask(t, js, "OLD-1", "Reused ID", "work")
+	if result, _ := js.UnarchiveTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "OLD-1"})); !result.IsError {
+		t.Error("Expected unarchive to refuse an existing task ID")
+	}
+	js.storage().DeleteTask("OLD-1")
+
+	result, _ = js.UnarchiveTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "OLD-1"}))
+	if result.IsError {
+		t.Fatalf("Unarchive failed: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	task, err := js.loadTask("OLD-1")
+	if err != nil || task.Title != "Migrate the billing cron" || task.Entries[len(task.Entries)-1].Content != "Task unarchived" {
+		t.Errorf("Expected the original task restored with an unarchive entry, got %+v (%v)", task, err)
+	}
+	if _, err := js.loadArchivedTask("OLD-1"); err == nil {
+		t.Error("Expected the archived copy removed")
+	}
+	if result, _ := js.UnarchiveTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "OLD-1"})); !result.IsError {
+		t.Error("Expected an error for a task that is not archived")
+	}
+}
@@ -0,0 +1,61 @@
+package servers
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// writeFileAtomic writes data to a temp file in the target directory and
+// renames it into place, so readers and crashes never see a partial file
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// fileLocks holds one mutex per locked file path. The MCP server, web server
+// and scheduler share a process, so an in-process lock serializes their
+// read-modify-write cycles.
+var fileLocks sync.Map
+
+// lockFile locks path and returns the unlock function
+func lockFile(path string) func() {
+	value, _ := fileLocks.LoadOrStore(filepath.Clean(path), &sync.Mutex{})
+	mu := value.(*sync.Mutex)
+	mu.Lock()
+	return mu.Unlock
+}
+
+// lockTask locks a task for a load-modify-save cycle, e.g.
+//
+//	defer js.lockTask(taskID)()
+func (js *JournalService) lockTask(taskID string) func() {
+	return lockFile(filepath.Join(js.dataDir(), "tasks", taskID+".json"))
+}
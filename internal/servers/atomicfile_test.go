@@ -0,0 +1,108 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "task.json")
+
+	if err := writeFileAtomic(path, []byte("first"), 0644); err != nil {
+		t.Fatalf("writeFileAtomic failed: %v", err)
+	}
+	if err := writeFileAtomic(path, []byte("second"), 0644); err != nil {
+		t.Fatalf("writeFileAtomic failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "second" {
+		t.Errorf("Expected replaced content, got %q", data)
+	}
+	files, _ := os.ReadDir(dir)
+	if len(files) != 1 {
+		t.Errorf("Expected no temp files left behind, got %d files", len(files))
+	}
+}
+
+func TestConcurrentTaskEntries(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	createTestTask(t, js, "RACE-1", "Concurrent writes", "work")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			js.AddTaskEntry(context.Background(), CreateMockRequest(map[string]interface{}{
+				"task_id": "RACE-1",
+				"content": fmt.Sprintf("entry %d", i),
+			}))
+		}(i)
+	}
+	wg.Wait()
+
+	task, err := js.loadTask("RACE-1")
+	if err != nil {
+		t.Fatalf("Failed to load task: %v", err)
+	}
+	count := 0
+	for _, entry := range task.Entries {
+		if strings.HasPrefix(entry.Content, "entry ") {
+			count++
+		}
+	}
+	if count != 20 {
+		t.Errorf("Expected all 20 concurrent entries to be kept, got %d", count)
+	}
+}
+
+func TestConcurrentTimerAndEntries(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "RACE-2", "Timed while logging", "work")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{
+				"task_id": "RACE-2",
+				"content": fmt.Sprintf("entry %d", i),
+			}))
+		}(i)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 5; i++ {
+			js.StartTimer(ctx, CreateMockRequest(map[string]interface{}{"task_id": "RACE-2"}))
+			js.StopTimer(ctx, CreateMockRequest(map[string]interface{}{"task_id": "RACE-2"}))
+		}
+	}()
+	wg.Wait()
+
+	task, err := js.loadTask("RACE-2")
+	if err != nil {
+		t.Fatalf("Failed to load task: %v", err)
+	}
+	entries, timed := 0, 0
+	for _, entry := range task.Entries {
+		switch {
+		case strings.HasPrefix(entry.Content, "entry "):
+			entries++
+		case entry.Type == "time":
+			timed++
+		}
+	}
+	if entries != 20 || timed != 5 || task.TimerStarted != nil {
+		t.Errorf("Expected 20 entries and 5 stopped timers kept, got %d and %d", entries, timed)
+	}
+}
@@ -0,0 +1,370 @@
+package servers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxAttachmentBytes caps a single attachment; the journal is not a file store
+const maxAttachmentBytes = 25 << 20
+
+// Attachment is a file attached to an entry. The content is stored once under
+// attachments/sha256/ in the data directory, named by its hash, however many
+// entries refer to it.
+type Attachment struct {
+	Name      string    `json:"name" yaml:"name"`
+	Path      string    `json:"path" yaml:"path"` // relative to the attachments directory, e.g. sha256/3f/3f2a...
+	SHA256    string    `json:"sha256" yaml:"sha256"`
+	Size      int64     `json:"size" yaml:"size"`
+	MediaType string    `json:"media_type,omitempty" yaml:"media_type,omitempty"`
+	Added     time.Time `json:"added" yaml:"added"`
+}
+
+// attachmentGCGrace keeps gc_attachments away from files stored moments ago
+// whose entry may not be saved yet
+const attachmentGCGrace = time.Hour
+
+func (js *JournalService) attachmentsDir() string {
+	return filepath.Join(js.dataDir(), "attachments")
+}
+
+// attachmentStorePath is where content with the given hash is stored, relative
+// to the attachments directory. Attachments from before content addressing
+// keep their <task ID>/<hash prefix>-<name> paths.
+func attachmentStorePath(hash string) string {
+	return path.Join("sha256", hash[:2], hash)
+}
+
+// attachmentDownloadName is the file name an attachment is served under: the
+// name query parameter, else the readable part of an older per-task path
+func attachmentDownloadName(relPath, name string) string {
+	if name != "" {
+		return filepath.Base(name)
+	}
+	name = path.Base(relPath)
+	if i := strings.Index(name, "-"); i >= 0 {
+		name = name[i+1:] // drop the hash prefix
+	}
+	return name
+}
+
+// attachmentMediaType guesses a media type from the file name, then the content
+func attachmentMediaType(name string, data []byte) string {
+	if mediaType := mime.TypeByExtension(strings.ToLower(filepath.Ext(name))); mediaType != "" {
+		return mediaType
+	}
+	return http.DetectContentType(data)
+}
+
+// storeAttachment writes data to the content store, reusing the stored file
+// when the same content was attached before anywhere in the journal
+func (js *JournalService) storeAttachment(name, mediaType string, data []byte) (Attachment, error) {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	if mediaType == "" {
+		mediaType = attachmentMediaType(name, data)
+	}
+	attachment := Attachment{
+		Name:      filepath.Base(name),
+		Path:      attachmentStorePath(hash),
+		SHA256:    hash,
+		Size:      int64(len(data)),
+		MediaType: mediaType,
+		Added:     time.Now(),
+	}
+
+	target := filepath.Join(js.attachmentsDir(), filepath.FromSlash(attachment.Path))
+	defer lockFile(target)()
+	if _, err := os.Stat(target); err == nil {
+		now := time.Now()
+		os.Chtimes(target, now, now) // a fresh reference; keeps gc_attachments' grace period
+		return attachment, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return attachment, fmt.Errorf("failed to create attachment directory: %w", err)
+	}
+	if err := js.writeDataFile(target, data, 0644); err != nil {
+		return attachment, fmt.Errorf("failed to write attachment: %w", err)
+	}
+	return attachment, nil
+}
+
+// readAttachment returns a stored attachment's content by its path
+func (js *JournalService) readAttachment(relPath string) ([]byte, error) {
+	if !isSafeArchivePath(relPath) {
+		return nil, fmt.Errorf("invalid attachment path %q", relPath)
+	}
+	return js.readDataFile(filepath.Join(js.attachmentsDir(), filepath.FromSlash(relPath)))
+}
+
+// AddAttachment attaches a file to an entry, or to a new entry when no entry_id is given
+func (js *JournalService) AddAttachment(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError("task_id is required"), nil
+	}
+
+	filePath := request.GetString("file_path", "")
+	encoded := request.GetString("content_base64", "")
+	name := request.GetString("name", "")
+	var data []byte
+	switch {
+	case filePath != "" && encoded != "":
+		return mcp.NewToolResultError("Pass either file_path or content_base64, not both"), nil
+	case filePath != "":
+		info, err := os.Stat(filePath)
+		if err != nil || info.IsDir() {
+			return mcp.NewToolResultError(fmt.Sprintf("File not found: %s", filePath)), nil
+		}
+		if info.Size() > maxAttachmentBytes {
+			return mcp.NewToolResultError(fmt.Sprintf("File is %d bytes; attachments are limited to %d bytes", info.Size(), maxAttachmentBytes)), nil
+		}
+		if data, err = os.ReadFile(filePath); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to read file: %v", err)), nil
+		}
+		if name == "" {
+			name = filepath.Base(filePath)
+		}
+	case encoded != "":
+		if name == "" {
+			return mcp.NewToolResultError("name is required with content_base64"), nil
+		}
+		if data, err = base64.StdEncoding.DecodeString(encoded); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid content_base64: %v", err)), nil
+		}
+		if len(data) > maxAttachmentBytes {
+			return mcp.NewToolResultError(fmt.Sprintf("Content is %d bytes; attachments are limited to %d bytes", len(data), maxAttachmentBytes)), nil
+		}
+	default:
+		return mcp.NewToolResultError("file_path or content_base64 is required"), nil
+	}
+
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskID)), nil
+	}
+
+	attachment, err := js.storeAttachment(name, request.GetString("media_type", ""), data)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to store attachment: %v", err)), nil
+	}
+
+	entryID := request.GetString("entry_id", "")
+	if entryID != "" {
+		found := false
+		for i := range task.Entries {
+			if task.Entries[i].ID == entryID {
+				task.Entries[i].Attachments = append(task.Entries[i].Attachments, attachment)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return mcp.NewToolResultError("Entry not found"), nil
+		}
+	} else {
+		caption := request.GetString("caption", "")
+		if caption == "" {
+			caption = "Attached " + attachment.Name
+		}
+		entry := Entry{
+			ID:          generateEntryID(),
+			Timestamp:   time.Now(),
+			Content:     caption,
+			Type:        "log",
+			Attachments: []Attachment{attachment},
+		}
+		task.Entries = append(task.Entries, entry)
+		entryID = entry.ID
+		js.updateDailyLog(taskID, entry)
+	}
+	task.Updated = time.Now()
+
+	if err := js.saveTask(task); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Attached %s (%s, %d bytes) to entry %s of task %s. Web API: /api/attachments/%s?name=%s",
+		attachment.Name, attachment.MediaType, attachment.Size, entryID, taskID, attachment.Path, url.QueryEscape(attachment.Name))), nil
+}
+
+// formatAttachments lists an entry's attachments under it in markdown
+func formatAttachments(entry Entry) string {
+	if len(entry.Attachments) == 0 {
+		return ""
+	}
+	var md strings.Builder
+	for _, attachment := range entry.Attachments {
+		md.WriteString(fmt.Sprintf("- Attachment: [%s](attachments/%s) (%s, %d bytes)\n", attachment.Name, attachment.Path, attachment.MediaType, attachment.Size))
+	}
+	md.WriteString("\n")
+	return md.String()
+}
+
+// exportAttachments returns the base64 content of every attachment on the
+// tasks, keyed by attachment path
+func (js *JournalService) exportAttachments(tasks []*Task) map[string]string {
+	contents := make(map[string]string)
+	for _, task := range tasks {
+		for _, entry := range task.Entries {
+			for _, attachment := range entry.Attachments {
+				if _, done := contents[attachment.Path]; done {
+					continue
+				}
+				if data, err := js.readAttachment(attachment.Path); err == nil {
+					contents[attachment.Path] = base64.StdEncoding.EncodeToString(data)
+				}
+			}
+		}
+	}
+	return contents
+}
+
+// AttachmentGCResult reports a gc_attachments run
+type AttachmentGCResult struct {
+	Files      int      `json:"files"`       // stored files still referred to
+	References int      `json:"references"`  // attachments referring to them
+	Shared     int      `json:"shared"`      // files referred to more than once
+	Removed    []string `json:"removed"`     // orphaned files, removed unless dry_run
+	FreedBytes int64    `json:"freed_bytes"` // on disk, as stored
+	Missing    []string `json:"missing,omitempty"`
+	DryRun     bool     `json:"dry_run"`
+	Summary    string   `json:"summary"`
+}
+
+// attachmentRefs counts the attachments referring to each stored file, across
+// live, archived and trashed tasks and the task versions undo_import restores
+func (js *JournalService) attachmentRefs() (map[string]int, error) {
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tasks: %w", err)
+	}
+	archived, err := js.loadArchivedTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load archived tasks: %w", err)
+	}
+	tasks = append(tasks, archived...)
+	trash, err := js.loadTrash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trash: %w", err)
+	}
+	for _, item := range trash {
+		tasks = append(tasks, item.Task)
+	}
+	jobs, _ := filepath.Glob(filepath.Join(js.importsDir(), "*.json"))
+	for _, file := range jobs {
+		if job, err := js.loadImportJob(strings.TrimSuffix(filepath.Base(file), ".json")); err == nil {
+			for _, task := range job.Replaced {
+				tasks = append(tasks, task)
+			}
+		}
+	}
+
+	refs := make(map[string]int)
+	for _, task := range tasks {
+		for _, entry := range task.Entries {
+			for _, attachment := range entry.Attachments {
+				refs[attachment.Path]++
+			}
+		}
+	}
+	return refs, nil
+}
+
+// GCAttachments removes stored attachment files no entry refers to any more
+func (js *JournalService) GCAttachments(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	dryRun := request.GetString("dry_run", "false") == "true"
+	refs, err := js.attachmentRefs()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to count attachment references: %v", err)), nil
+	}
+
+	result := AttachmentGCResult{Removed: []string{}, DryRun: dryRun}
+	present := make(map[string]bool)
+	var dirs []string
+	root := js.attachmentsDir()
+	cutoff := time.Now().Add(-attachmentGCGrace)
+	if _, err := os.Stat(root); err == nil {
+		err = filepath.WalkDir(root, func(file string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if file != root {
+					dirs = append(dirs, file)
+				}
+				return nil
+			}
+			rel, _ := filepath.Rel(root, file)
+			rel = filepath.ToSlash(rel)
+			if refs[rel] > 0 {
+				present[rel] = true
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil || info.ModTime().After(cutoff) {
+				return nil
+			}
+			result.Removed = append(result.Removed, rel)
+			result.FreedBytes += info.Size()
+			if dryRun {
+				return nil
+			}
+			defer lockFile(file)()
+			return os.Remove(file)
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to clean up attachments: %v", err)), nil
+		}
+	}
+	if !dryRun {
+		// Deepest first, so emptied hash and task directories go too
+		sort.Slice(dirs, func(i, j int) bool { return len(dirs[i]) > len(dirs[j]) })
+		for _, dir := range dirs {
+			os.Remove(dir) // fails, harmlessly, unless empty
+		}
+	}
+
+	for _, relPath := range sortedKeys(refs) {
+		if !present[relPath] {
+			result.Missing = append(result.Missing, relPath)
+			continue
+		}
+		result.Files++
+		result.References += refs[relPath]
+		if refs[relPath] > 1 {
+			result.Shared++
+		}
+	}
+
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+	result.Summary = fmt.Sprintf("%s %d orphaned attachment files (%d bytes); %d files are referred to by %d attachments, %d of them shared",
+		verb, len(result.Removed), result.FreedBytes, result.Files, result.References, result.Shared)
+	if len(result.Missing) > 0 {
+		result.Summary += fmt.Sprintf("; %d referenced files are missing", len(result.Missing))
+	}
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
@@ -0,0 +1,185 @@
+package servers
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestAddAttachment(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "ATT-1", "Flaky upload", "work")
+
+	logPath := filepath.Join(t.TempDir(), "upload trace.log")
+	os.WriteFile(logPath, []byte("ERROR timeout after 30s\n"), 0644)
+
+	result, _ := js.AddAttachment(ctx, CreateMockRequest(map[string]interface{}{"task_id": "ATT-1", "file_path": logPath, "caption": "Trace from the failed run"}))
+	if result.IsError {
+		t.Fatalf("Attach failed: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	task, _ := js.loadTask("ATT-1")
+	entry := task.Entries[len(task.Entries)-1]
+	if entry.Content != "Trace from the failed run" || len(entry.Attachments) != 1 {
+		t.Fatalf("Expected a new entry with the attachment, got %+v", entry)
+	}
+	trace := entry.Attachments[0]
+	if trace.Name != "upload trace.log" || trace.Size != 24 || len(trace.SHA256) != 64 ||
+		trace.Path != attachmentStorePath(trace.SHA256) || !strings.HasPrefix(trace.MediaType, "text/") {
+		t.Errorf("Unexpected attachment: %+v", trace)
+	}
+	if data, err := js.readAttachment(trace.Path); err != nil || string(data) != "ERROR timeout after 30s\n" {
+		t.Errorf("Expected the stored content, got %q (%v)", data, err)
+	}
+
+	// Attach a screenshot to the existing entry
+	png := []byte("\x89PNG\r\n\x1a\n fake image")
+	result, _ = js.AddAttachment(ctx, CreateMockRequest(map[string]interface{}{
+		"task_id": "ATT-1", "entry_id": entry.ID, "name": "screen.png", "content_base64": base64.StdEncoding.EncodeToString(png),
+	}))
+	if result.IsError {
+		t.Fatalf("Attach failed: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	task, _ = js.loadTask("ATT-1")
+	if attachments := task.Entries[len(task.Entries)-1].Attachments; len(attachments) != 2 || attachments[1].MediaType != "image/png" {
+		t.Errorf("Expected the screenshot on the same entry, got %+v", attachments)
+	}
+
+	for _, args := range []map[string]interface{}{
+		{"task_id": "ATT-1"},
+		{"task_id": "ATT-1", "content_base64": "aGk="},
+		{"task_id": "ATT-1", "file_path": logPath, "entry_id": "missing"},
+		{"task_id": "NOPE", "file_path": logPath},
+	} {
+		if result, _ := js.AddAttachment(ctx, CreateMockRequest(args)); !result.IsError {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+
+	// get_task lists attachments and JSON exports can embed them
+	got, _ := js.GetTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "ATT-1"}))
+	if !strings.Contains(got.Content[0].(mcp.TextContent).Text, "Attachment: [screen.png](attachments/sha256/") {
+		t.Errorf("Expected attachments in get_task:\n%s", got.Content[0].(mcp.TextContent).Text)
+	}
+	exported, _ := js.ExportData(ctx, CreateMockRequest(map[string]interface{}{"format": "json", "include_attachments": "true"}))
+	var export struct {
+		Attachments map[string]string `json:"attachments"`
+	}
+	json.Unmarshal([]byte(exported.Content[0].(mcp.TextContent).Text), &export)
+	if decoded, _ := base64.StdEncoding.DecodeString(export.Attachments[trace.Path]); string(decoded) != "ERROR timeout after 30s\n" {
+		t.Errorf("Expected the trace embedded in the export, got %v", export.Attachments)
+	}
+
+	// Backups hold the attachment files
+	backup, err := js.writeBackup(filepath.Join(tempDir, "backups", "with-attachments.zip"), false, "default", "")
+	if err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+	reader, _ := zip.OpenReader(backup.BackupPath)
+	defer reader.Close()
+	found := false
+	for _, file := range reader.File {
+		found = found || file.Name == "attachments/"+trace.Path
+	}
+	if !found {
+		t.Error("Expected the attachment in the backup")
+	}
+}
+
+func TestWebGetAttachment(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	attachment, err := js.storeAttachment("notes.html", "", []byte("<script>alert(1)</script>"))
+	if err != nil {
+		t.Fatalf("Failed to store attachment: %v", err)
+	}
+
+	ws := &WebServer{journalService: js}
+	router := mux.NewRouter()
+	ws.setupRoutes(router)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/attachments/"+attachment.Path+"?name=notes.html", nil))
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "<script>alert(1)</script>" {
+		t.Fatalf("Expected the attachment, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if disposition := recorder.Header().Get("Content-Disposition"); !strings.HasPrefix(disposition, "attachment") || !strings.Contains(disposition, "notes.html") {
+		t.Errorf("Expected HTML to be downloaded, not shown, got %q", disposition)
+	}
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/attachments/WEB-1/missing.png", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing attachment, got %d", recorder.Code)
+	}
+	if _, err := js.readAttachment("../config.yaml"); err == nil {
+		t.Error("Expected a path outside the attachments directory to be refused")
+	}
+}
+
+func TestGCAttachments(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "GC-1", "Login bug", "work")
+	createTestTask(t, js, "GC-2", "Login redesign", "work")
+
+	screenshot := base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n\x1a\n login page"))
+	for _, taskID := range []string{"GC-1", "GC-2"} {
+		js.AddAttachment(ctx, CreateMockRequest(map[string]interface{}{"task_id": taskID, "name": "login.png", "content_base64": screenshot}))
+	}
+	first, _ := js.loadTask("GC-1")
+	second, _ := js.loadTask("GC-2")
+	shared := first.Entries[len(first.Entries)-1].Attachments[0]
+	if second.Entries[len(second.Entries)-1].Attachments[0].Path != shared.Path {
+		t.Fatal("Expected the same content stored once")
+	}
+
+	// An old orphan from the per-task layout, and one stored a moment ago
+	attachments := filepath.Join(tempDir, "attachments")
+	stale := filepath.Join(attachments, "GC-9", "0123456789abcdef-old.log")
+	os.MkdirAll(filepath.Dir(stale), 0755)
+	os.WriteFile(stale, []byte("old"), 0644)
+	old := time.Now().Add(-2 * attachmentGCGrace)
+	os.Chtimes(stale, old, old)
+	fresh, _ := js.storeAttachment("fresh.txt", "", []byte("not yet saved"))
+
+	gc := func(args map[string]interface{}) AttachmentGCResult {
+		result, _ := js.GCAttachments(ctx, CreateMockRequest(args))
+		var gc AttachmentGCResult
+		json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &gc)
+		return gc
+	}
+	dry := gc(map[string]interface{}{"dry_run": "true"})
+	if len(dry.Removed) != 1 || dry.Removed[0] != "GC-9/0123456789abcdef-old.log" || dry.Files != 1 || dry.References != 2 || dry.Shared != 1 {
+		t.Fatalf("Unexpected dry run: %+v", dry)
+	}
+	if _, err := os.Stat(stale); err != nil {
+		t.Fatal("Expected a dry run to keep the orphan")
+	}
+
+	// A deleted task can still be restored, so its attachments stay
+	js.DeleteTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "GC-2"}))
+	result := gc(map[string]interface{}{})
+	if len(result.Removed) != 1 || result.References != 2 {
+		t.Errorf("Unexpected gc result: %+v", result)
+	}
+	if _, err := os.Stat(filepath.Dir(stale)); !os.IsNotExist(err) {
+		t.Error("Expected the orphan and its emptied directory removed")
+	}
+	if _, err := js.readAttachment(fresh.Path); err != nil {
+		t.Error("Expected a freshly stored file to survive the grace period")
+	}
+	if _, err := js.readAttachment(shared.Path); err != nil {
+		t.Error("Expected the shared screenshot kept")
+	}
+}
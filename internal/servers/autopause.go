@@ -0,0 +1,103 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// autoPauseJob pauses idle active tasks once a day when schedule.auto_pause_days is set
+func (js *JournalService) autoPauseJob() ScheduledJob {
+	return ScheduledJob{
+		Name: "auto_pause",
+		Due: func(now, lastRun time.Time) bool {
+			config, err := js.loadConfiguration()
+			if err != nil || config.Schedule.AutoPauseDays <= 0 {
+				return false
+			}
+			return dueDailyAt("00:00", js.location(), now, lastRun)
+		},
+		Run: func(ctx context.Context) error {
+			config, err := js.loadConfiguration()
+			if err != nil {
+				return err
+			}
+			_, err = js.autoPauseInactiveTasks(time.Now(), config.Schedule.AutoPauseDays)
+			return err
+		},
+	}
+}
+
+// lastActivity returns the time of a task's latest entry or update
+func lastActivity(task *Task) time.Time {
+	latest := task.Updated
+	for _, entry := range task.Entries {
+		if entry.Timestamp.After(latest) {
+			latest = entry.Timestamp
+		}
+	}
+	return latest
+}
+
+// autoPauseInactiveTasks moves active tasks with no activity for days to
+// paused, recording a status change entry and a notification for each. Tasks
+// with a due date or marked snoozed are left alone.
+func (js *JournalService) autoPauseInactiveTasks(now time.Time, days int) ([]string, error) {
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := now.AddDate(0, 0, -days)
+	var paused []string
+	for _, listed := range tasks {
+		task, idleSince, err := js.autoPauseTask(listed.ID, now, cutoff, days)
+		if err != nil {
+			return paused, err
+		}
+		if task == nil {
+			continue
+		}
+
+		message := fmt.Sprintf("Paused %s (%s): no activity since %s", task.ID, task.Title, idleSince.Format("2006-01-02"))
+		if err := js.notify("auto_pause", task.ID, message); err != nil {
+			return paused, err
+		}
+		paused = append(paused, task.ID)
+	}
+
+	return paused, nil
+}
+
+// autoPauseTask pauses one task under its lock if it is still active and idle
+// since cutoff once reloaded. The task is nil when it was left alone.
+func (js *JournalService) autoPauseTask(taskID string, now, cutoff time.Time, days int) (*Task, time.Time, error) {
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return nil, time.Time{}, nil
+	}
+	if task.Status != "active" || task.DueDate != "" || task.Snoozed {
+		return nil, time.Time{}, nil
+	}
+	idleSince := lastActivity(task)
+	if idleSince.After(cutoff) {
+		return nil, time.Time{}, nil
+	}
+
+	entry := Entry{
+		ID:        generateEntryID(),
+		Timestamp: now,
+		Content:   fmt.Sprintf("Status changed from active to paused: auto-paused after %d days without activity", days),
+		Type:      "status_change",
+	}
+	task.Status = "paused"
+	task.Updated = now
+	task.Entries = append(task.Entries, entry)
+	if err := js.saveTask(task); err != nil {
+		return nil, time.Time{}, err
+	}
+	js.updateDailyLog(task.ID, entry)
+	return task, idleSince, nil
+}
@@ -0,0 +1,72 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestAutoPauseInactiveTasks(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	now := time.Now()
+	idle := now.AddDate(0, 0, -20)
+
+	for _, id := range []string{"IDLE-1", "DUE-1", "SNOOZED-1", "BUSY-1"} {
+		createTestTask(t, js, id, "Task "+id, "work")
+		task, _ := js.loadTask(id)
+		task.Updated = idle
+		task.Entries[0].Timestamp = idle
+		switch id {
+		case "DUE-1":
+			task.DueDate = now.AddDate(0, 0, 3).Format("2006-01-02")
+		case "SNOOZED-1":
+			task.Snoozed = true
+		case "BUSY-1":
+			task.Entries = append(task.Entries, Entry{ID: "recent", Timestamp: now.AddDate(0, 0, -1), Content: "Still going", Type: "log"})
+		}
+		js.saveTask(task)
+	}
+
+	paused, err := js.autoPauseInactiveTasks(now, 14)
+	if err != nil {
+		t.Fatalf("autoPauseInactiveTasks failed: %v", err)
+	}
+	if len(paused) != 1 || paused[0] != "IDLE-1" {
+		t.Fatalf("Expected only IDLE-1 paused, got %v", paused)
+	}
+
+	task, _ := js.loadTask("IDLE-1")
+	if task.Status != "paused" || task.Entries[len(task.Entries)-1].Type != "status_change" {
+		t.Errorf("Expected paused task with status change entry, got %s %+v", task.Status, task.Entries)
+	}
+
+	result, _ := js.ListNotifications(context.Background(), CreateMockRequest(map[string]interface{}{}))
+	var notifications []Notification
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &notifications)
+	if len(notifications) != 1 || notifications[0].TaskID != "IDLE-1" {
+		t.Errorf("Expected one notification for IDLE-1, got %+v", notifications)
+	}
+
+	result, _ = js.ListNotifications(context.Background(), CreateMockRequest(map[string]interface{}{}))
+	if text := result.Content[0].(mcp.TextContent).Text; text != "[]" {
+		t.Errorf("Expected notifications to be marked read, got %s", text)
+	}
+}
+
+func TestAutoPauseJobDisabledByDefault(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+
+	if js.autoPauseJob().Due(time.Now(), time.Time{}) {
+		t.Error("Expected auto-pause to be off without schedule.auto_pause_days")
+	}
+
+	config := defaultConfiguration()
+	config.Schedule.AutoPauseDays = 14
+	js.saveConfiguration(config)
+	if !js.autoPauseJob().Due(time.Now(), time.Time{}) {
+		t.Error("Expected auto-pause to be due once configured")
+	}
+}
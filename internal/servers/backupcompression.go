@@ -0,0 +1,142 @@
+package servers
+
+import (
+	"archive/zip"
+	"compress/flate"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// zipMethodZstd is the ZIP compression method for Zstandard (APPNOTE 4.4.5)
+const zipMethodZstd uint16 = 93
+
+// backupCompressions maps the compression option, and its aliases, to the
+// name recorded in backup metadata
+var backupCompressions = map[string]string{
+	"none":    "store",
+	"store":   "store",
+	"default": "deflate",
+	"deflate": "deflate",
+	"maximum": "maximum",
+	"max":     "maximum",
+	"zstd":    "zstd",
+}
+
+// backupCompressionMethods is the ZIP method each recorded compression writes
+var backupCompressionMethods = map[string]uint16{
+	"store":   zip.Store,
+	"deflate": zip.Deflate,
+	"maximum": zip.Deflate,
+	"zstd":    zipMethodZstd,
+}
+
+// normalizeBackupCompression resolves a compression option; empty means deflate
+func normalizeBackupCompression(option string) (string, error) {
+	option = strings.ToLower(strings.TrimSpace(option))
+	if option == "" {
+		return "deflate", nil
+	}
+	if compression, ok := backupCompressions[option]; ok {
+		return compression, nil
+	}
+	return "", fmt.Errorf("invalid compression %q. Must be: none (store), default (deflate), maximum or zstd", option)
+}
+
+// backupZip is a ZIP writer that compresses every file it creates with one compression
+type backupZip struct {
+	*zip.Writer
+	method uint16
+}
+
+func newBackupZip(w io.Writer, compression string) *backupZip {
+	archive := &backupZip{Writer: zip.NewWriter(w), method: backupCompressionMethods[compression]}
+	switch compression {
+	case "maximum":
+		archive.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(out, flate.BestCompression)
+		})
+	case "zstd":
+		archive.RegisterCompressor(zipMethodZstd, func(out io.Writer) (io.WriteCloser, error) {
+			return zstd.NewWriter(out, zstd.WithEncoderLevel(zstd.SpeedBetterCompression))
+		})
+	}
+	return archive
+}
+
+// Create adds a file to the archive using the archive's compression
+func (b *backupZip) Create(name string) (io.Writer, error) {
+	return b.CreateHeader(&zip.FileHeader{Name: name, Method: b.method, Modified: time.Now()})
+}
+
+// registerBackupDecompressors lets a backup reader open zstd-compressed files
+func registerBackupDecompressors(reader *zip.Reader) {
+	reader.RegisterDecompressor(zipMethodZstd, func(r io.Reader) io.ReadCloser {
+		decoder, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return io.NopCloser(failingReader{err})
+		}
+		return decoder.IOReadCloser()
+	})
+}
+
+type failingReader struct{ err error }
+
+func (r failingReader) Read([]byte) (int, error) { return 0, r.err }
+
+// checkBackupFormat compares a backup's metadata with the archive: every file
+// must use a compression this server can read, files should use the
+// compression the metadata records, and a backup recorded as encrypted
+// should still be encrypted. Unreadable compression is an error; mismatches
+// are returned as warnings.
+func checkBackupFormat(reader *zip.Reader, encrypted bool) ([]string, error) {
+	var metadata struct {
+		Compression string `json:"compression"`
+		Encrypted   bool   `json:"encrypted"`
+	}
+	hasMetadata := false
+	for _, file := range reader.File {
+		if file.Name != "backup_metadata.json" {
+			continue
+		}
+		if r, err := file.Open(); err == nil {
+			hasMetadata = json.NewDecoder(r).Decode(&metadata) == nil
+			r.Close()
+		}
+	}
+
+	var warnings []string
+	// Older backups recorded the option as given, e.g. "default"
+	compression, err := normalizeBackupCompression(metadata.Compression)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("backup metadata records unknown compression %q", metadata.Compression))
+	}
+	expected, known := backupCompressionMethods[compression]
+	known = known && hasMetadata
+
+	mismatched := 0
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		switch file.Method {
+		case zip.Store, zip.Deflate, zipMethodZstd:
+		default:
+			return warnings, fmt.Errorf("%s uses unsupported compression method %d", file.Name, file.Method)
+		}
+		if known && file.Method != expected {
+			mismatched++
+		}
+	}
+	if mismatched > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d files do not use the %s compression recorded in the backup metadata", mismatched, compression))
+	}
+	if metadata.Encrypted && !encrypted {
+		warnings = append(warnings, "backup metadata records an encrypted backup, but the file is not encrypted")
+	}
+	return warnings, nil
+}
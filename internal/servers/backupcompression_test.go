@@ -0,0 +1,123 @@
+package servers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestBackupCompression(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "ZIP-1", "Compress me", "work")
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "ZIP-1", "content": strings.Repeat("Repetitive log line. ", 200)}))
+
+	sizes := make(map[string]int64)
+	for option, want := range map[string]uint16{"none": zip.Store, "default": zip.Deflate, "maximum": zip.Deflate, "zstd": zipMethodZstd} {
+		backup, err := js.writeBackup(filepath.Join(tempDir, "backups", option+".zip"), true, option, "")
+		if err != nil {
+			t.Fatalf("%s: failed to write backup: %v", option, err)
+		}
+		sizes[option] = backup.Size
+
+		reader, _, err := js.openBackup(backup.BackupPath, "")
+		if err != nil {
+			t.Fatalf("%s: failed to open backup: %v", option, err)
+		}
+		for _, file := range reader.File {
+			if file.Method != want {
+				t.Errorf("%s: expected %s to use method %d, got %d", option, file.Name, want, file.Method)
+			}
+		}
+
+		verification := js.checkBackup(backup.BackupPath, "", false)
+		if !verification.Valid || verification.Tasks != 1 {
+			t.Errorf("%s: expected a valid backup, got %+v", option, verification)
+		}
+		if normalized, _ := normalizeBackupCompression(option); verification.Compression != normalized {
+			t.Errorf("%s: expected compression %s recorded, got %q", option, normalized, verification.Compression)
+		}
+	}
+	if sizes["none"] <= sizes["default"] || sizes["none"] <= sizes["zstd"] {
+		t.Errorf("Expected compressed backups to be smaller than stored ones: %v", sizes)
+	}
+
+	if result, _ := js.CreateDataBackup(ctx, CreateMockRequest(map[string]interface{}{"compression": "lzma"})); !result.IsError {
+		t.Error("Expected an unknown compression to be rejected")
+	}
+}
+
+func TestPassphraseEncryptedBackup(t *testing.T) {
+	t.Setenv(encryptionPassphraseEnv, "")
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "SEC-1", "Performance review notes", "work")
+
+	result, _ := js.CreateDataBackup(ctx, CreateMockRequest(map[string]interface{}{"passphrase": "correct horse", "compression": "zstd"}))
+	var backup BackupResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &backup); err != nil {
+		t.Fatalf("Unexpected result: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	if !backup.Encrypted || !strings.HasSuffix(backup.BackupPath, ".zip"+encryptedBackupSuffix) {
+		t.Errorf("Expected an encrypted .zip.enc backup, got %+v", backup)
+	}
+	if data, _ := os.ReadFile(backup.BackupPath); !isEncrypted(data) || bytes.Contains(data, []byte("Performance review")) {
+		t.Error("Expected the archive to be encrypted")
+	}
+
+	for _, passphrase := range []string{"", "wrong"} {
+		result, _ := js.RestoreDataBackup(ctx, CreateMockRequest(map[string]interface{}{"backup_path": backup.BackupPath, "passphrase": passphrase, "dry_run": "true"}))
+		if !result.IsError {
+			t.Errorf("Expected restore with passphrase %q to fail", passphrase)
+		}
+	}
+
+	result, _ = js.RestoreDataBackup(ctx, CreateMockRequest(map[string]interface{}{"backup_path": backup.BackupPath, "passphrase": "correct horse", "profile": "restored"}))
+	var restore RestoreResult
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &restore)
+	if restore.TasksRestored != 1 || len(restore.Warnings) != 0 {
+		t.Errorf("Expected the task restored without warnings, got %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "profiles", "restored", "tasks", "SEC-1.json")); err != nil {
+		t.Errorf("Expected the task in the restored profile: %v", err)
+	}
+
+	if verification := js.checkBackup(backup.BackupPath, "correct horse", false); !verification.Valid || !verification.Encrypted || verification.Compression != "zstd" {
+		t.Errorf("Expected the encrypted backup to verify, got %+v", verification)
+	}
+}
+
+func TestCheckBackupFormat(t *testing.T) {
+	build := func(metadata string, method uint16) *zip.Reader {
+		var buf bytes.Buffer
+		writer := zip.NewWriter(&buf)
+		meta, _ := writer.Create("backup_metadata.json")
+		meta.Write([]byte(metadata))
+		if method == zip.Deflate {
+			file, _ := writer.Create("tasks/A.json")
+			file.Write([]byte("{}"))
+		} else {
+			writer.CreateRaw(&zip.FileHeader{Name: "tasks/A.json", Method: method})
+		}
+		writer.Close()
+		reader, _ := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		return reader
+	}
+
+	if warnings, err := checkBackupFormat(build(`{"compression": "default"}`, zip.Deflate), false); err != nil || len(warnings) != 0 {
+		t.Errorf("Expected an older deflate backup to pass, got %v %v", warnings, err)
+	}
+	if warnings, _ := checkBackupFormat(build(`{"compression": "zstd", "encrypted": true}`, zip.Deflate), false); len(warnings) != 2 {
+		t.Errorf("Expected compression and encryption mismatches, got %v", warnings)
+	}
+	if _, err := checkBackupFormat(build(`{"compression": "deflate"}`, 14), false); err == nil {
+		t.Error("Expected an unsupported compression method to be an error")
+	}
+}
@@ -0,0 +1,230 @@
+package servers
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestAutoBackupJobDue(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	job := js.autoBackupJob()
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+
+	if job.Due(now, time.Time{}) {
+		t.Error("Expected no backups while backup.auto_backup is off")
+	}
+
+	config := defaultConfiguration()
+	config.Backup.AutoBackup = true
+	config.Backup.BackupInterval = 6
+	js.saveConfiguration(config)
+
+	if !job.Due(now, time.Time{}) {
+		t.Error("Expected a first backup to be due immediately")
+	}
+	if job.Due(now, now.Add(-5*time.Hour)) {
+		t.Error("Expected no backup before the interval has passed")
+	}
+	if !job.Due(now, now.Add(-6*time.Hour)) {
+		t.Error("Expected a backup once the interval has passed")
+	}
+
+	// A failed backup is recorded and notified, not retried every minute
+	blocked := filepath.Join(js.DataDir, "not-a-directory")
+	os.WriteFile(blocked, []byte("file"), 0644)
+	config.Backup.BackupLocation = blocked
+	js.saveConfiguration(config)
+	if err := job.Run(context.Background()); err != nil {
+		t.Errorf("Expected the job to swallow a failed backup, got %v", err)
+	}
+	if status := js.loadBackupStatus(); status.LastError == "" {
+		t.Error("Expected the failure recorded")
+	}
+	if notifications, _ := js.loadNotifications(); len(notifications) != 1 || notifications[0].Kind != "backup_failed" {
+		t.Errorf("Expected one failure notification, got %+v", notifications)
+	}
+}
+
+func TestScheduledBackupRetention(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "TASK-1", "Backed up task", "work")
+
+	backupDir := filepath.Join(tempDir, "elsewhere")
+	config := defaultConfiguration()
+	config.Backup.AutoBackup = true
+	config.Backup.MaxBackups = 2
+	config.Backup.BackupLocation = backupDir
+	js.saveConfiguration(config)
+
+	os.MkdirAll(backupDir, 0755)
+	for _, stamp := range []string{"2026-01-01_00-00-00", "2026-01-02_00-00-00"} {
+		os.WriteFile(filepath.Join(backupDir, backupPrefix+stamp+".zip"), []byte("old"), 0644)
+	}
+	os.WriteFile(filepath.Join(backupDir, "unrelated.zip"), []byte("keep"), 0644)
+
+	result, err := js.runScheduledBackup(time.Now())
+	if err != nil {
+		t.Fatalf("Scheduled backup failed: %v", err)
+	}
+
+	backups, _ := js.listBackups()
+	if len(backups) != 2 || backups[0].Path != result.BackupPath || filepath.Base(backups[1].Path) != backupPrefix+"2026-01-02_00-00-00.zip" {
+		t.Errorf("Expected the new backup and the newest old one, got %+v", backups)
+	}
+	if _, err := os.Stat(filepath.Join(backupDir, "unrelated.zip")); err != nil {
+		t.Error("Expected files that are not backups to be left alone")
+	}
+
+	statusResult, _ := js.GetBackupStatus(ctx, CreateMockRequest(map[string]interface{}{}))
+	var status struct {
+		BackupCount int          `json:"backup_count"`
+		Status      BackupStatus `json:"status"`
+		NextBackup  string       `json:"next_backup"`
+	}
+	json.Unmarshal([]byte(statusResult.Content[0].(mcp.TextContent).Text), &status)
+	if status.BackupCount != 2 || status.Status.LastBackup != result.BackupPath || status.Status.LastError != "" || status.NextBackup == "" {
+		t.Errorf("Unexpected backup status: %+v", status)
+	}
+
+	listResult, _ := js.ListBackups(ctx, CreateMockRequest(map[string]interface{}{}))
+	var listing struct {
+		BackupDir string       `json:"backup_dir"`
+		Backups   []BackupInfo `json:"backups"`
+	}
+	json.Unmarshal([]byte(listResult.Content[0].(mcp.TextContent).Text), &listing)
+	if listing.BackupDir != backupDir || len(listing.Backups) != 2 {
+		t.Errorf("Unexpected backup listing: %+v", listing)
+	}
+}
+
+func TestVerifyBackupAndRestoreDryRun(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "TASK-1", "First", "work")
+	createTestTask(t, js, "TASK-2", "Second", "work")
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "TASK-1", "content": "Progress"}))
+
+	backup, err := js.writeBackup(filepath.Join(tempDir, "backups", backupPrefix+"2026-03-02_10-00-00.zip"), true, "default", "")
+	if err != nil {
+		t.Fatalf("Failed to write backup: %v", err)
+	}
+
+	result, _ := js.VerifyBackup(ctx, CreateMockRequest(map[string]interface{}{}))
+	var verification BackupVerification
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &verification)
+	if !verification.Valid || verification.Tasks != 2 || verification.Entries != 3 || verification.BackupPath != backup.BackupPath {
+		t.Errorf("Unexpected verification: %+v", verification)
+	}
+
+	// Change a task after the backup so the dry run reports an overwrite
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "TASK-2", "content": "Later"}))
+	os.Remove(filepath.Join(tempDir, "tasks", "TASK-2.json"))
+
+	result, _ = js.RestoreDataBackup(ctx, CreateMockRequest(map[string]interface{}{
+		"backup_path": backup.BackupPath, "overwrite_existing": "true", "dry_run": "true",
+	}))
+	var restore RestoreResult
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &restore)
+	if !restore.DryRun || restore.TasksRestored != 2 || restore.EntriesRestored != 3 {
+		t.Errorf("Unexpected dry run: %+v", restore)
+	}
+	if !slices.Contains(restore.WouldOverwrite, "tasks/TASK-1.json") || !slices.Contains(restore.WouldCreate, "tasks/TASK-2.json") {
+		t.Errorf("Expected TASK-1 to be overwritten and TASK-2 created, got %+v", restore)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "tasks", "TASK-2.json")); !os.IsNotExist(err) {
+		t.Error("Dry run must not touch disk")
+	}
+
+	// An uncompressed archive with a flipped byte fails its checksum
+	corrupt := filepath.Join(tempDir, "corrupt.zip")
+	file, _ := os.Create(corrupt)
+	writer := zip.NewWriter(file)
+	entry, _ := writer.CreateHeader(&zip.FileHeader{Name: "tasks/TASK-1.json", Method: zip.Store})
+	entry.Write([]byte(`{"id":"TASK-1","title":"Progress"}`))
+	writer.Close()
+	file.Close()
+	data, _ := os.ReadFile(corrupt)
+	data[bytes.Index(data, []byte("Progress"))] ^= 0xff
+	os.WriteFile(corrupt, data, 0644)
+
+	result, _ = js.VerifyBackup(ctx, CreateMockRequest(map[string]interface{}{"backup_path": corrupt}))
+	verification = BackupVerification{}
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &verification)
+	if verification.Valid || len(verification.Problems) != 2 {
+		t.Errorf("Expected a checksum error and missing metadata, got %+v", verification)
+	}
+}
+
+func TestIsSafeArchivePath(t *testing.T) {
+	for name, safe := range map[string]bool{"tasks/TASK-1.json": true, "../evil": false, "tasks/../../evil": false, "/etc/passwd": false} {
+		if isSafeArchivePath(name) != safe {
+			t.Errorf("isSafeArchivePath(%q) = %v, expected %v", name, !safe, safe)
+		}
+	}
+}
+
+func TestBackupManifestVerification(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "TASK-1", "First", "work")
+	createTestTask(t, js, "TASK-2", "Second", "work")
+
+	result, err := js.runScheduledBackup(time.Now())
+	if err != nil {
+		t.Fatalf("Scheduled backup failed: %v", err)
+	}
+	status := js.loadBackupStatus()
+	if status.LastVerification == nil || !status.LastVerification.Valid || status.LastVerification.Live.Unchanged != result.FilesBackup {
+		t.Fatalf("Expected the scheduled backup to verify against every live file, got %+v", status.LastVerification)
+	}
+
+	// Work after the backup is expected drift, not a problem
+	createTestTask(t, js, "TASK-3", "Third", "work")
+	os.Remove(filepath.Join(tempDir, "tasks", "TASK-2.json"))
+	verification := js.checkBackup(result.BackupPath, "", true)
+	if !verification.Valid || verification.Live.AddedSince != 1 || verification.Live.DeletedSince != 1 {
+		t.Errorf("Expected an added and a deleted file without problems, got %+v", verification)
+	}
+
+	// A file that predates the backup must be in it, with the same content
+	past := time.Now().Add(-time.Hour)
+	os.WriteFile(filepath.Join(tempDir, "tasks", "MISSED.json"), []byte(`{"id":"MISSED"}`), 0644)
+	os.Chtimes(filepath.Join(tempDir, "tasks", "MISSED.json"), past, past)
+	os.WriteFile(filepath.Join(tempDir, "tasks", "TASK-1.json"), []byte(`{"id":"TASK-1"}`), 0644)
+	os.Chtimes(filepath.Join(tempDir, "tasks", "TASK-1.json"), past, past)
+	verification = js.checkBackup(result.BackupPath, "", true)
+	if verification.Valid || len(verification.Problems) != 2 {
+		t.Errorf("Expected a missed file and a mismatched file, got %+v", verification)
+	}
+	if offline := js.checkBackup(result.BackupPath, "", false); !offline.Valid || offline.Live != nil {
+		t.Errorf("Expected the archive alone to verify, got %+v", offline)
+	}
+
+	// An archive member that does not match the manifest
+	tampered := filepath.Join(tempDir, "tampered.zip")
+	file, _ := os.Create(tampered)
+	writer := zip.NewWriter(file)
+	entry, _ := writer.Create("tasks/TASK-1.json")
+	entry.Write([]byte(`{"id":"TASK-1"}`))
+	entry, _ = writer.Create("backup_metadata.json")
+	entry.Write([]byte(`{"files_count":1,"manifest":{"tasks/TASK-1.json":"00","tasks/TASK-9.json":"00"}}`))
+	writer.Close()
+	file.Close()
+
+	toolResult, _ := js.VerifyBackup(ctx, CreateMockRequest(map[string]interface{}{"backup_path": tampered, "compare_live": "false"}))
+	verification = &BackupVerification{}
+	json.Unmarshal([]byte(toolResult.Content[0].(mcp.TextContent).Text), verification)
+	if verification.Valid || len(verification.Problems) != 2 {
+		t.Errorf("Expected a checksum mismatch and a missing file, got %+v", verification)
+	}
+}
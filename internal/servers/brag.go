@@ -0,0 +1,315 @@
+package servers
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// BragItem is one accomplishment in the brag document
+type BragItem struct {
+	ID       string    `json:"id"`
+	Date     string    `json:"date"`     // YYYY-MM-DD
+	Category string    `json:"category"` // completion, milestone, praise or manual
+	Text     string    `json:"text"`
+	TaskID   string    `json:"task_id,omitempty"`
+	Source   string    `json:"source"` // task:<id>, feedback:<id> or manual
+	Created  time.Time `json:"created"`
+}
+
+var bragCategoryTitles = map[string]string{
+	"milestone":  "Milestones",
+	"completion": "Key deliveries",
+	"praise":     "Praise",
+	"manual":     "Other highlights",
+}
+
+func bragID(source string) string {
+	sum := sha1.Sum([]byte(source))
+	return "brag_" + hex.EncodeToString(sum[:])[:10]
+}
+
+func (js *JournalService) bragPath() string {
+	return filepath.Join(js.dataDir(), "brag.json")
+}
+
+func (js *JournalService) loadBragItems() ([]BragItem, error) {
+	data, err := js.readDataFile(js.bragPath())
+	if os.IsNotExist(err) {
+		return []BragItem{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var items []BragItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (js *JournalService) saveBragItems(items []BragItem) error {
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Date < items[j].Date })
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return err
+	}
+	return js.writeDataFile(js.bragPath(), data, 0644)
+}
+
+// addBragItem appends an item unless one from the same source is already there
+func (js *JournalService) addBragItem(item BragItem) (bool, error) {
+	defer lockFile(js.bragPath())()
+
+	items, err := js.loadBragItems()
+	if err != nil {
+		return false, err
+	}
+	for _, existing := range items {
+		if existing.ID == item.ID {
+			return false, nil
+		}
+	}
+	return true, js.saveBragItems(append(items, item))
+}
+
+// bragCompletion records a notable completed task: a milestone (tagged
+// "milestone" or a parent whose subtasks are done) or high/urgent priority work
+func (js *JournalService) bragCompletion(task *Task) error {
+	category := ""
+	if priorityRank(task.Priority) >= priorityRank("high") {
+		category = "completion"
+	}
+	isMilestone := false
+	for _, tag := range task.Tags {
+		if strings.EqualFold(tag, "milestone") {
+			isMilestone = true
+		}
+	}
+	if !isMilestone {
+		if tasks, err := js.loadAllTasks(); err == nil {
+			isMilestone = len(childrenByParent(tasks)[task.ID]) > 0
+		}
+	}
+	if isMilestone {
+		category = "milestone"
+	}
+	if category == "" {
+		return nil
+	}
+
+	source := "task:" + task.ID
+	_, err := js.addBragItem(BragItem{
+		ID:       bragID(source),
+		Date:     time.Now().Format("2006-01-02"),
+		Category: category,
+		Text:     fmt.Sprintf("Completed %s: %s", task.ID, task.Title),
+		TaskID:   task.ID,
+		Source:   source,
+		Created:  time.Now(),
+	})
+	return err
+}
+
+// bragPraise records received feedback that reads as positive
+func (js *JournalService) bragPraise(item FeedbackItem) error {
+	if item.Direction != "received" {
+		return nil
+	}
+	if score, ok := sentimentScore(item.Content); !ok || score <= 0 {
+		return nil
+	}
+	text := item.Content
+	if item.Person != "" {
+		text = fmt.Sprintf("%s (from %s)", item.Content, item.Person)
+	}
+	source := "feedback:" + item.ID
+	_, err := js.addBragItem(BragItem{
+		ID:       bragID(source),
+		Date:     item.Date,
+		Category: "praise",
+		Text:     text,
+		Source:   source,
+		Created:  time.Now(),
+	})
+	return err
+}
+
+// AddBragItem adds an accomplishment to the brag document by hand
+func (js *JournalService) AddBragItem(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	text, err := request.RequireString("text")
+	if err != nil {
+		return mcp.NewToolResultError("text is required"), nil
+	}
+
+	date := request.GetString("date", time.Now().Format("2006-01-02"))
+	if validationErr := js.validateDateFormat(date, "date"); validationErr != nil {
+		return mcp.NewToolResultError(validationErr.Error()), nil
+	}
+
+	category := request.GetString("category", "manual")
+	if _, ok := bragCategoryTitles[category]; !ok {
+		return mcp.NewToolResultError("category must be one of: completion, milestone, praise, manual"), nil
+	}
+
+	source := fmt.Sprintf("manual:%s:%s", date, text)
+	item := BragItem{
+		ID:       bragID(source),
+		Date:     date,
+		Category: category,
+		Text:     text,
+		TaskID:   request.GetString("task_id", ""),
+		Source:   "manual",
+		Created:  time.Now(),
+	}
+	added, err := js.addBragItem(item)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save brag document: %v", err)), nil
+	}
+	if !added {
+		return mcp.NewToolResultText(fmt.Sprintf("Already in the brag document (%s)", item.ID)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Added %s to the brag document", item.ID)), nil
+}
+
+// EditBragItem rewrites, recategorizes or removes a brag document item
+func (js *JournalService) EditBragItem(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, err := request.RequireString("id")
+	if err != nil {
+		return mcp.NewToolResultError("id is required"), nil
+	}
+
+	defer lockFile(js.bragPath())()
+	items, err := js.loadBragItems()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load brag document: %v", err)), nil
+	}
+
+	index := -1
+	for i, item := range items {
+		if item.ID == id {
+			index = i
+		}
+	}
+	if index < 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Brag item not found: %s", id)), nil
+	}
+
+	message := fmt.Sprintf("Updated brag item %s", id)
+	if request.GetString("delete", "false") == "true" {
+		// Keep a tombstone so automatic sources don't add it back
+		items[index].Category = "deleted"
+		message = fmt.Sprintf("Removed brag item %s", id)
+	} else {
+		if text := request.GetString("text", ""); text != "" {
+			items[index].Text = text
+		}
+		if category := request.GetString("category", ""); category != "" {
+			if _, ok := bragCategoryTitles[category]; !ok {
+				return mcp.NewToolResultError("category must be one of: completion, milestone, praise, manual"), nil
+			}
+			items[index].Category = category
+		}
+		if date := request.GetString("date", ""); date != "" {
+			if validationErr := js.validateDateFormat(date, "date"); validationErr != nil {
+				return mcp.NewToolResultError(validationErr.Error()), nil
+			}
+			items[index].Date = date
+		}
+	}
+
+	if err := js.saveBragItems(items); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save brag document: %v", err)), nil
+	}
+	return mcp.NewToolResultText(message), nil
+}
+
+// GetBragDoc renders the brag document by quarter, optionally writing it to a file
+func (js *JournalService) GetBragDoc(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	items, err := js.loadBragItems()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load brag document: %v", err)), nil
+	}
+
+	dateFrom := request.GetString("date_from", "")
+	dateTo := request.GetString("date_to", "")
+	var selected []BragItem
+	for _, item := range items {
+		if item.Category == "deleted" || dateFrom != "" && item.Date < dateFrom || dateTo != "" && item.Date > dateTo {
+			continue
+		}
+		selected = append(selected, item)
+	}
+
+	var output string
+	switch format := request.GetString("format", "markdown"); format {
+	case "json":
+		if selected == nil {
+			selected = []BragItem{}
+		}
+		data, _ := json.MarshalIndent(selected, "", "  ")
+		output = string(data)
+	case "markdown":
+		if output, err = js.styleMarkdown(request.GetString("style", ""), formatBragDoc(selected)); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	case "pandoc", "docbook":
+		meta := js.documentMeta(request, "Brag Document", dateFrom, dateTo)
+		if output, err = renderStructured(format, meta, formatBragDoc(selected)); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to render %s: %v", format, err)), nil
+		}
+	default:
+		return mcp.NewToolResultError("format must be markdown, json, pandoc or docbook"), nil
+	}
+
+	if path := request.GetString("output_path", ""); path != "" {
+		if err := writeFileAtomic(path, []byte(output), 0644); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write brag document: %v", err)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Wrote %d brag items to %s", len(selected), path)), nil
+	}
+	return mcp.NewToolResultText(output), nil
+}
+
+// formatBragDoc groups items by quarter, newest first, then by category
+func formatBragDoc(items []BragItem) string {
+	var md strings.Builder
+	md.WriteString("# Brag Document\n\n")
+	if len(items) == 0 {
+		md.WriteString("Nothing recorded yet. Completing high-priority tasks or milestones, receiving praise, or add_brag_item will add entries.\n")
+		return md.String()
+	}
+
+	byQuarter := make(map[string][]BragItem)
+	for _, item := range items {
+		byQuarter[quarterOf(item.Date)] = append(byQuarter[quarterOf(item.Date)], item)
+	}
+	quarters := sortedKeys(byQuarter)
+	for i := len(quarters) - 1; i >= 0; i-- {
+		md.WriteString(fmt.Sprintf("## %s\n\n", quarters[i]))
+		for _, category := range []string{"milestone", "completion", "praise", "manual"} {
+			var lines []string
+			for _, item := range byQuarter[quarters[i]] {
+				if item.Category == category {
+					lines = append(lines, fmt.Sprintf("- %s %s _(%s)_\n", item.Date, item.Text, item.ID))
+				}
+			}
+			if len(lines) == 0 {
+				continue
+			}
+			md.WriteString(fmt.Sprintf("### %s\n", bragCategoryTitles[category]))
+			md.WriteString(strings.Join(lines, ""))
+			md.WriteString("\n")
+		}
+	}
+	return md.String()
+}
@@ -0,0 +1,70 @@
+package servers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestBragDocAutoMaintenance(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	js.CreateTask(ctx, CreateMockRequest(map[string]interface{}{"id": "HOT-1", "title": "Fix outage", "type": "work", "priority": "urgent"}))
+	js.CreateTask(ctx, CreateMockRequest(map[string]interface{}{"id": "LOW-1", "title": "Tidy docs", "type": "work", "priority": "low"}))
+	js.CreateTask(ctx, CreateMockRequest(map[string]interface{}{"id": "REL-1", "title": "Ship v2", "type": "work", "tags": []interface{}{"milestone"}}))
+	for _, id := range []string{"HOT-1", "LOW-1", "REL-1"} {
+		js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": id, "status": "completed"}))
+	}
+	// Reopening and completing again does not add a second item
+	js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": "HOT-1", "status": "active"}))
+	js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": "HOT-1", "status": "completed"}))
+
+	js.AddFeedback(ctx, CreateMockRequest(map[string]interface{}{"content": "Great work on the migration", "person": "Dana"}))
+	js.AddFeedback(ctx, CreateMockRequest(map[string]interface{}{"content": "Updates were confusing"}))
+
+	items, _ := js.loadBragItems()
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 brag items, got %+v", items)
+	}
+	categories := map[string]string{}
+	for _, item := range items {
+		categories[item.Category] = item.Text
+	}
+	if !strings.Contains(categories["completion"], "HOT-1") || !strings.Contains(categories["milestone"], "REL-1") ||
+		categories["praise"] != "Great work on the migration (from Dana)" {
+		t.Errorf("Unexpected brag items: %+v", categories)
+	}
+
+	js.AddBragItem(ctx, CreateMockRequest(map[string]interface{}{"text": "Gave a talk on tracing", "date": "2026-01-15"}))
+	result, _ := js.EditBragItem(ctx, CreateMockRequest(map[string]interface{}{"id": bragID("task:REL-1"), "text": "Led the v2 launch"}))
+	if result.IsError {
+		t.Fatalf("edit_brag_item failed: %v", result.Content)
+	}
+	js.EditBragItem(ctx, CreateMockRequest(map[string]interface{}{"id": bragID("task:HOT-1"), "delete": "true"}))
+
+	outPath := filepath.Join(tempDir, "brag.md")
+	js.GetBragDoc(ctx, CreateMockRequest(map[string]interface{}{"output_path": outPath}))
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("Expected exported brag doc: %v", err)
+	}
+	doc := string(data)
+	for _, want := range []string{"## 2026-Q1", "### Other highlights", "Gave a talk on tracing", "### Milestones", "Led the v2 launch", "### Praise"} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("Brag doc missing %q:\n%s", want, doc)
+		}
+	}
+	if strings.Contains(doc, "HOT-1") {
+		t.Errorf("Deleted item should not be exported:\n%s", doc)
+	}
+
+	result, _ = js.GetBragDoc(ctx, CreateMockRequest(map[string]interface{}{"date_to": "2026-03-31", "format": "json"}))
+	if text := result.Content[0].(mcp.TextContent).Text; strings.Contains(text, "v2") || !strings.Contains(text, "tracing") {
+		t.Errorf("Expected date filter to keep only the January item: %s", text)
+	}
+}
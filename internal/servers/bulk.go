@@ -0,0 +1,221 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// bulkOperations are the operations bulk_update_tasks applies to each matching task
+var bulkOperations = []string{"set_status", "add_tag", "remove_tag", "set_priority", "archive"}
+
+// bulkFilterKeys select the tasks, with the same meaning as in list_tasks
+var bulkFilterKeys = []string{"status", "type", "tags", "date_from", "date_to"}
+
+// BulkUpdateResult summarizes a bulk_update_tasks call
+type BulkUpdateResult struct {
+	Operation string           `json:"operation"`
+	Value     string           `json:"value,omitempty"`
+	DryRun    bool             `json:"dry_run,omitempty"`
+	Matched   int              `json:"matched"`
+	Updated   []BulkTaskChange `json:"updated"`
+	Unchanged []string         `json:"unchanged,omitempty"` // task IDs the operation would not change
+	Skipped   []BulkTaskChange `json:"skipped,omitempty"`   // tasks left alone, with the reason
+	Summary   string           `json:"summary"`
+}
+
+// BulkTaskChange describes what happened to one task
+type BulkTaskChange struct {
+	TaskID string `json:"task_id"`
+	Title  string `json:"title"`
+	Change string `json:"change"`
+}
+
+// applyBulkOperation changes a task in memory and describes the change, or
+// returns "" when the task already matches. Archiving is done by the caller.
+func applyBulkOperation(task *Task, operation, value string) string {
+	switch operation {
+	case "set_status":
+		if task.Status == value {
+			return ""
+		}
+		change := fmt.Sprintf("Status changed from %s to %s", task.Status, value)
+		task.Status = value
+		return change
+	case "add_tag":
+		if slices.Contains(task.Tags, value) {
+			return ""
+		}
+		task.Tags = append(task.Tags, value)
+		return fmt.Sprintf("Task updated: added tag %s", value)
+	case "remove_tag":
+		if !slices.Contains(task.Tags, value) {
+			return ""
+		}
+		task.Tags = slices.DeleteFunc(task.Tags, func(tag string) bool { return tag == value })
+		return fmt.Sprintf("Task updated: removed tag %s", value)
+	case "set_priority":
+		if task.Priority == value {
+			return ""
+		}
+		change := fmt.Sprintf("Task updated: priority: %s -> %s", task.Priority, value)
+		task.Priority = value
+		return change
+	case "archive":
+		return "Archived"
+	}
+	return ""
+}
+
+// BulkUpdateTasks applies one operation to every task matching a filter
+func (js *JournalService) BulkUpdateTasks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	operation, err := request.RequireString("operation")
+	if err != nil {
+		return mcp.NewToolResultError("operation is required"), nil
+	}
+	if !slices.Contains(bulkOperations, operation) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid operation %q. Must be: %s", operation, strings.Join(bulkOperations, ", "))), nil
+	}
+
+	value := strings.TrimSpace(request.GetString("value", ""))
+	switch operation {
+	case "set_status":
+		if !slices.Contains([]string{"active", "completed", "paused", "blocked", "someday"}, value) {
+			return mcp.NewToolResultError("set_status needs value: active, completed, paused, blocked or someday"), nil
+		}
+	case "set_priority":
+		if !slices.Contains([]string{"low", "medium", "high", "urgent"}, value) {
+			return mcp.NewToolResultError("set_priority needs value: low, medium, high or urgent"), nil
+		}
+	case "add_tag", "remove_tag":
+		if value == "" {
+			return mcp.NewToolResultError(operation + " needs value: the tag"), nil
+		}
+	}
+
+	// Only the filter keys reach filterTasks. An empty filter would touch every
+	// task, which is rarely what a cleanup means.
+	filters := make(map[string]interface{})
+	for key, v := range request.GetArguments() {
+		if slices.Contains(bulkFilterKeys, key) && v != nil && v != "" {
+			filters[key] = v
+		}
+	}
+	if len(filters) == 0 {
+		return mcp.NewToolResultError("At least one filter is required: " + strings.Join(bulkFilterKeys, ", ")), nil
+	}
+	for _, key := range []string{"date_from", "date_to"} {
+		if date := request.GetString(key, ""); date != "" {
+			if err := js.validateDateFormat(date, key); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+		}
+	}
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+	matched := js.filterTasks(tasks, filters)
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+
+	dryRun := request.GetString("dry_run", "false") == "true"
+	force := request.GetString("force", "false") == "true"
+	result := BulkUpdateResult{Operation: operation, Value: value, DryRun: dryRun, Matched: len(matched), Updated: []BulkTaskChange{}}
+
+	for _, match := range matched {
+		if message := js.bulkUpdateTask(match.ID, operation, value, dryRun, force, &result); message != "" {
+			result.Skipped = append(result.Skipped, BulkTaskChange{TaskID: match.ID, Title: match.Title, Change: message})
+		}
+	}
+
+	verb := "Updated"
+	if dryRun {
+		verb = "Would update"
+	}
+	result.Summary = fmt.Sprintf("%s %d of %d matching tasks (%s", verb, len(result.Updated), result.Matched, operation)
+	if value != "" {
+		result.Summary += " " + value
+	}
+	result.Summary += ")"
+	if len(result.Unchanged) > 0 {
+		result.Summary += fmt.Sprintf("; %d already matched", len(result.Unchanged))
+	}
+	if len(result.Skipped) > 0 {
+		result.Summary += fmt.Sprintf("; %d skipped", len(result.Skipped))
+	}
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// bulkUpdateTask applies the operation to one task under its lock, recording
+// the outcome in result. It returns a reason when the task was skipped.
+func (js *JournalService) bulkUpdateTask(taskID, operation, value string, dryRun, force bool, result *BulkUpdateResult) string {
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return fmt.Sprintf("failed to load: %v", err)
+	}
+
+	// Completing a task with open dependencies needs the same override as update_task_status
+	if operation == "set_status" && value == "completed" && task.Status != "completed" && !force {
+		if open := js.openDependencies(task); len(open) > 0 {
+			var ids []string
+			for _, dep := range open {
+				ids = append(ids, dep.ID)
+			}
+			return fmt.Sprintf("open dependencies: %s (pass force=true to complete anyway)", strings.Join(ids, ", "))
+		}
+	}
+
+	oldStatus := task.Status
+	change := applyBulkOperation(task, operation, value)
+	if change == "" {
+		result.Unchanged = append(result.Unchanged, task.ID)
+		return ""
+	}
+	if dryRun {
+		result.Updated = append(result.Updated, BulkTaskChange{TaskID: task.ID, Title: task.Title, Change: change})
+		return ""
+	}
+
+	if operation == "archive" {
+		if err := js.archiveTask(task, "bulk update"); err != nil {
+			return err.Error()
+		}
+		result.Updated = append(result.Updated, BulkTaskChange{TaskID: task.ID, Title: task.Title, Change: change})
+		return ""
+	}
+
+	entryType := "update"
+	if operation == "set_status" {
+		entryType = "status_change"
+	}
+	entry := Entry{
+		ID:        generateEntryID(),
+		Timestamp: time.Now(),
+		Content:   change + " (bulk update)",
+		Type:      entryType,
+	}
+	task.Entries = append(task.Entries, entry)
+	task.Updated = time.Now()
+
+	if err := js.saveTask(task); err != nil {
+		return fmt.Sprintf("failed to save: %v", err)
+	}
+	js.updateDailyLog(task.ID, entry)
+	if task.Status == "completed" && oldStatus != "completed" {
+		js.bragCompletion(task)
+	}
+
+	result.Updated = append(result.Updated, BulkTaskChange{TaskID: task.ID, Title: task.Title, Change: change})
+	return ""
+}
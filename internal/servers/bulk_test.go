@@ -0,0 +1,85 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func bulkUpdate(t *testing.T, js *JournalService, args map[string]interface{}) BulkUpdateResult {
+	t.Helper()
+	result, _ := js.BulkUpdateTasks(context.Background(), CreateMockRequest(args))
+	var bulk BulkUpdateResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &bulk); err != nil {
+		t.Fatalf("Unexpected bulk result: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	return bulk
+}
+
+func TestBulkUpdateTasks(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	for _, id := range []string{"OLD-1", "OLD-2", "OLD-3"} {
+		js.CreateTask(ctx, CreateMockRequest(map[string]interface{}{"id": id, "title": "Stale " + id, "type": "work", "tags": []interface{}{"legacy"}}))
+	}
+	createTestTask(t, js, "NEW-1", "Fresh", "learning")
+
+	// Filters are required, and operations are validated
+	for _, args := range []map[string]interface{}{
+		{"operation": "add_tag", "value": "x"},
+		{"operation": "explode", "type": "work"},
+		{"operation": "set_status", "value": "done", "type": "work"},
+	} {
+		if result, _ := js.BulkUpdateTasks(ctx, CreateMockRequest(args)); !result.IsError {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+
+	preview := bulkUpdate(t, js, map[string]interface{}{"operation": "set_status", "value": "paused", "tags": []interface{}{"legacy"}, "dry_run": "true"})
+	if preview.Matched != 3 || len(preview.Updated) != 3 {
+		t.Errorf("Expected three tasks in the preview, got %+v", preview)
+	}
+	if task, _ := js.loadTask("OLD-1"); task.Status != "active" {
+		t.Error("Dry run must not change tasks")
+	}
+
+	paused := bulkUpdate(t, js, map[string]interface{}{"operation": "set_status", "value": "paused", "tags": []interface{}{"legacy"}})
+	task, _ := js.loadTask("OLD-2")
+	if len(paused.Updated) != 3 || task.Status != "paused" || task.Entries[len(task.Entries)-1].Type != "status_change" {
+		t.Errorf("Expected the legacy tasks paused with a status entry, got %+v", paused)
+	}
+
+	tagged := bulkUpdate(t, js, map[string]interface{}{"operation": "add_tag", "value": "cleanup", "status": "paused"})
+	again := bulkUpdate(t, js, map[string]interface{}{"operation": "add_tag", "value": "cleanup", "status": "paused"})
+	if len(tagged.Updated) != 3 || len(again.Updated) != 0 || len(again.Unchanged) != 3 {
+		t.Errorf("Expected tags added once, got %+v then %+v", tagged, again)
+	}
+	bulkUpdate(t, js, map[string]interface{}{"operation": "remove_tag", "value": "legacy", "type": "work"})
+	if task, _ := js.loadTask("OLD-3"); slices.Contains(task.Tags, "legacy") || !slices.Contains(task.Tags, "cleanup") {
+		t.Errorf("Expected legacy removed and cleanup kept, got %v", task.Tags)
+	}
+
+	// Open dependencies hold back completion unless forced
+	js.AddTaskDependency(ctx, CreateMockRequest(map[string]interface{}{"task_id": "OLD-1", "depends_on": "NEW-1"}))
+	completed := bulkUpdate(t, js, map[string]interface{}{"operation": "set_status", "value": "completed", "status": "paused"})
+	if len(completed.Updated) != 2 || len(completed.Skipped) != 1 || completed.Skipped[0].TaskID != "OLD-1" {
+		t.Errorf("Expected OLD-1 skipped for its dependency, got %+v", completed)
+	}
+
+	archived := bulkUpdate(t, js, map[string]interface{}{"operation": "archive", "status": "completed"})
+	if len(archived.Updated) != 2 || !strings.Contains(archived.Summary, "2 of 2") {
+		t.Errorf("Expected two tasks archived, got %+v", archived)
+	}
+	if _, err := js.loadTask("OLD-2"); err == nil {
+		t.Error("Expected archived tasks to leave task storage")
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "archived", "OLD-2.json")); err != nil {
+		t.Errorf("Expected the task in archived/: %v", err)
+	}
+}
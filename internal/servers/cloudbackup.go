@@ -0,0 +1,299 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// BackupDestination is an off-machine location that backups are copied to
+type BackupDestination interface {
+	String() string // the destination URI, without credentials
+	Put(name string, data []byte) error
+	Get(name string) ([]byte, error)
+	Delete(name string) error
+}
+
+// parseBackupDestination checks a destination URI: s3://bucket/prefix,
+// gs://bucket/prefix, webdav://host/path (HTTPS) or webdav+http://host/path
+func parseBackupDestination(uri string) (*url.URL, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid backup destination: %s (expected s3://bucket/prefix, gs://bucket/prefix or webdav://host/path)", uri)
+	}
+	switch u.Scheme {
+	case "s3", "gs", "webdav", "webdav+http":
+		return u, nil
+	default:
+		return nil, fmt.Errorf("unsupported backup destination scheme: %s (expected s3, gs, webdav or webdav+http)", u.Scheme)
+	}
+}
+
+// backupDestination resolves a destination URI with the credentials in the backup section of config.yaml
+func (js *JournalService) backupDestination(uri string) (BackupDestination, error) {
+	u, err := parseBackupDestination(uri)
+	if err != nil {
+		return nil, err
+	}
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	secret := func(value, env string) string {
+		resolved, _ := js.resolveSecretRef(value)
+		if resolved == "" && env != "" {
+			resolved = os.Getenv(env)
+		}
+		return resolved
+	}
+
+	switch u.Scheme {
+	case "s3":
+		s3 := config.Backup.S3
+		region := s3.Region
+		if region == "" {
+			region = "us-east-1"
+		}
+		endpoint := s3.Endpoint
+		if endpoint == "" {
+			endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+		}
+		endpointURL, err := url.Parse(endpoint)
+		if err != nil || endpointURL.Host == "" {
+			return nil, fmt.Errorf("invalid backup.s3.endpoint: %s", endpoint)
+		}
+		accessKey := secret(s3.AccessKeyID, "AWS_ACCESS_KEY_ID")
+		secretKey := secret(s3.SecretAccessKey, "AWS_SECRET_ACCESS_KEY")
+		if accessKey == "" || secretKey == "" {
+			return nil, fmt.Errorf("S3 credentials missing: set backup.s3.access_key_id/secret_access_key or AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY")
+		}
+		return newS3Destination(uri, newS3Client(endpointURL, region, u.Host, u.Path, accessKey, secretKey, s3.PathStyle)), nil
+
+	case "gs":
+		accessKey := secret(config.Backup.GCS.AccessKeyID, "")
+		secretKey := secret(config.Backup.GCS.Secret, "")
+		if accessKey == "" || secretKey == "" {
+			return nil, fmt.Errorf("GCS credentials missing: set backup.gcs.access_key_id and backup.gcs.secret to an HMAC key")
+		}
+		endpoint, _ := url.Parse("https://storage.googleapis.com")
+		return newS3Destination(uri, newS3Client(endpoint, "auto", u.Host, u.Path, accessKey, secretKey, true)), nil
+
+	default:
+		base := *u
+		base.Scheme = "https"
+		if u.Scheme == "webdav+http" {
+			base.Scheme = "http"
+		}
+		base.Path = strings.TrimSuffix(base.Path, "/") + "/"
+		return &webdavDestination{
+			uri:      uri,
+			base:     &base,
+			username: secret(config.Backup.WebDAV.Username, ""),
+			password: secret(config.Backup.WebDAV.Password, ""),
+			client:   &http.Client{Timeout: 5 * time.Minute},
+		}, nil
+	}
+}
+
+// s3Destination stores backups in an S3 bucket, or a GCS bucket through its
+// S3-compatible XML API
+type s3Destination struct {
+	uri    string
+	client *s3Storage
+}
+
+// newS3Destination allows for backups that take longer to upload than task objects
+func newS3Destination(uri string, client *s3Storage) *s3Destination {
+	client.client.Timeout = 5 * time.Minute
+	return &s3Destination{uri: uri, client: client}
+}
+
+func (sd *s3Destination) String() string { return sd.uri }
+
+func (sd *s3Destination) Put(name string, data []byte) error {
+	return sd.client.putObject(sd.client.prefix+name, data)
+}
+
+func (sd *s3Destination) Get(name string) ([]byte, error) {
+	return sd.client.getObject(sd.client.prefix + name)
+}
+
+func (sd *s3Destination) Delete(name string) error {
+	return sd.client.deleteObject(sd.client.prefix + name)
+}
+
+// webdavDestination stores backups in a WebDAV collection (Nextcloud,
+// ownCloud, Apache mod_dav...), creating it on first upload
+type webdavDestination struct {
+	uri      string
+	base     *url.URL // collection URL, ending in /
+	username string
+	password string
+	client   *http.Client
+}
+
+func (wd *webdavDestination) String() string { return wd.uri }
+
+func (wd *webdavDestination) do(method string, u *url.URL, body []byte) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(context.Background(), method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	if wd.username != "" {
+		req.SetBasicAuth(wd.username, wd.password)
+	}
+	resp, err := wd.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	return resp, data, err
+}
+
+func (wd *webdavDestination) fileURL(name string) *url.URL {
+	u := *wd.base
+	u.Path += name
+	return &u
+}
+
+// ensureCollection creates each missing collection on the base path. Servers
+// answer 405 for collections that already exist.
+func (wd *webdavDestination) ensureCollection() error {
+	u := *wd.base
+	path := ""
+	for _, segment := range strings.Split(strings.Trim(wd.base.Path, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		path += "/" + segment
+		u.Path = path + "/"
+		resp, _, err := wd.do("MKCOL", &u, nil)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode >= 300 && resp.StatusCode != http.StatusMethodNotAllowed {
+			return fmt.Errorf("WebDAV MKCOL %s failed: %s", u.Path, resp.Status)
+		}
+	}
+	return nil
+}
+
+// Put uploads a file, creating the collection if the server reports it missing
+func (wd *webdavDestination) Put(name string, data []byte) error {
+	resp, body, err := wd.do(http.MethodPut, wd.fileURL(name), data)
+	if err == nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusConflict) {
+		if err := wd.ensureCollection(); err != nil {
+			return err
+		}
+		resp, body, err = wd.do(http.MethodPut, wd.fileURL(name), data)
+	}
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("WebDAV PUT %s failed: %s: %s", name, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func (wd *webdavDestination) Get(name string) ([]byte, error) {
+	resp, body, err := wd.do(http.MethodGet, wd.fileURL(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, &os.PathError{Op: "get", Path: name, Err: os.ErrNotExist}
+	case resp.StatusCode >= 300:
+		return nil, fmt.Errorf("WebDAV GET %s failed: %s", name, resp.Status)
+	}
+	return body, nil
+}
+
+func (wd *webdavDestination) Delete(name string) error {
+	resp, _, err := wd.do(http.MethodDelete, wd.fileURL(name), nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("WebDAV DELETE %s failed: %s", name, resp.Status)
+	}
+	return nil
+}
+
+// uploadBackup copies a local backup file to the destination and returns its remote location
+func uploadBackup(destination BackupDestination, backupPath string) (string, error) {
+	data, err := os.ReadFile(backupPath)
+	if err != nil {
+		return "", err
+	}
+	name := filepath.Base(backupPath)
+	if err := destination.Put(name, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(destination.String(), "/") + "/" + name, nil
+}
+
+// checkBackupDestination writes, reads back and deletes a small probe file
+func checkBackupDestination(destination BackupDestination) error {
+	name := fmt.Sprintf(".journal-mcp-probe-%d.txt", time.Now().UnixNano())
+	probe := []byte("journal-mcp backup destination check " + time.Now().Format(time.RFC3339))
+
+	if err := destination.Put(name, probe); err != nil {
+		return fmt.Errorf("write failed: %w", err)
+	}
+	data, err := destination.Get(name)
+	if err != nil {
+		return fmt.Errorf("read back failed: %w", err)
+	}
+	if !bytes.Equal(data, probe) {
+		return fmt.Errorf("read back %d bytes that differ from the %d written", len(data), len(probe))
+	}
+	if err := destination.Delete(name); err != nil {
+		return fmt.Errorf("delete failed: %w", err)
+	}
+	return nil
+}
+
+// TestBackupDestination checks that a backup destination accepts, returns and deletes files
+func (js *JournalService) TestBackupDestination(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	uri := request.GetString("destination", "")
+	if uri == "" {
+		if config, err := js.loadConfiguration(); err == nil {
+			uri = config.Backup.Destination
+		}
+	}
+	if uri == "" {
+		return mcp.NewToolResultError("No destination: pass destination or set backup.destination"), nil
+	}
+
+	destination, err := js.backupDestination(uri)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	start := time.Now()
+	err = checkBackupDestination(destination)
+	result := map[string]interface{}{
+		"destination": destination.String(),
+		"ok":          err == nil,
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result["error"] = err.Error()
+	}
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
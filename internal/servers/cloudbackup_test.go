@@ -0,0 +1,156 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// fakeWebDAV is a minimal WebDAV server that requires parent collections to exist
+type fakeWebDAV struct {
+	mu          sync.Mutex
+	collections map[string]bool
+	files       map[string][]byte
+}
+
+func (f *fakeWebDAV) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, pass, ok := r.BasicAuth(); !ok || user != "dana" || pass != "s3cret" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	name := strings.TrimSuffix(r.URL.Path, "/")
+	parent := path.Dir(name)
+	switch r.Method {
+	case "MKCOL":
+		if f.collections[name] {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if parent != "/" && !f.collections[parent] {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.collections[name] = true
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodPut:
+		if parent != "/" && !f.collections[parent] {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.files[name], _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet:
+		data, ok := f.files[name]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	case http.MethodDelete:
+		delete(f.files, name)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestParseBackupDestination(t *testing.T) {
+	for uri, valid := range map[string]bool{
+		"s3://journal/backups":        true,
+		"gs://journal":                true,
+		"webdav://cloud.example/dav":  true,
+		"webdav+http://nas.local/dav": true,
+		"ftp://example.com/backups":   false,
+		"s3:///no-bucket":             false,
+		"/home/dana/journal-backups":  false,
+	} {
+		if _, err := parseBackupDestination(uri); (err == nil) != valid {
+			t.Errorf("parseBackupDestination(%q) error = %v, expected valid=%v", uri, err, valid)
+		}
+	}
+}
+
+func TestS3BackupDestination(t *testing.T) {
+	fake := &fakeS3{objects: make(map[string][]byte)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "TASK-1", "Backed up off-machine", "work")
+
+	config := defaultConfiguration()
+	config.Backup.Destination = "s3://journal/laptop"
+	config.Backup.S3.Endpoint = server.URL
+	config.Backup.S3.AccessKeyID = "test-key"
+	config.Backup.S3.SecretAccessKey = "test-secret"
+	config.Backup.S3.PathStyle = true
+	js.saveConfiguration(config)
+
+	result, _ := js.TestBackupDestination(ctx, CreateMockRequest(map[string]interface{}{}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, `"ok": true`) || len(fake.objects) != 0 {
+		t.Errorf("Expected a passing check that cleans up after itself, got %s (%d objects)", text, len(fake.objects))
+	}
+
+	result, _ = js.CreateDataBackup(ctx, CreateMockRequest(map[string]interface{}{}))
+	var backup BackupResult
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &backup)
+	if backup.UploadError != "" || !strings.HasPrefix(backup.UploadedTo, "s3://journal/laptop/"+backupPrefix) {
+		t.Fatalf("Expected an uploaded backup, got %+v", backup)
+	}
+	local, _ := os.ReadFile(backup.BackupPath)
+	remote := fake.objects["/journal/laptop/"+strings.TrimPrefix(backup.UploadedTo, "s3://journal/laptop/")]
+	if len(remote) == 0 || string(remote) != string(local) {
+		t.Error("Expected the remote copy to match the local backup")
+	}
+}
+
+func TestWebDAVBackupDestination(t *testing.T) {
+	fake := &fakeWebDAV{collections: make(map[string]bool), files: make(map[string][]byte)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	t.Setenv(secretEnvName("webdav_password"), "s3cret")
+
+	config := defaultConfiguration()
+	config.Backup.WebDAV.Username = "dana"
+	config.Backup.WebDAV.Password = secretRefPrefix + "webdav_password"
+	js.saveConfiguration(config)
+
+	destination := "webdav+http://" + strings.TrimPrefix(server.URL, "http://") + "/dav/journal"
+	result, _ := js.CreateDataBackup(ctx, CreateMockRequest(map[string]interface{}{"destination": destination}))
+	var backup BackupResult
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &backup)
+	if backup.UploadError != "" || !fake.collections["/dav/journal"] {
+		t.Fatalf("Expected the collection to be created and the backup uploaded, got %+v", backup)
+	}
+	if len(fake.files) != 1 {
+		t.Errorf("Expected one uploaded file, got %d", len(fake.files))
+	}
+
+	// The local backup survives a failed upload
+	config.Backup.WebDAV.Password = "wrong"
+	js.saveConfiguration(config)
+	result, _ = js.CreateDataBackup(ctx, CreateMockRequest(map[string]interface{}{"destination": destination}))
+	backup = BackupResult{}
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &backup)
+	if !strings.Contains(backup.UploadError, "401") {
+		t.Errorf("Expected an upload error, got %+v", backup)
+	}
+	if _, err := os.Stat(backup.BackupPath); err != nil {
+		t.Errorf("Expected the local backup to be kept: %v", err)
+	}
+}
@@ -2,13 +2,20 @@ package servers
 
 import (
 	"archive/zip"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -23,8 +30,54 @@ type Configuration struct {
 		Repositories []string `json:"repositories,omitempty" yaml:"repositories,omitempty"`
 		AutoSync     bool     `json:"auto_sync" yaml:"auto_sync"`
 		SyncInterval int      `json:"sync_interval_minutes" yaml:"sync_interval_minutes"`
+		AuthMode     string   `json:"auth_mode,omitempty" yaml:"auth_mode,omitempty"` // "token" (default) or "app"
+
+		// IssueFields maps issue form headings to a task field name or one of
+		// checklist, tags, priority, entry, ignore
+		IssueFields map[string]string `json:"issue_fields,omitempty" yaml:"issue_fields,omitempty"`
+
+		App struct {
+			AppID          int64  `json:"app_id,omitempty" yaml:"app_id,omitempty"`
+			InstallationID int64  `json:"installation_id,omitempty" yaml:"installation_id,omitempty"`
+			PrivateKeyPath string `json:"private_key_path,omitempty" yaml:"private_key_path,omitempty"` // default: the github_app_private_key secret
+		} `json:"app" yaml:"app"`
 	} `json:"github" yaml:"github"`
 
+	GitLab struct {
+		BaseURL  string   `json:"base_url,omitempty" yaml:"base_url,omitempty"` // default: https://gitlab.com
+		Projects []string `json:"projects,omitempty" yaml:"projects,omitempty"` // group/project paths; empty syncs everything assigned to you
+	} `json:"gitlab" yaml:"gitlab"`
+
+	Jira struct {
+		BaseURL  string   `json:"base_url,omitempty" yaml:"base_url,omitempty"` // e.g. https://yourcompany.atlassian.net
+		Email    string   `json:"email,omitempty" yaml:"email,omitempty"`       // Jira Cloud account; empty uses a bearer personal access token
+		Projects []string `json:"projects,omitempty" yaml:"projects,omitempty"`
+		JQL      string   `json:"jql,omitempty" yaml:"jql,omitempty"` // overrides the default assigned-and-unresolved search
+	} `json:"jira" yaml:"jira"`
+
+	// Team is the people registry used by manager-mode reports
+	Team struct {
+		Members []TeamMember `json:"members,omitempty" yaml:"members,omitempty"`
+		Redact  string       `json:"redact,omitempty" yaml:"redact,omitempty"` // default for get_team_rollup: none, content or names
+	} `json:"team" yaml:"team"`
+
+	// Markdown selects the dialect of generated markdown (task views, logs, exports, reports)
+	Markdown struct {
+		Style  string                   `json:"style,omitempty" yaml:"style,omitempty"`   // default, obsidian, confluence or a name from styles
+		Styles map[string]MarkdownStyle `json:"styles,omitempty" yaml:"styles,omitempty"` // custom styles; may override a built-in name
+	} `json:"markdown" yaml:"markdown"`
+
+	// TagRules tag tasks whose titles or entries match a pattern
+	TagRules []TagRule `json:"tag_rules,omitempty" yaml:"tag_rules,omitempty"`
+
+	// Quotas are soft daily or weekly targets per task type, reported as balance in analytics
+	Quotas []TypeQuota `json:"quotas,omitempty" yaml:"quotas,omitempty"`
+
+	StackExchange struct {
+		UserID int64  `json:"user_id,omitempty" yaml:"user_id,omitempty"`
+		Site   string `json:"site,omitempty" yaml:"site,omitempty"` // default: stackoverflow
+	} `json:"stack_exchange" yaml:"stack_exchange"`
+
 	Web struct {
 		Enabled bool `json:"enabled" yaml:"enabled"`
 		Port    int  `json:"port" yaml:"port"`
@@ -35,13 +88,147 @@ type Configuration struct {
 		BackupInterval int    `json:"backup_interval_hours" yaml:"backup_interval_hours"`
 		BackupLocation string `json:"backup_location,omitempty" yaml:"backup_location,omitempty"`
 		MaxBackups     int    `json:"max_backups" yaml:"max_backups"`
+		Compression    string `json:"compression,omitempty" yaml:"compression,omitempty"` // none, default, maximum or zstd
+
+		// Destination also uploads every backup off-machine: s3://bucket/prefix,
+		// gs://bucket/prefix or webdav://host/path. Credential fields may hold secret: references.
+		Destination string `json:"destination,omitempty" yaml:"destination,omitempty"`
+
+		S3 struct {
+			Endpoint        string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"` // default: AWS for the region
+			Region          string `json:"region,omitempty" yaml:"region,omitempty"`
+			AccessKeyID     string `json:"access_key_id,omitempty" yaml:"access_key_id,omitempty"`         // default: AWS_ACCESS_KEY_ID
+			SecretAccessKey string `json:"secret_access_key,omitempty" yaml:"secret_access_key,omitempty"` // default: AWS_SECRET_ACCESS_KEY
+			PathStyle       bool   `json:"path_style,omitempty" yaml:"path_style,omitempty"`
+		} `json:"s3" yaml:"s3"`
+
+		// GCS uses the Cloud Storage XML API with HMAC keys
+		GCS struct {
+			AccessKeyID string `json:"access_key_id,omitempty" yaml:"access_key_id,omitempty"`
+			Secret      string `json:"secret,omitempty" yaml:"secret,omitempty"`
+		} `json:"gcs" yaml:"gcs"`
+
+		WebDAV struct {
+			Username string `json:"username,omitempty" yaml:"username,omitempty"`
+			Password string `json:"password,omitempty" yaml:"password,omitempty"`
+		} `json:"webdav" yaml:"webdav"`
 	} `json:"backup" yaml:"backup"`
 
+	// Encryption uses AES-256-GCM with a key derived from
+	// JOURNAL_MCP_ENCRYPTION_PASSPHRASE or the encryption_passphrase secret
+	Encryption struct {
+		Backups bool `json:"backups" yaml:"backups"` // encrypt backup archives
+		AtRest  bool `json:"at_rest" yaml:"at_rest"` // encrypt tasks, one-on-ones, daily logs and other journal files
+	} `json:"encryption" yaml:"encryption"`
+
 	General struct {
 		DefaultTaskType string `json:"default_task_type" yaml:"default_task_type"`
 		TimeZone        string `json:"timezone" yaml:"timezone"`
 		DateFormat      string `json:"date_format" yaml:"date_format"`
+		FocusLimit      int    `json:"focus_limit,omitempty" yaml:"focus_limit,omitempty"` // tasks shown by list_tasks focus=true (default 5)
+
+		// ReestimateFactor is how far tracked time may pass a task's estimate before a re-estimation prompt (default 1.5)
+		ReestimateFactor float64 `json:"reestimate_factor,omitempty" yaml:"reestimate_factor,omitempty"`
+
+		// StandupLookback is how many working days generate_standup reports as "yesterday" (default 1)
+		StandupLookback int `json:"standup_lookback,omitempty" yaml:"standup_lookback,omitempty"`
+
+		// WeekStart is the first day of the week for get_current_week_log and get_previous_week_log (default monday)
+		WeekStart string `json:"week_start,omitempty" yaml:"week_start,omitempty"`
 	} `json:"general" yaml:"general"`
+
+	Schedule struct {
+		DailySnapshot string `json:"daily_snapshot,omitempty" yaml:"daily_snapshot,omitempty"`   // HH:MM in general.timezone (default 07:00); "off" disables
+		AutoPauseDays int    `json:"auto_pause_days,omitempty" yaml:"auto_pause_days,omitempty"` // pause active tasks idle this many days; 0 disables
+		SomedayReview string `json:"someday_review,omitempty" yaml:"someday_review,omitempty"`   // weekday to resurface someday tasks (default monday); "off" disables
+		StreakNudge   string `json:"streak_nudge,omitempty" yaml:"streak_nudge,omitempty"`       // HH:MM to remind about an unbroken streak with no entry yet today (default 17:00); "off" disables
+	} `json:"schedule" yaml:"schedule"`
+
+	// Digest sends a summary of completions, upcoming due dates and blocked
+	// tasks to email, Slack or Discord channels
+	Digest struct {
+		Frequency  string          `json:"frequency,omitempty" yaml:"frequency,omitempty"`     // daily, weekly or off (default)
+		Time       string          `json:"time,omitempty" yaml:"time,omitempty"`               // HH:MM in general.timezone (default 08:00)
+		Weekday    string          `json:"weekday,omitempty" yaml:"weekday,omitempty"`         // weekly digests (default monday)
+		QuietHours string          `json:"quiet_hours,omitempty" yaml:"quiet_hours,omitempty"` // e.g. 22:00-07:00; deliveries wait until it ends
+		Channels   []DigestChannel `json:"channels,omitempty" yaml:"channels,omitempty"`
+
+		SMTP struct {
+			Host     string `json:"host,omitempty" yaml:"host,omitempty"`
+			Port     int    `json:"port,omitempty" yaml:"port,omitempty"` // default 587
+			Username string `json:"username,omitempty" yaml:"username,omitempty"`
+			Password string `json:"password,omitempty" yaml:"password,omitempty"` // may be a secret: reference
+			From     string `json:"from,omitempty" yaml:"from,omitempty"`
+		} `json:"smtp" yaml:"smtp"`
+	} `json:"digest" yaml:"digest"`
+
+	// Connectors add a nightly one-line summary from external tools to the daily log
+	Connectors struct {
+		Time    string      `json:"time,omitempty" yaml:"time,omitempty"` // HH:MM in general.timezone (default 23:30); "off" disables
+		Sources []Connector `json:"sources,omitempty" yaml:"sources,omitempty"`
+	} `json:"connectors" yaml:"connectors"`
+
+	// OnCall imports pages from your on-call shifts onto a task per rotation week
+	OnCall struct {
+		Provider  string   `json:"provider,omitempty" yaml:"provider,omitempty"`   // pagerduty or opsgenie; empty disables
+		UserID    string   `json:"user_id,omitempty" yaml:"user_id,omitempty"`     // PagerDuty user ID or Opsgenie username
+		Schedules []string `json:"schedules,omitempty" yaml:"schedules,omitempty"` // Opsgenie schedule names (required for opsgenie)
+		Token     string   `json:"token,omitempty" yaml:"token,omitempty"`         // API token or secret: reference (default the pagerduty_token or opsgenie_token secret)
+		BaseURL   string   `json:"base_url,omitempty" yaml:"base_url,omitempty"`   // Opsgenie API (default https://api.opsgenie.com; EU: https://api.eu.opsgenie.com)
+		AutoSync  bool     `json:"auto_sync,omitempty" yaml:"auto_sync,omitempty"` // import every hour while the server runs
+	} `json:"oncall" yaml:"oncall"`
+
+	// Watch follows the data directory for edits made by hand or by sync tools
+	Watch struct {
+		Enabled  bool     `json:"enabled,omitempty" yaml:"enabled,omitempty"`   // validate changed files and refresh the search index and SQLite mirror
+		Webhooks []string `json:"webhooks,omitempty" yaml:"webhooks,omitempty"` // URLs or secret: references that receive each change as a JSON POST
+	} `json:"watch" yaml:"watch"`
+
+	Obsidian struct {
+		Vault  string `json:"vault,omitempty" yaml:"vault,omitempty"`   // vault directory for export_to_obsidian
+		Folder string `json:"folder,omitempty" yaml:"folder,omitempty"` // folder inside the vault (default Journal)
+		Sync   bool   `json:"sync,omitempty" yaml:"sync,omitempty"`     // re-export every few minutes while the server runs
+	} `json:"obsidian" yaml:"obsidian"`
+
+	Secrets struct {
+		Provider string `json:"provider,omitempty" yaml:"provider,omitempty"` // "keyring" (default), "file" or "env"
+	} `json:"secrets" yaml:"secrets"`
+
+	Import struct {
+		DateOrder string `json:"date_order,omitempty" yaml:"date_order,omitempty"` // numeric dates in imported files: auto (default), dmy or mdy
+	} `json:"import" yaml:"import"`
+
+	// Tools turns MCP tools off; they are skipped at startup and never listed
+	Tools struct {
+		Disabled []string `json:"disabled,omitempty" yaml:"disabled,omitempty"` // tool names or patterns such as *github* or import_*
+	} `json:"tools" yaml:"tools"`
+
+	Storage struct {
+		Mode             string `json:"mode,omitempty" yaml:"mode,omitempty"`     // "files" (default), "events" or "s3"; s3 holds tasks only
+		Format           string `json:"format,omitempty" yaml:"format,omitempty"` // task files in files mode: "json" (default), "markdown", "both" or "mirror"
+		SnapshotInterval int    `json:"snapshot_interval,omitempty" yaml:"snapshot_interval,omitempty"`
+		SQLiteMirror     string `json:"sqlite_mirror,omitempty" yaml:"sqlite_mirror,omitempty"` // path kept in sync on every write; empty disables
+		SoftQuota        string `json:"soft_quota,omitempty" yaml:"soft_quota,omitempty"`       // e.g. 500MB; tools warn once the data directory grows past it
+
+		S3 struct {
+			Endpoint        string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"` // default: AWS for the region
+			Region          string `json:"region,omitempty" yaml:"region,omitempty"`
+			Bucket          string `json:"bucket,omitempty" yaml:"bucket,omitempty"`
+			Prefix          string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+			AccessKeyID     string `json:"access_key_id,omitempty" yaml:"access_key_id,omitempty"`         // default: AWS_ACCESS_KEY_ID
+			SecretAccessKey string `json:"secret_access_key,omitempty" yaml:"secret_access_key,omitempty"` // default: AWS_SECRET_ACCESS_KEY
+			PathStyle       bool   `json:"path_style,omitempty" yaml:"path_style,omitempty"`               // required for most MinIO setups
+		} `json:"s3" yaml:"s3"`
+	} `json:"storage" yaml:"storage"`
+}
+
+// TeamMember is a person in the team registry
+type TeamMember struct {
+	Name    string   `json:"name" yaml:"name"`
+	Aliases []string `json:"aliases,omitempty" yaml:"aliases,omitempty"` // handles and nicknames used in entries
+	Role    string   `json:"role,omitempty" yaml:"role,omitempty"`       // e.g. report, skip, peer, manager
+
+	Channels []string `json:"channels,omitempty" yaml:"channels,omitempty"` // digest.channels names that reach them, e.g. for handoffs
 }
 
 // BackupResult represents the result of a backup operation
@@ -49,80 +236,197 @@ type BackupResult struct {
 	BackupPath  string    `json:"backup_path"`
 	Size        int64     `json:"size_bytes"`
 	FilesBackup int       `json:"files_backup"`
+	Encrypted   bool      `json:"encrypted,omitempty"`
+	UploadedTo  string    `json:"uploaded_to,omitempty"`  // remote copy when a destination is set
+	UploadError string    `json:"upload_error,omitempty"` // the local backup is kept when the upload fails
 	CreatedAt   time.Time `json:"created_at"`
 	Summary     string    `json:"summary"`
 }
 
 // RestoreResult represents the result of a restore operation
 type RestoreResult struct {
-	FilesRestored   int      `json:"files_restored"`
-	TasksRestored   int      `json:"tasks_restored"`
-	EntriesRestored int      `json:"entries_restored"`
-	Warnings        []string `json:"warnings,omitempty"`
-	Summary         string   `json:"summary"`
+	FilesRestored   int            `json:"files_restored"`
+	TasksRestored   int            `json:"tasks_restored"`
+	EntriesRestored int            `json:"entries_restored"`
+	TargetDir       string         `json:"target_dir"`
+	Profile         string         `json:"profile,omitempty"` // profile restored into
+	DryRun          bool           `json:"dry_run,omitempty"`
+	WouldOverwrite  []string       `json:"would_overwrite,omitempty"` // dry run only
+	WouldCreate     []string       `json:"would_create,omitempty"`    // dry run only
+	Warnings        []string       `json:"warnings,omitempty"`
+	Report          *RestoreReport `json:"report,omitempty"`    // profile restores only
+	Merged          []TaskMerge    `json:"merged,omitempty"`    // merge restores only: tasks the backup changed or left alone
+	KeptLive        []string       `json:"kept_live,omitempty"` // merge restores only: files that differ from the backup, kept as they are
+	Summary         string         `json:"summary"`
 }
 
 // CreateDataBackup creates a backup of all journal data
 func (js *JournalService) CreateDataBackup(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	backupPath := request.GetString("backup_path", "")
 	includeConfig := request.GetString("include_config", "true") == "true"
-	compressionLevel := request.GetString("compression", "default")
+	passphrase := request.GetString("passphrase", "")
+	compressionLevel := request.GetString("compression", "")
+	if compressionLevel == "" {
+		if config, err := js.loadConfiguration(); err == nil {
+			compressionLevel = config.Backup.Compression
+		}
+	}
+	if _, err := normalizeBackupCompression(compressionLevel); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-	if backupPath == "" {
+	// Resolve the destination first so a bad URI fails before any work is done
+	var destination BackupDestination
+	uri := request.GetString("destination", "")
+	if uri == "" {
+		if config, err := js.loadConfiguration(); err == nil {
+			uri = config.Backup.Destination
+		}
+	}
+	if uri != "" {
+		resolved, err := js.backupDestination(uri)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		destination = resolved
+	}
+
+	defaultPath := backupPath == ""
+	if defaultPath {
 		// Generate default backup path
-		timestamp := time.Now().Format("2006-01-02_15-04-05")
-		backupPath = filepath.Join(js.DataDir, "backups", fmt.Sprintf("journal-backup-%s.zip", timestamp))
+		backupPath = js.newBackupPath()
+		if passphrase != "" && !strings.HasSuffix(backupPath, encryptedBackupSuffix) {
+			backupPath += encryptedBackupSuffix
+		}
+	}
+
+	result, err := js.writeBackup(backupPath, includeConfig, compressionLevel, passphrase)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create backup: %v", err)), nil
+	}
+
+	// Backups in the backup directory count towards backup.max_backups
+	if defaultPath {
+		js.pruneBackups()
+	}
+
+	if destination != nil {
+		if remote, err := uploadBackup(destination, result.BackupPath); err != nil {
+			result.UploadError = err.Error()
+			result.Summary += fmt.Sprintf(". Upload to %s failed: %v", destination, err)
+		} else {
+			result.UploadedTo = remote
+			result.Summary += ", uploaded to " + remote
+		}
+	}
+
+	resultJSON, _ := json.Marshal(result)
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// writeBackup writes a ZIP backup of all journal data to backupPath. The
+// backup is encrypted with passphrase when one is given, otherwise with the
+// encryption passphrase when encryption.backups is on.
+func (js *JournalService) writeBackup(backupPath string, includeConfig bool, compressionLevel, passphrase string) (*BackupResult, error) {
+	compression, err := normalizeBackupCompression(compressionLevel)
+	if err != nil {
+		return nil, err
+	}
+	if passphrase == "" {
+		if config, err := js.loadConfiguration(); err == nil && config.Encryption.Backups {
+			if passphrase, err = js.encryptionPassphrase(); err != nil {
+				return nil, fmt.Errorf("failed to encrypt backup: %w", err)
+			}
+		}
 	}
 
 	// Ensure backup directory exists
 	backupDir := filepath.Dir(backupPath)
 	if err := os.MkdirAll(backupDir, 0755); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create backup directory: %v", err)), nil
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
 	}
 
 	// Create ZIP file
 	zipFile, err := os.Create(backupPath)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create backup file: %v", err)), nil
+		return nil, fmt.Errorf("failed to create backup file: %w", err)
 	}
 	defer zipFile.Close()
 
-	zipWriter := zip.NewWriter(zipFile)
+	zipWriter := newBackupZip(zipFile, compression)
 	defer zipWriter.Close()
 
 	var filesBackup int
 	var totalSize int64
+	startedAt := time.Now()
+	manifest := make(map[string]string) // archive path -> SHA-256 of its content
 
 	// Backup tasks
-	tasksDir := filepath.Join(js.DataDir, "tasks")
-	if err := js.addDirectoryToZip(zipWriter, tasksDir, "tasks", &filesBackup, &totalSize); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to backup tasks: %v", err)), nil
+	tasksDir := filepath.Join(js.dataDir(), "tasks")
+	if err := js.addDirectoryToZip(zipWriter, tasksDir, "tasks", &filesBackup, &totalSize, manifest); err != nil {
+		return nil, fmt.Errorf("failed to backup tasks: %w", err)
 	}
 
 	// Backup daily logs
-	dailyDir := filepath.Join(js.DataDir, "daily")
-	if err := js.addDirectoryToZip(zipWriter, dailyDir, "daily", &filesBackup, &totalSize); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to backup daily logs: %v", err)), nil
+	dailyDir := filepath.Join(js.dataDir(), "daily")
+	if err := js.addDirectoryToZip(zipWriter, dailyDir, "daily", &filesBackup, &totalSize, manifest); err != nil {
+		return nil, fmt.Errorf("failed to backup daily logs: %w", err)
 	}
 
 	// Backup weekly logs
-	weeklyDir := filepath.Join(js.DataDir, "weekly")
-	if err := js.addDirectoryToZip(zipWriter, weeklyDir, "weekly", &filesBackup, &totalSize); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to backup weekly logs: %v", err)), nil
+	weeklyDir := filepath.Join(js.dataDir(), "weekly")
+	if err := js.addDirectoryToZip(zipWriter, weeklyDir, "weekly", &filesBackup, &totalSize, manifest); err != nil {
+		return nil, fmt.Errorf("failed to backup weekly logs: %w", err)
 	}
 
 	// Backup one-on-ones
-	oneOnOneDir := filepath.Join(js.DataDir, "one-on-ones")
-	if err := js.addDirectoryToZip(zipWriter, oneOnOneDir, "one-on-ones", &filesBackup, &totalSize); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to backup one-on-ones: %v", err)), nil
+	oneOnOneDir := filepath.Join(js.dataDir(), "one-on-ones")
+	if err := js.addDirectoryToZip(zipWriter, oneOnOneDir, "one-on-ones", &filesBackup, &totalSize, manifest); err != nil {
+		return nil, fmt.Errorf("failed to backup one-on-ones: %w", err)
+	}
+
+	// Backup other meetings
+	meetingsDir := filepath.Join(js.dataDir(), "meetings")
+	if err := js.addDirectoryToZip(zipWriter, meetingsDir, "meetings", &filesBackup, &totalSize, manifest); err != nil {
+		return nil, fmt.Errorf("failed to backup meetings: %w", err)
+	}
+
+	// Backup weekly reviews
+	reviewsDir := filepath.Join(js.dataDir(), "weekly-reviews")
+	if err := js.addDirectoryToZip(zipWriter, reviewsDir, "weekly-reviews", &filesBackup, &totalSize, manifest); err != nil {
+		return nil, fmt.Errorf("failed to backup weekly reviews: %w", err)
+	}
+
+	// Backup goals
+	goalsDir := filepath.Join(js.dataDir(), "goals")
+	if err := js.addDirectoryToZip(zipWriter, goalsDir, "goals", &filesBackup, &totalSize, manifest); err != nil {
+		return nil, fmt.Errorf("failed to backup goals: %w", err)
+	}
+
+	// Backup habits
+	habitsDir := filepath.Join(js.dataDir(), "habits")
+	if err := js.addDirectoryToZip(zipWriter, habitsDir, "habits", &filesBackup, &totalSize, manifest); err != nil {
+		return nil, fmt.Errorf("failed to backup habits: %w", err)
+	}
+
+	// Backup archived tasks
+	archivedDir := filepath.Join(js.dataDir(), "archived")
+	if err := js.addDirectoryToZip(zipWriter, archivedDir, "archived", &filesBackup, &totalSize, manifest); err != nil {
+		return nil, fmt.Errorf("failed to backup archived tasks: %w", err)
+	}
+
+	// Backup entry attachments
+	attachmentsDir := filepath.Join(js.dataDir(), "attachments")
+	if err := js.addDirectoryToZip(zipWriter, attachmentsDir, "attachments", &filesBackup, &totalSize, manifest); err != nil {
+		return nil, fmt.Errorf("failed to backup attachments: %w", err)
 	}
 
 	// Backup configuration if requested
 	if includeConfig {
-		configPath := filepath.Join(js.DataDir, "config.yaml")
+		configPath := filepath.Join(js.dataDir(), "config.yaml")
 		if _, err := os.Stat(configPath); err == nil {
-			if err := js.addFileToZip(zipWriter, configPath, "config.yaml", &totalSize); err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("Failed to backup config: %v", err)), nil
+			if err := js.addFileToZip(zipWriter, configPath, "config.yaml", &totalSize, manifest); err != nil {
+				return nil, fmt.Errorf("failed to backup config: %w", err)
 			}
 			filesBackup++
 		}
@@ -131,17 +435,20 @@ func (js *JournalService) CreateDataBackup(ctx context.Context, request mcp.Call
 	// Add backup metadata
 	metadata := map[string]interface{}{
 		"created_at":     time.Now(),
+		"started_at":     startedAt,
 		"version":        "1.0.0",
-		"source_dir":     js.DataDir,
+		"source_dir":     js.dataDir(),
 		"files_count":    filesBackup,
 		"include_config": includeConfig,
-		"compression":    compressionLevel,
+		"compression":    compression,
+		"encrypted":      passphrase != "",
+		"manifest":       manifest,
 	}
 
 	metadataJSON, _ := json.MarshalIndent(metadata, "", "  ")
 	metadataWriter, err := zipWriter.Create("backup_metadata.json")
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to create metadata: %v", err)), nil
+		return nil, fmt.Errorf("failed to create metadata: %w", err)
 	}
 	metadataWriter.Write(metadataJSON)
 
@@ -149,24 +456,38 @@ func (js *JournalService) CreateDataBackup(ctx context.Context, request mcp.Call
 	zipWriter.Close()
 	zipFile.Close()
 
+	encrypted := false
+	if passphrase != "" {
+		if err := encryptBackup(backupPath, passphrase); err != nil {
+			os.Remove(backupPath)
+			return nil, fmt.Errorf("failed to encrypt backup: %w", err)
+		}
+		encrypted = true
+	}
+
 	fileInfo, err := os.Stat(backupPath)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get backup file info: %v", err)), nil
+		return nil, fmt.Errorf("failed to get backup file info: %w", err)
 	}
 
-	result := BackupResult{
+	kind := "backup"
+	if encrypted {
+		kind = "encrypted backup"
+	}
+	return &BackupResult{
 		BackupPath:  backupPath,
 		Size:        fileInfo.Size(),
 		FilesBackup: filesBackup,
+		Encrypted:   encrypted,
 		CreatedAt:   time.Now(),
-		Summary:     fmt.Sprintf("Successfully created backup with %d files (%d bytes) at %s", filesBackup, fileInfo.Size(), backupPath),
-	}
-
-	resultJSON, _ := json.Marshal(result)
-	return mcp.NewToolResultText(string(resultJSON)), nil
+		Summary:     fmt.Sprintf("Successfully created %s with %d files (%d bytes) at %s", kind, filesBackup, fileInfo.Size(), backupPath),
+	}, nil
 }
 
-// RestoreDataBackup restores journal data from a backup file
+// RestoreDataBackup restores journal data from a backup file over the active
+// profile, into a new profile, or merged into the active profile: tasks are
+// three-way merged and other files restored only where they are missing. With
+// dry_run it only reports what would change.
 func (js *JournalService) RestoreDataBackup(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	backupPath := request.GetString("backup_path", "")
 	if backupPath == "" {
@@ -175,6 +496,20 @@ func (js *JournalService) RestoreDataBackup(ctx context.Context, request mcp.Cal
 
 	overwriteExisting := request.GetString("overwrite_existing", "false") == "true"
 	restoreConfig := request.GetString("restore_config", "true") == "true"
+	dryRun := request.GetString("dry_run", "false") == "true"
+	profile := request.GetString("profile", "")
+
+	if profile != "" {
+		if err := validateProfileName(profile); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if profile == defaultProfile || overwriteExisting {
+			return mcp.NewToolResultError("profile restores go into a new profile; use overwrite_existing=true without profile to restore over the active one"), nil
+		}
+		if _, err := os.Stat(js.profileDir(profile)); err == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Profile %s already exists; restore into a new profile name", profile)), nil
+		}
+	}
 
 	// Verify backup file exists
 	if _, err := os.Stat(backupPath); os.IsNotExist(err) {
@@ -182,24 +517,38 @@ func (js *JournalService) RestoreDataBackup(ctx context.Context, request mcp.Cal
 	}
 
 	// Open ZIP file
-	zipReader, err := zip.OpenReader(backupPath)
+	zipReader, encrypted, err := js.openBackup(backupPath, request.GetString("passphrase", ""))
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to open backup file: %v", err)), nil
 	}
-	defer zipReader.Close()
+	formatWarnings, err := checkBackupFormat(zipReader, encrypted)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Cannot restore backup: %v", err)), nil
+	}
 
 	var restoreResult RestoreResult
-	restoreResult.Warnings = []string{}
+	restoreResult.Warnings = append([]string{}, formatWarnings...)
+	restoreResult.DryRun = dryRun
+
+	// Restore into a new profile, or merge into live data unless overwriting
+	targetDir := js.dataDir()
+	var merge *restoreMerge
+	switch {
+	case profile != "":
+		targetDir = js.profileDir(profile)
+		restoreResult.Profile = profile
+	case !overwriteExisting:
+		merge = js.newRestoreMerge(backupPath, dryRun)
+	}
+	restoreResult.TargetDir = targetDir
 
-	// Create restore directory if needed
-	if !overwriteExisting {
-		timestamp := time.Now().Format("2006-01-02_15-04-05")
-		restoreDir := filepath.Join(js.DataDir, fmt.Sprintf("restore-%s", timestamp))
-		if err := os.MkdirAll(restoreDir, 0755); err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to create restore directory: %v", err)), nil
+	hasJSON := make(map[string]bool) // tasks the backup holds as JSON
+	for _, file := range zipReader.File {
+		if name, ok := strings.CutPrefix(file.Name, "tasks/"); ok && strings.HasSuffix(name, ".json") {
+			hasJSON[strings.TrimSuffix(name, ".json")] = true
 		}
-		js.DataDir = restoreDir
 	}
+	tasksMerged, entriesAdded, conflicted := 0, 0, 0
 
 	// Extract files
 	for _, file := range zipReader.File {
@@ -209,27 +558,111 @@ func (js *JournalService) RestoreDataBackup(ctx context.Context, request mcp.Cal
 		}
 
 		// Skip metadata file
-		if file.Name == "backup_metadata.json" {
+		if file.Name == "backup_metadata.json" || file.FileInfo().IsDir() {
 			continue
 		}
 
-		if err := js.extractFileFromZip(file, &restoreResult); err != nil {
-			restoreResult.Warnings = append(restoreResult.Warnings, fmt.Sprintf("Failed to extract %s: %v", file.Name, err))
+		if !isSafeArchivePath(file.Name) {
+			restoreResult.Warnings = append(restoreResult.Warnings, fmt.Sprintf("Skipped unsafe path %s", file.Name))
 			continue
 		}
 
-		restoreResult.FilesRestored++
-
 		// Count tasks and entries
 		if strings.HasPrefix(file.Name, "tasks/") {
-			restoreResult.TasksRestored++
-			// Could parse and count entries, but for now just increment
-			restoreResult.EntriesRestored += 10 // Estimate
+			task, err := js.readZipTask(file)
+			if err != nil {
+				restoreResult.Warnings = append(restoreResult.Warnings, fmt.Sprintf("Unreadable task %s: %v", file.Name, err))
+			} else {
+				restoreResult.TasksRestored++
+				restoreResult.EntriesRestored += len(task.Entries)
+			}
 		}
+
+		if merge != nil {
+			switch {
+			case strings.HasPrefix(file.Name, "tasks/"):
+				taskMerge, err := merge.mergeTaskFile(file, hasJSON)
+				if err != nil {
+					restoreResult.Warnings = append(restoreResult.Warnings, fmt.Sprintf("Failed to merge %s: %v", file.Name, err))
+					continue
+				}
+				if taskMerge == nil {
+					continue
+				}
+				restoreResult.Merged = append(restoreResult.Merged, *taskMerge)
+				switch taskMerge.Action {
+				case "created", "merged", "fast_forward":
+					restoreResult.FilesRestored++
+					tasksMerged++
+					entriesAdded += taskMerge.EntriesAdded
+				}
+				if len(taskMerge.Conflicts) > 0 {
+					conflicted++
+				}
+			case strings.HasPrefix(file.Name, "daily/") && strings.HasSuffix(file.Name, ".json"):
+				changed, err := merge.mergeDailyLog(file)
+				if err != nil {
+					restoreResult.Warnings = append(restoreResult.Warnings, fmt.Sprintf("Failed to merge %s: %v", file.Name, err))
+				} else if changed {
+					restoreResult.FilesRestored++
+				}
+			default:
+				created, differs, err := merge.mergeFile(file)
+				switch {
+				case err != nil:
+					restoreResult.Warnings = append(restoreResult.Warnings, fmt.Sprintf("Failed to restore %s: %v", file.Name, err))
+				case created:
+					restoreResult.FilesRestored++
+					if dryRun {
+						restoreResult.WouldCreate = append(restoreResult.WouldCreate, file.Name)
+					}
+				case differs:
+					restoreResult.KeptLive = append(restoreResult.KeptLive, file.Name)
+				}
+			}
+			continue
+		}
+
+		if dryRun {
+			if _, err := os.Stat(filepath.Join(targetDir, file.Name)); err == nil {
+				restoreResult.WouldOverwrite = append(restoreResult.WouldOverwrite, file.Name)
+			} else {
+				restoreResult.WouldCreate = append(restoreResult.WouldCreate, file.Name)
+			}
+			restoreResult.FilesRestored++
+			continue
+		}
+
+		if err := extractFileFromZip(file, targetDir); err != nil {
+			restoreResult.Warnings = append(restoreResult.Warnings, fmt.Sprintf("Failed to extract %s: %v", file.Name, err))
+			continue
+		}
+
+		restoreResult.FilesRestored++
 	}
 
-	restoreResult.Summary = fmt.Sprintf("Successfully restored %d files (%d tasks) from backup",
-		restoreResult.FilesRestored, restoreResult.TasksRestored)
+	if merge != nil {
+		verb := "Merged"
+		if dryRun {
+			verb = "Dry run: would merge"
+		}
+		restoreResult.Summary = fmt.Sprintf("%s the backup into %s: %d tasks created or updated with %d entries (%d with conflicts marked in a merge_conflict entry), %d files restored in all",
+			verb, targetDir, tasksMerged, entriesAdded, conflicted, restoreResult.FilesRestored)
+		if len(restoreResult.KeptLive) > 0 {
+			restoreResult.Summary += fmt.Sprintf("; %d files differ from the backup and were left as they are (see kept_live)", len(restoreResult.KeptLive))
+		}
+	} else if dryRun {
+		restoreResult.Summary = fmt.Sprintf("Dry run: would restore %d files (%d tasks, %d entries) into %s, overwriting %d existing files",
+			restoreResult.FilesRestored, restoreResult.TasksRestored, restoreResult.EntriesRestored, targetDir, len(restoreResult.WouldOverwrite))
+	} else if profile != "" {
+		ensureDataDirs(targetDir)
+		restoreResult.Report = js.buildRestoreReport(profile)
+		restoreResult.Summary = fmt.Sprintf("Restored %d files (%d tasks, %d entries) from backup into new profile %s. %s",
+			restoreResult.FilesRestored, restoreResult.TasksRestored, restoreResult.EntriesRestored, profile, restoreResult.Report.NextStep)
+	} else {
+		restoreResult.Summary = fmt.Sprintf("Successfully restored %d files (%d tasks, %d entries) from backup into %s",
+			restoreResult.FilesRestored, restoreResult.TasksRestored, restoreResult.EntriesRestored, targetDir)
+	}
 
 	resultJSON, _ := json.Marshal(restoreResult)
 	return mcp.NewToolResultText(string(resultJSON)), nil
@@ -237,28 +670,9 @@ func (js *JournalService) RestoreDataBackup(ctx context.Context, request mcp.Cal
 
 // GetConfiguration retrieves the current configuration
 func (js *JournalService) GetConfiguration(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	configPath := filepath.Join(js.DataDir, "config.yaml")
-
-	var config Configuration
-
-	// Load existing config or use defaults
-	if data, err := os.ReadFile(configPath); err == nil {
-		if err := yaml.Unmarshal(data, &config); err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Failed to parse config: %v", err)), nil
-		}
-	} else {
-		// Set defaults
-		config = Configuration{}
-		config.Web.Enabled = false
-		config.Web.Port = 8080
-		config.Backup.AutoBackup = false
-		config.Backup.BackupInterval = 24
-		config.Backup.MaxBackups = 7
-		config.General.DefaultTaskType = "work"
-		config.General.TimeZone = "UTC"
-		config.General.DateFormat = "2006-01-02"
-		config.GitHub.AutoSync = false
-		config.GitHub.SyncInterval = 60
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to parse config: %v", err)), nil
 	}
 
 	configJSON, _ := json.Marshal(config)
@@ -272,24 +686,32 @@ func (js *JournalService) UpdateConfiguration(ctx context.Context, request mcp.C
 		return mcp.NewToolResultError("config data is required"), nil
 	}
 
-	var config Configuration
-	if err := json.Unmarshal([]byte(configData), &config); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Invalid config JSON: %v", err)), nil
+	// Accept JSON or YAML, checked as strictly as validate_config
+	format := configFormat([]byte(configData))
+	parsed, diagnostics := js.diagnoseConfig([]byte(configData), format)
+	if parsed == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid configuration %s:\n%s", strings.ToUpper(format), formatConfigDiagnostics(diagnostics))), nil
 	}
-
-	// Validate configuration
-	if err := js.validateConfiguration(&config); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Invalid configuration: %v", err)), nil
+	config := *parsed
+	var warnings []string
+	for _, diagnostic := range diagnostics {
+		warnings = append(warnings, diagnostic.String())
 	}
 
-	// Save configuration
-	configPath := filepath.Join(js.DataDir, "config.yaml")
-	configYAML, err := yaml.Marshal(config)
-	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to marshal config: %v", err)), nil
+	// Keep raw tokens out of config.yaml
+	if token := config.GitHub.Token; token != "" && !strings.HasPrefix(token, secretRefPrefix) {
+		provider, err := js.secretProvider()
+		if err == nil {
+			err = provider.Set("github_token", token)
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to store GitHub token as a secret: %v", err)), nil
+		}
+		config.GitHub.Token = secretRefPrefix + "github_token"
 	}
 
-	if err := os.WriteFile(configPath, configYAML, 0644); err != nil {
+	// Save configuration
+	if err := js.saveConfiguration(&config); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to save config: %v", err)), nil
 	}
 
@@ -298,6 +720,9 @@ func (js *JournalService) UpdateConfiguration(ctx context.Context, request mcp.C
 		"message": "Configuration updated successfully",
 		"config":  config,
 	}
+	if len(warnings) > 0 {
+		result["warnings"] = warnings
+	}
 
 	resultJSON, _ := json.Marshal(result)
 	return mcp.NewToolResultText(string(resultJSON)), nil
@@ -322,9 +747,86 @@ func (js *JournalService) MigrateData(ctx context.Context, request mcp.CallToolR
 	return mcp.NewToolResultText(string(resultJSON)), nil
 }
 
+// defaultConfiguration returns the configuration used when no config.yaml exists
+func defaultConfiguration() *Configuration {
+	config := &Configuration{}
+	config.Web.Enabled = false
+	config.Web.Port = 8080
+	config.Backup.AutoBackup = false
+	config.Backup.BackupInterval = 24
+	config.Backup.MaxBackups = 7
+	config.General.DefaultTaskType = "work"
+	config.General.TimeZone = "UTC"
+	config.General.DateFormat = "2006-01-02"
+	config.GitHub.AutoSync = false
+	config.GitHub.SyncInterval = 60
+	return config
+}
+
+// loadConfiguration reads config.yaml from the data directory, falling back to
+// defaults only when there is none. Unknown keys are logged and ignored.
+func (js *JournalService) loadConfiguration() (*Configuration, error) {
+	data, err := os.ReadFile(filepath.Join(js.dataDir(), "config.yaml"))
+	if os.IsNotExist(err) {
+		return defaultConfiguration(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var config Configuration
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err == nil || err == io.EOF {
+		return &config, nil
+	}
+
+	// The strict decode fails on unknown keys as well as bad values; only bad values are fatal
+	config = Configuration{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	logUnknownConfigKeys(data)
+	return &config, nil
+}
+
+var (
+	configWarningsMu     sync.Mutex
+	configWarningsLogged string
+)
+
+// logUnknownConfigKeys logs the unknown keys in config.yaml, once per version
+// of the file since the configuration is loaded on nearly every call
+func logUnknownConfigKeys(data []byte) {
+	configWarningsMu.Lock()
+	defer configWarningsMu.Unlock()
+	if string(data) == configWarningsLogged {
+		return
+	}
+	configWarningsLogged = string(data)
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return
+	}
+	for _, diagnostic := range unknownConfigKeys(&document, reflect.TypeOf(Configuration{}), "yaml", "") {
+		log.Printf("config.yaml: %s", diagnostic)
+	}
+}
+
+// saveConfiguration writes the configuration to config.yaml in the data directory
+func (js *JournalService) saveConfiguration(config *Configuration) error {
+	configYAML, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	return writeFileAtomic(filepath.Join(js.dataDir(), "config.yaml"), configYAML, 0644)
+}
+
 // Helper methods for backup/restore
 
-func (js *JournalService) addDirectoryToZip(zipWriter *zip.Writer, sourceDir, zipDir string, fileCount *int, totalSize *int64) error {
+func (js *JournalService) addDirectoryToZip(zipWriter *backupZip, sourceDir, zipDir string, fileCount *int, totalSize *int64, manifest map[string]string) error {
 	if _, err := os.Stat(sourceDir); os.IsNotExist(err) {
 		return nil // Directory doesn't exist, skip
 	}
@@ -344,12 +846,17 @@ func (js *JournalService) addDirectoryToZip(zipWriter *zip.Writer, sourceDir, zi
 			return err
 		}
 
-		zipPath := filepath.Join(zipDir, relPath)
-		return js.addFileToZip(zipWriter, path, zipPath, totalSize)
+		zipPath := filepath.ToSlash(filepath.Join(zipDir, relPath))
+		if err := js.addFileToZip(zipWriter, path, zipPath, totalSize, manifest); err != nil {
+			return err
+		}
+		*fileCount++
+		return nil
 	})
 }
 
-func (js *JournalService) addFileToZip(zipWriter *zip.Writer, filePath, zipPath string, totalSize *int64) error {
+// addFileToZip copies a file into the archive and records its checksum in the manifest
+func (js *JournalService) addFileToZip(zipWriter *backupZip, filePath, zipPath string, totalSize *int64, manifest map[string]string) error {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return err
@@ -361,16 +868,18 @@ func (js *JournalService) addFileToZip(zipWriter *zip.Writer, filePath, zipPath
 		return err
 	}
 
-	written, err := io.Copy(writer, file)
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(writer, hash), file)
 	if err != nil {
 		return err
 	}
 
 	*totalSize += written
+	manifest[zipPath] = hex.EncodeToString(hash.Sum(nil))
 	return nil
 }
 
-func (js *JournalService) extractFileFromZip(file *zip.File, result *RestoreResult) error {
+func extractFileFromZip(file *zip.File, targetDir string) error {
 	reader, err := file.Open()
 	if err != nil {
 		return err
@@ -378,9 +887,8 @@ func (js *JournalService) extractFileFromZip(file *zip.File, result *RestoreResu
 	defer reader.Close()
 
 	// Create target directory
-	targetPath := filepath.Join(js.DataDir, file.Name)
-	targetDir := filepath.Dir(targetPath)
-	if err := os.MkdirAll(targetDir, 0755); err != nil {
+	targetPath := filepath.Join(targetDir, file.Name)
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
 		return err
 	}
 
@@ -409,12 +917,34 @@ func (js *JournalService) validateConfiguration(config *Configuration) error {
 	if config.Backup.MaxBackups < 1 {
 		return fmt.Errorf("max backups must be at least 1")
 	}
+	if destination := config.Backup.Destination; destination != "" {
+		if _, err := parseBackupDestination(destination); err != nil {
+			return err
+		}
+	}
 
 	// Validate GitHub configuration
 	if config.GitHub.SyncInterval < 5 {
 		return fmt.Errorf("GitHub sync interval must be at least 5 minutes")
 	}
 
+	switch config.GitHub.AuthMode {
+	case "", "token":
+	case "app":
+		if config.GitHub.App.AppID == 0 || config.GitHub.App.InstallationID == 0 {
+			return fmt.Errorf("GitHub App auth requires github.app.app_id and github.app.installation_id")
+		}
+	default:
+		return fmt.Errorf("invalid GitHub auth mode: %s (expected token or app)", config.GitHub.AuthMode)
+	}
+
+	if baseURL := config.GitLab.BaseURL; baseURL != "" && !strings.HasPrefix(baseURL, "https://") && !strings.HasPrefix(baseURL, "http://") {
+		return fmt.Errorf("invalid GitLab base URL: %s (expected http:// or https://)", baseURL)
+	}
+	if baseURL := config.Jira.BaseURL; baseURL != "" && !strings.HasPrefix(baseURL, "https://") && !strings.HasPrefix(baseURL, "http://") {
+		return fmt.Errorf("invalid Jira base URL: %s (expected http:// or https://)", baseURL)
+	}
+
 	// Validate general configuration
 	validTaskTypes := []string{"work", "learning", "personal", "investigation"}
 	valid := false
@@ -428,5 +958,169 @@ func (js *JournalService) validateConfiguration(config *Configuration) error {
 		return fmt.Errorf("invalid default task type: %s", config.General.DefaultTaskType)
 	}
 
-	return nil
+	if factor := config.General.ReestimateFactor; factor != 0 && factor < 1 {
+		return fmt.Errorf("invalid reestimate factor: %g (expected 1 or more)", factor)
+	}
+	if lookback := config.General.StandupLookback; lookback < 0 || lookback > maxStandupLookback {
+		return fmt.Errorf("invalid standup lookback: %d (expected 1 to %d working days)", lookback, maxStandupLookback)
+	}
+	if weekStart := config.General.WeekStart; weekStart != "" {
+		if _, ok := parseWeekday(weekStart); !ok {
+			return fmt.Errorf("invalid week start: %s (expected a weekday)", weekStart)
+		}
+	}
+
+	// Validate schedule configuration
+	if snapshot := config.Schedule.DailySnapshot; snapshot != "" && snapshot != "off" {
+		if _, err := time.Parse("15:04", snapshot); err != nil {
+			return fmt.Errorf("invalid daily snapshot time: %s (expected HH:MM or off)", snapshot)
+		}
+	}
+
+	if review := config.Schedule.SomedayReview; review != "" && review != "off" {
+		if _, ok := parseWeekday(review); !ok {
+			return fmt.Errorf("invalid someday review day: %s (expected a weekday or off)", review)
+		}
+	}
+
+	if nudge := config.Schedule.StreakNudge; nudge != "" && nudge != "off" {
+		if _, err := time.Parse("15:04", nudge); err != nil {
+			return fmt.Errorf("invalid streak nudge time: %s (expected HH:MM or off)", nudge)
+		}
+	}
+	if at := config.Connectors.Time; at != "" && at != "off" {
+		if _, err := time.Parse("15:04", at); err != nil {
+			return fmt.Errorf("invalid connectors time: %s (expected HH:MM or off)", at)
+		}
+	}
+	connectorLabels := make(map[string]bool)
+	for _, connector := range config.Connectors.Sources {
+		if !slices.Contains(connectorTypes, connector.Type) {
+			return fmt.Errorf("invalid connector type: %q (expected %s)", connector.Type, strings.Join(connectorTypes, ", "))
+		}
+		if connectorLabels[connector.label()] {
+			return fmt.Errorf("duplicate connector name: %s (give each connector of a type its own name)", connector.label())
+		}
+		connectorLabels[connector.label()] = true
+	}
+
+	if provider := config.OnCall.Provider; provider != "" && !slices.Contains(onCallProviders, provider) {
+		return fmt.Errorf("invalid on-call provider: %s (expected %s)", provider, strings.Join(onCallProviders, ", "))
+	}
+	for _, webhook := range config.Watch.Webhooks {
+		if !strings.HasPrefix(webhook, "https://") && !strings.HasPrefix(webhook, "http://") && !strings.HasPrefix(webhook, secretRefPrefix) {
+			return fmt.Errorf("invalid watch webhook: %s (expected an http(s) URL or a secret: reference)", webhook)
+		}
+	}
+
+	switch config.Team.Redact {
+	case "", "none", "content", "names":
+	default:
+		return fmt.Errorf("invalid team redact mode: %s (expected none, content or names)", config.Team.Redact)
+	}
+	for _, member := range config.Team.Members {
+		if strings.TrimSpace(member.Name) == "" {
+			return fmt.Errorf("team members need a name")
+		}
+		for _, name := range member.Channels {
+			if !slices.ContainsFunc(config.Digest.Channels, func(channel DigestChannel) bool { return channel.Name == name }) {
+				return fmt.Errorf("team member %s has unknown channel %s (expected a name from digest.channels)", member.Name, name)
+			}
+		}
+	}
+
+	for name, style := range config.Markdown.Styles {
+		if style.HeadingBase < 0 || style.HeadingBase > 6 {
+			return fmt.Errorf("invalid heading base for markdown style %s: %d (expected 1-6)", name, style.HeadingBase)
+		}
+		switch style.Bullet {
+		case "", "-", "*", "+":
+		default:
+			return fmt.Errorf("invalid bullet for markdown style %s: %s (expected -, * or +)", name, style.Bullet)
+		}
+		switch style.Emoji {
+		case "", "on", "off":
+		default:
+			return fmt.Errorf("invalid emoji setting for markdown style %s: %s (expected on or off)", name, style.Emoji)
+		}
+	}
+	if name := config.Markdown.Style; name != "" {
+		if _, ok := config.Markdown.Styles[name]; !ok {
+			if _, ok := builtinMarkdownStyles[name]; !ok {
+				return fmt.Errorf("unknown markdown style: %s", name)
+			}
+		}
+	}
+
+	if config.Schedule.AutoPauseDays < 0 {
+		return fmt.Errorf("auto pause days cannot be negative: %d", config.Schedule.AutoPauseDays)
+	}
+
+	if config.Obsidian.Sync && config.Obsidian.Vault == "" {
+		return fmt.Errorf("obsidian.sync requires obsidian.vault")
+	}
+
+	// Validate secrets configuration
+	switch config.Secrets.Provider {
+	case "", "keyring", "file", "env":
+	default:
+		return fmt.Errorf("invalid secret provider: %s (expected keyring, file or env)", config.Secrets.Provider)
+	}
+
+	switch config.Import.DateOrder {
+	case "", "auto", "dmy", "mdy":
+	default:
+		return fmt.Errorf("invalid import date order: %s (expected auto, dmy or mdy)", config.Import.DateOrder)
+	}
+
+	for _, pattern := range config.Tools.Disabled {
+		if _, err := filepath.Match(pattern, ""); err != nil || strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("invalid tools.disabled pattern: %q", pattern)
+		}
+	}
+
+	// Validate storage configuration
+	switch config.Storage.Mode {
+	case "", "files", "events":
+	case "s3":
+		if config.Storage.S3.Bucket == "" {
+			return fmt.Errorf("storage.s3.bucket is required for s3 storage")
+		}
+	default:
+		return fmt.Errorf("invalid storage mode: %s (expected files, events or s3)", config.Storage.Mode)
+	}
+
+	switch config.Storage.Format {
+	case "", "json":
+	case "markdown", "both", "mirror":
+		if config.Storage.Mode != "" && config.Storage.Mode != "files" {
+			return fmt.Errorf("storage format %s requires storage mode files", config.Storage.Format)
+		}
+	default:
+		return fmt.Errorf("invalid storage format: %s (expected json, markdown, both or mirror)", config.Storage.Format)
+	}
+
+	// The mirror is a plaintext copy of every task
+	if config.Storage.SQLiteMirror != "" && config.Encryption.AtRest {
+		return fmt.Errorf("storage.sqlite_mirror cannot be used with encryption.at_rest")
+	}
+
+	if config.Storage.SnapshotInterval < 0 {
+		return fmt.Errorf("snapshot interval cannot be negative")
+	}
+	if quota := config.Storage.SoftQuota; quota != "" {
+		if _, err := parseByteSize(quota); err != nil {
+			return fmt.Errorf("invalid storage soft quota: %w", err)
+		}
+	}
+
+	if err := validateTagRules(config.TagRules); err != nil {
+		return err
+	}
+
+	if err := validateQuotas(config.Quotas); err != nil {
+		return err
+	}
+
+	return validateDigestConfig(config)
 }
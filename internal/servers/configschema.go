@@ -0,0 +1,294 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigDiagnostic is one problem found in a configuration. Line and Column
+// are 1-based and 0 when the problem is not tied to a place in the text.
+type ConfigDiagnostic struct {
+	Severity string `json:"severity"` // error or warning
+	Path     string `json:"path,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Message  string `json:"message"`
+}
+
+func (d ConfigDiagnostic) String() string {
+	var place []string
+	switch {
+	case d.Column > 0:
+		place = append(place, fmt.Sprintf("line %d, column %d", d.Line, d.Column))
+	case d.Line > 0:
+		place = append(place, fmt.Sprintf("line %d", d.Line))
+	}
+	if d.Path != "" {
+		place = append(place, d.Path)
+	}
+	if len(place) == 0 {
+		return fmt.Sprintf("%s: %s", d.Severity, d.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", d.Severity, strings.Join(place, ", "), d.Message)
+}
+
+// ConfigValidation reports a validate_config run
+type ConfigValidation struct {
+	Source      string             `json:"source"` // config.yaml or the content passed in
+	Format      string             `json:"format"` // yaml or json
+	Valid       bool               `json:"valid"`  // no errors; warnings are allowed
+	Diagnostics []ConfigDiagnostic `json:"diagnostics"`
+	Summary     string             `json:"summary"`
+}
+
+// yamlErrorLine matches the "line N: message" form of yaml.v3 errors
+var yamlErrorLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// configFormat guesses whether configuration text is JSON or YAML
+func configFormat(data []byte) string {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		return "json"
+	}
+	return "yaml"
+}
+
+// offsetPosition turns a byte offset into a 1-based line and column
+func offsetPosition(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	return line, int(offset) - (bytes.LastIndexByte(before, '\n') + 1) + 1
+}
+
+// configNodeAt finds the value at a dotted key path, such as general.timezone,
+// in configuration text, or nil
+func configNodeAt(data []byte, path string) *yaml.Node {
+	var document yaml.Node
+	if path == "" || yaml.Unmarshal(data, &document) != nil || len(document.Content) == 0 {
+		return nil
+	}
+	node := document.Content[0]
+	for _, key := range strings.Split(path, ".") {
+		var next *yaml.Node
+		for i := 0; node.Kind == yaml.MappingNode && i+1 < len(node.Content); i += 2 {
+			if strings.EqualFold(node.Content[i].Value, key) {
+				next = node.Content[i+1]
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		node = next
+	}
+	return node
+}
+
+// decodeConfigDiagnostics decodes configuration text, turning syntax and type
+// errors into diagnostics
+func decodeConfigDiagnostics(data []byte, format string) (*Configuration, []ConfigDiagnostic) {
+	var config Configuration
+	if format == "json" {
+		err := json.Unmarshal(data, &config)
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case err == nil:
+			return &config, nil
+		case errors.As(err, &syntaxErr):
+			line, column := offsetPosition(data, syntaxErr.Offset-1) // Offset is just past the bad byte
+			return nil, []ConfigDiagnostic{{Severity: "error", Line: line, Column: column, Message: syntaxErr.Error()}}
+		case errors.As(err, &typeErr):
+			line, column := offsetPosition(data, typeErr.Offset)
+			if node := configNodeAt(data, typeErr.Field); node != nil {
+				line, column = node.Line, node.Column
+			}
+			return nil, []ConfigDiagnostic{{Severity: "error", Path: typeErr.Field, Line: line, Column: column,
+				Message: fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value)}}
+		default:
+			return nil, []ConfigDiagnostic{{Severity: "error", Message: err.Error()}}
+		}
+	}
+
+	err := yaml.Unmarshal(data, &config)
+	if err == nil {
+		return &config, nil
+	}
+	messages := []string{err.Error()}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	}
+	var diagnostics []ConfigDiagnostic
+	for _, message := range messages {
+		diagnostic := ConfigDiagnostic{Severity: "error", Message: message}
+		if match := yamlErrorLine.FindStringSubmatch(message); match != nil {
+			diagnostic.Line, _ = strconv.Atoi(match[1])
+			diagnostic.Message = match[2]
+		}
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return nil, diagnostics
+}
+
+// configFieldTypes maps the keys of a configuration struct to their types,
+// by their json or yaml tag
+func configFieldTypes(structType reflect.Type, tag string) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// unknownConfigKeys walks a parsed document against the type it configures
+// and warns about every key that type has no field for. JSON parses as YAML,
+// so one walk serves both formats.
+func unknownConfigKeys(node *yaml.Node, valueType reflect.Type, tag, path string) []ConfigDiagnostic {
+	for valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+	var diagnostics []ConfigDiagnostic
+	switch {
+	case node.Kind == yaml.DocumentNode:
+		for _, child := range node.Content {
+			diagnostics = append(diagnostics, unknownConfigKeys(child, valueType, tag, path)...)
+		}
+	case node.Kind == yaml.MappingNode && valueType.Kind() == reflect.Struct:
+		fields := configFieldTypes(valueType, tag)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := strings.TrimPrefix(path+"."+key.Value, ".")
+			fieldType, ok := fields[key.Value]
+			if !ok && tag == "json" { // encoding/json matches keys case-insensitively
+				for name, candidate := range fields {
+					if strings.EqualFold(name, key.Value) {
+						fieldType, ok = candidate, true
+					}
+				}
+			}
+			if !ok {
+				message := fmt.Sprintf("unknown key %q is ignored", key.Value)
+				if suggestion := closestConfigKey(key.Value, fields); suggestion != "" {
+					message += fmt.Sprintf("; did you mean %q?", suggestion)
+				}
+				diagnostics = append(diagnostics, ConfigDiagnostic{Severity: "warning", Path: keyPath, Line: key.Line, Column: key.Column, Message: message})
+				continue
+			}
+			diagnostics = append(diagnostics, unknownConfigKeys(value, fieldType, tag, keyPath)...)
+		}
+	case node.Kind == yaml.MappingNode && valueType.Kind() == reflect.Map:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			diagnostics = append(diagnostics, unknownConfigKeys(node.Content[i+1], valueType.Elem(), tag, path+"."+node.Content[i].Value)...)
+		}
+	case node.Kind == yaml.SequenceNode && valueType.Kind() == reflect.Slice:
+		for i, item := range node.Content {
+			diagnostics = append(diagnostics, unknownConfigKeys(item, valueType.Elem(), tag, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return diagnostics
+}
+
+// closestConfigKey suggests the known key a misspelt one was probably meant
+// to be, or "" when none is close
+func closestConfigKey(key string, fields map[string]reflect.Type) string {
+	best, bestDistance := "", 3
+	for _, name := range sortedKeys(fields) {
+		if distance := editDistance(strings.ToLower(key), name); distance < bestDistance {
+			best, bestDistance = name, distance
+		}
+	}
+	return best
+}
+
+// diagnoseConfig checks configuration text strictly: syntax, value types,
+// unknown keys and the rules validateConfiguration enforces. The
+// configuration is returned when it has no errors.
+func (js *JournalService) diagnoseConfig(data []byte, format string) (*Configuration, []ConfigDiagnostic) {
+	config, diagnostics := decodeConfigDiagnostics(data, format)
+	if config == nil {
+		return nil, diagnostics
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err == nil {
+		diagnostics = append(diagnostics, unknownConfigKeys(&document, reflect.TypeOf(Configuration{}), format, "")...)
+	}
+	if err := js.validateConfiguration(config); err != nil {
+		return nil, append(diagnostics, ConfigDiagnostic{Severity: "error", Message: err.Error()})
+	}
+	return config, diagnostics
+}
+
+// formatConfigDiagnostics lists diagnostics one per line
+func formatConfigDiagnostics(diagnostics []ConfigDiagnostic) string {
+	lines := make([]string, len(diagnostics))
+	for i, diagnostic := range diagnostics {
+		lines[i] = "- " + diagnostic.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ValidateConfig checks config.yaml, or configuration passed as config, without saving anything
+func (js *JournalService) ValidateConfig(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	result := ConfigValidation{Source: "config.yaml", Format: "yaml"}
+	data := []byte(request.GetString("config", ""))
+	if len(data) > 0 {
+		result.Source = "config parameter"
+		result.Format = request.GetString("format", configFormat(data))
+	} else {
+		var err error
+		data, err = os.ReadFile(filepath.Join(js.dataDir(), "config.yaml"))
+		if os.IsNotExist(err) {
+			return mcp.NewToolResultText("No config.yaml yet; the defaults are in use."), nil
+		}
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to read config: %v", err)), nil
+		}
+	}
+	if result.Format != "yaml" && result.Format != "json" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format: %s (expected yaml or json)", result.Format)), nil
+	}
+
+	config, diagnostics := js.diagnoseConfig(data, result.Format)
+	result.Valid = config != nil
+	result.Diagnostics = diagnostics
+	if result.Diagnostics == nil {
+		result.Diagnostics = []ConfigDiagnostic{}
+	}
+	errorCount := 0
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == "error" {
+			errorCount++
+		}
+	}
+	if result.Valid {
+		result.Summary = fmt.Sprintf("%s is valid with %d warnings", result.Source, len(diagnostics))
+	} else {
+		result.Summary = fmt.Sprintf("%s has %d errors and %d warnings", result.Source, errorCount, len(diagnostics)-errorCount)
+	}
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
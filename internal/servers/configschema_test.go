@@ -0,0 +1,122 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestDiagnoseConfig(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	valid := "web:\n  port: 8080\nbackup:\n  backup_interval_hours: 24\n  max_backups: 7\ngithub:\n  sync_interval_minutes: 60\ngeneral:\n  default_task_type: work\n"
+
+	cases := []struct {
+		name, text, format string
+		expected           ConfigDiagnostic
+		ok                 bool
+	}{
+		{"unknown key", valid + "  timezon: UTC\n", "yaml",
+			ConfigDiagnostic{Severity: "warning", Path: "general.timezon", Line: 10, Column: 3, Message: `unknown key "timezon" is ignored; did you mean "timezone"?`}, true},
+		{"unknown section", valid + "webb:\n  enabled: true\n", "yaml",
+			ConfigDiagnostic{Severity: "warning", Path: "webb", Line: 10, Column: 1, Message: `unknown key "webb" is ignored; did you mean "web"?`}, true},
+		{"unknown key in a list", valid + "team:\n  members:\n    - name: Sam\n      alias: sam\n", "yaml",
+			ConfigDiagnostic{Severity: "warning", Path: "team.members[0].alias", Line: 13, Column: 7, Message: `unknown key "alias" is ignored; did you mean "aliases"?`}, true},
+		{"wrong type", strings.Replace(valid, "port: 8080", "port: eighty", 1), "yaml",
+			ConfigDiagnostic{Severity: "error", Line: 2, Message: "cannot unmarshal !!str `eighty` into int"}, false},
+		{"bad syntax", valid + "\tfocus_limit: 5\n", "yaml",
+			ConfigDiagnostic{Severity: "error", Line: 9}, false}, // as yaml.v3 reports it
+		{"rule", strings.Replace(valid, "port: 8080", "port: 99999", 1), "yaml",
+			ConfigDiagnostic{Severity: "error", Message: "invalid web port: 99999"}, false},
+		{"json syntax", "{\n  \"web\": {\"port\": 8080,}\n}", "json",
+			ConfigDiagnostic{Severity: "error", Line: 2, Column: 24}, false},
+		{"json type", "{\n  \"general\": {\"focus_limit\": \"five\"}\n}", "json",
+			ConfigDiagnostic{Severity: "error", Path: "general.focus_limit", Line: 2, Column: 30, Message: "expected int, got string"}, false},
+	}
+	for _, c := range cases {
+		config, diagnostics := js.diagnoseConfig([]byte(c.text), c.format)
+		if (config != nil) != c.ok || len(diagnostics) != 1 {
+			t.Errorf("%s: unexpected result %v, %+v", c.name, config != nil, diagnostics)
+			continue
+		}
+		got := diagnostics[0]
+		if c.expected.Message == "" {
+			got.Message = ""
+		}
+		if got != c.expected {
+			t.Errorf("%s: expected %+v, got %+v", c.name, c.expected, diagnostics[0])
+		}
+	}
+}
+
+func TestValidateConfigTool(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	os.WriteFile(filepath.Join(tempDir, "config.yaml"), []byte("web:\n  port: 8080\n  enabld: true\n"), 0644)
+	result, _ := js.ValidateConfig(ctx, CreateMockRequest(map[string]interface{}{}))
+	var validation ConfigValidation
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &validation)
+	if validation.Valid || validation.Source != "config.yaml" || len(validation.Diagnostics) != 2 {
+		t.Errorf("Expected the misspelt key and the missing backup interval reported, got %+v", validation)
+	}
+
+	// update_configuration takes YAML too, and passes warnings back
+	config := "web:\n  port: 9090\n  colour: blue\nbackup:\n  backup_interval_hours: 24\n  max_backups: 7\ngithub:\n  sync_interval_minutes: 60\ngeneral:\n  default_task_type: work\n"
+	result, _ = js.UpdateConfiguration(ctx, CreateMockRequest(map[string]interface{}{"config": config}))
+	if text := result.Content[0].(mcp.TextContent).Text; result.IsError || !strings.Contains(text, `line 3, column 3, web.colour: unknown key \"colour\" is ignored`) {
+		t.Errorf("Expected the update saved with a warning, got %s", text)
+	}
+	if saved, _ := js.loadConfiguration(); saved.Web.Port != 9090 {
+		t.Errorf("Expected the YAML update saved, got port %d", saved.Web.Port)
+	}
+
+	result, _ = js.UpdateConfiguration(ctx, CreateMockRequest(map[string]interface{}{"config": `{"web": {"port": "high"}}`}))
+	if text := result.Content[0].(mcp.TextContent).Text; !result.IsError || !strings.Contains(text, "line 1, column 18, web.port") {
+		t.Errorf("Expected the type error located, got %s", text)
+	}
+}
+
+func TestLoadConfigurationUnknownKeys(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	path := filepath.Join(js.DataDir, "config.yaml")
+
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	os.WriteFile(path, []byte("general:\n  timezone: Europe/Berlin\n  time_zone: UTC\n"), 0644)
+	config, err := js.loadConfiguration()
+	if err != nil {
+		t.Fatalf("Expected unknown keys to load, got %v", err)
+	}
+	if config.General.TimeZone != "Europe/Berlin" {
+		t.Errorf("Expected known keys to load, got time zone %q", config.General.TimeZone)
+	}
+	if !strings.Contains(logged.String(), "general.time_zone") {
+		t.Errorf("Expected a warning for the unknown key, got %q", logged.String())
+	}
+
+	logged.Reset()
+	js.loadConfiguration()
+	if logged.Len() != 0 {
+		t.Errorf("Expected the warning once per file version, got %q", logged.String())
+	}
+
+	os.WriteFile(path, []byte("general:\n  timezone: [1, 2]\n"), 0644)
+	if _, err := js.loadConfiguration(); err == nil {
+		t.Error("Expected an invalid value to fail")
+	}
+
+	os.Remove(path)
+	os.Mkdir(path, 0755) // unreadable as a file
+	if _, err := js.loadConfiguration(); err == nil {
+		t.Error("Expected a read error to be returned rather than the defaults")
+	}
+}
@@ -0,0 +1,382 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const defaultConnectorTime = "23:30"
+
+// connectorTypes are the external sources a connector can summarize
+var connectorTypes = []string{"github_ci", "pagerduty", "jira"}
+
+// connectorNames label each connector type in the daily log
+var connectorNames = map[string]string{"github_ci": "GitHub CI", "pagerduty": "PagerDuty", "jira": "Jira"}
+
+// pagerDutyAPIURL is the PagerDuty REST API; tests replace it
+var pagerDutyAPIURL = "https://api.pagerduty.com/"
+
+// Connector is an external source summarized in the daily log, configured under connectors.sources
+type Connector struct {
+	Name         string   `json:"name,omitempty" yaml:"name,omitempty"`                 // label in the daily log (default per type)
+	Type         string   `json:"type" yaml:"type"`                                     // github_ci, pagerduty or jira
+	Repositories []string `json:"repositories,omitempty" yaml:"repositories,omitempty"` // github_ci: owner/repo (default github.repositories)
+	ServiceIDs   []string `json:"service_ids,omitempty" yaml:"service_ids,omitempty"`   // pagerduty: services to count (default all)
+	JQL          string   `json:"jql,omitempty" yaml:"jql,omitempty"`                   // jira: issues to count (default yours, in jira.projects)
+	Token        string   `json:"token,omitempty" yaml:"token,omitempty"`               // pagerduty: API token or secret: reference (default the pagerduty_token secret)
+}
+
+// label is the connector's name in the daily log
+func (c Connector) label() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return connectorNames[c.Type]
+}
+
+// ExternalSummary is one connector's line in a daily log
+type ExternalSummary struct {
+	Connector string    `json:"connector"`
+	Type      string    `json:"type"`
+	Count     int       `json:"count"`
+	Summary   string    `json:"summary"`
+	Fetched   time.Time `json:"fetched"`
+}
+
+// ConnectorRun reports a run of the connectors for one day
+type ConnectorRun struct {
+	Date      string            `json:"date"`
+	DryRun    bool              `json:"dry_run,omitempty"`
+	Summaries []ExternalSummary `json:"summaries"`
+	Failed    map[string]string `json:"failed,omitempty"` // connector -> error
+	Summary   string            `json:"summary"`
+}
+
+// connectorsJob appends the day's external summaries to the daily log each night
+func (js *JournalService) connectorsJob() ScheduledJob {
+	return ScheduledJob{
+		Name: "connectors",
+		Due: func(now, lastRun time.Time) bool {
+			config, err := js.loadConfiguration()
+			if err != nil || len(config.Connectors.Sources) == 0 {
+				return false
+			}
+			at := config.Connectors.Time
+			if at == "off" {
+				return false
+			}
+			if at == "" {
+				at = defaultConnectorTime
+			}
+			return dueDailyAt(at, js.location(), now, lastRun)
+		},
+		Run: func(ctx context.Context) error {
+			// Failures are logged rather than returned so a broken source is not
+			// retried every minute; the next night tries again
+			run, err := js.runConnectors(ctx, time.Now().In(js.location()), nil, false)
+			if err != nil {
+				return err
+			}
+			for name, failure := range run.Failed {
+				log.Printf("Connector %s failed: %s", name, failure)
+			}
+			return nil
+		},
+	}
+}
+
+// runConnectors summarizes day from the configured connectors, or only those
+// named, and stores the lines in the day's log unless dryRun is set
+func (js *JournalService) runConnectors(ctx context.Context, day time.Time, names []string, dryRun bool) (*ConnectorRun, error) {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	loc := js.location()
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	end := start.AddDate(0, 0, 1)
+
+	run := &ConnectorRun{Date: start.Format("2006-01-02"), DryRun: dryRun, Summaries: []ExternalSummary{}}
+	for _, connector := range config.Connectors.Sources {
+		name := connector.label()
+		if len(names) > 0 && !slices.Contains(names, name) {
+			continue
+		}
+		summary, err := js.summarizeConnector(ctx, config, connector, start, end)
+		if err != nil {
+			if run.Failed == nil {
+				run.Failed = make(map[string]string)
+			}
+			run.Failed[name] = err.Error()
+			continue
+		}
+		summary.Connector = name
+		summary.Type = connector.Type
+		summary.Fetched = time.Now()
+		run.Summaries = append(run.Summaries, summary)
+	}
+
+	run.Summary = fmt.Sprintf("%d of %d connectors summarized %s", len(run.Summaries), len(run.Summaries)+len(run.Failed), run.Date)
+	if dryRun || len(run.Summaries) == 0 {
+		return run, nil
+	}
+	if err := js.saveExternalSummaries(run.Date, run.Summaries); err != nil {
+		return nil, fmt.Errorf("failed to save daily log: %w", err)
+	}
+	return run, nil
+}
+
+// saveExternalSummaries stores summaries in date's log, replacing earlier
+// lines from the same connectors so re-runs do not repeat them
+func (js *JournalService) saveExternalSummaries(date string, summaries []ExternalSummary) error {
+	dailyPath := filepath.Join(js.dataDir(), "daily", date+".json")
+	defer lockFile(dailyPath)()
+
+	activity := DailyActivity{Date: date}
+	if data, err := js.readDataFile(dailyPath); err == nil {
+		json.Unmarshal(data, &activity)
+	}
+	if activity.Tasks == nil {
+		activity.Tasks = make(map[string][]Entry)
+	}
+	for _, summary := range summaries {
+		if i := slices.IndexFunc(activity.External, func(s ExternalSummary) bool { return s.Connector == summary.Connector }); i >= 0 {
+			activity.External[i] = summary
+		} else {
+			activity.External = append(activity.External, summary)
+		}
+	}
+	return js.saveDailyActivity(&activity)
+}
+
+// summarizeConnector fetches a connector's count for [start, end) and words it as one line
+func (js *JournalService) summarizeConnector(ctx context.Context, config *Configuration, connector Connector, start, end time.Time) (ExternalSummary, error) {
+	switch connector.Type {
+	case "github_ci":
+		return js.summarizeGitHubCI(ctx, config, connector, start, end)
+	case "pagerduty":
+		return js.summarizePagerDuty(ctx, connector, start, end)
+	case "jira":
+		return js.summarizeJira(ctx, config, connector, start, end)
+	}
+	return ExternalSummary{}, fmt.Errorf("unknown connector type: %s", connector.Type)
+}
+
+// summarizeGitHubCI counts failed GitHub Actions workflow runs per repository
+func (js *JournalService) summarizeGitHubCI(ctx context.Context, config *Configuration, connector Connector, start, end time.Time) (ExternalSummary, error) {
+	repositories := connector.Repositories
+	if len(repositories) == 0 {
+		repositories = config.GitHub.Repositories
+	}
+	if len(repositories) == 0 {
+		return ExternalSummary{}, fmt.Errorf("github_ci connectors need repositories (or github.repositories)")
+	}
+	gs, err := js.githubService(mcp.CallToolRequest{})
+	if err != nil {
+		return ExternalSummary{}, err
+	}
+
+	created := start.Format(time.RFC3339) + ".." + end.Add(-time.Second).Format(time.RFC3339)
+	total := 0
+	var perRepo []string
+	for _, repository := range repositories {
+		owner, repo, ok := strings.Cut(repository, "/")
+		if !ok {
+			return ExternalSummary{}, fmt.Errorf("invalid repository %q (expected owner/repo)", repository)
+		}
+		opts := &github.ListWorkflowRunsOptions{Status: "failure", Created: created, ListOptions: github.ListOptions{PerPage: 1}}
+		runs, _, err := gs.client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, opts)
+		if err != nil {
+			return ExternalSummary{}, fmt.Errorf("failed to list workflow runs for %s: %w", repository, err)
+		}
+		if count := runs.GetTotalCount(); count > 0 {
+			total += count
+			perRepo = append(perRepo, fmt.Sprintf("%s %d", repository, count))
+		}
+	}
+
+	line := fmt.Sprintf("%s: no failed workflow runs", connector.label())
+	if total > 0 {
+		line = fmt.Sprintf("%s: %d failed workflow %s", connector.label(), total, pluralNoun(total, "run", "runs"))
+		if len(repositories) > 1 {
+			line += " (" + strings.Join(perRepo, ", ") + ")"
+		}
+	}
+	return ExternalSummary{Count: total, Summary: line}, nil
+}
+
+// summarizePagerDuty counts PagerDuty incidents opened in [start, end)
+func (js *JournalService) summarizePagerDuty(ctx context.Context, connector Connector, start, end time.Time) (ExternalSummary, error) {
+	token := connector.Token
+	if token == "" {
+		token = secretRefPrefix + "pagerduty_token"
+	}
+	token, _ = js.resolveSecretRef(token)
+	if token == "" {
+		return ExternalSummary{}, fmt.Errorf("a PagerDuty token is required (store it once with set_secret name=pagerduty_token)")
+	}
+
+	total, high := 0, 0
+	for offset := 0; ; {
+		params := url.Values{
+			"since":  {start.Format(time.RFC3339)},
+			"until":  {end.Format(time.RFC3339)},
+			"limit":  {"100"},
+			"offset": {strconv.Itoa(offset)},
+		}
+		for _, id := range connector.ServiceIDs {
+			params.Add("service_ids[]", id)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, pagerDutyAPIURL+"incidents?"+params.Encode(), nil)
+		if err != nil {
+			return ExternalSummary{}, err
+		}
+		req.Header.Set("Accept", "application/vnd.pagerduty+json;version=2")
+		req.Header.Set("Authorization", "Token token="+token)
+
+		client := &http.Client{Timeout: 30 * time.Second}
+		resp, err := client.Do(req)
+		if err != nil {
+			return ExternalSummary{}, fmt.Errorf("failed to list PagerDuty incidents: %w", err)
+		}
+		var page struct {
+			Incidents []struct {
+				Urgency string `json:"urgency"`
+			} `json:"incidents"`
+			More bool `json:"more"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return ExternalSummary{}, fmt.Errorf("failed to list PagerDuty incidents: %s", resp.Status)
+		}
+		if err != nil {
+			return ExternalSummary{}, fmt.Errorf("failed to read PagerDuty incidents: %w", err)
+		}
+		for _, incident := range page.Incidents {
+			total++
+			if incident.Urgency == "high" {
+				high++
+			}
+		}
+		if !page.More || len(page.Incidents) == 0 {
+			break
+		}
+		offset += len(page.Incidents)
+	}
+
+	line := fmt.Sprintf("%s: no incidents", connector.label())
+	if total > 0 {
+		line = fmt.Sprintf("%s: %d %s", connector.label(), total, pluralNoun(total, "incident", "incidents"))
+		if high > 0 {
+			line += fmt.Sprintf(" (%d high urgency)", high)
+		}
+	}
+	return ExternalSummary{Count: total, Summary: line}, nil
+}
+
+// summarizeJira counts Jira issues resolved in [start, end)
+func (js *JournalService) summarizeJira(ctx context.Context, config *Configuration, connector Connector, start, end time.Time) (ExternalSummary, error) {
+	jiraService, err := js.jiraService(mcp.CallToolRequest{})
+	if err != nil {
+		return ExternalSummary{}, err
+	}
+	issues, err := jiraService.searchIssues(ctx, resolvedJQL(connector.JQL, config.Jira.Projects, start, end))
+	if err != nil {
+		return ExternalSummary{}, fmt.Errorf("failed to search Jira: %w", err)
+	}
+
+	line := fmt.Sprintf("%s: no tickets closed", connector.label())
+	if len(issues) > 0 {
+		var keys []string
+		for _, issue := range issues {
+			keys = append(keys, issue.Key)
+		}
+		shown := keys
+		if len(keys) > 5 {
+			shown = append(keys[:5:5], fmt.Sprintf("%d more", len(keys)-5))
+		}
+		line = fmt.Sprintf("%s: %d %s closed (%s)", connector.label(), len(issues), pluralNoun(len(issues), "ticket", "tickets"), strings.Join(shown, ", "))
+	}
+	return ExternalSummary{Count: len(issues), Summary: line}, nil
+}
+
+// resolvedJQL narrows jql, by default your issues in projects, to those
+// resolved in [start, end)
+func resolvedJQL(jql string, projects []string, start, end time.Time) string {
+	if i := strings.Index(strings.ToUpper(jql), "ORDER BY"); i >= 0 {
+		jql = strings.TrimSpace(jql[:i])
+	}
+	if jql == "" {
+		jql = "assignee = currentUser()"
+		if len(projects) > 0 {
+			jql += fmt.Sprintf(" AND project in (%s)", strings.Join(projects, ", "))
+		}
+	} else {
+		jql = "(" + jql + ")"
+	}
+	return fmt.Sprintf(`%s AND resolved >= "%s" AND resolved < "%s"`, jql, start.Format("2006-01-02 15:04"), end.Format("2006-01-02 15:04"))
+}
+
+// pluralNoun picks singular or plural for n
+func pluralNoun(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
+
+// writeExternalMarkdown lists the connectors' lines under a daily log
+func writeExternalMarkdown(md *strings.Builder, external []ExternalSummary) {
+	md.WriteString("## Elsewhere\n")
+	for _, summary := range external {
+		md.WriteString("- " + summary.Summary + "\n")
+	}
+	md.WriteString("\n")
+}
+
+// RunConnectors fetches the day's summaries from the configured connectors
+// and appends them to the daily log
+func (js *JournalService) RunConnectors(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	loc := js.location()
+	day := time.Now().In(loc)
+	if date := request.GetString("date", ""); date != "" {
+		if validationErr := js.validateDateFormat(date, "date"); validationErr != nil {
+			return mcp.NewToolResultError(validationErr.Error()), nil
+		}
+		day, _ = time.ParseInLocation("2006-01-02", date, loc)
+	}
+
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+	if len(config.Connectors.Sources) == 0 {
+		return mcp.NewToolResultError("No connectors configured (add them under connectors.sources)"), nil
+	}
+	names := request.GetStringSlice("connectors", nil)
+	for _, name := range names {
+		if !slices.ContainsFunc(config.Connectors.Sources, func(c Connector) bool { return c.label() == name }) {
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown connector: %s", name)), nil
+		}
+	}
+
+	run, err := js.runConnectors(ctx, day, names, request.GetString("dry_run", "false") == "true")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to run connectors: %v", err)), nil
+	}
+	result, _ := json.MarshalIndent(run, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
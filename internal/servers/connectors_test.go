@@ -0,0 +1,157 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestResolvedJQL(t *testing.T) {
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	if jql := resolvedJQL("", []string{"OPS"}, start, end); jql != `assignee = currentUser() AND project in (OPS) AND resolved >= "2026-03-02 00:00" AND resolved < "2026-03-03 00:00"` {
+		t.Errorf("Unexpected default JQL: %s", jql)
+	}
+	if jql := resolvedJQL("project = WEB OR project = API order by created", nil, start, end); !strings.HasPrefix(jql, "(project = WEB OR project = API) AND resolved") {
+		t.Errorf("Expected the JQL grouped and its ordering dropped, got %s", jql)
+	}
+}
+
+func TestRunConnectors(t *testing.T) {
+	var pagerDutyQuery, jql string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/github/repos/acme/api/actions/runs":
+			if r.URL.Query().Get("status") != "failure" || !strings.HasPrefix(r.URL.Query().Get("created"), "2026-03-02T00:00:00Z..") {
+				t.Errorf("Unexpected workflow run query: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"total_count": 3, "workflow_runs": []}`))
+		case "/github/repos/acme/web/actions/runs":
+			w.Write([]byte(`{"total_count": 1, "workflow_runs": []}`))
+		case "/pagerduty/incidents":
+			if r.Header.Get("Authorization") != "Token token=pd-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			pagerDutyQuery = r.URL.RawQuery
+			if r.URL.Query().Get("offset") == "0" {
+				w.Write([]byte(`{"incidents": [{"urgency": "high"}, {"urgency": "low"}], "more": true}`))
+			} else {
+				w.Write([]byte(`{"incidents": [{"urgency": "high"}], "more": false}`))
+			}
+		case "/rest/api/2/search":
+			jql = r.URL.Query().Get("jql")
+			w.Write([]byte(`{"total": 2, "issues": [{"key": "OPS-1", "fields": {}}, {"key": "OPS-4", "fields": {}}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	originalGitHub, originalPagerDuty := githubAPIURL, pagerDutyAPIURL
+	githubAPIURL, pagerDutyAPIURL = server.URL+"/github/", server.URL+"/pagerduty/"
+	defer func() { githubAPIURL, pagerDutyAPIURL = originalGitHub, originalPagerDuty }()
+	t.Setenv("JOURNAL_MCP_SECRET_PAGERDUTY_TOKEN", "pd-token")
+	t.Setenv("JOURNAL_MCP_SECRET_JIRA_TOKEN", "jira-token")
+
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	config := defaultConfiguration()
+	config.General.TimeZone = "UTC"
+	config.Secrets.Provider = "env"
+	config.GitHub.Token = "gh-token"
+	config.GitHub.Repositories = []string{"acme/api", "acme/web"}
+	config.Jira.BaseURL = server.URL
+	config.Jira.Projects = []string{"OPS"}
+	config.Connectors.Sources = []Connector{{Type: "github_ci"}, {Type: "pagerduty", ServiceIDs: []string{"PABC"}}, {Type: "jira"}}
+	if err := js.saveConfiguration(config); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	run := func(args map[string]interface{}) ConnectorRun {
+		result, _ := js.RunConnectors(ctx, CreateMockRequest(args))
+		text := result.Content[0].(mcp.TextContent).Text
+		if result.IsError {
+			t.Fatalf("Run failed: %s", text)
+		}
+		var run ConnectorRun
+		json.Unmarshal([]byte(text), &run)
+		return run
+	}
+
+	preview := run(map[string]interface{}{"date": "2026-03-02", "dry_run": "true"})
+	if len(preview.Summaries) != 3 || len(preview.Failed) != 0 {
+		t.Fatalf("Expected three summaries, got %+v", preview)
+	}
+	expected := []string{
+		"GitHub CI: 4 failed workflow runs (acme/api 3, acme/web 1)",
+		"PagerDuty: 3 incidents (2 high urgency)",
+		"Jira: 2 tickets closed (OPS-1, OPS-4)",
+	}
+	for i, line := range expected {
+		if preview.Summaries[i].Summary != line {
+			t.Errorf("Expected %q, got %q", line, preview.Summaries[i].Summary)
+		}
+	}
+	if !strings.Contains(pagerDutyQuery, "service_ids%5B%5D=PABC") || !strings.Contains(pagerDutyQuery, "since=2026-03-02T00%3A00%3A00Z") {
+		t.Errorf("Unexpected PagerDuty query: %s", pagerDutyQuery)
+	}
+	if !strings.Contains(jql, `project in (OPS) AND resolved >= "2026-03-02 00:00"`) {
+		t.Errorf("Unexpected JQL: %s", jql)
+	}
+	if _, err := js.readDataFile(js.DataDir + "/daily/2026-03-02.json"); err == nil {
+		t.Error("Expected a dry run to leave the daily log alone")
+	}
+
+	// Saved runs appear in the daily log, and re-runs replace their lines
+	run(map[string]interface{}{"date": "2026-03-02"})
+	run(map[string]interface{}{"date": "2026-03-02", "connectors": []interface{}{"Jira"}})
+	result, _ := js.GetDailyLog(ctx, CreateMockRequest(map[string]interface{}{"date": "2026-03-02"}))
+	markdown := result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(markdown, "## Elsewhere\n- "+expected[0]) || strings.Count(markdown, "Jira: 2 tickets") != 1 {
+		t.Errorf("Expected each connector listed once, got:\n%s", markdown)
+	}
+
+	// A failing source does not stop the others
+	t.Setenv("JOURNAL_MCP_SECRET_PAGERDUTY_TOKEN", "wrong")
+	failed := run(map[string]interface{}{"date": "2026-03-02", "dry_run": "true"})
+	if len(failed.Summaries) != 2 || !strings.Contains(failed.Failed["PagerDuty"], "401") {
+		t.Errorf("Expected PagerDuty reported as failed, got %+v", failed)
+	}
+
+	result, _ = js.RunConnectors(ctx, CreateMockRequest(map[string]interface{}{"connectors": []interface{}{"Slack"}}))
+	if !result.IsError {
+		t.Error("Expected an unknown connector rejected")
+	}
+	config.Connectors.Sources = append(config.Connectors.Sources, Connector{Type: "jira"})
+	if err := js.validateConfiguration(config); err == nil {
+		t.Error("Expected two connectors named Jira rejected")
+	}
+}
+
+func TestConnectorsJobDue(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	config := defaultConfiguration()
+	config.General.TimeZone = "UTC"
+	js.saveConfiguration(config)
+
+	job := js.connectorsJob()
+	night := time.Date(2026, 3, 2, 23, 45, 0, 0, time.UTC)
+	if job.Due(night, night.AddDate(0, 0, -1)) {
+		t.Error("Expected no run without connectors")
+	}
+	config.Connectors.Sources = []Connector{{Type: "jira"}}
+	js.saveConfiguration(config)
+	if !job.Due(night, night.AddDate(0, 0, -1)) {
+		t.Error("Expected a run after 23:30")
+	}
+	if job.Due(night.Add(-time.Hour), night.AddDate(0, 0, -1)) {
+		t.Error("Expected no run before 23:30")
+	}
+}
@@ -0,0 +1,348 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// DailyLogRepair lists what rebuilding one day's log changed. Entries are
+// named <task ID>/<entry ID>.
+type DailyLogRepair struct {
+	Date       string   `json:"date"`
+	Added      []string `json:"added,omitempty"`      // in a task but missing from the log
+	Removed    []string `json:"removed,omitempty"`    // in the log but in no task on that day
+	Duplicates []string `json:"duplicates,omitempty"` // logged more than once
+	Updated    []string `json:"updated,omitempty"`    // logged copy differs from the task's entry
+	Deleted    bool     `json:"deleted,omitempty"`    // nothing left, so the file was removed
+}
+
+// RebuildDailyLogsResult reports a rebuild_daily_logs run
+type RebuildDailyLogsResult struct {
+	Days      int              `json:"days"` // daily logs after the rebuild
+	Unchanged int              `json:"unchanged"`
+	Repairs   []DailyLogRepair `json:"repairs"`
+	DryRun    bool             `json:"dry_run"`
+	Summary   string           `json:"summary"`
+}
+
+// dailyEntryKey names an entry in a repair report
+func dailyEntryKey(taskID string, entry Entry) string {
+	if entry.ID == "" {
+		return taskID + "/" + entry.Timestamp.Format(time.RFC3339Nano)
+	}
+	return taskID + "/" + entry.ID
+}
+
+// expectedDailyLogs buckets the entries of every task the journal still holds
+// (live, archived and deleted but restorable) by day in general.timezone
+func (js *JournalService) expectedDailyLogs() (map[string]map[string][]Entry, error) {
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tasks: %w", err)
+	}
+	archived, err := js.loadArchivedTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load archived tasks: %w", err)
+	}
+	tasks = append(tasks, archived...)
+	trash, err := js.loadTrash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load trash: %w", err)
+	}
+	seen := make(map[string]bool)
+	for _, task := range tasks {
+		seen[task.ID] = true
+	}
+	for _, item := range trash {
+		if !seen[item.Task.ID] { // only the latest deletion of a task ID
+			seen[item.Task.ID] = true
+			tasks = append(tasks, item.Task)
+		}
+	}
+
+	loc := js.location()
+	days := make(map[string]map[string][]Entry)
+	for _, task := range tasks {
+		for _, entry := range task.Entries {
+			date := entryDate(entry, loc)
+			if days[date] == nil {
+				days[date] = make(map[string][]Entry)
+			}
+			days[date][task.ID] = append(days[date][task.ID], entry)
+		}
+	}
+	for _, day := range days {
+		for _, entries := range day {
+			sortEntriesByTime(entries)
+		}
+	}
+	return days, nil
+}
+
+// compareDailyLog lists how a stored day's entries differ from the expected ones
+func compareDailyLog(date string, logged, expected map[string][]Entry) DailyLogRepair {
+	repair := DailyLogRepair{Date: date}
+	want := make(map[string]Entry)
+	for taskID, entries := range expected {
+		for _, entry := range entries {
+			want[dailyEntryKey(taskID, entry)] = entry
+		}
+	}
+	have := make(map[string]Entry)
+	counts := make(map[string]int)
+	for taskID, entries := range logged {
+		for _, entry := range entries {
+			key := dailyEntryKey(taskID, entry)
+			if counts[key] == 0 {
+				have[key] = entry
+			}
+			counts[key]++
+		}
+	}
+
+	for _, key := range sortedKeys(want) {
+		entry, ok := have[key]
+		switch {
+		case !ok:
+			repair.Added = append(repair.Added, key)
+		case jsonString(entry) != jsonString(want[key]):
+			repair.Updated = append(repair.Updated, key)
+		}
+	}
+	for _, key := range sortedKeys(have) {
+		if _, ok := want[key]; !ok {
+			repair.Removed = append(repair.Removed, key)
+		}
+		if counts[key] > 1 {
+			repair.Duplicates = append(repair.Duplicates, key)
+		}
+	}
+	return repair
+}
+
+func (repair DailyLogRepair) changed() bool {
+	return len(repair.Added)+len(repair.Removed)+len(repair.Duplicates)+len(repair.Updated) > 0 || repair.Deleted
+}
+
+// RebuildDailyLogs regenerates the daily activity files from the task entries,
+// keeping each day's task snapshot, and reports where they had drifted
+func (js *JournalService) RebuildDailyLogs(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	dryRun := request.GetString("dry_run", "false") == "true"
+	expected, err := js.expectedDailyLogs()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to rebuild daily logs: %v", err)), nil
+	}
+
+	dailyDir := filepath.Join(js.dataDir(), "daily")
+	dates := make(map[string]bool)
+	for date := range expected {
+		dates[date] = true
+	}
+	files, _ := filepath.Glob(filepath.Join(dailyDir, "*.json"))
+	for _, file := range files {
+		date := strings.TrimSuffix(filepath.Base(file), ".json")
+		if _, err := time.Parse("2006-01-02", date); err == nil {
+			dates[date] = true
+		}
+	}
+	if !dryRun {
+		if err := os.MkdirAll(dailyDir, 0755); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to create daily directory: %v", err)), nil
+		}
+	}
+
+	result := RebuildDailyLogsResult{Repairs: []DailyLogRepair{}, DryRun: dryRun}
+	for _, date := range sortedKeys(dates) {
+		dailyPath := filepath.Join(dailyDir, date+".json")
+		unlock := lockFile(dailyPath)
+
+		var stored DailyActivity
+		data, readErr := js.readDataFile(dailyPath)
+		if readErr == nil {
+			json.Unmarshal(data, &stored)
+		}
+		rebuilt := DailyActivity{Date: date, Tasks: expected[date], Snapshot: stored.Snapshot}
+		if rebuilt.Tasks == nil {
+			rebuilt.Tasks = make(map[string][]Entry)
+		}
+
+		repair := compareDailyLog(date, stored.Tasks, rebuilt.Tasks)
+		empty := len(rebuilt.Tasks) == 0 && rebuilt.Snapshot == nil
+		repair.Deleted = empty && readErr == nil
+		if !empty {
+			result.Days++
+		}
+		if !repair.changed() {
+			result.Unchanged++
+			unlock()
+			continue
+		}
+		result.Repairs = append(result.Repairs, repair)
+		var writeErr error
+		if !dryRun {
+			if empty {
+				writeErr = removeDataFile(dailyPath)
+			} else {
+				writeErr = js.saveDailyActivity(&rebuilt)
+			}
+		}
+		unlock()
+		if writeErr != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write daily log %s: %v", date, writeErr)), nil
+		}
+	}
+
+	var added, removed, duplicates, updated int
+	for _, repair := range result.Repairs {
+		added += len(repair.Added)
+		removed += len(repair.Removed)
+		duplicates += len(repair.Duplicates)
+		updated += len(repair.Updated)
+	}
+	verb := "Repaired"
+	if dryRun {
+		verb = "Would repair"
+	}
+	result.Summary = fmt.Sprintf("%s %d of %d daily logs: %d entries added, %d removed, %d duplicates dropped, %d updated",
+		verb, len(result.Repairs), len(result.Repairs)+result.Unchanged, added, removed, duplicates, updated)
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// DuplicateCleanup reports a cleanup_duplicates run
+type DuplicateCleanup struct {
+	DailyLogs map[string]int `json:"daily_logs"`          // date -> repeated entries dropped
+	Tasks     map[string]int `json:"tasks"`               // task ID -> repeated entries dropped
+	Conflicts []string       `json:"conflicts,omitempty"` // <task ID>/<entry ID> shared by entries that differ, left alone
+	DryRun    bool           `json:"dry_run"`
+	Summary   string         `json:"summary"`
+}
+
+// dedupeLoggedEntries keeps one copy of each logged entry ID, the last one
+// written, in the place of the first
+func dedupeLoggedEntries(entries []Entry) ([]Entry, int) {
+	position := make(map[string]int)
+	var kept []Entry
+	for _, entry := range entries {
+		if i, ok := position[entry.ID]; ok && entry.ID != "" {
+			kept[i] = entry
+			continue
+		}
+		position[entry.ID] = len(kept)
+		kept = append(kept, entry)
+	}
+	return kept, len(entries) - len(kept)
+}
+
+// dedupeTaskEntries drops exact repeats of a task's entries. Entries that share
+// an ID but differ are both kept and returned as conflicts.
+func dedupeTaskEntries(entries []Entry) ([]Entry, int, []string) {
+	seen := make(map[string][]string) // entry ID -> JSON of the kept entries with it
+	var kept []Entry
+	var conflicts []string
+	for _, entry := range entries {
+		encoded := jsonString(entry)
+		if slices.Contains(seen[entry.ID], encoded) {
+			continue
+		}
+		if len(seen[entry.ID]) > 0 && entry.ID != "" && !slices.Contains(conflicts, entry.ID) {
+			conflicts = append(conflicts, entry.ID)
+		}
+		seen[entry.ID] = append(seen[entry.ID], encoded)
+		kept = append(kept, entry)
+	}
+	return kept, len(entries) - len(kept), conflicts
+}
+
+// CleanupDuplicates removes entries logged more than once from the daily logs
+// and exact repeats of entries from task files
+func (js *JournalService) CleanupDuplicates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	dryRun := request.GetString("dry_run", "false") == "true"
+	result := DuplicateCleanup{DailyLogs: make(map[string]int), Tasks: make(map[string]int), DryRun: dryRun}
+
+	files, _ := filepath.Glob(filepath.Join(js.dataDir(), "daily", "*.json"))
+	sort.Strings(files)
+	for _, file := range files {
+		unlock := lockFile(file)
+		var activity DailyActivity
+		data, err := js.readDataFile(file)
+		if err != nil || json.Unmarshal(data, &activity) != nil {
+			unlock()
+			continue
+		}
+		dropped := 0
+		for taskID, entries := range activity.Tasks {
+			kept, n := dedupeLoggedEntries(entries)
+			activity.Tasks[taskID] = kept
+			dropped += n
+		}
+		if dropped > 0 {
+			result.DailyLogs[activity.Date] = dropped
+			if !dryRun {
+				err = js.saveDailyActivity(&activity)
+			}
+		}
+		unlock()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write daily log %s: %v", activity.Date, err)), nil
+		}
+	}
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+	for _, listed := range tasks {
+		unlock := js.lockTask(listed.ID)
+		task, err := js.loadTask(listed.ID)
+		if err != nil {
+			unlock()
+			continue
+		}
+		kept, dropped, conflicts := dedupeTaskEntries(task.Entries)
+		for _, entryID := range conflicts {
+			result.Conflicts = append(result.Conflicts, task.ID+"/"+entryID)
+		}
+		if dropped > 0 {
+			result.Tasks[task.ID] = dropped
+			if !dryRun {
+				task.Entries = kept
+				err = js.saveTask(task)
+			}
+		}
+		unlock()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to save task %s: %v", task.ID, err)), nil
+		}
+	}
+
+	var inLogs, inTasks int
+	for _, n := range result.DailyLogs {
+		inLogs += n
+	}
+	for _, n := range result.Tasks {
+		inTasks += n
+	}
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+	result.Summary = fmt.Sprintf("%s %d duplicated entries from %d daily logs and %d from %d tasks",
+		verb, inLogs, len(result.DailyLogs), inTasks, len(result.Tasks))
+	if len(result.Conflicts) > 0 {
+		result.Summary += fmt.Sprintf("; %d entry IDs are shared by differing entries and were left alone", len(result.Conflicts))
+	}
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
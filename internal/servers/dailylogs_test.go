@@ -0,0 +1,134 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestRebuildDailyLogs(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "DL-1", "Drifting", "work")
+	task, _ := js.loadTask("DL-1")
+	day := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	first := Entry{ID: "first", Timestamp: day, Content: "Imported twice"}
+	second := Entry{ID: "second", Timestamp: day.Add(time.Hour), Content: "Added by hand"}
+	task.Entries = append(task.Entries, first, second)
+	js.saveTask(task)
+
+	// The day's log has the first entry twice, misses the second and keeps
+	// an entry of a task that no longer exists; another day only has that
+	ghost := Entry{ID: "ghost", Timestamp: day, Content: "Gone"}
+	js.saveDailyActivity(&DailyActivity{Date: "2026-03-02", Tasks: map[string][]Entry{"DL-1": {first, first}, "GONE-1": {ghost}}})
+	js.saveDailyActivity(&DailyActivity{Date: "2026-03-01", Tasks: map[string][]Entry{"GONE-1": {ghost}}})
+	js.saveDailyActivity(&DailyActivity{Date: "2026-02-28", Tasks: map[string][]Entry{}, Snapshot: &TaskSnapshot{TakenAt: day, Tasks: []SnapshotTask{}}})
+
+	rebuild := func(args map[string]interface{}) RebuildDailyLogsResult {
+		result, _ := js.RebuildDailyLogs(ctx, CreateMockRequest(args))
+		var rebuilt RebuildDailyLogsResult
+		json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &rebuilt)
+		return rebuilt
+	}
+
+	dry := rebuild(map[string]interface{}{"dry_run": "true"})
+	repairs := make(map[string]DailyLogRepair)
+	for _, repair := range dry.Repairs {
+		repairs[repair.Date] = repair
+	}
+	repair := repairs["2026-03-02"]
+	if !slices.Equal(repair.Added, []string{"DL-1/second"}) || !slices.Equal(repair.Removed, []string{"GONE-1/ghost"}) ||
+		!slices.Equal(repair.Duplicates, []string{"DL-1/first"}) {
+		t.Errorf("Unexpected repair: %+v", repair)
+	}
+	if !repairs["2026-03-01"].Deleted {
+		t.Errorf("Expected the ghost-only day to be removed, got %+v", dry.Repairs)
+	}
+	if _, ok := repairs["2026-02-28"]; ok {
+		t.Errorf("Expected the snapshot-only day left alone, got %+v", dry.Repairs)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "daily", "2026-03-01.json")); err != nil {
+		t.Fatal("Expected a dry run to change nothing")
+	}
+
+	rebuild(map[string]interface{}{})
+	data, _ := js.readDataFile(filepath.Join(tempDir, "daily", "2026-03-02.json"))
+	var activity DailyActivity
+	json.Unmarshal(data, &activity)
+	if len(activity.Tasks) != 1 || len(activity.Tasks["DL-1"]) != 2 || activity.Tasks["DL-1"][1].ID != "second" {
+		t.Errorf("Expected the day rebuilt from the task, got %+v", activity.Tasks)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "daily", "2026-03-01.json")); !os.IsNotExist(err) {
+		t.Error("Expected the ghost-only day removed")
+	}
+	data, _ = js.readDataFile(filepath.Join(tempDir, "daily", "2026-02-28.json"))
+	activity = DailyActivity{}
+	json.Unmarshal(data, &activity)
+	if activity.Snapshot == nil {
+		t.Error("Expected the snapshot-only day kept")
+	}
+
+	if again := rebuild(map[string]interface{}{}); len(again.Repairs) != 0 || again.Unchanged != again.Days {
+		t.Errorf("Expected a second rebuild to change nothing, got %+v", again)
+	}
+}
+
+func TestCleanupDuplicates(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "DUP-1", "Imported twice", "work")
+	day := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	entry := Entry{ID: "e1", Timestamp: day, Content: "Same entry"}
+
+	// Logging an entry again replaces its copy
+	js.updateDailyLog("DUP-1", entry)
+	edited := entry
+	edited.Content = "Same entry, edited"
+	js.updateDailyLog("DUP-1", edited)
+	dailyPath := filepath.Join(tempDir, "daily", "2026-03-02.json")
+	var activity DailyActivity
+	data, _ := js.readDataFile(dailyPath)
+	json.Unmarshal(data, &activity)
+	if logged := activity.Tasks["DUP-1"]; len(logged) != 1 || logged[0].Content != "Same entry, edited" {
+		t.Fatalf("Expected one up-to-date copy, got %+v", logged)
+	}
+
+	// Older data with repeats in the daily log and the task file
+	js.saveDailyActivity(&DailyActivity{Date: "2026-03-02", Tasks: map[string][]Entry{"DUP-1": {entry, entry, edited}}})
+	task, _ := js.loadTask("DUP-1")
+	clash := Entry{ID: "e1", Timestamp: day.Add(time.Hour), Content: "Different entry, same ID"}
+	task.Entries = append(task.Entries, entry, entry, clash)
+	js.saveTask(task)
+
+	cleanup := func(args map[string]interface{}) DuplicateCleanup {
+		result, _ := js.CleanupDuplicates(ctx, CreateMockRequest(args))
+		var cleanup DuplicateCleanup
+		json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &cleanup)
+		return cleanup
+	}
+	dry := cleanup(map[string]interface{}{"dry_run": "true"})
+	if dry.DailyLogs["2026-03-02"] != 2 || dry.Tasks["DUP-1"] != 1 || !slices.Equal(dry.Conflicts, []string{"DUP-1/e1"}) {
+		t.Fatalf("Unexpected dry run: %+v", dry)
+	}
+
+	cleanup(map[string]interface{}{})
+	data, _ = js.readDataFile(dailyPath)
+	activity = DailyActivity{}
+	json.Unmarshal(data, &activity)
+	if logged := activity.Tasks["DUP-1"]; len(logged) != 1 || logged[0].Content != "Same entry, edited" {
+		t.Errorf("Expected the latest copy kept in the daily log, got %+v", logged)
+	}
+	task, _ = js.loadTask("DUP-1")
+	if len(task.Entries) != 3 || task.Entries[2].Content != "Different entry, same ID" {
+		t.Errorf("Expected one repeat dropped and the clash kept, got %+v", task.Entries)
+	}
+	if again := cleanup(map[string]interface{}{}); len(again.DailyLogs)+len(again.Tasks) != 0 {
+		t.Errorf("Expected nothing left to clean up, got %+v", again)
+	}
+}
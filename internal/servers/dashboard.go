@@ -0,0 +1,205 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ProjectDashboard is everything about one project in a single response
+type ProjectDashboard struct {
+	Project        string           `json:"project"`
+	MatchedBy      string           `json:"matched_by"` // "tag" or "field:<name>"
+	GeneratedAt    time.Time        `json:"generated_at"`
+	Summary        string           `json:"summary"`
+	TotalTasks     int              `json:"total_tasks"`
+	CompletedTasks int              `json:"completed_tasks"`
+	OpenTasks      []DashboardTask  `json:"open_tasks"`
+	Blockers       []DashboardTask  `json:"blockers"`
+	Burndown       []BurndownPoint  `json:"burndown"`
+	RecentActivity []DashboardEntry `json:"recent_activity"`
+	Decisions      []DashboardEntry `json:"decisions"`
+}
+
+// DashboardTask is a task line on a project dashboard
+type DashboardTask struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Status    string   `json:"status"`
+	Priority  string   `json:"priority,omitempty"`
+	DueDate   string   `json:"due_date,omitempty"`
+	Overdue   bool     `json:"overdue,omitempty"`
+	BlockedBy []string `json:"blocked_by,omitempty"`
+}
+
+// DashboardEntry is an entry with the task it belongs to
+type DashboardEntry struct {
+	TaskID    string    `json:"task_id"`
+	Timestamp time.Time `json:"timestamp"`
+	Content   string    `json:"content"`
+	Type      string    `json:"type,omitempty"`
+}
+
+// BurndownPoint is the number of open project tasks at the end of a day
+type BurndownPoint struct {
+	Date string `json:"date"`
+	Open int    `json:"open"`
+}
+
+// completedAt returns when a completed task was last marked completed
+func completedAt(task *Task) (time.Time, bool) {
+	if task.Status != "completed" {
+		return time.Time{}, false
+	}
+	for i := len(task.Entries) - 1; i >= 0; i-- {
+		entry := task.Entries[i]
+		if entry.Type == "status_change" && strings.Contains(entry.Content, "to completed") {
+			return entry.Timestamp, true
+		}
+	}
+	return task.Updated, true
+}
+
+// isDecision reports whether an entry records a decision
+func isDecision(entry Entry) bool {
+	if entry.Type == "decision" {
+		return true
+	}
+	lower := strings.ToLower(entry.Content)
+	return strings.HasPrefix(lower, "decision:") || strings.HasPrefix(lower, "decided ")
+}
+
+// GetProjectDashboard combines open tasks, blockers, burndown, recent activity
+// and decisions for the tasks of one project (a tag or a custom field value)
+func (js *JournalService) GetProjectDashboard(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	project, err := request.RequireString("project")
+	if err != nil {
+		return mcp.NewToolResultError("project is required"), nil
+	}
+	field := request.GetString("field", "")
+
+	days := 14
+	if daysStr := request.GetString("days", ""); daysStr != "" {
+		if _, err := fmt.Sscanf(daysStr, "%d", &days); err != nil || days < 1 || days > 365 {
+			return mcp.NewToolResultError("days must be a number between 1 and 365"), nil
+		}
+	}
+
+	allTasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+
+	dashboard := ProjectDashboard{
+		Project:        project,
+		MatchedBy:      "tag",
+		GeneratedAt:    time.Now(),
+		OpenTasks:      []DashboardTask{},
+		Blockers:       []DashboardTask{},
+		RecentActivity: []DashboardEntry{},
+		Decisions:      []DashboardEntry{},
+	}
+	if field != "" {
+		dashboard.MatchedBy = "field:" + field
+	}
+
+	var tasks []*Task
+	byID := make(map[string]*Task)
+	for _, task := range allTasks {
+		byID[task.ID] = task
+		if field != "" && strings.EqualFold(task.Fields[field], project) ||
+			field == "" && slices.ContainsFunc(task.Tags, func(tag string) bool { return strings.EqualFold(tag, project) }) {
+			tasks = append(tasks, task)
+		}
+	}
+	if len(tasks) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("No tasks found for project %s (matched by %s)", project, dashboard.MatchedBy)), nil
+	}
+
+	now := time.Now()
+	today := now.Format("2006-01-02")
+	since := now.AddDate(0, 0, -days)
+
+	for _, task := range tasks {
+		dashboard.TotalTasks++
+		if task.Status == "completed" {
+			dashboard.CompletedTasks++
+		} else {
+			item := DashboardTask{
+				ID:       task.ID,
+				Title:    task.Title,
+				Status:   task.Status,
+				Priority: task.Priority,
+				DueDate:  task.DueDate,
+				Overdue:  isOverdue(task, today),
+			}
+			for _, depID := range task.DependsOn {
+				if dep, ok := byID[depID]; ok && dep.Status != "completed" {
+					item.BlockedBy = append(item.BlockedBy, depID)
+				}
+			}
+			dashboard.OpenTasks = append(dashboard.OpenTasks, item)
+			if task.Status == "blocked" || len(item.BlockedBy) > 0 {
+				dashboard.Blockers = append(dashboard.Blockers, item)
+			}
+		}
+
+		for _, entry := range task.Entries {
+			item := DashboardEntry{TaskID: task.ID, Timestamp: entry.Timestamp, Content: entry.Content, Type: entry.Type}
+			if isDecision(entry) {
+				dashboard.Decisions = append(dashboard.Decisions, item)
+			}
+			if entry.Timestamp.After(since) {
+				dashboard.RecentActivity = append(dashboard.RecentActivity, item)
+			}
+		}
+	}
+
+	sort.Slice(dashboard.OpenTasks, func(i, j int) bool {
+		a, b := dashboard.OpenTasks[i], dashboard.OpenTasks[j]
+		if a.Overdue != b.Overdue {
+			return a.Overdue
+		}
+		if priorityRank(a.Priority) != priorityRank(b.Priority) {
+			return priorityRank(a.Priority) > priorityRank(b.Priority)
+		}
+		return a.ID < b.ID
+	})
+	newestFirst := func(entries []DashboardEntry) {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Timestamp.After(entries[j].Timestamp) })
+	}
+	newestFirst(dashboard.RecentActivity)
+	newestFirst(dashboard.Decisions)
+	if len(dashboard.RecentActivity) > 25 {
+		dashboard.RecentActivity = dashboard.RecentActivity[:25]
+	}
+
+	// Open tasks at the end of each day in the window
+	for d := days - 1; d >= 0; d-- {
+		day := now.AddDate(0, 0, -d)
+		endOfDay := time.Date(day.Year(), day.Month(), day.Day(), 23, 59, 59, 0, day.Location())
+		open := 0
+		for _, task := range tasks {
+			if task.Created.After(endOfDay) {
+				continue
+			}
+			if doneAt, done := completedAt(task); done && !doneAt.After(endOfDay) {
+				continue
+			}
+			open++
+		}
+		dashboard.Burndown = append(dashboard.Burndown, BurndownPoint{Date: day.Format("2006-01-02"), Open: open})
+	}
+
+	dashboard.Summary = fmt.Sprintf("%s: %d of %d tasks completed, %d open, %d blocked, %d entries in the last %d days",
+		project, dashboard.CompletedTasks, dashboard.TotalTasks, len(dashboard.OpenTasks), len(dashboard.Blockers), len(dashboard.RecentActivity), days)
+
+	resultJSON, _ := json.MarshalIndent(dashboard, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
@@ -0,0 +1,78 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestGetProjectDashboard(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	createTestTask(t, js, "PAY-1", "Design schema", "work")
+	done, _ := js.loadTask("PAY-1")
+	done.Tags = []string{"payments"}
+	done.Status = "completed"
+	done.Created = time.Now().AddDate(0, 0, -5)
+	done.Entries = append(done.Entries,
+		Entry{ID: "e1", Timestamp: time.Now().AddDate(0, 0, -2), Content: "Decision: use idempotency keys", Type: "log"},
+		Entry{ID: "e2", Timestamp: time.Now().AddDate(0, 0, -1), Content: "Status changed from active to completed", Type: "status_change"},
+	)
+	js.saveTask(done)
+
+	createTestTask(t, js, "PAY-2", "Build API", "work")
+	open, _ := js.loadTask("PAY-2")
+	open.Tags = []string{"payments"}
+	open.DependsOn = []string{"PAY-3"}
+	js.saveTask(open)
+
+	createTestTask(t, js, "PAY-3", "Provision queue", "work")
+	other, _ := js.loadTask("PAY-3")
+	other.Fields = map[string]string{"project": "payments"}
+	js.saveTask(other)
+
+	createTestTask(t, js, "OTHER-1", "Unrelated", "work")
+
+	result, _ := js.GetProjectDashboard(ctx, CreateMockRequest(map[string]interface{}{"project": "payments", "days": "7"}))
+	if result.IsError {
+		t.Fatalf("Expected dashboard, got error: %v", result.Content)
+	}
+
+	var dashboard ProjectDashboard
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &dashboard); err != nil {
+		t.Fatalf("Failed to parse dashboard: %v", err)
+	}
+	if dashboard.TotalTasks != 2 || dashboard.CompletedTasks != 1 || len(dashboard.OpenTasks) != 1 {
+		t.Errorf("Expected 2 tagged tasks with 1 completed, got %+v", dashboard)
+	}
+	if len(dashboard.Blockers) != 1 || dashboard.Blockers[0].BlockedBy[0] != "PAY-3" {
+		t.Errorf("Expected PAY-2 blocked by PAY-3, got %+v", dashboard.Blockers)
+	}
+	if len(dashboard.Decisions) != 1 {
+		t.Errorf("Expected 1 decision, got %+v", dashboard.Decisions)
+	}
+	if len(dashboard.Burndown) != 7 {
+		t.Fatalf("Expected 7 burndown points, got %d", len(dashboard.Burndown))
+	}
+	if first, last := dashboard.Burndown[2], dashboard.Burndown[6]; first.Open != 1 || last.Open != 1 {
+		t.Errorf("Expected one open task before and after completion, got %+v", dashboard.Burndown)
+	}
+
+	result, _ = js.GetProjectDashboard(ctx, CreateMockRequest(map[string]interface{}{"project": "payments", "field": "project"}))
+	if result.IsError {
+		t.Fatalf("Expected field-based dashboard, got error: %v", result.Content)
+	}
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &dashboard)
+	if dashboard.TotalTasks != 1 || dashboard.MatchedBy != "field:project" {
+		t.Errorf("Expected PAY-3 matched by field, got %+v", dashboard)
+	}
+
+	result, _ = js.GetProjectDashboard(ctx, CreateMockRequest(map[string]interface{}{"project": "nothing"}))
+	if !result.IsError {
+		t.Error("Expected error for unknown project")
+	}
+}
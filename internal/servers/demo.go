@@ -0,0 +1,267 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Demo mode runs the server against a throwaway data directory seeded with
+// sample tasks, time tracking and 1-on-1 history, so the tools can be tried
+// without touching the real journal.
+
+// demoEntry is a sample entry, placed daysAgo at hour:00
+type demoEntry struct {
+	daysAgo int
+	hour    int
+	content string
+	kind    string
+	minutes int
+}
+
+type demoTask struct {
+	id, title, kind, status, priority string
+	tags                              []string
+	dueInDays                         int // 0 means no due date
+	estimate                          int
+	dependsOn                         []string
+	entries                           []demoEntry
+}
+
+var demoTasks = []demoTask{
+	{
+		id: "DEMO-101", title: "Migrate billing service to the new payments API", kind: "work", status: "active", priority: "high",
+		tags: []string{"billing", "backend"}, dueInDays: 3, estimate: 480,
+		entries: []demoEntry{
+			{daysAgo: 9, hour: 9, content: "Task created: Migrate billing service to the new payments API", kind: "creation"},
+			{daysAgo: 9, hour: 10, content: "Read through the payments API v2 docs; refunds now need an idempotency key"},
+			{daysAgo: 8, hour: 14, content: "Worked 2h00m (12:00-14:00): mapped v1 calls to v2", kind: "time", minutes: 120},
+			{daysAgo: 5, hour: 11, content: "Decision: keep the v1 client behind a flag until the first invoice run passes", kind: "decision"},
+			{daysAgo: 2, hour: 16, content: "Worked 3h00m (13:00-16:00): ported charge and refund flows", kind: "time", minutes: 180},
+			{daysAgo: 1, hour: 10, content: "Staging invoices match production totals for the sample accounts"},
+		},
+	},
+	{
+		id: "DEMO-102", title: "Fix flaky checkout integration test", kind: "work", status: "blocked", priority: "medium",
+		tags: []string{"testing", "ci"},
+		entries: []demoEntry{
+			{daysAgo: 6, hour: 9, content: "Task created: Fix flaky checkout integration test", kind: "creation"},
+			{daysAgo: 6, hour: 15, content: "Fails about one run in ten; the mock payment server sometimes starts after the test"},
+			{daysAgo: 4, hour: 10, content: "Status changed from active to blocked: waiting on the CI team to bump runner memory", kind: "status_change"},
+		},
+	},
+	{
+		id: "DEMO-103", title: "Write runbook for the billing cutover", kind: "work", status: "active", priority: "medium",
+		tags: []string{"billing", "docs"}, dueInDays: 7, dependsOn: []string{"DEMO-101"},
+		entries: []demoEntry{
+			{daysAgo: 3, hour: 9, content: "Task created: Write runbook for the billing cutover", kind: "creation"},
+			{daysAgo: 3, hour: 13, content: "Outlined rollback steps and the on-call contacts"},
+		},
+	},
+	{
+		id: "DEMO-104", title: "Set up alerting for payment webhook failures", kind: "work", status: "completed", priority: "high",
+		tags: []string{"billing", "observability"}, estimate: 120,
+		entries: []demoEntry{
+			{daysAgo: 12, hour: 9, content: "Task created: Set up alerting for payment webhook failures", kind: "creation"},
+			{daysAgo: 12, hour: 11, content: "Worked 1h30m (09:30-11:00): dashboards and the error-rate alert", kind: "time", minutes: 90},
+			{daysAgo: 11, hour: 15, content: "Alert fired correctly in a staging drill; paged the right rotation"},
+			{daysAgo: 11, hour: 16, content: "Status changed from active to completed", kind: "status_change"},
+		},
+	},
+	{
+		id: "DEMO-201", title: "Learn Go generics for the shared utils package", kind: "learning", status: "active", priority: "low",
+		tags: []string{"go", "learning"},
+		entries: []demoEntry{
+			{daysAgo: 10, hour: 18, content: "Task created: Learn Go generics for the shared utils package", kind: "creation"},
+			{daysAgo: 10, hour: 19, content: "Worked through the type parameters tutorial"},
+			{daysAgo: 7, hour: 19, content: "Rewrote the slice helpers with constraints; cut 200 lines of duplication"},
+		},
+	},
+	{
+		id: "DEMO-301", title: "Investigate p99 latency spike on the search endpoint", kind: "investigation", status: "completed", priority: "high",
+		tags: []string{"performance", "search"},
+		entries: []demoEntry{
+			{daysAgo: 15, hour: 10, content: "Task created: Investigate p99 latency spike on the search endpoint", kind: "creation"},
+			{daysAgo: 15, hour: 14, content: "Spike lines up with the nightly reindex job"},
+			{daysAgo: 14, hour: 11, content: "Moved the reindex to a replica; p99 back under 200ms"},
+			{daysAgo: 14, hour: 12, content: "Status changed from active to completed", kind: "status_change"},
+		},
+	},
+	{
+		id: "DEMO-401", title: "Plan the team offsite agenda", kind: "personal", status: "someday",
+		tags: []string{"team"},
+		entries: []demoEntry{
+			{daysAgo: 20, hour: 17, content: "Task created: Plan the team offsite agenda", kind: "creation"},
+		},
+	},
+}
+
+var demoOneOnOnes = []struct {
+	daysAgo                   int
+	insights, todos, feedback []string
+	notes                     string
+}{
+	{
+		daysAgo:  14,
+		insights: []string{"Billing migration is the team's top priority this quarter"},
+		todos:    []string{"Share the payments API v2 migration plan", "Pair with Sam on the webhook alerts"},
+		feedback: []string{"The latency investigation write-up was clear and quick"},
+		notes:    "Talked about taking on more cross-team work.",
+	},
+	{
+		daysAgo:  7,
+		insights: []string{"CI capacity is a shared blocker across three teams"},
+		todos:    []string{"Escalate runner memory with the CI team", "Draft the billing cutover runbook"},
+		notes:    "Agreed to demo the new billing flow at the next all-hands.",
+	},
+}
+
+// demoTourSteps walks through the main tools against the seeded data
+var demoTourSteps = []struct{ tool, try string }{
+	{"list_tasks", "list the active tasks, then only the blocked ones"},
+	{"get_task", "open DEMO-101 to see its entries, estimate and tracked time"},
+	{"add_task_entry", "log progress on DEMO-101"},
+	{"start_timer", "start a timer on DEMO-103, then stop it with stop_timer"},
+	{"update_task_status", "unblock DEMO-102 or try to complete DEMO-103 while DEMO-101 is still open"},
+	{"create_task", "create a task of your own, with a due date and an estimate"},
+	{"get_daily_log", "view yesterday's log"},
+	{"get_weekly_log", "view this week's log"},
+	{"search_entries", "search for \"billing\""},
+	{"get_one_on_one_history", "review the two past 1-on-1s and their todos"},
+	{"create_one_on_one", "record today's 1-on-1"},
+	{"get_analytics_report", "generate a productivity report for the month"},
+	{"get_task_recommendations", "ask what to work on next"},
+	{"export_data", "export everything as markdown"},
+}
+
+// seedDemoData fills the service's data directory with sample tasks, daily logs and 1-on-1s dated relative to now
+func (js *JournalService) seedDemoData(now time.Time) error {
+	at := func(daysAgo, hour int) time.Time {
+		day := now.AddDate(0, 0, -daysAgo)
+		return time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, now.Location())
+	}
+
+	for _, sample := range demoTasks {
+		task := &Task{
+			ID:              sample.id,
+			Title:           sample.title,
+			Type:            sample.kind,
+			Tags:            sample.tags,
+			Status:          sample.status,
+			Priority:        sample.priority,
+			EstimateMinutes: sample.estimate,
+			DependsOn:       sample.dependsOn,
+			Entries:         []Entry{},
+		}
+		if sample.dueInDays != 0 {
+			task.DueDate = now.AddDate(0, 0, sample.dueInDays).Format("2006-01-02")
+		}
+		for i, e := range sample.entries {
+			entry := Entry{
+				ID:        fmt.Sprintf("entry_%d", at(e.daysAgo, e.hour).UnixNano()+int64(i)),
+				Timestamp: at(e.daysAgo, e.hour),
+				Content:   e.content,
+				Type:      e.kind,
+				Minutes:   e.minutes,
+			}
+			if entry.Type == "" {
+				entry.Type = "log"
+			}
+			task.Entries = append(task.Entries, entry)
+			js.updateDailyLog(task.ID, entry)
+		}
+		task.Created = task.Entries[0].Timestamp
+		task.Updated = task.Entries[len(task.Entries)-1].Timestamp
+
+		if err := js.saveTask(task); err != nil {
+			return fmt.Errorf("failed to save %s: %w", task.ID, err)
+		}
+	}
+
+	for _, sample := range demoOneOnOnes {
+		meeting := OneOnOne{
+			Date:     at(sample.daysAgo, 0).Format("2006-01-02"),
+			Insights: sample.insights,
+			Todos:    sample.todos,
+			Feedback: sample.feedback,
+			Notes:    sample.notes,
+			Created:  at(sample.daysAgo, 15),
+		}
+		if err := js.saveOneOnOne(&meeting); err != nil {
+			return fmt.Errorf("failed to save 1-on-1: %w", err)
+		}
+		if _, err := js.addFeedbackItems(meetingFeedbackItems(&meeting)); err != nil {
+			return fmt.Errorf("failed to save feedback: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// NewDemoJournalService creates a journal service on a temporary data
+// directory seeded with sample data. The returned cleanup removes the directory.
+func NewDemoJournalService() (*JournalService, func(), error) {
+	dataDir, err := os.MkdirTemp("", "journal-mcp-demo-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create demo directory: %w", err)
+	}
+	cleanup := func() { os.RemoveAll(dataDir) }
+
+	if err := ensureDataDirs(dataDir); err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to prepare demo directory: %w", err)
+	}
+	js := &JournalService{DataDir: dataDir}
+	if err := js.seedDemoData(time.Now()); err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return js, cleanup, nil
+}
+
+// DemoTourPrompt guides the client through the tools using the demo data
+func (js *JournalService) DemoTourPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	registered := make(map[string]bool, len(js.Tools))
+	for _, name := range js.Tools {
+		registered[name] = true
+	}
+
+	var md strings.Builder
+	md.WriteString("# journal-mcp demo\n\n")
+	md.WriteString(fmt.Sprintf("This server is running on a temporary journal in %s, seeded with sample tasks (DEMO-101 to DEMO-401), "+
+		"two weeks of entries and time tracking, and two past 1-on-1s. Nothing here touches a real journal, and it is deleted on exit.\n\n", js.dataDir()))
+
+	md.WriteString("## Tour\n\n")
+	step := 0
+	toured := make(map[string]bool)
+	for _, s := range demoTourSteps {
+		if len(registered) > 0 && !registered[s.tool] {
+			continue
+		}
+		step++
+		toured[s.tool] = true
+		md.WriteString(fmt.Sprintf("%d. `%s`: %s\n", step, s.tool, s.try))
+	}
+
+	var others []string
+	for name := range registered {
+		if !toured[name] {
+			others = append(others, "`"+name+"`")
+		}
+	}
+	if len(others) > 0 {
+		sort.Strings(others)
+		md.WriteString(fmt.Sprintf("\n## Other tools\n\n%s\n", strings.Join(others, ", ")))
+	}
+
+	return promptResult("Guided tour of journal-mcp on sample data", md.String(),
+		"Walk me through journal-mcp one step at a time using the tour above. For each step, explain in a "+
+			"sentence what the tool is for, call it on the demo data, and show me the interesting part of the "+
+			"result before moving on. Pause after every few steps and ask whether to continue or to try one of "+
+			"the other tools. To use the server for real, I can restart it without the demo argument."), nil
+}
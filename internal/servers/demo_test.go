@@ -0,0 +1,55 @@
+package servers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestDemoJournalService(t *testing.T) {
+	js, cleanup, err := NewDemoJournalService()
+	if err != nil {
+		t.Fatalf("Demo setup failed: %v", err)
+	}
+	ctx := context.Background()
+
+	tasks, _ := js.loadAllTasks()
+	if len(tasks) != len(demoTasks) {
+		t.Fatalf("Expected %d demo tasks, got %d", len(demoTasks), len(tasks))
+	}
+	meetings, _ := js.loadOneOnOnes()
+	if len(meetings) != len(demoOneOnOnes) {
+		t.Errorf("Expected %d demo 1-on-1s, got %d", len(demoOneOnOnes), len(meetings))
+	}
+
+	// The history shows up in the same places real entries do
+	result, _ := js.SearchEntries(ctx, CreateMockRequest(map[string]interface{}{"query": "idempotency"}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "DEMO-101") {
+		t.Errorf("Expected the seeded entry to be searchable, got %s", text)
+	}
+	task, _ := js.loadTask("DEMO-101")
+	if trackedMinutes(task) != 300 || task.DueDate == "" {
+		t.Errorf("Expected tracked time and a due date, got %+v", task)
+	}
+	if _, err := os.Stat(filepath.Join(js.DataDir, "daily", task.Entries[1].Timestamp.Format("2006-01-02")+".json")); err != nil {
+		t.Errorf("Expected a daily log for the seeded entries: %v", err)
+	}
+
+	js.Tools = []string{"list_tasks", "get_task", "apply_encryption"}
+	text := promptText(t, js.DemoTourPrompt, nil)
+	if !strings.Contains(text, "1. `list_tasks`") || !strings.Contains(text, "2. `get_task`") || strings.Contains(text, "`search_entries`") {
+		t.Errorf("Expected the tour limited to registered tools, got:\n%s", text)
+	}
+	if !strings.Contains(text, "## Other tools\n\n`apply_encryption`") {
+		t.Errorf("Expected the remaining tools listed, got:\n%s", text)
+	}
+
+	cleanup()
+	if _, err := os.Stat(js.DataDir); !os.IsNotExist(err) {
+		t.Errorf("Expected the demo directory to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// openDependencies returns the dependencies of task that are not completed.
+// Dependencies that no longer exist are ignored.
+func (js *JournalService) openDependencies(task *Task) []*Task {
+	var open []*Task
+	for _, depID := range task.DependsOn {
+		dep, err := js.loadTask(depID)
+		if err != nil {
+			continue
+		}
+		if dep.Status != "completed" {
+			open = append(open, dep)
+		}
+	}
+	return open
+}
+
+// dependencyPath returns the chain of dependencies from fromID to toID, or nil
+// if toID is not reachable
+func (js *JournalService) dependencyPath(fromID, toID string) []string {
+	visited := make(map[string]bool)
+	var walk func(id string) []string
+	walk = func(id string) []string {
+		if id == toID {
+			return []string{id}
+		}
+		if visited[id] {
+			return nil
+		}
+		visited[id] = true
+
+		task, err := js.loadTask(id)
+		if err != nil {
+			return nil
+		}
+		for _, depID := range task.DependsOn {
+			if path := walk(depID); path != nil {
+				return append([]string{id}, path...)
+			}
+		}
+		return nil
+	}
+	return walk(fromID)
+}
+
+// AddTaskDependency records that a task cannot be completed before another
+func (js *JournalService) AddTaskDependency(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError("task_id is required"), nil
+	}
+	dependsOn, err := request.RequireString("depends_on")
+	if err != nil {
+		return mcp.NewToolResultError("depends_on is required"), nil
+	}
+
+	if taskID == dependsOn {
+		return mcp.NewToolResultError("A task cannot depend on itself"), nil
+	}
+
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load task: %v", err)), nil
+	}
+	dep, err := js.loadTask(dependsOn)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Dependency not found: %s", dependsOn)), nil
+	}
+
+	if slices.Contains(task.DependsOn, dependsOn) {
+		return mcp.NewToolResultText(fmt.Sprintf("Task %s already depends on %s", taskID, dependsOn)), nil
+	}
+	if cycle := js.dependencyPath(dependsOn, taskID); cycle != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Adding this dependency would create a cycle: %s -> %s", taskID, strings.Join(cycle, " -> "))), nil
+	}
+
+	task.DependsOn = append(task.DependsOn, dependsOn)
+	task.Updated = time.Now()
+
+	entry := Entry{
+		ID:        generateEntryID(),
+		Timestamp: time.Now(),
+		Content:   fmt.Sprintf("Now depends on %s: %s", dep.ID, dep.Title),
+		Type:      "dependency",
+	}
+	task.Entries = append(task.Entries, entry)
+
+	if err := js.saveTask(task); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task: %v", err)), nil
+	}
+	js.updateDailyLog(taskID, entry)
+
+	return mcp.NewToolResultText(fmt.Sprintf("Task %s now depends on %s", taskID, dependsOn)), nil
+}
+
+// RemoveTaskDependency removes a dependency between two tasks
+func (js *JournalService) RemoveTaskDependency(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError("task_id is required"), nil
+	}
+	dependsOn, err := request.RequireString("depends_on")
+	if err != nil {
+		return mcp.NewToolResultError("depends_on is required"), nil
+	}
+
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load task: %v", err)), nil
+	}
+
+	index := slices.Index(task.DependsOn, dependsOn)
+	if index < 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Task %s does not depend on %s", taskID, dependsOn)), nil
+	}
+
+	task.DependsOn = slices.Delete(task.DependsOn, index, index+1)
+	task.Updated = time.Now()
+
+	entry := Entry{
+		ID:        generateEntryID(),
+		Timestamp: time.Now(),
+		Content:   fmt.Sprintf("No longer depends on %s", dependsOn),
+		Type:      "dependency",
+	}
+	task.Entries = append(task.Entries, entry)
+
+	if err := js.saveTask(task); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task: %v", err)), nil
+	}
+	js.updateDailyLog(taskID, entry)
+
+	return mcp.NewToolResultText(fmt.Sprintf("Removed dependency of %s on %s", taskID, dependsOn)), nil
+}
+
+// formatDependencies renders the "Blocked by" section of a task's markdown
+func (js *JournalService) formatDependencies(task *Task) string {
+	if len(task.DependsOn) == 0 {
+		return ""
+	}
+
+	var md strings.Builder
+	md.WriteString("**Blocked by:**\n")
+	for _, depID := range task.DependsOn {
+		dep, err := js.loadTask(depID)
+		if err != nil {
+			md.WriteString(fmt.Sprintf("- %s (missing)\n", depID))
+			continue
+		}
+		mark := " "
+		if dep.Status == "completed" {
+			mark = "x"
+		}
+		md.WriteString(fmt.Sprintf("- [%s] %s: %s (%s)\n", mark, dep.ID, dep.Title, dep.Status))
+	}
+	md.WriteString("\n")
+	return md.String()
+}
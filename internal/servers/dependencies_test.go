@@ -0,0 +1,53 @@
+package servers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestTaskDependencies(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	createTestTask(t, js, "DEP-1", "Ship feature", "work")
+	createTestTask(t, js, "DEP-2", "Security review", "work")
+	createTestTask(t, js, "DEP-3", "Threat model", "work")
+
+	for _, args := range []map[string]interface{}{
+		{"task_id": "DEP-1", "depends_on": "DEP-2"},
+		{"task_id": "DEP-2", "depends_on": "DEP-3"},
+	} {
+		if result, _ := js.AddTaskDependency(ctx, CreateMockRequest(args)); result.IsError {
+			t.Fatalf("Unexpected error: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+	}
+
+	result, _ := js.AddTaskDependency(ctx, CreateMockRequest(map[string]interface{}{"task_id": "DEP-3", "depends_on": "DEP-1"}))
+	if !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "DEP-3 -> DEP-1 -> DEP-2 -> DEP-3") {
+		t.Errorf("Expected cycle to be rejected, got %v", result.Content)
+	}
+
+	result, _ = js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": "DEP-1", "status": "completed"}))
+	if !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "DEP-2 (active)") {
+		t.Errorf("Expected completion to be refused, got %v", result.Content)
+	}
+
+	result, _ = js.GetTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "DEP-1"}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "**Blocked by:**\n- [ ] DEP-2: Security review (active)") {
+		t.Errorf("Expected blocked-by section, got:\n%s", text)
+	}
+
+	result, _ = js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": "DEP-1", "status": "completed", "force": "true"}))
+	if result.IsError {
+		t.Errorf("Expected force to override, got %v", result.Content)
+	}
+
+	js.RemoveTaskDependency(ctx, CreateMockRequest(map[string]interface{}{"task_id": "DEP-2", "depends_on": "DEP-3"}))
+	result, _ = js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": "DEP-2", "status": "completed"}))
+	if result.IsError {
+		t.Errorf("Expected completion once the dependency is removed, got %v", result.Content)
+	}
+}
@@ -0,0 +1,554 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	digestDueWindowDays  = 7    // due dates this many days ahead are "due soon"
+	digestMaxAttempts    = 5    // deliveries are dropped, with a notification, after this many failures
+	discordMessageLimit  = 2000 // Discord rejects longer webhook messages
+	defaultDigestTime    = "08:00"
+	defaultDigestWeekday = time.Monday
+)
+
+// digestChannelTypes are the supported delivery channels
+var digestChannelTypes = []string{"email", "slack", "discord"}
+
+// sendMail delivers email; tests replace it
+var sendMail = smtp.SendMail
+
+// DigestChannel is somewhere the digest is delivered, configured under digest.channels
+type DigestChannel struct {
+	Name       string   `json:"name" yaml:"name"`
+	Type       string   `json:"type" yaml:"type"`                                   // email, slack or discord
+	WebhookURL string   `json:"webhook_url,omitempty" yaml:"webhook_url,omitempty"` // slack and discord; may be a secret: reference
+	To         []string `json:"to,omitempty" yaml:"to,omitempty"`                   // email recipients
+	Template   string   `json:"template,omitempty" yaml:"template,omitempty"`       // Go text/template over the digest (default per type)
+	QuietHours string   `json:"quiet_hours,omitempty" yaml:"quiet_hours,omitempty"` // overrides digest.quiet_hours, e.g. 22:00-07:00
+}
+
+// Digest summarizes a period for the notification digest
+type Digest struct {
+	Period    string       `json:"period"` // daily or weekly
+	Title     string       `json:"title"`
+	From      string       `json:"from"`
+	To        string       `json:"to"`
+	Completed []DigestItem `json:"completed"`
+	DueSoon   []DigestItem `json:"due_soon"` // overdue or due within the next week
+	Blocked   []DigestItem `json:"blocked"`
+}
+
+// DigestItem is one task in a digest section
+type DigestItem struct {
+	TaskID string `json:"task_id"`
+	Title  string `json:"title"`
+	Due    string `json:"due,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// pendingDigest is a rendered digest waiting for delivery to one channel
+type pendingDigest struct {
+	Channel   string    `json:"channel"`
+	Subject   string    `json:"subject"`
+	Body      string    `json:"body"`
+	Created   time.Time `json:"created"`
+	Attempts  int       `json:"attempts,omitempty"`
+	LastError string    `json:"last_error,omitempty"`
+}
+
+// DigestDelivery reports what happened to a digest on each channel
+type DigestDelivery struct {
+	Period    string            `json:"period"`
+	DryRun    bool              `json:"dry_run,omitempty"`
+	Delivered []string          `json:"delivered,omitempty"`
+	Queued    []string          `json:"queued,omitempty"` // waiting for quiet hours to end
+	Failed    map[string]string `json:"failed,omitempty"` // channel -> error; retried by the scheduler
+	Previews  map[string]string `json:"previews,omitempty"`
+	Summary   string            `json:"summary"`
+}
+
+// digestTemplate returns the default template for a channel type: Slack and
+// Discord get their own bold markup, email is plain text
+func digestTemplate(channelType string) string {
+	bold := ""
+	switch channelType {
+	case "slack":
+		bold = "*"
+	case "discord":
+		bold = "**"
+	}
+	section := func(heading, field, empty string) string {
+		return fmt.Sprintf("%[1]s%[2]s ({{len .%[3]s}})%[1]s\n{{range .%[3]s}}- {{.TaskID}}: {{.Title}}{{if .Detail}} ({{.Detail}}){{end}}\n{{else}}- %[4]s\n{{end}}", bold, heading, field, empty)
+	}
+	return bold + "{{.Title}}" + bold + "\n\n" +
+		section("Completed", "Completed", "nothing completed") + "\n" +
+		section("Due soon", "DueSoon", "nothing due") + "\n" +
+		section("Blocked", "Blocked", "nothing blocked")
+}
+
+// parseQuietHours parses HH:MM-HH:MM into minutes after midnight
+func parseQuietHours(spec string) (start, end int, err error) {
+	from, to, ok := strings.Cut(spec, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid quiet hours %q (expected HH:MM-HH:MM)", spec)
+	}
+	var times [2]int
+	for i, clock := range []string{from, to} {
+		t, err := time.Parse("15:04", strings.TrimSpace(clock))
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid quiet hours %q (expected HH:MM-HH:MM)", spec)
+		}
+		times[i] = t.Hour()*60 + t.Minute()
+	}
+	return times[0], times[1], nil
+}
+
+// inQuietHours reports whether now falls in quiet hours such as 22:00-07:00,
+// which may wrap past midnight
+func inQuietHours(spec string, now time.Time) bool {
+	if spec == "" {
+		return false
+	}
+	start, end, err := parseQuietHours(spec)
+	if err != nil || start == end {
+		return false
+	}
+	minute := now.Hour()*60 + now.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// buildDigest collects completions in the period, open tasks due within a
+// week (or overdue) and blocked tasks
+func (js *JournalService) buildDigest(period string, now time.Time) (*Digest, error) {
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	now = now.In(js.location())
+	today := now.Format("2006-01-02")
+	from := now.AddDate(0, 0, -1)
+	if period == "weekly" {
+		from = now.AddDate(0, 0, -7)
+	}
+	dueBy := now.AddDate(0, 0, digestDueWindowDays).Format("2006-01-02")
+	digest := &Digest{
+		Period:    period,
+		From:      from.Format("2006-01-02"),
+		To:        today,
+		Completed: []DigestItem{},
+		DueSoon:   []DigestItem{},
+		Blocked:   []DigestItem{},
+	}
+	periodName := "Daily"
+	if period == "weekly" {
+		periodName = "Weekly"
+	}
+	digest.Title = fmt.Sprintf("%s journal digest for %s", periodName, today)
+
+	for _, task := range tasks {
+		if at, ok := completedAt(task); ok && at.After(from) && !at.After(now) {
+			digest.Completed = append(digest.Completed, DigestItem{TaskID: task.ID, Title: task.Title})
+		}
+		if task.DueDate != "" && task.Status != "completed" && task.DueDate <= dueBy {
+			detail := "due " + task.DueDate
+			if isOverdue(task, today) {
+				detail = "overdue since " + task.DueDate
+			}
+			digest.DueSoon = append(digest.DueSoon, DigestItem{TaskID: task.ID, Title: task.Title, Due: task.DueDate, Detail: detail})
+		}
+		if task.Status == "blocked" {
+			digest.Blocked = append(digest.Blocked, DigestItem{TaskID: task.ID, Title: task.Title})
+		} else if task.Status == "active" {
+			if open := js.openDependencies(task); len(open) > 0 {
+				var ids []string
+				for _, dep := range open {
+					ids = append(ids, dep.ID)
+				}
+				digest.Blocked = append(digest.Blocked, DigestItem{TaskID: task.ID, Title: task.Title, Detail: "waiting on " + strings.Join(ids, ", ")})
+			}
+		}
+	}
+	for _, items := range [][]DigestItem{digest.Completed, digest.Blocked} {
+		slices.SortFunc(items, func(a, b DigestItem) int { return strings.Compare(a.TaskID, b.TaskID) })
+	}
+	slices.SortFunc(digest.DueSoon, func(a, b DigestItem) int {
+		if c := strings.Compare(a.Due, b.Due); c != 0 {
+			return c
+		}
+		return strings.Compare(a.TaskID, b.TaskID)
+	})
+	return digest, nil
+}
+
+// renderDigest renders a digest with the channel's template or its type's default
+func renderDigest(channel DigestChannel, digest *Digest) (string, error) {
+	text := channel.Template
+	if text == "" {
+		text = digestTemplate(channel.Type)
+	}
+	tmpl, err := template.New(channel.Name).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template for channel %s: %w", channel.Name, err)
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, digest); err != nil {
+		return "", fmt.Errorf("failed to render digest for channel %s: %w", channel.Name, err)
+	}
+	return out.String(), nil
+}
+
+// deliverDigest sends a rendered digest to one channel
+func (js *JournalService) deliverDigest(channel DigestChannel, subject, body string) error {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return err
+	}
+
+	switch channel.Type {
+	case "slack", "discord":
+		url, err := js.resolveSecretRef(channel.WebhookURL)
+		if err != nil || url == "" {
+			return fmt.Errorf("no webhook URL for channel %s", channel.Name)
+		}
+		payload := map[string]string{"text": body}
+		if channel.Type == "discord" {
+			if runes := []rune(body); len(runes) > discordMessageLimit {
+				body = string(runes[:discordMessageLimit-1]) + "…"
+			}
+			payload = map[string]string{"content": body}
+		}
+		data, _ := json.Marshal(payload)
+		client := &http.Client{Timeout: 30 * time.Second}
+		resp, err := client.Post(url, "application/json", bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("%s webhook returned %s", channel.Type, resp.Status)
+		}
+		return nil
+
+	case "email":
+		settings := config.Digest.SMTP
+		if settings.Host == "" || settings.From == "" {
+			return fmt.Errorf("digest.smtp.host and digest.smtp.from are required for email channel %s", channel.Name)
+		}
+		port := settings.Port
+		if port == 0 {
+			port = 587
+		}
+		var auth smtp.Auth
+		if settings.Username != "" {
+			password, err := js.resolveSecretRef(settings.Password)
+			if err != nil {
+				return fmt.Errorf("failed to read SMTP password: %w", err)
+			}
+			auth = smtp.PlainAuth("", settings.Username, password, settings.Host)
+		}
+		var message strings.Builder
+		message.WriteString("From: " + settings.From + "\r\n")
+		message.WriteString("To: " + strings.Join(channel.To, ", ") + "\r\n")
+		message.WriteString("Subject: " + subject + "\r\n")
+		message.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+		message.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n")
+		message.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+		return sendMail(fmt.Sprintf("%s:%d", settings.Host, port), auth, settings.From, channel.To, []byte(message.String()))
+	}
+	return fmt.Errorf("unknown channel type %q", channel.Type)
+}
+
+func (js *JournalService) digestQueuePath() string {
+	return filepath.Join(js.dataDir(), ".journal-mcp", "digest-queue.json")
+}
+
+func (js *JournalService) loadDigestQueue() []pendingDigest {
+	var queue []pendingDigest
+	if data, err := js.readDataFile(js.digestQueuePath()); err == nil {
+		json.Unmarshal(data, &queue)
+	}
+	return queue
+}
+
+func (js *JournalService) saveDigestQueue(queue []pendingDigest) error {
+	if err := os.MkdirAll(filepath.Dir(js.digestQueuePath()), 0755); err != nil {
+		return err
+	}
+	data, _ := json.MarshalIndent(queue, "", "  ")
+	return js.writeDataFile(js.digestQueuePath(), data, 0644)
+}
+
+// queueDigest renders the digest for each channel (all when names is empty) and queues it for delivery
+func (js *JournalService) queueDigest(period string, names []string, now time.Time) error {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return err
+	}
+	digest, err := js.buildDigest(period, now)
+	if err != nil {
+		return err
+	}
+
+	defer lockFile(js.digestQueuePath())()
+	queue := js.loadDigestQueue()
+	for _, channel := range config.Digest.Channels {
+		if len(names) > 0 && !slices.Contains(names, channel.Name) {
+			continue
+		}
+		body, err := renderDigest(channel, digest)
+		if err != nil {
+			return err
+		}
+		queue = append(queue, pendingDigest{Channel: channel.Name, Subject: digest.Title, Body: body, Created: now})
+	}
+	return js.saveDigestQueue(queue)
+}
+
+// flushDigestQueue delivers queued digests to channels outside quiet hours.
+// Failed deliveries stay queued; after digestMaxAttempts they are dropped
+// with a notification.
+func (js *JournalService) flushDigestQueue(now time.Time, ignoreQuietHours bool, result *DigestDelivery) error {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return err
+	}
+	channels := make(map[string]DigestChannel)
+	for _, channel := range config.Digest.Channels {
+		channels[channel.Name] = channel
+	}
+
+	defer lockFile(js.digestQueuePath())()
+	var remaining []pendingDigest
+	for _, pending := range js.loadDigestQueue() {
+		channel, ok := channels[pending.Channel]
+		if !ok {
+			continue // the channel was removed from the configuration
+		}
+		quiet := channel.QuietHours
+		if quiet == "" {
+			quiet = config.Digest.QuietHours
+		}
+		if !ignoreQuietHours && inQuietHours(quiet, now.In(js.location())) {
+			remaining = append(remaining, pending)
+			if result != nil {
+				result.Queued = append(result.Queued, channel.Name)
+			}
+			continue
+		}
+
+		if err := js.deliverDigest(channel, pending.Subject, pending.Body); err != nil {
+			pending.Attempts++
+			pending.LastError = err.Error()
+			if result != nil {
+				result.Failed[channel.Name] = err.Error()
+			}
+			if pending.Attempts >= digestMaxAttempts {
+				js.notify("digest_failed", "", fmt.Sprintf("Gave up delivering the digest to %s after %d attempts: %v", channel.Name, pending.Attempts, err))
+				continue
+			}
+			remaining = append(remaining, pending)
+			continue
+		}
+		if result != nil {
+			result.Delivered = append(result.Delivered, channel.Name)
+		}
+	}
+	return js.saveDigestQueue(remaining)
+}
+
+// digestDue reports whether the configured digest is due at now
+func (js *JournalService) digestDue(now, lastRun time.Time) bool {
+	config, err := js.loadConfiguration()
+	if err != nil || len(config.Digest.Channels) == 0 {
+		return false
+	}
+	clock := config.Digest.Time
+	if clock == "" {
+		clock = defaultDigestTime
+	}
+	loc := js.location()
+	switch config.Digest.Frequency {
+	case "daily":
+		return dueDailyAt(clock, loc, now, lastRun)
+	case "weekly":
+		day := defaultDigestWeekday
+		if config.Digest.Weekday != "" {
+			var ok bool
+			if day, ok = parseWeekday(config.Digest.Weekday); !ok {
+				return false
+			}
+		}
+		// Due from the configured time on that day, catching up later in the week
+		local := now.In(loc)
+		if local.Weekday() == day && !dueDailyAt(clock, loc, now, time.Time{}) {
+			return false
+		}
+		return dueWeeklyOn(day, loc, now, lastRun)
+	}
+	return false
+}
+
+// digestJob queues the daily or weekly digest for every channel
+func (js *JournalService) digestJob() ScheduledJob {
+	return ScheduledJob{
+		Name: "notification_digest",
+		Due:  js.digestDue,
+		Run: func(ctx context.Context) error {
+			config, err := js.loadConfiguration()
+			if err != nil {
+				return err
+			}
+			now := time.Now()
+			if err := js.queueDigest(config.Digest.Frequency, nil, now); err != nil {
+				return err
+			}
+			return js.flushDigestQueue(now, false, nil)
+		},
+	}
+}
+
+// digestDeliveryJob retries queued digests every minute, e.g. once quiet hours end
+func (js *JournalService) digestDeliveryJob() ScheduledJob {
+	return ScheduledJob{
+		Name: "digest_delivery",
+		Due: func(now, lastRun time.Time) bool {
+			return len(js.loadDigestQueue()) > 0
+		},
+		Run: func(ctx context.Context) error {
+			return js.flushDigestQueue(time.Now(), false, nil)
+		},
+	}
+}
+
+// SendDigest builds the digest now and delivers it, or previews it with dry_run
+func (js *JournalService) SendDigest(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load configuration: %v", err)), nil
+	}
+	if len(config.Digest.Channels) == 0 {
+		return mcp.NewToolResultError("No digest channels configured; add digest.channels (email, slack or discord) to the configuration"), nil
+	}
+
+	period := request.GetString("period", config.Digest.Frequency)
+	if period != "weekly" {
+		period = "daily"
+	}
+	var names []string
+	if name := request.GetString("channel", ""); name != "" {
+		if !slices.ContainsFunc(config.Digest.Channels, func(c DigestChannel) bool { return c.Name == name }) {
+			return mcp.NewToolResultError(fmt.Sprintf("Unknown digest channel %q", name)), nil
+		}
+		names = []string{name}
+	}
+
+	now := time.Now()
+	result := DigestDelivery{Period: period, Failed: map[string]string{}}
+	if request.GetString("dry_run", "false") == "true" {
+		digest, err := js.buildDigest(period, now)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to build digest: %v", err)), nil
+		}
+		result.DryRun = true
+		result.Previews = make(map[string]string)
+		for _, channel := range config.Digest.Channels {
+			if len(names) > 0 && !slices.Contains(names, channel.Name) {
+				continue
+			}
+			body, err := renderDigest(channel, digest)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			result.Previews[channel.Name] = body
+		}
+		result.Summary = fmt.Sprintf("Previewed the %s digest for %d channels: %d completed, %d due soon, %d blocked",
+			period, len(result.Previews), len(digest.Completed), len(digest.DueSoon), len(digest.Blocked))
+	} else {
+		if err := js.queueDigest(period, names, now); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to build digest: %v", err)), nil
+		}
+		if err := js.flushDigestQueue(now, request.GetString("ignore_quiet_hours", "false") == "true", &result); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to deliver digest: %v", err)), nil
+		}
+		result.Summary = fmt.Sprintf("Delivered the %s digest to %d channels", period, len(result.Delivered))
+		if len(result.Queued) > 0 {
+			result.Summary += fmt.Sprintf("; %d waiting for quiet hours to end", len(result.Queued))
+		}
+		if len(result.Failed) > 0 {
+			result.Summary += fmt.Sprintf("; %d failed and will be retried", len(result.Failed))
+		}
+	}
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// validateDigestConfig checks the digest schedule, quiet hours and channels
+func validateDigestConfig(config *Configuration) error {
+	digest := config.Digest
+	switch digest.Frequency {
+	case "", "off", "daily", "weekly":
+	default:
+		return fmt.Errorf("invalid digest frequency: %s (expected daily, weekly or off)", digest.Frequency)
+	}
+	if digest.Time != "" {
+		if _, err := time.Parse("15:04", digest.Time); err != nil {
+			return fmt.Errorf("invalid digest time: %s (expected HH:MM)", digest.Time)
+		}
+	}
+	if digest.Weekday != "" {
+		if _, ok := parseWeekday(digest.Weekday); !ok {
+			return fmt.Errorf("invalid digest weekday: %s", digest.Weekday)
+		}
+	}
+	if digest.QuietHours != "" {
+		if _, _, err := parseQuietHours(digest.QuietHours); err != nil {
+			return err
+		}
+	}
+	seen := make(map[string]bool)
+	for _, channel := range digest.Channels {
+		if channel.Name == "" || seen[channel.Name] {
+			return fmt.Errorf("digest channels need unique names")
+		}
+		seen[channel.Name] = true
+		if !slices.Contains(digestChannelTypes, channel.Type) {
+			return fmt.Errorf("invalid type for digest channel %s: %s (expected %s)", channel.Name, channel.Type, strings.Join(digestChannelTypes, ", "))
+		}
+		if channel.Type == "email" && len(channel.To) == 0 {
+			return fmt.Errorf("email digest channel %s needs recipients in to", channel.Name)
+		}
+		if channel.Type != "email" && channel.WebhookURL == "" {
+			return fmt.Errorf("%s digest channel %s needs a webhook_url", channel.Type, channel.Name)
+		}
+		if channel.QuietHours != "" {
+			if _, _, err := parseQuietHours(channel.QuietHours); err != nil {
+				return err
+			}
+		}
+		if channel.Template != "" {
+			if _, err := template.New(channel.Name).Parse(channel.Template); err != nil {
+				return fmt.Errorf("invalid template for digest channel %s: %w", channel.Name, err)
+			}
+		}
+	}
+	return nil
+}
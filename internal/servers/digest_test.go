@@ -0,0 +1,257 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestBuildDigest(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	config := defaultConfiguration()
+	config.General.TimeZone = "UTC"
+	js.saveConfiguration(config)
+
+	createTestTask(t, js, "DG-1", "Ship the release", "work")
+	createTestTask(t, js, "DG-2", "Renew certificates", "work")
+	createTestTask(t, js, "DG-3", "Wait on vendor", "work")
+	createTestTask(t, js, "DG-4", "Deploy after certificates", "work")
+	js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": "DG-1", "status": "completed"}))
+	js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": "DG-3", "status": "blocked"}))
+
+	now := time.Now().UTC()
+	task, _ := js.loadTask("DG-2")
+	task.DueDate = now.AddDate(0, 0, -1).Format("2006-01-02")
+	js.saveTask(task)
+	task, _ = js.loadTask("DG-4")
+	task.DependsOn = []string{"DG-2"}
+	task.DueDate = now.AddDate(0, 0, 30).Format("2006-01-02")
+	js.saveTask(task)
+
+	digest, err := js.buildDigest("daily", now)
+	if err != nil {
+		t.Fatalf("Failed to build digest: %v", err)
+	}
+	if len(digest.Completed) != 1 || digest.Completed[0].TaskID != "DG-1" {
+		t.Errorf("Expected DG-1 completed, got %+v", digest.Completed)
+	}
+	if len(digest.DueSoon) != 1 || digest.DueSoon[0].TaskID != "DG-2" || !strings.HasPrefix(digest.DueSoon[0].Detail, "overdue since") {
+		t.Errorf("Expected only DG-2 due soon and overdue, got %+v", digest.DueSoon)
+	}
+	if len(digest.Blocked) != 2 || digest.Blocked[0].TaskID != "DG-3" || digest.Blocked[1].Detail != "waiting on DG-2" {
+		t.Errorf("Expected DG-3 blocked and DG-4 waiting on DG-2, got %+v", digest.Blocked)
+	}
+
+	// A completion from before the period is left out
+	if digest, _ := js.buildDigest("daily", now.AddDate(0, 0, 3)); len(digest.Completed) != 0 {
+		t.Errorf("Expected no completions three days later, got %+v", digest.Completed)
+	}
+	if digest, _ := js.buildDigest("weekly", now.AddDate(0, 0, 3)); len(digest.Completed) != 1 || !strings.HasPrefix(digest.Title, "Weekly") {
+		t.Errorf("Expected the weekly digest to include DG-1, got %+v", digest)
+	}
+}
+
+func TestInQuietHours(t *testing.T) {
+	at := func(clock string) time.Time {
+		parsed, _ := time.Parse("15:04", clock)
+		return parsed
+	}
+	tests := []struct {
+		spec  string
+		clock string
+		quiet bool
+	}{
+		{"22:00-07:00", "23:30", true},
+		{"22:00-07:00", "06:59", true},
+		{"22:00-07:00", "07:00", false},
+		{"22:00-07:00", "12:00", false},
+		{"12:00-13:00", "12:30", true},
+		{"12:00-13:00", "13:30", false},
+		{"", "03:00", false},
+	}
+	for _, tt := range tests {
+		if got := inQuietHours(tt.spec, at(tt.clock)); got != tt.quiet {
+			t.Errorf("inQuietHours(%q, %s) = %v, want %v", tt.spec, tt.clock, got, tt.quiet)
+		}
+	}
+}
+
+func TestDigestDue(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	config := defaultConfiguration()
+	config.General.TimeZone = "UTC"
+	config.Digest.Frequency = "weekly"
+	config.Digest.Weekday = "monday"
+	config.Digest.Channels = []DigestChannel{{Name: "team", Type: "slack", WebhookURL: "https://example.com/hook"}}
+	js.saveConfiguration(config)
+
+	monday := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	lastWeek := monday.AddDate(0, 0, -7).Add(9 * time.Hour)
+	if js.digestDue(monday.Add(7*time.Hour), lastWeek) {
+		t.Error("Expected the weekly digest to wait for 08:00")
+	}
+	if !js.digestDue(monday.Add(9*time.Hour), lastWeek) {
+		t.Error("Expected the weekly digest to be due after 08:00 on Monday")
+	}
+	if !js.digestDue(monday.AddDate(0, 0, 2), lastWeek) {
+		t.Error("Expected a missed weekly digest to catch up later in the week")
+	}
+	if js.digestDue(monday.AddDate(0, 0, 2), monday.Add(9*time.Hour)) {
+		t.Error("Expected the weekly digest to run once a week")
+	}
+
+	config.Digest.Frequency = "daily"
+	js.saveConfiguration(config)
+	if !js.digestDue(monday.Add(9*time.Hour), lastWeek) || js.digestDue(monday.Add(10*time.Hour), monday.Add(9*time.Hour)) {
+		t.Error("Expected the daily digest once a day after 08:00")
+	}
+}
+
+func TestSendDigest(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "DG-1", "Ship the release", "work")
+	js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": "DG-1", "status": "completed"}))
+
+	posts := make(map[string]map[string]string)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &payload)
+		posts[r.URL.Path] = payload
+	}))
+	defer webhook.Close()
+
+	var mailed []string
+	original := sendMail
+	sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		mailed = append(mailed, addr+" "+strings.Join(to, ",")+"\n"+string(msg))
+		return nil
+	}
+	t.Cleanup(func() { sendMail = original })
+
+	config := defaultConfiguration()
+	config.Digest.Frequency = "daily"
+	config.Digest.SMTP.Host = "smtp.example.com"
+	config.Digest.SMTP.From = "journal@example.com"
+	config.Digest.Channels = []DigestChannel{
+		{Name: "team", Type: "slack", WebhookURL: webhook.URL + "/slack"},
+		{Name: "gaming", Type: "discord", WebhookURL: webhook.URL + "/discord", Template: "Done: {{range .Completed}}{{.TaskID}} {{end}}"},
+		{Name: "inbox", Type: "email", To: []string{"me@example.com"}},
+	}
+	js.saveConfiguration(config)
+
+	result, _ := js.SendDigest(ctx, CreateMockRequest(map[string]interface{}{"dry_run": "true"}))
+	var preview DigestDelivery
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &preview)
+	if !preview.DryRun || len(preview.Previews) != 3 || !strings.HasPrefix(preview.Previews["team"], "*Daily journal digest") ||
+		preview.Previews["gaming"] != "Done: DG-1 " || !strings.Contains(preview.Previews["inbox"], "- DG-1: Ship the release") {
+		t.Fatalf("Unexpected previews: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	if len(posts) != 0 || len(mailed) != 0 || len(js.loadDigestQueue()) != 0 {
+		t.Fatal("Expected a dry run to deliver nothing")
+	}
+
+	result, _ = js.SendDigest(ctx, CreateMockRequest(map[string]interface{}{}))
+	var delivery DigestDelivery
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &delivery)
+	if len(delivery.Delivered) != 3 {
+		t.Fatalf("Expected delivery to every channel, got %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	if !strings.Contains(posts["/slack"]["text"], "DG-1") || posts["/discord"]["content"] != "Done: DG-1 " {
+		t.Errorf("Unexpected webhook payloads: %v", posts)
+	}
+	if len(mailed) != 1 || !strings.HasPrefix(mailed[0], "smtp.example.com:587 me@example.com") || !strings.Contains(mailed[0], "Subject: Daily journal digest") {
+		t.Errorf("Unexpected email: %v", mailed)
+	}
+	if len(js.loadDigestQueue()) != 0 {
+		t.Error("Expected the queue to be empty after delivery")
+	}
+
+	if result, _ := js.SendDigest(ctx, CreateMockRequest(map[string]interface{}{"channel": "pager"})); !result.IsError {
+		t.Error("Expected an unknown channel to be rejected")
+	}
+}
+
+func TestDigestQuietHoursAndRetries(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	failing := true
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer webhook.Close()
+
+	config := defaultConfiguration()
+	// Quiet hours around the current time
+	local := time.Now().In(js.location())
+	config.Digest.QuietHours = local.Add(-time.Hour).Format("15:04") + "-" + local.Add(time.Hour).Format("15:04")
+	config.Digest.Channels = []DigestChannel{{Name: "team", Type: "slack", WebhookURL: webhook.URL}}
+	js.saveConfiguration(config)
+
+	result, _ := js.SendDigest(ctx, CreateMockRequest(map[string]interface{}{"channel": "team"}))
+	var delivery DigestDelivery
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &delivery)
+	if len(delivery.Queued) != 1 || len(delivery.Delivered) != 0 || len(js.loadDigestQueue()) != 1 {
+		t.Fatalf("Expected the digest held for quiet hours, got %s", result.Content[0].(mcp.TextContent).Text)
+	}
+
+	// Ignoring quiet hours tries the webhook, which fails and stays queued
+	delivery = DigestDelivery{Failed: map[string]string{}}
+	if err := js.flushDigestQueue(time.Now(), true, &delivery); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	queue := js.loadDigestQueue()
+	if len(delivery.Failed) != 1 || len(queue) != 1 || queue[0].Attempts != 1 || !strings.Contains(queue[0].LastError, "502") {
+		t.Fatalf("Expected a failed attempt to stay queued, got %+v %+v", delivery, queue)
+	}
+
+	failing = false
+	delivery = DigestDelivery{Failed: map[string]string{}}
+	js.flushDigestQueue(time.Now(), true, &delivery)
+	if len(delivery.Delivered) != 1 || len(js.loadDigestQueue()) != 0 {
+		t.Errorf("Expected the retry to deliver, got %+v", delivery)
+	}
+}
+
+func TestValidateDigestConfig(t *testing.T) {
+	for name, channels := range map[string][]DigestChannel{
+		"unknown type":    {{Name: "a", Type: "sms"}},
+		"missing webhook": {{Name: "a", Type: "slack"}},
+		"no recipients":   {{Name: "a", Type: "email"}},
+		"duplicate names": {{Name: "a", Type: "slack", WebhookURL: "x"}, {Name: "a", Type: "discord", WebhookURL: "y"}},
+		"bad template":    {{Name: "a", Type: "slack", WebhookURL: "x", Template: "{{.Completed"}},
+		"bad quiet hours": {{Name: "a", Type: "slack", WebhookURL: "x", QuietHours: "late"}},
+	} {
+		config := defaultConfiguration()
+		config.Digest.Channels = channels
+		if err := validateDigestConfig(config); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+
+	config := defaultConfiguration()
+	config.Digest.Frequency = "hourly"
+	if err := validateDigestConfig(config); err == nil {
+		t.Error("Expected an invalid frequency to be rejected")
+	}
+	config.Digest.Frequency = "weekly"
+	config.Digest.Weekday = "Friday"
+	config.Digest.QuietHours = "22:00-07:00"
+	config.Digest.Channels = []DigestChannel{{Name: "inbox", Type: "email", To: []string{"me@example.com"}}}
+	if err := validateDigestConfig(config); err != nil {
+		t.Errorf("Expected a valid digest configuration, got %v", err)
+	}
+}
@@ -0,0 +1,365 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GitHubDiscussion is a discussion the user took part in, with their comments
+type GitHubDiscussion struct {
+	Repository string                    `json:"repository"`
+	Number     int                       `json:"number"`
+	Title      string                    `json:"title"`
+	URL        string                    `json:"url"`
+	Author     string                    `json:"author"`
+	CreatedAt  time.Time                 `json:"created_at"`
+	Comments   []GitHubDiscussionComment `json:"comments"`
+}
+
+// GitHubDiscussionComment is a discussion comment or reply
+type GitHubDiscussionComment struct {
+	ID        string    `json:"id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	URL       string    `json:"url"`
+	IsAnswer  bool      `json:"is_answer"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GitHubDiscussionSyncResult summarizes a discussion sync
+type GitHubDiscussionSyncResult struct {
+	DiscussionsProcessed int       `json:"discussions_processed"`
+	EntriesAdded         int       `json:"entries_added"`
+	TasksCreated         []string  `json:"tasks_created,omitempty"`
+	Errors               []string  `json:"errors,omitempty"`
+	Summary              string    `json:"summary"`
+	LastSyncTime         time.Time `json:"last_sync_time"`
+}
+
+const discussionSearchQuery = `query($q: String!) {
+  search(query: $q, type: DISCUSSION, first: 50) {
+    nodes {
+      ... on Discussion {
+        number
+        title
+        url
+        createdAt
+        author { login }
+        comments(first: 50) {
+          nodes {
+            id
+            url
+            body
+            createdAt
+            isAnswer
+            author { login }
+            replies(first: 50) {
+              nodes { id url body createdAt author { login } }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// graphQL posts a query to the GitHub GraphQL API using the service's authenticated client
+func (gs *GitHubService) graphQL(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	payload, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, gs.client.BaseURL.String()+"graphql", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := gs.client.Client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GitHub GraphQL request failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var envelope struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return err
+	}
+	if len(envelope.Errors) > 0 {
+		return fmt.Errorf("GitHub GraphQL error: %s", envelope.Errors[0].Message)
+	}
+	return json.Unmarshal(envelope.Data, out)
+}
+
+// getParticipatedDiscussions returns discussions in repo that involve username
+// (authored, commented or mentioned), updated since the given time
+func (gs *GitHubService) getParticipatedDiscussions(ctx context.Context, repo, username string, since *time.Time) ([]GitHubDiscussion, error) {
+	query := fmt.Sprintf("repo:%s involves:%s", repo, username)
+	if since != nil {
+		query += " updated:>=" + since.Format("2006-01-02")
+	}
+
+	type author struct {
+		Login string `json:"login"`
+	}
+	type comment struct {
+		ID        string    `json:"id"`
+		URL       string    `json:"url"`
+		Body      string    `json:"body"`
+		CreatedAt time.Time `json:"createdAt"`
+		IsAnswer  bool      `json:"isAnswer"`
+		Author    author    `json:"author"`
+		Replies   struct {
+			Nodes []comment `json:"nodes"`
+		} `json:"replies"`
+	}
+	var data struct {
+		Search struct {
+			Nodes []struct {
+				Number    int       `json:"number"`
+				Title     string    `json:"title"`
+				URL       string    `json:"url"`
+				CreatedAt time.Time `json:"createdAt"`
+				Author    author    `json:"author"`
+				Comments  struct {
+					Nodes []comment `json:"nodes"`
+				} `json:"comments"`
+			} `json:"nodes"`
+		} `json:"search"`
+	}
+
+	if err := gs.graphQL(ctx, discussionSearchQuery, map[string]interface{}{"q": query}, &data); err != nil {
+		return nil, err
+	}
+
+	toComment := func(c comment) GitHubDiscussionComment {
+		return GitHubDiscussionComment{
+			ID: c.ID, Author: c.Author.Login, Body: c.Body, URL: c.URL, IsAnswer: c.IsAnswer, CreatedAt: c.CreatedAt,
+		}
+	}
+
+	var discussions []GitHubDiscussion
+	for _, node := range data.Search.Nodes {
+		if node.Number == 0 {
+			continue
+		}
+		discussion := GitHubDiscussion{
+			Repository: repo,
+			Number:     node.Number,
+			Title:      node.Title,
+			URL:        node.URL,
+			Author:     node.Author.Login,
+			CreatedAt:  node.CreatedAt,
+		}
+		for _, c := range node.Comments.Nodes {
+			discussion.Comments = append(discussion.Comments, toComment(c))
+			for _, reply := range c.Replies.Nodes {
+				discussion.Comments = append(discussion.Comments, toComment(reply))
+			}
+		}
+		discussions = append(discussions, discussion)
+	}
+
+	return discussions, nil
+}
+
+// discussionEntries turns a user's participation in a discussion into entries.
+// Entry IDs derive from GitHub node IDs so repeated syncs do not duplicate them.
+func discussionEntries(discussion GitHubDiscussion, username string) []Entry {
+	var entries []Entry
+	participated := false
+
+	if strings.EqualFold(discussion.Author, username) {
+		participated = true
+		entries = append(entries, Entry{
+			ID:        fmt.Sprintf("gh_discussion_%s_%d", strings.ReplaceAll(discussion.Repository, "/", "_"), discussion.Number),
+			Timestamp: discussion.CreatedAt,
+			Content:   fmt.Sprintf("Started discussion #%d: %s (%s)", discussion.Number, discussion.Title, discussion.URL),
+			Type:      "github_discussion",
+		})
+	}
+
+	mentioned := false
+	for _, comment := range discussion.Comments {
+		if !strings.EqualFold(comment.Author, username) {
+			if strings.Contains(strings.ToLower(comment.Body), "@"+strings.ToLower(username)) && !mentioned {
+				mentioned = true
+				entries = append(entries, Entry{
+					ID:        "gh_discussion_mention_" + comment.ID,
+					Timestamp: comment.CreatedAt,
+					Content:   fmt.Sprintf("Mentioned by @%s in discussion #%d: %s (%s)", comment.Author, discussion.Number, discussion.Title, comment.URL),
+					Type:      "github_discussion",
+				})
+			}
+			continue
+		}
+
+		participated = true
+		action := "Commented on"
+		if comment.IsAnswer {
+			action = "Answered (accepted)"
+		}
+		entries = append(entries, Entry{
+			ID:        "gh_discussion_" + comment.ID,
+			Timestamp: comment.CreatedAt,
+			Content:   fmt.Sprintf("%s discussion #%d: %s (%s)", action, discussion.Number, discussion.Title, comment.URL),
+			Type:      "github_discussion",
+		})
+	}
+
+	// A mention only matters when the user did not otherwise take part
+	if participated && mentioned {
+		var kept []Entry
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry.ID, "gh_discussion_mention_") {
+				kept = append(kept, entry)
+			}
+		}
+		entries = kept
+	}
+
+	return entries
+}
+
+// communityTaskID names the per-repository task that collects discussion activity
+func communityTaskID(repo string) string {
+	return "community-" + strings.ReplaceAll(repo, "/", "-")
+}
+
+// SyncGitHubDiscussions records the user's GitHub Discussions activity as entries on a community task per repo
+func (js *JournalService) SyncGitHubDiscussions(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	githubService, err := js.githubService(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	config, _ := js.loadConfiguration()
+
+	username := request.GetString("username", "")
+	if username == "" && config != nil {
+		username = config.GitHub.Username
+	}
+	if username == "" {
+		return mcp.NewToolResultError("username is required (or set github.username in config)"), nil
+	}
+
+	repositories := request.GetStringSlice("repositories", nil)
+	if len(repositories) == 0 && config != nil {
+		repositories = config.GitHub.Repositories
+	}
+	if len(repositories) == 0 {
+		return mcp.NewToolResultError("repositories is required (or set github.repositories in config); discussions are searched per repository"), nil
+	}
+
+	var since *time.Time
+	if sinceStr := request.GetString("since", ""); sinceStr != "" {
+		sinceTime, err := time.Parse("2006-01-02", sinceStr)
+		if err != nil {
+			return mcp.NewToolResultError("Invalid since date format. Use YYYY-MM-DD"), nil
+		}
+		since = &sinceTime
+	}
+
+	result := GitHubDiscussionSyncResult{LastSyncTime: time.Now(), Errors: []string{}}
+
+	for _, repo := range repositories {
+		discussions, err := githubService.getParticipatedDiscussions(ctx, repo, username, since)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", repo, err))
+			continue
+		}
+
+		var newEntries []Entry
+		for _, discussion := range discussions {
+			result.DiscussionsProcessed++
+			newEntries = append(newEntries, discussionEntries(discussion, username)...)
+		}
+		if len(newEntries) == 0 {
+			continue
+		}
+
+		added, created, err := js.appendCommunityEntries(repo, newEntries)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", repo, err))
+			continue
+		}
+		result.EntriesAdded += added
+		if created {
+			result.TasksCreated = append(result.TasksCreated, communityTaskID(repo))
+		}
+	}
+
+	result.Summary = fmt.Sprintf("Processed %d discussions, added %d entries", result.DiscussionsProcessed, result.EntriesAdded)
+
+	resultJSON, _ := json.Marshal(result)
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// appendCommunityEntries adds entries not already recorded to the repo's community task, creating it if needed
+func (js *JournalService) appendCommunityEntries(repo string, entries []Entry) (int, bool, error) {
+	taskID := communityTaskID(repo)
+	created := false
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return 0, false, err
+		}
+		now := time.Now()
+		task = &Task{
+			ID:      taskID,
+			Title:   fmt.Sprintf("Community: %s discussions", repo),
+			Type:    "work",
+			Tags:    []string{"community", "github-discussions"},
+			Status:  "active",
+			Created: now,
+			Updated: now,
+			Entries: []Entry{},
+		}
+		created = true
+	}
+
+	existing := make(map[string]bool)
+	for _, entry := range task.Entries {
+		existing[entry.ID] = true
+	}
+
+	added := 0
+	for _, entry := range entries {
+		if existing[entry.ID] {
+			continue
+		}
+		existing[entry.ID] = true
+		task.Entries = append(task.Entries, entry)
+		js.updateDailyLog(taskID, entry)
+		added++
+	}
+
+	if added == 0 && !created {
+		return 0, false, nil
+	}
+
+	task.Updated = time.Now()
+	return added, created, js.saveTask(task)
+}
@@ -0,0 +1,105 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiscussionEntries(t *testing.T) {
+	created := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	discussion := GitHubDiscussion{
+		Repository: "acme/widgets",
+		Number:     12,
+		Title:      "How do I configure retries?",
+		URL:        "https://github.com/acme/widgets/discussions/12",
+		Author:     "someone",
+		CreatedAt:  created,
+		Comments: []GitHubDiscussionComment{
+			{ID: "DC_1", Author: "someone", Body: "cc @Alice", CreatedAt: created.Add(time.Minute)},
+			{ID: "DC_2", Author: "alice", Body: "Set retries: 3", IsAnswer: true, CreatedAt: created.Add(time.Hour)},
+		},
+	}
+
+	entries := discussionEntries(discussion, "alice")
+	if len(entries) != 1 {
+		t.Fatalf("Expected only the answer once the user participated, got %+v", entries)
+	}
+	if entries[0].ID != "gh_discussion_DC_2" || !strings.HasPrefix(entries[0].Content, "Answered (accepted) discussion #12") {
+		t.Errorf("Unexpected entry: %+v", entries[0])
+	}
+
+	discussion.Comments = discussion.Comments[:1]
+	entries = discussionEntries(discussion, "alice")
+	if len(entries) != 1 || !strings.HasPrefix(entries[0].Content, "Mentioned by @someone") {
+		t.Errorf("Expected a mention entry, got %+v", entries)
+	}
+}
+
+func TestSyncGitHubDiscussions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" {
+			http.NotFound(w, r)
+			return
+		}
+		var body struct {
+			Variables map[string]string `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Variables["q"] != "repo:acme/widgets involves:alice" {
+			http.Error(w, "unexpected query "+body.Variables["q"], http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"data":{"search":{"nodes":[{
+			"number": 5, "title": "Roadmap", "url": "https://github.com/acme/widgets/discussions/5",
+			"createdAt": "2025-03-01T10:00:00Z", "author": {"login": "alice"},
+			"comments": {"nodes": [{
+				"id": "DC_9", "url": "https://github.com/acme/widgets/discussions/5#c9", "body": "Thanks",
+				"createdAt": "2025-03-02T10:00:00Z", "isAnswer": false, "author": {"login": "bob"},
+				"replies": {"nodes": [{"id": "DC_10", "url": "u", "body": "np", "createdAt": "2025-03-02T11:00:00Z", "author": {"login": "alice"}}]}
+			}]}
+		}]}}}`))
+	}))
+	defer server.Close()
+
+	js, _ := CreateTestJournalService(t)
+	gs := NewGitHubService("test-token")
+	gs.client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	discussions, err := gs.getParticipatedDiscussions(context.Background(), "acme/widgets", "alice", nil)
+	if err != nil {
+		t.Fatalf("Failed to query discussions: %v", err)
+	}
+
+	var entries []Entry
+	for _, discussion := range discussions {
+		entries = append(entries, discussionEntries(discussion, "alice")...)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected started + reply entries, got %+v", entries)
+	}
+
+	added, created, err := js.appendCommunityEntries("acme/widgets", entries)
+	if err != nil || added != 2 || !created {
+		t.Fatalf("Expected new community task with 2 entries, got added=%d created=%v err=%v", added, created, err)
+	}
+
+	// Syncing again must not duplicate entries
+	added, created, _ = js.appendCommunityEntries("acme/widgets", entries)
+	if added != 0 || created {
+		t.Errorf("Expected idempotent sync, got added=%d created=%v", added, created)
+	}
+
+	task, err := js.loadTask("community-acme-widgets")
+	if err != nil {
+		t.Fatalf("Failed to load community task: %v", err)
+	}
+	if len(task.Entries) != 2 || task.Tags[0] != "community" {
+		t.Errorf("Unexpected community task: %+v", task)
+	}
+}
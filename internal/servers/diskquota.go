@@ -0,0 +1,164 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// quotaCheckInterval is how long a measured data directory size is reused
+// before the next tool call measures it again
+const quotaCheckInterval = time.Minute
+
+// dataDirUsage caches the measured size of each data directory, keyed by path
+var dataDirUsage sync.Map
+
+type measuredUsage struct {
+	sizes map[string]int64 // bytes under each top-level entry
+	at    time.Time
+}
+
+var byteSizeUnits = map[string]int64{"": 1, "B": 1, "KB": 1 << 10, "MB": 1 << 20, "GB": 1 << 30, "TB": 1 << 40}
+
+// parseByteSize reads a size such as 500MB, 1.5 GB or 2048 (bytes). Units are
+// binary, as formatBytes prints them.
+func parseByteSize(value string) (int64, error) {
+	trimmed := strings.ToUpper(strings.TrimSpace(value))
+	number := strings.TrimRight(trimmed, "KMGTB ")
+	unit := strings.TrimSpace(trimmed[len(number):])
+	multiplier, ok := byteSizeUnits[unit]
+	size, err := strconv.ParseFloat(number, 64)
+	if !ok || err != nil || size <= 0 {
+		return 0, fmt.Errorf("%q is not a size such as 500MB or 2GB", value)
+	}
+	return int64(size * float64(multiplier)), nil
+}
+
+// softQuota returns storage.soft_quota in bytes, or 0 when none is set
+func (js *JournalService) softQuota() int64 {
+	config, err := js.loadConfiguration()
+	if err != nil || config.Storage.SoftQuota == "" {
+		return 0
+	}
+	quota, err := parseByteSize(config.Storage.SoftQuota)
+	if err != nil {
+		return 0
+	}
+	return quota
+}
+
+// dataDirSizes returns the bytes under each top-level entry of the data
+// directory, measured at most once every quotaCheckInterval
+func (js *JournalService) dataDirSizes(now time.Time) map[string]int64 {
+	if cached, ok := dataDirUsage.Load(js.dataDir()); ok {
+		if usage := cached.(measuredUsage); now.Sub(usage.at) < quotaCheckInterval {
+			return usage.sizes
+		}
+	}
+	sizes, _ := dirSizes(js.dataDir())
+	dataDirUsage.Store(js.dataDir(), measuredUsage{sizes: sizes, at: now})
+	return sizes
+}
+
+// quotaWarning is the note tools add while the data directory is over
+// storage.soft_quota, or "" when it is within it
+func (js *JournalService) quotaWarning(now time.Time) string {
+	quota := js.softQuota()
+	if quota == 0 {
+		return ""
+	}
+	total := sumSizes(js.dataDirSizes(now))
+	if total <= quota {
+		return ""
+	}
+	return fmt.Sprintf("_Warning: the journal data directory is %s, over its %s soft quota (storage.soft_quota). get_analytics_report suggests what to clean up._",
+		formatBytes(total), formatBytes(quota))
+}
+
+// QuotaMiddleware appends a warning to every tool result while the data
+// directory is over storage.soft_quota
+func (js *JournalService) QuotaMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if err != nil || result == nil {
+			return result, err
+		}
+		if warning := js.quotaWarning(time.Now()); warning != "" {
+			result.Content = append(result.Content, mcp.NewTextContent(warning))
+		}
+		return result, nil
+	}
+}
+
+// quotaInsights says when the data directory is over or nearing
+// storage.soft_quota and suggests retention and archival steps for the
+// directories taking the most space
+func (js *JournalService) quotaInsights(now time.Time) []string {
+	quota := js.softQuota()
+	if quota == 0 {
+		return nil
+	}
+	sizes := js.dataDirSizes(now)
+	total := sumSizes(sizes)
+	if total*10 < quota*9 {
+		return nil
+	}
+
+	var insights []string
+	if total > quota {
+		insights = append(insights, fmt.Sprintf("The journal data directory is %s, over its %s soft quota.", formatBytes(total), formatBytes(quota)))
+	} else {
+		insights = append(insights, fmt.Sprintf("The journal data directory is %s, %d%% of its %s soft quota.", formatBytes(total), total*100/quota, formatBytes(quota)))
+	}
+
+	config, _ := js.loadConfiguration()
+	dirs := sortedKeys(sizes)
+	sort.SliceStable(dirs, func(i, j int) bool { return sizes[dirs[i]] > sizes[dirs[j]] })
+	for _, dir := range dirs {
+		size := sizes[dir]
+		if size*10 < total { // less than a tenth is not worth the trouble
+			continue
+		}
+		switch dir {
+		case "backups":
+			advice := fmt.Sprintf("Backups take %s. Lower backup.max_backups or point backup.backup_location outside the data directory", formatBytes(size))
+			if config != nil && config.Backup.Destination == "" {
+				advice += ", and set backup.destination to keep copies off this machine"
+			}
+			insights = append(insights, advice+".")
+		case "attachments":
+			insights = append(insights, fmt.Sprintf("Attachments take %s. Run gc_attachments to remove files no entry uses any more.", formatBytes(size)))
+		case "daily":
+			insights = append(insights, fmt.Sprintf("Daily logs take %s. rebuild_daily_logs drops entries duplicated by imports.", formatBytes(size)))
+		case "tasks":
+			if old := js.oldCompletedTasks(now.AddDate(0, -6, 0)); old > 0 {
+				insights = append(insights, fmt.Sprintf("Tasks take %s, and %d were completed more than six months ago. Archive them with archive_task, then keep archived/ in off-machine backups (backup.destination) rather than on this disk.", formatBytes(size), old))
+			}
+		case "archived", "trash":
+			insights = append(insights, fmt.Sprintf("%s/ takes %s. Once a backup holds it off this machine (backup.destination), older files there can be removed.", dir, formatBytes(size)))
+		}
+	}
+	return insights
+}
+
+// oldCompletedTasks counts completed tasks last updated before cutoff
+func (js *JournalService) oldCompletedTasks(cutoff time.Time) int {
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return 0
+	}
+	count := 0
+	for _, task := range tasksWithStatus(tasks, "completed") {
+		if task.Updated.Before(cutoff) {
+			count++
+		}
+	}
+	return count
+}
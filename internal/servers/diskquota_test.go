@@ -0,0 +1,66 @@
+package servers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestParseByteSize(t *testing.T) {
+	cases := map[string]int64{"2048": 2048, "500MB": 500 << 20, "1.5 gb": 3 << 29, "64 KB": 64 << 10, "10B": 10}
+	for value, expected := range cases {
+		if got, err := parseByteSize(value); err != nil || got != expected {
+			t.Errorf("parseByteSize(%q) = %d, %v; expected %d", value, got, err, expected)
+		}
+	}
+	for _, value := range []string{"", "MB", "lots", "-5MB", "5 PB", "5MBB"} {
+		if _, err := parseByteSize(value); err == nil {
+			t.Errorf("Expected %q to be refused", value)
+		}
+	}
+}
+
+func TestSoftQuota(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "Q-1", "Fill the disk", "work")
+	os.MkdirAll(filepath.Join(tempDir, "backups"), 0755)
+	os.WriteFile(filepath.Join(tempDir, "backups", "old.zip"), make([]byte, 8<<10), 0644)
+
+	handler := js.QuotaMiddleware(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("done"), nil
+	})
+	if result, _ := handler(ctx, CreateMockRequest(map[string]interface{}{})); len(result.Content) != 1 {
+		t.Error("Expected no warning without a quota")
+	}
+
+	config := defaultConfiguration()
+	config.Storage.SoftQuota = "4KB"
+	js.saveConfiguration(config)
+	result, _ := handler(ctx, CreateMockRequest(map[string]interface{}{}))
+	if len(result.Content) != 2 || !strings.Contains(result.Content[1].(mcp.TextContent).Text, "over its 4.0 KB soft quota") {
+		t.Errorf("Expected a quota warning, got %+v", result.Content)
+	}
+
+	insights := strings.Join(js.quotaInsights(time.Now()), "\n")
+	if !strings.Contains(insights, "over its 4.0 KB soft quota") || !strings.Contains(insights, "Backups take 8.0 KB. Lower backup.max_backups") ||
+		!strings.Contains(insights, "set backup.destination") {
+		t.Errorf("Expected retention advice, got:\n%s", insights)
+	}
+
+	config.Storage.SoftQuota = "1GB"
+	js.saveConfiguration(config)
+	if warning := js.quotaWarning(time.Now()); warning != "" {
+		t.Errorf("Expected no warning within the quota, got %q", warning)
+	}
+
+	config.Storage.SoftQuota = "plenty"
+	if err := js.validateConfiguration(config); err == nil {
+		t.Error("Expected an invalid soft quota to fail validation")
+	}
+}
@@ -0,0 +1,84 @@
+package servers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// tasksDue returns open tasks with a due date in [from, to), sorted by due
+// date. An empty from means no lower bound.
+func (js *JournalService) tasksDue(from, to string) []*Task {
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return nil
+	}
+
+	var due []*Task
+	for _, task := range tasks {
+		if task.DueDate == "" || task.Status == "completed" {
+			continue
+		}
+		if (from == "" || task.DueDate >= from) && task.DueDate < to {
+			due = append(due, task)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].DueDate != due[j].DueDate {
+			return due[i].DueDate < due[j].DueDate
+		}
+		return due[i].ID < due[j].ID
+	})
+	return due
+}
+
+// isOverdue reports whether an open task's due date is before asOf (YYYY-MM-DD)
+func isOverdue(task *Task, asOf string) bool {
+	return task.DueDate != "" && task.Status != "completed" && task.DueDate < asOf
+}
+
+// endOfWeek returns the Sunday ending the week that contains date
+func endOfWeek(date time.Time) time.Time {
+	return date.AddDate(0, 0, (7-int(date.Weekday()))%7)
+}
+
+// matchesDueFilter implements the list_tasks due filter: overdue, due_today or due_this_week
+func matchesDueFilter(task *Task, filter string, now time.Time) bool {
+	today := now.Format("2006-01-02")
+	switch filter {
+	case "overdue":
+		return isOverdue(task, today)
+	case "due_today":
+		return task.Status != "completed" && task.DueDate == today
+	case "due_this_week":
+		return task.Status != "completed" && task.DueDate >= today && task.DueDate <= endOfWeek(now).Format("2006-01-02")
+	default:
+		return true
+	}
+}
+
+// writeDueSection lists tasks under a heading with how late or soon each is due
+func writeDueSection(md *strings.Builder, heading string, tasks []*Task, asOf string) {
+	if len(tasks) == 0 {
+		return
+	}
+
+	ref, _ := time.Parse("2006-01-02", asOf)
+	md.WriteString(fmt.Sprintf("## %s\n", heading))
+	for _, task := range tasks {
+		due, _ := time.Parse("2006-01-02", task.DueDate)
+		days := int(ref.Sub(due).Hours() / 24)
+
+		note := due.Format("Monday")
+		switch {
+		case days == 1:
+			note = "1 day overdue"
+		case days > 1:
+			note = fmt.Sprintf("%d days overdue", days)
+		}
+		md.WriteString(fmt.Sprintf("- %s: %s (due %s, %s)\n", task.ID, task.Title, task.DueDate, note))
+	}
+	md.WriteString("\n")
+}
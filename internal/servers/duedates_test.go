@@ -0,0 +1,52 @@
+package servers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestDueDates(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	today := time.Now().Format("2006-01-02")
+	lastWeek := time.Now().AddDate(0, 0, -7).Format("2006-01-02")
+
+	js.CreateTask(ctx, CreateMockRequest(map[string]interface{}{"id": "DUE-1", "title": "Quarterly report", "type": "work", "due_date": lastWeek}))
+	js.CreateTask(ctx, CreateMockRequest(map[string]interface{}{"id": "DUE-2", "title": "Renew cert", "type": "work", "due_date": today}))
+	js.CreateTask(ctx, CreateMockRequest(map[string]interface{}{"id": "DUE-3", "title": "No deadline", "type": "work"}))
+
+	if result, _ := js.CreateTask(ctx, CreateMockRequest(map[string]interface{}{"id": "DUE-4", "title": "Bad", "type": "work", "due_date": "next week"})); !result.IsError {
+		t.Error("Expected invalid due date to be rejected")
+	}
+
+	result, _ := js.ListTasks(ctx, CreateMockRequest(map[string]interface{}{"due": "overdue"}))
+	text := result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "DUE-1") || strings.Contains(text, "DUE-2") || !strings.Contains(text, "(overdue)") {
+		t.Errorf("Expected only DUE-1 overdue, got:\n%s", text)
+	}
+
+	result, _ = js.ListTasks(ctx, CreateMockRequest(map[string]interface{}{"due": "due_this_week"}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "DUE-2") || strings.Contains(text, "DUE-1") {
+		t.Errorf("Expected DUE-2 due this week, got:\n%s", text)
+	}
+
+	result, _ = js.GetDailyLog(ctx, CreateMockRequest(map[string]interface{}{"date": today}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "## Overdue\n- DUE-1: Quarterly report (due "+lastWeek+", 7 days overdue)") {
+		t.Errorf("Expected overdue section in daily log, got:\n%s", text)
+	}
+
+	result, _ = js.UpdateTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "DUE-1", "due_date": "none", "priority": "high"}))
+	if result.IsError {
+		t.Fatalf("Unexpected error: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	task, _ := js.loadTask("DUE-1")
+	last := task.Entries[len(task.Entries)-1]
+	if task.DueDate != "" || task.Priority != "high" || !strings.Contains(last.Content, `due_date: "`+lastWeek+`" -> ""`) {
+		t.Errorf("Expected due date cleared and change logged, got %+v / %s", task, last.Content)
+	}
+}
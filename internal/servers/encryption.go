@@ -0,0 +1,311 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	encryptionPassphraseEnv    = "JOURNAL_MCP_ENCRYPTION_PASSPHRASE"
+	encryptionPassphraseSecret = "encryption_passphrase"
+	encryptedBackupSuffix      = ".enc"
+	encryptionSaltSize         = 16
+)
+
+// encryptedMagic starts every encrypted file. The layout is
+// magic | salt (16 bytes) | nonce (12 bytes) | AES-256-GCM ciphertext, so any
+// file can be decrypted with the passphrase alone.
+var encryptedMagic = []byte("JMCPENC1")
+
+var errNoEncryptionPassphrase = errors.New("no encryption passphrase: set " + encryptionPassphraseEnv +
+	" or store one with set_secret name=" + encryptionPassphraseSecret)
+
+// encryptionKeys caches derived keys by passphrase and salt; PBKDF2 is
+// deliberately slow and data files share the data directory's salt
+var encryptionKeys sync.Map
+
+func isEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedMagic)
+}
+
+func encryptionAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	digest := sha256.Sum256(append([]byte(passphrase+"\x00"), salt...))
+	cacheKey := hex.EncodeToString(digest[:])
+	if aead, ok := encryptionKeys.Load(cacheKey); ok {
+		return aead.(cipher.AEAD), nil
+	}
+
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, secretsFileIterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	encryptionKeys.Store(cacheKey, aead)
+	return aead, nil
+}
+
+// sealData encrypts plaintext under a key derived from passphrase and salt
+func sealData(passphrase string, salt, plaintext []byte) ([]byte, error) {
+	aead, err := encryptionAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := make([]byte, 0, len(encryptedMagic)+len(salt)+len(nonce)+len(plaintext)+aead.Overhead())
+	sealed = append(sealed, encryptedMagic...)
+	sealed = append(sealed, salt...)
+	sealed = append(sealed, nonce...)
+	return aead.Seal(sealed, nonce, plaintext, nil), nil
+}
+
+// openData decrypts data written by sealData
+func openData(passphrase string, data []byte) ([]byte, error) {
+	header := len(encryptedMagic) + encryptionSaltSize
+	if !isEncrypted(data) || len(data) < header {
+		return nil, fmt.Errorf("not an encrypted journal file")
+	}
+	salt := data[len(encryptedMagic):header]
+	aead, err := encryptionAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < header+aead.NonceSize() {
+		return nil, fmt.Errorf("encrypted file is truncated")
+	}
+	nonce := data[header : header+aead.NonceSize()]
+	plaintext, err := aead.Open(nil, nonce, data[header+aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt (wrong passphrase or damaged file)")
+	}
+	return plaintext, nil
+}
+
+// encryptionPassphrase returns JOURNAL_MCP_ENCRYPTION_PASSPHRASE or the
+// encryption_passphrase secret. It is never read from config.yaml, which
+// lives next to the data it protects.
+func (js *JournalService) encryptionPassphrase() (string, error) {
+	if passphrase := os.Getenv(encryptionPassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	if passphrase, err := js.getSecret(encryptionPassphraseSecret); err == nil && passphrase != "" {
+		return passphrase, nil
+	}
+	return "", errNoEncryptionPassphrase
+}
+
+func (js *JournalService) encryptionSaltPath() string {
+	return filepath.Join(js.dataDir(), ".journal-mcp", "encryption_salt")
+}
+
+// encryptionSalt returns the data directory's salt for files encrypted at
+// rest, creating it on first use
+func (js *JournalService) encryptionSalt() ([]byte, error) {
+	path := js.encryptionSaltPath()
+	defer lockFile(path)()
+
+	if salt, err := os.ReadFile(path); err == nil && len(salt) == encryptionSaltSize {
+		return salt, nil
+	}
+	salt := make([]byte, encryptionSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	return salt, writeFileAtomic(path, salt, 0600)
+}
+
+// atRestEncryption reports whether encryption.at_rest is on
+func (js *JournalService) atRestEncryption() bool {
+	config, err := js.loadConfiguration()
+	return err == nil && config.Encryption.AtRest
+}
+
+// decryptData returns data unchanged unless it is encrypted. Encrypted files
+// are always readable with the passphrase, even after encryption.at_rest is
+// turned off.
+func (js *JournalService) decryptData(data []byte) ([]byte, error) {
+	if !isEncrypted(data) {
+		return data, nil
+	}
+	passphrase, err := js.encryptionPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	return openData(passphrase, data)
+}
+
+// encryptData seals data with the data directory's salt
+func (js *JournalService) encryptData(data []byte) ([]byte, error) {
+	passphrase, err := js.encryptionPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	salt, err := js.encryptionSalt()
+	if err != nil {
+		return nil, err
+	}
+	return sealData(passphrase, salt, data)
+}
+
+// readDataFile reads a journal file, decrypting it if it was encrypted at rest
+func (js *JournalService) readDataFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return js.decryptData(data)
+}
+
+// writeDataFile writes a journal file atomically, encrypting it when
+// encryption.at_rest is on
+func (js *JournalService) writeDataFile(path string, data []byte, perm os.FileMode) error {
+	if js.atRestEncryption() {
+		sealed, err := js.encryptData(data)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt %s: %w", filepath.Base(path), err)
+		}
+		data = sealed
+	}
+	noteOwnWrite(path, data)
+	return writeFileAtomic(path, data, perm)
+}
+
+// encryptedDataFiles lists the files covered by encryption.at_rest: tasks
+// (JSON and markdown), trashed and archived tasks, meeting notes, daily logs,
+// weekly reviews, goals, event log snapshots, the feedback bank, the brag
+// document, the search index, notifications, the digest queue and the running
+// focus session. The event log itself is sealed line by line; see
+// reencryptEventLog.
+func (js *JournalService) encryptedDataFiles() []string {
+	var paths []string
+	for _, dir := range []string{"tasks", "trash", filepath.Join("trash", "one-on-ones"), filepath.Join("trash", "meetings"), "archived", "one-on-ones", "meetings", "weekly-reviews", "goals", "habits", "daily", "imports", filepath.Join("events", "snapshots")} {
+		matches, _ := filepath.Glob(filepath.Join(js.dataDir(), dir, "*.json"))
+		paths = append(paths, matches...)
+	}
+	markdown, _ := filepath.Glob(filepath.Join(js.dataDir(), "tasks", "*.md"))
+	paths = append(paths, markdown...)
+	filepath.Walk(js.attachmentsDir(), func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	for _, path := range []string{js.feedbackPath(), js.bragPath(), js.searchIndexPath(), js.notificationsPath(), js.digestQueuePath(), js.focusSessionPath(), filepath.Join(js.dataDir(), "events", "head.json")} {
+		if _, err := os.Stat(path); err == nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// ApplyEncryption rewrites existing data files to match encryption.at_rest:
+// plaintext files are encrypted when it is on and decrypted when it is off
+func (js *JournalService) ApplyEncryption(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	dryRun := request.GetString("dry_run", "false") == "true"
+	encrypt := js.atRestEncryption()
+	if _, err := js.encryptionPassphrase(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var changed, unchanged int
+	var failures []string
+	for _, path := range js.encryptedDataFiles() {
+		rel, _ := filepath.Rel(js.dataDir(), path)
+		unlock := lockFile(path)
+		data, err := os.ReadFile(path)
+		if err == nil && isEncrypted(data) == encrypt {
+			unchanged++
+			unlock()
+			continue
+		}
+		if err == nil && !dryRun {
+			var plaintext []byte
+			if plaintext, err = js.decryptData(data); err == nil {
+				err = js.writeDataFile(path, plaintext, 0644)
+			}
+		}
+		unlock()
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", filepath.ToSlash(rel), err))
+			continue
+		}
+		changed++
+	}
+
+	logPath := filepath.Join(js.dataDir(), "events", "events.jsonl")
+	if _, err := os.Stat(logPath); err == nil {
+		if logChanged, err := js.reencryptEventLog(logPath, dryRun); err != nil {
+			failures = append(failures, fmt.Sprintf("events/events.jsonl: %v", err))
+		} else if logChanged {
+			changed++
+		} else {
+			unchanged++
+		}
+	}
+
+	action := "decrypted"
+	if encrypt {
+		action = "encrypted"
+	}
+	result := map[string]interface{}{
+		"at_rest":   encrypt,
+		"dry_run":   dryRun,
+		"changed":   changed,
+		"unchanged": unchanged,
+		"failures":  failures,
+	}
+	if dryRun {
+		result["summary"] = fmt.Sprintf("Would leave %d files %s (%d already are)", changed, action, unchanged)
+	} else {
+		result["summary"] = fmt.Sprintf("%s %d files (%d already were)", strings.ToUpper(action[:1])+action[1:], changed, unchanged)
+	}
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// encryptBackup encrypts a backup archive in place. Each backup gets its own
+// salt so it can be restored into any data directory with the passphrase.
+func encryptBackup(path, passphrase string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	salt := make([]byte, encryptionSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	sealed, err := sealData(passphrase, salt, data)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, sealed, 0600)
+}
@@ -0,0 +1,193 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestSealAndOpenData(t *testing.T) {
+	salt := make([]byte, encryptionSaltSize)
+	sealed, err := sealData("passphrase", salt, []byte(`{"title":"Salary review"}`))
+	if err != nil {
+		t.Fatalf("Failed to seal: %v", err)
+	}
+	if !isEncrypted(sealed) || strings.Contains(string(sealed), "Salary") {
+		t.Fatalf("Expected an encrypted envelope, got %q", sealed)
+	}
+
+	plaintext, err := openData("passphrase", sealed)
+	if err != nil || string(plaintext) != `{"title":"Salary review"}` {
+		t.Errorf("Round trip failed: %q (%v)", plaintext, err)
+	}
+	if _, err := openData("wrong", sealed); err == nil {
+		t.Error("Expected the wrong passphrase to fail")
+	}
+	sealed[len(sealed)-1] ^= 0xff
+	if _, err := openData("passphrase", sealed); err == nil {
+		t.Error("Expected a damaged file to fail authentication")
+	}
+}
+
+func TestAtRestEncryption(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	t.Setenv(encryptionPassphraseEnv, "correct horse battery staple")
+
+	createTestTask(t, js, "TASK-1", "Written before encryption", "work")
+
+	config := defaultConfiguration()
+	config.Encryption.AtRest = true
+	js.saveConfiguration(config)
+
+	createTestTask(t, js, "TASK-2", "Performance improvement plan", "work")
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "TASK-2", "content": "Drafted goals"}))
+	js.CreateOneOnOne(ctx, CreateMockRequest(map[string]interface{}{"date": "2026-03-02", "notes": "Discussed compensation"}))
+
+	for _, rel := range []string{"tasks/TASK-2.json", "one-on-ones/2026-03-02.json"} {
+		data, _ := os.ReadFile(filepath.Join(tempDir, rel))
+		if !isEncrypted(data) {
+			t.Errorf("Expected %s to be encrypted, got %s", rel, data)
+		}
+	}
+	dailyLogs, _ := filepath.Glob(filepath.Join(tempDir, "daily", "*.json"))
+	if len(dailyLogs) != 1 {
+		t.Fatalf("Expected one daily log, got %v", dailyLogs)
+	}
+	if data, _ := os.ReadFile(dailyLogs[0]); !isEncrypted(data) {
+		t.Error("Expected the daily log to be encrypted")
+	}
+
+	task, err := js.loadTask("TASK-2")
+	if err != nil || task.Title != "Performance improvement plan" {
+		t.Fatalf("Expected the encrypted task to load, got %+v (%v)", task, err)
+	}
+	result, _ := js.GetOneOnOneHistory(ctx, CreateMockRequest(map[string]interface{}{}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "Discussed compensation") {
+		t.Errorf("Expected the encrypted one-on-one in the history, got:\n%s", text)
+	}
+
+	// TASK-1 is still plaintext until apply_encryption rewrites it
+	result, _ = js.ApplyEncryption(ctx, CreateMockRequest(map[string]interface{}{}))
+	var applied struct {
+		Changed  int      `json:"changed"`
+		Failures []string `json:"failures"`
+	}
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &applied)
+	if applied.Changed != 1 || len(applied.Failures) != 0 {
+		t.Errorf("Expected only TASK-1 to be encrypted, got %+v", applied)
+	}
+	if data, _ := os.ReadFile(filepath.Join(tempDir, "tasks", "TASK-1.json")); !isEncrypted(data) {
+		t.Error("Expected TASK-1 to be encrypted")
+	}
+
+	// Turning encryption off and applying again decrypts everything
+	js.saveConfiguration(defaultConfiguration())
+	js.ApplyEncryption(ctx, CreateMockRequest(map[string]interface{}{}))
+	for _, path := range js.encryptedDataFiles() {
+		if data, _ := os.ReadFile(path); isEncrypted(data) {
+			t.Errorf("Expected %s to be decrypted", path)
+		}
+	}
+}
+
+func TestEncryptedBackup(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	t.Setenv(encryptionPassphraseEnv, "correct horse battery staple")
+	createTestTask(t, js, "TASK-1", "Confidential", "work")
+
+	config := defaultConfiguration()
+	config.Encryption.Backups = true
+	js.saveConfiguration(config)
+
+	result, _ := js.CreateDataBackup(ctx, CreateMockRequest(map[string]interface{}{}))
+	var backup BackupResult
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &backup)
+	if !backup.Encrypted || !strings.HasSuffix(backup.BackupPath, ".zip"+encryptedBackupSuffix) {
+		t.Fatalf("Expected an encrypted backup, got %+v", backup)
+	}
+	if data, _ := os.ReadFile(backup.BackupPath); !isEncrypted(data) || strings.Contains(string(data), "Confidential") {
+		t.Error("Expected the backup file to be encrypted")
+	}
+	if backups, _ := js.listBackups(); len(backups) != 1 {
+		t.Errorf("Expected the encrypted backup to be listed, got %+v", backups)
+	}
+
+	result, _ = js.VerifyBackup(ctx, CreateMockRequest(map[string]interface{}{}))
+	var verification BackupVerification
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &verification)
+	if !verification.Valid || !verification.Encrypted || verification.Tasks != 1 {
+		t.Errorf("Unexpected verification: %+v", verification)
+	}
+
+	result, _ = js.RestoreDataBackup(ctx, CreateMockRequest(map[string]interface{}{"backup_path": backup.BackupPath}))
+	var restore RestoreResult
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &restore)
+	if restore.TasksRestored != 1 || !strings.HasPrefix(restore.TargetDir, tempDir) {
+		t.Errorf("Unexpected restore: %+v", restore)
+	}
+	if _, err := os.Stat(filepath.Join(restore.TargetDir, "tasks", "TASK-1.json")); err != nil {
+		t.Errorf("Expected the task to be restored: %v", err)
+	}
+
+	t.Setenv(encryptionPassphraseEnv, "wrong passphrase")
+	result, _ = js.VerifyBackup(ctx, CreateMockRequest(map[string]interface{}{}))
+	verification = BackupVerification{}
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &verification)
+	if verification.Valid || len(verification.Problems) != 1 {
+		t.Errorf("Expected the wrong passphrase to be reported, got %+v", verification)
+	}
+}
+
+func TestAtRestEncryptionEventMode(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	t.Setenv(encryptionPassphraseEnv, "correct horse battery staple")
+
+	// One event written before encryption is switched on
+	enableEventStorage(t, js, 2)
+	createTestTask(t, js, "ENC-1", "Hiring plan", "work")
+
+	config, _ := js.loadConfiguration()
+	config.Encryption.AtRest = true
+	js.saveConfiguration(config)
+	if result, _ := js.ApplyEncryption(ctx, CreateMockRequest(map[string]interface{}{})); result.IsError {
+		t.Fatalf("ApplyEncryption failed: %v", result.Content)
+	}
+
+	createTestTask(t, js, "ENC-2", "Layoff planning", "work")
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "ENC-2", "content": "Severance for the Berlin team"}))
+	js.StartFocusSession(ctx, CreateMockRequest(map[string]interface{}{"task_id": "ENC-2", "goal": "Severance letters"}))
+	js.notify("reminder", "ENC-2", "Severance review tomorrow")
+
+	filepath.Walk(tempDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || filepath.Base(path) == "config.yaml" {
+			return nil
+		}
+		data, _ := os.ReadFile(path)
+		for _, secret := range []string{"Severance", "Hiring plan", "Layoff"} {
+			if strings.Contains(string(data), secret) {
+				rel, _ := filepath.Rel(tempDir, path)
+				t.Errorf("Found %q in plaintext in %s", secret, rel)
+			}
+		}
+		return nil
+	})
+
+	// The log still replays
+	result, _ := js.GetTaskHistory(ctx, CreateMockRequest(map[string]interface{}{"task_id": "ENC-2"}))
+	if text := result.Content[0].(mcp.TextContent).Text; result.IsError || !strings.Contains(text, "Severance for the Berlin team") {
+		t.Errorf("Expected the encrypted history to be readable, got:\n%s", text)
+	}
+
+	config.Storage.SQLiteMirror = defaultSQLiteMirror
+	if err := js.validateConfiguration(config); err == nil {
+		t.Error("Expected the SQLite mirror refused with at-rest encryption")
+	}
+}
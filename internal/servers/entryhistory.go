@@ -0,0 +1,118 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Entries are never changed without a trace: update_task_entry keeps the
+// previous content in the entry's history, and delete_task_entry replaces the
+// entry with a "deleted" tombstone that keeps what was removed. Entries that
+// take the place of others list their IDs in Replaces, so merging in an older
+// copy of the task does not bring them back.
+
+// formatEntryHistory renders an entry's earlier versions as quoted lines, newest first
+func formatEntryHistory(entry Entry) string {
+	if len(entry.History) == 0 {
+		return ""
+	}
+	var md strings.Builder
+	label := "Edited"
+	if entry.Type == "deleted" {
+		label = "Removed"
+	}
+	for i := len(entry.History) - 1; i >= 0; i-- {
+		edit := entry.History[i]
+		md.WriteString(fmt.Sprintf("> _%s %s; was:_ %s\n", label, edit.EditedAt.Format("2006-01-02 15:04"), strings.ReplaceAll(edit.Content, "\n", "\n> ")))
+	}
+	md.WriteString("\n")
+	return md.String()
+}
+
+// formatEntryIDs lists a task's entries with their IDs, for update_task_entry and delete_task_entry
+func formatEntryIDs(task *Task) string {
+	var md strings.Builder
+	md.WriteString("\n## Entry IDs\n")
+	for _, entry := range task.Entries {
+		preview := strings.Join(strings.Fields(entry.Content), " ")
+		if runes := []rune(preview); len(runes) > 60 {
+			preview = string(runes[:57]) + "..."
+		}
+		md.WriteString(fmt.Sprintf("- `%s` %s (%s): %s\n", entry.ID, entry.Timestamp.Format("2006-01-02 15:04"), entryTypeLabel(entry), preview))
+	}
+	return md.String()
+}
+
+// DeleteTaskEntry removes an entry, leaving a tombstone entry that records when
+// it was deleted, why, and what it said
+func (js *JournalService) DeleteTaskEntry(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError("task_id is required"), nil
+	}
+
+	entryID, err := request.RequireString("entry_id")
+	if err != nil {
+		return mcp.NewToolResultError("entry_id is required"), nil
+	}
+
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load task: %v", err)), nil
+	}
+
+	index := -1
+	for i, entry := range task.Entries {
+		if entry.ID == entryID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return mcp.NewToolResultError("Entry not found"), nil
+	}
+	deleted := task.Entries[index]
+	if deleted.Type == "deleted" {
+		return mcp.NewToolResultError("Deletion records cannot be deleted"), nil
+	}
+
+	now := time.Now()
+	content := fmt.Sprintf("Deleted %s entry from %s", entryTypeLabel(deleted), deleted.Timestamp.Format("2006-01-02 15:04"))
+	if reason := strings.TrimSpace(request.GetString("reason", "")); reason != "" {
+		content += ": " + reason
+	}
+	tombstone := Entry{
+		ID:        generateEntryID(),
+		Timestamp: now,
+		Content:   content,
+		Type:      "deleted",
+		History:   append(deleted.History, EntryEdit{Content: deleted.Content, EditedAt: now}),
+		Replaces:  append(slices.Clone(deleted.Replaces), deleted.ID),
+	}
+
+	task.Entries = append(task.Entries[:index], task.Entries[index+1:]...)
+	task.Entries = append(task.Entries, tombstone)
+	task.Updated = now
+
+	if err := js.saveTask(task); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task: %v", err)), nil
+	}
+	js.updateDailyLog(taskID, tombstone)
+
+	return mcp.NewToolResultText(fmt.Sprintf("Deleted entry %s from task %s; the deletion and its content are kept in entry %s", entryID, taskID, tombstone.ID)), nil
+}
+
+// entryTypeLabel names an entry's type for the tombstone, "log" when unset
+func entryTypeLabel(entry Entry) string {
+	if entry.Type == "" {
+		return "log"
+	}
+	return entry.Type
+}
@@ -0,0 +1,68 @@
+package servers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestEntryEditHistoryAndDeletion(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "HIST-1", "Cache invalidation", "work")
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "HIST-1", "content": "Cache TTL set to 5 minutes"}))
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "HIST-1", "content": "Pasted the prod password by mistake"}))
+
+	task, _ := js.loadTask("HIST-1")
+	editID := task.Entries[len(task.Entries)-2].ID
+	deleteID := task.Entries[len(task.Entries)-1].ID
+
+	for _, content := range []string{"Cache TTL set to 10 minutes", "Cache TTL set to 15 minutes"} {
+		if result, _ := js.UpdateTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "HIST-1", "entry_id": editID, "content": content})); result.IsError {
+			t.Fatalf("Update failed: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+	}
+	task, _ = js.loadTask("HIST-1")
+	edited := task.Entries[len(task.Entries)-2]
+	if edited.Content != "Cache TTL set to 15 minutes" || len(edited.History) != 2 ||
+		edited.History[0].Content != "Cache TTL set to 5 minutes" || edited.History[0].EditedAt.IsZero() {
+		t.Errorf("Expected two earlier versions, oldest first, got %+v", edited)
+	}
+
+	result, _ := js.DeleteTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "HIST-1", "entry_id": deleteID, "reason": "contained a secret"}))
+	if result.IsError {
+		t.Fatalf("Delete failed: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	task, _ = js.loadTask("HIST-1")
+	for _, entry := range task.Entries {
+		if entry.ID == deleteID {
+			t.Error("Expected the deleted entry removed")
+		}
+	}
+	tombstone := task.Entries[len(task.Entries)-1]
+	if tombstone.Type != "deleted" || !strings.Contains(tombstone.Content, "contained a secret") ||
+		len(tombstone.History) != 1 || tombstone.History[0].Content != "Pasted the prod password by mistake" {
+		t.Errorf("Expected a tombstone keeping the removed content, got %+v", tombstone)
+	}
+
+	// Tombstones are final
+	if result, _ := js.DeleteTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "HIST-1", "entry_id": tombstone.ID})); !result.IsError {
+		t.Error("Expected deleting a tombstone to fail")
+	}
+	if result, _ := js.UpdateTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "HIST-1", "entry_id": tombstone.ID, "content": "nothing to see"})); !result.IsError {
+		t.Error("Expected editing a tombstone to fail")
+	}
+	if result, _ := js.DeleteTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "HIST-1", "entry_id": "missing"})); !result.IsError {
+		t.Error("Expected an error for an unknown entry")
+	}
+
+	got, _ := js.GetTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "HIST-1", "show_entry_ids": "true"}))
+	text := got.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"_Edited", "was:_ Cache TTL set to 5 minutes", "_Removed", "## Entry IDs", editID} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected %q in get_task output:\n%s", want, text)
+		}
+	}
+}
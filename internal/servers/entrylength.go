@@ -0,0 +1,138 @@
+package servers
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	readingWordsPerMinute = 200
+	terseEntryWords       = 12 // average words per entry below which a documentation-heavy task reads as terse
+	terseMinEntries       = 3  // a task needs a few entries before its average means anything
+)
+
+// documentationTags mark tasks whose entries are expected to explain what was tried and found
+var documentationTags = map[string]bool{
+	"docs": true, "documentation": true, "investigation": true, "research": true, "spike": true, "design": true, "rfc": true,
+}
+
+// EntryLengthMetrics describes how much is written in entries and how long it takes to read
+type EntryLengthMetrics struct {
+	EntriesMeasured      int                `json:"entries_measured"`
+	TotalWords           int                `json:"total_words"`
+	AverageWords         float64            `json:"average_words"`
+	MedianWords          int                `json:"median_words"`
+	Distribution         map[string]int     `json:"distribution"`            // entry count per word-count bucket
+	ReadingMinutesByTask map[string]float64 `json:"reading_minutes_by_task"` // at 200 words per minute
+	ReadingMinutesByWeek map[string]float64 `json:"reading_minutes_by_week"` // ISO week, e.g. 2026-W09
+	TerseDocumentation   []TerseTask        `json:"terse_documentation,omitempty"`
+}
+
+// TerseTask is a documentation-heavy task whose entries are short
+type TerseTask struct {
+	TaskID       string  `json:"task_id"`
+	Title        string  `json:"title"`
+	Entries      int     `json:"entries"`
+	AverageWords float64 `json:"average_words"`
+}
+
+// entryLengthBucket names the word-count range of an entry
+func entryLengthBucket(words int) string {
+	switch {
+	case words < 10:
+		return "under_10"
+	case words < 50:
+		return "10_to_49"
+	case words < 150:
+		return "50_to_149"
+	default:
+		return "150_plus"
+	}
+}
+
+// isDocumentationHeavy reports whether a task's entries should carry detail:
+// investigations, and tasks tagged as docs, research, design or spikes
+func isDocumentationHeavy(task *Task) bool {
+	if task.Type == "investigation" {
+		return true
+	}
+	for _, tag := range task.Tags {
+		if documentationTags[strings.ToLower(tag)] {
+			return true
+		}
+	}
+	return false
+}
+
+// calculateEntryLengthMetrics measures written entries, or returns nil when there are none
+func (js *JournalService) calculateEntryLengthMetrics(tasks []*Task) *EntryLengthMetrics {
+	metrics := &EntryLengthMetrics{
+		Distribution:         make(map[string]int),
+		ReadingMinutesByTask: make(map[string]float64),
+		ReadingMinutesByWeek: make(map[string]float64),
+	}
+	round := func(v float64) float64 { return math.Round(v*10) / 10 }
+	var lengths []int
+	weekWords := make(map[string]int)
+
+	for _, task := range tasks {
+		taskWords, taskEntries := 0, 0
+		for _, entry := range task.Entries {
+			if !isWrittenEntry(entry) {
+				continue
+			}
+			words := len(strings.Fields(entry.Content))
+			lengths = append(lengths, words)
+			metrics.Distribution[entryLengthBucket(words)]++
+			weekWords[isoWeek(entry.Timestamp)] += words
+			taskWords += words
+			taskEntries++
+		}
+		if taskEntries == 0 {
+			continue
+		}
+		metrics.TotalWords += taskWords
+		metrics.ReadingMinutesByTask[task.ID] = round(float64(taskWords) / readingWordsPerMinute)
+
+		average := float64(taskWords) / float64(taskEntries)
+		if isDocumentationHeavy(task) && taskEntries >= terseMinEntries && average < terseEntryWords {
+			metrics.TerseDocumentation = append(metrics.TerseDocumentation, TerseTask{
+				TaskID:       task.ID,
+				Title:        task.Title,
+				Entries:      taskEntries,
+				AverageWords: round(average),
+			})
+		}
+	}
+	if len(lengths) == 0 {
+		return nil
+	}
+
+	metrics.EntriesMeasured = len(lengths)
+	metrics.AverageWords = round(float64(metrics.TotalWords) / float64(len(lengths)))
+	sort.Ints(lengths)
+	metrics.MedianWords = lengths[len(lengths)/2]
+	for week, words := range weekWords {
+		metrics.ReadingMinutesByWeek[week] = round(float64(words) / readingWordsPerMinute)
+	}
+	sort.Slice(metrics.TerseDocumentation, func(i, j int) bool {
+		return metrics.TerseDocumentation[i].TaskID < metrics.TerseDocumentation[j].TaskID
+	})
+
+	return metrics
+}
+
+// entryLengthInsights nudges toward fuller logging on documentation-heavy tasks
+func entryLengthInsights(metrics *EntryLengthMetrics) []string {
+	if metrics == nil {
+		return nil
+	}
+	var insights []string
+	for _, task := range metrics.TerseDocumentation {
+		insights = append(insights, fmt.Sprintf("%s (%s) is documentation-heavy, but its %d entries average %.0f words. Log what you tried, what you found and why, so the trail is useful later.",
+			task.TaskID, task.Title, task.Entries, task.AverageWords))
+	}
+	return insights
+}
@@ -0,0 +1,57 @@
+package servers
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEntryLengthMetrics(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	monday := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	long := strings.Repeat("word ", 400)
+
+	tasks := []*Task{
+		{ID: "INV-1", Title: "Why is the cache cold", Type: "investigation", Entries: []Entry{
+			{Timestamp: monday, Content: "Task created: Why is the cache cold", Type: "creation"},
+			{Timestamp: monday, Content: "checked logs", Type: "log"},
+			{Timestamp: monday, Content: "nothing there", Type: "log"},
+			{Timestamp: monday.AddDate(0, 0, 7), Content: "fixed it", Type: "log"},
+		}},
+		{ID: "DOC-1", Title: "Write the design doc", Type: "work", Tags: []string{"design"}, Entries: []Entry{
+			{Timestamp: monday, Content: long, Type: "log"},
+			{Timestamp: monday, Content: long, Type: "log"},
+			{Timestamp: monday, Content: long, Type: "log"},
+		}},
+		{ID: "OPS-1", Title: "Rotate keys", Type: "work", Entries: []Entry{
+			{Timestamp: monday, Content: "done", Type: "log"},
+			{Timestamp: monday, Content: "Worked 30m", Type: "time", Minutes: 30},
+		}},
+	}
+
+	metrics := js.calculateEntryLengthMetrics(tasks)
+	if metrics == nil || metrics.EntriesMeasured != 7 {
+		t.Fatalf("Expected 7 written entries, got %+v", metrics)
+	}
+	if metrics.Distribution["under_10"] != 4 || metrics.Distribution["150_plus"] != 3 {
+		t.Errorf("Unexpected distribution: %v", metrics.Distribution)
+	}
+	if metrics.MedianWords != 2 {
+		t.Errorf("Expected a median of 2 words, got %d", metrics.MedianWords)
+	}
+	if metrics.ReadingMinutesByTask["DOC-1"] != 6 || metrics.ReadingMinutesByWeek["2026-W10"] != 6 {
+		t.Errorf("Expected 6 minutes of reading for 1200 words, got %v and %v", metrics.ReadingMinutesByTask, metrics.ReadingMinutesByWeek)
+	}
+
+	// Only the investigation is both documentation-heavy and terse
+	if len(metrics.TerseDocumentation) != 1 || metrics.TerseDocumentation[0].TaskID != "INV-1" {
+		t.Fatalf("Expected INV-1 flagged as terse, got %+v", metrics.TerseDocumentation)
+	}
+	if insights := entryLengthInsights(metrics); len(insights) != 1 || !strings.Contains(insights[0], "INV-1") {
+		t.Errorf("Expected a terse documentation insight, got %v", insights)
+	}
+
+	if js.calculateEntryLengthMetrics([]*Task{{ID: "T"}}) != nil {
+		t.Error("Expected no metrics without written entries")
+	}
+}
@@ -0,0 +1,219 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultSplitSeparator marks a split point when it is alone on a line. It may
+// be followed by a time, e.g. "--- 14:30", to timestamp the next part.
+const defaultSplitSeparator = "---"
+
+// splitPart is one piece of an entry being split
+type splitPart struct {
+	content string
+	clock   string // HH:MM from the separator line, if any
+}
+
+// splitEntryContent breaks content at separator lines
+func splitEntryContent(content, separator string) []splitPart {
+	var parts []splitPart
+	current := splitPart{}
+	var lines []string
+	flush := func() {
+		current.content = strings.TrimSpace(strings.Join(lines, "\n"))
+		if current.content != "" {
+			parts = append(parts, current)
+		}
+		lines = nil
+	}
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == separator {
+			flush()
+			current = splitPart{}
+			continue
+		}
+		if rest, ok := strings.CutPrefix(trimmed, separator+" "); ok {
+			if _, err := time.Parse("15:04", strings.TrimSpace(rest)); err == nil {
+				flush()
+				current = splitPart{clock: strings.TrimSpace(rest)}
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return parts
+}
+
+// SplitEntry breaks an entry into several at separator lines. The first part
+// keeps the entry's ID and timestamp, with the original content kept in its
+// history; later parts follow a second apart unless their separator gives a time.
+func (js *JournalService) SplitEntry(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError("task_id is required"), nil
+	}
+	entryID, err := request.RequireString("entry_id")
+	if err != nil {
+		return mcp.NewToolResultError("entry_id is required"), nil
+	}
+	separator := strings.TrimSpace(request.GetString("separator", defaultSplitSeparator))
+	if separator == "" {
+		separator = defaultSplitSeparator
+	}
+
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskID)), nil
+	}
+	index := findEntryIndex(task, entryID)
+	if index < 0 {
+		return mcp.NewToolResultError("Entry not found"), nil
+	}
+	original := task.Entries[index]
+	if original.Type == "deleted" {
+		return mcp.NewToolResultError("Deletion records cannot be split"), nil
+	}
+
+	parts := splitEntryContent(original.Content, separator)
+	if len(parts) < 2 {
+		return mcp.NewToolResultError(fmt.Sprintf("No split points found; put a line with %s (optionally followed by HH:MM) where the entry should be split", separator)), nil
+	}
+
+	now := time.Now()
+	loc := js.location()
+	day := original.Timestamp.In(loc)
+	entries := make([]Entry, len(parts))
+	for i, part := range parts {
+		if i == 0 {
+			entries[0] = original
+			entries[0].Content = part.content
+			entries[0].History = append(append([]EntryEdit{}, original.History...), EntryEdit{Content: original.Content, EditedAt: now})
+			continue
+		}
+		timestamp := entries[i-1].Timestamp.Add(time.Second)
+		if part.clock != "" {
+			clock, _ := time.Parse("15:04", part.clock)
+			timestamp = time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+			if timestamp.Before(entries[i-1].Timestamp) {
+				return mcp.NewToolResultError(fmt.Sprintf("Split times must be in order: %s is before the previous part", part.clock)), nil
+			}
+		}
+		entries[i] = Entry{ID: fmt.Sprintf("%s_%d", generateEntryID(), i), Timestamp: timestamp, Content: part.content, Type: original.Type}
+	}
+
+	task.Entries = append(task.Entries[:index], append(entries, task.Entries[index+1:]...)...)
+	task.Updated = now
+	if err := js.saveTask(task); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task: %v", err)), nil
+	}
+
+	if _, err := js.removeDailyLogEntry(taskID, original); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Split the entry but failed to update the daily log: %v", err)), nil
+	}
+	var ids []string
+	for _, entry := range entries {
+		js.updateDailyLog(taskID, entry)
+		ids = append(ids, entry.ID)
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Split entry %s of task %s into %d entries: %s", entryID, taskID, len(entries), strings.Join(ids, ", "))), nil
+}
+
+// MergeEntries combines consecutive entries into the first one. The merged
+// entry keeps the first entry's ID, timestamp and type, sums tracked minutes
+// and keeps every attachment; the first entry's previous content goes into
+// its history.
+func (js *JournalService) MergeEntries(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError("task_id is required"), nil
+	}
+	entryIDs := request.GetStringSlice("entry_ids", nil)
+	if len(entryIDs) < 2 {
+		return mcp.NewToolResultError("entry_ids needs at least two entries"), nil
+	}
+	separator := request.GetString("separator", "\n\n")
+
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskID)), nil
+	}
+
+	var indexes []int
+	for _, id := range entryIDs {
+		index := findEntryIndex(task, id)
+		if index < 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Entry not found: %s", id)), nil
+		}
+		if task.Entries[index].Type == "deleted" {
+			return mcp.NewToolResultError("Deletion records cannot be merged"), nil
+		}
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for i := 1; i < len(indexes); i++ {
+		if indexes[i] != indexes[i-1]+1 {
+			return mcp.NewToolResultError("Only consecutive entries can be merged; get_task with show_entry_ids=true lists them in order"), nil
+		}
+	}
+
+	now := time.Now()
+	first := indexes[0]
+	merged := task.Entries[first]
+	removed := task.Entries[first : indexes[len(indexes)-1]+1]
+	var contents []string
+	merged.History = nil
+	merged.Attachments = nil
+	merged.Minutes = 0
+	merged.Replaces = slices.Clone(merged.Replaces)
+	for i, entry := range removed {
+		contents = append(contents, entry.Content)
+		merged.History = append(merged.History, entry.History...)
+		merged.Attachments = append(merged.Attachments, entry.Attachments...)
+		merged.Minutes += entry.Minutes
+		if i > 0 {
+			merged.Replaces = append(append(merged.Replaces, entry.Replaces...), entry.ID)
+		}
+	}
+	merged.History = append(merged.History, EntryEdit{Content: task.Entries[first].Content, EditedAt: now})
+	merged.Content = strings.Join(contents, separator)
+
+	removed = append([]Entry{}, removed...)
+	task.Entries = append(task.Entries[:first], append([]Entry{merged}, task.Entries[indexes[len(indexes)-1]+1:]...)...)
+	task.Updated = now
+	if err := js.saveTask(task); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task: %v", err)), nil
+	}
+
+	for _, entry := range removed {
+		if _, err := js.removeDailyLogEntry(taskID, entry); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Merged the entries but failed to update the daily log: %v", err)), nil
+		}
+	}
+	js.updateDailyLog(taskID, merged)
+
+	return mcp.NewToolResultText(fmt.Sprintf("Merged %d entries of task %s into entry %s", len(removed), taskID, merged.ID)), nil
+}
+
+// findEntryIndex returns the position of an entry in a task, or -1
+func findEntryIndex(task *Task, entryID string) int {
+	for i, entry := range task.Entries {
+		if entry.ID == entryID {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,113 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func dailyLogEntryIDs(t *testing.T, js *JournalService, taskID string, day time.Time) []string {
+	t.Helper()
+	data, _ := js.readDataFile(filepath.Join(js.DataDir, "daily", day.Format("2006-01-02")+".json"))
+	var activity DailyActivity
+	json.Unmarshal(data, &activity)
+	var ids []string
+	for _, entry := range activity.Tasks[taskID] {
+		ids = append(ids, entry.ID)
+	}
+	return ids
+}
+
+func TestSplitEntry(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	config := defaultConfiguration()
+	config.General.TimeZone = "UTC"
+	js.saveConfiguration(config)
+	createTestTask(t, js, "SPL-1", "Imported notes", "work")
+
+	task, _ := js.loadTask("SPL-1")
+	day := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	imported := Entry{ID: "entry_import", Timestamp: day, Type: "log", Content: "Standup notes\n---\nReviewed the PR\n--- 14:30\nDeployed to staging\n---\n"}
+	task.Entries = append(task.Entries, imported)
+	js.saveTask(task)
+	js.updateDailyLog("SPL-1", imported)
+
+	result, _ := js.SplitEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "SPL-1", "entry_id": "entry_import"}))
+	if result.IsError {
+		t.Fatalf("Split failed: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+
+	task, _ = js.loadTask("SPL-1")
+	parts := task.Entries[len(task.Entries)-3:]
+	if parts[0].ID != "entry_import" || parts[0].Content != "Standup notes" || !parts[0].Timestamp.Equal(day) {
+		t.Errorf("Expected the first part to keep the entry, got %+v", parts[0])
+	}
+	if len(parts[0].History) != 1 || parts[0].History[0].Content != imported.Content {
+		t.Errorf("Expected the original content in the history, got %+v", parts[0].History)
+	}
+	if parts[1].Content != "Reviewed the PR" || !parts[1].Timestamp.Equal(day.Add(time.Second)) || parts[1].Type != "log" {
+		t.Errorf("Unexpected second part: %+v", parts[1])
+	}
+	if parts[2].Content != "Deployed to staging" || !parts[2].Timestamp.Equal(time.Date(2026, 3, 14, 14, 30, 0, 0, time.UTC)) {
+		t.Errorf("Expected the third part at 14:30, got %+v", parts[2])
+	}
+	if ids := dailyLogEntryIDs(t, js, "SPL-1", day); len(ids) != 3 || ids[0] != "entry_import" {
+		t.Errorf("Expected the three parts in the daily log, got %v", ids)
+	}
+
+	if result, _ := js.SplitEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "SPL-1", "entry_id": parts[1].ID})); !result.IsError {
+		t.Error("Expected an entry without separators to be refused")
+	}
+}
+
+func TestMergeEntries(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "MRG-1", "Choppy log", "work")
+	for _, content := range []string{"Looked at", "the flaky test", "and fixed it"} {
+		js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "MRG-1", "content": content}))
+	}
+	task, _ := js.loadTask("MRG-1")
+	n := len(task.Entries)
+	first, second, third := task.Entries[n-3], task.Entries[n-2], task.Entries[n-1]
+
+	if result, _ := js.MergeEntries(ctx, CreateMockRequest(map[string]interface{}{"task_id": "MRG-1", "entry_ids": []interface{}{first.ID, third.ID}})); !result.IsError {
+		t.Error("Expected non-consecutive entries to be refused")
+	}
+
+	result, _ := js.MergeEntries(ctx, CreateMockRequest(map[string]interface{}{
+		"task_id": "MRG-1", "entry_ids": []interface{}{third.ID, first.ID, second.ID}, "separator": " ",
+	}))
+	if result.IsError {
+		t.Fatalf("Merge failed: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	task, _ = js.loadTask("MRG-1")
+	if len(task.Entries) != n-2 {
+		t.Fatalf("Expected %d entries, got %d", n-2, len(task.Entries))
+	}
+	merged := task.Entries[len(task.Entries)-1]
+	if merged.ID != first.ID || merged.Content != "Looked at the flaky test and fixed it" || !merged.Timestamp.Equal(first.Timestamp) {
+		t.Errorf("Unexpected merged entry: %+v", merged)
+	}
+	if len(merged.History) != 1 || merged.History[0].Content != "Looked at" {
+		t.Errorf("Expected the first entry's content in the history, got %+v", merged.History)
+	}
+	ids := dailyLogEntryIDs(t, js, "MRG-1", first.Timestamp)
+	for _, id := range ids {
+		if id == second.ID || id == third.ID {
+			t.Errorf("Expected merged entries removed from the daily log, got %v", ids)
+		}
+	}
+	if ids[len(ids)-1] != first.ID {
+		t.Errorf("Expected the merged entry in the daily log, got %v", ids)
+	}
+
+	if result, _ := js.MergeEntries(ctx, CreateMockRequest(map[string]interface{}{"task_id": "MRG-1", "entry_ids": []interface{}{first.ID}})); !result.IsError {
+		t.Error("Expected a single entry to be refused")
+	}
+}
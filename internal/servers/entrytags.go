@@ -0,0 +1,184 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// entryHashtag finds hashtags such as #decision or #follow-up in entry text.
+// A tag starts with a letter, so issue references like #123 and markdown
+// headings are not tags, and a # inside a word, as in a URL fragment, is not
+// either.
+var entryHashtag = regexp.MustCompile(`(?:^|[\s(\[])#(\pL[\pL\pN_-]*)`)
+
+// normalizeEntryTag lowercases a tag and drops its leading #
+func normalizeEntryTag(tag string) string {
+	return strings.ToLower(strings.TrimLeft(strings.TrimSpace(tag), "#"))
+}
+
+// normalizeEntryTags normalizes tags, dropping empty and repeated ones
+func normalizeEntryTags(tags []string) []string {
+	var normalized []string
+	for _, tag := range tags {
+		if tag = normalizeEntryTag(tag); tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// hashtags returns the tags written into content, normalized
+func hashtags(content string) []string {
+	var tags []string
+	for _, match := range entryHashtag.FindAllStringSubmatch(content, -1) {
+		tags = append(tags, strings.TrimRight(match[1], "-_"))
+	}
+	return normalizeEntryTags(tags)
+}
+
+// retagEntry brings an entry's tags in line with its content after an edit:
+// hashtags removed from the text are dropped and new ones added. Tags given
+// explicitly, rather than written in the text, are kept.
+func retagEntry(entry *Entry, previous string) {
+	current := hashtags(entry.Content)
+	tags := slices.DeleteFunc(slices.Clone(entry.Tags), func(tag string) bool {
+		return slices.Contains(hashtags(previous), tag) && !slices.Contains(current, tag)
+	})
+	entry.Tags = normalizeEntryTags(append(tags, current...))
+}
+
+// entryHasTag reports whether an entry carries any of tags, which are
+// normalized. Hashtags in the text count too, so entries written before tags
+// were recorded still match.
+func entryHasTag(entry Entry, tags []string) bool {
+	for _, tag := range append(slices.Clone(entry.Tags), hashtags(entry.Content)...) {
+		if slices.Contains(tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// entryTagSuffix lists the tags an entry carries beyond the hashtags in its
+// text, for markdown output
+func entryTagSuffix(entry Entry) string {
+	written := hashtags(entry.Content)
+	var extra []string
+	for _, tag := range entry.Tags {
+		if !slices.Contains(written, tag) {
+			extra = append(extra, "#"+tag)
+		}
+	}
+	if len(extra) == 0 {
+		return ""
+	}
+	return " " + strings.Join(extra, " ")
+}
+
+// TaggedEntry is an entry found by list_entries_by_tag
+type TaggedEntry struct {
+	TaskID    string
+	TaskTitle string
+	Entry     Entry
+}
+
+// ListEntriesByTag lists the entries carrying a tag, oldest first, such as
+// every #decision made in a quarter
+func (js *JournalService) ListEntriesByTag(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tagArg, err := request.RequireString("tag")
+	if err != nil {
+		return mcp.NewToolResultError("tag is required"), nil
+	}
+	tags := normalizeEntryTags(strings.Split(tagArg, ","))
+	if len(tags) == 0 {
+		return mcp.NewToolResultError("tag is required"), nil
+	}
+	lines, err := wantsNDJSON(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	loc := js.location()
+	var from, to time.Time
+	if timeframe := request.GetString("timeframe", ""); timeframe != "" {
+		if from, to, err = timeframeBounds(timeframe, loc); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+	if dateFrom := request.GetString("date_from", ""); dateFrom != "" {
+		if validationErr := js.validateDateFormat(dateFrom, "date_from"); validationErr != nil {
+			return mcp.NewToolResultError(validationErr.Error()), nil
+		}
+		from, _ = time.ParseInLocation("2006-01-02", dateFrom, loc)
+	}
+	if dateTo := request.GetString("date_to", ""); dateTo != "" {
+		if validationErr := js.validateDateFormat(dateTo, "date_to"); validationErr != nil {
+			return mcp.NewToolResultError(validationErr.Error()), nil
+		}
+		to, _ = time.ParseInLocation("2006-01-02", dateTo, loc)
+		to = to.AddDate(0, 0, 1)
+	}
+	taskType := request.GetString("task_type", "")
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+	if request.GetString("include_archived", "false") == "true" {
+		archived, err := js.loadArchivedTasks()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to load archived tasks: %v", err)), nil
+		}
+		tasks = append(tasks, archived...)
+	}
+
+	var found []TaggedEntry
+	for _, task := range tasks {
+		if taskType != "" && task.Type != taskType {
+			continue
+		}
+		for _, entry := range task.Entries {
+			if entry.Type == "deleted" || !entryHasTag(entry, tags) {
+				continue
+			}
+			if !from.IsZero() && entry.Timestamp.Before(from) || !to.IsZero() && !entry.Timestamp.Before(to) {
+				continue
+			}
+			title := task.Title
+			if task.Archived {
+				title += " (archived)"
+			}
+			entry.Timestamp = entry.Timestamp.In(loc)
+			found = append(found, TaggedEntry{TaskID: task.ID, TaskTitle: title, Entry: entry})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].Entry.Timestamp.Before(found[j].Entry.Timestamp) })
+
+	if lines {
+		page := make([]LogLine, 0, len(found))
+		for _, tagged := range found {
+			page = append(page, LogLine{Date: tagged.Entry.Timestamp.Format("2006-01-02"), TaskID: tagged.TaskID, TaskTitle: tagged.TaskTitle, Entry: tagged.Entry})
+		}
+		return mcp.NewToolResultText(ndjson(page)), nil
+	}
+
+	var markdown strings.Builder
+	markdown.WriteString(fmt.Sprintf("# Entries tagged #%s\n\n", strings.Join(tags, ", #")))
+	if len(found) == 0 {
+		markdown.WriteString("No tagged entries found.")
+		return mcp.NewToolResultText(markdown.String()), nil
+	}
+	for _, tagged := range found {
+		markdown.WriteString(fmt.Sprintf("## %s · %s: %s\n\n", tagged.Entry.Timestamp.Format("2006-01-02 15:04"), tagged.TaskID, tagged.TaskTitle))
+		markdown.WriteString(tagged.Entry.Content + entryTagSuffix(tagged.Entry) + "\n\n")
+	}
+	markdown.WriteString(fmt.Sprintf("%d entries\n", len(found)))
+	return mcp.NewToolResultText(markdown.String()), nil
+}
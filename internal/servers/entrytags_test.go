@@ -0,0 +1,143 @@
+package servers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestHashtags(t *testing.T) {
+	tests := []struct {
+		content string
+		want    []string
+	}{
+		{"Going with Postgres #decision", []string{"decision"}},
+		{"#Blocker: waiting on #infra, see #123", []string{"blocker", "infra"}},
+		{"# Heading\nsee https://example.com/page#section", nil},
+		{"(#follow-up) and #idea- later #idea", []string{"follow-up", "idea"}},
+	}
+	for _, test := range tests {
+		if got := hashtags(test.content); !slices.Equal(got, test.want) {
+			t.Errorf("hashtags(%q) = %v, want %v", test.content, got, test.want)
+		}
+	}
+
+	entry := Entry{Content: "Rolled back #incident", Tags: []string{"decision", "blocker"}}
+	retagEntry(&entry, "Rolled back #blocker")
+	if !slices.Equal(entry.Tags, []string{"decision", "incident"}) {
+		t.Errorf("Expected the removed hashtag dropped and the given tag kept, got %v", entry.Tags)
+	}
+}
+
+func TestEntryTags(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	config := defaultConfiguration()
+	config.General.TimeZone = "UTC"
+	js.saveConfiguration(config)
+
+	createTestTask(t, js, "TAG-1", "Pick a queue", "work")
+	createTestTask(t, js, "TAG-2", "Learn Rust", "learning")
+
+	addEntry := func(taskID, content, timestamp string, tags ...interface{}) {
+		args := map[string]interface{}{"task_id": taskID, "content": content, "timestamp": timestamp}
+		if len(tags) > 0 {
+			args["tags"] = tags
+		}
+		if result, _ := js.AddTaskEntry(ctx, CreateMockRequest(args)); result.IsError {
+			t.Fatalf("AddTaskEntry failed: %v", result.Content)
+		}
+	}
+	addEntry("TAG-1", "Going with SQS over Kafka #decision", "2026-07-14T10:00:00Z")
+	addEntry("TAG-1", "Queue quota request pending", "2026-07-14T15:00:00Z", "#Blocker")
+	addEntry("TAG-2", "Use the queue crate for the workshop", "2026-10-02T09:00:00Z", "decision", "idea")
+	addEntry("TAG-2", "Ownership finally clicked", "2026-10-02T11:00:00Z")
+
+	// An entry saved before tags were recorded still matches its hashtags
+	task, _ := js.loadTask("TAG-2")
+	task.Entries = append(task.Entries, Entry{ID: generateEntryID(), Timestamp: time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC), Type: "note", Content: "Skip the async chapter #decision"})
+	js.saveTask(task)
+
+	task, _ = js.loadTask("TAG-1")
+	if tags := task.Entries[2].Tags; !slices.Equal(tags, []string{"blocker"}) {
+		t.Errorf("Expected the given tag normalized, got %v", tags)
+	}
+
+	text := func(result *mcp.CallToolResult) string {
+		t.Helper()
+		if result.IsError {
+			t.Fatalf("Tool failed: %v", result.Content)
+		}
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	result, _ := js.ListEntriesByTag(ctx, CreateMockRequest(map[string]interface{}{"tag": "#decision"}))
+	listed := text(result)
+	if !strings.Contains(listed, "3 entries") || strings.Index(listed, "async chapter") > strings.Index(listed, "SQS") || strings.Index(listed, "SQS") > strings.Index(listed, "queue crate") {
+		t.Errorf("Expected all three decisions, oldest first:\n%s", listed)
+	}
+	if !strings.Contains(listed, "Use the queue crate for the workshop #decision #idea") {
+		t.Errorf("Expected given tags shown:\n%s", listed)
+	}
+	result, _ = js.ListEntriesByTag(ctx, CreateMockRequest(map[string]interface{}{"tag": "decision", "timeframe": "2026-Q4"}))
+	if listed := text(result); !strings.Contains(listed, "1 entries") || strings.Contains(listed, "SQS") {
+		t.Errorf("Expected only the quarter's decision:\n%s", listed)
+	}
+	result, _ = js.ListEntriesByTag(ctx, CreateMockRequest(map[string]interface{}{"tag": "decision,blocker", "task_type": "work", "format": "ndjson"}))
+	if lines := strings.Split(strings.TrimSpace(text(result)), "\n"); len(lines) != 2 {
+		t.Errorf("Expected two work entries, got %v", lines)
+	}
+	if result, _ := js.ListEntriesByTag(ctx, CreateMockRequest(map[string]interface{}{"tag": "decision", "timeframe": "2026-Q5"})); !result.IsError {
+		t.Error("Expected an invalid timeframe refused")
+	}
+
+	// Search filtered by entry tag
+	result, _ = js.SearchEntries(ctx, CreateMockRequest(map[string]interface{}{"query": "queue", "tags": []interface{}{"decision"}}))
+	found := text(result)
+	if !strings.Contains(found, "queue crate") || strings.Contains(found, "quota") {
+		t.Errorf("Expected only the tagged match:\n%s", found)
+	}
+
+	// Daily log filtered by entry tag
+	result, _ = js.GetDailyLog(ctx, CreateMockRequest(map[string]interface{}{"date": "2026-10-02", "tags": []interface{}{"idea"}}))
+	daily := text(result)
+	if !strings.Contains(daily, "queue crate") || strings.Contains(daily, "Ownership") {
+		t.Errorf("Expected only the tagged entry in the daily log:\n%s", daily)
+	}
+
+	// Editing the text moves hashtags; tags replaces them
+	entryID := task.Entries[1].ID
+	js.UpdateTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "TAG-1", "entry_id": entryID, "content": "Going with SQS #decision #adr"}))
+	task, _ = js.loadTask("TAG-1")
+	if tags := task.Entries[1].Tags; !slices.Equal(tags, []string{"decision", "adr"}) {
+		t.Errorf("Expected the new hashtag added, got %v", tags)
+	}
+	result, _ = js.UpdateTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "TAG-1", "entry_id": entryID, "content": "Going with SQS #decision #adr", "tags": []interface{}{"architecture"}}))
+	if text(result) == "Entry in task TAG-1 is unchanged" {
+		t.Error("Expected a tag change to count as an update")
+	}
+	task, _ = js.loadTask("TAG-1")
+	if edited := task.Entries[1]; !slices.Equal(edited.Tags, []string{"architecture", "decision", "adr"}) || len(edited.History) != 1 {
+		t.Errorf("Expected tags replaced without a new history version, got %+v", edited)
+	}
+
+	// Tags edited into a markdown copy are read back
+	config.Storage.Format = "mirror"
+	js.saveConfiguration(config)
+	js.saveTask(task)
+	copyPath := filepath.Join(js.DataDir, "tasks", "TAG-1.md")
+	data, _ := os.ReadFile(copyPath)
+	os.WriteFile(copyPath, []byte(strings.Replace(string(data), "Queue quota request pending", "Queue quota approved #unblocked", 1)), 0644)
+	at := time.Now().Add(time.Second)
+	os.Chtimes(copyPath, at, at)
+	task, _ = js.loadTask("TAG-1")
+	if tags := task.Entries[2].Tags; !slices.Equal(tags, []string{"blocker", "unblocked"}) {
+		t.Errorf("Expected the hashtag from the markdown edit, got %v", tags)
+	}
+}
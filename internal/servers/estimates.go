@@ -0,0 +1,148 @@
+package servers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultReestimateFactor = 1.5
+
+// EstimateMetrics compares tracked time with task estimates
+type EstimateMetrics struct {
+	Factor             float64           `json:"reestimate_factor"`
+	EstimatedTasks     int               `json:"estimated_tasks"`
+	CompletedEstimated int               `json:"completed_estimated_tasks"`
+	AccuracyRatio      float64           `json:"accuracy_ratio,omitempty"` // tracked / estimated time across completed estimated tasks
+	Overruns           []EstimateOverrun `json:"overruns,omitempty"`       // open tasks past the factor
+}
+
+// EstimateOverrun is an open task whose tracked time has passed its estimate by the factor
+type EstimateOverrun struct {
+	TaskID          string  `json:"task_id"`
+	Title           string  `json:"title"`
+	EstimateMinutes int     `json:"estimate_minutes"`
+	TrackedMinutes  int     `json:"tracked_minutes"`
+	Ratio           float64 `json:"ratio"`
+}
+
+// parseEstimate reads an estimate as minutes ("90") or a duration ("90m", "3h", "1h30m")
+func parseEstimate(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if minutes, err := strconv.Atoi(value); err == nil && minutes > 0 {
+		return minutes, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < time.Minute {
+		return 0, fmt.Errorf("invalid estimate %q (use minutes or a duration such as 90m, 3h or 1h30m)", value)
+	}
+	return int(duration.Minutes()), nil
+}
+
+// trackedMinutes sums the time recorded on a task's time entries
+func trackedMinutes(task *Task) int {
+	total := 0
+	for _, entry := range task.Entries {
+		total += entry.Minutes
+	}
+	return total
+}
+
+// reestimateFactor returns general.reestimate_factor, defaulting to 1.5
+func (js *JournalService) reestimateFactor() float64 {
+	if config, err := js.loadConfiguration(); err == nil && config.General.ReestimateFactor > 0 {
+		return config.General.ReestimateFactor
+	}
+	return defaultReestimateFactor
+}
+
+// checkEstimate adds a "reestimate" entry, once per estimate, when the time
+// tracked on a task passes its estimate by general.reestimate_factor. The
+// caller saves the task.
+func (js *JournalService) checkEstimate(task *Task, now time.Time) bool {
+	if task.EstimateMinutes == 0 || task.ReestimatePrompted {
+		return false
+	}
+	tracked := trackedMinutes(task)
+	factor := js.reestimateFactor()
+	if float64(tracked) <= float64(task.EstimateMinutes)*factor {
+		return false
+	}
+
+	message := fmt.Sprintf("Tracked %s against an estimate of %s (%.1fx). Re-estimate the remaining work with update_task estimate=...",
+		formatMinutes(tracked), formatMinutes(task.EstimateMinutes), float64(tracked)/float64(task.EstimateMinutes))
+	task.Entries = append(task.Entries, Entry{
+		ID:        generateEntryID(),
+		Timestamp: now,
+		Content:   message,
+		Type:      "reestimate",
+	})
+	task.ReestimatePrompted = true
+	js.notify("reestimate", task.ID, fmt.Sprintf("%s: %s", task.ID, message))
+	return true
+}
+
+// calculateEstimateMetrics reports estimate accuracy and open overruns, or nil
+// when no task has an estimate
+func (js *JournalService) calculateEstimateMetrics(tasks []*Task) *EstimateMetrics {
+	metrics := &EstimateMetrics{Factor: js.reestimateFactor()}
+	var estimated, tracked int
+	for _, task := range tasks {
+		if task.EstimateMinutes == 0 {
+			continue
+		}
+		metrics.EstimatedTasks++
+		minutes := trackedMinutes(task)
+		ratio := float64(minutes) / float64(task.EstimateMinutes)
+
+		if task.Status == "completed" {
+			if minutes > 0 {
+				metrics.CompletedEstimated++
+				estimated += task.EstimateMinutes
+				tracked += minutes
+			}
+		} else if ratio > metrics.Factor {
+			metrics.Overruns = append(metrics.Overruns, EstimateOverrun{
+				TaskID:          task.ID,
+				Title:           task.Title,
+				EstimateMinutes: task.EstimateMinutes,
+				TrackedMinutes:  minutes,
+				Ratio:           float64(int(ratio*100+0.5)) / 100,
+			})
+		}
+	}
+	if metrics.EstimatedTasks == 0 {
+		return nil
+	}
+	if estimated > 0 {
+		metrics.AccuracyRatio = float64(int(float64(tracked)/float64(estimated)*100+0.5)) / 100
+	}
+	sort.Slice(metrics.Overruns, func(i, j int) bool { return metrics.Overruns[i].Ratio > metrics.Overruns[j].Ratio })
+	return metrics
+}
+
+// estimateInsights turns estimate metrics into report insights
+func estimateInsights(metrics *EstimateMetrics) []string {
+	if metrics == nil {
+		return nil
+	}
+	var insights []string
+	for _, overrun := range metrics.Overruns {
+		insights = append(insights, fmt.Sprintf("%s (%s) has taken %.1fx its estimate (%s of %s). Consider re-estimating it.",
+			overrun.TaskID, overrun.Title, overrun.Ratio, formatMinutes(overrun.TrackedMinutes), formatMinutes(overrun.EstimateMinutes)))
+	}
+	// A single task says little about how estimates run
+	if metrics.CompletedEstimated >= 3 && (metrics.AccuracyRatio > 1.2 || metrics.AccuracyRatio < 0.8) {
+		insights = append(insights, fmt.Sprintf("Completed tasks took %.1fx their estimates. Scale new estimates accordingly.", metrics.AccuracyRatio))
+	}
+	return insights
+}
+
+func formatEstimate(minutes int) string {
+	if minutes == 0 {
+		return "none"
+	}
+	return formatMinutes(minutes)
+}
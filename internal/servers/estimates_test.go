@@ -0,0 +1,104 @@
+package servers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestParseEstimate(t *testing.T) {
+	for value, want := range map[string]int{"90": 90, "90m": 90, "3h": 180, "1h30m": 90} {
+		if got, err := parseEstimate(value); err != nil || got != want {
+			t.Errorf("parseEstimate(%q) = %d, %v; want %d", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"", "0", "-5", "soon", "30s"} {
+		if _, err := parseEstimate(value); err == nil {
+			t.Errorf("Expected parseEstimate(%q) to fail", value)
+		}
+	}
+}
+
+func TestReestimationPrompt(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	js.CreateTask(ctx, CreateMockRequest(map[string]interface{}{"id": "EST-1", "title": "Migrate billing", "type": "work", "estimate": "2h"}))
+
+	track := func(minutes int) string {
+		task, _ := js.loadTask("EST-1")
+		started := time.Now().Add(-time.Duration(minutes) * time.Minute)
+		task.TimerStarted = &started
+		js.saveTask(task)
+		result, _ := js.StopTimer(ctx, CreateMockRequest(map[string]interface{}{"task_id": "EST-1"}))
+		return result.Content[0].(mcp.TextContent).Text
+	}
+	countPrompts := func() int {
+		task, _ := js.loadTask("EST-1")
+		count := 0
+		for _, entry := range task.Entries {
+			if entry.Type == "reestimate" {
+				count++
+			}
+		}
+		return count
+	}
+
+	// 2h30m of a 2h estimate is within the default 1.5x factor
+	track(150)
+	if countPrompts() != 0 {
+		t.Error("Expected no prompt within the factor")
+	}
+
+	if text := track(40); !strings.Contains(text, "Re-estimate") || countPrompts() != 1 {
+		t.Errorf("Expected a re-estimation prompt past 3h, got %s", text)
+	}
+	track(30)
+	if countPrompts() != 1 {
+		t.Error("Expected only one prompt per estimate")
+	}
+	if notifications, _ := js.loadNotifications(); len(notifications) != 1 || notifications[0].Kind != "reestimate" {
+		t.Errorf("Expected a reestimate notification, got %+v", notifications)
+	}
+
+	tasks, _ := js.loadAllTasks()
+	metrics := js.calculateEstimateMetrics(tasks)
+	if metrics == nil || len(metrics.Overruns) != 1 || metrics.Overruns[0].TrackedMinutes != 220 {
+		t.Fatalf("Expected an open overrun, got %+v", metrics)
+	}
+	if insights := estimateInsights(metrics); len(insights) != 1 || !strings.Contains(insights[0], "EST-1") {
+		t.Errorf("Expected an overrun insight, got %v", insights)
+	}
+
+	// A new estimate re-arms the prompt
+	js.UpdateTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "EST-1", "estimate": "4h"}))
+	track(30)
+	if countPrompts() != 1 {
+		t.Error("Expected no prompt within the new estimate")
+	}
+	track(120)
+	if countPrompts() != 2 {
+		t.Error("Expected a second prompt past the new estimate")
+	}
+}
+
+func TestEstimateAccuracy(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	var tasks []*Task
+	for _, tracked := range []int{120, 90, 150} {
+		tasks = append(tasks, &Task{ID: "T", Status: "completed", EstimateMinutes: 60, Entries: []Entry{{Type: "time", Minutes: tracked}}})
+	}
+
+	metrics := js.calculateEstimateMetrics(tasks)
+	if metrics.CompletedEstimated != 3 || metrics.AccuracyRatio != 2 || len(metrics.Overruns) != 0 {
+		t.Errorf("Unexpected metrics: %+v", metrics)
+	}
+	if insights := estimateInsights(metrics); len(insights) != 1 || !strings.Contains(insights[0], "2.0x their estimates") {
+		t.Errorf("Expected an accuracy insight, got %v", insights)
+	}
+	if js.calculateEstimateMetrics([]*Task{{ID: "T"}}) != nil {
+		t.Error("Expected no metrics without estimates")
+	}
+}
@@ -0,0 +1,736 @@
+package servers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	eventTaskCreated  = "task_created"
+	eventTaskUpdated  = "task_updated"
+	eventTaskDeleted  = "task_deleted"
+	eventEntryAdded   = "entry_added"
+	eventEntryUpdated = "entry_updated"
+	eventEntryRemoved = "entry_removed"
+
+	defaultSnapshotInterval = 100
+)
+
+// eventLogMu serializes appends so sequence numbers stay contiguous
+var eventLogMu sync.Mutex
+
+// TaskEvent is a single mutation recorded in the append-only event log
+type TaskEvent struct {
+	Seq       int64     `json:"seq"`
+	Timestamp time.Time `json:"timestamp"`
+	Type      string    `json:"type"`
+	TaskID    string    `json:"task_id"`
+	Task      *Task     `json:"task,omitempty"`     // task_created (full), task_updated (metadata only)
+	Entry     *Entry    `json:"entry,omitempty"`    // entry_added, entry_updated
+	EntryID   string    `json:"entry_id,omitempty"` // entry_removed
+}
+
+// eventSnapshot is the projected state of every task at a point in the log
+type eventSnapshot struct {
+	Seq       int64            `json:"seq"`
+	Timestamp time.Time        `json:"timestamp"`
+	Tasks     map[string]*Task `json:"tasks"`
+}
+
+// eventStorage records every mutation in events/events.jsonl and keeps the
+// tasks/ directory as a projection of the log, so reads stay as cheap as
+// plain file storage. Snapshots of the projection are written every
+// snapshotInterval events to bound replay time for as-of queries.
+//
+// The first write after enabling event mode records the existing tasks as a
+// genesis snapshot; history from before that point is not available.
+type eventStorage struct {
+	dir              string
+	projection       *fileStorage
+	snapshotInterval int
+	js               *JournalService // for encryption.at_rest
+}
+
+func newEventStorage(dataDir string, projection *fileStorage, snapshotInterval int) *eventStorage {
+	if snapshotInterval <= 0 {
+		snapshotInterval = defaultSnapshotInterval
+	}
+	return &eventStorage{
+		dir:              filepath.Join(dataDir, "events"),
+		projection:       projection,
+		snapshotInterval: snapshotInterval,
+		js:               projection.js,
+	}
+}
+
+func (es *eventStorage) SaveTask(task *Task) error {
+	eventLogMu.Lock()
+	defer eventLogMu.Unlock()
+
+	if err := es.ensureGenesis(); err != nil {
+		return err
+	}
+
+	previous, err := es.projection.LoadTask(task.ID)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := es.append(diffTask(previous, task, time.Now())); err != nil {
+		return err
+	}
+	if err := es.projection.SaveTask(task); err != nil {
+		return err
+	}
+	return es.maybeSnapshot()
+}
+
+func (es *eventStorage) LoadTask(taskID string) (*Task, error) {
+	return es.projection.LoadTask(taskID)
+}
+
+func (es *eventStorage) ListTaskIDs() ([]string, error) {
+	return es.projection.ListTaskIDs()
+}
+
+func (es *eventStorage) DeleteTask(taskID string) error {
+	eventLogMu.Lock()
+	defer eventLogMu.Unlock()
+
+	if err := es.ensureGenesis(); err != nil {
+		return err
+	}
+
+	event := TaskEvent{Timestamp: time.Now(), Type: eventTaskDeleted, TaskID: taskID}
+	if err := es.append([]TaskEvent{event}); err != nil {
+		return err
+	}
+	if err := es.projection.DeleteTask(taskID); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return es.maybeSnapshot()
+}
+
+func (es *eventStorage) logPath() string {
+	return filepath.Join(es.dir, "events.jsonl")
+}
+
+func (es *eventStorage) snapshotDir() string {
+	return filepath.Join(es.dir, "snapshots")
+}
+
+// ensureGenesis starts a new log, capturing the current projection as snapshot 0
+func (es *eventStorage) ensureGenesis() error {
+	if _, err := os.Stat(es.logPath()); err == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(es.snapshotDir(), 0755); err != nil {
+		return err
+	}
+
+	tasks, err := es.projectionState()
+	if err != nil {
+		return err
+	}
+	if err := es.writeSnapshot(eventSnapshot{Seq: 0, Timestamp: time.Now(), Tasks: tasks}); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(es.logPath(), nil, 0644)
+}
+
+func (es *eventStorage) append(events []TaskEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	seq, err := es.lastSeq()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for i := range events {
+		seq++
+		events[i].Seq = seq
+		line, err := json.Marshal(events[i])
+		if err != nil {
+			return err
+		}
+		if line, err = es.js.sealEventLine(line); err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	file, err := os.OpenFile(es.logPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	return es.saveHead(eventLogHead{Seq: seq, Size: info.Size()})
+}
+
+// eventLinePrefix marks an event log line sealed for encryption.at_rest. Lines
+// are sealed one at a time, as base64 after the prefix, so appending an event
+// never rewrites the log.
+const eventLinePrefix = "enc:"
+
+// sealEventLine encrypts one event log line when encryption.at_rest is on
+func (js *JournalService) sealEventLine(line []byte) ([]byte, error) {
+	if !js.atRestEncryption() {
+		return line, nil
+	}
+	sealed, err := js.encryptData(line)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt event: %w", err)
+	}
+	return []byte(eventLinePrefix + base64.StdEncoding.EncodeToString(sealed)), nil
+}
+
+// openEventLine returns an event log line as JSON, decrypting it if it was sealed
+func (js *JournalService) openEventLine(line []byte) ([]byte, error) {
+	encoded, sealed := bytes.CutPrefix(line, []byte(eventLinePrefix))
+	if !sealed {
+		return line, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(string(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("corrupt event log line: %v", err)
+	}
+	return js.decryptData(data)
+}
+
+// reencryptEventLog rewrites the event log so every line matches
+// encryption.at_rest, reporting whether any line had to change
+func (js *JournalService) reencryptEventLog(path string, dryRun bool) (bool, error) {
+	eventLogMu.Lock()
+	defer eventLogMu.Unlock()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	encrypt := js.atRestEncryption()
+	changed := false
+	var buf bytes.Buffer
+	for _, line := range bytes.SplitAfter(data, []byte{'\n'}) {
+		line = bytes.TrimSuffix(line, []byte{'\n'})
+		if len(line) == 0 {
+			continue
+		}
+		if bytes.HasPrefix(line, []byte(eventLinePrefix)) != encrypt {
+			changed = true
+			if line, err = js.openEventLine(line); err == nil {
+				line, err = js.sealEventLine(line)
+			}
+			if err != nil {
+				return false, err
+			}
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if !changed || dryRun {
+		return changed, nil
+	}
+	// The size changes, so lastSeq recounts the log and saves a new head
+	return true, writeFileAtomic(path, buf.Bytes(), 0644)
+}
+
+// eventLogHead records the newest sequence number and the log size it goes
+// with, so an append does not have to read the whole log to number its events
+type eventLogHead struct {
+	Seq  int64 `json:"seq"`
+	Size int64 `json:"size"`
+}
+
+func (es *eventStorage) headPath() string {
+	return filepath.Join(es.dir, "head.json")
+}
+
+func (es *eventStorage) saveHead(head eventLogHead) error {
+	data, err := json.Marshal(head)
+	if err != nil {
+		return err
+	}
+	return es.js.writeDataFile(es.headPath(), data, 0644)
+}
+
+// lastSeq returns the sequence number of the newest event (0 for an empty log).
+// The log is only counted when the head is missing or no longer matches its
+// size, e.g. after a crash between an append and the head update.
+func (es *eventStorage) lastSeq() (int64, error) {
+	info, err := os.Stat(es.logPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	var head eventLogHead
+	if data, err := es.js.readDataFile(es.headPath()); err == nil && json.Unmarshal(data, &head) == nil && head.Size == info.Size() {
+		return head.Seq, nil
+	}
+
+	data, err := os.ReadFile(es.logPath())
+	if err != nil {
+		return 0, err
+	}
+	head = eventLogHead{Seq: int64(bytes.Count(data, []byte{'\n'})), Size: int64(len(data))}
+	return head.Seq, es.saveHead(head)
+}
+
+// readEvents returns the events matching keep, in log order
+func (es *eventStorage) readEvents(keep func(TaskEvent) bool) ([]TaskEvent, error) {
+	file, err := os.Open(es.logPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var events []TaskEvent
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		line, err := es.js.openEventLine(scanner.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		var event TaskEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			return nil, fmt.Errorf("corrupt event log line: %v", err)
+		}
+		if keep(event) {
+			events = append(events, event)
+		}
+	}
+
+	return events, scanner.Err()
+}
+
+func (es *eventStorage) projectionState() (map[string]*Task, error) {
+	tasks := make(map[string]*Task)
+
+	taskIDs, err := es.projection.ListTaskIDs()
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, taskID := range taskIDs {
+		task, err := es.projection.LoadTask(taskID)
+		if err != nil {
+			return nil, err
+		}
+		tasks[taskID] = task
+	}
+
+	return tasks, nil
+}
+
+// snapshotName encodes sequence and time so snapshots can be chosen without reading them
+func snapshotName(seq int64, timestamp time.Time) string {
+	return fmt.Sprintf("%012d-%d.json", seq, timestamp.UnixNano())
+}
+
+func parseSnapshotName(name string) (int64, time.Time, bool) {
+	parts := strings.SplitN(strings.TrimSuffix(name, ".json"), "-", 2)
+	if len(parts) != 2 || !strings.HasSuffix(name, ".json") {
+		return 0, time.Time{}, false
+	}
+	seq, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	nanos, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	return seq, time.Unix(0, nanos), true
+}
+
+func (es *eventStorage) writeSnapshot(snapshot eventSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return es.js.writeDataFile(filepath.Join(es.snapshotDir(), snapshotName(snapshot.Seq, snapshot.Timestamp)), data, 0644)
+}
+
+// maybeSnapshot writes a snapshot once snapshotInterval events have passed since the last one
+func (es *eventStorage) maybeSnapshot() error {
+	seq, err := es.lastSeq()
+	if err != nil {
+		return err
+	}
+
+	latest, _, err := es.latestSnapshotName(time.Now())
+	if err != nil {
+		return err
+	}
+	latestSeq, _, _ := parseSnapshotName(latest)
+	if seq-latestSeq < int64(es.snapshotInterval) {
+		return nil
+	}
+
+	tasks, err := es.projectionState()
+	if err != nil {
+		return err
+	}
+	return es.writeSnapshot(eventSnapshot{Seq: seq, Timestamp: time.Now(), Tasks: tasks})
+}
+
+// compact drops the log's history: the current projection becomes a new
+// genesis snapshot and every earlier event and snapshot is removed. It returns
+// how many events were dropped. purge_person_data uses it to erase content
+// that the append-only log would otherwise keep.
+func (es *eventStorage) compact() (int64, error) {
+	eventLogMu.Lock()
+	defer eventLogMu.Unlock()
+
+	seq, err := es.lastSeq()
+	if err != nil {
+		return 0, err
+	}
+	tasks, err := es.projectionState()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.RemoveAll(es.snapshotDir()); err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(es.snapshotDir(), 0755); err != nil {
+		return 0, err
+	}
+	if err := es.writeSnapshot(eventSnapshot{Seq: 0, Timestamp: time.Now(), Tasks: tasks}); err != nil {
+		return 0, err
+	}
+	if err := writeFileAtomic(es.logPath(), nil, 0644); err != nil {
+		return 0, err
+	}
+	return seq, es.saveHead(eventLogHead{})
+}
+
+// latestSnapshotName finds the newest snapshot taken at or before asOf, falling
+// back to the oldest snapshot when asOf predates the log
+func (es *eventStorage) latestSnapshotName(asOf time.Time) (string, bool, error) {
+	entries, err := os.ReadDir(es.snapshotDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if _, _, ok := parseSnapshotName(entry.Name()); ok {
+			names = append(names, entry.Name())
+		}
+	}
+	if len(names) == 0 {
+		return "", false, nil
+	}
+	sort.Strings(names)
+
+	for i := len(names) - 1; i >= 0; i-- {
+		if _, timestamp, _ := parseSnapshotName(names[i]); !timestamp.After(asOf) {
+			return names[i], true, nil
+		}
+	}
+	return names[0], false, nil
+}
+
+// stateAsOf replays the log from the closest snapshot up to asOf
+func (es *eventStorage) stateAsOf(asOf time.Time) (map[string]*Task, error) {
+	name, _, err := es.latestSnapshotName(asOf)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, fmt.Errorf("event log has not been started yet")
+	}
+
+	data, err := es.js.readDataFile(filepath.Join(es.snapshotDir(), name))
+	if err != nil {
+		return nil, err
+	}
+	var snapshot eventSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("corrupt snapshot %s: %v", name, err)
+	}
+	if snapshot.Tasks == nil {
+		snapshot.Tasks = make(map[string]*Task)
+	}
+
+	events, err := es.readEvents(func(event TaskEvent) bool {
+		return event.Seq > snapshot.Seq && !event.Timestamp.After(asOf)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		applyEvent(snapshot.Tasks, event)
+	}
+	return snapshot.Tasks, nil
+}
+
+// rebuildProjection replays the full log and rewrites tasks/ to match
+func (es *eventStorage) rebuildProjection() (int, error) {
+	eventLogMu.Lock()
+	defer eventLogMu.Unlock()
+
+	state, err := es.stateAsOf(time.Now().Add(time.Hour))
+	if err != nil {
+		return 0, err
+	}
+
+	existing, err := es.projection.ListTaskIDs()
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	for _, taskID := range existing {
+		if _, ok := state[taskID]; !ok {
+			if err := es.projection.DeleteTask(taskID); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	for _, task := range state {
+		if err := es.projection.SaveTask(task); err != nil {
+			return 0, err
+		}
+	}
+	return len(state), nil
+}
+
+// diffTask turns the change from previous to next into events
+func diffTask(previous, next *Task, now time.Time) []TaskEvent {
+	if previous == nil {
+		return []TaskEvent{{Timestamp: now, Type: eventTaskCreated, TaskID: next.ID, Task: copyTask(next)}}
+	}
+
+	var events []TaskEvent
+
+	if !sameJSON(taskMetadata(previous), taskMetadata(next)) {
+		events = append(events, TaskEvent{Timestamp: now, Type: eventTaskUpdated, TaskID: next.ID, Task: taskMetadata(next)})
+	}
+
+	previousEntries := make(map[string]Entry)
+	for _, entry := range previous.Entries {
+		previousEntries[entry.ID] = entry
+	}
+
+	nextIDs := make(map[string]bool)
+	for _, entry := range next.Entries {
+		entry := entry
+		nextIDs[entry.ID] = true
+
+		old, existed := previousEntries[entry.ID]
+		switch {
+		case !existed:
+			events = append(events, TaskEvent{Timestamp: now, Type: eventEntryAdded, TaskID: next.ID, Entry: &entry})
+		case !sameJSON(old, entry):
+			events = append(events, TaskEvent{Timestamp: now, Type: eventEntryUpdated, TaskID: next.ID, Entry: &entry})
+		}
+	}
+
+	for _, entry := range previous.Entries {
+		if !nextIDs[entry.ID] {
+			events = append(events, TaskEvent{Timestamp: now, Type: eventEntryRemoved, TaskID: next.ID, EntryID: entry.ID})
+		}
+	}
+
+	return events
+}
+
+// applyEvent projects a single event onto state
+func applyEvent(state map[string]*Task, event TaskEvent) {
+	switch event.Type {
+	case eventTaskCreated:
+		state[event.TaskID] = copyTask(event.Task)
+	case eventTaskDeleted:
+		delete(state, event.TaskID)
+	case eventTaskUpdated:
+		task, ok := state[event.TaskID]
+		if !ok {
+			return
+		}
+		updated := copyTask(event.Task)
+		updated.Entries = task.Entries
+		state[event.TaskID] = updated
+	case eventEntryAdded:
+		if task, ok := state[event.TaskID]; ok && event.Entry != nil {
+			task.Entries = append(task.Entries, *event.Entry)
+		}
+	case eventEntryUpdated:
+		if task, ok := state[event.TaskID]; ok && event.Entry != nil {
+			for i := range task.Entries {
+				if task.Entries[i].ID == event.Entry.ID {
+					task.Entries[i] = *event.Entry
+				}
+			}
+		}
+	case eventEntryRemoved:
+		if task, ok := state[event.TaskID]; ok {
+			var kept []Entry
+			for _, entry := range task.Entries {
+				if entry.ID != event.EntryID {
+					kept = append(kept, entry)
+				}
+			}
+			task.Entries = kept
+		}
+	}
+}
+
+// copyTask deep-copies a task through JSON so new fields are always carried along
+func copyTask(task *Task) *Task {
+	data, _ := json.Marshal(task)
+	var copied Task
+	json.Unmarshal(data, &copied)
+	return &copied
+}
+
+func taskMetadata(task *Task) *Task {
+	metadata := copyTask(task)
+	metadata.Entries = nil
+	return metadata
+}
+
+func sameJSON(a, b interface{}) bool {
+	dataA, _ := json.Marshal(a)
+	dataB, _ := json.Marshal(b)
+	return bytes.Equal(dataA, dataB)
+}
+
+// eventStore returns the event storage, or an error when event mode is not enabled
+func (js *JournalService) eventStore() (*eventStorage, error) {
+	store := js.storage()
+	if mirrored, ok := store.(*mirroredStorage); ok {
+		store = mirrored.Storage
+	}
+	if es, ok := store.(*eventStorage); ok {
+		return es, nil
+	}
+	return nil, fmt.Errorf("Event history requires storage mode \"events\" (set storage.mode in config.yaml)")
+}
+
+// GetTaskAsOf returns a task as it was at a point in time
+func (js *JournalService) GetTaskAsOf(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError("task_id is required"), nil
+	}
+
+	asOfStr, err := request.RequireString("as_of")
+	if err != nil {
+		return mcp.NewToolResultError("as_of is required (RFC3339 timestamp or YYYY-MM-DD)"), nil
+	}
+
+	asOf, err := time.Parse(time.RFC3339, asOfStr)
+	if err != nil {
+		day, dayErr := time.Parse("2006-01-02", asOfStr)
+		if dayErr != nil {
+			return mcp.NewToolResultError("as_of must be an RFC3339 timestamp or YYYY-MM-DD"), nil
+		}
+		// A bare date means the end of that day
+		asOf = day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	es, err := js.eventStore()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	state, err := es.stateAsOf(asOf)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to replay event log: %v", err)), nil
+	}
+
+	task, ok := state[taskID]
+	if !ok {
+		return mcp.NewToolResultError(fmt.Sprintf("Task %s did not exist as of %s", taskID, asOf.Format(time.RFC3339))), nil
+	}
+
+	markdown, err := js.styleMarkdown(request.GetString("style", ""), fmt.Sprintf("_As of %s_\n\n%s", asOf.Format(time.RFC3339), js.formatTaskAsMarkdown(task)))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(markdown), nil
+}
+
+// GetTaskHistory lists the recorded events for a task
+func (js *JournalService) GetTaskHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError("task_id is required"), nil
+	}
+
+	es, err := js.eventStore()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	events, err := es.readEvents(func(event TaskEvent) bool {
+		return event.TaskID == taskID
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read event log: %v", err)), nil
+	}
+
+	result := map[string]interface{}{
+		"task_id": taskID,
+		"count":   len(events),
+		"events":  events,
+	}
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// RebuildProjection regenerates the task files from the event log
+func (js *JournalService) RebuildProjection(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	es, err := js.eventStore()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	count, err := es.rebuildProjection()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to rebuild projection: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Rebuilt %d tasks from the event log", count)), nil
+}
@@ -0,0 +1,198 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func enableEventStorage(t *testing.T, js *JournalService, snapshotInterval int) {
+	t.Helper()
+
+	config := defaultConfiguration()
+	config.Storage.Mode = "events"
+	config.Storage.SnapshotInterval = snapshotInterval
+	if err := js.saveConfiguration(config); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+}
+
+func TestEventStorage(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	// Created before event mode, so it should land in the genesis snapshot
+	createTestTask(t, js, "PRE-1", "Existing task", "work")
+	enableEventStorage(t, js, 3)
+
+	createTestTask(t, js, "EVT-1", "Event sourced task", "work")
+	time.Sleep(5 * time.Millisecond)
+	beforeEntry := time.Now()
+	time.Sleep(5 * time.Millisecond)
+
+	_, err := js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{
+		"task_id": "EVT-1",
+		"content": "First progress note",
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_, err = js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{
+		"task_id": "EVT-1",
+		"status":  "completed",
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The projection stays readable through the normal tools
+	task, err := js.loadTask("EVT-1")
+	if err != nil {
+		t.Fatalf("Failed to load task: %v", err)
+	}
+	if task.Status != "completed" {
+		t.Errorf("Expected status completed, got %s", task.Status)
+	}
+
+	t.Run("history", func(t *testing.T) {
+		result, err := js.GetTaskHistory(ctx, CreateMockRequest(map[string]interface{}{"task_id": "EVT-1"}))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var history struct {
+			Events []TaskEvent `json:"events"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &history); err != nil {
+			t.Fatalf("Failed to parse history: %v", err)
+		}
+		if len(history.Events) == 0 || history.Events[0].Type != eventTaskCreated {
+			t.Fatalf("Expected history to start with %s, got %+v", eventTaskCreated, history.Events)
+		}
+		for i := 1; i < len(history.Events); i++ {
+			if history.Events[i].Seq <= history.Events[i-1].Seq {
+				t.Errorf("Expected increasing sequence numbers, got %d after %d", history.Events[i].Seq, history.Events[i-1].Seq)
+			}
+		}
+	})
+
+	t.Run("as of", func(t *testing.T) {
+		result, err := js.GetTaskAsOf(ctx, CreateMockRequest(map[string]interface{}{
+			"task_id": "EVT-1",
+			"as_of":   beforeEntry.Format(time.RFC3339Nano),
+		}))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		if result.IsError {
+			t.Fatalf("Unexpected tool error: %s", text)
+		}
+		if strings.Contains(text, "First progress note") {
+			t.Error("Expected entry added after as_of to be excluded")
+		}
+		if !strings.Contains(text, "active") {
+			t.Error("Expected task to still be active as of the earlier time")
+		}
+
+		result, _ = js.GetTaskAsOf(ctx, CreateMockRequest(map[string]interface{}{
+			"task_id": "PRE-1",
+			"as_of":   time.Now().Format(time.RFC3339Nano),
+		}))
+		if result.IsError {
+			t.Error("Expected task from genesis snapshot to be available")
+		}
+	})
+
+	t.Run("snapshots", func(t *testing.T) {
+		entries, err := os.ReadDir(filepath.Join(js.DataDir, "events", "snapshots"))
+		if err != nil {
+			t.Fatalf("Failed to read snapshots: %v", err)
+		}
+		if len(entries) < 2 {
+			t.Errorf("Expected genesis plus at least one interval snapshot, got %d", len(entries))
+		}
+	})
+
+	t.Run("sequence head", func(t *testing.T) {
+		es, err := js.eventStore()
+		if err != nil {
+			t.Fatalf("Failed to open event store: %v", err)
+		}
+		data, _ := os.ReadFile(es.logPath())
+		lines := int64(strings.Count(string(data), "\n"))
+		if seq, _ := es.lastSeq(); seq != lines {
+			t.Errorf("Expected the head at %d, got %d", lines, seq)
+		}
+
+		// A head left behind by an interrupted append is recounted
+		os.WriteFile(es.logPath(), append(data, []byte(`{"seq":0,"type":"task_deleted","task_id":"GONE"}`+"\n")...), 0644)
+		if seq, _ := es.lastSeq(); seq != lines+1 {
+			t.Errorf("Expected a stale head recounted to %d, got %d", lines+1, seq)
+		}
+		os.WriteFile(es.logPath(), data, 0644)
+		os.Remove(es.headPath())
+		if seq, _ := es.lastSeq(); seq != lines {
+			t.Errorf("Expected a missing head recounted to %d, got %d", lines, seq)
+		}
+	})
+
+	t.Run("rebuild projection", func(t *testing.T) {
+		os.Remove(filepath.Join(js.DataDir, "tasks", "EVT-1.json"))
+
+		result, err := js.RebuildProjection(ctx, CreateMockRequest(map[string]interface{}{}))
+		if err != nil || result.IsError {
+			t.Fatalf("Unexpected error rebuilding projection: %v", err)
+		}
+
+		rebuilt, err := js.loadTask("EVT-1")
+		if err != nil {
+			t.Fatalf("Expected task to be rebuilt: %v", err)
+		}
+		if rebuilt.Status != "completed" || len(rebuilt.Entries) != len(task.Entries) {
+			t.Errorf("Rebuilt task does not match projection: %+v", rebuilt)
+		}
+	})
+}
+
+func TestEventHistoryRequiresEventMode(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	createTestTask(t, js, "FILE-1", "Plain file task", "work")
+
+	result, err := js.GetTaskHistory(context.Background(), CreateMockRequest(map[string]interface{}{"task_id": "FILE-1"}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("Expected an error when event storage is not enabled")
+	}
+}
+
+func TestDiffAndApplyEvents(t *testing.T) {
+	now := time.Now()
+	previous := &Task{ID: "T-1", Title: "Old", Status: "active", Entries: []Entry{
+		{ID: "e1", Content: "keep"},
+		{ID: "e2", Content: "edit me"},
+		{ID: "e3", Content: "remove me"},
+	}}
+	next := &Task{ID: "T-1", Title: "New", Status: "active", Entries: []Entry{
+		{ID: "e1", Content: "keep"},
+		{ID: "e2", Content: "edited"},
+		{ID: "e4", Content: "added"},
+	}}
+
+	state := map[string]*Task{"T-1": copyTask(previous)}
+	for _, event := range diffTask(previous, next, now) {
+		applyEvent(state, event)
+	}
+
+	if !sameJSON(state["T-1"], next) {
+		t.Errorf("Replayed task does not match: got %+v, want %+v", state["T-1"], next)
+	}
+}
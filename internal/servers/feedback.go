@@ -0,0 +1,308 @@
+package servers
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// FeedbackItem is a piece of feedback received or given
+type FeedbackItem struct {
+	ID        string    `json:"id"`
+	Date      string    `json:"date"`      // YYYY-MM-DD
+	Direction string    `json:"direction"` // received or given
+	Person    string    `json:"person,omitempty"`
+	Content   string    `json:"content"`
+	Themes    []string  `json:"themes,omitempty"`
+	Source    string    `json:"source"` // manual, one_on_one:<date>, one_on_one:<person>-<date> or meeting:<name>
+	Created   time.Time `json:"created"`
+}
+
+// FeedbackTheme is one recurring theme in a feedback report
+type FeedbackTheme struct {
+	Theme     string         `json:"theme"`
+	Count     int            `json:"count"`
+	Received  int            `json:"received"`
+	Given     int            `json:"given"`
+	ByQuarter map[string]int `json:"by_quarter"`
+	Recurring bool           `json:"recurring"` // seen in two or more quarters
+	Examples  []string       `json:"examples"`
+}
+
+// FeedbackThemesReport clusters feedback by theme over time
+type FeedbackThemesReport struct {
+	TotalItems int             `json:"total_items"`
+	Themes     []FeedbackTheme `json:"themes"`
+	Summary    string          `json:"summary"`
+}
+
+// feedbackThemeKeywords assigns a theme when feedback mentions one of its words
+var feedbackThemeKeywords = map[string][]string{
+	"communication": {"communicat", "clear", "explain", "present", "writing", "document", "update", "listen"},
+	"collaboration": {"collaborat", "team", "help", "pair", "support", "partner", "cross-team"},
+	"ownership":     {"ownership", "owned", "owning", "initiative", "proactive", "accountab", "drive", "follow through", "follow-through"},
+	"technical":     {"technical", "code", "design", "architecture", "debug", "performance", "review", "test"},
+	"delivery":      {"deliver", "deadline", "ship", "scope", "estimate", "on time", "priorit"},
+	"leadership":    {"lead", "mentor", "coach", "influence", "decision", "vision", "delegat"},
+	"growth":        {"learn", "grow", "improve", "stretch", "career", "skill"},
+}
+
+// inferFeedbackThemes returns the themes whose keywords appear in content
+func inferFeedbackThemes(content string) []string {
+	lower := strings.ToLower(content)
+	var themes []string
+	for _, theme := range sortedKeys(feedbackThemeKeywords) {
+		for _, keyword := range feedbackThemeKeywords[theme] {
+			if strings.Contains(lower, keyword) {
+				themes = append(themes, theme)
+				break
+			}
+		}
+	}
+	if len(themes) == 0 {
+		themes = []string{"general"}
+	}
+	return themes
+}
+
+// feedbackID derives a stable ID so re-importing the same item is a no-op
+func feedbackID(source, content string) string {
+	sum := sha1.Sum([]byte(source + "\n" + content))
+	return "fb_" + hex.EncodeToString(sum[:])[:10]
+}
+
+// quarterOf formats the quarter of a YYYY-MM-DD date, e.g. 2026-Q1
+func quarterOf(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return "unknown"
+	}
+	return fmt.Sprintf("%d-Q%d", t.Year(), (int(t.Month())-1)/3+1)
+}
+
+func (js *JournalService) feedbackPath() string {
+	return filepath.Join(js.dataDir(), "feedback.json")
+}
+
+func (js *JournalService) loadFeedback() ([]FeedbackItem, error) {
+	data, err := js.readDataFile(js.feedbackPath())
+	if os.IsNotExist(err) {
+		return []FeedbackItem{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var items []FeedbackItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (js *JournalService) saveFeedback(items []FeedbackItem) error {
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return err
+	}
+	return js.writeDataFile(js.feedbackPath(), data, 0644)
+}
+
+// addFeedbackItems stores items not already in the bank and returns how many were added
+func (js *JournalService) addFeedbackItems(newItems []FeedbackItem) (int, error) {
+	defer lockFile(js.feedbackPath())()
+
+	items, err := js.loadFeedback()
+	if err != nil {
+		return 0, err
+	}
+	existing := make(map[string]bool, len(items))
+	for _, item := range items {
+		existing[item.ID] = true
+	}
+
+	var added []FeedbackItem
+	for _, item := range newItems {
+		if existing[item.ID] {
+			continue
+		}
+		existing[item.ID] = true
+		items = append(items, item)
+		added = append(added, item)
+	}
+	if len(added) == 0 {
+		return 0, nil
+	}
+	if err := js.saveFeedback(items); err != nil {
+		return 0, err
+	}
+
+	// Praise goes straight into the brag document
+	for _, item := range added {
+		js.bragPraise(item)
+	}
+	return len(added), nil
+}
+
+// meetingFeedbackItems turns a meeting's feedback list into received feedback
+func meetingFeedbackItems(meeting *Meeting) []FeedbackItem {
+	source := meetingFeedbackSource(meeting)
+	var items []FeedbackItem
+	for _, content := range meeting.Feedback {
+		items = append(items, FeedbackItem{
+			ID:        feedbackID(source, content),
+			Date:      meeting.Date,
+			Direction: "received",
+			Person:    meeting.Person,
+			Content:   content,
+			Themes:    inferFeedbackThemes(content),
+			Source:    source,
+			Created:   time.Now(),
+		})
+	}
+	return items
+}
+
+// meetingFeedbackSource is the feedback bank source of a meeting's feedback
+func meetingFeedbackSource(meeting *Meeting) string {
+	if meetingType(meeting) == "one_on_one" {
+		return "one_on_one:" + oneOnOneName(meeting)
+	}
+	return "meeting:" + meetingName(meeting)
+}
+
+// syncMeetingFeedback imports feedback recorded in meetings into the bank
+func (js *JournalService) syncMeetingFeedback() error {
+	meetings, err := js.loadMeetings()
+	if err != nil {
+		return err
+	}
+	var items []FeedbackItem
+	for _, meeting := range meetings {
+		items = append(items, meetingFeedbackItems(meeting)...)
+	}
+	_, err = js.addFeedbackItems(items)
+	return err
+}
+
+// AddFeedback records feedback received or given in the feedback bank
+func (js *JournalService) AddFeedback(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	content, err := request.RequireString("content")
+	if err != nil {
+		return mcp.NewToolResultError("content is required"), nil
+	}
+
+	direction := request.GetString("direction", "received")
+	if direction != "received" && direction != "given" {
+		return mcp.NewToolResultError("direction must be received or given"), nil
+	}
+
+	date := request.GetString("date", time.Now().Format("2006-01-02"))
+	if validationErr := js.validateDateFormat(date, "date"); validationErr != nil {
+		return mcp.NewToolResultError(validationErr.Error()), nil
+	}
+
+	themes := request.GetStringSlice("themes", nil)
+	if len(themes) == 0 {
+		themes = inferFeedbackThemes(content)
+	}
+	for i := range themes {
+		themes[i] = strings.ToLower(strings.TrimSpace(themes[i]))
+	}
+
+	person := request.GetString("person", "")
+	item := FeedbackItem{
+		ID:        feedbackID(fmt.Sprintf("manual:%s:%s:%s", date, direction, person), content),
+		Date:      date,
+		Direction: direction,
+		Person:    person,
+		Content:   content,
+		Themes:    themes,
+		Source:    "manual",
+		Created:   time.Now(),
+	}
+
+	added, err := js.addFeedbackItems([]FeedbackItem{item})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save feedback: %v", err)), nil
+	}
+	if added == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("Feedback already recorded (%s)", item.ID)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Recorded %s feedback %s (themes: %s)", direction, item.ID, strings.Join(themes, ", "))), nil
+}
+
+// GetFeedbackThemes reports recurring feedback themes over time, for performance reviews
+func (js *JournalService) GetFeedbackThemes(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if err := js.syncMeetingFeedback(); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to import one-on-one feedback: %v", err)), nil
+	}
+	items, err := js.loadFeedback()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load feedback: %v", err)), nil
+	}
+
+	dateFrom := request.GetString("date_from", "")
+	dateTo := request.GetString("date_to", "")
+	direction := request.GetString("direction", "")
+	person := request.GetString("person", "")
+
+	themes := make(map[string]*FeedbackTheme)
+	report := FeedbackThemesReport{Themes: []FeedbackTheme{}}
+	for _, item := range items {
+		if dateFrom != "" && item.Date < dateFrom || dateTo != "" && item.Date > dateTo ||
+			direction != "" && item.Direction != direction || person != "" && !strings.EqualFold(item.Person, person) {
+			continue
+		}
+		report.TotalItems++
+		for _, name := range item.Themes {
+			theme, ok := themes[name]
+			if !ok {
+				theme = &FeedbackTheme{Theme: name, ByQuarter: make(map[string]int)}
+				themes[name] = theme
+			}
+			theme.Count++
+			if item.Direction == "given" {
+				theme.Given++
+			} else {
+				theme.Received++
+			}
+			theme.ByQuarter[quarterOf(item.Date)]++
+			if len(theme.Examples) < 3 {
+				theme.Examples = append(theme.Examples, fmt.Sprintf("%s: %s", item.Date, item.Content))
+			}
+		}
+	}
+
+	var recurring []string
+	for _, theme := range themes {
+		theme.Recurring = len(theme.ByQuarter) >= 2
+		if theme.Recurring {
+			recurring = append(recurring, theme.Theme)
+		}
+		report.Themes = append(report.Themes, *theme)
+	}
+	sort.Slice(report.Themes, func(i, j int) bool {
+		if report.Themes[i].Count != report.Themes[j].Count {
+			return report.Themes[i].Count > report.Themes[j].Count
+		}
+		return report.Themes[i].Theme < report.Themes[j].Theme
+	})
+	sort.Strings(recurring)
+
+	report.Summary = fmt.Sprintf("%d feedback items across %d themes", report.TotalItems, len(report.Themes))
+	if len(recurring) > 0 {
+		report.Summary += fmt.Sprintf("; recurring: %s", strings.Join(recurring, ", "))
+	}
+
+	resultJSON, _ := json.MarshalIndent(report, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
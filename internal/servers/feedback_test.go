@@ -0,0 +1,78 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestInferFeedbackThemes(t *testing.T) {
+	themes := inferFeedbackThemes("Great job explaining the design to the team")
+	if !equalStringSlices(themes, []string{"collaboration", "communication", "technical"}) {
+		t.Errorf("Unexpected themes: %v", themes)
+	}
+	if themes := inferFeedbackThemes("Thanks!"); !equalStringSlices(themes, []string{"general"}) {
+		t.Errorf("Expected general theme, got %v", themes)
+	}
+}
+
+func TestFeedbackBank(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	result, _ := js.AddFeedback(ctx, CreateMockRequest(map[string]interface{}{
+		"content":   "Took ownership of the migration",
+		"person":    "Dana",
+		"date":      "2026-02-10",
+		"themes":    []interface{}{"Ownership"},
+		"direction": "received",
+	}))
+	if result.IsError {
+		t.Fatalf("add_feedback failed: %v", result.Content)
+	}
+	result, _ = js.AddFeedback(ctx, CreateMockRequest(map[string]interface{}{"content": "Be clearer", "direction": "sideways"}))
+	if !result.IsError {
+		t.Error("Expected an error for an invalid direction")
+	}
+
+	js.CreateOneOnOne(ctx, CreateMockRequest(map[string]interface{}{
+		"date":     "2026-05-04",
+		"feedback": []interface{}{"Showed real ownership on the release"},
+	}))
+	// A 1:1 recorded before the bank existed is picked up by the report
+	js.saveOneOnOne(&OneOnOne{Date: "2026-05-18", Feedback: []string{"Status updates could be clearer"}})
+
+	result, _ = js.GetFeedbackThemes(ctx, CreateMockRequest(map[string]interface{}{}))
+	var report FeedbackThemesReport
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report); err != nil {
+		t.Fatalf("Failed to parse report: %v", err)
+	}
+	if report.TotalItems != 3 {
+		t.Fatalf("Expected 3 feedback items, got %d", report.TotalItems)
+	}
+	if report.Themes[0].Theme != "ownership" || report.Themes[0].Count != 2 || !report.Themes[0].Recurring {
+		t.Errorf("Expected recurring ownership theme first, got %+v", report.Themes[0])
+	}
+	if report.Themes[0].ByQuarter["2026-Q1"] != 1 || report.Themes[0].ByQuarter["2026-Q2"] != 1 {
+		t.Errorf("Unexpected quarters: %v", report.Themes[0].ByQuarter)
+	}
+	if !strings.Contains(report.Summary, "recurring: ownership") {
+		t.Errorf("Summary should name recurring themes: %s", report.Summary)
+	}
+
+	// Re-running the import does not duplicate one-on-one feedback
+	js.GetFeedbackThemes(ctx, CreateMockRequest(map[string]interface{}{}))
+	items, _ := js.loadFeedback()
+	if len(items) != 3 {
+		t.Errorf("Expected 3 stored items after re-import, got %d", len(items))
+	}
+
+	result, _ = js.GetFeedbackThemes(ctx, CreateMockRequest(map[string]interface{}{"person": "dana"}))
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report)
+	if report.TotalItems != 1 {
+		t.Errorf("Expected person filter to keep 1 item, got %d", report.TotalItems)
+	}
+}
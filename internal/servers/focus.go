@@ -0,0 +1,64 @@
+package servers
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+const defaultFocusLimit = 5
+
+// triageScore ranks open tasks for daily planning: priority first, then due
+// dates, with a nudge for recent momentum and a penalty for being blocked
+func (js *JournalService) triageScore(task *Task, now time.Time) int {
+	score := priorityRank(task.Priority) * 10
+
+	today := now.Format("2006-01-02")
+	switch {
+	case isOverdue(task, today):
+		score += 25
+	case task.DueDate == today:
+		score += 15
+	case task.DueDate != "" && task.DueDate <= endOfWeek(now).Format("2006-01-02"):
+		score += 8
+	}
+
+	if now.Sub(lastActivity(task)) < 48*time.Hour {
+		score += 5
+	}
+	if task.Status == "blocked" || len(js.openDependencies(task)) > 0 {
+		score -= 10
+	}
+	return score
+}
+
+// focusTasks drops snoozed, paused, completed, low-priority and someday tasks
+// and returns the rest ordered by triage score, highest first
+func (js *JournalService) focusTasks(tasks []*Task, now time.Time) []*Task {
+	var focus []*Task
+	for _, task := range tasks {
+		if task.Snoozed || task.Status == "paused" || task.Status == "completed" || task.Status == "someday" ||
+			task.Priority == "low" || slices.Contains(task.Tags, "someday") {
+			continue
+		}
+		focus = append(focus, task)
+	}
+
+	scores := make(map[string]int, len(focus))
+	for _, task := range focus {
+		scores[task.ID] = js.triageScore(task, now)
+	}
+	sort.SliceStable(focus, func(i, j int) bool {
+		return scores[focus[i].ID] > scores[focus[j].ID]
+	})
+	return focus
+}
+
+// focusLimit returns how many tasks focus mode shows (general.focus_limit)
+func (js *JournalService) focusLimit() int {
+	config, err := js.loadConfiguration()
+	if err != nil || config.General.FocusLimit <= 0 {
+		return defaultFocusLimit
+	}
+	return config.General.FocusLimit
+}
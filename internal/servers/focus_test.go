@@ -0,0 +1,51 @@
+package servers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestListTasksFocus(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+
+	setup := map[string]func(*Task){
+		"URGENT-1":  func(task *Task) { task.Priority = "urgent" },
+		"LATE-1":    func(task *Task) { task.Priority = "medium"; task.DueDate = yesterday },
+		"PLAIN-1":   func(task *Task) {},
+		"LOW-1":     func(task *Task) { task.Priority = "low" },
+		"PAUSED-1":  func(task *Task) { task.Status = "paused" },
+		"SNOOZED-1": func(task *Task) { task.Snoozed = true },
+		"SOMEDAY-1": func(task *Task) { task.Tags = []string{"someday"} },
+	}
+	for id, apply := range setup {
+		createTestTask(t, js, id, "Task "+id, "work")
+		task, _ := js.loadTask(id)
+		apply(task)
+		js.saveTask(task)
+	}
+
+	config := defaultConfiguration()
+	config.General.FocusLimit = 2
+	js.saveConfiguration(config)
+
+	result, _ := js.ListTasks(context.Background(), CreateMockRequest(map[string]interface{}{"focus": "true"}))
+	text := result.Content[0].(mcp.TextContent).Text
+
+	for _, hidden := range []string{"LOW-1", "PAUSED-1", "SNOOZED-1", "SOMEDAY-1", "PLAIN-1"} {
+		if strings.Contains(text, hidden) {
+			t.Errorf("Expected %s to be hidden in focus mode, got:\n%s", hidden, text)
+		}
+	}
+	late, urgent := strings.Index(text, "LATE-1"), strings.Index(text, "URGENT-1")
+	if late < 0 || urgent < 0 || late > urgent {
+		t.Errorf("Expected overdue LATE-1 ranked above URGENT-1, got:\n%s", text)
+	}
+	if !strings.Contains(text, "of 3 total") {
+		t.Errorf("Expected three focus candidates capped at two, got:\n%s", text)
+	}
+}
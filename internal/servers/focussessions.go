@@ -0,0 +1,349 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	defaultFocusMinutes = 25
+	maxFocusMinutes     = 180
+)
+
+// FocusSession is the focus session in progress. One runs at a time, kept in
+// .journal-mcp/focus-session.json so the scheduler can end it without loading
+// every task.
+type FocusSession struct {
+	TaskID  string    `json:"task_id"`
+	Started time.Time `json:"started"`
+	Minutes int       `json:"minutes"` // planned length
+	Goal    string    `json:"goal,omitempty"`
+}
+
+// Ends returns when the session reaches its planned length
+func (s *FocusSession) Ends() time.Time {
+	return s.Started.Add(time.Duration(s.Minutes) * time.Minute)
+}
+
+func (js *JournalService) focusSessionPath() string {
+	return filepath.Join(js.dataDir(), ".journal-mcp", "focus-session.json")
+}
+
+// loadFocusSession returns the running session, or nil
+func (js *JournalService) loadFocusSession() (*FocusSession, error) {
+	data, err := js.readDataFile(js.focusSessionPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var session FocusSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// saveFocusSession records the running session; nil clears it
+func (js *JournalService) saveFocusSession(session *FocusSession) error {
+	if session == nil {
+		if err := os.Remove(js.focusSessionPath()); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(js.focusSessionPath()), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return err
+	}
+	return js.writeDataFile(js.focusSessionPath(), data, 0644)
+}
+
+// finishFocusSession records a session as a "focus" entry on its task and
+// clears it. Time counts up to the planned length; a session ended early
+// records the time focused so far. The caller holds the session lock.
+func (js *JournalService) finishFocusSession(session *FocusSession, now time.Time, note string) (Entry, error) {
+	end := session.Ends()
+	if now.Before(end) {
+		end = now
+	}
+	minutes := int(math.Round(end.Sub(session.Started).Minutes()))
+	if minutes < 1 {
+		minutes = 1
+	}
+
+	content := fmt.Sprintf("Focused %s (%s-%s)", formatMinutes(minutes), session.Started.Format("15:04"), end.Format("15:04"))
+	if minutes < session.Minutes {
+		content += fmt.Sprintf(" of a planned %s", formatMinutes(session.Minutes))
+	}
+	if details := nonEmpty([]string{session.Goal, note}); len(details) > 0 {
+		content += ": " + strings.Join(details, "; ")
+	}
+	entry := Entry{
+		ID:             generateEntryID(),
+		Timestamp:      end,
+		Content:        content,
+		Type:           "focus",
+		Minutes:        minutes,
+		PlannedMinutes: session.Minutes,
+	}
+
+	unlock := js.lockTask(session.TaskID)
+	defer unlock()
+	task, err := js.loadTask(session.TaskID)
+	if err != nil {
+		// The task is gone; the session cannot be recorded anywhere
+		js.saveFocusSession(nil)
+		return entry, fmt.Errorf("failed to load task %s: %w", session.TaskID, err)
+	}
+	task.Entries = append(task.Entries, entry)
+	task.Updated = now
+	js.checkEstimate(task, now)
+	if err := js.saveTask(task); err != nil {
+		return entry, err
+	}
+	js.updateDailyLog(task.ID, entry)
+	return entry, js.saveFocusSession(nil)
+}
+
+// focusSessionJob ends a session once it reaches its planned length, leaving
+// a notification as the cue for a break
+func (js *JournalService) focusSessionJob() ScheduledJob {
+	return ScheduledJob{
+		Name: "focus_session_end",
+		Due: func(now, lastRun time.Time) bool {
+			session, err := js.loadFocusSession()
+			return err == nil && session != nil && !now.Before(session.Ends())
+		},
+		Run: func(ctx context.Context) error {
+			defer lockFile(js.focusSessionPath())()
+			session, err := js.loadFocusSession()
+			if err != nil || session == nil {
+				return err
+			}
+			now := time.Now()
+			if now.Before(session.Ends()) {
+				return nil
+			}
+			entry, err := js.finishFocusSession(session, now, "")
+			if err != nil {
+				return err
+			}
+			return js.notify("focus_session", session.TaskID, fmt.Sprintf("Focus session on %s finished after %s; time for a break", session.TaskID, formatMinutes(entry.Minutes)))
+		},
+	}
+}
+
+// StartFocusSession starts a timed focus session on a task, stopping any running timer
+func (js *JournalService) StartFocusSession(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError("task_id is required"), nil
+	}
+	minutes := defaultFocusMinutes
+	if value := request.GetString("minutes", ""); value != "" {
+		minutes, err = strconv.Atoi(value)
+		if err != nil || minutes < 1 || minutes > maxFocusMinutes {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid minutes: %s (expected 1 to %d)", value, maxFocusMinutes)), nil
+		}
+	}
+	goal := strings.TrimSpace(request.GetString("goal", ""))
+
+	defer lockFile(js.focusSessionPath())()
+
+	now := time.Now()
+	var messages []string
+	running, err := js.loadFocusSession()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load focus session: %v", err)), nil
+	}
+	if running != nil {
+		if now.Before(running.Ends()) {
+			return mcp.NewToolResultError(fmt.Sprintf("A focus session is running on %s until %s; end it with end_focus_session first", running.TaskID, running.Ends().Format("15:04"))), nil
+		}
+		// Finished while the scheduler was not running
+		if _, err := js.finishFocusSession(running, now, ""); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to record the last focus session: %v", err)), nil
+		}
+		messages = append(messages, fmt.Sprintf("Recorded the finished session on %s", running.TaskID))
+	}
+
+	if _, err := js.loadTask(taskID); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load task: %v", err)), nil
+	}
+
+	// A running timer would count the same time twice
+	timers, err := js.runningTimers()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+	for _, task := range timers {
+		entry, _, err := js.stopRunningTimer(task.ID, now, "")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to stop timer on %s: %v", task.ID, err)), nil
+		}
+		if entry != nil {
+			messages = append(messages, fmt.Sprintf("Stopped timer on %s (%s)", task.ID, formatMinutes(entry.Minutes)))
+		}
+	}
+
+	session := &FocusSession{TaskID: taskID, Started: now, Minutes: minutes, Goal: goal}
+	if err := js.saveFocusSession(session); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save focus session: %v", err)), nil
+	}
+
+	message := fmt.Sprintf("Started a %s focus session on %s, until %s", formatMinutes(minutes), taskID, session.Ends().Format("15:04"))
+	if len(messages) > 0 {
+		message += ". " + strings.Join(messages, ". ")
+	}
+	return mcp.NewToolResultText(message), nil
+}
+
+// EndFocusSession ends the running focus session and records it on its task
+func (js *JournalService) EndFocusSession(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	note := strings.TrimSpace(request.GetString("note", ""))
+
+	defer lockFile(js.focusSessionPath())()
+
+	session, err := js.loadFocusSession()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load focus session: %v", err)), nil
+	}
+	if session == nil {
+		return mcp.NewToolResultError("No focus session is running"), nil
+	}
+
+	entry, err := js.finishFocusSession(session, time.Now(), note)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to record focus session: %v", err)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Ended focus session on %s: %s", session.TaskID, entry.Content)), nil
+}
+
+// FocusPeriod is focus time over one day or week
+type FocusPeriod struct {
+	Start          string  `json:"start"` // the day, or the first day of the week
+	Sessions       int     `json:"sessions"`
+	Completed      int     `json:"completed"` // sessions that ran their planned length
+	FocusMinutes   int     `json:"focus_minutes"`
+	TrackedMinutes int     `json:"tracked_minutes"` // all tracked time, focus sessions included
+	FocusShare     float64 `json:"focus_share"`     // focus minutes over tracked minutes
+}
+
+// FocusReport summarizes focus sessions over a date range
+type FocusReport struct {
+	DateFrom   string             `json:"date_from"`
+	DateTo     string             `json:"date_to"`
+	GroupBy    string             `json:"group_by"`
+	Sessions   int                `json:"sessions"`
+	Completed  int                `json:"completed"`
+	FocusHours float64            `json:"focus_hours"`
+	Periods    []FocusPeriod      `json:"periods"`
+	ByTask     map[string]float64 `json:"by_task"` // focus hours
+	Running    *FocusSession      `json:"running,omitempty"`
+	Summary    string             `json:"summary"`
+}
+
+// focusReport aggregates focus entries and tracked time per day or week
+func (js *JournalService) focusReport(tasks []*Task, dateFrom, dateTo, groupBy string) FocusReport {
+	loc := js.location()
+	report := FocusReport{DateFrom: dateFrom, DateTo: dateTo, GroupBy: groupBy, Periods: []FocusPeriod{}, ByTask: make(map[string]float64)}
+
+	periods := make(map[string]*FocusPeriod)
+	byTask := make(map[string]int)
+	total := 0
+	for _, task := range tasks {
+		for _, entry := range task.Entries {
+			if entry.Minutes == 0 {
+				continue
+			}
+			at := entry.Timestamp.In(loc)
+			day := at.Format("2006-01-02")
+			if day < dateFrom || day > dateTo {
+				continue
+			}
+			start := day
+			if groupBy == "week" {
+				start = js.weekStartIn(at, loc).Format("2006-01-02")
+			}
+			period, ok := periods[start]
+			if !ok {
+				period = &FocusPeriod{Start: start}
+				periods[start] = period
+			}
+
+			period.TrackedMinutes += entry.Minutes
+			if entry.Type != "focus" {
+				continue
+			}
+			period.Sessions++
+			period.FocusMinutes += entry.Minutes
+			report.Sessions++
+			if entry.Minutes >= entry.PlannedMinutes {
+				period.Completed++
+				report.Completed++
+			}
+			byTask[task.ID] += entry.Minutes
+			total += entry.Minutes
+		}
+	}
+
+	for _, start := range sortedKeys(periods) {
+		period := periods[start]
+		period.FocusShare = math.Round(float64(period.FocusMinutes)/float64(period.TrackedMinutes)*100) / 100
+		report.Periods = append(report.Periods, *period)
+	}
+	for taskID, minutes := range byTask {
+		report.ByTask[taskID] = roundHours(minutes)
+	}
+	report.FocusHours = roundHours(total)
+	report.Summary = fmt.Sprintf("Focused %s in %d sessions (%d ran their full length) between %s and %s", formatMinutes(total), report.Sessions, report.Completed, dateFrom, dateTo)
+	return report
+}
+
+// GetFocusReport summarizes focus sessions per day or week beside all tracked time
+func (js *JournalService) GetFocusReport(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	now := time.Now().In(js.location())
+	groupBy := request.GetString("group_by", "day")
+	if groupBy != "day" && groupBy != "week" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid group_by: %s (expected day or week)", groupBy)), nil
+	}
+	defaultFrom := now.AddDate(0, 0, -6)
+	if groupBy == "week" {
+		defaultFrom = js.weekStartIn(now, js.location()).AddDate(0, 0, -21)
+	}
+	dateFrom := request.GetString("date_from", defaultFrom.Format("2006-01-02"))
+	dateTo := request.GetString("date_to", now.Format("2006-01-02"))
+	if validationErr := js.validateDateFormat(dateFrom, "date_from"); validationErr != nil {
+		return mcp.NewToolResultError(validationErr.Error()), nil
+	}
+	if validationErr := js.validateDateFormat(dateTo, "date_to"); validationErr != nil {
+		return mcp.NewToolResultError(validationErr.Error()), nil
+	}
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+
+	report := js.focusReport(tasks, dateFrom, dateTo, groupBy)
+	if session, err := js.loadFocusSession(); err == nil && session != nil {
+		report.Running = session
+	}
+
+	resultJSON, _ := json.MarshalIndent(report, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
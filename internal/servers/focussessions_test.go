@@ -0,0 +1,135 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestFocusSessions(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	config := defaultConfiguration()
+	config.General.TimeZone = "UTC"
+	js.saveConfiguration(config)
+
+	createTestTask(t, js, "FOCUS-1", "Write the design doc", "work")
+	createTestTask(t, js, "FOCUS-2", "Review the migration", "work")
+
+	// Starting a session stops a running timer
+	js.StartTimer(ctx, CreateMockRequest(map[string]interface{}{"task_id": "FOCUS-2"}))
+	result, _ := js.StartFocusSession(ctx, CreateMockRequest(map[string]interface{}{"task_id": "FOCUS-1", "goal": "draft the rollout section"}))
+	if text := result.Content[0].(mcp.TextContent).Text; result.IsError || !strings.Contains(text, "Started a 25m focus session on FOCUS-1") || !strings.Contains(text, "Stopped timer on FOCUS-2") {
+		t.Fatalf("Unexpected start: %s", text)
+	}
+	if task, _ := js.loadTask("FOCUS-2"); task.TimerStarted != nil {
+		t.Error("Expected the timer stopped")
+	}
+	if result, _ := js.StartFocusSession(ctx, CreateMockRequest(map[string]interface{}{"task_id": "FOCUS-2"})); !result.IsError {
+		t.Error("Expected a second session refused")
+	}
+	if result, _ := js.StartTimer(ctx, CreateMockRequest(map[string]interface{}{"task_id": "FOCUS-2"})); !result.IsError {
+		t.Error("Expected a timer refused during a session")
+	}
+	if result, _ := js.StartFocusSession(ctx, CreateMockRequest(map[string]interface{}{"task_id": "FOCUS-2", "minutes": "240"})); !result.IsError {
+		t.Error("Expected an overlong session refused")
+	}
+
+	// Ended early: the time focused so far is recorded
+	session, _ := js.loadFocusSession()
+	session.Started = time.Now().Add(-10 * time.Minute)
+	js.saveFocusSession(session)
+	result, _ = js.EndFocusSession(ctx, CreateMockRequest(map[string]interface{}{"note": "outline done"}))
+	if result.IsError {
+		t.Fatalf("EndFocusSession failed: %v", result.Content)
+	}
+	task, _ := js.loadTask("FOCUS-1")
+	early := task.Entries[len(task.Entries)-1]
+	if early.Type != "focus" || early.Minutes != 10 || early.PlannedMinutes != 25 || !strings.Contains(early.Content, "of a planned 25m: draft the rollout section; outline done") {
+		t.Errorf("Unexpected focus entry: %+v", early)
+	}
+	if result, _ := js.EndFocusSession(ctx, CreateMockRequest(map[string]interface{}{})); !result.IsError {
+		t.Error("Expected an error with no session running")
+	}
+
+	// The scheduler ends a session at its planned length
+	js.StartFocusSession(ctx, CreateMockRequest(map[string]interface{}{"task_id": "FOCUS-1", "minutes": "30"}))
+	job := js.focusSessionJob()
+	if job.Due(time.Now(), time.Time{}) {
+		t.Error("Expected a running session not due")
+	}
+	session, _ = js.loadFocusSession()
+	session.Started = time.Now().Add(-45 * time.Minute)
+	js.saveFocusSession(session)
+	if !job.Due(time.Now(), time.Time{}) {
+		t.Fatal("Expected a finished session due")
+	}
+	if err := job.Run(ctx); err != nil {
+		t.Fatalf("Job failed: %v", err)
+	}
+	if session, _ := js.loadFocusSession(); session != nil {
+		t.Error("Expected the session cleared")
+	}
+	task, _ = js.loadTask("FOCUS-1")
+	if full := task.Entries[len(task.Entries)-1]; full.Type != "focus" || full.Minutes != 30 {
+		t.Errorf("Expected the session capped at its planned 30m, got %+v", full)
+	}
+	if notifications, _ := js.loadNotifications(); len(notifications) != 1 || notifications[0].Kind != "focus_session" {
+		t.Errorf("Expected a break notification, got %+v", notifications)
+	}
+
+	// Focus time counts as tracked time
+	result, _ = js.GetTimeReport(ctx, CreateMockRequest(map[string]interface{}{}))
+	var timeReport TimeReport
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &timeReport)
+	if timeReport.ByTask["FOCUS-1"] != roundHours(40) {
+		t.Errorf("Expected focus minutes in the time report, got %+v", timeReport.ByTask)
+	}
+
+	getReport := func(args map[string]interface{}) FocusReport {
+		result, _ := js.GetFocusReport(ctx, CreateMockRequest(args))
+		text := result.Content[0].(mcp.TextContent).Text
+		if result.IsError {
+			t.Fatalf("GetFocusReport failed: %s", text)
+		}
+		var report FocusReport
+		json.Unmarshal([]byte(text), &report)
+		return report
+	}
+
+	report := getReport(map[string]interface{}{})
+	if report.Sessions != 2 || report.Completed != 1 || report.ByTask["FOCUS-1"] != roundHours(40) {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if len(report.Periods) == 0 || report.Periods[len(report.Periods)-1].FocusMinutes == 0 {
+		t.Errorf("Expected today's focus time, got %+v", report.Periods)
+	}
+
+	// Older sessions group into weeks
+	task, _ = js.loadTask("FOCUS-2")
+	task.Entries = append(task.Entries,
+		Entry{ID: generateEntryID(), Timestamp: time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC), Type: "focus", Minutes: 25, PlannedMinutes: 25},
+		Entry{ID: generateEntryID(), Timestamp: time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC), Type: "focus", Minutes: 15, PlannedMinutes: 25},
+		Entry{ID: generateEntryID(), Timestamp: time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC), Type: "time", Minutes: 60},
+		Entry{ID: generateEntryID(), Timestamp: time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC), Type: "focus", Minutes: 50, PlannedMinutes: 50},
+	)
+	js.saveTask(task)
+
+	report = getReport(map[string]interface{}{"date_from": "2026-03-01", "date_to": "2026-03-15", "group_by": "week"})
+	if len(report.Periods) != 2 {
+		t.Fatalf("Expected two weeks, got %+v", report.Periods)
+	}
+	if first := report.Periods[0]; first.Sessions != 2 || first.Completed != 1 || first.FocusMinutes != 40 || first.TrackedMinutes != 100 || first.FocusShare != 0.4 {
+		t.Errorf("Unexpected first week: %+v", first)
+	}
+	if report.Periods[1].FocusMinutes != 50 {
+		t.Errorf("Unexpected second week: %+v", report.Periods[1])
+	}
+	if result, _ := js.GetFocusReport(ctx, CreateMockRequest(map[string]interface{}{"group_by": "month"})); !result.IsError {
+		t.Error("Expected an invalid group_by refused")
+	}
+}
@@ -0,0 +1,130 @@
+package servers
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+// Relevance scores for search results. An exact phrase hit scores highest;
+// otherwise each query word is scored against its best word in the text and
+// the scores are averaged, so typos rank below exact words.
+const (
+	phraseScore    = 1.0
+	wordScore      = 0.9
+	prefixScore    = 0.85
+	substringScore = 0.75
+	typoScore      = 0.7 // less typoPenalty per edit
+	typoPenalty    = 0.15
+
+	// titleWeight scales a task title match for each of the task's entries
+	titleWeight = 0.8
+)
+
+// maxTypos is how many edits a query word may be from a word in the text.
+// Short words must match exactly, or nearly every short word would match.
+func maxTypos(word string) int {
+	switch n := utf8.RuneCountInString(word); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	}
+	return 2
+}
+
+// editDistance returns the optimal string alignment distance between a and b:
+// insertions, deletions, substitutions and swaps of neighbouring letters each
+// count as one edit, so "kuberentes" is one edit from "kubernetes"
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(min(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
+
+// wordSimilarity scores how well a lowercase query word matches a word in the
+// text, from 0 (no match) to wordScore (identical)
+func wordSimilarity(query, word string) float64 {
+	switch {
+	case query == word:
+		return wordScore
+	case strings.HasPrefix(word, query):
+		return prefixScore
+	case strings.Contains(word, query):
+		return substringScore
+	}
+	limit := maxTypos(query)
+	if limit == 0 {
+		return 0
+	}
+	if diff := utf8.RuneCountInString(word) - utf8.RuneCountInString(query); diff > limit || -diff > limit {
+		return 0
+	}
+	if distance := editDistance(query, word); distance <= limit {
+		return typoScore - typoPenalty*float64(distance)
+	}
+	return 0
+}
+
+// searchScore scores text against a lowercase query. Without fuzzy only the
+// exact phrase matches; with fuzzy every query word must match some word of
+// the text, allowing typos. Zero means no match.
+func searchScore(query string, queryWords []string, text string, fuzzy bool) float64 {
+	lower := strings.ToLower(text)
+	if strings.Contains(lower, query) {
+		return phraseScore
+	}
+	if !fuzzy || len(queryWords) == 0 {
+		return 0
+	}
+
+	words := searchTokens(lower)
+	total := 0.0
+	for _, queryWord := range queryWords {
+		best := 0.0
+		for _, word := range words {
+			best = math.Max(best, wordSimilarity(queryWord, word))
+		}
+		if best == 0 {
+			return 0
+		}
+		total += best
+	}
+	return total / float64(len(queryWords))
+}
+
+// matchOffset returns where in content a result matched, for showing an
+// excerpt: the phrase if present, else the first word a query word matches
+func matchOffset(content, query string, queryWords []string) int {
+	lower := strings.ToLower(content)
+	if pos := strings.Index(lower, query); pos >= 0 {
+		return pos
+	}
+	for _, span := range searchTokenPattern.FindAllStringIndex(lower, -1) {
+		for _, queryWord := range queryWords {
+			if wordSimilarity(queryWord, lower[span[0]:span[1]]) > 0 {
+				return span[0]
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,110 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestEditDistance(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		distance int
+	}{
+		{"kubernetes", "kubernetes", 0},
+		{"kuberentes", "kubernetes", 1},
+		{"kubernets", "kubernetes", 1},
+		{"kubernetez", "kubernetes", 1},
+		{"abc", "ca", 3},
+		{"", "abc", 3},
+		{"café", "cafe", 1},
+	}
+	for _, c := range cases {
+		if got := editDistance(c.a, c.b); got != c.distance {
+			t.Errorf("editDistance(%q, %q) = %d, expected %d", c.a, c.b, got, c.distance)
+		}
+	}
+}
+
+func TestSearchScore(t *testing.T) {
+	if searchScore("k8s", []string{"k8s"}, "Set up k8s", false) != phraseScore {
+		t.Error("Expected a phrase hit to score highest")
+	}
+	if searchScore("kuberentes", []string{"kuberentes"}, "Kubernetes upgrade", false) != 0 {
+		t.Error("Expected no typo tolerance without fuzzy")
+	}
+	typo := searchScore("kuberentes", []string{"kuberentes"}, "Kubernetes upgrade", true)
+	exact := searchScore("upgrade kubernetes", []string{"kubernetes", "upgrade"}, "Kubernetes upgrade", true)
+	if typo == 0 || exact <= typo || exact >= phraseScore {
+		t.Errorf("Expected 0 < typo (%v) < words (%v) < phrase", typo, exact)
+	}
+	if searchScore("cat", []string{"cat"}, "A car", true) != 0 {
+		t.Error("Expected short words to need an exact match")
+	}
+	if searchScore("kuberentes outage", []string{"kuberentes", "outage"}, "Kubernetes upgrade", true) != 0 {
+		t.Error("Expected every query word to need a match")
+	}
+}
+
+func TestSearchEntriesFuzzy(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "FZ-1", "Cluster investigation", "investigation")
+	createTestTask(t, js, "FZ-2", "Team notes", "work")
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "FZ-1", "content": "Kubernetes pods restart under memory pressure"}))
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "FZ-2", "content": "Discussed the kuberentes rollout"}))
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "FZ-2", "content": "Lunch order"}))
+
+	result, _ := js.SearchEntries(ctx, CreateMockRequest(map[string]interface{}{"query": "kuberentes"}))
+	text := result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "Found 2 matching entries") {
+		t.Fatalf("Expected the typo to find both entries:\n%s", text)
+	}
+	// The exact spelling ranks above the entry found through a typo
+	if strings.Index(text, "FZ-2") > strings.Index(text, "FZ-1") || !strings.Contains(text, "**Score:** 1.00") {
+		t.Errorf("Expected results ordered by score:\n%s", text)
+	}
+
+	result, _ = js.SearchEntries(ctx, CreateMockRequest(map[string]interface{}{"query": "kuberentes", "fuzzy": "false"}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "Found 1 matching entries") || strings.Contains(text, "FZ-1") {
+		t.Errorf("Expected only the exact spelling without fuzzy:\n%s", text)
+	}
+}
+
+func TestSearchEntriesPagination(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "PG-1", "Deploys", "work")
+	createTestTask(t, js, "PG-2", "Rollbacks", "work")
+	for i := 0; i < 3; i++ {
+		js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "PG-1", "content": fmt.Sprintf("Canary release %d", i)}))
+	}
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "PG-2", "content": "Canary failed, rolled back"}))
+
+	result, _ := js.SearchEntries(ctx, CreateMockRequest(map[string]interface{}{"query": "canary", "limit": "2"}))
+	text := result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "Found 4 matching entries (showing 1-2)") || strings.Count(text, "**Score:**") != 2 || !strings.Contains(text, "offset=2") {
+		t.Errorf("Expected the first page of two:\n%s", text)
+	}
+	result, _ = js.SearchEntries(ctx, CreateMockRequest(map[string]interface{}{"query": "canary", "limit": "2", "offset": "2"}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "(showing 3-4)") || strings.Contains(text, "offset=") {
+		t.Errorf("Expected the second page, got %s", text)
+	}
+	result, _ = js.SearchEntries(ctx, CreateMockRequest(map[string]interface{}{"query": "canary", "limit": "2", "offset": "10"}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "Found 4 matching entries (showing 0 of 4)") {
+		t.Errorf("Expected an empty page past the end:\n%s", text)
+	}
+
+	result, _ = js.SearchEntries(ctx, CreateMockRequest(map[string]interface{}{"query": "canary", "group_by_task": "true", "limit": "1"}))
+	text = result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "Found 4 matching entries in 2 tasks (showing tasks 1-1)") || strings.Count(text, "## ") != 1 || strings.Count(text, "**Score:**") < 1 || !strings.Contains(text, "offset=1") {
+		t.Errorf("Expected one task's group per page:\n%s", text)
+	}
+
+	if result, _ := js.SearchEntries(ctx, CreateMockRequest(map[string]interface{}{"query": "canary", "limit": "0"})); !result.IsError {
+		t.Error("Expected an invalid limit to fail")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"sort"
 	"strconv"
 	"strings"
@@ -80,29 +82,40 @@ func NewGitHubService(token string) *GitHubService {
 	tc := oauth2.NewClient(ctx, ts)
 
 	return &GitHubService{
-		client: github.NewClient(tc),
+		client: newGitHubClient(tc),
 		token:  token,
 	}
 }
 
+// newGitHubClient creates a go-github client against githubAPIURL
+func newGitHubClient(httpClient *http.Client) *github.Client {
+	client := github.NewClient(httpClient)
+	if baseURL, err := url.Parse(githubAPIURL); err == nil {
+		client.BaseURL = baseURL
+	}
+	return client
+}
+
 // SyncWithGitHub syncs assigned GitHub issues with tasks
 func (js *JournalService) SyncWithGitHub(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	token := request.GetString("github_token", "")
-	if token == "" {
-		return mcp.NewToolResultError("github_token is required"), nil
+	githubService, err := js.githubService(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	username := request.GetString("username", "")
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load configuration: %v", err)), nil
+	}
+	username := request.GetString("username", config.GitHub.Username)
 	if username == "" {
-		return mcp.NewToolResultError("username is required"), nil
+		return mcp.NewToolResultError("username is required (or set github.username in config)"), nil
 	}
 
-	repositories := request.GetStringSlice("repositories", nil)
+	repositories := request.GetStringSlice("repositories", config.GitHub.Repositories)
 	createTasks := request.GetString("create_tasks", "true") == "true"
 	updateExisting := request.GetString("update_existing", "true") == "true"
 
-	githubService := NewGitHubService(token)
-
 	syncResult := GitHubSyncResult{
 		LastSyncTime: time.Now(),
 		Errors:       []string{},
@@ -119,30 +132,22 @@ func (js *JournalService) SyncWithGitHub(ctx context.Context, request mcp.CallTo
 	for _, issue := range issues {
 		taskID := generateTaskIDFromIssue(issue)
 
-		// Check if task already exists
-		existingTask, err := js.loadTask(taskID)
-		if err != nil {
-			// Task doesn't exist, create new one if enabled
-			if createTasks {
-				task := js.createTaskFromGitHubIssue(issue)
-				if err := js.saveTask(task); err != nil {
-					syncResult.Errors = append(syncResult.Errors, fmt.Sprintf("Failed to save task %s: %v", taskID, err))
-					continue
-				}
-				syncResult.TasksCreated++
-			}
-		} else {
-			// Task exists, update if enabled
-			if updateExisting {
-				updated := js.updateTaskFromGitHubIssue(existingTask, issue)
-				if updated {
-					if err := js.saveTask(existingTask); err != nil {
-						syncResult.Errors = append(syncResult.Errors, fmt.Sprintf("Failed to update task %s: %v", taskID, err))
-						continue
-					}
-					syncResult.TasksUpdated++
-				}
-			}
+		var create func() *Task
+		var update func(*Task) bool
+		if createTasks {
+			create = func() *Task { return js.createTaskFromGitHubIssue(issue) }
+		}
+		if updateExisting {
+			update = func(task *Task) bool { return js.updateTaskFromGitHubIssue(task, issue) }
+		}
+		sync := js.syncIssueTask(taskID, create, update)
+		switch {
+		case sync.Err != nil:
+			syncResult.Errors = append(syncResult.Errors, sync.failure())
+		case sync.Created:
+			syncResult.TasksCreated++
+		case sync.Updated:
+			syncResult.TasksUpdated++
 		}
 	}
 
@@ -155,9 +160,9 @@ func (js *JournalService) SyncWithGitHub(ctx context.Context, request mcp.CallTo
 
 // PullIssueUpdates pulls latest comments and events for tracked GitHub issues
 func (js *JournalService) PullIssueUpdates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	token := request.GetString("github_token", "")
-	if token == "" {
-		return mcp.NewToolResultError("github_token is required"), nil
+	githubService, err := js.githubService(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	taskID := request.GetString("task_id", "")
@@ -172,8 +177,6 @@ func (js *JournalService) PullIssueUpdates(ctx context.Context, request mcp.Call
 		since = &sinceTime
 	}
 
-	githubService := NewGitHubService(token)
-
 	// Get all tasks with GitHub issues or specific task
 	var tasks []*Task
 	if taskID != "" {
@@ -216,35 +219,30 @@ func (js *JournalService) PullIssueUpdates(ctx context.Context, request mcp.Call
 		}
 
 		// Add new entries for comments and events
-		entriesAdded := 0
+		var entries []Entry
 		for _, comment := range comments {
-			entry := Entry{
+			entries = append(entries, Entry{
 				ID:        generateEntryID(),
 				Timestamp: comment.CreatedAt,
 				Content:   fmt.Sprintf("GitHub comment by %s: %s", comment.Author, comment.Body),
 				Type:      "github_comment",
-			}
-			task.Entries = append(task.Entries, entry)
-			entriesAdded++
+			})
 		}
-
 		for _, event := range events {
-			entry := Entry{
+			entries = append(entries, Entry{
 				ID:        generateEntryID(),
 				Timestamp: event.CreatedAt,
 				Content:   fmt.Sprintf("GitHub event: %s by %s", event.Event, event.Actor),
 				Type:      "github_event",
-			}
-			task.Entries = append(task.Entries, entry)
-			entriesAdded++
+			})
 		}
 
-		if entriesAdded > 0 {
-			task.Updated = time.Now()
-			if err := js.saveTask(task); err != nil {
-				errors = append(errors, fmt.Sprintf("Failed to save task %s: %v", task.ID, err))
-				continue
-			}
+		added, err := js.addIssueActivity(task.ID, entries, nil)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("Failed to save task %s: %v", task.ID, err))
+			continue
+		}
+		if added > 0 {
 			updateCount++
 		}
 	}
@@ -261,11 +259,80 @@ func (js *JournalService) PullIssueUpdates(ctx context.Context, request mcp.Call
 	return mcp.NewToolResultText(string(resultJSON)), nil
 }
 
+// issueSync is the outcome of syncing one issue onto its task
+type issueSync struct {
+	TaskID  string
+	Created bool // a new task was saved, or would have been when Err is set
+	Updated bool
+	Err     error
+}
+
+// failure describes a failed issue sync for a sync result's errors
+func (s issueSync) failure() string {
+	if s.Created {
+		return fmt.Sprintf("Failed to save task %s: %v", s.TaskID, s.Err)
+	}
+	return fmt.Sprintf("Failed to update task %s: %v", s.TaskID, s.Err)
+}
+
+// syncIssueTask creates or updates the task tracking an issue under the
+// task's lock. create builds the task when it does not exist yet and update
+// applies the issue to an existing one, reporting a change; either may be
+// nil to skip that case.
+func (js *JournalService) syncIssueTask(taskID string, create func() *Task, update func(*Task) bool) issueSync {
+	defer js.lockTask(taskID)()
+
+	sync := issueSync{TaskID: taskID}
+	existing, err := js.loadTask(taskID)
+	if err != nil {
+		if create != nil {
+			sync.Created = true
+			sync.Err = js.saveTask(create())
+		}
+		return sync
+	}
+	if update != nil && update(existing) {
+		sync.Updated = true
+		sync.Err = js.saveTask(existing)
+	}
+	return sync
+}
+
+// addIssueActivity appends entries pulled from an issue tracker to a task
+// under its lock. The task is reloaded first so edits made while the activity
+// was fetched are kept; when cutoff is set, only entries newer than the time
+// it returns for the reloaded task are added. It returns the entries added.
+func (js *JournalService) addIssueActivity(taskID string, entries []Entry, cutoff func(*Task) *time.Time) (int, error) {
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return 0, err
+	}
+	var after *time.Time
+	if cutoff != nil {
+		after = cutoff(task)
+	}
+	added := 0
+	for _, entry := range entries {
+		if after != nil && !entry.Timestamp.After(*after) {
+			continue
+		}
+		task.Entries = append(task.Entries, entry)
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	task.Updated = time.Now()
+	return added, js.saveTask(task)
+}
+
 // CreateTaskFromGitHubIssue creates a new task from a GitHub issue URL
 func (js *JournalService) CreateTaskFromGitHubIssue(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	token := request.GetString("github_token", "")
-	if token == "" {
-		return mcp.NewToolResultError("github_token is required"), nil
+	githubService, err := js.githubService(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	issueURL := request.GetString("issue_url", "")
@@ -276,8 +343,6 @@ func (js *JournalService) CreateTaskFromGitHubIssue(ctx context.Context, request
 	taskType := request.GetString("type", "work")
 	priority := request.GetString("priority", "medium")
 
-	githubService := NewGitHubService(token)
-
 	owner, repo, issueNum, err := parseGitHubURL(issueURL)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Invalid GitHub URL: %v", err)), nil
@@ -452,26 +517,13 @@ func (js *JournalService) createTaskFromGitHubIssue(issue *github.Issue) *Task {
 		labels = append(labels, label.GetName())
 	}
 
-	// Determine priority from labels
-	priority := "medium"
-	for _, label := range labels {
-		switch strings.ToLower(label) {
-		case "priority/high", "high", "critical":
-			priority = "high"
-		case "priority/low", "low":
-			priority = "low"
-		case "priority/urgent", "urgent":
-			priority = "urgent"
-		}
-	}
-
 	task := &Task{
 		ID:       taskID,
 		Title:    issue.GetTitle(),
 		Type:     "work", // Default, can be overridden
 		Tags:     labels,
 		Status:   mapGitHubStateToTaskStatus(issue.GetState()),
-		Priority: priority,
+		Priority: priorityFromLabels(labels),
 		IssueURL: issue.GetHTMLURL(),
 		IssueID:  strconv.Itoa(issue.GetNumber()),
 		Created:  time.Now(),
@@ -479,6 +531,15 @@ func (js *JournalService) createTaskFromGitHubIssue(issue *github.Issue) *Task {
 		Entries:  []Entry{},
 	}
 
+	// Issue forms and templates become structured fields; other bodies are kept as one entry
+	var fieldMapping map[string]string
+	if config, err := js.loadConfiguration(); err == nil {
+		fieldMapping = config.GitHub.IssueFields
+	}
+	if applyIssueForm(task, issue.GetBody(), fieldMapping) {
+		return task
+	}
+
 	// Add initial entry with issue description
 	if issue.GetBody() != "" {
 		task.Entries = append(task.Entries, Entry{
@@ -543,6 +604,23 @@ func generateTaskIDFromIssue(issue *github.Issue) string {
 	return fmt.Sprintf("GH-issue-%d", issue.GetNumber())
 }
 
+// priorityFromLabels maps labels such as "priority/high" or GitLab's scoped
+// "priority::high" to a task priority
+func priorityFromLabels(labels []string) string {
+	priority := "medium"
+	for _, label := range labels {
+		switch strings.ReplaceAll(strings.ToLower(label), "::", "/") {
+		case "priority/high", "high", "critical":
+			priority = "high"
+		case "priority/low", "low":
+			priority = "low"
+		case "priority/urgent", "urgent":
+			priority = "urgent"
+		}
+	}
+	return priority
+}
+
 func parseGitHubURL(url string) (owner, repo string, number int, err error) {
 	// Parse URLs like: https://github.com/owner/repo/issues/123
 	parts := strings.Split(url, "/")
@@ -0,0 +1,204 @@
+package servers
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"golang.org/x/oauth2"
+)
+
+// githubAPIURL is the REST API base used by every GitHub client; tests point it at a fake server
+var githubAPIURL = "https://api.github.com/"
+
+// githubAppTokenSource exchanges a GitHub App JWT for short-lived installation
+// access tokens. Wrap it in oauth2.ReuseTokenSource to cache tokens until expiry.
+type githubAppTokenSource struct {
+	appID          int64
+	installationID int64
+	key            *rsa.PrivateKey
+	baseURL        string
+	client         *http.Client
+}
+
+func (ts *githubAppTokenSource) Token() (*oauth2.Token, error) {
+	jwt, err := githubAppJWT(ts.appID, ts.key, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%sapp/installations/%d/access_tokens", ts.baseURL, ts.installationID)
+	req, err := http.NewRequest(http.MethodPost, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := ts.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("GitHub App installation token request failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var installationToken struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.Unmarshal(body, &installationToken); err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{
+		AccessToken: installationToken.Token,
+		TokenType:   "token",
+		Expiry:      installationToken.ExpiresAt,
+	}, nil
+}
+
+// githubAppJWT builds the RS256 JWT GitHub expects from an app. The issued-at
+// time is backdated a minute to allow for clock drift; GitHub caps expiry at 10 minutes.
+func githubAppJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func parseGitHubAppKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("GitHub App private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %v", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("GitHub App private key must be an RSA key")
+	}
+	return key, nil
+}
+
+// NewGitHubAppService creates a GitHub service authenticated as an app installation
+func NewGitHubAppService(appID, installationID int64, key *rsa.PrivateKey) *GitHubService {
+	source := &githubAppTokenSource{
+		appID:          appID,
+		installationID: installationID,
+		key:            key,
+		baseURL:        githubAPIURL,
+		client:         &http.Client{Timeout: 30 * time.Second},
+	}
+	tc := oauth2.NewClient(context.Background(), oauth2.ReuseTokenSource(nil, source))
+
+	return &GitHubService{client: newGitHubClient(tc)}
+}
+
+// githubService picks GitHub authentication: an explicit github_token argument
+// wins, then the GitHub App installation when github.auth_mode is "app", then
+// a configured token (see configuredGitHubToken)
+func (js *JournalService) githubService(request mcp.CallToolRequest) (*GitHubService, error) {
+	if token := request.GetString("github_token", ""); token != "" {
+		return NewGitHubService(token), nil
+	}
+
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load config: %v", err)
+	}
+
+	if config.GitHub.AuthMode == "app" {
+		keyPEM, err := js.githubAppKey(config)
+		if err != nil {
+			return nil, err
+		}
+		key, err := parseGitHubAppKey(keyPEM)
+		if err != nil {
+			return nil, err
+		}
+		return NewGitHubAppService(config.GitHub.App.AppID, config.GitHub.App.InstallationID, key), nil
+	}
+
+	if token := js.configuredGitHubToken(config); token != "" {
+		return NewGitHubService(token), nil
+	}
+
+	return nil, fmt.Errorf("github_token is required (or store it once with set_secret name=github_token, or set GITHUB_TOKEN)")
+}
+
+// configuredGitHubToken finds a token without it passing through the
+// conversation: github.token (a raw token or a secret: reference), the
+// github_token secret, then the GITHUB_TOKEN or GH_TOKEN environment variables
+func (js *JournalService) configuredGitHubToken(config *Configuration) string {
+	if ref := config.GitHub.Token; ref != "" {
+		if name, ok := strings.CutPrefix(ref, secretRefPrefix); ok {
+			if token, _ := js.getSecret(name); token != "" {
+				return token
+			}
+		} else {
+			return ref
+		}
+	}
+	if token, _ := js.getSecret("github_token"); token != "" {
+		return token
+	}
+	for _, env := range []string{"GITHUB_TOKEN", "GH_TOKEN"} {
+		if token := os.Getenv(env); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// githubAppKey reads the app private key from github.app.private_key_path or
+// the github_app_private_key secret
+func (js *JournalService) githubAppKey(config *Configuration) ([]byte, error) {
+	if path := config.GitHub.App.PrivateKeyPath; path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read GitHub App private key: %v", err)
+		}
+		return data, nil
+	}
+
+	key, err := js.getSecret("github_app_private_key")
+	if err != nil {
+		return nil, fmt.Errorf("GitHub App auth needs github.app.private_key_path or the github_app_private_key secret")
+	}
+	return []byte(key), nil
+}
@@ -0,0 +1,157 @@
+package servers
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGitHubAppTokenSource(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/app/installations/99/access_tokens" {
+			http.Error(w, "unexpected request", http.StatusNotFound)
+			return
+		}
+
+		parts := strings.Split(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), ".")
+		if len(parts) != 3 {
+			http.Error(w, "malformed JWT", http.StatusUnauthorized)
+			return
+		}
+		signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature); err != nil {
+			http.Error(w, "bad signature", http.StatusUnauthorized)
+			return
+		}
+
+		claimsJSON, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		var claims map[string]interface{}
+		json.Unmarshal(claimsJSON, &claims)
+		if claims["iss"] != "42" {
+			http.Error(w, "wrong issuer", http.StatusUnauthorized)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"token":      "ghs_installation",
+			"expires_at": time.Now().Add(time.Hour),
+		})
+	}))
+	defer server.Close()
+
+	source := &githubAppTokenSource{
+		appID:          42,
+		installationID: 99,
+		key:            key,
+		baseURL:        server.URL + "/",
+		client:         server.Client(),
+	}
+
+	token, err := source.Token()
+	if err != nil {
+		t.Fatalf("Failed to get installation token: %v", err)
+	}
+	if token.AccessToken != "ghs_installation" {
+		t.Errorf("Expected installation token, got %s", token.AccessToken)
+	}
+}
+
+func TestGitHubServiceAuthMode(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	keyPath := filepath.Join(t.TempDir(), "app.pem")
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	os.WriteFile(keyPath, keyPEM, 0600)
+
+	config := defaultConfiguration()
+	config.GitHub.AuthMode = "app"
+	config.GitHub.App.AppID = 42
+	config.GitHub.App.InstallationID = 99
+	config.GitHub.App.PrivateKeyPath = keyPath
+	if err := js.validateConfiguration(config); err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+	js.saveConfiguration(config)
+
+	service, err := js.githubService(CreateMockRequest(map[string]interface{}{}))
+	if err != nil {
+		t.Fatalf("Expected app-authenticated service: %v", err)
+	}
+	if service.token != "" {
+		t.Error("Expected app auth rather than a personal token")
+	}
+
+	// An explicit token still overrides the configured mode
+	service, _ = js.githubService(CreateMockRequest(map[string]interface{}{"github_token": "ghp_override"}))
+	if service.token != "ghp_override" {
+		t.Errorf("Expected explicit token, got %q", service.token)
+	}
+
+	config.GitHub.App.InstallationID = 0
+	if err := js.validateConfiguration(config); err == nil {
+		t.Error("Expected missing installation ID to fail validation")
+	}
+}
+
+func TestConfiguredGitHubToken(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	config := defaultConfiguration()
+	config.Secrets.Provider = "env"
+	js.saveConfiguration(config)
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("GH_TOKEN", "")
+
+	if token := js.configuredGitHubToken(config); token != "" {
+		t.Errorf("Expected no token, got %q", token)
+	}
+	if _, err := js.githubService(CreateMockRequest(map[string]interface{}{})); err == nil {
+		t.Error("Expected an error without any token")
+	}
+
+	t.Setenv("GH_TOKEN", "from-gh-env")
+	if token := js.configuredGitHubToken(config); token != "from-gh-env" {
+		t.Errorf("Expected GH_TOKEN, got %q", token)
+	}
+	t.Setenv("GITHUB_TOKEN", "from-env")
+	if token := js.configuredGitHubToken(config); token != "from-env" {
+		t.Errorf("Expected GITHUB_TOKEN to win over GH_TOKEN, got %q", token)
+	}
+
+	t.Setenv(secretEnvName("github_token"), "from-default-secret")
+	if token := js.configuredGitHubToken(config); token != "from-default-secret" {
+		t.Errorf("Expected the github_token secret, got %q", token)
+	}
+
+	t.Setenv(secretEnvName("work_pat"), "from-named-secret")
+	config.GitHub.Token = "secret:work_pat"
+	if token := js.configuredGitHubToken(config); token != "from-named-secret" {
+		t.Errorf("Expected the referenced secret, got %q", token)
+	}
+
+	config.GitHub.Token = "ghp_raw"
+	js.saveConfiguration(config)
+	service, err := js.githubService(CreateMockRequest(map[string]interface{}{}))
+	if err != nil || service.token != "ghp_raw" {
+		t.Errorf("Expected the raw config token, got %v", err)
+	}
+}
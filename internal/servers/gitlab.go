@@ -0,0 +1,461 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// GitLabService handles GitLab REST API interactions, on gitlab.com or self-hosted
+type GitLabService struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// GitLabIssue is an issue or merge request as returned by the GitLab API
+type GitLabIssue struct {
+	IID         int      `json:"iid"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	State       string   `json:"state"` // opened, closed or merged
+	Labels      []string `json:"labels"`
+	WebURL      string   `json:"web_url"`
+	References  struct {
+		Full string `json:"full"` // group/project#12 or group/project!5
+	} `json:"references"`
+	MergeRequest bool `json:"-"`
+}
+
+// GitLabNote is a comment or system note on an issue or merge request
+type GitLabNote struct {
+	ID     int64  `json:"id"`
+	Body   string `json:"body"`
+	System bool   `json:"system"`
+	Author struct {
+		Username string `json:"username"`
+	} `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GitLabSyncResult represents the result of a GitLab sync operation
+type GitLabSyncResult struct {
+	TasksCreated    int       `json:"tasks_created"`
+	TasksUpdated    int       `json:"tasks_updated"`
+	IssuesProcessed int       `json:"issues_processed"`
+	Errors          []string  `json:"errors,omitempty"`
+	Summary         string    `json:"summary"`
+	LastSyncTime    time.Time `json:"last_sync_time"`
+}
+
+// NewGitLabService creates a GitLab service for baseURL
+func NewGitLabService(baseURL, token string) *GitLabService {
+	return &GitLabService{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  http.DefaultClient,
+	}
+}
+
+// gitlabService builds a GitLab client from gitlab.base_url and the
+// gitlab_token parameter or secret
+func (js *JournalService) gitlabService(request mcp.CallToolRequest) (*GitLabService, error) {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load config: %v", err)
+	}
+	baseURL := config.GitLab.BaseURL
+	if baseURL == "" {
+		baseURL = "https://gitlab.com"
+	}
+
+	token := request.GetString("gitlab_token", "")
+	if token == "" {
+		token, _ = js.getSecret("gitlab_token")
+	}
+	if token == "" {
+		return nil, fmt.Errorf("gitlab_token is required (or store it once with set_secret name=gitlab_token)")
+	}
+	return NewGitLabService(baseURL, token), nil
+}
+
+// SyncWithGitLab syncs assigned GitLab issues and merge requests with tasks
+func (js *JournalService) SyncWithGitLab(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	gitlabService, err := js.gitlabService(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load configuration: %v", err)), nil
+	}
+
+	projects := request.GetStringSlice("projects", config.GitLab.Projects)
+	includeMRs := request.GetString("include_merge_requests", "true") == "true"
+	createTasks := request.GetString("create_tasks", "true") == "true"
+	updateExisting := request.GetString("update_existing", "true") == "true"
+
+	syncResult := GitLabSyncResult{
+		LastSyncTime: time.Now(),
+		Errors:       []string{},
+	}
+
+	issues, err := gitlabService.getAssignedIssues(ctx, projects, includeMRs)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch GitLab issues: %v", err)), nil
+	}
+	syncResult.IssuesProcessed = len(issues)
+
+	for _, issue := range issues {
+		var create func() *Task
+		var update func(*Task) bool
+		if createTasks {
+			create = func() *Task { return createTaskFromGitLabIssue(issue) }
+		}
+		if updateExisting {
+			update = func(task *Task) bool { return updateTaskFromGitLabIssue(task, issue) }
+		}
+
+		sync := js.syncIssueTask(generateTaskIDFromGitLabIssue(issue), create, update)
+		switch {
+		case sync.Err != nil:
+			syncResult.Errors = append(syncResult.Errors, sync.failure())
+		case sync.Created:
+			syncResult.TasksCreated++
+		case sync.Updated:
+			syncResult.TasksUpdated++
+		}
+	}
+
+	syncResult.Summary = fmt.Sprintf("Processed %d issues and merge requests: %d tasks created, %d tasks updated",
+		syncResult.IssuesProcessed, syncResult.TasksCreated, syncResult.TasksUpdated)
+
+	result, _ := json.Marshal(syncResult)
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+// PullGitLabUpdates pulls comments and system notes for tracked GitLab issues and merge requests
+func (js *JournalService) PullGitLabUpdates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	gitlabService, err := js.gitlabService(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	taskID := request.GetString("task_id", "")
+	var since *time.Time
+	if sinceStr := request.GetString("since", ""); sinceStr != "" {
+		sinceTime, err := time.Parse("2006-01-02T15:04:05Z", sinceStr)
+		if err != nil {
+			return mcp.NewToolResultError("Invalid since timestamp format. Use ISO 8601 (2006-01-02T15:04:05Z)"), nil
+		}
+		since = &sinceTime
+	}
+
+	var tasks []*Task
+	if taskID != "" {
+		task, err := js.loadTask(taskID)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskID)), nil
+		}
+		if _, _, _, err := gitlabService.parseURL(task.IssueURL); err != nil {
+			return mcp.NewToolResultError("Task does not have a GitLab issue associated"), nil
+		}
+		tasks = []*Task{task}
+	} else {
+		allTasks, err := js.getAllTasks()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+		}
+		for _, task := range allTasks {
+			if _, _, _, err := gitlabService.parseURL(task.IssueURL); err == nil {
+				tasks = append(tasks, task)
+			}
+		}
+	}
+
+	updateCount := 0
+	errors := []string{}
+
+	for _, task := range tasks {
+		project, kind, iid, _ := gitlabService.parseURL(task.IssueURL)
+		notes, err := gitlabService.getNotes(ctx, project, kind, iid)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("Failed to fetch updates for task %s: %v", task.ID, err))
+			continue
+		}
+
+		var entries []Entry
+		for _, note := range notes {
+			entry := Entry{
+				ID:        generateEntryID(),
+				Timestamp: note.CreatedAt,
+				Content:   fmt.Sprintf("GitLab comment by %s: %s", note.Author.Username, note.Body),
+				Type:      "gitlab_comment",
+			}
+			if note.System {
+				entry.Content = fmt.Sprintf("GitLab event: %s %s", note.Author.Username, note.Body)
+				entry.Type = "gitlab_event"
+			}
+			entries = append(entries, entry)
+		}
+
+		// Without since, only notes newer than the last pull are added
+		added, err := js.addIssueActivity(task.ID, entries, func(task *Task) *time.Time {
+			if since != nil {
+				return since
+			}
+			return lastGitLabActivity(task)
+		})
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("Failed to save task %s: %v", task.ID, err))
+			continue
+		}
+		if added > 0 {
+			updateCount++
+		}
+	}
+
+	result := map[string]interface{}{
+		"tasks_updated":  updateCount,
+		"total_tasks":    len(tasks),
+		"errors":         errors,
+		"summary":        fmt.Sprintf("Updated %d tasks with latest GitLab activity", updateCount),
+		"last_sync_time": time.Now(),
+	}
+
+	resultJSON, _ := json.Marshal(result)
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// Helper methods for GitLab service
+
+// get performs a GET against the GitLab API and returns the next page number, or 0 on the last page
+func (gls *GitLabService) get(ctx context.Context, path string, params url.Values, out interface{}) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gls.baseURL+"/api/v4/"+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", gls.token)
+
+	resp, err := gls.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message interface{} `json:"message"`
+		}
+		json.NewDecoder(resp.Body).Decode(&apiErr)
+		return 0, fmt.Errorf("%s: %v", resp.Status, apiErr.Message)
+	}
+
+	next, _ := strconv.Atoi(resp.Header.Get("X-Next-Page"))
+	return next, json.NewDecoder(resp.Body).Decode(out)
+}
+
+// list fetches every page of an issue or merge request listing
+func (gls *GitLabService) list(ctx context.Context, path string, params url.Values) ([]GitLabIssue, error) {
+	var all []GitLabIssue
+	params.Set("per_page", "100")
+	for page := 1; page != 0; {
+		params.Set("page", strconv.Itoa(page))
+		var issues []GitLabIssue
+		next, err := gls.get(ctx, path, params, &issues)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, issues...)
+		page = next
+	}
+	return all, nil
+}
+
+func (gls *GitLabService) getAssignedIssues(ctx context.Context, projects []string, includeMRs bool) ([]GitLabIssue, error) {
+	prefixes := []string{""}
+	if len(projects) > 0 {
+		prefixes = nil
+		for _, project := range projects {
+			prefixes = append(prefixes, "projects/"+url.PathEscape(project)+"/")
+		}
+	}
+
+	var all []GitLabIssue
+	for _, prefix := range prefixes {
+		params := url.Values{"scope": {"assigned_to_me"}, "state": {"opened"}}
+		issues, err := gls.list(ctx, prefix+"issues", params)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, issues...)
+
+		if includeMRs {
+			mrs, err := gls.list(ctx, prefix+"merge_requests", params)
+			if err != nil {
+				return nil, err
+			}
+			for i := range mrs {
+				mrs[i].MergeRequest = true
+			}
+			all = append(all, mrs...)
+		}
+	}
+	return all, nil
+}
+
+// getNotes fetches all notes on an issue or merge request, oldest first
+func (gls *GitLabService) getNotes(ctx context.Context, project, kind string, iid int) ([]GitLabNote, error) {
+	path := fmt.Sprintf("projects/%s/%s/%d/notes", url.PathEscape(project), kind, iid)
+	params := url.Values{"sort": {"asc"}, "order_by": {"created_at"}, "per_page": {"100"}}
+
+	var all []GitLabNote
+	for page := 1; page != 0; {
+		params.Set("page", strconv.Itoa(page))
+		var notes []GitLabNote
+		next, err := gls.get(ctx, path, params, &notes)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, notes...)
+		page = next
+	}
+	return all, nil
+}
+
+// parseURL splits a web URL like https://gitlab.example.com/group/project/-/issues/12
+// into the project path, "issues" or "merge_requests", and the IID
+func (gls *GitLabService) parseURL(webURL string) (project, kind string, iid int, err error) {
+	rest, ok := strings.CutPrefix(webURL, gls.baseURL+"/")
+	if !ok {
+		return "", "", 0, fmt.Errorf("not a %s URL", gls.baseURL)
+	}
+	project, tail, ok := strings.Cut(rest, "/-/")
+	if !ok {
+		return "", "", 0, fmt.Errorf("invalid GitLab URL format")
+	}
+	kind, number, _ := strings.Cut(tail, "/")
+	if kind != "issues" && kind != "merge_requests" {
+		return "", "", 0, fmt.Errorf("invalid GitLab URL format")
+	}
+	iid, err = strconv.Atoi(strings.Trim(number, "/"))
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid issue number")
+	}
+	return project, kind, iid, nil
+}
+
+func createTaskFromGitLabIssue(issue GitLabIssue) *Task {
+	task := &Task{
+		ID:       generateTaskIDFromGitLabIssue(issue),
+		Title:    issue.Title,
+		Type:     "work", // Default, can be overridden
+		Tags:     issue.Labels,
+		Status:   mapGitLabStateToTaskStatus(issue.State),
+		Priority: priorityFromLabels(issue.Labels),
+		IssueURL: issue.WebURL,
+		IssueID:  strconv.Itoa(issue.IID),
+		Created:  time.Now(),
+		Updated:  time.Now(),
+		Entries:  []Entry{},
+	}
+	if issue.MergeRequest {
+		task.Tags = append(append([]string{}, issue.Labels...), "merge-request")
+	}
+
+	if issue.Description != "" {
+		task.Entries = append(task.Entries, Entry{
+			ID:        generateEntryID(),
+			Timestamp: time.Now(),
+			Content:   fmt.Sprintf("GitLab Description: %s", issue.Description),
+			Type:      "gitlab_description",
+		})
+	}
+
+	return task
+}
+
+func updateTaskFromGitLabIssue(task *Task, issue GitLabIssue) bool {
+	updated := false
+
+	newStatus := mapGitLabStateToTaskStatus(issue.State)
+	if task.Status != newStatus {
+		task.Status = newStatus
+		task.Entries = append(task.Entries, Entry{
+			ID:        generateEntryID(),
+			Timestamp: time.Now(),
+			Content:   fmt.Sprintf("Status updated from GitLab: %s", newStatus),
+			Type:      "status_change",
+		})
+		updated = true
+	}
+
+	if task.Title != issue.Title {
+		task.Title = issue.Title
+		updated = true
+	}
+
+	labels := issue.Labels
+	if issue.MergeRequest {
+		labels = append(append([]string{}, issue.Labels...), "merge-request")
+	}
+	if !equalStringSlices(task.Tags, labels) {
+		task.Tags = labels
+		updated = true
+	}
+
+	if updated {
+		task.Updated = time.Now()
+	}
+	return updated
+}
+
+// Helper functions
+
+// generateTaskIDFromGitLabIssue builds GL-<project>-<iid>, or GL-<project>-MR<iid> for merge requests
+func generateTaskIDFromGitLabIssue(issue GitLabIssue) string {
+	ref := issue.References.Full
+	separator := "#"
+	if issue.MergeRequest {
+		separator = "!"
+	}
+	project, _, _ := strings.Cut(ref, separator)
+	if slash := strings.LastIndex(project, "/"); slash >= 0 {
+		project = project[slash+1:]
+	}
+	if project == "" {
+		project = "issue"
+	}
+	if issue.MergeRequest {
+		return fmt.Sprintf("GL-%s-MR%d", project, issue.IID)
+	}
+	return fmt.Sprintf("GL-%s-%d", project, issue.IID)
+}
+
+// lastGitLabActivity returns the newest GitLab note entry on a task
+func lastGitLabActivity(task *Task) *time.Time {
+	var latest *time.Time
+	for i := range task.Entries {
+		entry := task.Entries[i]
+		if (entry.Type == "gitlab_comment" || entry.Type == "gitlab_event") && (latest == nil || entry.Timestamp.After(*latest)) {
+			latest = &task.Entries[i].Timestamp
+		}
+	}
+	return latest
+}
+
+func mapGitLabStateToTaskStatus(state string) string {
+	switch state {
+	case "closed", "merged":
+		return "completed"
+	default:
+		return "active"
+	}
+}
@@ -0,0 +1,105 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestParseGitLabURL(t *testing.T) {
+	gls := NewGitLabService("https://gitlab.example.com/", "token")
+
+	project, kind, iid, err := gls.parseURL("https://gitlab.example.com/platform/backend/api/-/merge_requests/42")
+	if err != nil || project != "platform/backend/api" || kind != "merge_requests" || iid != 42 {
+		t.Errorf("Unexpected parse: %s %s %d %v", project, kind, iid, err)
+	}
+	if _, _, _, err := gls.parseURL("https://github.com/owner/repo/issues/1"); err == nil {
+		t.Error("Expected an error for a non-GitLab URL")
+	}
+}
+
+func TestGitLabSync(t *testing.T) {
+	state := "opened"
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "secret-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v4/projects/platform/api/issues":
+			if r.URL.Query().Get("page") == "1" {
+				w.Header().Set("X-Next-Page", "2")
+				json.NewEncoder(w).Encode([]map[string]interface{}{{
+					"iid": 7, "title": "Flaky deploy", "description": "Fails on Mondays", "state": state,
+					"labels": []string{"ci", "priority::high"}, "web_url": server.URL + "/platform/api/-/issues/7",
+					"references": map[string]string{"full": "platform/api#7"},
+				}})
+				return
+			}
+			w.Write([]byte(`[]`))
+		case "/api/v4/projects/platform/api/merge_requests":
+			json.NewEncoder(w).Encode([]map[string]interface{}{{
+				"iid": 3, "title": "Retry deploys", "state": "opened", "labels": []string{},
+				"web_url": server.URL + "/platform/api/-/merge_requests/3", "references": map[string]string{"full": "platform/api!3"},
+			}})
+		case "/api/v4/projects/platform/api/issues/7/notes":
+			w.Write([]byte(`[
+				{"id": 1, "body": "Seen again today", "system": false, "author": {"username": "dana"}, "created_at": "2026-03-02T10:00:00Z"},
+				{"id": 2, "body": "added ~ci label", "system": true, "author": {"username": "dana"}, "created_at": "2026-03-02T11:00:00Z"}
+			]`))
+		case "/api/v4/projects/platform/api/merge_requests/3/notes":
+			w.Write([]byte(`[]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	config := defaultConfiguration()
+	config.GitLab.BaseURL = server.URL
+	config.GitLab.Projects = []string{"platform/api"}
+	js.saveConfiguration(config)
+
+	request := CreateMockRequest(map[string]interface{}{"gitlab_token": "secret-token"})
+	result, _ := js.SyncWithGitLab(ctx, request)
+	var sync GitLabSyncResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &sync); err != nil {
+		t.Fatalf("Failed to parse sync result: %v", result.Content)
+	}
+	if sync.IssuesProcessed != 2 || sync.TasksCreated != 2 {
+		t.Fatalf("Unexpected sync result: %+v", sync)
+	}
+
+	task, err := js.loadTask("GL-api-7")
+	if err != nil {
+		t.Fatalf("Expected task GL-api-7: %v", err)
+	}
+	if task.Priority != "high" || !equalStringSlices(task.Tags, []string{"ci", "priority::high"}) || len(task.Entries) != 1 {
+		t.Errorf("Unexpected task: %+v", task)
+	}
+	if mr, err := js.loadTask("GL-api-MR3"); err != nil || !equalStringSlices(mr.Tags, []string{"merge-request"}) {
+		t.Errorf("Expected merge request task, got %+v (%v)", mr, err)
+	}
+
+	js.PullGitLabUpdates(ctx, request)
+	js.PullGitLabUpdates(ctx, request)
+	task, _ = js.loadTask("GL-api-7")
+	if len(task.Entries) != 3 || task.Entries[1].Type != "gitlab_comment" || task.Entries[2].Content != "GitLab event: dana added ~ci label" {
+		t.Errorf("Unexpected entries after pull: %+v", task.Entries)
+	}
+
+	state = "closed"
+	result, _ = js.SyncWithGitLab(ctx, request)
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &sync)
+	task, _ = js.loadTask("GL-api-7")
+	if sync.TasksUpdated != 1 || task.Status != "completed" {
+		t.Errorf("Expected closed issue to complete the task, got %+v / %s", sync, task.Status)
+	}
+}
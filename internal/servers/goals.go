@@ -0,0 +1,431 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// goalPeriods are how long a goal runs
+var goalPeriods = []string{"quarterly", "annual"}
+
+// goalPeriodNouns name the timeframe of each period in reports
+var goalPeriodNouns = map[string]string{"quarterly": "quarter", "annual": "year"}
+
+var (
+	quarterPattern = regexp.MustCompile(`^(\d{4})-[Qq]([1-4])$`)
+	yearPattern    = regexp.MustCompile(`^\d{4}$`)
+)
+
+// Goal is an objective for a quarter or a year, measured by its key results,
+// stored as goals/{id}.json
+type Goal struct {
+	ID          string      `json:"id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description,omitempty"`
+	Period      string      `json:"period"`    // quarterly or annual
+	Timeframe   string      `json:"timeframe"` // 2026-Q4 or 2026
+	KeyResults  []KeyResult `json:"key_results,omitempty"`
+	TaskIDs     []string    `json:"task_ids,omitempty"` // tasks linked to the goal rather than a key result
+	Created     time.Time   `json:"created"`
+	Updated     time.Time   `json:"updated"`
+}
+
+// KeyResult is a measurable outcome of a goal, tracked through its linked tasks
+type KeyResult struct {
+	ID      string   `json:"id"` // KR1, KR2, ...
+	Title   string   `json:"title"`
+	TaskIDs []string `json:"task_ids,omitempty"`
+}
+
+// GoalProgress is a goal's progress computed from its linked tasks
+type GoalProgress struct {
+	ID         string              `json:"id"`
+	Title      string              `json:"title"`
+	Timeframe  string              `json:"timeframe"`
+	Progress   int                 `json:"progress"` // percent, the mean of the key results (and directly linked tasks)
+	Elapsed    int                 `json:"elapsed"`  // percent of the timeframe gone
+	Status     string              `json:"status"`   // not started, on track, behind or done
+	KeyResults []KeyResultProgress `json:"key_results"`
+	Direct     *KeyResultProgress  `json:"direct,omitempty"` // tasks linked to the goal itself
+	Entries    int                 `json:"entries"`          // written on linked tasks within the timeframe
+	Minutes    int                 `json:"minutes,omitempty"`
+	LastEntry  string              `json:"last_entry,omitempty"`
+}
+
+// KeyResultProgress is one key result's share of its linked tasks completed
+type KeyResultProgress struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Tasks     int      `json:"tasks"`
+	Completed int      `json:"completed"`
+	Progress  int      `json:"progress"` // percent
+	Entries   int      `json:"entries"`
+	Missing   []string `json:"missing,omitempty"` // linked tasks that no longer exist
+}
+
+// currentTimeframe is the quarter (2026-Q4) or year (2026) containing now
+func currentTimeframe(period string, now time.Time) string {
+	if period == "annual" {
+		return strconv.Itoa(now.Year())
+	}
+	return fmt.Sprintf("%d-Q%d", now.Year(), (int(now.Month())-1)/3+1)
+}
+
+// timeframeBounds returns the start and end of a 2026-Q4 or 2026 timeframe in loc
+func timeframeBounds(timeframe string, loc *time.Location) (time.Time, time.Time, error) {
+	if m := quarterPattern.FindStringSubmatch(timeframe); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		quarter, _ := strconv.Atoi(m[2])
+		start := time.Date(year, time.Month(3*(quarter-1)+1), 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(0, 3, 0), nil
+	}
+	if yearPattern.MatchString(timeframe) {
+		year, _ := strconv.Atoi(timeframe)
+		start := time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(1, 0, 0), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid timeframe: %s (expected a quarter such as 2026-Q4 or a year such as 2026)", timeframe)
+}
+
+func (js *JournalService) goalPath(id string) string {
+	return filepath.Join(js.dataDir(), "goals", id+".json")
+}
+
+func (js *JournalService) loadGoal(id string) (*Goal, error) {
+	data, err := js.readDataFile(js.goalPath(id))
+	if err != nil {
+		return nil, err
+	}
+	var goal Goal
+	if err := json.Unmarshal(data, &goal); err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
+
+func (js *JournalService) saveGoal(goal *Goal) error {
+	path := js.goalPath(goal.ID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(goal, "", "  ")
+	if err != nil {
+		return err
+	}
+	return js.writeDataFile(path, data, 0644)
+}
+
+// loadGoals loads every goal, ordered by timeframe and then ID
+func (js *JournalService) loadGoals() ([]*Goal, error) {
+	files, err := os.ReadDir(filepath.Join(js.dataDir(), "goals"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var goals []*Goal
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		goal, err := js.loadGoal(strings.TrimSuffix(file.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		goals = append(goals, goal)
+	}
+	sort.Slice(goals, func(i, j int) bool {
+		if goals[i].Timeframe != goals[j].Timeframe {
+			return goals[i].Timeframe < goals[j].Timeframe
+		}
+		return goals[i].ID < goals[j].ID
+	})
+	return goals, nil
+}
+
+// findKeyResult finds a goal's key result by ID (KR2) or number (2)
+func findKeyResult(goal *Goal, ref string) *KeyResult {
+	if n, err := strconv.Atoi(ref); err == nil {
+		ref = fmt.Sprintf("KR%d", n)
+	}
+	for i := range goal.KeyResults {
+		if strings.EqualFold(goal.KeyResults[i].ID, ref) {
+			return &goal.KeyResults[i]
+		}
+	}
+	return nil
+}
+
+// goalProgress computes a goal's progress from its linked tasks at now
+func goalProgress(goal *Goal, tasks map[string]*Task, loc *time.Location, now time.Time) GoalProgress {
+	start, end, _ := timeframeBounds(goal.Timeframe, loc)
+	progress := GoalProgress{ID: goal.ID, Title: goal.Title, Timeframe: goal.Timeframe, KeyResults: []KeyResultProgress{}}
+	var last time.Time
+
+	measure := func(id, title string, taskIDs []string) KeyResultProgress {
+		kr := KeyResultProgress{ID: id, Title: title}
+		for _, taskID := range taskIDs {
+			task, ok := tasks[taskID]
+			if !ok {
+				kr.Missing = append(kr.Missing, taskID)
+				continue
+			}
+			kr.Tasks++
+			if task.Status == "completed" {
+				kr.Completed++
+			}
+			for _, entry := range task.Entries {
+				if entry.Timestamp.Before(start) || !entry.Timestamp.Before(end) {
+					continue
+				}
+				progress.Minutes += entry.Minutes
+				if isWrittenEntry(entry) {
+					kr.Entries++
+					if entry.Timestamp.After(last) {
+						last = entry.Timestamp
+					}
+				}
+			}
+		}
+		if kr.Tasks > 0 {
+			kr.Progress = kr.Completed * 100 / kr.Tasks
+		}
+		progress.Entries += kr.Entries
+		return kr
+	}
+
+	total, parts := 0, 0
+	for _, keyResult := range goal.KeyResults {
+		kr := measure(keyResult.ID, keyResult.Title, keyResult.TaskIDs)
+		progress.KeyResults = append(progress.KeyResults, kr)
+		total += kr.Progress
+		parts++
+	}
+	if len(goal.TaskIDs) > 0 {
+		direct := measure("", "Linked to the goal", goal.TaskIDs)
+		progress.Direct = &direct
+		total += direct.Progress
+		parts++
+	}
+	if parts > 0 {
+		progress.Progress = total / parts
+	}
+	if !last.IsZero() {
+		progress.LastEntry = last.In(loc).Format("2006-01-02")
+	}
+
+	switch {
+	case now.Before(start):
+		progress.Elapsed = 0
+	case !now.Before(end):
+		progress.Elapsed = 100
+	default:
+		progress.Elapsed = int(now.Sub(start) * 100 / end.Sub(start))
+	}
+	switch {
+	case progress.Progress == 100:
+		progress.Status = "done"
+	case progress.Progress == 0 && progress.Entries == 0:
+		progress.Status = "not started"
+	case progress.Progress+10 >= progress.Elapsed:
+		progress.Status = "on track"
+	default:
+		progress.Status = "behind"
+	}
+	return progress
+}
+
+// CreateGoal creates a quarterly or annual goal with its key results
+func (js *JournalService) CreateGoal(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	title, err := request.RequireString("title")
+	if err != nil || strings.TrimSpace(title) == "" {
+		return mcp.NewToolResultError("title is required"), nil
+	}
+	period := request.GetString("period", "quarterly")
+	if !slices.Contains(goalPeriods, period) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid period: %s (expected %s)", period, strings.Join(goalPeriods, ", "))), nil
+	}
+	loc := js.location()
+	timeframe := strings.ToUpper(request.GetString("timeframe", currentTimeframe(period, time.Now().In(loc))))
+	if _, _, err := timeframeBounds(timeframe, loc); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if (period == "annual") != yearPattern.MatchString(timeframe) {
+		return mcp.NewToolResultError(fmt.Sprintf("Timeframe %s does not fit a %s goal", timeframe, period)), nil
+	}
+
+	goal := &Goal{
+		ID:          strings.ToLower(timeframe) + "-" + slugify(title, 40),
+		Title:       strings.TrimSpace(title),
+		Description: request.GetString("description", ""),
+		Period:      period,
+		Timeframe:   timeframe,
+		Created:     time.Now(),
+		Updated:     time.Now(),
+	}
+	defer lockFile(js.goalPath(goal.ID))()
+	if _, err := js.loadGoal(goal.ID); err == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Goal %s already exists", goal.ID)), nil
+	}
+	for _, keyResult := range nonEmpty(request.GetStringSlice("key_results", nil)) {
+		goal.KeyResults = append(goal.KeyResults, KeyResult{ID: fmt.Sprintf("KR%d", len(goal.KeyResults)+1), Title: keyResult})
+	}
+	if err := js.saveGoal(goal); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save goal: %v", err)), nil
+	}
+
+	result, _ := json.MarshalIndent(goal, "", "  ")
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+// LinkTaskToGoal links tasks to a goal or one of its key results, or unlinks them
+func (js *JournalService) LinkTaskToGoal(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	goalID, err := request.RequireString("goal_id")
+	if err != nil {
+		return mcp.NewToolResultError("goal_id is required"), nil
+	}
+	taskIDs := request.GetStringSlice("task_ids", nil)
+	if len(taskIDs) == 0 {
+		return mcp.NewToolResultError("task_ids is required"), nil
+	}
+	unlink := request.GetString("unlink", "false") == "true"
+
+	defer lockFile(js.goalPath(goalID))()
+	goal, err := js.loadGoal(goalID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Goal not found: %s", goalID)), nil
+	}
+
+	target, label := &goal.TaskIDs, goal.ID
+	if ref := request.GetString("key_result", ""); ref != "" {
+		keyResult := findKeyResult(goal, ref)
+		if keyResult == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Key result not found on %s: %s", goal.ID, ref)), nil
+		}
+		target, label = &keyResult.TaskIDs, goal.ID+" "+keyResult.ID
+	}
+
+	changed := 0
+	for _, taskID := range taskIDs {
+		if unlink {
+			if i := slices.Index(*target, taskID); i >= 0 {
+				*target = slices.Delete(*target, i, i+1)
+				changed++
+			}
+			continue
+		}
+		if _, err := js.loadTask(taskID); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskID)), nil
+		}
+		if !slices.Contains(*target, taskID) {
+			*target = append(*target, taskID)
+			changed++
+		}
+	}
+	goal.Updated = time.Now()
+	if err := js.saveGoal(goal); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save goal: %v", err)), nil
+	}
+
+	if unlink {
+		return mcp.NewToolResultText(fmt.Sprintf("Unlinked %d tasks from %s", changed, label)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Linked %d tasks to %s (%d now linked)", changed, label, len(*target))), nil
+}
+
+// GetGoalProgress reports progress on one goal, or on every goal in a timeframe,
+// from the completion of linked tasks and the entries logged on them
+func (js *JournalService) GetGoalProgress(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	goals, err := js.loadGoals()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load goals: %v", err)), nil
+	}
+	if goalID := request.GetString("goal_id", ""); goalID != "" {
+		goals = slices.DeleteFunc(goals, func(goal *Goal) bool { return goal.ID != goalID })
+		if len(goals) == 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Goal not found: %s", goalID)), nil
+		}
+	} else if timeframe := strings.ToUpper(request.GetString("timeframe", "")); timeframe != "" {
+		goals = slices.DeleteFunc(goals, func(goal *Goal) bool { return goal.Timeframe != timeframe })
+	}
+
+	tasks := make(map[string]*Task)
+	allTasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+	// Archived tasks still count toward the goals they were linked to
+	archived, _ := js.loadArchivedTasks()
+	for _, task := range append(archived, allTasks...) {
+		tasks[task.ID] = task
+	}
+	loc := js.location()
+	now := time.Now()
+
+	if request.GetString("format", "markdown") == "json" {
+		progress := []GoalProgress{}
+		for _, goal := range goals {
+			progress = append(progress, goalProgress(goal, tasks, loc, now))
+		}
+		result, _ := json.MarshalIndent(progress, "", "  ")
+		return mcp.NewToolResultText(string(result)), nil
+	}
+
+	var md strings.Builder
+	md.WriteString("# Goal Progress\n\n")
+	if len(goals) == 0 {
+		md.WriteString("No goals yet. Create one with create_goal.\n")
+	}
+	for _, goal := range goals {
+		progress := goalProgress(goal, tasks, loc, now)
+		md.WriteString(fmt.Sprintf("## %s (%s)\n", goal.Title, goal.Timeframe))
+		md.WriteString(fmt.Sprintf("**%d%% complete, %s** (%d%% of the %s gone) · `%s`\n\n", progress.Progress, progress.Status, progress.Elapsed, goalPeriodNouns[goal.Period], goal.ID))
+		if goal.Description != "" {
+			md.WriteString(goal.Description + "\n\n")
+		}
+		parts := progress.KeyResults
+		if progress.Direct != nil {
+			parts = append(parts, *progress.Direct)
+		}
+		for _, kr := range parts {
+			label := kr.Title
+			if kr.ID != "" {
+				label = kr.ID + ": " + kr.Title
+			}
+			if kr.Tasks == 0 {
+				md.WriteString(fmt.Sprintf("- %s: no linked tasks\n", label))
+			} else {
+				md.WriteString(fmt.Sprintf("- %s: %d%% (%d of %d tasks completed, %d entries)\n", label, kr.Progress, kr.Completed, kr.Tasks, kr.Entries))
+			}
+			if len(kr.Missing) > 0 {
+				md.WriteString(fmt.Sprintf("  - Missing tasks: %s\n", strings.Join(kr.Missing, ", ")))
+			}
+		}
+		activity := fmt.Sprintf("%d entries this %s", progress.Entries, goalPeriodNouns[goal.Period])
+		if progress.Minutes > 0 {
+			activity += ", " + formatMinutes(progress.Minutes) + " tracked"
+		}
+		if progress.LastEntry != "" {
+			activity += ", last on " + progress.LastEntry
+		}
+		md.WriteString("\n*" + activity + "*\n\n")
+	}
+
+	markdown, err := js.styleMarkdown(request.GetString("style", ""), md.String())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(markdown), nil
+}
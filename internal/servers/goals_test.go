@@ -0,0 +1,99 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestTimeframeBounds(t *testing.T) {
+	start, end, err := timeframeBounds("2026-Q4", time.UTC)
+	if err != nil || !start.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) || !end.Equal(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected Q4 bounds: %v %v %v", start, end, err)
+	}
+	start, end, err = timeframeBounds("2026", time.UTC)
+	if err != nil || start.Month() != time.January || end.Year() != 2027 {
+		t.Errorf("Unexpected year bounds: %v %v %v", start, end, err)
+	}
+	if _, _, err := timeframeBounds("2026-Q5", time.UTC); err == nil {
+		t.Error("Expected a fifth quarter rejected")
+	}
+	if tf := currentTimeframe("quarterly", time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC)); tf != "2026-Q2" {
+		t.Errorf("Expected 2026-Q2, got %s", tf)
+	}
+}
+
+func TestGoals(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	call := func(handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]interface{}) string {
+		result, _ := handler(ctx, CreateMockRequest(args))
+		text := result.Content[0].(mcp.TextContent).Text
+		if result.IsError {
+			t.Fatalf("Call failed: %s", text)
+		}
+		return text
+	}
+
+	var goal Goal
+	json.Unmarshal([]byte(call(js.CreateGoal, map[string]interface{}{
+		"title":       "Ship the new importer",
+		"timeframe":   "2026-q4",
+		"key_results": []interface{}{"Parse Excel exports", "Migrate the three biggest teams"},
+	})), &goal)
+	if goal.ID != "2026-q4-ship-the-new-importer" || goal.Timeframe != "2026-Q4" || len(goal.KeyResults) != 2 || goal.KeyResults[1].ID != "KR2" {
+		t.Fatalf("Unexpected goal: %+v", goal)
+	}
+
+	for _, id := range []string{"OKR-1", "OKR-2", "OKR-3"} {
+		createTestTask(t, js, id, "Importer work "+id, "work")
+	}
+	task, _ := js.loadTask("OKR-1")
+	task.Status = "completed"
+	task.Entries = append(task.Entries, Entry{ID: "okr1", Timestamp: time.Date(2026, 10, 5, 10, 0, 0, 0, time.UTC), Content: "Parser done", Minutes: 45})
+	js.saveTask(task)
+
+	call(js.LinkTaskToGoal, map[string]interface{}{"goal_id": goal.ID, "task_ids": []interface{}{"OKR-1", "OKR-2"}, "key_result": "1"})
+	if text := call(js.LinkTaskToGoal, map[string]interface{}{"goal_id": goal.ID, "task_ids": []interface{}{"OKR-2", "OKR-3"}, "key_result": "KR1"}); text != "Linked 1 tasks to "+goal.ID+" KR1 (3 now linked)" {
+		t.Errorf("Expected links not repeated, got %s", text)
+	}
+	call(js.LinkTaskToGoal, map[string]interface{}{"goal_id": goal.ID, "task_ids": []interface{}{"OKR-3"}, "key_result": "1", "unlink": "true"})
+
+	var progress []GoalProgress
+	json.Unmarshal([]byte(call(js.GetGoalProgress, map[string]interface{}{"goal_id": goal.ID, "format": "json"})), &progress)
+	if len(progress) != 1 {
+		t.Fatalf("Expected one goal, got %+v", progress)
+	}
+	kr1, kr2 := progress[0].KeyResults[0], progress[0].KeyResults[1]
+	if kr1.Tasks != 2 || kr1.Completed != 1 || kr1.Progress != 50 || kr2.Tasks != 0 || progress[0].Progress != 25 {
+		t.Errorf("Expected KR1 half done and the goal a quarter done, got %+v", progress[0])
+	}
+	if progress[0].Entries != 1 || progress[0].Minutes != 45 || progress[0].LastEntry != "2026-10-05" {
+		t.Errorf("Expected the October entry counted, got %+v", progress[0])
+	}
+
+	js.DeleteTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "OKR-2"}))
+	markdown := call(js.GetGoalProgress, map[string]interface{}{"timeframe": "2026-Q4"})
+	for _, expected := range []string{"## Ship the new importer (2026-Q4)", "KR1: Parse Excel exports: 100% (1 of 1 tasks completed, 1 entries)", "Missing tasks: OKR-2", "KR2: Migrate the three biggest teams: no linked tasks", "45m tracked"} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, markdown)
+		}
+	}
+
+	for _, args := range []map[string]interface{}{
+		{"title": "Ship the new importer", "timeframe": "2026-Q4"},
+		{"title": "Grow", "period": "annual", "timeframe": "2026-Q1"},
+		{"title": "Grow", "period": "monthly"},
+	} {
+		if result, _ := js.CreateGoal(ctx, CreateMockRequest(args)); !result.IsError {
+			t.Errorf("Expected %v rejected", args)
+		}
+	}
+	if result, _ := js.LinkTaskToGoal(ctx, CreateMockRequest(map[string]interface{}{"goal_id": goal.ID, "task_ids": []interface{}{"OKR-1"}, "key_result": "KR9"})); !result.IsError {
+		t.Error("Expected an unknown key result rejected")
+	}
+}
@@ -0,0 +1,377 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Habit is a daily practice tracked as a streak, stored as habits/{id}.json.
+// A day counts as done when it is logged with log_habit or, with a match,
+// when the journal has a matching entry that day.
+type Habit struct {
+	ID      string      `json:"id"`
+	Name    string      `json:"name"`
+	Match   *HabitMatch `json:"match,omitempty"`  // nil for habits logged by hand only
+	Logged  []string    `json:"logged,omitempty"` // days marked done by hand, YYYY-MM-DD
+	Created time.Time   `json:"created"`
+	Updated time.Time   `json:"updated"`
+}
+
+// HabitMatch picks the entries that complete a habit. Every criterion given
+// must hold, and any one of a criterion's values will do. Without entry
+// types only written entries count.
+type HabitMatch struct {
+	AnyEntry   bool     `json:"any_entry,omitempty"` // any written entry, e.g. "write a journal entry"
+	EntryTypes []string `json:"entry_types,omitempty"`
+	TaskTypes  []string `json:"task_types,omitempty"`
+	Tags       []string `json:"tags,omitempty"`     // tags on the entry's task
+	Keywords   []string `json:"keywords,omitempty"` // in the entry content, ignoring case
+}
+
+// HabitStatus is a habit's streaks as of a day
+type HabitStatus struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Automatic   bool    `json:"automatic"` // completed from journal activity
+	DoneToday   bool    `json:"done_today"`
+	Current     int     `json:"current"`
+	Longest     int     `json:"longest"`
+	LongestFrom string  `json:"longest_from,omitempty"`
+	LongestTo   string  `json:"longest_to,omitempty"`
+	LastDone    string  `json:"last_done,omitempty"`
+	Consistency float64 `json:"consistency"` // share of days done over the reported weeks
+}
+
+// matches reports whether an entry on task completes the habit
+func (m *HabitMatch) matches(task *Task, entry Entry) bool {
+	if systemEntryTypes[entry.Type] {
+		return false
+	}
+	if len(m.EntryTypes) > 0 {
+		entryType := entry.Type
+		if entryType == "" {
+			entryType = "log"
+		}
+		if !slices.Contains(m.EntryTypes, entryType) {
+			return false
+		}
+	} else if !isWrittenEntry(entry) {
+		return false
+	}
+	if len(m.TaskTypes) > 0 && !slices.Contains(m.TaskTypes, task.Type) {
+		return false
+	}
+	if len(m.Tags) > 0 && !slices.ContainsFunc(m.Tags, func(tag string) bool {
+		return slices.ContainsFunc(task.Tags, func(t string) bool { return strings.EqualFold(t, tag) })
+	}) {
+		return false
+	}
+	if len(m.Keywords) > 0 {
+		content := strings.ToLower(entry.Content)
+		if !slices.ContainsFunc(m.Keywords, func(keyword string) bool { return strings.Contains(content, strings.ToLower(keyword)) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// habitDays returns the days (YYYY-MM-DD in loc) a habit was done
+func habitDays(habit *Habit, tasks []*Task, loc *time.Location) map[string]bool {
+	days := make(map[string]bool)
+	for _, day := range habit.Logged {
+		days[day] = true
+	}
+	if habit.Match == nil {
+		return days
+	}
+	for _, task := range tasks {
+		for _, entry := range task.Entries {
+			if habit.Match.matches(task, entry) {
+				days[entry.Timestamp.In(loc).Format("2006-01-02")] = true
+			}
+		}
+	}
+	return days
+}
+
+// habitStatus works out a habit's streaks as of now, with consistency over weeks
+func habitStatus(habit *Habit, days map[string]bool, now time.Time, loc *time.Location, weeks int) HabitStatus {
+	streaks := computeStreaks(days, now, loc, weeks)
+	return HabitStatus{
+		ID:          habit.ID,
+		Name:        habit.Name,
+		Automatic:   habit.Match != nil,
+		DoneToday:   streaks.JournaledToday,
+		Current:     streaks.Current,
+		Longest:     streaks.Longest,
+		LongestFrom: streaks.LongestFrom,
+		LongestTo:   streaks.LongestTo,
+		LastDone:    streaks.LastEntry,
+		Consistency: streaks.Consistency,
+	}
+}
+
+func (js *JournalService) habitPath(id string) string {
+	return filepath.Join(js.dataDir(), "habits", id+".json")
+}
+
+// loadHabit loads a habit by ID or by name
+func (js *JournalService) loadHabit(ref string) (*Habit, error) {
+	data, err := js.readDataFile(js.habitPath(slugify(ref, 40)))
+	if err != nil {
+		return nil, err
+	}
+	var habit Habit
+	if err := json.Unmarshal(data, &habit); err != nil {
+		return nil, err
+	}
+	return &habit, nil
+}
+
+func (js *JournalService) saveHabit(habit *Habit) error {
+	path := js.habitPath(habit.ID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(habit, "", "  ")
+	if err != nil {
+		return err
+	}
+	return js.writeDataFile(path, data, 0644)
+}
+
+// loadHabits loads every habit, ordered by ID
+func (js *JournalService) loadHabits() ([]*Habit, error) {
+	files, err := os.ReadDir(filepath.Join(js.dataDir(), "habits"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var habits []*Habit
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		habit, err := js.loadHabit(strings.TrimSuffix(file.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		habits = append(habits, habit)
+	}
+	return habits, nil
+}
+
+// habitStatuses works out every habit's streaks as of now
+func (js *JournalService) habitStatuses(now time.Time, weeks int) ([]HabitStatus, error) {
+	habits, err := js.loadHabits()
+	if err != nil || len(habits) == 0 {
+		return nil, err
+	}
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return nil, err
+	}
+	loc := js.location()
+	statuses := make([]HabitStatus, 0, len(habits))
+	for _, habit := range habits {
+		statuses = append(statuses, habitStatus(habit, habitDays(habit, tasks, loc), now, loc, weeks))
+	}
+	return statuses, nil
+}
+
+// habitInsights point out habits at their best streak, lapsed habits that
+// had a good run, and habits rarely kept
+func habitInsights(statuses []HabitStatus, weeks int) []string {
+	var insights []string
+	for _, status := range statuses {
+		switch {
+		case status.Current >= 7 && status.Current >= status.Longest:
+			insights = append(insights, fmt.Sprintf("You have kept up %q for %s, your longest streak yet.", status.Name, pluralDays(status.Current)))
+		case status.Current == 0 && status.Longest >= 7:
+			insights = append(insights, fmt.Sprintf("%q has lapsed; your longest streak was %s.", status.Name, pluralDays(status.Longest)))
+		case status.LastDone != "" && status.Consistency < 0.5:
+			insights = append(insights, fmt.Sprintf("%q was done on %.0f%% of days over the last %d weeks.", status.Name, status.Consistency*100, weeks))
+		}
+	}
+	return insights
+}
+
+// writeWeekHabits adds each habit's days done in the week and its streak as
+// of the week's end (or now, for the current week) to a weekly log
+func (js *JournalService) writeWeekHabits(md *strings.Builder, days []DailyActivity, loc *time.Location) {
+	habits, err := js.loadHabits()
+	if err != nil || len(habits) == 0 || len(days) == 0 {
+		return
+	}
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return
+	}
+	now := time.Now().In(loc)
+	today := now.Format("2006-01-02")
+	end, _ := time.ParseInLocation("2006-01-02", days[len(days)-1].Date, loc)
+	asOf := minTime(now, end.AddDate(0, 0, 1).Add(-time.Nanosecond))
+
+	md.WriteString("\n## Habits\n")
+	for _, habit := range habits {
+		done := habitDays(habit, tasks, loc)
+		count, elapsed := 0, 0
+		for _, day := range days {
+			if day.Date > today {
+				break
+			}
+			elapsed++
+			if done[day.Date] {
+				count++
+			}
+		}
+		streaks := computeStreaks(done, asOf, loc, 1)
+		line := fmt.Sprintf("- %s: %d of %d days", habit.Name, count, elapsed)
+		if streaks.Current > 0 {
+			line += fmt.Sprintf(", %d-day streak", streaks.Current)
+		}
+		if streaks.Longest > 0 {
+			line += fmt.Sprintf(" (longest %s)", pluralDays(streaks.Longest))
+		}
+		md.WriteString(line + "\n")
+	}
+}
+
+// CreateHabit defines a habit to track, completed automatically from matching
+// journal entries when any match criteria are given
+func (js *JournalService) CreateHabit(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil || strings.TrimSpace(name) == "" {
+		return mcp.NewToolResultError("name is required"), nil
+	}
+	habit := &Habit{ID: slugify(name, 40), Name: strings.TrimSpace(name), Created: time.Now(), Updated: time.Now()}
+	if habit.ID == "" {
+		return mcp.NewToolResultError("name needs at least one letter or digit"), nil
+	}
+
+	match := &HabitMatch{
+		AnyEntry:   request.GetString("any_entry", "false") == "true",
+		EntryTypes: nonEmpty(request.GetStringSlice("entry_types", nil)),
+		TaskTypes:  nonEmpty(request.GetStringSlice("task_types", nil)),
+		Tags:       nonEmpty(request.GetStringSlice("tags", nil)),
+		Keywords:   nonEmpty(request.GetStringSlice("keywords", nil)),
+	}
+	if match.AnyEntry || len(match.EntryTypes)+len(match.TaskTypes)+len(match.Tags)+len(match.Keywords) > 0 {
+		habit.Match = match
+	}
+
+	defer lockFile(js.habitPath(habit.ID))()
+	if _, err := js.loadHabit(habit.ID); err == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Habit %s already exists", habit.ID)), nil
+	}
+	if err := js.saveHabit(habit); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save habit: %v", err)), nil
+	}
+
+	if habit.Match == nil {
+		return mcp.NewToolResultText(fmt.Sprintf("Created habit %s; mark days done with log_habit", habit.ID)), nil
+	}
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+	loc := js.location()
+	days := habitDays(habit, tasks, loc)
+	status := habitStatus(habit, days, time.Now(), loc, 1)
+	return mcp.NewToolResultText(fmt.Sprintf("Created habit %s, completed automatically by matching entries: %d past days match, current streak %s",
+		habit.ID, len(days), pluralDays(status.Current))), nil
+}
+
+// LogHabit marks a day done for a habit, or clears a day logged by hand
+func (js *JournalService) LogHabit(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ref, err := request.RequireString("habit_id")
+	if err != nil {
+		return mcp.NewToolResultError("habit_id is required"), nil
+	}
+	loc := js.location()
+	date := request.GetString("date", time.Now().In(loc).Format("2006-01-02"))
+	if validationErr := js.validateDateFormat(date, "date"); validationErr != nil {
+		return mcp.NewToolResultError(validationErr.Error()), nil
+	}
+	undo := request.GetString("undo", "false") == "true"
+
+	defer lockFile(js.habitPath(slugify(ref, 40)))()
+	habit, err := js.loadHabit(ref)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Habit not found: %s", ref)), nil
+	}
+	i, logged := slices.BinarySearch(habit.Logged, date)
+	switch {
+	case undo && !logged:
+		return mcp.NewToolResultError(fmt.Sprintf("%s was not logged for %s", date, habit.ID)), nil
+	case undo:
+		habit.Logged = slices.Delete(habit.Logged, i, i+1)
+	case !logged:
+		habit.Logged = slices.Insert(habit.Logged, i, date)
+	}
+	habit.Updated = time.Now()
+	if err := js.saveHabit(habit); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save habit: %v", err)), nil
+	}
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+	status := habitStatus(habit, habitDays(habit, tasks, loc), time.Now(), loc, 1)
+	verb := "Logged"
+	if undo {
+		verb = "Cleared"
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("%s %s for %s; current streak %s, longest %s",
+		verb, date, habit.Name, pluralDays(status.Current), pluralDays(status.Longest))), nil
+}
+
+// GetHabits reports every habit's current and longest streaks
+func (js *JournalService) GetHabits(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	weeks := defaultStreakWeeks
+	if value := request.GetString("weeks", ""); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > 52 {
+			return mcp.NewToolResultError("weeks must be between 1 and 52"), nil
+		}
+		weeks = n
+	}
+	statuses, err := js.habitStatuses(time.Now(), weeks)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load habits: %v", err)), nil
+	}
+	if statuses == nil {
+		statuses = []HabitStatus{}
+	}
+	resultJSON, _ := json.MarshalIndent(statuses, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// DeleteHabit stops tracking a habit; the journal entries that completed it are untouched
+func (js *JournalService) DeleteHabit(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ref, err := request.RequireString("habit_id")
+	if err != nil {
+		return mcp.NewToolResultError("habit_id is required"), nil
+	}
+	path := js.habitPath(slugify(ref, 40))
+	defer lockFile(path)()
+	if err := removeDataFile(path); err != nil {
+		if os.IsNotExist(err) {
+			return mcp.NewToolResultError(fmt.Sprintf("Habit not found: %s", ref)), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete habit: %v", err)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Deleted habit %s", slugify(ref, 40))), nil
+}
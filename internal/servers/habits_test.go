@@ -0,0 +1,130 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestHabitMatch(t *testing.T) {
+	task := &Task{ID: "H-1", Type: "work", Tags: []string{"Code-Review"}}
+	match := &HabitMatch{Tags: []string{"code-review"}, Keywords: []string{"PR"}}
+	for _, test := range []struct {
+		entry    Entry
+		expected bool
+	}{
+		{Entry{Content: "Reviewed two pr's"}, true},
+		{Entry{Content: "Lunch"}, false},
+		{Entry{Type: "status_change", Content: "PR status changed"}, false},
+		{Entry{Type: "deleted", Content: "PR entry deleted"}, false},
+		{Entry{Type: "review", Content: "Left PR comments"}, true},
+		{Entry{Type: "log", Content: "Task created: PR review rotation"}, false},
+		{Entry{Type: "decision", Content: "Decision: squash merge every PR"}, true},
+		{Entry{Type: "time", Content: "Logged 30m reviewing PRs", Minutes: 30}, false},
+	} {
+		if match.matches(task, test.entry) != test.expected {
+			t.Errorf("Expected matches(%q) = %v", test.entry.Content, test.expected)
+		}
+	}
+	if (&HabitMatch{EntryTypes: []string{"time"}}).matches(task, Entry{Type: "time", Content: "Logged 30m"}) != true {
+		t.Error("Expected an entry type given to match entries that are not written")
+	}
+	if (&HabitMatch{AnyEntry: true, TaskTypes: []string{"learning"}}).matches(task, Entry{Content: "Notes"}) {
+		t.Error("Expected a task type mismatch to fail")
+	}
+}
+
+func TestHabits(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	call := func(handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]interface{}) string {
+		result, _ := handler(ctx, CreateMockRequest(args))
+		text := result.Content[0].(mcp.TextContent).Text
+		if result.IsError {
+			t.Fatalf("Call failed: %s", text)
+		}
+		return text
+	}
+
+	// Reviews on each of the last three days, and one a week ago
+	loc := js.location()
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, loc)
+	createTestTask(t, js, "HB-1", "Review queue", "work")
+	task, _ := js.loadTask("HB-1")
+	for _, daysAgo := range []int{0, 1, 2, 7} {
+		task.Entries = append(task.Entries, Entry{ID: "hb" + string(rune('a'+daysAgo)), Timestamp: today.AddDate(0, 0, -daysAgo), Type: "review", Content: "Reviewed PRs"})
+	}
+	js.saveTask(task)
+
+	text := call(js.CreateHabit, map[string]interface{}{"name": "Review PRs daily", "entry_types": []interface{}{"review"}})
+	if text != "Created habit review-prs-daily, completed automatically by matching entries: 4 past days match, current streak 3 days" {
+		t.Errorf("Unexpected result: %s", text)
+	}
+	call(js.CreateHabit, map[string]interface{}{"name": "Stretch"})
+	if result, _ := js.CreateHabit(ctx, CreateMockRequest(map[string]interface{}{"name": "stretch!"})); !result.IsError {
+		t.Error("Expected a second habit with the same ID rejected")
+	}
+
+	yesterday := today.AddDate(0, 0, -1).Format("2006-01-02")
+	text = call(js.LogHabit, map[string]interface{}{"habit_id": "Stretch", "date": yesterday})
+	if !strings.Contains(text, "current streak 1 day, longest 1 day") {
+		t.Errorf("Unexpected log result: %s", text)
+	}
+	call(js.LogHabit, map[string]interface{}{"habit_id": "stretch"})
+	call(js.LogHabit, map[string]interface{}{"habit_id": "stretch"}) // logging a day twice keeps one
+	habit, _ := js.loadHabit("stretch")
+	if len(habit.Logged) != 2 || habit.Logged[0] != yesterday {
+		t.Errorf("Expected two sorted days logged, got %v", habit.Logged)
+	}
+	text = call(js.LogHabit, map[string]interface{}{"habit_id": "stretch", "undo": "true"})
+	if !strings.HasPrefix(text, "Cleared") || !strings.Contains(text, "current streak 1 day") {
+		t.Errorf("Unexpected undo result: %s", text)
+	}
+
+	var statuses []HabitStatus
+	json.Unmarshal([]byte(call(js.GetHabits, map[string]interface{}{"weeks": "2"})), &statuses)
+	if len(statuses) != 2 || statuses[0].ID != "review-prs-daily" || !statuses[0].Automatic || !statuses[0].DoneToday || statuses[0].Current != 3 || statuses[0].Longest != 3 {
+		t.Fatalf("Unexpected statuses: %+v", statuses)
+	}
+	if statuses[1].ID != "stretch" || statuses[1].Automatic || statuses[1].DoneToday || statuses[1].Current != 1 {
+		t.Errorf("Unexpected stretch status: %+v", statuses[1])
+	}
+
+	report := js.generateAnalyticsReport([]*Task{task}, "overview", "week")
+	if len(report.Habits) != 2 {
+		t.Errorf("Expected habits in the analytics report, got %+v", report.Habits)
+	}
+
+	week := js.weekStartIn(now, loc).Format("2006-01-02")
+	weekly := call(js.GetWeeklyLog, map[string]interface{}{"week_start": week})
+	if !strings.Contains(weekly, "## Habits\n- Review PRs daily: ") || !strings.Contains(weekly, "3-day streak (longest 3 days)") {
+		t.Errorf("Expected habits in the weekly log:\n%s", weekly)
+	}
+
+	call(js.DeleteHabit, map[string]interface{}{"habit_id": "Stretch"})
+	if result, _ := js.DeleteHabit(ctx, CreateMockRequest(map[string]interface{}{"habit_id": "stretch"})); !result.IsError {
+		t.Error("Expected deleting a missing habit to fail")
+	}
+}
+
+func TestHabitInsights(t *testing.T) {
+	insights := habitInsights([]HabitStatus{
+		{Name: "Journal", Current: 9, Longest: 9, LastDone: "2026-03-04", Consistency: 0.9},
+		{Name: "Stretch", Current: 0, Longest: 12, LastDone: "2026-02-01", Consistency: 0.1},
+		{Name: "Read", Current: 1, Longest: 2, LastDone: "2026-03-04", Consistency: 0.25},
+		{Name: "New", Current: 0, Longest: 0},
+	}, 8)
+	expected := []string{
+		`You have kept up "Journal" for 9 days, your longest streak yet.`,
+		`"Stretch" has lapsed; your longest streak was 12 days.`,
+		`"Read" was done on 25% of days over the last 8 weeks.`,
+	}
+	if strings.Join(insights, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected insights: %v", insights)
+	}
+}
@@ -0,0 +1,320 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// handoffListLimit caps the entries quoted in each handoff section
+const handoffListLimit = 10
+
+// Handoff records a change of a task's owner
+type Handoff struct {
+	Time    time.Time `json:"time" yaml:"time"`
+	From    string    `json:"from,omitempty" yaml:"from,omitempty"` // empty when the task was unassigned
+	To      string    `json:"to" yaml:"to"`
+	EntryID string    `json:"entry_id,omitempty" yaml:"entry_id,omitempty"` // the handoff entry holding the document
+}
+
+// HandoffDocument is what the new owner needs to pick a task up, drawn from its entries
+type HandoffDocument struct {
+	TaskID        string
+	Title         string
+	From          string
+	To            string
+	Date          string
+	Note          string
+	Context       []string
+	Decisions     []string
+	OpenQuestions []string
+	NextSteps     []string
+}
+
+// handoffQuestionTags and handoffNextTags mark entries for the open questions and next steps
+var (
+	handoffQuestionTags = []string{"question", "blocker"}
+	handoffNextTags     = []string{"next", "todo", "next-step"}
+)
+
+// handoffLine quotes an entry by date, on one line
+func handoffLine(entry Entry, loc *time.Location) string {
+	text := strings.Join(strings.Fields(entry.Content), " ")
+	if runes := []rune(text); len(runes) > 200 {
+		text = string(runes[:199]) + "…"
+	}
+	return fmt.Sprintf("%s: %s", entry.Timestamp.In(loc).Format("2006-01-02"), text)
+}
+
+// isNextStepLine reports whether a line of an entry reads as a next step
+func isNextStepLine(line string) bool {
+	lower := strings.ToLower(strings.TrimSpace(line))
+	for _, prefix := range []string{"next:", "next step:", "next steps:", "todo:", "- [ ]", "* [ ]"} {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// latest keeps the last n lines, the most recent of what a section collected
+func latest(lines []string, n int) []string {
+	if len(lines) > n {
+		return lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// handoffDocument summarizes a task for its new owner: where it stands and
+// what was written lately, the decisions made, the questions and blockers
+// still open, and the next steps from entries, the checklist and subtasks
+func (js *JournalService) handoffDocument(task *Task, from, to, note string, now time.Time) HandoffDocument {
+	loc := js.location()
+	doc := HandoffDocument{TaskID: task.ID, Title: task.Title, From: from, To: to, Date: now.In(loc).Format("2006-01-02"), Note: note}
+
+	state := []string{"status " + task.Status}
+	if task.Priority != "" {
+		state = append(state, "priority "+task.Priority)
+	}
+	if task.DueDate != "" {
+		state = append(state, "due "+task.DueDate)
+	}
+	if task.EstimateMinutes > 0 {
+		state = append(state, "estimate "+formatEstimate(task.EstimateMinutes))
+	}
+	doc.Context = append(doc.Context, "State: "+strings.Join(state, ", "))
+	if task.IssueURL != "" {
+		doc.Context = append(doc.Context, "Issue: "+task.IssueURL)
+	}
+	if len(task.Tags) > 0 {
+		doc.Context = append(doc.Context, "Tags: "+strings.Join(task.Tags, ", "))
+	}
+
+	var recent []string
+	for _, entry := range task.Entries {
+		if !isWrittenEntry(entry) {
+			continue
+		}
+		recent = append(recent, handoffLine(entry, loc))
+		if entry.Type == "decision" || slices.Contains(entry.Tags, "decision") {
+			doc.Decisions = append(doc.Decisions, handoffLine(entry, loc))
+		}
+		if entryHasTag(entry, handoffQuestionTags) {
+			doc.OpenQuestions = append(doc.OpenQuestions, handoffLine(entry, loc))
+		} else {
+			for _, line := range strings.Split(entry.Content, "\n") {
+				if line = strings.TrimSpace(line); strings.HasSuffix(line, "?") {
+					doc.OpenQuestions = append(doc.OpenQuestions, handoffLine(Entry{Timestamp: entry.Timestamp, Content: line}, loc))
+				}
+			}
+		}
+		if entryHasTag(entry, handoffNextTags) {
+			doc.NextSteps = append(doc.NextSteps, handoffLine(entry, loc))
+			continue
+		}
+		for _, line := range strings.Split(entry.Content, "\n") {
+			if isNextStepLine(line) {
+				doc.NextSteps = append(doc.NextSteps, handoffLine(Entry{Timestamp: entry.Timestamp, Content: line}, loc))
+			}
+		}
+	}
+	doc.Context = append(doc.Context, latest(recent, 5)...)
+	doc.Decisions = latest(doc.Decisions, handoffListLimit)
+	doc.OpenQuestions = latest(doc.OpenQuestions, handoffListLimit)
+	doc.NextSteps = latest(doc.NextSteps, handoffListLimit)
+
+	for _, dep := range js.openDependencies(task) {
+		doc.OpenQuestions = append(doc.OpenQuestions, fmt.Sprintf("Waiting on %s: %s (%s)", dep.ID, dep.Title, dep.Status))
+	}
+	for _, item := range task.Checklist {
+		if !item.Done {
+			doc.NextSteps = append(doc.NextSteps, "Checklist: "+item.Text)
+		}
+	}
+	if tasks, err := js.loadAllTasks(); err == nil {
+		for _, child := range childrenByParent(tasks)[task.ID] {
+			if child.Status != "completed" {
+				doc.NextSteps = append(doc.NextSteps, fmt.Sprintf("Subtask %s: %s (%s)", child.ID, child.Title, child.Status))
+			}
+		}
+	}
+	return doc
+}
+
+// handoffHeadline names the change of owner, e.g. "Handed off from Ana to Ben"
+func handoffHeadline(from, to string) string {
+	if from == "" {
+		return "Handed off to " + to
+	}
+	return fmt.Sprintf("Handed off from %s to %s", from, to)
+}
+
+// render writes the document's sections; the headline and note come first
+func (doc HandoffDocument) render() string {
+	var md strings.Builder
+	md.WriteString(handoffHeadline(doc.From, doc.To) + " on " + doc.Date)
+	if doc.Note != "" {
+		md.WriteString(": " + doc.Note)
+	}
+	md.WriteString("\n")
+	for _, section := range []struct {
+		heading string
+		lines   []string
+	}{
+		{"Context", doc.Context},
+		{"Decisions", doc.Decisions},
+		{"Open questions", doc.OpenQuestions},
+		{"Next steps", doc.NextSteps},
+	} {
+		md.WriteString(fmt.Sprintf("\n### %s\n", section.heading))
+		if len(section.lines) == 0 {
+			md.WriteString("- None\n")
+		}
+		for _, line := range section.lines {
+			md.WriteString("- " + line + "\n")
+		}
+	}
+	return md.String()
+}
+
+// teamMemberChannels returns the digest channels configured for a team member
+func teamMemberChannels(config *Configuration, name string) []DigestChannel {
+	var channels []DigestChannel
+	for _, member := range config.Team.Members {
+		if member.Name != name {
+			continue
+		}
+		for _, channel := range config.Digest.Channels {
+			if slices.Contains(member.Channels, channel.Name) {
+				channels = append(channels, channel)
+			}
+		}
+	}
+	return channels
+}
+
+// HandoffTask reassigns a task to a team member with a handoff document drawn
+// from its entries. The document is recorded as a "handoff" entry, the change
+// of owner in the task's handoffs (shown on both people's 1-on-1 agendas), and
+// the new owner is notified on their team.members channels.
+func (js *JournalService) HandoffTask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError("task_id is required"), nil
+	}
+	to, err := request.RequireString("to")
+	if err != nil {
+		return mcp.NewToolResultError("to is required"), nil
+	}
+	note := strings.TrimSpace(request.GetString("note", ""))
+	dryRun := request.GetString("dry_run", "false") == "true"
+
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load configuration: %v", err)), nil
+	}
+	to = teamMemberName(config, to)
+	if to == "" {
+		return mcp.NewToolResultError("to is required"), nil
+	}
+	if len(config.Team.Members) > 0 && !slices.ContainsFunc(config.Team.Members, func(member TeamMember) bool { return member.Name == to }) {
+		return mcp.NewToolResultError(fmt.Sprintf("%s is not in team.members", to)), nil
+	}
+
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load task: %v", err)), nil
+	}
+	if task.Status == "completed" {
+		return mcp.NewToolResultError(fmt.Sprintf("Task %s is completed; reopen it before handing it off", taskID)), nil
+	}
+	from := teamMemberName(config, request.GetString("from", task.Assignee))
+	if strings.EqualFold(from, to) {
+		return mcp.NewToolResultError(fmt.Sprintf("Task %s is already owned by %s", taskID, to)), nil
+	}
+
+	now := time.Now()
+	doc := js.handoffDocument(task, from, to, note, now)
+	document := doc.render()
+	title := fmt.Sprintf("Handoff: %s %s", task.ID, task.Title)
+	if dryRun {
+		return mcp.NewToolResultText(fmt.Sprintf("# %s (dry run)\n\n%s", title, document)), nil
+	}
+
+	var messages []string
+	// Time from here on is the new owner's
+	if task.TimerStarted != nil {
+		entry := js.stopTaskTimer(task, now, "")
+		js.updateDailyLog(task.ID, entry)
+		messages = append(messages, fmt.Sprintf("Stopped the timer (%s)", formatMinutes(entry.Minutes)))
+	}
+
+	entry := Entry{
+		ID:        generateEntryID(),
+		Timestamp: now,
+		Content:   document,
+		Type:      "handoff",
+	}
+	task.Entries = append(task.Entries, entry)
+	task.Handoffs = append(task.Handoffs, Handoff{Time: now, From: from, To: to, EntryID: entry.ID})
+	task.Assignee = to
+	task.Updated = now
+	if err := js.saveTask(task); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task: %v", err)), nil
+	}
+	js.updateDailyLog(task.ID, entry)
+
+	headline := fmt.Sprintf("%s: %s", task.ID, handoffHeadline(from, to))
+	js.notify("handoff", task.ID, headline)
+	for _, channel := range teamMemberChannels(config, to) {
+		if err := js.deliverDigest(channel, title, fmt.Sprintf("%s\n\n%s", title, document)); err != nil {
+			messages = append(messages, fmt.Sprintf("Failed to notify %s on %s: %v", to, channel.Name, err))
+			js.notify("handoff", task.ID, fmt.Sprintf("Could not notify %s of the handoff of %s on %s: %v", to, task.ID, channel.Name, err))
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("Notified %s on %s", to, channel.Name))
+	}
+
+	result := fmt.Sprintf("# %s\n\n%s", title, document)
+	if len(messages) > 0 {
+		result += "\n" + strings.Join(messages, "\n") + "\n"
+	}
+	return mcp.NewToolResultText(result), nil
+}
+
+// agendaHandoffs lists the handoffs to or from person between since and until, for their 1-on-1 agenda
+func agendaHandoffs(tasks []*Task, person string, since, until time.Time) []string {
+	type line struct {
+		at   time.Time
+		text string
+	}
+	var found []line
+	for _, task := range tasks {
+		for _, handoff := range task.Handoffs {
+			if handoff.Time.Before(since) || !handoff.Time.Before(until) {
+				continue
+			}
+			date := handoff.Time.Format("2006-01-02")
+			switch {
+			case strings.EqualFold(handoff.To, person) && handoff.From != "":
+				found = append(found, line{handoff.Time, fmt.Sprintf("Picked up %s: %s from %s (%s)", task.ID, task.Title, handoff.From, date)})
+			case strings.EqualFold(handoff.To, person):
+				found = append(found, line{handoff.Time, fmt.Sprintf("Picked up %s: %s (%s)", task.ID, task.Title, date)})
+			case strings.EqualFold(handoff.From, person):
+				found = append(found, line{handoff.Time, fmt.Sprintf("Handed off %s: %s to %s (%s)", task.ID, task.Title, handoff.To, date)})
+			}
+		}
+	}
+	slices.SortStableFunc(found, func(a, b line) int { return a.at.Compare(b.at) })
+	lines := make([]string, 0, len(found))
+	for _, f := range found {
+		lines = append(lines, f.text)
+	}
+	return lines
+}
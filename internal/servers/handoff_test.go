@@ -0,0 +1,134 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestHandoffTask(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	var posted []string
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &payload)
+		posted = append(posted, payload["text"])
+	}))
+	defer webhook.Close()
+
+	config := defaultConfiguration()
+	config.General.TimeZone = "UTC"
+	config.Digest.Channels = []DigestChannel{{Name: "ben-dm", Type: "slack", WebhookURL: webhook.URL}}
+	config.Team.Members = []TeamMember{
+		{Name: "Ana", Aliases: []string{"@ana"}},
+		{Name: "Ben", Aliases: []string{"@ben"}, Channels: []string{"ben-dm"}},
+	}
+	if err := js.saveConfiguration(config); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	createTestTask(t, js, "HO-1", "Migrate billing webhooks", "work")
+	createTestTask(t, js, "HO-2", "Rotate the signing secret", "work")
+	createTestTask(t, js, "HO-3", "Update the webhook docs", "work")
+	add := func(content string, tags ...interface{}) {
+		args := map[string]interface{}{"task_id": "HO-1", "content": content}
+		if len(tags) > 0 {
+			args["tags"] = tags
+		}
+		js.AddTaskEntry(ctx, CreateMockRequest(args))
+	}
+	add("Keeping the v1 endpoint alive until March #decision")
+	add("Do retries need idempotency keys?\nNext: replay the failed events from staging")
+	add("Waiting on finance to confirm the cutover date", "blocker")
+
+	task, _ := js.loadTask("HO-1")
+	task.Assignee = "Ana"
+	task.DependsOn = []string{"HO-2"}
+	task.Checklist = []ChecklistItem{{Text: "Dual-write events", Done: true}, {Text: "Switch the consumers"}}
+	now := time.Now()
+	task.TimerStarted = &now
+	js.saveTask(task)
+	child, _ := js.loadTask("HO-3")
+	child.ParentID = "HO-1"
+	js.saveTask(child)
+
+	text := func(result *mcp.CallToolResult) string {
+		t.Helper()
+		if result.IsError {
+			t.Fatalf("Tool failed: %v", result.Content)
+		}
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	result, _ := js.HandoffTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "HO-1", "to": "@ben", "dry_run": "true"}))
+	preview := text(result)
+	for _, want := range []string{
+		"Handed off from Ana to Ben",
+		"### Decisions\n- " + now.UTC().Format("2006-01-02") + ": Keeping the v1 endpoint alive until March #decision",
+		"Do retries need idempotency keys?",
+		"Waiting on finance to confirm the cutover date",
+		"Waiting on HO-2: Rotate the signing secret (active)",
+		"Next: replay the failed events from staging",
+		"Checklist: Switch the consumers",
+		"Subtask HO-3: Update the webhook docs (active)",
+	} {
+		if !strings.Contains(preview, want) {
+			t.Errorf("Expected %q in the document:\n%s", want, preview)
+		}
+	}
+	if strings.Contains(preview, "Dual-write") {
+		t.Errorf("Expected done checklist items left out:\n%s", preview)
+	}
+	if task, _ := js.loadTask("HO-1"); task.Assignee != "Ana" || len(posted) != 0 {
+		t.Error("Expected a dry run to change nothing")
+	}
+
+	if result, _ := js.HandoffTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "HO-1", "to": "Cleo"})); !result.IsError {
+		t.Error("Expected a handoff to someone outside team.members refused")
+	}
+	if result, _ := js.HandoffTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "HO-1", "to": "ana"})); !result.IsError {
+		t.Error("Expected a handoff to the current owner refused")
+	}
+
+	result, _ = js.HandoffTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "HO-1", "to": "Ben", "note": "ping me about finance"}))
+	if done := text(result); !strings.Contains(done, "Notified Ben on ben-dm") || !strings.Contains(done, "Stopped the timer") {
+		t.Errorf("Unexpected result:\n%s", done)
+	}
+	task, _ = js.loadTask("HO-1")
+	last := task.Entries[len(task.Entries)-1]
+	if task.Assignee != "Ben" || task.TimerStarted != nil || last.Type != "handoff" || !strings.Contains(last.Content, "ping me about finance") {
+		t.Errorf("Expected the task reassigned with a handoff entry, got assignee %s, entry %+v", task.Assignee, last)
+	}
+	if len(task.Handoffs) != 1 || task.Handoffs[0].From != "Ana" || task.Handoffs[0].To != "Ben" || task.Handoffs[0].EntryID != last.ID {
+		t.Errorf("Unexpected handoffs: %+v", task.Handoffs)
+	}
+	if len(posted) != 1 || !strings.Contains(posted[0], "Handoff: HO-1 Migrate billing webhooks") {
+		t.Errorf("Expected the document posted to Ben's channel, got %v", posted)
+	}
+	if notifications, _ := js.loadNotifications(); len(notifications) != 1 || notifications[0].Kind != "handoff" {
+		t.Errorf("Expected a handoff notification, got %+v", notifications)
+	}
+
+	// Both sides see it on their next 1-on-1 agenda
+	for person, want := range map[string]string{"Ana": "Handed off HO-1: Migrate billing webhooks to Ben", "Ben": "Picked up HO-1: Migrate billing webhooks from Ana"} {
+		result, _ := js.BuildOneOnOneAgenda(ctx, CreateMockRequest(map[string]interface{}{"person": person}))
+		if agenda := text(result); !strings.Contains(agenda, want) {
+			t.Errorf("Expected %q on %s's agenda:\n%s", want, person, agenda)
+		}
+	}
+
+	config.Team.Members[1].Channels = []string{"missing"}
+	if err := js.validateConfiguration(config); err == nil {
+		t.Error("Expected an unknown member channel rejected")
+	}
+}
@@ -13,6 +13,7 @@ import (
 func CreateTestJournalService(t *testing.T) (*JournalService, string) {
 	// Create temporary directory
 	tempDir := t.TempDir()
+	t.Cleanup(flushSearchIndex) // before the directory is removed
 
 	// Create subdirectories
 	os.MkdirAll(filepath.Join(tempDir, "tasks"), 0755)
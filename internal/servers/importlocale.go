@@ -0,0 +1,362 @@
+package servers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// errNoImportContent is returned when none of content, file_path and
+// content_base64 was passed
+var errNoImportContent = errors.New("content is required")
+
+// windows1252 maps the bytes 0x80-0x9F, where Windows-1252 differs from
+// Latin-1; 0 marks the five bytes it leaves undefined
+var windows1252 = [32]rune{
+	'€', 0, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0, 'Ž', 0,
+	0, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0, 'ž', 'Ÿ',
+}
+
+// importContent returns the text to import from content, file_path or
+// content_base64, and a note when file bytes had to be converted
+func importContent(request mcp.CallToolRequest) (string, string, error) {
+	content := request.GetString("content", "")
+	filePath := request.GetString("file_path", "")
+	encoded := request.GetString("content_base64", "")
+
+	var data []byte
+	switch {
+	case content != "" && filePath == "" && encoded == "":
+		return strings.TrimPrefix(content, "\ufeff"), "", nil
+	case content == "" && filePath == "" && encoded == "":
+		return "", "", errNoImportContent
+	case filePath != "" && content == "" && encoded == "":
+		var err error
+		if data, err = os.ReadFile(filePath); err != nil {
+			return "", "", fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+	case encoded != "" && content == "" && filePath == "":
+		var err error
+		if data, err = base64.StdEncoding.DecodeString(encoded); err != nil {
+			return "", "", fmt.Errorf("invalid content_base64: %w", err)
+		}
+	default:
+		return "", "", errors.New("pass only one of content, file_path and content_base64")
+	}
+	text, note := decodeImportBytes(data)
+	return text, note, nil
+}
+
+// decodeImportBytes turns file bytes into text. It reads UTF-8 with or
+// without a byte order mark, and UTF-16, which Excel writes for "Unicode
+// Text". Anything else is read as Windows-1252, Excel's CSV encoding on
+// Windows, and the note says so.
+func decodeImportBytes(data []byte) (string, string) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		data = data[3:]
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return decodeUTF16(data[2:], binary.LittleEndian), "Converted from UTF-16"
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return decodeUTF16(data[2:], binary.BigEndian), "Converted from UTF-16"
+	default:
+		if order := guessUTF16(data); order != nil {
+			return decodeUTF16(data, order), "Converted from UTF-16"
+		}
+	}
+	if utf8.Valid(data) {
+		return string(data), ""
+	}
+
+	var text strings.Builder
+	for _, b := range data {
+		r := rune(b)
+		if b >= 0x80 && b < 0xA0 {
+			if r = windows1252[b-0x80]; r == 0 {
+				r = utf8.RuneError
+			}
+		}
+		text.WriteRune(r)
+	}
+	return text.String(), "The file is not UTF-8, so it was read as Windows-1252; export it as \"CSV UTF-8\" if characters look wrong"
+}
+
+// guessUTF16 spots UTF-16 without a byte order mark from its zero bytes:
+// mostly-ASCII text has a zero high byte in nearly every pair
+func guessUTF16(data []byte) binary.ByteOrder {
+	if len(data) < 4 || len(data)%2 != 0 {
+		return nil
+	}
+	var evenZeros, oddZeros int
+	for i := 0; i < len(data); i += 2 {
+		if data[i] == 0 {
+			evenZeros++
+		}
+		if data[i+1] == 0 {
+			oddZeros++
+		}
+	}
+	pairs := len(data) / 2
+	switch {
+	case oddZeros*2 > pairs && evenZeros == 0:
+		return binary.LittleEndian
+	case evenZeros*2 > pairs && oddZeros == 0:
+		return binary.BigEndian
+	}
+	return nil
+}
+
+func decodeUTF16(data []byte, order binary.ByteOrder) string {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[2*i:])
+	}
+	return string(utf16.Decode(units))
+}
+
+// csvDelimiters are the separators sniffCSVDelimiter chooses between
+var csvDelimiters = []rune{',', ';', '\t', '|'}
+
+// parseCSVDelimiter reads the delimiter parameter: a single character, or tab
+func parseCSVDelimiter(value string) (rune, error) {
+	if strings.EqualFold(value, "tab") || value == `\t` {
+		return '\t', nil
+	}
+	if r, size := utf8.DecodeRuneInString(value); size == len(value) && size > 0 && r != '"' && r != '\n' && r != '\r' {
+		return r, nil
+	}
+	return 0, fmt.Errorf("invalid delimiter %q: use a single character such as ; or tab", value)
+}
+
+// sniffCSVDelimiter picks the separator that splits the first lines into
+// the same number of fields, preferring the one giving the most fields.
+// Excel's "sep=;" first line settles it and is dropped.
+func sniffCSVDelimiter(content string) (rune, string) {
+	firstLine, rest, _ := strings.Cut(content, "\n")
+	if hint := strings.TrimSpace(firstLine); strings.HasPrefix(strings.ToLower(hint), "sep=") {
+		if r, err := parseCSVDelimiter(hint[len("sep="):]); err == nil {
+			return r, rest
+		}
+	}
+
+	var sample []string
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) != "" {
+			sample = append(sample, line)
+		}
+		if len(sample) == 10 {
+			break
+		}
+	}
+
+	best, bestCount := ',', 0
+	for _, delimiter := range csvDelimiters {
+		count := -1
+		for _, line := range sample {
+			n := countOutsideQuotes(line, delimiter)
+			if count == -1 {
+				count = n
+			} else if n != count {
+				count = 0 // inconsistent rows rule it out
+				break
+			}
+		}
+		if count > bestCount {
+			best, bestCount = delimiter, count
+		}
+	}
+	return best, content
+}
+
+// countOutsideQuotes counts a separator outside double-quoted fields
+func countOutsideQuotes(line string, separator rune) int {
+	count := 0
+	inQuotes := false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == separator && !inQuotes:
+			count++
+		}
+	}
+	return count
+}
+
+// numericDate matches day, month and year in either order, or year first,
+// with /, . or - between them and an optional time after
+var numericDate = regexp.MustCompile(`^(\d{1,4})[/.\-](\d{1,2})[/.\-](\d{1,4})(?:[ T]+(.+))?$`)
+
+var importTimeLayouts = []string{"15:04", "15:04:05", "3:04 PM", "3:04:05 PM", "3:04PM", "3:04pm", "3:04 pm"}
+
+var isoImportLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// parseImportDate reads a date from an imported file. ISO dates are read as
+// they are; others are read day first for dmy and month first for mdy.
+// Times without a zone are in loc.
+func parseImportDate(text, order string, loc *time.Location) (time.Time, bool) {
+	text = strings.TrimSpace(text)
+	for _, layout := range isoImportLayouts {
+		if parsed, err := time.ParseInLocation(layout, text, loc); err == nil {
+			return parsed, true
+		}
+	}
+
+	match := numericDate.FindStringSubmatch(text)
+	if match == nil {
+		return time.Time{}, false
+	}
+	first, _ := strconv.Atoi(match[1])
+	second, _ := strconv.Atoi(match[2])
+	third, _ := strconv.Atoi(match[3])
+
+	var year, month, day int
+	switch {
+	case len(match[1]) == 4:
+		year, month, day = first, second, third
+	case len(match[3]) == 4 || len(match[3]) == 2:
+		year = third
+		if len(match[3]) == 2 {
+			year += 2000
+			if third >= 70 {
+				year -= 100
+			}
+		}
+		if order == "dmy" {
+			day, month = first, second
+		} else {
+			month, day = first, second
+		}
+	default:
+		return time.Time{}, false
+	}
+
+	var clock time.Time
+	if match[4] != "" {
+		parsed := false
+		for _, layout := range importTimeLayouts {
+			if t, err := time.Parse(layout, strings.TrimSpace(match[4])); err == nil {
+				clock, parsed = t, true
+				break
+			}
+		}
+		if !parsed {
+			return time.Time{}, false
+		}
+	}
+
+	date := time.Date(year, time.Month(month), day, clock.Hour(), clock.Minute(), clock.Second(), 0, loc)
+	if date.Day() != day || int(date.Month()) != month { // e.g. 31/02 rolled over
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// detectDateOrder decides whether numeric dates are day or month first from
+// values that can only be one of them, such as 25/03/2025. It returns "" when
+// the values do not settle it, and conflict when some can only be day first
+// and others only month first.
+func detectDateOrder(values []string) (order string, conflict bool) {
+	dayFirst, monthFirst := false, false
+	for _, value := range values {
+		match := numericDate.FindStringSubmatch(strings.TrimSpace(value))
+		if match == nil || len(match[1]) == 4 {
+			continue
+		}
+		first, _ := strconv.Atoi(match[1])
+		second, _ := strconv.Atoi(match[2])
+		if first > 12 && second <= 12 {
+			dayFirst = true
+		}
+		if second > 12 && first <= 12 {
+			monthFirst = true
+		}
+	}
+	switch {
+	case dayFirst && monthFirst:
+		return "", true
+	case dayFirst:
+		return "dmy", false
+	case monthFirst:
+		return "mdy", false
+	}
+	return "", false
+}
+
+// ambiguousDates reports whether any value is a numeric date that reads
+// differently day first and month first, such as 03/04/2025
+func ambiguousDates(values []string) bool {
+	for _, value := range values {
+		match := numericDate.FindStringSubmatch(strings.TrimSpace(value))
+		if match == nil || len(match[1]) == 4 {
+			continue
+		}
+		first, _ := strconv.Atoi(match[1])
+		second, _ := strconv.Atoi(match[2])
+		if first != second && first <= 12 && second <= 12 {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveDateOrder settles the order for a file's dates: dmy or mdy as
+// configured, or, for auto, what the values show, falling back to month
+// first. The warning explains a fallback that could be wrong.
+func resolveDateOrder(configured string, values []string) (string, string) {
+	if configured == "dmy" || configured == "mdy" {
+		return configured, ""
+	}
+	order, conflict := detectDateOrder(values)
+	switch {
+	case conflict:
+		return "mdy", "Dates disagree on day/month order (some can only be day first, others only month first); read as month first. Pass date_order to choose"
+	case order != "":
+		return order, ""
+	case ambiguousDates(values):
+		return "mdy", "Dates such as 03/04 could be day or month first; read as month first. Pass date_order=dmy if they are day first"
+	}
+	return "mdy", ""
+}
+
+// importDateOrder is the date_order parameter, else import.date_order in
+// config, else auto
+func (js *JournalService) importDateOrder(request mcp.CallToolRequest) (string, error) {
+	order := request.GetString("date_order", "")
+	if order == "" {
+		if config, err := js.loadConfiguration(); err == nil {
+			order = config.Import.DateOrder
+		}
+	}
+	switch order {
+	case "":
+		return "auto", nil
+	case "auto", "dmy", "mdy":
+		return order, nil
+	}
+	return "", fmt.Errorf("invalid date_order: %s (expected auto, dmy or mdy)", order)
+}
+
+// importSourceName is the file name recorded for an import: source, else the
+// name of file_path
+func importSourceName(request mcp.CallToolRequest) string {
+	if source := request.GetString("source", ""); source != "" {
+		return source
+	}
+	if filePath := request.GetString("file_path", ""); filePath != "" {
+		return filepath.Base(filePath)
+	}
+	return ""
+}
@@ -0,0 +1,181 @@
+package servers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// utf16LE encodes text as Excel's "Unicode Text" does, with a byte order mark
+func utf16LE(text string) []byte {
+	data := []byte{0xFF, 0xFE}
+	for _, unit := range utf16.Encode([]rune(text)) {
+		data = append(data, byte(unit), byte(unit>>8))
+	}
+	return data
+}
+
+func TestDecodeImportBytes(t *testing.T) {
+	cases := []struct {
+		name, expected string
+		data           []byte
+		note           bool
+	}{
+		{"utf-8 bom", "Café", append([]byte{0xEF, 0xBB, 0xBF}, "Café"...), false},
+		{"utf-8", "Café", []byte("Café"), false},
+		{"utf-16le", "Café;Zoë", utf16LE("Café;Zoë"), true},
+		{"utf-16be", "Hé", []byte{0xFE, 0xFF, 0x00, 'H', 0x00, 0xE9}, true},
+		{"utf-16le without bom", "date", []byte{'d', 0, 'a', 0, 't', 0, 'e', 0}, true},
+		{"windows-1252", "Café – “ok”", []byte{'C', 'a', 'f', 0xE9, ' ', 0x96, ' ', 0x93, 'o', 'k', 0x94}, true},
+	}
+	for _, c := range cases {
+		text, note := decodeImportBytes(c.data)
+		if text != c.expected || (note != "") != c.note {
+			t.Errorf("%s: expected %q (note %v), got %q (note %q)", c.name, c.expected, c.note, text, note)
+		}
+	}
+}
+
+func TestSniffCSVDelimiter(t *testing.T) {
+	cases := []struct {
+		name, content string
+		expected      rune
+	}{
+		{"comma", "date,content\n2025-01-02,Hello\n", ','},
+		{"semicolon with decimal commas", "date;content;hours\n25/03/2025;Review, part 1;1,5\n26/03/2025;Review;2\n", ';'},
+		{"tab", "date\tcontent\n2025-01-02\tHello, world\n", '\t'},
+		{"quoted separators", "date|content\n2025-01-02|\"a;b;c\"\n", '|'},
+		{"excel hint", "sep=;\ndate,content;x\n", ';'},
+	}
+	for _, c := range cases {
+		delimiter, rest := sniffCSVDelimiter(c.content)
+		if delimiter != c.expected {
+			t.Errorf("%s: expected %q, got %q", c.name, c.expected, delimiter)
+		}
+		if c.name == "excel hint" && rest != "date,content;x\n" {
+			t.Errorf("Expected the sep= line dropped, got %q", rest)
+		}
+	}
+
+	if _, err := parseCSVDelimiter("tab"); err != nil {
+		t.Errorf("Expected tab accepted, got %v", err)
+	}
+	if _, err := parseCSVDelimiter(";;"); err == nil {
+		t.Error("Expected more than one character rejected")
+	}
+}
+
+func TestParseImportDate(t *testing.T) {
+	cases := []struct {
+		text, order string
+		expected    time.Time
+		ok          bool
+	}{
+		{"03/04/2025", "dmy", time.Date(2025, 4, 3, 0, 0, 0, 0, time.UTC), true},
+		{"03/04/2025", "mdy", time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), true},
+		{"25.03.2025 14:30", "dmy", time.Date(2025, 3, 25, 14, 30, 0, 0, time.UTC), true},
+		{"3/25/25 2:05 PM", "mdy", time.Date(2025, 3, 25, 14, 5, 0, 0, time.UTC), true},
+		{"2025/03/04", "dmy", time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), true},
+		{"2025-03-04 09:15", "dmy", time.Date(2025, 3, 4, 9, 15, 0, 0, time.UTC), true},
+		{"01/02/99", "dmy", time.Date(1999, 2, 1, 0, 0, 0, 0, time.UTC), true},
+		{"25/03/2025", "mdy", time.Time{}, false}, // no 25th month
+		{"31/02/2025", "dmy", time.Time{}, false},
+		{"next Tuesday", "dmy", time.Time{}, false},
+	}
+	for _, c := range cases {
+		parsed, ok := parseImportDate(c.text, c.order, time.UTC)
+		if ok != c.ok || !parsed.Equal(c.expected) {
+			t.Errorf("%s (%s): expected %v %v, got %v %v", c.text, c.order, c.expected, c.ok, parsed, ok)
+		}
+	}
+}
+
+func TestResolveDateOrder(t *testing.T) {
+	cases := []struct {
+		name, configured string
+		values           []string
+		expected         string
+		warning          bool
+	}{
+		{"configured", "dmy", []string{"12/25/2025"}, "dmy", false},
+		{"day first", "auto", []string{"03/04/2025", "25/04/2025"}, "dmy", false},
+		{"month first", "auto", []string{"03/04/2025", "04/25/2025"}, "mdy", false},
+		{"ambiguous", "auto", []string{"03/04/2025", "05/06/2025"}, "mdy", true},
+		{"conflict", "auto", []string{"25/04/2025", "04/25/2025"}, "mdy", true},
+		{"unambiguous", "auto", []string{"2025-03-04", "01/01/2025"}, "mdy", false},
+	}
+	for _, c := range cases {
+		order, warning := resolveDateOrder(c.configured, c.values)
+		if order != c.expected || (warning != "") != c.warning {
+			t.Errorf("%s: expected %s (warning %v), got %s %q", c.name, c.expected, c.warning, order, warning)
+		}
+	}
+}
+
+func TestImportLocaleCSV(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	importCSV := func(args map[string]interface{}) (ImportResult, *mcp.CallToolResult) {
+		args["format"] = "csv"
+		args["task_prefix"] = "XL"
+		result, _ := js.ImportData(ctx, CreateMockRequest(args))
+		var imported ImportResult
+		json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &imported)
+		return imported, result
+	}
+
+	// A German Excel export saved as Unicode Text: semicolons, day-first dates
+	csv := "Title;Date;Notes\nReview;03/04/2025 09:30;Überarbeitet\nReview;25/04/2025;\"Fertig; endlich\"\n"
+	imported, _ := importCSV(map[string]interface{}{"content_base64": base64.StdEncoding.EncodeToString(utf16LE(csv))})
+	if imported.TasksCreated != 1 || imported.EntriesAdded != 2 {
+		t.Fatalf("Expected one task with two entries, got %+v", imported)
+	}
+	task, err := js.loadTask("XL-Review")
+	if err != nil {
+		t.Fatalf("Expected the task imported: %v", err)
+	}
+	first := task.Entries[len(task.Entries)-2]
+	if first.Content != "Überarbeitet" || first.Timestamp.Month() != time.April || first.Timestamp.Day() != 3 || first.Timestamp.Hour() != 9 {
+		t.Errorf("Expected 3 April read day first, got %+v", first)
+	}
+	if last := task.Entries[len(task.Entries)-1]; last.Content != "Fertig; endlich" {
+		t.Errorf("Expected the quoted delimiter kept, got %q", last.Content)
+	}
+	if len(imported.Warnings) == 0 || imported.Warnings[0] != "Converted from UTF-16" {
+		t.Errorf("Expected the conversion noted, got %v", imported.Warnings)
+	}
+
+	// Ambiguous dates warn; config settles them
+	path := filepath.Join(tempDir, "export.csv")
+	os.WriteFile(path, []byte("title,date,content\nPlan,03/04/2025,Draft\n"), 0644)
+	imported, _ = importCSV(map[string]interface{}{"file_path": path})
+	if len(imported.Warnings) != 1 {
+		t.Errorf("Expected a warning about the guessed order, got %v", imported.Warnings)
+	}
+	config, _ := js.loadConfiguration()
+	config.Import.DateOrder = "dmy"
+	js.saveConfiguration(config)
+	imported, _ = importCSV(map[string]interface{}{"file_path": path})
+	if len(imported.Warnings) != 0 {
+		t.Errorf("Expected no warning with import.date_order set, got %v", imported.Warnings)
+	}
+	task, _ = js.loadTask("XL-Plan")
+	if entry := task.Entries[len(task.Entries)-1]; entry.Timestamp.Month() != time.April {
+		t.Errorf("Expected 3 April from import.date_order, got %v", entry.Timestamp)
+	}
+
+	if _, result := importCSV(map[string]interface{}{"content": "a,b", "file_path": path}); !result.IsError {
+		t.Error("Expected content and file_path together rejected")
+	}
+	if _, result := importCSV(map[string]interface{}{"file_path": path, "date_order": "ymd"}); !result.IsError {
+		t.Error("Expected an unknown date_order rejected")
+	}
+}
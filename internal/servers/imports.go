@@ -0,0 +1,263 @@
+package servers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ImportProvenance records where an imported task came from
+type ImportProvenance struct {
+	JobID      string    `json:"job_id" yaml:"job_id"`
+	Format     string    `json:"format" yaml:"format"`
+	Source     string    `json:"source,omitempty" yaml:"source,omitempty"` // original file name, if known
+	SourceHash string    `json:"source_hash" yaml:"source_hash"`           // sha256 of the imported content
+	ImportedAt time.Time `json:"imported_at" yaml:"imported_at"`
+}
+
+// ImportJob is one import_data run, kept in imports/ so it can be undone. An
+// import that overwrote an existing task keeps the task's earlier version.
+type ImportJob struct {
+	ImportProvenance
+	Tasks    []string         `json:"tasks"`
+	Replaced map[string]*Task `json:"replaced,omitempty"` // task ID -> version before the import
+	UndoneAt *time.Time       `json:"undone_at,omitempty"`
+}
+
+// UndoImportResult reports what undo_import did, or would do with dry_run
+type UndoImportResult struct {
+	JobID    string   `json:"job_id"`
+	DryRun   bool     `json:"dry_run,omitempty"`
+	Removed  []string `json:"removed"`            // tasks moved to trash
+	Restored []string `json:"restored,omitempty"` // tasks put back to their version before the import
+	Kept     []string `json:"kept,omitempty"`     // tasks with entries added since, kept without the imported entries
+	Missing  []string `json:"missing,omitempty"`  // tasks already gone
+	Entries  int      `json:"entries_removed"`
+	Summary  string   `json:"summary"`
+}
+
+func (js *JournalService) importsDir() string {
+	return filepath.Join(js.dataDir(), "imports")
+}
+
+// newImportJob starts a job for content imported in format
+func newImportJob(format, source, content string) *ImportJob {
+	sum := sha256.Sum256([]byte(content))
+	now := time.Now()
+	hash := hex.EncodeToString(sum[:])
+	return &ImportJob{
+		ImportProvenance: ImportProvenance{
+			JobID:      fmt.Sprintf("import_%s_%s", now.Format("20060102-150405"), hash[:8]),
+			Format:     format,
+			Source:     source,
+			SourceHash: hash,
+			ImportedAt: now,
+		},
+		Replaced: make(map[string]*Task),
+	}
+}
+
+// saveImportedTask stamps a task and its new entries with the job's
+// provenance and saves it, remembering any existing task it replaces
+func (js *JournalService) saveImportedTask(task *Task, job *ImportJob) error {
+	provenance := job.ImportProvenance
+	task.Import = &provenance
+	for i := range task.Entries {
+		if task.Entries[i].ImportJob == "" {
+			task.Entries[i].ImportJob = job.JobID
+		}
+	}
+
+	if !slices.Contains(job.Tasks, task.ID) {
+		if existing, err := js.loadTask(task.ID); err == nil {
+			job.Replaced[task.ID] = existing
+		}
+	}
+	if err := js.saveTask(task); err != nil {
+		return err
+	}
+	if !slices.Contains(job.Tasks, task.ID) {
+		job.Tasks = append(job.Tasks, task.ID)
+	}
+	return nil
+}
+
+func (js *JournalService) saveImportJob(job *ImportJob) error {
+	if err := os.MkdirAll(js.importsDir(), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(job, "", "  ")
+	if err != nil {
+		return err
+	}
+	return js.writeDataFile(filepath.Join(js.importsDir(), job.JobID+".json"), data, 0644)
+}
+
+func (js *JournalService) loadImportJob(jobID string) (*ImportJob, error) {
+	if jobID == "" || strings.ContainsAny(jobID, `/\.`) {
+		return nil, fmt.Errorf("invalid import job ID: %s", jobID)
+	}
+	data, err := js.readDataFile(filepath.Join(js.importsDir(), jobID+".json"))
+	if err != nil {
+		return nil, err
+	}
+	var job ImportJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListImports lists import jobs, newest first
+func (js *JournalService) ListImports(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	files, _ := filepath.Glob(filepath.Join(js.importsDir(), "*.json"))
+	var jobs []*ImportJob
+	for _, file := range files {
+		if job, err := js.loadImportJob(strings.TrimSuffix(filepath.Base(file), ".json")); err == nil {
+			jobs = append(jobs, job)
+		}
+	}
+	if len(jobs) == 0 {
+		return mcp.NewToolResultText("No imports recorded yet."), nil
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ImportedAt.After(jobs[j].ImportedAt) })
+
+	var md strings.Builder
+	md.WriteString("# Imports\n\n")
+	for _, job := range jobs {
+		source := job.Source
+		if source == "" {
+			source = "pasted content"
+		}
+		md.WriteString(fmt.Sprintf("- **%s** (%s): %s from %s, %d tasks", job.JobID, job.ImportedAt.Format("2006-01-02 15:04"), job.Format, source, len(job.Tasks)))
+		if job.UndoneAt != nil {
+			md.WriteString(fmt.Sprintf(", undone %s", job.UndoneAt.Format("2006-01-02 15:04")))
+		}
+		md.WriteString("\n")
+	}
+	return mcp.NewToolResultText(md.String()), nil
+}
+
+// UndoImport reverses an import job. Tasks it created go to the trash, tasks
+// it overwrote get their earlier version back, and tasks written to since keep
+// their newer entries.
+func (js *JournalService) UndoImport(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	jobID, err := request.RequireString("job_id")
+	if err != nil {
+		return mcp.NewToolResultError("job_id is required"), nil
+	}
+	dryRun := request.GetString("dry_run", "false") == "true"
+
+	job, err := js.loadImportJob(jobID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Import not found: %s", jobID)), nil
+	}
+	if job.UndoneAt != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Import %s was already undone on %s", jobID, job.UndoneAt.Format("2006-01-02 15:04"))), nil
+	}
+
+	result := UndoImportResult{JobID: jobID, DryRun: dryRun, Removed: []string{}}
+	for _, taskID := range job.Tasks {
+		outcome, entries, err := js.undoImportedTask(taskID, job, dryRun)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to undo task %s: %v", taskID, err)), nil
+		}
+		result.Entries += entries
+		switch outcome {
+		case "removed":
+			result.Removed = append(result.Removed, taskID)
+		case "restored":
+			result.Restored = append(result.Restored, taskID)
+		case "kept":
+			result.Kept = append(result.Kept, taskID)
+		case "missing":
+			result.Missing = append(result.Missing, taskID)
+		}
+	}
+
+	verb := "Undid"
+	if dryRun {
+		verb = "Would undo"
+	} else {
+		now := time.Now()
+		job.UndoneAt = &now
+		if err := js.saveImportJob(job); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to record the undo: %v", err)), nil
+		}
+	}
+	result.Summary = fmt.Sprintf("%s import %s: %d tasks to trash, %d restored, %d kept with later entries, %d imported entries removed",
+		verb, jobID, len(result.Removed), len(result.Restored), len(result.Kept), result.Entries)
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// undoImportedTask reverses one task of an import and reports what it did
+// and how many imported entries it removed
+func (js *JournalService) undoImportedTask(taskID string, job *ImportJob, dryRun bool) (string, int, error) {
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return "missing", 0, nil
+	}
+	var later []Entry
+	for _, entry := range task.Entries {
+		if entry.ImportJob != job.JobID {
+			later = append(later, entry)
+		}
+	}
+	removed := len(task.Entries) - len(later)
+
+	// A later import replaced the task; only this job's entries go
+	if task.Import == nil || task.Import.JobID != job.JobID {
+		if removed > 0 && !dryRun {
+			task.Entries = later
+			task.Updated = time.Now()
+			if err := js.saveTask(task); err != nil {
+				return "", 0, err
+			}
+		}
+		return "kept", removed, nil
+	}
+
+	if previous, ok := job.Replaced[taskID]; ok {
+		if !dryRun {
+			previous.Entries = append(previous.Entries, later...)
+			previous.Updated = time.Now()
+			if err := js.saveTask(previous); err != nil {
+				return "", 0, err
+			}
+		}
+		return "restored", removed, nil
+	}
+
+	if len(later) > 0 {
+		if !dryRun {
+			task.Entries = later
+			task.Import = nil
+			task.Updated = time.Now()
+			if err := js.saveTask(task); err != nil {
+				return "", 0, err
+			}
+		}
+		return "kept", removed, nil
+	}
+
+	if !dryRun {
+		if err := js.trashTask(task, "undo_import "+job.JobID); err != nil {
+			return "", 0, err
+		}
+	}
+	return "removed", removed, nil
+}
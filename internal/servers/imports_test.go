@@ -0,0 +1,105 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestImportProvenance(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	result, _ := js.ImportData(ctx, CreateMockRequest(map[string]interface{}{
+		"content":     "# Setup\nInstalled the tools\n\n# Reading\nFinished chapter one",
+		"format":      "markdown",
+		"task_prefix": "MD",
+		"source":      "notes.md",
+	}))
+	var imported ImportResult
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &imported)
+	job, err := js.loadImportJob(imported.JobID)
+	if err != nil || len(job.Tasks) != 2 {
+		t.Fatalf("Expected a job for two tasks, got %+v", imported)
+	}
+
+	task, err := js.loadTask(job.Tasks[0])
+	if err != nil {
+		t.Fatalf("Failed to load imported task: %v", err)
+	}
+	if task.Import == nil || task.Import.JobID != imported.JobID || task.Import.Source != "notes.md" || task.Import.Format != "markdown" || len(task.Import.SourceHash) != 64 {
+		t.Errorf("Unexpected provenance: %+v", task.Import)
+	}
+	if task.Entries[0].ImportJob != imported.JobID {
+		t.Errorf("Expected entries tagged with the job, got %q", task.Entries[0].ImportJob)
+	}
+
+	result, _ = js.ListImports(ctx, CreateMockRequest(map[string]interface{}{}))
+	if text := result.Content[0].(mcp.TextContent).Text; !contains(text, imported.JobID) || !contains(text, "notes.md") {
+		t.Errorf("Expected the import listed, got: %s", text)
+	}
+}
+
+func TestUndoImport(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	createTestTask(t, js, "JS-1", "Original title", "work")
+	content := `{"tasks": [
+		{"id": "1", "title": "Imported title", "entries": [{"content": "Imported entry"}]},
+		{"id": "2", "title": "New task", "entries": [{"content": "Another entry"}]},
+		{"id": "3", "title": "Kept task", "entries": [{"content": "Third entry"}]}
+	]}`
+	result, _ := js.ImportData(ctx, CreateMockRequest(map[string]interface{}{"content": content, "format": "json", "task_prefix": "JS"}))
+	var imported ImportResult
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &imported)
+	if imported.JobID == "" {
+		t.Fatalf("Expected an import job, got: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+
+	// An entry written after the import survives the undo
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "JS-3", "content": "Written later"}))
+
+	result, _ = js.UndoImport(ctx, CreateMockRequest(map[string]interface{}{"job_id": imported.JobID, "dry_run": "true"}))
+	var undo UndoImportResult
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &undo)
+	if !undo.DryRun || len(undo.Removed) != 1 || len(undo.Restored) != 1 || len(undo.Kept) != 1 {
+		t.Fatalf("Unexpected dry run: %+v", undo)
+	}
+	if _, err := js.loadTask("JS-2"); err != nil {
+		t.Fatal("Expected a dry run to leave tasks alone")
+	}
+
+	result, _ = js.UndoImport(ctx, CreateMockRequest(map[string]interface{}{"job_id": imported.JobID}))
+	if result.IsError {
+		t.Fatalf("Failed to undo import: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	if task, err := js.loadTask("JS-1"); err != nil || task.Title != "Original title" || task.Import != nil {
+		t.Errorf("Expected the overwritten task restored, got %+v", task)
+	}
+	if _, err := js.loadTask("JS-2"); err == nil {
+		t.Error("Expected the created task moved to trash")
+	}
+	trashed, _ := js.loadTrash()
+	if len(trashed) != 1 || trashed[0].Task.ID != "JS-2" {
+		t.Errorf("Expected JS-2 in the trash, got %+v", trashed)
+	}
+	kept, err := js.loadTask("JS-3")
+	if err != nil || len(kept.Entries) != 1 || kept.Entries[0].Content != "Written later" || kept.Import != nil {
+		t.Errorf("Expected only the later entry kept, got %+v", kept)
+	}
+
+	job, _ := js.loadImportJob(imported.JobID)
+	if job.UndoneAt == nil || time.Since(*job.UndoneAt) > time.Minute {
+		t.Errorf("Expected the job marked undone, got %+v", job.UndoneAt)
+	}
+	if result, _ := js.UndoImport(ctx, CreateMockRequest(map[string]interface{}{"job_id": imported.JobID})); !result.IsError {
+		t.Error("Expected undoing twice to fail")
+	}
+	if result, _ := js.UndoImport(ctx, CreateMockRequest(map[string]interface{}{"job_id": "../config"})); !result.IsError {
+		t.Error("Expected an invalid job ID to fail")
+	}
+}
@@ -0,0 +1,165 @@
+package servers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ChecklistItem is a checkbox item on a task
+type ChecklistItem struct {
+	Text string `json:"text" yaml:"text"`
+	Done bool   `json:"done" yaml:"done"`
+}
+
+// issueFormSection is one "### Heading" block of an issue form or template
+type issueFormSection struct {
+	Heading string
+	Content string
+}
+
+// Special targets for github.issue_fields; any other value names a custom field
+const (
+	issueFieldChecklist = "checklist"
+	issueFieldTags      = "tags"
+	issueFieldPriority  = "priority"
+	issueFieldEntry     = "entry"
+	issueFieldIgnore    = "ignore"
+)
+
+var (
+	checkboxPattern  = regexp.MustCompile(`^\s*[-*]\s+\[([ xX])\]\s+(.+)$`)
+	fieldNamePattern = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// parseIssueForm splits a rendered issue form body into its ### sections and
+// the text before the first heading. It returns no sections for free-form bodies.
+func parseIssueForm(body string) (string, []issueFormSection) {
+	var preamble strings.Builder
+	var sections []issueFormSection
+	var current *issueFormSection
+
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		if heading, ok := strings.CutPrefix(line, "### "); ok {
+			if current != nil {
+				sections = append(sections, *current)
+			}
+			current = &issueFormSection{Heading: strings.TrimSpace(heading)}
+			continue
+		}
+
+		if current == nil {
+			preamble.WriteString(line + "\n")
+		} else {
+			current.Content += line + "\n"
+		}
+	}
+	if current != nil {
+		sections = append(sections, *current)
+	}
+
+	for i := range sections {
+		sections[i].Content = strings.TrimSpace(sections[i].Content)
+	}
+	return strings.TrimSpace(preamble.String()), sections
+}
+
+// parseChecklist returns the checkbox items in content and whether every
+// non-empty line was a checkbox
+func parseChecklist(content string) ([]ChecklistItem, bool) {
+	var items []ChecklistItem
+	onlyCheckboxes := true
+
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		match := checkboxPattern.FindStringSubmatch(line)
+		if match == nil {
+			onlyCheckboxes = false
+			continue
+		}
+		items = append(items, ChecklistItem{Text: strings.TrimSpace(match[2]), Done: match[1] != " "})
+	}
+
+	return items, onlyCheckboxes && len(items) > 0
+}
+
+// issueFieldName turns a form heading into a field key, e.g. "Steps to reproduce" -> "steps_to_reproduce"
+func issueFieldName(heading string) string {
+	return strings.Trim(fieldNamePattern.ReplaceAllString(strings.ToLower(heading), "_"), "_")
+}
+
+// applyIssueForm fills task fields, checklist, tags and priority from an issue
+// form body. mapping maps headings (case-insensitive) to a field name or one
+// of the special targets. It reports false when the body has no form sections.
+func applyIssueForm(task *Task, body string, mapping map[string]string) bool {
+	preamble, sections := parseIssueForm(body)
+	if len(sections) == 0 {
+		return false
+	}
+
+	targets := make(map[string]string)
+	for heading, target := range mapping {
+		targets[strings.ToLower(strings.TrimSpace(heading))] = target
+	}
+
+	now := time.Now()
+	if preamble != "" {
+		task.Entries = append(task.Entries, Entry{
+			ID:        generateEntryID(),
+			Timestamp: now,
+			Content:   fmt.Sprintf("GitHub Issue Description: %s", preamble),
+			Type:      "github_description",
+		})
+	}
+
+	for _, section := range sections {
+		// GitHub renders unanswered optional form fields as "_No response_"
+		if section.Content == "" || section.Content == "_No response_" {
+			continue
+		}
+
+		target, mapped := targets[strings.ToLower(section.Heading)]
+		items, onlyCheckboxes := parseChecklist(section.Content)
+		if !mapped && onlyCheckboxes {
+			target = issueFieldChecklist
+		}
+
+		switch target {
+		case issueFieldIgnore:
+		case issueFieldChecklist:
+			task.Checklist = append(task.Checklist, items...)
+		case issueFieldTags:
+			for _, tag := range strings.FieldsFunc(section.Content, func(r rune) bool { return r == ',' || r == '\n' }) {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					task.Tags = append(task.Tags, tag)
+				}
+			}
+		case issueFieldPriority:
+			switch priority := strings.ToLower(section.Content); priority {
+			case "low", "medium", "high", "urgent":
+				task.Priority = priority
+			}
+		case issueFieldEntry:
+			task.Entries = append(task.Entries, Entry{
+				ID:        generateEntryID(),
+				Timestamp: now,
+				Content:   fmt.Sprintf("%s: %s", section.Heading, section.Content),
+				Type:      "github_description",
+			})
+		default:
+			name := target
+			if name == "" {
+				name = issueFieldName(section.Heading)
+			}
+			if task.Fields == nil {
+				task.Fields = make(map[string]string)
+			}
+			task.Fields[name] = section.Content
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,98 @@
+package servers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v66/github"
+)
+
+const bugReportForm = `Reported from the support queue.
+
+### What happened?
+
+Checkout times out after 8 seconds.
+
+### Steps to reproduce
+
+1. Add item
+2. Pay with card
+
+### Severity
+
+High
+
+### Affected areas
+
+api, payments
+
+### Browser
+
+_No response_
+
+### Code of Conduct
+
+- [X] I agree to follow this project's Code of Conduct
+- [ ] I searched for existing issues
+`
+
+func TestCreateTaskFromIssueForm(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+
+	config := defaultConfiguration()
+	config.GitHub.IssueFields = map[string]string{
+		"Severity":       "priority",
+		"Affected areas": "tags",
+		"What happened?": "summary",
+	}
+	js.saveConfiguration(config)
+
+	task := js.createTaskFromGitHubIssue(&github.Issue{
+		Number: github.Int(7),
+		Title:  github.String("Checkout timeout"),
+		Body:   github.String(bugReportForm),
+		State:  github.String("open"),
+	})
+
+	if task.Fields["summary"] != "Checkout times out after 8 seconds." {
+		t.Errorf("Expected mapped summary field, got %q", task.Fields["summary"])
+	}
+	if !strings.Contains(task.Fields["steps_to_reproduce"], "2. Pay with card") {
+		t.Errorf("Expected unmapped heading as slug field, got %v", task.Fields)
+	}
+	if _, ok := task.Fields["browser"]; ok {
+		t.Error("Expected _No response_ sections to be skipped")
+	}
+	if task.Priority != "high" {
+		t.Errorf("Expected priority high, got %s", task.Priority)
+	}
+	if strings.Join(task.Tags, ",") != "api,payments" {
+		t.Errorf("Expected tags from form, got %v", task.Tags)
+	}
+	if len(task.Checklist) != 2 || !task.Checklist[0].Done || task.Checklist[1].Done {
+		t.Errorf("Expected checkbox section as checklist, got %+v", task.Checklist)
+	}
+	if len(task.Entries) != 1 || !strings.Contains(task.Entries[0].Content, "support queue") {
+		t.Errorf("Expected only the preamble as an entry, got %+v", task.Entries)
+	}
+
+	markdown := js.formatTaskAsMarkdown(task)
+	if !strings.Contains(markdown, "- [x] I agree") || !strings.Contains(markdown, "**summary:**") {
+		t.Errorf("Expected fields and checklist in markdown, got:\n%s", markdown)
+	}
+}
+
+func TestCreateTaskFromFreeFormIssue(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+
+	task := js.createTaskFromGitHubIssue(&github.Issue{
+		Number: github.Int(8),
+		Title:  github.String("Plain issue"),
+		Body:   github.String("Just a description without a template."),
+		State:  github.String("open"),
+	})
+
+	if len(task.Fields) != 0 || len(task.Entries) != 1 {
+		t.Errorf("Expected free-form body as a single entry, got fields %v entries %d", task.Fields, len(task.Entries))
+	}
+}
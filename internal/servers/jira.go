@@ -0,0 +1,588 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// JiraService handles Jira REST API interactions
+type JiraService struct {
+	baseURL string
+	email   string // set for Jira Cloud (basic auth with an API token); empty uses a bearer personal access token
+	token   string
+	client  *http.Client
+}
+
+// JiraIssue is the subset of a Jira issue the journal uses
+type JiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string   `json:"summary"`
+		Description string   `json:"description"`
+		Labels      []string `json:"labels"`
+		Status      struct {
+			Name           string `json:"name"`
+			StatusCategory struct {
+				Key string `json:"key"` // new, indeterminate or done
+			} `json:"statusCategory"`
+		} `json:"status"`
+		Priority *struct {
+			Name string `json:"name"`
+		} `json:"priority"`
+	} `json:"fields"`
+}
+
+// JiraIssueComment represents a comment on a Jira issue
+type JiraIssueComment struct {
+	ID        string    `json:"id"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// JiraIssueEvent represents a status transition on a Jira issue
+type JiraIssueEvent struct {
+	ID        string    `json:"id"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Actor     string    `json:"actor"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// JiraSyncResult represents the result of a Jira sync operation
+type JiraSyncResult struct {
+	TasksCreated    int       `json:"tasks_created"`
+	TasksUpdated    int       `json:"tasks_updated"`
+	IssuesProcessed int       `json:"issues_processed"`
+	Errors          []string  `json:"errors,omitempty"`
+	Summary         string    `json:"summary"`
+	LastSyncTime    time.Time `json:"last_sync_time"`
+}
+
+// jiraTimeLayout is the timestamp format used by the Jira REST API
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// NewJiraService creates a Jira service for baseURL
+func NewJiraService(baseURL, email, token string) *JiraService {
+	return &JiraService{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		email:   email,
+		token:   token,
+		client:  http.DefaultClient,
+	}
+}
+
+// jiraService builds a Jira client from jira.base_url and the jira_token
+// parameter or secret
+func (js *JournalService) jiraService(request mcp.CallToolRequest) (*JiraService, error) {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load config: %v", err)
+	}
+	if config.Jira.BaseURL == "" {
+		return nil, fmt.Errorf("jira.base_url is required in config (e.g. https://yourcompany.atlassian.net)")
+	}
+
+	token := request.GetString("jira_token", "")
+	if token == "" {
+		token, _ = js.getSecret("jira_token")
+	}
+	if token == "" {
+		return nil, fmt.Errorf("jira_token is required (or store it once with set_secret name=jira_token)")
+	}
+	return NewJiraService(config.Jira.BaseURL, config.Jira.Email, token), nil
+}
+
+// SyncWithJira syncs assigned Jira issues with tasks
+func (js *JournalService) SyncWithJira(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	jiraService, err := js.jiraService(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load configuration: %v", err)), nil
+	}
+
+	jql := request.GetString("jql", config.Jira.JQL)
+	if jql == "" {
+		jql = defaultJiraJQL(request.GetStringSlice("projects", config.Jira.Projects))
+	}
+	createTasks := request.GetString("create_tasks", "true") == "true"
+	updateExisting := request.GetString("update_existing", "true") == "true"
+
+	syncResult := JiraSyncResult{
+		LastSyncTime: time.Now(),
+		Errors:       []string{},
+	}
+
+	issues, err := jiraService.searchIssues(ctx, jql)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch Jira issues: %v", err)), nil
+	}
+	syncResult.IssuesProcessed = len(issues)
+
+	tasks, err := js.getAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+
+	for _, issue := range issues {
+		var create func() *Task
+		var update func(*Task) bool
+		if createTasks {
+			create = func() *Task { return jiraService.createTaskFromJiraIssue(issue) }
+		}
+		if updateExisting {
+			update = func(task *Task) bool { return updateTaskFromJiraIssue(task, issue) }
+		}
+
+		// A task linked by hand keeps its own ID
+		taskID := issue.Key
+		if existingTask := jiraService.findTask(tasks, issue.Key); existingTask != nil {
+			taskID = existingTask.ID
+		}
+
+		sync := js.syncIssueTask(taskID, create, update)
+		switch {
+		case sync.Err != nil:
+			syncResult.Errors = append(syncResult.Errors, sync.failure())
+		case sync.Created:
+			syncResult.TasksCreated++
+		case sync.Updated:
+			syncResult.TasksUpdated++
+		}
+	}
+
+	syncResult.Summary = fmt.Sprintf("Processed %d issues: %d tasks created, %d tasks updated",
+		syncResult.IssuesProcessed, syncResult.TasksCreated, syncResult.TasksUpdated)
+
+	result, _ := json.Marshal(syncResult)
+	return mcp.NewToolResultText(string(result)), nil
+}
+
+// PullJiraUpdates pulls latest comments and status transitions for tracked Jira issues
+func (js *JournalService) PullJiraUpdates(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	jiraService, err := js.jiraService(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	taskID := request.GetString("task_id", "")
+	var since *time.Time
+	if sinceStr := request.GetString("since", ""); sinceStr != "" {
+		sinceTime, err := time.Parse("2006-01-02T15:04:05Z", sinceStr)
+		if err != nil {
+			return mcp.NewToolResultError("Invalid since timestamp format. Use ISO 8601 (2006-01-02T15:04:05Z)"), nil
+		}
+		since = &sinceTime
+	}
+
+	var tasks []*Task
+	if taskID != "" {
+		task, err := js.loadTask(taskID)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskID)), nil
+		}
+		if jiraService.issueKey(task) == "" {
+			return mcp.NewToolResultError("Task does not have a Jira issue associated"), nil
+		}
+		tasks = []*Task{task}
+	} else {
+		allTasks, err := js.getAllTasks()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+		}
+		for _, task := range allTasks {
+			if jiraService.issueKey(task) != "" {
+				tasks = append(tasks, task)
+			}
+		}
+	}
+
+	updateCount := 0
+	errors := []string{}
+
+	for _, task := range tasks {
+		key := jiraService.issueKey(task)
+		comments, events, err := jiraService.getIssueUpdates(ctx, key)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("Failed to fetch updates for task %s: %v", task.ID, err))
+			continue
+		}
+
+		var entries []Entry
+		for _, comment := range comments {
+			entries = append(entries, Entry{
+				ID:        generateEntryID(),
+				Timestamp: comment.CreatedAt,
+				Content:   fmt.Sprintf("Jira comment by %s: %s", comment.Author, comment.Body),
+				Type:      "jira_comment",
+			})
+		}
+		for _, event := range events {
+			entries = append(entries, Entry{
+				ID:        generateEntryID(),
+				Timestamp: event.CreatedAt,
+				Content:   fmt.Sprintf("Jira status: %s → %s by %s", event.From, event.To, event.Actor),
+				Type:      "jira_event",
+			})
+		}
+
+		// Without since, only activity newer than the last pull is added
+		added, err := js.addIssueActivity(task.ID, entries, func(task *Task) *time.Time {
+			if since != nil {
+				return since
+			}
+			return lastJiraActivity(task)
+		})
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("Failed to save task %s: %v", task.ID, err))
+			continue
+		}
+		if added > 0 {
+			updateCount++
+		}
+	}
+
+	result := map[string]interface{}{
+		"tasks_updated":  updateCount,
+		"total_tasks":    len(tasks),
+		"errors":         errors,
+		"summary":        fmt.Sprintf("Updated %d tasks with latest Jira activity", updateCount),
+		"last_sync_time": time.Now(),
+	}
+
+	resultJSON, _ := json.Marshal(result)
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// CreateTaskFromJiraIssue creates a new task from a Jira issue URL or key
+func (js *JournalService) CreateTaskFromJiraIssue(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	jiraService, err := js.jiraService(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	issueRef := request.GetString("issue", "")
+	if issueRef == "" {
+		return mcp.NewToolResultError("issue is required (URL or key such as PROJ-123)"), nil
+	}
+	key := parseJiraKey(issueRef)
+	if key == "" {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid Jira issue: %s", issueRef)), nil
+	}
+
+	issue, err := jiraService.getIssue(ctx, key)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch Jira issue: %v", err)), nil
+	}
+
+	task := jiraService.createTaskFromJiraIssue(*issue)
+	task.Type = request.GetString("type", "work")
+	if priority := request.GetString("priority", ""); priority != "" {
+		task.Priority = priority
+	}
+
+	defer js.lockTask(task.ID)()
+	if _, err := js.loadTask(task.ID); err == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Task %s already exists", task.ID)), nil
+	}
+	if err := js.saveTask(task); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task: %v", err)), nil
+	}
+
+	result := map[string]interface{}{
+		"task_id":    task.ID,
+		"title":      task.Title,
+		"status":     task.Status,
+		"issue_url":  task.IssueURL,
+		"created_at": task.Created,
+		"summary":    fmt.Sprintf("Created task %s from Jira issue %s", task.ID, key),
+	}
+
+	resultJSON, _ := json.Marshal(result)
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// Helper methods for Jira service
+
+// get performs a GET against the Jira REST API and decodes the response
+func (jsv *JiraService) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	endpoint := jsv.baseURL + "/rest/api/2/" + path
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if jsv.email != "" {
+		req.SetBasicAuth(jsv.email, jsv.token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+jsv.token)
+	}
+
+	resp, err := jsv.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			ErrorMessages []string `json:"errorMessages"`
+		}
+		json.NewDecoder(resp.Body).Decode(&apiErr)
+		return fmt.Errorf("%s: %s", resp.Status, strings.Join(apiErr.ErrorMessages, "; "))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// searchIssues runs a JQL search, following pagination
+func (jsv *JiraService) searchIssues(ctx context.Context, jql string) ([]JiraIssue, error) {
+	var issues []JiraIssue
+	startAt := 0
+	for {
+		params := url.Values{
+			"jql":        {jql},
+			"fields":     {"summary,description,labels,status,priority"},
+			"startAt":    {strconv.Itoa(startAt)},
+			"maxResults": {"50"},
+		}
+		var page struct {
+			Issues []JiraIssue `json:"issues"`
+			Total  int         `json:"total"`
+		}
+		if err := jsv.get(ctx, "search", params, &page); err != nil {
+			return nil, err
+		}
+		issues = append(issues, page.Issues...)
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			break
+		}
+	}
+	return issues, nil
+}
+
+func (jsv *JiraService) getIssue(ctx context.Context, key string) (*JiraIssue, error) {
+	var issue JiraIssue
+	params := url.Values{"fields": {"summary,description,labels,status,priority"}}
+	if err := jsv.get(ctx, "issue/"+url.PathEscape(key), params, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// getIssueUpdates fetches an issue's comments and status transitions
+func (jsv *JiraService) getIssueUpdates(ctx context.Context, key string) ([]JiraIssueComment, []JiraIssueEvent, error) {
+	var commentPage struct {
+		Comments []struct {
+			ID     string `json:"id"`
+			Author struct {
+				DisplayName string `json:"displayName"`
+			} `json:"author"`
+			Body    string `json:"body"`
+			Created string `json:"created"`
+		} `json:"comments"`
+	}
+	if err := jsv.get(ctx, "issue/"+url.PathEscape(key)+"/comment", nil, &commentPage); err != nil {
+		return nil, nil, err
+	}
+
+	var comments []JiraIssueComment
+	for _, c := range commentPage.Comments {
+		created, _ := time.Parse(jiraTimeLayout, c.Created)
+		comments = append(comments, JiraIssueComment{ID: c.ID, Author: c.Author.DisplayName, Body: c.Body, CreatedAt: created})
+	}
+
+	var changelog struct {
+		Changelog struct {
+			Histories []struct {
+				ID     string `json:"id"`
+				Author struct {
+					DisplayName string `json:"displayName"`
+				} `json:"author"`
+				Created string `json:"created"`
+				Items   []struct {
+					Field      string `json:"field"`
+					FromString string `json:"fromString"`
+					ToString   string `json:"toString"`
+				} `json:"items"`
+			} `json:"histories"`
+		} `json:"changelog"`
+	}
+	params := url.Values{"expand": {"changelog"}, "fields": {"status"}}
+	if err := jsv.get(ctx, "issue/"+url.PathEscape(key), params, &changelog); err != nil {
+		return nil, nil, err
+	}
+
+	var events []JiraIssueEvent
+	for _, history := range changelog.Changelog.Histories {
+		created, _ := time.Parse(jiraTimeLayout, history.Created)
+		for _, item := range history.Items {
+			if item.Field == "status" {
+				events = append(events, JiraIssueEvent{ID: history.ID, From: item.FromString, To: item.ToString, Actor: history.Author.DisplayName, CreatedAt: created})
+			}
+		}
+	}
+
+	return comments, events, nil
+}
+
+// issueKey returns the Jira key of a task linked to this Jira instance
+func (jsv *JiraService) issueKey(task *Task) string {
+	if task.IssueURL == "" || !strings.HasPrefix(task.IssueURL, jsv.baseURL) {
+		return ""
+	}
+	return parseJiraKey(task.IssueURL)
+}
+
+func (jsv *JiraService) createTaskFromJiraIssue(issue JiraIssue) *Task {
+	task := &Task{
+		ID:       issue.Key,
+		Title:    issue.Fields.Summary,
+		Type:     "work", // Default, can be overridden
+		Tags:     issue.Fields.Labels,
+		Status:   mapJiraStatusToTaskStatus(issue),
+		Priority: mapJiraPriority(issue),
+		IssueURL: jsv.baseURL + "/browse/" + issue.Key,
+		IssueID:  issue.Key,
+		Created:  time.Now(),
+		Updated:  time.Now(),
+		Entries:  []Entry{},
+	}
+
+	if issue.Fields.Description != "" {
+		task.Entries = append(task.Entries, Entry{
+			ID:        generateEntryID(),
+			Timestamp: time.Now(),
+			Content:   fmt.Sprintf("Jira Issue Description: %s", issue.Fields.Description),
+			Type:      "jira_description",
+		})
+	}
+
+	return task
+}
+
+func updateTaskFromJiraIssue(task *Task, issue JiraIssue) bool {
+	updated := false
+
+	newStatus := mapJiraStatusToTaskStatus(issue)
+	if task.Status != newStatus {
+		task.Status = newStatus
+		task.Entries = append(task.Entries, Entry{
+			ID:        generateEntryID(),
+			Timestamp: time.Now(),
+			Content:   fmt.Sprintf("Status updated from Jira: %s (%s)", newStatus, issue.Fields.Status.Name),
+			Type:      "status_change",
+		})
+		updated = true
+	}
+
+	if task.Title != issue.Fields.Summary {
+		task.Title = issue.Fields.Summary
+		updated = true
+	}
+
+	if !equalStringSlices(task.Tags, issue.Fields.Labels) {
+		task.Tags = issue.Fields.Labels
+		updated = true
+	}
+
+	if updated {
+		task.Updated = time.Now()
+	}
+	return updated
+}
+
+// Helper functions
+
+// findTask finds the task linked to a Jira key, by issue URL or ID, then by task ID
+func (jsv *JiraService) findTask(tasks []*Task, key string) *Task {
+	for _, task := range tasks {
+		if jsv.issueKey(task) == key || task.IssueID == key {
+			return task
+		}
+	}
+	for _, task := range tasks {
+		if task.ID == key {
+			return task
+		}
+	}
+	return nil
+}
+
+// lastJiraActivity returns the newest Jira comment or event entry on a task
+func lastJiraActivity(task *Task) *time.Time {
+	var latest *time.Time
+	for i := range task.Entries {
+		entry := task.Entries[i]
+		if (entry.Type == "jira_comment" || entry.Type == "jira_event") && (latest == nil || entry.Timestamp.After(*latest)) {
+			latest = &task.Entries[i].Timestamp
+		}
+	}
+	return latest
+}
+
+// parseJiraKey extracts an issue key such as PROJ-123 from a key or URL
+func parseJiraKey(ref string) string {
+	parts := strings.Split(strings.TrimRight(ref, "/"), "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		part := strings.SplitN(parts[i], "?", 2)[0]
+		project, number, ok := strings.Cut(part, "-")
+		if !ok || project == "" || strings.ToUpper(project) != project {
+			continue
+		}
+		if _, err := strconv.Atoi(number); err == nil {
+			return part
+		}
+	}
+	return ""
+}
+
+// defaultJiraJQL selects unresolved issues assigned to the token's user
+func defaultJiraJQL(projects []string) string {
+	jql := "assignee = currentUser() AND statusCategory != Done"
+	if len(projects) > 0 {
+		jql += fmt.Sprintf(" AND project in (%s)", strings.Join(projects, ", "))
+	}
+	return jql + " ORDER BY updated DESC"
+}
+
+func mapJiraStatusToTaskStatus(issue JiraIssue) string {
+	if strings.Contains(strings.ToLower(issue.Fields.Status.Name), "block") {
+		return "blocked"
+	}
+	if issue.Fields.Status.StatusCategory.Key == "done" {
+		return "completed"
+	}
+	return "active"
+}
+
+func mapJiraPriority(issue JiraIssue) string {
+	if issue.Fields.Priority == nil {
+		return "medium"
+	}
+	switch strings.ToLower(issue.Fields.Priority.Name) {
+	case "blocker", "critical", "highest":
+		return "urgent"
+	case "high", "major":
+		return "high"
+	case "low", "lowest", "minor", "trivial":
+		return "low"
+	default:
+		return "medium"
+	}
+}
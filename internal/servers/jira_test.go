@@ -0,0 +1,120 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestParseJiraKey(t *testing.T) {
+	tests := map[string]string{
+		"PROJ-123": "PROJ-123",
+		"https://acme.atlassian.net/browse/OPS-7":         "OPS-7",
+		"https://jira.acme.com/browse/OPS-7?focused=true": "OPS-7",
+		"https://acme.atlassian.net/browse/proj-1":        "",
+		"not-a-key": "",
+	}
+	for ref, want := range tests {
+		if got := parseJiraKey(ref); got != want {
+			t.Errorf("parseJiraKey(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
+
+func TestJiraSync(t *testing.T) {
+	status := `{"name": "In Progress", "statusCategory": {"key": "indeterminate"}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/rest/api/2/search":
+			w.Write([]byte(`{"total": 2, "issues": [
+				{"key": "PROJ-1", "fields": {"summary": "Fix login", "description": "Users get logged out", "labels": ["auth"], "status": ` + status + `, "priority": {"name": "Highest"}}},
+				{"key": "PROJ-2", "fields": {"summary": "Renamed upstream", "labels": [], "status": {"name": "Done", "statusCategory": {"key": "done"}}}}
+			]}`))
+		case "/rest/api/2/issue/PROJ-1/comment":
+			w.Write([]byte(`{"comments": [{"id": "10", "author": {"displayName": "Dana"}, "body": "Repro attached", "created": "2026-03-02T10:00:00.000+0000"}]}`))
+		case "/rest/api/2/issue/PROJ-1":
+			if r.URL.Query().Get("expand") == "changelog" {
+				w.Write([]byte(`{"changelog": {"histories": [{"id": "5", "author": {"displayName": "Dana"}, "created": "2026-03-01T09:00:00.000+0000",
+					"items": [{"field": "status", "fromString": "To Do", "toString": "In Progress"}, {"field": "assignee", "fromString": "", "toString": "me"}]}]}}`))
+				return
+			}
+			w.Write([]byte(`{"key": "PROJ-1", "fields": {"summary": "Fix login", "labels": [], "status": ` + status + `}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	config := defaultConfiguration()
+	config.Jira.BaseURL = server.URL
+	js.saveConfiguration(config)
+
+	// A task linked by hand is updated rather than duplicated
+	js.CreateTask(ctx, CreateMockRequest(map[string]interface{}{"id": "old-2", "title": "Old title", "type": "work", "issue_url": server.URL + "/browse/PROJ-2"}))
+
+	result, _ := js.SyncWithJira(ctx, CreateMockRequest(map[string]interface{}{}))
+	if !result.IsError {
+		t.Fatal("Expected an error without a token")
+	}
+
+	request := CreateMockRequest(map[string]interface{}{"jira_token": "secret-token"})
+	result, _ = js.SyncWithJira(ctx, request)
+	var sync JiraSyncResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &sync); err != nil {
+		t.Fatalf("Failed to parse sync result: %v", result.Content)
+	}
+	if sync.IssuesProcessed != 2 || sync.TasksCreated != 1 || sync.TasksUpdated != 1 {
+		t.Fatalf("Unexpected sync result: %+v", sync)
+	}
+
+	task, err := js.loadTask("PROJ-1")
+	if err != nil {
+		t.Fatalf("Expected task PROJ-1: %v", err)
+	}
+	if task.Status != "active" || task.Priority != "urgent" || task.IssueID != "PROJ-1" || len(task.Entries) != 1 {
+		t.Errorf("Unexpected task: %+v", task)
+	}
+	linked, _ := js.loadTask("old-2")
+	if linked.Status != "completed" || linked.Title != "Renamed upstream" {
+		t.Errorf("Expected linked task to follow Jira, got %s %q", linked.Status, linked.Title)
+	}
+
+	js.PullJiraUpdates(ctx, request)
+	task, _ = js.loadTask("PROJ-1")
+	if len(task.Entries) != 3 || !strings.Contains(task.Entries[1].Content, "Repro attached") ||
+		task.Entries[2].Content != "Jira status: To Do → In Progress by Dana" {
+		t.Errorf("Unexpected entries after pull: %+v", task.Entries)
+	}
+
+	// A second pull only adds newer activity
+	js.PullJiraUpdates(ctx, request)
+	task, _ = js.loadTask("PROJ-1")
+	if len(task.Entries) != 3 {
+		t.Errorf("Expected repeated pull to add nothing, got %d entries", len(task.Entries))
+	}
+
+	js.DeleteTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "PROJ-1"}))
+	result, _ = js.CreateTaskFromJiraIssue(ctx, CreateMockRequest(map[string]interface{}{"jira_token": "secret-token", "issue": "PROJ-1", "type": "investigation"}))
+	if result.IsError {
+		t.Fatalf("create_task_from_jira_issue failed: %v", result.Content)
+	}
+	task, _ = js.loadTask("PROJ-1")
+	if task == nil || task.Type != "investigation" || task.IssueURL != server.URL+"/browse/PROJ-1" {
+		t.Errorf("Unexpected task from issue: %+v", task)
+	}
+	result, _ = js.CreateTaskFromJiraIssue(ctx, CreateMockRequest(map[string]interface{}{"jira_token": "secret-token", "issue": "PROJ-1"}))
+	if !result.IsError {
+		t.Error("Expected an existing task not overwritten")
+	}
+}
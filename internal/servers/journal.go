@@ -2,13 +2,19 @@ package servers
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -16,39 +22,76 @@ import (
 
 type JournalService struct {
 	DataDir string
+	RootDir string   // root of the profile tree; empty means DataDir
+	Profile string   // active profile; empty means the default profile
+	Tools   []string // registered tool names, for the usage report
+
+	// profileMu guards DataDir, RootDir and Profile, which use_profile changes
+	// while other tool calls and the web server read them
+	profileMu sync.RWMutex
 }
 
 type Task struct {
-	ID       string    `json:"id"`
-	Title    string    `json:"title"`
-	Type     string    `json:"type"` // work, learning, personal, investigation
-	Tags     []string  `json:"tags"`
-	Status   string    `json:"status"` // active, completed, paused, blocked
-	Priority string    `json:"priority,omitempty"`
-	IssueURL string    `json:"issue_url,omitempty"`
-	IssueID  string    `json:"issue_id,omitempty"`
-	Created  time.Time `json:"created"`
-	Updated  time.Time `json:"updated"`
-	Entries  []Entry   `json:"entries"`
+	ID       string      `json:"id" yaml:"id"`
+	Title    string      `json:"title" yaml:"title"`
+	Type     string      `json:"type" yaml:"type"` // work, learning, personal, investigation
+	Tags     []string    `json:"tags" yaml:"tags"`
+	Status   string      `json:"status" yaml:"status"` // active, completed, paused, blocked, someday
+	Priority string      `json:"priority,omitempty" yaml:"priority,omitempty"`
+	IssueURL string      `json:"issue_url,omitempty" yaml:"issue_url,omitempty"`
+	IssueID  string      `json:"issue_id,omitempty" yaml:"issue_id,omitempty"`
+	Created  time.Time   `json:"created" yaml:"created"`
+	Updated  time.Time   `json:"updated" yaml:"updated"`
+	Entries  []Entry     `json:"entries" yaml:"entries"`
+	Clock    VectorClock `json:"clock,omitempty" yaml:"clock,omitempty"` // per-device write counters used to merge synced copies
+	ParentID string      `json:"parent_id,omitempty" yaml:"parent_id,omitempty"`
+	DueDate  string      `json:"due_date,omitempty" yaml:"due_date,omitempty"` // YYYY-MM-DD
+	Snoozed  bool        `json:"snoozed,omitempty" yaml:"snoozed,omitempty"`   // exempt from the auto-pause policy
+	Assignee string      `json:"assignee,omitempty" yaml:"assignee,omitempty"` // team member who owns the task
+
+	TimerStarted *time.Time `json:"timer_started,omitempty" yaml:"timer_started,omitempty"` // set while a timer is running
+
+	EstimateMinutes    int  `json:"estimate_minutes,omitempty" yaml:"estimate_minutes,omitempty"`
+	ReestimatePrompted bool `json:"reestimate_prompted,omitempty" yaml:"reestimate_prompted,omitempty"` // a reestimate entry was added for the current estimate
+
+	DependsOn []string `json:"depends_on,omitempty" yaml:"depends_on,omitempty"` // task IDs that must be completed first
+
+	Handoffs []Handoff `json:"handoffs,omitempty" yaml:"handoffs,omitempty"` // changes of assignee made with handoff_task, oldest first
+
+	Archived bool `json:"-" yaml:"-"` // loaded from archived/ rather than task storage
+
+	Fields    map[string]string `json:"fields,omitempty" yaml:"fields,omitempty"` // custom fields, e.g. from GitHub issue forms
+	Checklist []ChecklistItem   `json:"checklist,omitempty" yaml:"checklist,omitempty"`
+
+	Import *ImportProvenance `json:"import,omitempty" yaml:"import,omitempty"` // set on tasks created by import_data
 }
 
 type Entry struct {
-	ID        string    `json:"id"`
-	Timestamp time.Time `json:"timestamp"`
-	Content   string    `json:"content"`
-	Type      string    `json:"type,omitempty"` // log, status_change, completion, etc.
+	ID        string      `json:"id" yaml:"id"`
+	Timestamp time.Time   `json:"timestamp" yaml:"timestamp"`
+	Content   string      `json:"content" yaml:"-"`                                 // kept in the body of markdown task files
+	Type      string      `json:"type,omitempty" yaml:"type,omitempty"`             // log, status_change, completion, etc.
+	Minutes   int         `json:"minutes,omitempty" yaml:"minutes,omitempty"`       // time spent, on "time" and "focus" entries
+	History   []EntryEdit `json:"history,omitempty" yaml:"history,omitempty"`       // earlier versions, oldest first
+	ImportJob string      `json:"import_job,omitempty" yaml:"import_job,omitempty"` // import_data job that added the entry
+	Mood      int         `json:"mood,omitempty" yaml:"mood,omitempty"`             // 1 (low) to 5 (high), when rated
+	Energy    int         `json:"energy,omitempty" yaml:"energy,omitempty"`         // 1 (low) to 5 (high), when rated
+	Tags      []string    `json:"tags,omitempty" yaml:"tags,omitempty"`             // lowercase, without #; includes hashtags in the content
+	Replaces  []string    `json:"replaces,omitempty" yaml:"replaces,omitempty"`     // IDs of entries this one removed, which merges drop
+
+	PlannedMinutes int `json:"planned_minutes,omitempty" yaml:"planned_minutes,omitempty"` // planned length, on "focus" entries
+
+	Attachments []Attachment `json:"attachments,omitempty" yaml:"attachments,omitempty"`
 }
 
-type OneOnOne struct {
-	Date     string    `json:"date"`
-	Insights []string  `json:"insights,omitempty"`
-	Todos    []string  `json:"todos,omitempty"`
-	Feedback []string  `json:"feedback,omitempty"`
-	Notes    string    `json:"notes,omitempty"`
-	Created  time.Time `json:"created"`
+// EntryEdit is an earlier version of an entry's content, replaced at EditedAt
+type EntryEdit struct {
+	Content  string    `json:"content" yaml:"content"`
+	EditedAt time.Time `json:"edited_at" yaml:"edited_at"`
 }
 
 type ImportResult struct {
+	JobID             string   `json:"job_id,omitempty"` // pass to undo_import to reverse the import
 	TasksCreated      int      `json:"tasks_created"`
 	EntriesAdded      int      `json:"entries_added"`
 	DuplicatesSkipped int      `json:"duplicates_skipped"`
@@ -81,6 +124,14 @@ type AnalyticsReport struct {
 	ProductivityMetrics ProductivityMetrics `json:"productivity_metrics"`
 	PatternAnalysis     PatternAnalysis     `json:"pattern_analysis"`
 	Trends              []Trend             `json:"trends,omitempty"`
+	Impact              *ImpactMetrics      `json:"impact,omitempty"` // mentorship and knowledge-sharing work
+	Tone                *ToneMetrics        `json:"tone,omitempty"`   // lexicon-based sentiment of written entries
+	Estimates           *EstimateMetrics    `json:"estimates,omitempty"`
+	EntryLength         *EntryLengthMetrics `json:"entry_length,omitempty"` // written entry lengths and reading time
+	Balance             *BalanceMetrics     `json:"balance,omitempty"`      // recent time and entries per type against quotas
+	OnCall              *OnCallMetrics      `json:"on_call,omitempty"`      // pages per week, to explain heavy weeks
+	Wellbeing           *WellbeingMetrics   `json:"wellbeing,omitempty"`    // mood and energy beside the work done
+	Habits              []HabitStatus       `json:"habits,omitempty"`       // current and longest habit streaks
 	Insights            []string            `json:"insights"`
 }
 
@@ -100,6 +151,7 @@ type ProductivityMetrics struct {
 	AverageTaskDuration  float64 `json:"average_task_duration_days"`
 	MostProductiveType   string  `json:"most_productive_type"`
 	ProductivityScore    float64 `json:"productivity_score"`
+	HoursTrackedPeriod   float64 `json:"hours_tracked_period,omitempty"` // from start_timer/stop_timer
 }
 
 type PatternAnalysis struct {
@@ -117,23 +169,39 @@ type Trend struct {
 }
 
 type DailyActivity struct {
-	Date  string             `json:"date"`
-	Tasks map[string][]Entry `json:"tasks"` // task_id -> entries for that day
+	Date     string             `json:"date"`
+	Tasks    map[string][]Entry `json:"tasks"`              // task_id -> entries for that day
+	Snapshot *TaskSnapshot      `json:"snapshot,omitempty"` // active and blocked tasks at the start of the day
+	External []ExternalSummary  `json:"external,omitempty"` // nightly connector summaries
 }
 
-func NewJournalService() *JournalService {
+// DefaultDataDir returns the data directory, honoring the JOURNAL_MCP_DATA_DIR override
+func DefaultDataDir() string {
+	if dataDir := os.Getenv("JOURNAL_MCP_DATA_DIR"); dataDir != "" {
+		return dataDir
+	}
+
 	homeDir, _ := os.UserHomeDir()
-	dataDir := filepath.Join(homeDir, ".journal-mcp")
+	return filepath.Join(homeDir, ".journal-mcp")
+}
+
+func NewJournalService() *JournalService {
+	dataDir := DefaultDataDir()
 
 	// Ensure directories exist
-	os.MkdirAll(filepath.Join(dataDir, "tasks"), 0755)
-	os.MkdirAll(filepath.Join(dataDir, "daily"), 0755)
-	os.MkdirAll(filepath.Join(dataDir, "weekly"), 0755)
-	os.MkdirAll(filepath.Join(dataDir, "one-on-ones"), 0755)
+	ensureDataDirs(dataDir)
 
-	return &JournalService{
+	js := &JournalService{
 		DataDir: dataDir,
 	}
+
+	// Start in a named profile when requested
+	if profile := os.Getenv("JOURNAL_MCP_PROFILE"); profile != "" && validateProfileName(profile) == nil {
+		js.switchProfile(profile)
+		ensureDataDirs(js.dataDir())
+	}
+
+	return js
 }
 
 func (js *JournalService) CreateTask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -174,6 +242,27 @@ func (js *JournalService) CreateTask(ctx context.Context, request mcp.CallToolRe
 		task.Priority = priority
 	}
 
+	if estimate := request.GetString("estimate", ""); estimate != "" {
+		minutes, err := parseEstimate(estimate)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		task.EstimateMinutes = minutes
+	}
+
+	if dueDate := request.GetString("due_date", ""); dueDate != "" {
+		if err := js.validateDateFormat(dueDate, "due_date"); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		task.DueDate = dueDate
+	}
+
+	if request.GetString("someday", "false") == "true" {
+		task.Status = "someday"
+	}
+
+	task.Assignee = request.GetString("assignee", "")
+
 	if issueURL := request.GetString("issue_url", ""); issueURL != "" {
 		task.IssueURL = issueURL
 		// Extract issue ID from URL for easier referencing
@@ -201,6 +290,7 @@ func (js *JournalService) CreateTask(ctx context.Context, request mcp.CallToolRe
 		Content:   fmt.Sprintf("Task created: %s", title),
 		Type:      "creation",
 	})
+	tagged := js.applyTagRulesTo(&task, true)
 
 	// Save task
 	if err := js.saveTask(&task); err != nil {
@@ -219,7 +309,7 @@ func (js *JournalService) CreateTask(ctx context.Context, request mcp.CallToolRe
 		Content: []mcp.Content{
 			mcp.TextContent{
 				Type: "text",
-				Text: fmt.Sprintf("Created task %s: %s", id, title),
+				Text: fmt.Sprintf("Created task %s: %s%s", id, title, taggedSuffix(tagged)),
 			},
 		},
 	}, nil
@@ -235,6 +325,16 @@ func (js *JournalService) AddTaskEntry(ctx context.Context, request mcp.CallTool
 	if err != nil {
 		return mcp.NewToolResultError("content is required"), nil
 	}
+	mood, err := parseMoodLevel(request, "mood")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	energy, err := parseMoodLevel(request, "energy")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	defer js.lockTask(taskID)()
 
 	// Load existing task
 	task, err := js.loadTask(taskID)
@@ -255,11 +355,15 @@ func (js *JournalService) AddTaskEntry(ctx context.Context, request mcp.CallTool
 		ID:        generateEntryID(),
 		Timestamp: timestamp,
 		Content:   content,
-		Type:      "log",
+		Type:      request.GetString("entry_type", "log"),
+		Mood:      mood,
+		Energy:    energy,
+		Tags:      normalizeEntryTags(append(request.GetStringSlice("tags", nil), hashtags(content)...)),
 	}
 
 	task.Entries = append(task.Entries, entry)
 	task.Updated = time.Now()
+	tagged := js.applyTagRulesTo(task, false, entry)
 
 	// Save updated task
 	if err := js.saveTask(task); err != nil {
@@ -269,7 +373,7 @@ func (js *JournalService) AddTaskEntry(ctx context.Context, request mcp.CallTool
 	// Update daily log
 	js.updateDailyLog(taskID, entry)
 
-	return mcp.NewToolResultText(fmt.Sprintf("Added entry to task %s at %s", taskID, timestamp.Format("15:04"))), nil
+	return mcp.NewToolResultText(fmt.Sprintf("Added entry to task %s at %s%s", taskID, timestamp.Format("15:04"), taggedSuffix(tagged))), nil
 }
 
 func (js *JournalService) UpdateTaskEntry(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -287,6 +391,9 @@ func (js *JournalService) UpdateTaskEntry(ctx context.Context, request mcp.CallT
 	if err != nil {
 		return mcp.NewToolResultError("content is required"), nil
 	}
+	tags := request.GetStringSlice("tags", nil)
+
+	defer js.lockTask(taskID)()
 
 	// Load task
 	task, err := js.loadTask(taskID)
@@ -294,12 +401,30 @@ func (js *JournalService) UpdateTaskEntry(ctx context.Context, request mcp.CallT
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to load task: %v", err)), nil
 	}
 
-	// Find and update entry
+	// Find and update entry, keeping the previous content
 	found := false
 	for i, entry := range task.Entries {
 		if entry.ID == entryID {
-			task.Entries[i].Content = content
-			task.Updated = time.Now()
+			if entry.Type == "deleted" {
+				return mcp.NewToolResultError("Deletion records cannot be edited"), nil
+			}
+			updated := entry
+			updated.Content = content
+			if tags != nil {
+				// Given tags replace the entry's tags; hashtags in the text still count
+				updated.Tags = normalizeEntryTags(append(tags, hashtags(content)...))
+			} else {
+				retagEntry(&updated, entry.Content)
+			}
+			if entry.Content == content && equalStringSlices(entry.Tags, updated.Tags) {
+				return mcp.NewToolResultText(fmt.Sprintf("Entry in task %s is unchanged", taskID)), nil
+			}
+			now := time.Now()
+			if entry.Content != content {
+				updated.History = append(updated.History, EntryEdit{Content: entry.Content, EditedAt: now})
+			}
+			task.Entries[i] = updated
+			task.Updated = now
 			found = true
 			break
 		}
@@ -329,16 +454,34 @@ func (js *JournalService) GetTask(ctx context.Context, request mcp.CallToolReque
 	}
 
 	// Format task as markdown for easy reading
-	markdown := js.formatTaskAsMarkdown(task)
+	content := js.formatTaskAsMarkdown(task) + js.formatTaskHierarchy(task)
+	if request.GetString("show_entry_ids", "false") == "true" {
+		content += formatEntryIDs(task)
+	}
+	markdown, err := js.styleMarkdown(request.GetString("style", ""), content)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	return mcp.NewToolResultText(markdown), nil
 }
 
 func (js *JournalService) ListTasks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	lines, err := wantsNDJSON(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 	tasks, err := js.loadAllTasks()
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
 	}
+	if request.GetString("include_archived", "false") == "true" {
+		archived, err := js.loadArchivedTasks()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to load archived tasks: %v", err)), nil
+		}
+		tasks = append(tasks, archived...)
+	}
 
 	// Apply filters (use request.GetArguments() to get raw map for filtering)
 	filtered := js.filterTasks(tasks, request.GetArguments())
@@ -348,8 +491,17 @@ func (js *JournalService) ListTasks(ctx context.Context, request mcp.CallToolReq
 		return filtered[i].Updated.After(filtered[j].Updated)
 	})
 
+	// Focus mode hides noise and ranks what is left by triage score
+	focus := request.GetString("focus", "false") == "true"
+	if focus {
+		filtered = js.focusTasks(filtered, time.Now())
+	}
+
 	// Apply pagination
 	limit := 50 // default
+	if focus {
+		limit = js.focusLimit()
+	}
 	if limitStr := request.GetString("limit", ""); limitStr != "" {
 		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
 			if parsedLimit > 200 {
@@ -378,20 +530,34 @@ func (js *JournalService) ListTasks(ctx context.Context, request mcp.CallToolReq
 	}
 
 	paginatedTasks := filtered[startIndex:endIndex]
+	if lines {
+		return mcp.NewToolResultText(ndjson(taskLines(paginatedTasks))), nil
+	}
 
 	// Format as list
 	var result strings.Builder
-	result.WriteString(fmt.Sprintf("# Task List (showing %d-%d of %d total)\n\n",
-		startIndex+1, endIndex, totalTasks))
+	if startIndex == endIndex {
+		result.WriteString(fmt.Sprintf("# Task List (showing 0 of %d total)\n\n", totalTasks))
+	} else {
+		result.WriteString(fmt.Sprintf("# Task List (showing %d-%d of %d total)\n\n", startIndex+1, endIndex, totalTasks))
+	}
 
 	if len(paginatedTasks) == 0 {
 		result.WriteString("No tasks found matching the criteria.")
 		return mcp.NewToolResultText(result.String()), nil
 	}
 
+	if request.GetString("view", "list") == "tree" {
+		result.WriteString(formatTaskTree(paginatedTasks))
+		return mcp.NewToolResultText(result.String()), nil
+	}
+
 	for _, task := range paginatedTasks {
 		result.WriteString(fmt.Sprintf("## %s: %s\n", task.ID, task.Title))
 		result.WriteString(fmt.Sprintf("**Type:** %s | **Status:** %s", task.Type, task.Status))
+		if task.Archived {
+			result.WriteString(" (archived)")
+		}
 		if task.Priority != "" {
 			result.WriteString(fmt.Sprintf(" | **Priority:** %s", task.Priority))
 		}
@@ -402,6 +568,19 @@ func (js *JournalService) ListTasks(ctx context.Context, request mcp.CallToolReq
 		if task.IssueURL != "" {
 			result.WriteString(fmt.Sprintf("**Issue:** [%s](%s)\n", task.IssueID, task.IssueURL))
 		}
+		if task.ParentID != "" {
+			result.WriteString(fmt.Sprintf("**Parent:** %s\n", task.ParentID))
+		}
+		if task.DueDate != "" {
+			due := fmt.Sprintf("**Due:** %s", task.DueDate)
+			if isOverdue(task, time.Now().Format("2006-01-02")) {
+				due += " (overdue)"
+			}
+			result.WriteString(due + "\n")
+		}
+		if focus {
+			result.WriteString(fmt.Sprintf("**Triage score:** %d\n", js.triageScore(task, time.Now())))
+		}
 		result.WriteString(fmt.Sprintf("**Updated:** %s\n\n", task.Updated.Format("2006-01-02 15:04")))
 	}
 
@@ -420,7 +599,7 @@ func (js *JournalService) UpdateTaskStatus(ctx context.Context, request mcp.Call
 	}
 
 	// Validate status
-	validStatuses := []string{"active", "completed", "paused", "blocked"}
+	validStatuses := []string{"active", "completed", "paused", "blocked", "someday"}
 	isValid := false
 	for _, validStatus := range validStatuses {
 		if status == validStatus {
@@ -429,15 +608,28 @@ func (js *JournalService) UpdateTaskStatus(ctx context.Context, request mcp.Call
 		}
 	}
 	if !isValid {
-		return mcp.NewToolResultError("Invalid status. Must be: active, completed, paused, blocked"), nil
+		return mcp.NewToolResultError("Invalid status. Must be: active, completed, paused, blocked, someday"), nil
 	}
 
+	defer js.lockTask(taskID)()
+
 	// Load task
 	task, err := js.loadTask(taskID)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to load task: %v", err)), nil
 	}
 
+	// Completing a task with open dependencies needs an explicit override
+	if status == "completed" && request.GetString("force", "false") != "true" {
+		if open := js.openDependencies(task); len(open) > 0 {
+			var ids []string
+			for _, dep := range open {
+				ids = append(ids, fmt.Sprintf("%s (%s)", dep.ID, dep.Status))
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("Task %s is blocked by open dependencies: %s. Pass force=true to complete it anyway", taskID, strings.Join(ids, ", "))), nil
+		}
+	}
+
 	oldStatus := task.Status
 	task.Status = status
 	task.Updated = time.Now()
@@ -465,6 +657,10 @@ func (js *JournalService) UpdateTaskStatus(ctx context.Context, request mcp.Call
 	// Update daily log
 	js.updateDailyLog(taskID, entry)
 
+	if status == "completed" && oldStatus != "completed" {
+		js.bragCompletion(task)
+	}
+
 	return mcp.NewToolResultText(fmt.Sprintf("Updated task %s status to %s", taskID, status)), nil
 }
 
@@ -480,11 +676,22 @@ func (js *JournalService) GetDailyLog(ctx context.Context, request mcp.CallToolR
 		return mcp.NewToolResultError(validationErr.Error()), nil
 	}
 
-	// Load daily activity file if it exists
-	dailyPath := filepath.Join(js.DataDir, "daily", date+".json")
+	loc, logged, err := js.logLocation(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	lines, err := wantsNDJSON(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Load daily activity file if it exists; the stored logs are bucketed in
+	// general.timezone, so another zone is always gathered from the tasks
+	dailyPath := filepath.Join(js.dataDir(), "daily", date+".json")
 	var dailyActivity DailyActivity
 
-	if data, err := os.ReadFile(dailyPath); err == nil {
+	data, readErr := js.readDataFile(dailyPath)
+	if logged && readErr == nil {
 		json.Unmarshal(data, &dailyActivity)
 	} else {
 		// Create new daily activity by scanning all tasks for entries on this date
@@ -502,7 +709,7 @@ func (js *JournalService) GetDailyLog(ctx context.Context, request mcp.CallToolR
 		for _, task := range tasks {
 			var dayEntries []Entry
 			for _, entry := range task.Entries {
-				if entry.Timestamp.Format("2006-01-02") == date {
+				if entryDate(entry, loc) == date {
 					dayEntries = append(dayEntries, entry)
 				}
 			}
@@ -511,12 +718,49 @@ func (js *JournalService) GetDailyLog(ctx context.Context, request mcp.CallToolR
 			}
 		}
 
+		// Connector summaries only live in the stored log
+		if readErr == nil {
+			var stored DailyActivity
+			if json.Unmarshal(data, &stored) == nil {
+				dailyActivity.External = stored.External
+			}
+		}
+
 		// Save the daily activity for future reference
-		js.saveDailyActivity(&dailyActivity)
+		if logged {
+			js.saveDailyActivity(&dailyActivity)
+		}
+	}
+	for _, entries := range dailyActivity.Tasks {
+		for i := range entries {
+			entries[i].Timestamp = entries[i].Timestamp.In(loc)
+		}
+	}
+	if tags := normalizeEntryTags(request.GetStringSlice("tags", nil)); len(tags) > 0 {
+		for taskID, entries := range dailyActivity.Tasks {
+			entries = slices.DeleteFunc(entries, func(entry Entry) bool { return !entryHasTag(entry, tags) })
+			if len(entries) == 0 {
+				delete(dailyActivity.Tasks, taskID)
+				continue
+			}
+			dailyActivity.Tasks[taskID] = entries
+		}
+	}
+	if lines {
+		tasks := make(map[string]*Task)
+		for taskID := range dailyActivity.Tasks {
+			if task, err := js.loadTask(taskID); err == nil {
+				tasks[taskID] = task
+			}
+		}
+		return mcp.NewToolResultText(ndjson(dayLines([]DailyActivity{dailyActivity}, tasks))), nil
 	}
 
 	// Format as markdown
-	markdown := js.formatDailyLogAsMarkdown(&dailyActivity)
+	markdown, err := js.styleMarkdown(request.GetString("style", ""), js.formatDailyLogAsMarkdown(&dailyActivity))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	return mcp.NewToolResultText(markdown), nil
 }
@@ -533,82 +777,73 @@ func (js *JournalService) GetWeeklyLog(ctx context.Context, request mcp.CallTool
 	}
 
 	startDate, _ := time.Parse("2006-01-02", weekStart) // Safe to parse since validation passed
+	loc, logged, err := js.logLocation(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	lines, err := wantsNDJSON(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if lines {
+		days, tasks := js.weekActivity(startDate, loc, logged)
+		return mcp.NewToolResultText(ndjson(dayLines(days, tasks))), nil
+	}
 
 	var weeklyMarkdown strings.Builder
 	weeklyMarkdown.WriteString(fmt.Sprintf("# Weekly Log: %s to %s\n\n",
 		startDate.Format("2006-01-02"),
 		startDate.AddDate(0, 0, 6).Format("2006-01-02")))
 
-	totalEntries := 0
-	tasksWorked := make(map[string]bool)
+	weekEnd := startDate.AddDate(0, 0, 7).Format("2006-01-02")
+	writeDueSection(&weeklyMarkdown, "Overdue", js.tasksDue("", weekStart), weekStart)
+	writeDueSection(&weeklyMarkdown, "Due This Week", js.tasksDue(weekStart, weekEnd), weekStart)
 
-	// Aggregate daily logs for 7 days
-	for i := 0; i < 7; i++ {
-		currentDate := startDate.AddDate(0, 0, i)
-		dateStr := currentDate.Format("2006-01-02")
-
-		// Load daily activity file if it exists
-		dailyPath := filepath.Join(js.DataDir, "daily", dateStr+".json")
-		var dailyActivity DailyActivity
-
-		if data, err := os.ReadFile(dailyPath); err == nil {
-			if json.Unmarshal(data, &dailyActivity) == nil {
-				for taskID, entries := range dailyActivity.Tasks {
-					tasksWorked[taskID] = true
-					totalEntries += len(entries)
-				}
-			}
-		} else {
-			// Create daily activity by scanning tasks for this date
-			tasks, err := js.loadAllTasks()
-			if err == nil {
-				dailyActivity = DailyActivity{
-					Date:  dateStr,
-					Tasks: make(map[string][]Entry),
-				}
+	groupBy := request.GetString("group_by", "day")
+	order := request.GetString("order", "id")
+	if !slices.Contains(weeklyGroupings, groupBy) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid group_by: %s (expected %s)", groupBy, strings.Join(weeklyGroupings, ", "))), nil
+	}
+	if !slices.Contains(weeklyOrders, order) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid order: %s (expected %s)", order, strings.Join(weeklyOrders, ", "))), nil
+	}
 
-				for _, task := range tasks {
-					var dayEntries []Entry
-					for _, entry := range task.Entries {
-						if entry.Timestamp.Format("2006-01-02") == dateStr {
-							dayEntries = append(dayEntries, entry)
-						}
-					}
-					if len(dayEntries) > 0 {
-						dailyActivity.Tasks[task.ID] = dayEntries
-						tasksWorked[task.ID] = true
-						totalEntries += len(dayEntries)
+	// Aggregate daily logs for 7 days
+	days, tasks := js.weekActivity(startDate, loc, logged)
+	totalEntries, pages, pagesAfterHours := 0, 0, 0
+	tasksWorked := make(map[string]bool)
+	for _, day := range days {
+		for taskID, entries := range day.Tasks {
+			tasksWorked[taskID] = true
+			totalEntries += len(entries)
+			for _, entry := range entries {
+				if entry.Type == "page" {
+					pages++
+					if afterHours(entry.Timestamp, loc) {
+						pagesAfterHours++
 					}
 				}
 			}
 		}
+	}
 
-		weeklyMarkdown.WriteString(fmt.Sprintf("## %s (%s)\n",
-			dateStr, currentDate.Format("Monday")))
-
-		if len(dailyActivity.Tasks) == 0 {
-			weeklyMarkdown.WriteString("_No activity_\n\n")
-		} else {
-			for taskID, entries := range dailyActivity.Tasks {
-				if task, err := js.loadTask(taskID); err == nil {
-					weeklyMarkdown.WriteString(fmt.Sprintf("### %s: %s\n", taskID, task.Title))
-				} else {
-					weeklyMarkdown.WriteString(fmt.Sprintf("### %s\n", taskID))
-				}
-
-				for _, entry := range entries {
-					weeklyMarkdown.WriteString(fmt.Sprintf("- %s: %s\n",
-						entry.Timestamp.Format("15:04"), entry.Content))
-				}
-				weeklyMarkdown.WriteString("\n")
-			}
-		}
+	collapse := request.GetString("collapse_empty_days", "false") == "true"
+	switch groupBy {
+	case "task":
+		writeWeekByTask(&weeklyMarkdown, days, tasks, order)
+	case "type":
+		writeWeekByType(&weeklyMarkdown, days, tasks, order)
+	default:
+		writeWeekByDay(&weeklyMarkdown, days, tasks, order, collapse)
 	}
 
 	// Add weekly summary
 	weeklyMarkdown.WriteString("## Weekly Summary\n")
 	weeklyMarkdown.WriteString(fmt.Sprintf("- **Total entries:** %d\n", totalEntries))
 	weeklyMarkdown.WriteString(fmt.Sprintf("- **Tasks worked on:** %d\n", len(tasksWorked)))
+	if pages > 0 {
+		weeklyMarkdown.WriteString(fmt.Sprintf("- **On call:** %d pages, %d after hours\n", pages, pagesAfterHours))
+	}
 	if len(tasksWorked) > 0 {
 		weeklyMarkdown.WriteString("- **Tasks:** ")
 		var taskIDs []string
@@ -618,8 +853,14 @@ func (js *JournalService) GetWeeklyLog(ctx context.Context, request mcp.CallTool
 		sort.Strings(taskIDs)
 		weeklyMarkdown.WriteString(strings.Join(taskIDs, ", ") + "\n")
 	}
+	js.writeWeekHabits(&weeklyMarkdown, days, loc)
+
+	markdown, err := js.styleMarkdown(request.GetString("style", ""), weeklyMarkdown.String())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-	return mcp.NewToolResultText(weeklyMarkdown.String()), nil
+	return mcp.NewToolResultText(markdown), nil
 }
 
 func (js *JournalService) CreateOneOnOne(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -638,6 +879,12 @@ func (js *JournalService) CreateOneOnOne(ctx context.Context, request mcp.CallTo
 		Created: time.Now(),
 	}
 
+	// Names and aliases from team.members are stored under the member's name
+	if person := request.GetString("person", ""); person != "" {
+		config, _ := js.loadConfiguration()
+		oneOnOne.Person = teamMemberName(config, person)
+	}
+
 	// Parse optional arrays
 	if insights := request.GetStringSlice("insights", nil); insights != nil {
 		oneOnOne.Insights = insights
@@ -656,18 +903,24 @@ func (js *JournalService) CreateOneOnOne(ctx context.Context, request mcp.CallTo
 		oneOnOne.Notes = notes
 	}
 
-	// Save to file
-	filePath := filepath.Join(js.DataDir, "one-on-ones", date+".json")
-	data, err := json.MarshalIndent(oneOnOne, "", "  ")
+	linked, err := js.recordMeeting(&oneOnOne)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize one-on-one: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save one-on-one: %v", err)), nil
 	}
 
-	if err := os.WriteFile(filePath, data, 0644); err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to save one-on-one: %v", err)), nil
+	linked.Summary = fmt.Sprintf("Created one-on-one meeting notes for %s", date)
+	if oneOnOne.Person != "" {
+		linked.Summary = fmt.Sprintf("Created one-on-one meeting notes with %s for %s", oneOnOne.Person, date)
+	}
+	if len(oneOnOne.Todos) > 0 {
+		linked.Summary += fmt.Sprintf("; linked %d of %d todos to tasks", len(linked.Linked), len(oneOnOne.Todos))
+	}
+	if len(linked.Suggestions) > 0 {
+		linked.Summary += ". The rest have suggested tasks: create them with create_task"
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Created one-on-one meeting notes for %s", date)), nil
+	resultJSON, _ := json.MarshalIndent(linked, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
 }
 
 func (js *JournalService) GetOneOnOneHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -678,82 +931,55 @@ func (js *JournalService) GetOneOnOneHistory(ctx context.Context, request mcp.Ca
 		}
 	}
 
-	// Read all one-on-one files
-	oneOnOnesDir := filepath.Join(js.DataDir, "one-on-ones")
-	files, err := os.ReadDir(oneOnOnesDir)
+	meetings, err := js.loadOneOnOnes()
 	if err != nil {
-		// Directory might not exist yet
-		return mcp.NewToolResultText("# One-on-One History\n\nNo one-on-one meetings recorded yet."), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load one-on-ones: %v", err)), nil
 	}
 
-	var oneOnOnes []OneOnOne
-	for _, file := range files {
-		if strings.HasSuffix(file.Name(), ".json") {
-			filePath := filepath.Join(oneOnOnesDir, file.Name())
-			data, err := os.ReadFile(filePath)
-			if err != nil {
-				continue
-			}
+	person := request.GetString("person", "")
+	if person != "" {
+		config, _ := js.loadConfiguration()
+		person = teamMemberName(config, person)
+	}
 
-			var oneOnOne OneOnOne
-			if err := json.Unmarshal(data, &oneOnOne); err == nil {
-				oneOnOnes = append(oneOnOnes, oneOnOne)
-			}
+	// Most recent first
+	var oneOnOnes []*OneOnOne
+	for i := len(meetings) - 1; i >= 0; i-- {
+		if meetingWith(meetings[i], person) {
+			oneOnOnes = append(oneOnOnes, meetings[i])
 		}
 	}
 
-	// Sort by date (most recent first)
-	sort.Slice(oneOnOnes, func(i, j int) bool {
-		return oneOnOnes[i].Date > oneOnOnes[j].Date
-	})
-
 	// Apply limit
 	if len(oneOnOnes) > limit {
 		oneOnOnes = oneOnOnes[:limit]
 	}
 
+	// Action item status comes from the tasks they are linked to
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+	byID := make(map[string]*Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+
 	// Format as markdown
 	var markdown strings.Builder
 	markdown.WriteString("# One-on-One History\n\n")
 
+	if len(oneOnOnes) == 0 && person != "" {
+		markdown.WriteString(fmt.Sprintf("No one-on-one meetings with %s recorded yet.", person))
+		return mcp.NewToolResultText(markdown.String()), nil
+	}
 	if len(oneOnOnes) == 0 {
 		markdown.WriteString("No one-on-one meetings recorded yet.")
 		return mcp.NewToolResultText(markdown.String()), nil
 	}
 
 	for _, meeting := range oneOnOnes {
-		markdown.WriteString(fmt.Sprintf("## %s\n", meeting.Date))
-
-		if len(meeting.Insights) > 0 {
-			markdown.WriteString("**Insights:**\n")
-			for _, insight := range meeting.Insights {
-				markdown.WriteString(fmt.Sprintf("- %s\n", insight))
-			}
-			markdown.WriteString("\n")
-		}
-
-		if len(meeting.Todos) > 0 {
-			markdown.WriteString("**Action Items:**\n")
-			for _, todo := range meeting.Todos {
-				markdown.WriteString(fmt.Sprintf("- [ ] %s\n", todo))
-			}
-			markdown.WriteString("\n")
-		}
-
-		if len(meeting.Feedback) > 0 {
-			markdown.WriteString("**Feedback:**\n")
-			for _, fb := range meeting.Feedback {
-				markdown.WriteString(fmt.Sprintf("- %s\n", fb))
-			}
-			markdown.WriteString("\n")
-		}
-
-		if meeting.Notes != "" {
-			markdown.WriteString("**Notes:**\n")
-			markdown.WriteString(meeting.Notes + "\n\n")
-		}
-
-		markdown.WriteString("---\n\n")
+		writeMeetingMarkdown(&markdown, meeting, byID)
 	}
 
 	return mcp.NewToolResultText(markdown.String()), nil
@@ -765,12 +991,43 @@ func (js *JournalService) SearchEntries(ctx context.Context, request mcp.CallToo
 		return mcp.NewToolResultError("query is required"), nil
 	}
 
+	lines, err := wantsNDJSON(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	query = strings.ToLower(query)
+	queryWords := searchTokens(query)
+	fuzzy := request.GetString("fuzzy", "true") != "false"
+
+	// Pagination
+	limit := 50 // default
+	if limitStr := request.GetString("limit", ""); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit < 1 {
+			return mcp.NewToolResultError("limit must be a positive number"), nil
+		}
+		limit = min(parsedLimit, 200) // max limit
+	}
+	offset := 0
+	if offsetStr := request.GetString("offset", ""); offsetStr != "" {
+		parsedOffset, err := strconv.Atoi(offsetStr)
+		if err != nil || parsedOffset < 0 {
+			return mcp.NewToolResultError("offset must be zero or more"), nil
+		}
+		offset = parsedOffset
+	}
 
 	// Optional filters
 	taskType := request.GetString("task_type", "")
 	dateFrom := request.GetString("date_from", "")
 	dateTo := request.GetString("date_to", "")
+	person := request.GetString("person", "")
+	tags := normalizeEntryTags(request.GetStringSlice("tags", nil))
+	if person != "" {
+		config, _ := js.loadConfiguration()
+		person = teamMemberName(config, person)
+	}
 
 	// Parse dates safely (invalid dates are ignored with warning in logs)
 	fromTime := js.parseDateSafely(dateFrom)
@@ -779,17 +1036,26 @@ func (js *JournalService) SearchEntries(ctx context.Context, request mcp.CallToo
 		toTime = toTime.AddDate(0, 0, 1) // Include the entire day
 	}
 
-	// Search through all tasks
-	tasks, err := js.loadAllTasks()
+	// Search the tasks the index says can match
+	tasks, err := js.searchCandidateTasks(query, fuzzy)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
 	}
+	// Archived tasks are not indexed, so they are scanned in full
+	if request.GetString("include_archived", "false") == "true" {
+		archived, err := js.loadArchivedTasks()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to load archived tasks: %v", err)), nil
+		}
+		tasks = append(tasks, archived...)
+	}
 
 	type SearchResult struct {
 		TaskID    string
 		TaskTitle string
 		Entry     Entry
 		Context   string
+		Score     float64
 	}
 
 	var results []SearchResult
@@ -799,9 +1065,13 @@ func (js *JournalService) SearchEntries(ctx context.Context, request mcp.CallToo
 		if taskType != "" && task.Type != taskType {
 			continue
 		}
+		// With a person, only tasks assigned to them
+		if person != "" && !strings.EqualFold(task.Assignee, person) {
+			continue
+		}
 
 		// Search in task title and entries
-		taskMatches := strings.Contains(strings.ToLower(task.Title), query)
+		titleScore := searchScore(query, queryWords, task.Title, fuzzy) * titleWeight
 
 		for _, entry := range task.Entries {
 			// Filter by date range if specified
@@ -811,38 +1081,46 @@ func (js *JournalService) SearchEntries(ctx context.Context, request mcp.CallToo
 			if !toTime.IsZero() && entry.Timestamp.After(toTime) {
 				continue
 			}
+			if len(tags) > 0 && !entryHasTag(entry, tags) {
+				continue
+			}
 
-			entryMatches := strings.Contains(strings.ToLower(entry.Content), query)
+			entryScore := searchScore(query, queryWords, entry.Content, fuzzy)
 
-			if taskMatches || entryMatches {
+			if titleScore > 0 || entryScore > 0 {
 				context := "task"
-				if entryMatches {
+				if entryScore > 0 {
 					context = "entry"
 				}
-				if taskMatches && entryMatches {
+				if titleScore > 0 && entryScore > 0 {
 					context = "both"
 				}
 
+				title := task.Title
+				if task.Archived {
+					title += " (archived)"
+				}
 				results = append(results, SearchResult{
 					TaskID:    task.ID,
-					TaskTitle: task.Title,
+					TaskTitle: title,
 					Entry:     entry,
 					Context:   context,
+					Score:     math.Max(titleScore, entryScore),
 				})
 			}
 		}
 	}
 
-	// Search through one-on-ones
-	oneOnOnesDir := filepath.Join(js.DataDir, "one-on-ones")
-	if files, err := os.ReadDir(oneOnOnesDir); err == nil {
+	// Search through one-on-ones; meeting notes carry no entry tags
+	oneOnOnesDir := filepath.Join(js.dataDir(), "one-on-ones")
+	if files, err := os.ReadDir(oneOnOnesDir); len(tags) == 0 && err == nil {
 		for _, file := range files {
 			if !strings.HasSuffix(file.Name(), ".json") {
 				continue
 			}
 
 			filePath := filepath.Join(oneOnOnesDir, file.Name())
-			data, err := os.ReadFile(filePath)
+			data, err := js.readDataFile(filePath)
 			if err != nil {
 				continue
 			}
@@ -851,6 +1129,9 @@ func (js *JournalService) SearchEntries(ctx context.Context, request mcp.CallToo
 			if json.Unmarshal(data, &oneOnOne) != nil {
 				continue
 			}
+			if !meetingWith(&oneOnOne, person) {
+				continue
+			}
 
 			// Filter by date if specified
 			meetingDate, _ := time.Parse("2006-01-02", oneOnOne.Date)
@@ -863,26 +1144,81 @@ func (js *JournalService) SearchEntries(ctx context.Context, request mcp.CallToo
 
 			// Search in one-on-one content
 			searchText := strings.ToLower(oneOnOne.Notes + " " + strings.Join(oneOnOne.Insights, " ") + " " + strings.Join(oneOnOne.Todos, " ") + " " + strings.Join(oneOnOne.Feedback, " "))
-			if strings.Contains(searchText, query) {
+			if score := searchScore(query, queryWords, searchText, fuzzy); score > 0 {
+				title := fmt.Sprintf("One-on-One: %s", oneOnOne.Date)
+				if oneOnOne.Person != "" {
+					title = fmt.Sprintf("One-on-One: %s %s", oneOnOne.Person, oneOnOne.Date)
+				}
 				results = append(results, SearchResult{
 					TaskID:    "one-on-one",
-					TaskTitle: fmt.Sprintf("One-on-One: %s", oneOnOne.Date),
+					TaskTitle: title,
 					Entry: Entry{
 						Timestamp: meetingDate,
 						Content:   oneOnOne.Notes,
 						Type:      "one-on-one",
 					},
 					Context: "one-on-one",
+					Score:   score,
+				})
+			}
+		}
+	}
+
+	// Search through other meetings
+	if meetings, err := js.loadMeetings(); len(tags) == 0 && err == nil {
+		for _, meeting := range meetings {
+			if meetingType(meeting) == "one_on_one" || !meetingAttendedBy(meeting, person) {
+				continue
+			}
+			meetingDate, _ := time.Parse("2006-01-02", meeting.Date)
+			if !fromTime.IsZero() && meetingDate.Before(fromTime) || !toTime.IsZero() && meetingDate.After(toTime) {
+				continue
+			}
+
+			var texts []string
+			for _, items := range oneOnOneLists(meeting) {
+				texts = append(texts, *items...)
+			}
+			searchText := strings.ToLower(meeting.Title + " " + meeting.Notes + " " + strings.Join(texts, " "))
+			if score := searchScore(query, queryWords, searchText, fuzzy); score > 0 {
+				content := meeting.Notes
+				if content == "" {
+					content = strings.Join(meeting.Decisions, "; ")
+				}
+				results = append(results, SearchResult{
+					TaskID:    "meeting",
+					TaskTitle: fmt.Sprintf("Meeting: %s %s", meetingLabel(meeting), meeting.Date),
+					Entry: Entry{
+						Timestamp: meetingDate,
+						Content:   content,
+						Type:      "meeting",
+					},
+					Context: meetingType(meeting),
+					Score:   score,
 				})
 			}
 		}
 	}
 
-	// Sort results by relevance (timestamp descending)
-	sort.Slice(results, func(i, j int) bool {
+	// Sort results by relevance, newest first among equally relevant results
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
 		return results[i].Entry.Timestamp.After(results[j].Entry.Timestamp)
 	})
 
+	// One line per result; group_by_task is for reading, so it does not apply
+	if lines {
+		start, end := searchPage(len(results), limit, offset)
+		page := make([]LogLine, 0, end-start)
+		for _, result := range results[start:end] {
+			page = append(page, LogLine{Date: result.Entry.Timestamp.Format("2006-01-02"), TaskID: result.TaskID,
+				TaskTitle: result.TaskTitle, Context: result.Context, Score: result.Score, Entry: result.Entry})
+		}
+		return mcp.NewToolResultText(ndjson(page)), nil
+	}
+
 	// Format results
 	var markdown strings.Builder
 	markdown.WriteString(fmt.Sprintf("# Search Results for \"%s\"\n\n", query))
@@ -892,19 +1228,15 @@ func (js *JournalService) SearchEntries(ctx context.Context, request mcp.CallToo
 		return mcp.NewToolResultText(markdown.String()), nil
 	}
 
-	markdown.WriteString(fmt.Sprintf("Found %d matching entries:\n\n", len(results)))
-
-	for _, result := range results {
-		markdown.WriteString(fmt.Sprintf("## %s: %s\n", result.TaskID, result.TaskTitle))
-		markdown.WriteString(fmt.Sprintf("**Date:** %s | **Context:** %s\n\n",
-			result.Entry.Timestamp.Format("2006-01-02 15:04"), result.Context))
+	writeResult := func(result SearchResult, heading string) {
+		markdown.WriteString(fmt.Sprintf("%s**Date:** %s | **Context:** %s | **Score:** %.2f\n\n",
+			heading, result.Entry.Timestamp.Format("2006-01-02 15:04"), result.Context, result.Score))
 
 		// Highlight the matching content (simple approach)
 		content := result.Entry.Content
 		if len(content) > 200 {
 			// Find the query position and show context around it
-			lowerContent := strings.ToLower(content)
-			queryPos := strings.Index(lowerContent, query)
+			queryPos := matchOffset(content, query, queryWords)
 			if queryPos >= 0 {
 				start := max(0, queryPos-50)
 				end := min(len(content), queryPos+len(query)+100)
@@ -917,24 +1249,82 @@ func (js *JournalService) SearchEntries(ctx context.Context, request mcp.CallToo
 		markdown.WriteString(content + "\n\n---\n\n")
 	}
 
+	if request.GetString("group_by_task", "false") == "true" {
+		// Results are already in relevance order, so each task's group is
+		// ranked by its best result. One-on-ones are grouped per meeting.
+		var groups [][]SearchResult
+		index := make(map[string]int)
+		for _, result := range results {
+			key := result.TaskID + "\x00" + result.TaskTitle
+			i, ok := index[key]
+			if !ok {
+				i = len(groups)
+				index[key] = i
+				groups = append(groups, nil)
+			}
+			groups[i] = append(groups[i], result)
+		}
+
+		start, end := searchPage(len(groups), limit, offset)
+		markdown.WriteString(fmt.Sprintf("Found %d matching entries in %d tasks (showing tasks %s):\n\n",
+			len(results), len(groups), pageRange(start, end, len(groups))))
+		for _, group := range groups[start:end] {
+			markdown.WriteString(fmt.Sprintf("## %s: %s (%d)\n\n", group[0].TaskID, group[0].TaskTitle, len(group)))
+			for _, result := range group {
+				writeResult(result, "")
+			}
+		}
+		if end < len(groups) {
+			markdown.WriteString(fmt.Sprintf("More tasks match; use offset=%d for the next page.\n", end))
+		}
+		return mcp.NewToolResultText(markdown.String()), nil
+	}
+
+	start, end := searchPage(len(results), limit, offset)
+	markdown.WriteString(fmt.Sprintf("Found %d matching entries (showing %s):\n\n", len(results), pageRange(start, end, len(results))))
+	for _, result := range results[start:end] {
+		writeResult(result, fmt.Sprintf("## %s: %s\n", result.TaskID, result.TaskTitle))
+	}
+	if end < len(results) {
+		markdown.WriteString(fmt.Sprintf("More entries match; use offset=%d for the next page.\n", end))
+	}
+
 	return mcp.NewToolResultText(markdown.String()), nil
 }
 
+// searchPage returns the bounds of the page of total results starting at offset
+func searchPage(total, limit, offset int) (int, int) {
+	start := min(offset, total)
+	return start, min(start+limit, total)
+}
+
+// pageRange describes a page for a result header, e.g. "51-60", or "0 of 50"
+// for an offset past the end
+func pageRange(start, end, total int) string {
+	if start >= end {
+		return fmt.Sprintf("0 of %d", total)
+	}
+	return fmt.Sprintf("%d-%d", start+1, end)
+}
+
 func (js *JournalService) ExportData(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	format, err := request.RequireString("format")
 	if err != nil {
-		return mcp.NewToolResultError("format is required (json|markdown|csv)"), nil
+		return mcp.NewToolResultError("format is required (json|markdown|csv|pandoc|docbook)"), nil
 	}
 
 	// Validate format
-	if format != "json" && format != "markdown" && format != "csv" {
-		return mcp.NewToolResultError("Invalid format. Must be: json, markdown, csv"), nil
+	if format != "json" && format != "markdown" && format != "csv" && format != "pandoc" && format != "docbook" {
+		return mcp.NewToolResultError("Invalid format. Must be: json, markdown, csv, pandoc, docbook"), nil
 	}
 
 	// Optional filters
 	dateFrom := request.GetString("date_from", "")
 	dateTo := request.GetString("date_to", "")
 	taskFilter := request.GetString("task_filter", "")
+	taskIDs := request.GetStringSlice("task_ids", nil)
+	tags := request.GetStringSlice("tags", nil)
+	selecting := len(taskIDs) > 0 || len(tags) > 0
 
 	// Parse dates safely (invalid dates are ignored)
 	fromTime := js.parseDateSafely(dateFrom)
@@ -949,11 +1339,21 @@ func (js *JournalService) ExportData(ctx context.Context, request mcp.CallToolRe
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
 	}
 
+	for _, taskID := range taskIDs {
+		if !slices.ContainsFunc(tasks, func(task *Task) bool { return task.ID == taskID }) {
+			return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskID)), nil
+		}
+	}
+
 	var filteredTasks []*Task
 	for _, task := range tasks {
 		if taskFilter != "" && task.Type != taskFilter {
 			continue
 		}
+		// task_ids and tags select the tasks that match either
+		if selecting && !slices.Contains(taskIDs, task.ID) && !slices.ContainsFunc(task.Tags, func(tag string) bool { return slices.Contains(tags, tag) }) {
+			continue
+		}
 
 		// Filter entries by date if specified
 		var filteredEntries []Entry
@@ -976,17 +1376,17 @@ func (js *JournalService) ExportData(ctx context.Context, request mcp.CallToolRe
 		}
 	}
 
-	// Load one-on-ones if in date range
+	// Load one-on-ones if in date range; an export of selected tasks leaves them out
 	var oneOnOnes []OneOnOne
-	oneOnOnesDir := filepath.Join(js.DataDir, "one-on-ones")
-	if files, err := os.ReadDir(oneOnOnesDir); err == nil {
+	oneOnOnesDir := filepath.Join(js.dataDir(), "one-on-ones")
+	if files, err := os.ReadDir(oneOnOnesDir); err == nil && !selecting {
 		for _, file := range files {
 			if !strings.HasSuffix(file.Name(), ".json") {
 				continue
 			}
 
 			filePath := filepath.Join(oneOnOnesDir, file.Name())
-			data, err := os.ReadFile(filePath)
+			data, err := js.readDataFile(filePath)
 			if err != nil {
 				continue
 			}
@@ -1009,6 +1409,25 @@ func (js *JournalService) ExportData(ctx context.Context, request mcp.CallToolRe
 		}
 	}
 
+	// Replace names, URLs, IDs and emails so the export can be shared
+	if request.GetString("anonymize", "false") == "true" {
+		var members []TeamMember
+		if config, err := js.loadConfiguration(); err == nil {
+			members = config.Team.Members
+		}
+		names := request.GetStringSlice("names", nil)
+		for _, meeting := range oneOnOnes {
+			names = append(names, meeting.Person)
+		}
+		anon := newAnonymizer(members, names, tasks)
+		for i, task := range filteredTasks {
+			filteredTasks[i] = anon.task(task)
+		}
+		for i, meeting := range oneOnOnes {
+			oneOnOnes[i] = anon.oneOnOne(meeting)
+		}
+	}
+
 	// Export based on format
 	switch format {
 	case "json":
@@ -1017,6 +1436,9 @@ func (js *JournalService) ExportData(ctx context.Context, request mcp.CallToolRe
 			"one_on_ones": oneOnOnes,
 			"exported_at": time.Now().Format(time.RFC3339),
 		}
+		if request.GetString("include_attachments", "false") == "true" {
+			exportData["attachments"] = js.exportAttachments(filteredTasks)
+		}
 
 		jsonData, err := json.MarshalIndent(exportData, "", "  ")
 		if err != nil {
@@ -1026,42 +1448,19 @@ func (js *JournalService) ExportData(ctx context.Context, request mcp.CallToolRe
 		return mcp.NewToolResultText(string(jsonData)), nil
 
 	case "markdown":
-		var md strings.Builder
-		md.WriteString("# Journal Export\n\n")
-		md.WriteString(fmt.Sprintf("Exported on: %s\n\n", time.Now().Format("2006-01-02 15:04")))
-
-		if len(filteredTasks) > 0 {
-			md.WriteString("## Tasks\n\n")
-			for _, task := range filteredTasks {
-				md.WriteString(js.formatTaskAsMarkdown(task))
-				md.WriteString("\n---\n\n")
-			}
+		markdown, err := js.styleMarkdown(request.GetString("style", ""), js.formatExportAsMarkdown(filteredTasks, oneOnOnes))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
 		}
+		return mcp.NewToolResultText(markdown), nil
 
-		if len(oneOnOnes) > 0 {
-			md.WriteString("## One-on-One Meetings\n\n")
-			for _, meeting := range oneOnOnes {
-				md.WriteString(fmt.Sprintf("### %s\n", meeting.Date))
-				if len(meeting.Insights) > 0 {
-					md.WriteString("**Insights:**\n")
-					for _, insight := range meeting.Insights {
-						md.WriteString(fmt.Sprintf("- %s\n", insight))
-					}
-				}
-				if len(meeting.Todos) > 0 {
-					md.WriteString("**Action Items:**\n")
-					for _, todo := range meeting.Todos {
-						md.WriteString(fmt.Sprintf("- [ ] %s\n", todo))
-					}
-				}
-				if meeting.Notes != "" {
-					md.WriteString("**Notes:**\n" + meeting.Notes + "\n")
-				}
-				md.WriteString("\n")
-			}
+	case "pandoc", "docbook":
+		meta := js.documentMeta(request, "Journal Export", dateFrom, dateTo)
+		output, err := renderStructured(format, meta, js.formatExportAsMarkdown(filteredTasks, oneOnOnes))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to render %s: %v", format, err)), nil
 		}
-
-		return mcp.NewToolResultText(md.String()), nil
+		return mcp.NewToolResultText(output), nil
 
 	case "csv":
 		var csv strings.Builder
@@ -1088,8 +1487,13 @@ func (js *JournalService) ExportData(ctx context.Context, request mcp.CallToolRe
 				content += " | Todos: " + strings.Join(meeting.Todos, "; ")
 			}
 
-			csv.WriteString(fmt.Sprintf("one-on-one,%s,00:00,one-on-one,\"One-on-One Meeting\",\"%s\",meeting\n",
+			title := "One-on-One Meeting"
+			if meeting.Person != "" {
+				title += " with " + meeting.Person
+			}
+			csv.WriteString(fmt.Sprintf("one-on-one,%s,00:00,one-on-one,\"%s\",\"%s\",meeting\n",
 				meeting.Date,
+				strings.ReplaceAll(title, "\"", "\"\""),
 				strings.ReplaceAll(content, "\"", "\"\"")))
 		}
 
@@ -1100,10 +1504,13 @@ func (js *JournalService) ExportData(ctx context.Context, request mcp.CallToolRe
 }
 
 func (js *JournalService) ImportData(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	content, err := request.RequireString("content")
-	if err != nil {
+	content, note, err := importContent(request)
+	if errors.Is(err, errNoImportContent) {
 		return mcp.NewToolResultError("content is required"), nil
 	}
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read content: %v", err)), nil
+	}
 
 	if strings.TrimSpace(content) == "" {
 		return mcp.NewToolResultError("content cannot be empty"), nil
@@ -1129,21 +1536,45 @@ func (js *JournalService) ImportData(ctx context.Context, request mcp.CallToolRe
 		return mcp.NewToolResultError("default_type must be one of: work, learning, personal, investigation"), nil
 	}
 
+	dateOrder, err := js.importDateOrder(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	var delimiter rune
+	if value := request.GetString("delimiter", ""); value != "" {
+		if delimiter, err = parseCSVDelimiter(value); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+
+	job := newImportJob(format, importSourceName(request), content)
+
 	var result ImportResult
 	var warnings []string
 
 	switch format {
 	case "txt":
-		result, warnings = js.importFromPlainText(content, taskPrefix, defaultType)
+		result, warnings = js.importFromPlainText(content, taskPrefix, defaultType, job)
 	case "markdown":
-		result, warnings = js.importFromMarkdown(content, taskPrefix, defaultType)
+		result, warnings = js.importFromMarkdown(content, taskPrefix, defaultType, job)
 	case "json":
-		result, warnings = js.importFromJSON(content, taskPrefix, defaultType)
+		result, warnings = js.importFromJSON(content, taskPrefix, defaultType, job)
 	case "csv":
-		result, warnings = js.importFromCSV(content, taskPrefix, defaultType)
+		result, warnings = js.importFromCSV(content, taskPrefix, defaultType, csvImportOptions{delimiter: delimiter, dateOrder: dateOrder}, job)
 	}
 
+	if note != "" {
+		warnings = append([]string{note}, warnings...)
+	}
 	result.Warnings = warnings
+	if len(job.Tasks) > 0 {
+		if err := js.saveImportJob(job); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Failed to record the import for undo_import: %v", err))
+		} else {
+			result.JobID = job.JobID
+			result.Summary += fmt.Sprintf(" (import %s; undo_import reverses it)", job.JobID)
+		}
+	}
 
 	resultJSON, _ := json.MarshalIndent(result, "", "  ")
 	return mcp.NewToolResultText(string(resultJSON)), nil
@@ -1216,49 +1647,41 @@ func (js *JournalService) GetAnalyticsReport(ctx context.Context, request mcp.Ca
 	// Generate analytics report
 	report := js.generateAnalyticsReport(filteredTasks, reportType, timePeriod)
 
+	// Balance looks at recent weeks across every type, whatever the filters
+	if config, err := js.loadConfiguration(); err == nil {
+		report.Balance = calculateBalanceMetrics(allTasks, config.Quotas, time.Now(), js.location())
+		report.Insights = append(report.Insights, balanceInsights(report.Balance)...)
+	}
+
 	resultJSON, _ := json.MarshalIndent(report, "", "  ")
 	return mcp.NewToolResultText(string(resultJSON)), nil
 }
 
 // Helper methods
 func (js *JournalService) saveTask(task *Task) error {
-	filePath := filepath.Join(js.DataDir, "tasks", task.ID+".json")
-	data, err := json.MarshalIndent(task, "", "  ")
-	if err != nil {
+	task.Clock = task.Clock.tick(deviceID())
+	if err := js.storage().SaveTask(task); err != nil {
 		return err
 	}
-	return os.WriteFile(filePath, data, 0644)
+	js.indexTask(task)
+	return nil
 }
 
 func (js *JournalService) loadTask(taskID string) (*Task, error) {
-	filePath := filepath.Join(js.DataDir, "tasks", taskID+".json")
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		return nil, err
-	}
-
-	var task Task
-	if err := json.Unmarshal(data, &task); err != nil {
-		return nil, err
-	}
-
-	return &task, nil
+	return js.storage().LoadTask(taskID)
 }
 
 func (js *JournalService) loadAllTasks() ([]*Task, error) {
-	tasksDir := filepath.Join(js.DataDir, "tasks")
-	files, err := os.ReadDir(tasksDir)
+	store := js.storage()
+	taskIDs, err := store.ListTaskIDs()
 	if err != nil {
 		return nil, err
 	}
 
 	var tasks []*Task
-	for _, file := range files {
-		if strings.HasSuffix(file.Name(), ".json") {
-			taskID := strings.TrimSuffix(file.Name(), ".json")
-			if task, err := js.loadTask(taskID); err == nil {
-				tasks = append(tasks, task)
-			}
+	for _, taskID := range taskIDs {
+		if task, err := store.LoadTask(taskID); err == nil {
+			tasks = append(tasks, task)
 		}
 	}
 
@@ -1276,8 +1699,12 @@ func (js *JournalService) filterTasks(tasks []*Task, filters map[string]interfac
 	for _, task := range tasks {
 		include := true
 
-		// Filter by status
-		if status, exists := filters["status"].(string); exists && task.Status != status {
+		// Filter by status; someday tasks only show when asked for
+		if status, exists := filters["status"].(string); exists && status != "" {
+			if task.Status != status {
+				include = false
+			}
+		} else if task.Status == "someday" {
 			include = false
 		}
 
@@ -1286,6 +1713,23 @@ func (js *JournalService) filterTasks(tasks []*Task, filters map[string]interfac
 			include = false
 		}
 
+		// Filter by due date: overdue, due_today or due_this_week
+		if due, exists := filters["due"].(string); exists && !matchesDueFilter(task, due, time.Now()) {
+			include = false
+		}
+
+		// Filter by assignee
+		if assignee, exists := filters["assignee"].(string); exists && assignee != "" && !strings.EqualFold(task.Assignee, assignee) {
+			include = false
+		}
+
+		// Filter by parent ("none" keeps top-level tasks only)
+		if parent, exists := filters["parent"].(string); exists && parent != "" {
+			if parent == "none" && task.ParentID != "" || parent != "none" && task.ParentID != parent {
+				include = false
+			}
+		}
+
 		// Filter by tags
 		if tagsRaw, exists := filters["tags"].([]interface{}); exists {
 			hasTag := false
@@ -1361,6 +1805,153 @@ func (js *JournalService) parseDateSafely(dateStr string) time.Time {
 	return time.Time{}
 }
 
+// UpdateTask changes task metadata (title, type, priority, tags, issue URL, due date, assignee, snoozed) and logs what changed
+func (js *JournalService) UpdateTask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError("task_id is required"), nil
+	}
+
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load task: %v", err)), nil
+	}
+
+	var changes []string
+	setField := func(name string, field *string, value string) {
+		if value != *field {
+			changes = append(changes, fmt.Sprintf("%s: %q -> %q", name, *field, value))
+			*field = value
+		}
+	}
+
+	if title := request.GetString("title", ""); title != "" {
+		setField("title", &task.Title, title)
+	}
+	if taskType := request.GetString("type", ""); taskType != "" {
+		validTypes := map[string]bool{"work": true, "learning": true, "personal": true, "investigation": true}
+		if !validTypes[taskType] {
+			return mcp.NewToolResultError("Invalid type. Must be: work, learning, personal, investigation"), nil
+		}
+		setField("type", &task.Type, taskType)
+	}
+	if priority := request.GetString("priority", ""); priority != "" {
+		if priority == "none" {
+			priority = ""
+		}
+		setField("priority", &task.Priority, priority)
+	}
+	if issueURL := request.GetString("issue_url", ""); issueURL != "" {
+		setField("issue_url", &task.IssueURL, issueURL)
+	}
+	// "none" clears the assignee
+	if assignee := request.GetString("assignee", ""); assignee != "" {
+		if assignee == "none" {
+			assignee = ""
+		}
+		setField("assignee", &task.Assignee, assignee)
+	}
+	// "none" clears the due date
+	if dueDate := request.GetString("due_date", ""); dueDate != "" {
+		if dueDate == "none" {
+			dueDate = ""
+		} else if err := js.validateDateFormat(dueDate, "due_date"); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		setField("due_date", &task.DueDate, dueDate)
+	}
+	// "none" clears the estimate; a new estimate re-arms the re-estimation prompt
+	if value := request.GetString("estimate", ""); value != "" {
+		estimate := 0
+		if value != "none" {
+			parsed, err := parseEstimate(value)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			estimate = parsed
+		}
+		if estimate != task.EstimateMinutes {
+			changes = append(changes, fmt.Sprintf("estimate: %s -> %s", formatEstimate(task.EstimateMinutes), formatEstimate(estimate)))
+			task.EstimateMinutes = estimate
+			task.ReestimatePrompted = false
+		}
+	}
+	if snoozed := request.GetString("snoozed", ""); snoozed != "" && (snoozed == "true") != task.Snoozed {
+		changes = append(changes, fmt.Sprintf("snoozed: %t -> %t", task.Snoozed, !task.Snoozed))
+		task.Snoozed = !task.Snoozed
+	}
+	if tags := request.GetStringSlice("tags", nil); tags != nil && !equalStringSlices(tags, task.Tags) {
+		changes = append(changes, fmt.Sprintf("tags: [%s] -> [%s]", strings.Join(task.Tags, ", "), strings.Join(tags, ", ")))
+		task.Tags = tags
+	}
+
+	if len(changes) == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("No changes to task %s", taskID)), nil
+	}
+
+	entry := Entry{
+		ID:        generateEntryID(),
+		Timestamp: time.Now(),
+		Content:   "Task updated: " + strings.Join(changes, "; "),
+		Type:      "update",
+	}
+	task.Entries = append(task.Entries, entry)
+	task.Updated = time.Now()
+
+	if err := js.saveTask(task); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task: %v", err)), nil
+	}
+	js.updateDailyLog(taskID, entry)
+
+	return mcp.NewToolResultText(fmt.Sprintf("Updated task %s: %s", taskID, strings.Join(changes, "; "))), nil
+}
+
+// formatExportAsMarkdown renders exported tasks and one-on-ones as one markdown document
+func (js *JournalService) formatExportAsMarkdown(filteredTasks []*Task, oneOnOnes []OneOnOne) string {
+	var md strings.Builder
+	md.WriteString("# Journal Export\n\n")
+	md.WriteString(fmt.Sprintf("Exported on: %s\n\n", time.Now().Format("2006-01-02 15:04")))
+
+	if len(filteredTasks) > 0 {
+		md.WriteString("## Tasks\n\n")
+		for _, task := range filteredTasks {
+			md.WriteString(js.formatTaskAsMarkdown(task))
+			md.WriteString("\n---\n\n")
+		}
+	}
+
+	if len(oneOnOnes) > 0 {
+		md.WriteString("## One-on-One Meetings\n\n")
+		for _, meeting := range oneOnOnes {
+			if meeting.Person != "" {
+				md.WriteString(fmt.Sprintf("### %s with %s\n", meeting.Date, meeting.Person))
+			} else {
+				md.WriteString(fmt.Sprintf("### %s\n", meeting.Date))
+			}
+			if len(meeting.Insights) > 0 {
+				md.WriteString("**Insights:**\n")
+				for _, insight := range meeting.Insights {
+					md.WriteString(fmt.Sprintf("- %s\n", insight))
+				}
+			}
+			if len(meeting.Todos) > 0 {
+				md.WriteString("**Action Items:**\n")
+				for _, todo := range meeting.Todos {
+					md.WriteString(fmt.Sprintf("- [ ] %s\n", todo))
+				}
+			}
+			if meeting.Notes != "" {
+				md.WriteString("**Notes:**\n" + meeting.Notes + "\n")
+			}
+			md.WriteString("\n")
+		}
+	}
+
+	return md.String()
+}
+
 func (js *JournalService) formatTaskAsMarkdown(task *Task) string {
 	var md strings.Builder
 
@@ -1379,10 +1970,48 @@ func (js *JournalService) formatTaskAsMarkdown(task *Task) string {
 		md.WriteString(fmt.Sprintf("**Issue:** [%s](%s)\n", task.IssueID, task.IssueURL))
 	}
 
+	if task.DueDate != "" {
+		md.WriteString(fmt.Sprintf("**Due:** %s\n", task.DueDate))
+	}
+
+	if task.EstimateMinutes > 0 {
+		md.WriteString(fmt.Sprintf("**Estimate:** %s | **Tracked:** %s\n", formatMinutes(task.EstimateMinutes), formatMinutes(trackedMinutes(task))))
+	}
+
+	if task.Assignee != "" {
+		md.WriteString(fmt.Sprintf("**Assignee:** %s\n", task.Assignee))
+	}
+
 	md.WriteString(fmt.Sprintf("**Created:** %s | **Updated:** %s\n\n",
 		task.Created.Format("2006-01-02 15:04"),
 		task.Updated.Format("2006-01-02 15:04")))
 
+	if len(task.Fields) > 0 {
+		for _, name := range sortedKeys(task.Fields) {
+			value := task.Fields[name]
+			if strings.Contains(value, "\n") {
+				md.WriteString(fmt.Sprintf("**%s:**\n%s\n", name, value))
+			} else {
+				md.WriteString(fmt.Sprintf("**%s:** %s\n", name, value))
+			}
+		}
+		md.WriteString("\n")
+	}
+
+	md.WriteString(js.formatDependencies(task))
+
+	if len(task.Checklist) > 0 {
+		md.WriteString("**Checklist:**\n")
+		for _, item := range task.Checklist {
+			mark := " "
+			if item.Done {
+				mark = "x"
+			}
+			md.WriteString(fmt.Sprintf("- [%s] %s\n", mark, item.Text))
+		}
+		md.WriteString("\n")
+	}
+
 	// Group entries by date
 	entriesByDate := make(map[string][]Entry)
 	for _, entry := range task.Entries {
@@ -1406,22 +2035,46 @@ func (js *JournalService) formatTaskAsMarkdown(task *Task) string {
 		})
 
 		for _, entry := range entries {
-			md.WriteString(fmt.Sprintf("### %s\n", entry.Timestamp.Format("15:04")))
+			md.WriteString(fmt.Sprintf("### %s\n", entryTimeHeading(entry)))
 			md.WriteString(fmt.Sprintf("%s\n\n", entry.Content))
+			md.WriteString(formatAttachments(entry))
+			md.WriteString(formatEntryHistory(entry))
 		}
 	}
 
 	return md.String()
 }
 
+// logLocation is the time zone daily and weekly logs bucket entries in: the
+// timezone parameter, or general.timezone. logged reports whether that is the
+// zone the stored daily logs use.
+func (js *JournalService) logLocation(request mcp.CallToolRequest) (*time.Location, bool, error) {
+	configured := js.location()
+	name := request.GetString("timezone", "")
+	if name == "" {
+		return configured, true, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid timezone %q: use an IANA name such as Europe/Berlin", name)
+	}
+	return loc, loc.String() == configured.String(), nil
+}
+
+// entryDate is the day an entry falls on in loc
+func entryDate(entry Entry, loc *time.Location) string {
+	return entry.Timestamp.In(loc).Format("2006-01-02")
+}
+
 func (js *JournalService) updateDailyLog(taskID string, entry Entry) {
-	date := entry.Timestamp.Format("2006-01-02")
-	dailyPath := filepath.Join(js.DataDir, "daily", date+".json")
+	date := entryDate(entry, js.location())
+	dailyPath := filepath.Join(js.dataDir(), "daily", date+".json")
+	defer lockFile(dailyPath)()
 
 	var dailyActivity DailyActivity
 
 	// Load existing daily activity or create new one
-	if data, err := os.ReadFile(dailyPath); err == nil {
+	if data, err := js.readDataFile(dailyPath); err == nil {
 		json.Unmarshal(data, &dailyActivity)
 	} else {
 		dailyActivity = DailyActivity{
@@ -1430,20 +2083,60 @@ func (js *JournalService) updateDailyLog(taskID string, entry Entry) {
 		}
 	}
 
-	// Add entry to the task's entries for this day
-	dailyActivity.Tasks[taskID] = append(dailyActivity.Tasks[taskID], entry)
+	// Add entry to the task's entries for this day, replacing an earlier copy
+	// of it so re-run tools and restores do not log it twice
+	logged := dailyActivity.Tasks[taskID]
+	if i := slices.IndexFunc(logged, func(e Entry) bool { return e.ID == entry.ID }); entry.ID != "" && i >= 0 {
+		logged[i] = entry
+	} else {
+		dailyActivity.Tasks[taskID] = append(logged, entry)
+	}
 
 	// Save updated daily activity
 	js.saveDailyActivity(&dailyActivity)
 }
 
+// removeDailyLogEntry removes an entry's copy from its day's log, reporting whether it was there
+func (js *JournalService) removeDailyLogEntry(taskID string, entry Entry) (bool, error) {
+	dailyPath := filepath.Join(js.dataDir(), "daily", entryDate(entry, js.location())+".json")
+	defer lockFile(dailyPath)()
+
+	data, err := js.readDataFile(dailyPath)
+	if err != nil {
+		return false, nil // no daily log for that day
+	}
+	var activity DailyActivity
+	if err := json.Unmarshal(data, &activity); err != nil {
+		return false, err
+	}
+
+	var kept []Entry
+	found := false
+	for _, logged := range activity.Tasks[taskID] {
+		if logged.ID == entry.ID {
+			found = true
+			continue
+		}
+		kept = append(kept, logged)
+	}
+	if !found {
+		return false, nil
+	}
+	if len(kept) == 0 {
+		delete(activity.Tasks, taskID)
+	} else {
+		activity.Tasks[taskID] = kept
+	}
+	return true, js.saveDailyActivity(&activity)
+}
+
 func (js *JournalService) saveDailyActivity(activity *DailyActivity) error {
-	filePath := filepath.Join(js.DataDir, "daily", activity.Date+".json")
+	filePath := filepath.Join(js.dataDir(), "daily", activity.Date+".json")
 	data, err := json.MarshalIndent(activity, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(filePath, data, 0644)
+	return js.writeDataFile(filePath, data, 0644)
 }
 
 func (js *JournalService) formatDailyLogAsMarkdown(activity *DailyActivity) string {
@@ -1451,8 +2144,18 @@ func (js *JournalService) formatDailyLogAsMarkdown(activity *DailyActivity) stri
 
 	md.WriteString(fmt.Sprintf("# Daily Log: %s\n\n", activity.Date))
 
+	writeDueSection(&md, "Overdue", js.tasksDue("", activity.Date), activity.Date)
+
+	if len(activity.External) > 0 {
+		writeExternalMarkdown(&md, activity.External)
+	}
+
 	if len(activity.Tasks) == 0 {
 		md.WriteString("No activity recorded for this date.")
+		if activity.Snapshot != nil {
+			md.WriteString("\n\n")
+			writeSnapshotMarkdown(&md, activity.Snapshot)
+		}
 		return md.String()
 	}
 
@@ -1479,11 +2182,17 @@ func (js *JournalService) formatDailyLogAsMarkdown(activity *DailyActivity) stri
 		})
 
 		for _, entry := range entries {
-			md.WriteString(fmt.Sprintf("### %s\n", entry.Timestamp.Format("15:04")))
+			md.WriteString(fmt.Sprintf("### %s\n", entryTimeHeading(entry)))
 			md.WriteString(fmt.Sprintf("%s\n\n", entry.Content))
+			md.WriteString(formatAttachments(entry))
+			md.WriteString(formatEntryHistory(entry))
 		}
 	}
 
+	if activity.Snapshot != nil {
+		writeSnapshotMarkdown(&md, activity.Snapshot)
+	}
+
 	return md.String()
 }
 
@@ -1507,7 +2216,7 @@ func max(a, b int) int {
 }
 
 // Import helper functions
-func (js *JournalService) importFromPlainText(content, taskPrefix, defaultType string) (ImportResult, []string) {
+func (js *JournalService) importFromPlainText(content, taskPrefix, defaultType string, job *ImportJob) (ImportResult, []string) {
 	var result ImportResult
 	var warnings []string
 
@@ -1555,7 +2264,7 @@ func (js *JournalService) importFromPlainText(content, taskPrefix, defaultType s
 	}
 
 	if len(currentTask.Entries) > 0 {
-		if err := js.saveTask(currentTask); err != nil {
+		if err := js.saveImportedTask(currentTask, job); err != nil {
 			warnings = append(warnings, fmt.Sprintf("Failed to save task: %v", err))
 		} else {
 			result.TasksCreated++
@@ -1566,7 +2275,7 @@ func (js *JournalService) importFromPlainText(content, taskPrefix, defaultType s
 	return result, warnings
 }
 
-func (js *JournalService) importFromMarkdown(content, taskPrefix, defaultType string) (ImportResult, []string) {
+func (js *JournalService) importFromMarkdown(content, taskPrefix, defaultType string, job *ImportJob) (ImportResult, []string) {
 	var result ImportResult
 	var warnings []string
 
@@ -1583,7 +2292,7 @@ func (js *JournalService) importFromMarkdown(content, taskPrefix, defaultType st
 		if strings.HasPrefix(line, "#") {
 			// Save previous task if exists
 			if currentTask != nil && len(currentTask.Entries) > 0 {
-				if err := js.saveTask(currentTask); err != nil {
+				if err := js.saveImportedTask(currentTask, job); err != nil {
 					warnings = append(warnings, fmt.Sprintf("Failed to save task %s: %v", currentTask.ID, err))
 				} else {
 					result.TasksCreated++
@@ -1645,7 +2354,7 @@ func (js *JournalService) importFromMarkdown(content, taskPrefix, defaultType st
 
 	// Save last task
 	if currentTask != nil && len(currentTask.Entries) > 0 {
-		if err := js.saveTask(currentTask); err != nil {
+		if err := js.saveImportedTask(currentTask, job); err != nil {
 			warnings = append(warnings, fmt.Sprintf("Failed to save task %s: %v", currentTask.ID, err))
 		} else {
 			result.TasksCreated++
@@ -1656,7 +2365,7 @@ func (js *JournalService) importFromMarkdown(content, taskPrefix, defaultType st
 	return result, warnings
 }
 
-func (js *JournalService) importFromJSON(content, taskPrefix, defaultType string) (ImportResult, []string) {
+func (js *JournalService) importFromJSON(content, taskPrefix, defaultType string, job *ImportJob) (ImportResult, []string) {
 	var result ImportResult
 	var warnings []string
 
@@ -1721,7 +2430,7 @@ func (js *JournalService) importFromJSON(content, taskPrefix, defaultType string
 			}
 
 			if len(task.Entries) > 0 {
-				if err := js.saveTask(task); err != nil {
+				if err := js.saveImportedTask(task, job); err != nil {
 					warnings = append(warnings, fmt.Sprintf("Failed to save task %s: %v", task.ID, err))
 				} else {
 					result.TasksCreated++
@@ -1751,7 +2460,7 @@ func (js *JournalService) importFromJSON(content, taskPrefix, defaultType string
 		task.Entries = append(task.Entries, entry)
 		result.EntriesAdded++
 
-		if err := js.saveTask(task); err != nil {
+		if err := js.saveImportedTask(task, job); err != nil {
 			warnings = append(warnings, fmt.Sprintf("Failed to save task: %v", err))
 		} else {
 			result.TasksCreated++
@@ -1762,21 +2471,52 @@ func (js *JournalService) importFromJSON(content, taskPrefix, defaultType string
 	return result, warnings
 }
 
-func (js *JournalService) importFromCSV(content, taskPrefix, defaultType string) (ImportResult, []string) {
+// csvImportOptions are the locale settings for a CSV import
+type csvImportOptions struct {
+	delimiter rune   // 0 sniffs it from the first lines
+	dateOrder string // auto, dmy or mdy
+}
+
+func (js *JournalService) importFromCSV(content, taskPrefix, defaultType string, options csvImportOptions, job *ImportJob) (ImportResult, []string) {
 	var result ImportResult
 	var warnings []string
 
-	lines := strings.Split(content, "\n")
-	if len(lines) < 2 {
+	delimiter := options.delimiter
+	if delimiter == 0 {
+		delimiter, content = sniffCSVDelimiter(content)
+	}
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	type csvRow struct {
+		line   int
+		fields []string
+	}
+	var rows []csvRow
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Row %d could not be read: %v", line, err))
+			continue
+		}
+		rows = append(rows, csvRow{line: line, fields: fields})
+	}
+	if len(rows) < 2 {
 		warnings = append(warnings, "CSV must have at least header and one data row")
 		result.Summary = "Invalid CSV format"
 		return result, warnings
 	}
 
 	// Parse header
-	header := strings.Split(lines[0], ",")
+	header := rows[0].fields
 	for i := range header {
-		header[i] = strings.TrimSpace(strings.Trim(header[i], "\""))
+		header[i] = strings.TrimSpace(header[i])
 	}
 
 	// Find column indices
@@ -1798,24 +2538,38 @@ func (js *JournalService) importFromCSV(content, taskPrefix, defaultType string)
 		return result, warnings
 	}
 
+	// Day or month first is settled once for the whole file
+	dateOrder := options.dateOrder
+	if dateCol >= 0 {
+		var dates []string
+		for _, row := range rows[1:] {
+			if dateCol < len(row.fields) {
+				dates = append(dates, row.fields[dateCol])
+			}
+		}
+		var warning string
+		if dateOrder, warning = resolveDateOrder(dateOrder, dates); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	loc := js.location()
+
 	taskMap := make(map[string]*Task)
 
 	// Process data rows
-	for lineNum, line := range lines[1:] {
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		fields := js.parseCSVLine(line)
+	for _, row := range rows[1:] {
+		fields := row.fields
 		if len(fields) <= max(titleCol, max(dateCol, contentCol)) {
-			warnings = append(warnings, fmt.Sprintf("Row %d has insufficient columns", lineNum+2))
+			if len(fields) > 1 || strings.TrimSpace(fields[0]) != "" {
+				warnings = append(warnings, fmt.Sprintf("Row %d has insufficient columns", row.line))
+			}
 			continue
 		}
 
 		// Determine task ID
 		taskID := fmt.Sprintf("%s-%d", taskPrefix, time.Now().Unix())
 		if titleCol >= 0 && titleCol < len(fields) && strings.TrimSpace(fields[titleCol]) != "" {
-			taskTitle := strings.TrimSpace(strings.Trim(fields[titleCol], "\""))
+			taskTitle := strings.TrimSpace(fields[titleCol])
 			taskID = fmt.Sprintf("%s-%s", taskPrefix, strings.ReplaceAll(taskTitle, " ", "-"))
 		}
 
@@ -1823,7 +2577,7 @@ func (js *JournalService) importFromCSV(content, taskPrefix, defaultType string)
 		if _, exists := taskMap[taskID]; !exists {
 			title := "Imported from CSV"
 			if titleCol >= 0 && titleCol < len(fields) {
-				title = strings.TrimSpace(strings.Trim(fields[titleCol], "\""))
+				title = strings.TrimSpace(fields[titleCol])
 			}
 
 			taskMap[taskID] = &Task{
@@ -1840,16 +2594,16 @@ func (js *JournalService) importFromCSV(content, taskPrefix, defaultType string)
 		// Parse timestamp
 		timestamp := time.Now()
 		if dateCol >= 0 && dateCol < len(fields) {
-			dateStr := strings.TrimSpace(strings.Trim(fields[dateCol], "\""))
-			if parsed, err := time.Parse("2006-01-02", dateStr); err == nil {
-				timestamp = parsed
-			} else if parsed, err := time.Parse("01/02/2006", dateStr); err == nil {
+			dateStr := strings.TrimSpace(fields[dateCol])
+			if parsed, ok := parseImportDate(dateStr, dateOrder, loc); ok {
 				timestamp = parsed
+			} else if dateStr != "" {
+				warnings = append(warnings, fmt.Sprintf("Row %d has an unrecognized date %q; imported as now", row.line, dateStr))
 			}
 		}
 
 		// Create entry
-		content := strings.TrimSpace(strings.Trim(fields[contentCol], "\""))
+		content := strings.TrimSpace(fields[contentCol])
 		if content != "" {
 			entry := Entry{
 				ID:        generateEntryID(),
@@ -1866,7 +2620,7 @@ func (js *JournalService) importFromCSV(content, taskPrefix, defaultType string)
 	// Save all tasks
 	for _, task := range taskMap {
 		if len(task.Entries) > 0 {
-			if err := js.saveTask(task); err != nil {
+			if err := js.saveImportedTask(task, job); err != nil {
 				warnings = append(warnings, fmt.Sprintf("Failed to save task %s: %v", task.ID, err))
 			} else {
 				result.TasksCreated++
@@ -1897,31 +2651,6 @@ func (js *JournalService) extractTimestamp(text string) (bool, time.Time) {
 	return false, time.Time{}
 }
 
-func (js *JournalService) parseCSVLine(line string) []string {
-	var fields []string
-	var current strings.Builder
-	inQuotes := false
-
-	for _, char := range line {
-		switch char {
-		case '"':
-			inQuotes = !inQuotes
-		case ',':
-			if !inQuotes {
-				fields = append(fields, current.String())
-				current.Reset()
-			} else {
-				current.WriteRune(char)
-			}
-		default:
-			current.WriteRune(char)
-		}
-	}
-
-	fields = append(fields, current.String())
-	return fields
-}
-
 // AI-assisted recommendation analysis
 func (js *JournalService) analyzeAndRecommend(tasks []*Task, taskType, focusArea string, limit int) []TaskRecommendation {
 	var recommendations []TaskRecommendation
@@ -1962,7 +2691,7 @@ func (js *JournalService) generateProductivityRecommendations(tasks []*Task, lim
 			recommendations = append(recommendations, TaskRecommendation{
 				Type:          "task_breakdown",
 				Title:         fmt.Sprintf("Break down '%s' into smaller tasks", task.Title),
-				Description:   "This task has many entries and might benefit from being split into smaller, more manageable tasks",
+				Description:   "This task has many entries and might benefit from being split into smaller, more manageable tasks with create_subtask",
 				Rationale:     fmt.Sprintf("Task has %d entries, suggesting it's complex and could be decomposed", len(task.Entries)),
 				Priority:      "medium",
 				Confidence:    0.7,
@@ -2315,8 +3044,31 @@ func (js *JournalService) generateAnalyticsReport(tasks []*Task, reportType, tim
 		ProductivityMetrics: js.calculateProductivityMetrics(tasks, timePeriod),
 		PatternAnalysis:     js.calculatePatternAnalysis(tasks),
 		Insights:            js.generateInsights(tasks, reportType),
+		Impact:              js.calculateImpactMetrics(tasks),
+		Tone:                js.calculateToneMetrics(tasks),
+		Estimates:           js.calculateEstimateMetrics(tasks),
+		EntryLength:         js.calculateEntryLengthMetrics(tasks),
+	}
+
+	if report.Tone != nil {
+		for _, stretch := range report.Tone.NegativeStretches {
+			report.Insights = append(report.Insights, fmt.Sprintf("Your entries have read negative for a sustained stretch (%s). Consider what is weighing on you.", stretch))
+		}
 	}
 
+	report.Insights = append(report.Insights, estimateInsights(report.Estimates)...)
+	report.Insights = append(report.Insights, entryLengthInsights(report.EntryLength)...)
+	report.Insights = append(report.Insights, js.quotaInsights(time.Now())...)
+
+	report.OnCall = calculateOnCallMetrics(tasks, js.firstWeekday(), js.location())
+	report.Insights = append(report.Insights, onCallInsights(report.OnCall)...)
+
+	report.Wellbeing = calculateWellbeingMetrics(tasks, js.firstWeekday(), js.location())
+	report.Insights = append(report.Insights, wellbeingInsights(report.Wellbeing)...)
+
+	report.Habits, _ = js.habitStatuses(time.Now(), defaultStreakWeeks)
+	report.Insights = append(report.Insights, habitInsights(report.Habits, defaultStreakWeeks)...)
+
 	if reportType == "trends" || reportType == "overview" {
 		report.Trends = js.calculateTrends(tasks, timePeriod)
 	}
@@ -2371,6 +3123,7 @@ func (js *JournalService) calculateProductivityMetrics(tasks []*Task, timePeriod
 	var totalDuration float64
 	var durationCount int
 	typeEntries := make(map[string]int)
+	trackedMinutes := make(map[string]int)
 	entriesInPeriod := 0
 
 	// Calculate time period bounds
@@ -2395,6 +3148,7 @@ func (js *JournalService) calculateProductivityMetrics(tasks []*Task, timePeriod
 			if timePeriod == "all" || entry.Timestamp.After(periodStart) {
 				entriesInPeriod++
 				typeEntries[task.Type]++
+				trackedMinutes[task.Type] += entry.Minutes
 			}
 		}
 
@@ -2420,7 +3174,16 @@ func (js *JournalService) calculateProductivityMetrics(tasks []*Task, timePeriod
 		metrics.AverageTaskDuration = totalDuration / float64(durationCount)
 	}
 
-	// Find most productive type
+	// Find most productive type, by tracked time when there is any
+	totalMinutes := 0
+	for _, minutes := range trackedMinutes {
+		totalMinutes += minutes
+	}
+	metrics.HoursTrackedPeriod = roundHours(totalMinutes)
+	if totalMinutes > 0 {
+		typeEntries = trackedMinutes
+	}
+
 	maxEntries := 0
 	for taskType, entries := range typeEntries {
 		if entries > maxEntries {
@@ -602,6 +602,41 @@ func TestExportData(t *testing.T) {
 	}
 }
 
+func TestExportDataSelectedTasks(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "HAND-1", "Payments retry", "work")
+	createTestTask(t, js, "HAND-2", "Ledger cleanup", "work")
+	createTestTask(t, js, "OTHER-1", "Team offsite", "personal")
+	task, _ := js.loadTask("HAND-2")
+	task.Tags = []string{"handoff"}
+	js.saveTask(task)
+	js.saveOneOnOne(&OneOnOne{Date: "2026-03-14", Notes: "Private notes"})
+
+	result, _ := js.ExportData(ctx, CreateMockRequest(map[string]interface{}{
+		"format": "json", "task_ids": []interface{}{"HAND-1"}, "tags": []interface{}{"handoff"},
+	}))
+	var export struct {
+		Tasks     []Task     `json:"tasks"`
+		OneOnOnes []OneOnOne `json:"one_on_ones"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &export); err != nil {
+		t.Fatalf("Invalid export: %v", err)
+	}
+	ids := map[string]bool{}
+	for _, task := range export.Tasks {
+		ids[task.ID] = true
+	}
+	if len(ids) != 2 || !ids["HAND-1"] || !ids["HAND-2"] || len(export.OneOnOnes) != 0 {
+		t.Errorf("Expected only the selected tasks, got %v and %d one-on-ones", ids, len(export.OneOnOnes))
+	}
+
+	result, _ = js.ExportData(ctx, CreateMockRequest(map[string]interface{}{"format": "csv", "task_ids": []interface{}{"NOPE-1"}}))
+	if !result.IsError || !contains(result.Content[0].(mcp.TextContent).Text, "NOPE-1") {
+		t.Error("Expected an unknown task ID to be reported")
+	}
+}
+
 // Helper function
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
@@ -748,6 +783,42 @@ func TestGetOneOnOneHistory(t *testing.T) {
 	}
 }
 
+func TestOneOnOnesPerPerson(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	config := defaultConfiguration()
+	config.Team.Members = []TeamMember{{Name: "Sam Lee", Aliases: []string{"@sam"}}}
+	js.saveConfiguration(config)
+
+	// A legacy meeting without a person, then two people on the same day
+	legacy, _ := json.MarshalIndent(OneOnOne{Date: "2025-01-01", Notes: "Old format"}, "", "  ")
+	os.WriteFile(filepath.Join(tempDir, "one-on-ones", "2025-01-01.json"), legacy, 0644)
+	js.CreateOneOnOne(ctx, CreateMockRequest(map[string]interface{}{"date": "2025-02-03", "person": "@sam", "notes": "Roadmap review"}))
+	js.CreateOneOnOne(ctx, CreateMockRequest(map[string]interface{}{"date": "2025-02-03", "person": "Dana", "notes": "Roadmap budget"}))
+
+	for _, name := range []string{"sam-lee-2025-02-03.json", "dana-2025-02-03.json", "2025-01-01.json"} {
+		if _, err := os.Stat(filepath.Join(tempDir, "one-on-ones", name)); err != nil {
+			t.Errorf("Expected %s: %v", name, err)
+		}
+	}
+
+	result, _ := js.GetOneOnOneHistory(ctx, CreateMockRequest(map[string]interface{}{"person": "sam lee"}))
+	text := result.Content[0].(mcp.TextContent).Text
+	if !contains(text, "## 2025-02-03 with Sam Lee") || contains(text, "Dana") || contains(text, "Old format") {
+		t.Errorf("Expected only the meeting with Sam, got:\n%s", text)
+	}
+	result, _ = js.GetOneOnOneHistory(ctx, CreateMockRequest(map[string]interface{}{}))
+	if text := result.Content[0].(mcp.TextContent).Text; !contains(text, "Sam Lee") || !contains(text, "Dana") || !contains(text, "Old format") {
+		t.Errorf("Expected all three meetings, got:\n%s", text)
+	}
+
+	result, _ = js.SearchEntries(ctx, CreateMockRequest(map[string]interface{}{"query": "roadmap", "person": "Dana"}))
+	text = result.Content[0].(mcp.TextContent).Text
+	if !contains(text, "One-on-One: Dana 2025-02-03") || contains(text, "Sam Lee") {
+		t.Errorf("Expected only Dana's meeting in the search, got:\n%s", text)
+	}
+}
+
 func TestGetWeeklyLog(t *testing.T) {
 	js, tempDir := CreateTestJournalService(t)
 	ctx := context.Background()
@@ -0,0 +1,303 @@
+package servers
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// knowledgeTaskID is the default task that collects answered questions
+const knowledgeTaskID = "knowledge-sharing"
+
+// stackExchangeAPI is the Stack Exchange API base URL; tests point it at a fake server
+var stackExchangeAPI = "https://api.stackexchange.com/2.3/"
+
+// ImpactMetrics summarizes knowledge-sharing work for reviews
+type ImpactMetrics struct {
+	AnswersLogged      int            `json:"answers_logged"`
+	AcceptedAnswers    int            `json:"accepted_answers"`
+	DiscussionActivity int            `json:"discussion_activity"`
+	BySource           map[string]int `json:"by_source"`
+	Highlights         []string       `json:"highlights,omitempty"`
+}
+
+// AnswerSyncResult summarizes a Stack Exchange answer sync
+type AnswerSyncResult struct {
+	AnswersFound int      `json:"answers_found"`
+	EntriesAdded int      `json:"entries_added"`
+	TaskID       string   `json:"task_id"`
+	Errors       []string `json:"errors,omitempty"`
+	Summary      string   `json:"summary"`
+}
+
+// answerEntry builds the journal entry for an answered question. Entries with a
+// link get an ID derived from it so logging the same answer twice is a no-op.
+func answerEntry(title, link, source string, accepted bool, answeredAt time.Time) Entry {
+	content := fmt.Sprintf("Answered [%s]: %s", source, title)
+	if link != "" {
+		content += fmt.Sprintf(" (%s)", link)
+	}
+	if accepted {
+		content += " [accepted]"
+	}
+
+	id := generateEntryID()
+	if link != "" {
+		sum := sha1.Sum([]byte(link))
+		id = "answer_" + hex.EncodeToString(sum[:6])
+	}
+
+	return Entry{ID: id, Timestamp: answeredAt, Content: content, Type: "answer"}
+}
+
+// appendAnswerEntries adds entries to the knowledge-sharing task, creating it if
+// needed. Entries whose ID is already recorded have their content refreshed so
+// a later accepted status is picked up.
+func (js *JournalService) appendAnswerEntries(taskID string, entries []Entry) (int, error) {
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return 0, err
+		}
+		now := time.Now()
+		task = &Task{
+			ID:      taskID,
+			Title:   "Knowledge sharing",
+			Type:    "work",
+			Tags:    []string{"knowledge-sharing", "mentorship"},
+			Status:  "active",
+			Created: now,
+			Updated: now,
+			Entries: []Entry{},
+		}
+	}
+
+	existing := make(map[string]int)
+	for i, entry := range task.Entries {
+		existing[entry.ID] = i
+	}
+
+	added := 0
+	for _, entry := range entries {
+		if i, ok := existing[entry.ID]; ok {
+			task.Entries[i].Content = entry.Content
+			continue
+		}
+		existing[entry.ID] = len(task.Entries)
+		task.Entries = append(task.Entries, entry)
+		js.updateDailyLog(taskID, entry)
+		added++
+	}
+
+	task.Updated = time.Now()
+	return added, js.saveTask(task)
+}
+
+// LogAnswer records a question answered (Stack Overflow, internal Q&A, chat) on the knowledge-sharing task
+func (js *JournalService) LogAnswer(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	title, err := request.RequireString("title")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	link := request.GetString("url", "")
+	source := request.GetString("source", "internal")
+	accepted := request.GetString("accepted", "false") == "true"
+	taskID := request.GetString("task_id", knowledgeTaskID)
+
+	answeredAt := time.Now()
+	if answeredStr := request.GetString("answered_at", ""); answeredStr != "" {
+		answeredAt = js.parseDateSafely(answeredStr)
+		if answeredAt.IsZero() {
+			return mcp.NewToolResultError("Invalid answered_at date format. Use YYYY-MM-DD"), nil
+		}
+	}
+
+	entry := answerEntry(title, link, source, accepted, answeredAt)
+	added, err := js.appendAnswerEntries(taskID, []Entry{entry})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to log answer: %v", err)), nil
+	}
+
+	if added == 0 {
+		return mcp.NewToolResultText(fmt.Sprintf("Answer already logged on %s; updated: %s", taskID, entry.Content)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Logged answer on %s: %s", taskID, entry.Content)), nil
+}
+
+// SyncStackOverflowAnswers pulls a user's answers from the Stack Exchange API into the knowledge-sharing task
+func (js *JournalService) SyncStackOverflowAnswers(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	config, _ := js.loadConfiguration()
+	if config == nil {
+		config = defaultConfiguration()
+	}
+
+	userID := request.GetString("user_id", "")
+	if userID == "" && config.StackExchange.UserID != 0 {
+		userID = fmt.Sprintf("%d", config.StackExchange.UserID)
+	}
+	if userID == "" {
+		return mcp.NewToolResultError("user_id is required (or set stack_exchange.user_id in config)"), nil
+	}
+
+	site := request.GetString("site", config.StackExchange.Site)
+	if site == "" {
+		site = "stackoverflow"
+	}
+	taskID := request.GetString("task_id", knowledgeTaskID)
+
+	params := url.Values{}
+	params.Set("site", site)
+	params.Set("pagesize", "100")
+	params.Set("order", "desc")
+	params.Set("sort", "creation")
+	if sinceStr := request.GetString("since", ""); sinceStr != "" {
+		since, err := time.Parse("2006-01-02", sinceStr)
+		if err != nil {
+			return mcp.NewToolResultError("Invalid since date format. Use YYYY-MM-DD"), nil
+		}
+		params.Set("fromdate", fmt.Sprintf("%d", since.Unix()))
+	}
+	// An API key is optional; it only raises the request quota
+	if key, err := js.getSecret("stackexchange_key"); err == nil && key != "" {
+		params.Set("key", key)
+	}
+
+	var answers struct {
+		Items []struct {
+			QuestionID   int64 `json:"question_id"`
+			AnswerID     int64 `json:"answer_id"`
+			IsAccepted   bool  `json:"is_accepted"`
+			CreationDate int64 `json:"creation_date"`
+		} `json:"items"`
+	}
+	if err := getStackExchange(ctx, fmt.Sprintf("users/%s/answers", url.PathEscape(userID)), params, &answers); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to fetch answers: %v", err)), nil
+	}
+
+	result := AnswerSyncResult{AnswersFound: len(answers.Items), TaskID: taskID}
+	if len(answers.Items) == 0 {
+		result.Summary = "No answers found"
+		resultJSON, _ := json.Marshal(result)
+		return mcp.NewToolResultText(string(resultJSON)), nil
+	}
+
+	// The answers endpoint omits titles, so look the questions up in one batch
+	var questionIDs []string
+	for _, answer := range answers.Items {
+		questionIDs = append(questionIDs, fmt.Sprintf("%d", answer.QuestionID))
+	}
+	questionParams := url.Values{"site": {site}, "pagesize": {"100"}}
+	if key := params.Get("key"); key != "" {
+		questionParams.Set("key", key)
+	}
+
+	var questions struct {
+		Items []struct {
+			QuestionID int64  `json:"question_id"`
+			Title      string `json:"title"`
+			Link       string `json:"link"`
+		} `json:"items"`
+	}
+	if err := getStackExchange(ctx, "questions/"+strings.Join(questionIDs, ";"), questionParams, &questions); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("Failed to fetch question titles: %v", err))
+	}
+
+	titles := make(map[int64]string)
+	links := make(map[int64]string)
+	for _, question := range questions.Items {
+		titles[question.QuestionID] = html.UnescapeString(question.Title)
+		links[question.QuestionID] = question.Link
+	}
+
+	var entries []Entry
+	for _, answer := range answers.Items {
+		title := titles[answer.QuestionID]
+		if title == "" {
+			title = fmt.Sprintf("Question %d", answer.QuestionID)
+		}
+		link := fmt.Sprintf("https://%s.com/a/%d", site, answer.AnswerID)
+		if questionLink := links[answer.QuestionID]; questionLink != "" {
+			link = fmt.Sprintf("%s#%d", questionLink, answer.AnswerID)
+		}
+		entries = append(entries, answerEntry(title, link, site, answer.IsAccepted, time.Unix(answer.CreationDate, 0)))
+	}
+
+	added, err := js.appendAnswerEntries(taskID, entries)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save answers: %v", err)), nil
+	}
+	result.EntriesAdded = added
+	result.Summary = fmt.Sprintf("Found %d answers on %s, added %d new entries to %s", result.AnswersFound, site, added, taskID)
+
+	resultJSON, _ := json.Marshal(result)
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// getStackExchange performs a GET against the Stack Exchange API and decodes the response
+func getStackExchange(ctx context.Context, path string, params url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, stackExchangeAPI+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			ErrorMessage string `json:"error_message"`
+		}
+		json.NewDecoder(resp.Body).Decode(&apiErr)
+		return fmt.Errorf("%s: %s", resp.Status, apiErr.ErrorMessage)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// calculateImpactMetrics summarizes answers and discussion activity, or returns
+// nil when the tasks contain no knowledge-sharing entries
+func (js *JournalService) calculateImpactMetrics(tasks []*Task) *ImpactMetrics {
+	metrics := &ImpactMetrics{BySource: make(map[string]int)}
+
+	for _, task := range tasks {
+		for _, entry := range task.Entries {
+			switch entry.Type {
+			case "answer":
+				metrics.AnswersLogged++
+				if source, _, ok := strings.Cut(strings.TrimPrefix(entry.Content, "Answered ["), "]"); ok {
+					metrics.BySource[source]++
+				}
+				if strings.HasSuffix(entry.Content, "[accepted]") {
+					metrics.AcceptedAnswers++
+					if len(metrics.Highlights) < 5 {
+						metrics.Highlights = append(metrics.Highlights, entry.Content)
+					}
+				}
+			case "github_discussion":
+				metrics.DiscussionActivity++
+				metrics.BySource["github_discussions"]++
+			}
+		}
+	}
+
+	if metrics.AnswersLogged == 0 && metrics.DiscussionActivity == 0 {
+		return nil
+	}
+	return metrics
+}
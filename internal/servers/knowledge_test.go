@@ -0,0 +1,88 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestLogAnswer(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	args := map[string]interface{}{
+		"title":  "How do I vendor a Go module?",
+		"url":    "https://wiki.example.com/q/42",
+		"source": "internal",
+	}
+	if result, _ := js.LogAnswer(ctx, CreateMockRequest(args)); result.IsError {
+		t.Fatalf("Unexpected error: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+
+	// Logging the same link again updates the entry instead of adding another
+	args["accepted"] = "true"
+	js.LogAnswer(ctx, CreateMockRequest(args))
+
+	task, err := js.loadTask(knowledgeTaskID)
+	if err != nil {
+		t.Fatalf("Expected knowledge-sharing task: %v", err)
+	}
+	if len(task.Entries) != 1 || !strings.HasSuffix(task.Entries[0].Content, "[accepted]") {
+		t.Errorf("Expected a single accepted entry, got %+v", task.Entries)
+	}
+
+	impact := js.calculateImpactMetrics([]*Task{task})
+	if impact == nil || impact.AnswersLogged != 1 || impact.AcceptedAnswers != 1 || impact.BySource["internal"] != 1 {
+		t.Errorf("Unexpected impact metrics: %+v", impact)
+	}
+}
+
+func TestSyncStackOverflowAnswers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/123/answers":
+			json.NewEncoder(w).Encode(map[string]interface{}{"items": []map[string]interface{}{
+				{"question_id": 1, "answer_id": 10, "is_accepted": true, "creation_date": 1700000000},
+				{"question_id": 2, "answer_id": 20, "is_accepted": false, "creation_date": 1700100000},
+			}})
+		case "/questions/1;2":
+			json.NewEncoder(w).Encode(map[string]interface{}{"items": []map[string]interface{}{
+				{"question_id": 1, "title": "Why is &quot;nil&quot; not nil?", "link": "https://stackoverflow.com/questions/1/why"},
+				{"question_id": 2, "title": "Goroutine leak", "link": "https://stackoverflow.com/questions/2/leak"},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	original := stackExchangeAPI
+	stackExchangeAPI = server.URL + "/"
+	defer func() { stackExchangeAPI = original }()
+
+	js, _ := CreateTestJournalService(t)
+	request := CreateMockRequest(map[string]interface{}{"user_id": "123"})
+
+	result, _ := js.SyncStackOverflowAnswers(context.Background(), request)
+	var sync AnswerSyncResult
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &sync)
+	if sync.AnswersFound != 2 || sync.EntriesAdded != 2 {
+		t.Fatalf("Unexpected sync result: %+v", sync)
+	}
+
+	result, _ = js.SyncStackOverflowAnswers(context.Background(), request)
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &sync)
+	if sync.EntriesAdded != 0 {
+		t.Errorf("Expected repeated sync to add nothing, added %d", sync.EntriesAdded)
+	}
+
+	task, _ := js.loadTask(knowledgeTaskID)
+	if !strings.Contains(task.Entries[0].Content, `Why is "nil" not nil?`) || !strings.HasSuffix(task.Entries[0].Content, "[accepted]") {
+		t.Errorf("Unexpected entry: %s", task.Entries[0].Content)
+	}
+}
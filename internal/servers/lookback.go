@@ -0,0 +1,263 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// lookbackDates returns the same calendar day in previous years and/or months,
+// most recent first. Months without that day (e.g. the 31st) are skipped.
+func lookbackDates(day time.Time, period string, months, years int) []time.Time {
+	var dates []time.Time
+	if period == "months" || period == "both" {
+		for i := 1; i <= months; i++ {
+			d := time.Date(day.Year(), day.Month()-time.Month(i), day.Day(), 0, 0, 0, 0, day.Location())
+			if d.Day() == day.Day() && (period == "months" || i%12 != 0) {
+				dates = append(dates, d)
+			}
+		}
+	}
+	if period == "years" || period == "both" {
+		for i := 1; i <= years; i++ {
+			d := time.Date(day.Year()-i, day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+			if d.Day() == day.Day() {
+				dates = append(dates, d)
+			}
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].After(dates[j]) })
+	return dates
+}
+
+// OnThisDay shows entries and completions from the same date in previous months and years
+func (js *JournalService) OnThisDay(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	loc := js.location()
+	date := request.GetString("date", time.Now().In(loc).Format("2006-01-02"))
+	day, err := time.ParseInLocation("2006-01-02", date, loc)
+	if err != nil {
+		return mcp.NewToolResultError("date must be in YYYY-MM-DD format"), nil
+	}
+
+	period := request.GetString("period", "both")
+	if period != "months" && period != "years" && period != "both" {
+		return mcp.NewToolResultError("period must be months, years or both"), nil
+	}
+	months := 12
+	if monthsStr := request.GetString("months", ""); monthsStr != "" {
+		if parsed, err := strconv.Atoi(monthsStr); err == nil && parsed > 0 {
+			months = parsed
+		}
+	}
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+
+	// Index written entries and completions by local date
+	entriesByDay := make(map[string][]string)
+	completionsByDay := make(map[string][]string)
+	oldest := day
+	for _, task := range tasks {
+		for _, entry := range task.Entries {
+			if !isWrittenEntry(entry) {
+				continue
+			}
+			local := entry.Timestamp.In(loc)
+			key := local.Format("2006-01-02")
+			entriesByDay[key] = append(entriesByDay[key], fmt.Sprintf("- %s %s: %s", local.Format("15:04"), task.ID, entry.Content))
+			if local.Before(oldest) {
+				oldest = local
+			}
+		}
+		if doneAt, ok := completedAt(task); ok {
+			key := doneAt.In(loc).Format("2006-01-02")
+			completionsByDay[key] = append(completionsByDay[key], fmt.Sprintf("- %s: %s", task.ID, task.Title))
+		}
+	}
+
+	years := day.Year() - oldest.Year()
+	var md strings.Builder
+	md.WriteString(fmt.Sprintf("# On This Day: %s\n\n", day.Format("January 2")))
+	found := 0
+	for _, d := range lookbackDates(day, period, months, years) {
+		key := d.Format("2006-01-02")
+		entries, completions := entriesByDay[key], completionsByDay[key]
+		if len(entries) == 0 && len(completions) == 0 {
+			continue
+		}
+		found++
+		sort.Strings(entries)
+		sort.Strings(completions)
+		md.WriteString(fmt.Sprintf("## %s (%s)\n", d.Format("Monday, January 2, 2006"), lookbackAge(day, d)))
+		if len(completions) > 0 {
+			md.WriteString("**Completed:**\n" + strings.Join(completions, "\n") + "\n")
+		}
+		if len(entries) > 0 {
+			md.WriteString("**Entries:**\n" + strings.Join(entries, "\n") + "\n")
+		}
+		md.WriteString("\n")
+	}
+	if found == 0 {
+		md.WriteString("Nothing recorded on this day in earlier months or years yet.\n")
+	}
+
+	markdown, err := js.styleMarkdown(request.GetString("style", ""), md.String())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(markdown), nil
+}
+
+// lookbackAge describes how long ago then was, e.g. "1 year ago" or "3 months ago"
+func lookbackAge(now, then time.Time) string {
+	months := (now.Year()-then.Year())*12 + int(now.Month()-then.Month())
+	if months%12 == 0 {
+		if months == 12 {
+			return "1 year ago"
+		}
+		return fmt.Sprintf("%d years ago", months/12)
+	}
+	if months == 1 {
+		return "1 month ago"
+	}
+	return fmt.Sprintf("%d months ago", months)
+}
+
+// quarterSummary collects one quarter of a yearly retrospective
+type quarterSummary struct {
+	created, entries, minutes int
+	completed                 []*Task
+	tags                      map[string]int
+	decisions                 []string
+}
+
+// GetYearInReview summarizes a year by quarter: completions, activity, time,
+// focus areas and decisions
+func (js *JournalService) GetYearInReview(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	loc := js.location()
+	year := time.Now().In(loc).Year()
+	if yearStr := request.GetString("year", ""); yearStr != "" {
+		parsed, err := strconv.Atoi(yearStr)
+		if err != nil || parsed < 1970 {
+			return mcp.NewToolResultError("year must be a four-digit year"), nil
+		}
+		year = parsed
+	}
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+
+	var quarters [4]quarterSummary
+	for i := range quarters {
+		quarters[i].tags = make(map[string]int)
+	}
+	quarterIndex := func(t time.Time) int {
+		local := t.In(loc)
+		if local.Year() != year {
+			return -1
+		}
+		return (int(local.Month()) - 1) / 3
+	}
+
+	for _, task := range tasks {
+		if q := quarterIndex(task.Created); q >= 0 {
+			quarters[q].created++
+		}
+		if doneAt, ok := completedAt(task); ok {
+			if q := quarterIndex(doneAt); q >= 0 {
+				quarters[q].completed = append(quarters[q].completed, task)
+				for _, tag := range task.Tags {
+					quarters[q].tags[tag]++
+				}
+			}
+		}
+		for _, entry := range task.Entries {
+			q := quarterIndex(entry.Timestamp)
+			if q < 0 {
+				continue
+			}
+			quarters[q].minutes += entry.Minutes
+			if isWrittenEntry(entry) {
+				quarters[q].entries++
+			}
+			if isDecision(entry) {
+				quarters[q].decisions = append(quarters[q].decisions, fmt.Sprintf("%s: %s", task.ID, entry.Content))
+			}
+		}
+	}
+
+	var md strings.Builder
+	md.WriteString(fmt.Sprintf("# %d in Review\n\n", year))
+
+	totalCompleted, totalEntries, totalMinutes := 0, 0, 0
+	for _, q := range quarters {
+		totalCompleted += len(q.completed)
+		totalEntries += q.entries
+		totalMinutes += q.minutes
+	}
+	md.WriteString(fmt.Sprintf("**%d tasks completed, %d entries written", totalCompleted, totalEntries))
+	if totalMinutes > 0 {
+		md.WriteString(", " + formatMinutes(totalMinutes) + " tracked")
+	}
+	md.WriteString("**\n\n")
+
+	for i, q := range quarters {
+		md.WriteString(fmt.Sprintf("## Q%d\n", i+1))
+		if q.created == 0 && len(q.completed) == 0 && q.entries == 0 {
+			md.WriteString("No activity recorded.\n\n")
+			continue
+		}
+		md.WriteString(fmt.Sprintf("- Tasks started: %d, completed: %d, entries: %d\n", q.created, len(q.completed), q.entries))
+		if q.minutes > 0 {
+			md.WriteString(fmt.Sprintf("- Time tracked: %s\n", formatMinutes(q.minutes)))
+		}
+		if len(q.tags) > 0 {
+			tags := sortedKeys(q.tags)
+			sort.SliceStable(tags, func(a, b int) bool { return q.tags[tags[a]] > q.tags[tags[b]] })
+			if len(tags) > 3 {
+				tags = tags[:3]
+			}
+			md.WriteString(fmt.Sprintf("- Focus areas: %s\n", strings.Join(tags, ", ")))
+		}
+
+		if len(q.completed) > 0 {
+			// Highest priority first, then by ID
+			sort.Slice(q.completed, func(a, b int) bool {
+				if priorityRank(q.completed[a].Priority) != priorityRank(q.completed[b].Priority) {
+					return priorityRank(q.completed[a].Priority) > priorityRank(q.completed[b].Priority)
+				}
+				return q.completed[a].ID < q.completed[b].ID
+			})
+			md.WriteString("\n**Highlights:**\n")
+			for j, task := range q.completed {
+				if j == 5 {
+					md.WriteString(fmt.Sprintf("- ...and %d more\n", len(q.completed)-5))
+					break
+				}
+				md.WriteString(fmt.Sprintf("- %s: %s\n", task.ID, task.Title))
+			}
+		}
+		if len(q.decisions) > 0 {
+			md.WriteString("\n**Decisions:**\n")
+			for _, decision := range q.decisions {
+				md.WriteString("- " + decision + "\n")
+			}
+		}
+		md.WriteString("\n")
+	}
+
+	markdown, err := js.styleMarkdown(request.GetString("style", ""), md.String())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(markdown), nil
+}
@@ -0,0 +1,74 @@
+package servers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestLookbackDates(t *testing.T) {
+	day := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	var got []string
+	for _, d := range lookbackDates(day, "both", 13, 2) {
+		got = append(got, d.Format("2006-01-02"))
+	}
+	// February, April, June, September and November have no 31st
+	want := []string{"2026-01-31", "2025-12-31", "2025-10-31", "2025-08-31", "2025-07-31", "2025-05-31", "2025-03-31", "2024-03-31"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("lookbackDates = %v, want %v", got, want)
+	}
+}
+
+func TestOnThisDayAndYearInReview(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	config := defaultConfiguration()
+	config.General.TimeZone = "UTC"
+	js.saveConfiguration(config)
+
+	createTestTask(t, js, "OLD-1", "Launch beta", "work")
+	task, _ := js.loadTask("OLD-1")
+	task.Created = time.Date(2025, 2, 1, 9, 0, 0, 0, time.UTC)
+	task.Priority = "high"
+	task.Tags = []string{"launch"}
+	task.Status = "completed"
+	task.Entries = []Entry{
+		{ID: "e1", Timestamp: time.Date(2025, 6, 10, 9, 0, 0, 0, time.UTC), Content: "Decision: ship behind a flag", Type: "decision"},
+		{ID: "e2", Timestamp: time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC), Content: "Kicked off follow-up", Type: "log"},
+		{ID: "e3", Timestamp: time.Date(2025, 6, 10, 17, 0, 0, 0, time.UTC), Content: "Status changed from active to completed", Type: "status_change"},
+		{ID: "e4", Timestamp: time.Date(2025, 5, 2, 9, 0, 0, 0, time.UTC), Content: "Pairing", Type: "time", Minutes: 90},
+	}
+	js.saveTask(task)
+
+	result, _ := js.OnThisDay(ctx, CreateMockRequest(map[string]interface{}{"date": "2026-06-10"}))
+	text := result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"Sunday, May 10, 2026 (1 month ago)", "Kicked off follow-up", "Tuesday, June 10, 2025 (1 year ago)", "Decision: ship behind a flag", "OLD-1: Launch beta"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("on_this_day missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Index(text, "May 10, 2026") > strings.Index(text, "June 10, 2025") {
+		t.Errorf("Expected most recent first:\n%s", text)
+	}
+
+	result, _ = js.OnThisDay(ctx, CreateMockRequest(map[string]interface{}{"date": "2026-06-10", "period": "years"}))
+	if text := result.Content[0].(mcp.TextContent).Text; strings.Contains(text, "Kicked off") || !strings.Contains(text, "Launch beta") {
+		t.Errorf("Expected only the yearly lookback:\n%s", text)
+	}
+
+	result, _ = js.OnThisDay(ctx, CreateMockRequest(map[string]interface{}{"date": "2026-06-11"}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "Nothing recorded") {
+		t.Errorf("Expected nothing on June 11:\n%s", text)
+	}
+
+	result, _ = js.GetYearInReview(ctx, CreateMockRequest(map[string]interface{}{"year": "2025"}))
+	text = result.Content[0].(mcp.TextContent).Text
+	for _, want := range []string{"# 2025 in Review", "**1 tasks completed, 1 entries written, 1h30m tracked**", "## Q1\n- Tasks started: 1, completed: 0", "- Focus areas: launch", "Decision: ship behind a flag", "## Q4\nNo activity recorded."} {
+		if !strings.Contains(text, want) {
+			t.Errorf("year in review missing %q:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package servers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MarkdownStyle controls the markdown dialect of generated output. Zero fields
+// fall back to the default style.
+type MarkdownStyle struct {
+	HeadingBase int    `json:"heading_base,omitempty" yaml:"heading_base,omitempty"` // level of top headings, 1-6 (default 1)
+	DateFormat  string `json:"date_format,omitempty" yaml:"date_format,omitempty"`   // Go layout for dates (default: general.date_format)
+	Bullet      string `json:"bullet,omitempty" yaml:"bullet,omitempty"`             // "-" (default), "*" or "+"
+	Emoji       string `json:"emoji,omitempty" yaml:"emoji,omitempty"`               // "on" (default) or "off" to strip emoji
+}
+
+// builtinMarkdownStyles can be selected by name without any configuration
+var builtinMarkdownStyles = map[string]MarkdownStyle{
+	"default": {},
+	// Dates become wiki links to daily notes
+	"obsidian": {DateFormat: "[[2006-01-02]]"},
+	// Confluence's markdown import prefers * bullets and mangles emoji
+	"confluence": {Bullet: "*", Emoji: "off"},
+}
+
+var (
+	mdHeadingPattern = regexp.MustCompile(`^(#{1,6}) `)
+	mdBulletPattern  = regexp.MustCompile(`^(\s*)[-*+] `)
+	mdDatePattern    = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+	mdEmojiPattern   = regexp.MustCompile("[\U0001F000-\U0001FAFF\u2600-\u27BF\u2B00-\u2BFF\uFE0F\u200D] ?")
+)
+
+// markdownStyle resolves a style by name: an entry in markdown.styles, then a
+// built-in style. An empty name uses markdown.style from config.
+func (js *JournalService) markdownStyle(name string) (MarkdownStyle, error) {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return MarkdownStyle{}, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if name == "" {
+		name = config.Markdown.Style
+	}
+	if name == "" {
+		name = "default"
+	}
+
+	style, ok := config.Markdown.Styles[name]
+	if !ok {
+		if style, ok = builtinMarkdownStyles[name]; !ok {
+			return MarkdownStyle{}, fmt.Errorf("unknown markdown style %q", name)
+		}
+	}
+	if style.DateFormat == "" {
+		style.DateFormat = config.General.DateFormat
+	}
+	return style, nil
+}
+
+// styleMarkdown renders generated markdown in the named style
+func (js *JournalService) styleMarkdown(name, md string) (string, error) {
+	style, err := js.markdownStyle(name)
+	if err != nil {
+		return "", err
+	}
+	return style.apply(md), nil
+}
+
+// apply rewrites markdown generated in the default style (# headings, - bullets,
+// YYYY-MM-DD dates). Fenced code blocks are left alone.
+func (style MarkdownStyle) apply(md string) string {
+	shift := 0
+	if style.HeadingBase > 1 {
+		shift = min(style.HeadingBase, 6) - 1
+	}
+	bullet := style.Bullet
+	if bullet == "" {
+		bullet = "-"
+	}
+	reformatDates := style.DateFormat != "" && style.DateFormat != "2006-01-02"
+
+	lines := strings.Split(md, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		if shift > 0 {
+			if m := mdHeadingPattern.FindStringSubmatch(line); m != nil {
+				level := min(len(m[1])+shift, 6)
+				line = strings.Repeat("#", level) + line[len(m[1]):]
+			}
+		}
+		if bullet != "-" {
+			if m := mdBulletPattern.FindStringSubmatch(line); m != nil {
+				line = m[1] + bullet + line[len(m[1])+1:]
+			}
+		}
+		if reformatDates {
+			line = reformatMarkdownDates(line, style.DateFormat)
+		}
+		if style.Emoji == "off" {
+			line = mdEmojiPattern.ReplaceAllString(line, "")
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// reformatMarkdownDates rewrites standalone ISO dates, leaving ones that are part
+// of IDs, paths or URLs (e.g. STANDUP-2026-03-02 or daily/2026-03-02.json)
+func reformatMarkdownDates(line, layout string) string {
+	var out strings.Builder
+	last := 0
+	for _, loc := range mdDatePattern.FindAllStringIndex(line, -1) {
+		start, end := loc[0], loc[1]
+		if start > 0 && (strings.ContainsRune("/-_.[", rune(line[start-1])) || isWordByte(line, start-1)) {
+			continue
+		}
+		if end < len(line) {
+			next := line[end]
+			if isWordByte(line, end) || strings.ContainsRune("/-_]", rune(next)) || next == '.' && isWordByte(line, end+1) {
+				continue
+			}
+		}
+		date, err := time.Parse("2006-01-02", line[start:end])
+		if err != nil {
+			continue
+		}
+		out.WriteString(line[last:start])
+		out.WriteString(date.Format(layout))
+		last = end
+	}
+	out.WriteString(line[last:])
+	return out.String()
+}
+
+func isWordByte(s string, i int) bool {
+	if i < 0 || i >= len(s) {
+		return false
+	}
+	c := s[i]
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
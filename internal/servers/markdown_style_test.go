@@ -0,0 +1,78 @@
+package servers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestMarkdownStyleApply(t *testing.T) {
+	md := strings.Join([]string{
+		"# Daily Log: 2026-03-02",
+		"## TASK-1: Ship it 🚀",
+		"- Logged 2026-03-02 10:00, see daily/2026-03-02.json and STANDUP-2026-03-02",
+		"  - [x] Nested item",
+		"```",
+		"# not a heading 2026-03-02",
+		"```",
+		"---",
+	}, "\n")
+
+	style := MarkdownStyle{HeadingBase: 2, DateFormat: "Jan 2, 2006", Bullet: "*", Emoji: "off"}
+	want := strings.Join([]string{
+		"## Daily Log: Mar 2, 2026",
+		"### TASK-1: Ship it ",
+		"* Logged Mar 2, 2026 10:00, see daily/2026-03-02.json and STANDUP-2026-03-02",
+		"  * [x] Nested item",
+		"```",
+		"# not a heading 2026-03-02",
+		"```",
+		"---",
+	}, "\n")
+	if got := style.apply(md); got != want {
+		t.Errorf("Unexpected styled markdown:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := (MarkdownStyle{}).apply(md); got != md {
+		t.Errorf("Default style should not change markdown, got:\n%s", got)
+	}
+}
+
+func TestMarkdownStyleSelection(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "TASK-1", "Styled task", "work")
+
+	request := CreateMockRequest(map[string]interface{}{"task_id": "TASK-1", "style": "obsidian"})
+	result, _ := js.GetTask(ctx, request)
+	text := result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "**Created:** [[") {
+		t.Errorf("Expected obsidian date links, got:\n%s", text)
+	}
+
+	config := defaultConfiguration()
+	config.Markdown.Style = "wiki"
+	config.Markdown.Styles = map[string]MarkdownStyle{"wiki": {HeadingBase: 3}}
+	if err := js.validateConfiguration(config); err != nil {
+		t.Fatalf("Expected custom style to validate: %v", err)
+	}
+	js.saveConfiguration(config)
+
+	result, _ = js.GetTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "TASK-1"}))
+	text = result.Content[0].(mcp.TextContent).Text
+	if !strings.HasPrefix(text, "### TASK-1: Styled task") {
+		t.Errorf("Expected configured style to shift headings, got:\n%s", text)
+	}
+
+	result, _ = js.GetTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "TASK-1", "style": "fancy"}))
+	if !result.IsError {
+		t.Error("Expected an error for an unknown style")
+	}
+
+	config.Markdown.Styles["wiki"] = MarkdownStyle{Bullet: "•"}
+	if err := js.validateConfiguration(config); err == nil {
+		t.Error("Expected an invalid bullet to fail validation")
+	}
+}
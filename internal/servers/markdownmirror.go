@@ -0,0 +1,270 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// markdownMirrorStorage keeps a markdown copy of every task next to its JSON
+// file, like markdownCopyStorage, and reads edits to the copies back
+// (storage.format "mirror"). A copy written after its JSON file that differs
+// from it wins: loads return the edited task and the next save writes it to
+// the JSON file, which stays canonical. A markdown file without a JSON file
+// is a new task. Removing a copy does not delete its task; the copy is
+// written again on the next save. A copy that cannot be parsed is ignored by
+// loads, and saves fail rather than overwrite it.
+type markdownMirrorStorage struct {
+	markdownCopyStorage
+}
+
+func newMarkdownMirrorStorage(js *JournalService) *markdownMirrorStorage {
+	return &markdownMirrorStorage{markdownCopyStorage{Storage: newFileStorage(js), markdown: newMarkdownStorage(js)}}
+}
+
+func (mm *markdownMirrorStorage) LoadTask(taskID string) (*Task, error) {
+	task, err := mm.Storage.LoadTask(taskID)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	missing := err
+	if missing != nil {
+		task = nil
+	}
+
+	edited, err := mm.editedCopy(taskID, task)
+	if err != nil && task == nil {
+		return nil, err
+	}
+	if edited != nil {
+		return edited, nil
+	}
+	if task == nil {
+		return nil, missing
+	}
+	return task, nil
+}
+
+func (mm *markdownMirrorStorage) SaveTask(task *Task) error {
+	if current, err := mm.Storage.LoadTask(task.ID); err == nil {
+		if _, err := mm.editedCopy(task.ID, current); err != nil {
+			return fmt.Errorf("markdown copy has edits that cannot be read; fix or remove it first: %w", err)
+		}
+	}
+	return mm.markdownCopyStorage.SaveTask(task)
+}
+
+func (mm *markdownMirrorStorage) ListTaskIDs() ([]string, error) {
+	ids, err := mm.Storage.ListTaskIDs()
+	if err != nil {
+		return nil, err
+	}
+	copies, err := mm.markdown.ListTaskIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	for _, id := range copies {
+		if !seen[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// DeleteTask removes both files; a task added as markdown has no JSON file yet
+func (mm *markdownMirrorStorage) DeleteTask(taskID string) error {
+	jsonErr := mm.Storage.DeleteTask(taskID)
+	if jsonErr != nil && !os.IsNotExist(jsonErr) {
+		return jsonErr
+	}
+	markdownErr := mm.markdown.DeleteTask(taskID)
+	if markdownErr != nil && !os.IsNotExist(markdownErr) {
+		return markdownErr
+	}
+	if jsonErr != nil && markdownErr != nil {
+		return jsonErr
+	}
+	return nil
+}
+
+// editedCopy returns the task as its markdown copy has it when the copy holds
+// edits newer than task (nil when there is no JSON file), or nil. Changed
+// entry text keeps the JSON version in the entry's history, and hashtags
+// removed from it are dropped from the entry's tags.
+func (mm *markdownMirrorStorage) editedCopy(taskID string, task *Task) (*Task, error) {
+	path := filepath.Join(mm.markdown.dir, taskID+".md")
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var rendered []byte
+	if task != nil {
+		// A copy older than its JSON file is stale, e.g. after a sync from another machine
+		if jsonInfo, err := os.Stat(filepath.Join(mm.markdown.dir, taskID+".json")); err == nil && info.ModTime().Before(jsonInfo.ModTime()) {
+			return nil, nil
+		}
+		data, err := mm.js().readDataFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if rendered, err = mm.js().renderTaskFile(task); err != nil {
+			return nil, err
+		}
+		if bytes.Equal(data, rendered) {
+			return nil, nil
+		}
+	}
+
+	edited, err := mm.markdown.LoadTask(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if edited.ID != taskID {
+		return nil, fmt.Errorf("%s.md: task ID %s does not match the file name", taskID, edited.ID)
+	}
+	if task == nil {
+		return edited, nil
+	}
+
+	// Whitespace or headings in another time zone are not edits
+	if reparsed, err := mm.js().renderTaskFile(edited); err != nil || bytes.Equal(reparsed, rendered) {
+		return nil, err
+	}
+
+	previous := make(map[string]string, len(task.Entries))
+	for _, entry := range task.Entries {
+		previous[entry.ID] = entry.Content
+	}
+	for i, entry := range edited.Entries {
+		if content, ok := previous[entry.ID]; ok && strings.Trim(content, "\n") != entry.Content {
+			edited.Entries[i].History = append(edited.Entries[i].History, EntryEdit{Content: content, EditedAt: info.ModTime()})
+			retagEntry(&edited.Entries[i], content)
+		}
+	}
+	edited.Updated = info.ModTime()
+	return edited, nil
+}
+
+func (mm *markdownMirrorStorage) js() *JournalService {
+	return mm.markdown.js
+}
+
+// markdownMirror returns the mirror storage, or an error when storage.format is not mirror
+func (js *JournalService) markdownMirror() (*markdownMirrorStorage, error) {
+	store := js.storage()
+	if mirrored, ok := store.(*mirroredStorage); ok {
+		store = mirrored.Storage
+	}
+	if mm, ok := store.(*markdownMirrorStorage); ok {
+		return mm, nil
+	}
+	return nil, fmt.Errorf("Markdown mirror sync requires storage.format \"mirror\" (switch with migrate_storage_format format=mirror)")
+}
+
+// pullMarkdownEdit writes a task's edited markdown copy to its JSON file,
+// reporting whether there was an edit to pull
+func (js *JournalService) pullMarkdownEdit(mm *markdownMirrorStorage, taskID string) (bool, error) {
+	defer js.lockTask(taskID)()
+
+	task, err := mm.Storage.LoadTask(taskID)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	if err != nil {
+		task = nil
+	}
+
+	edited, err := mm.editedCopy(taskID, task)
+	if err != nil || edited == nil {
+		return false, err
+	}
+	return true, js.saveTask(edited)
+}
+
+// MirrorSync reports a sync_markdown_mirror run
+type MirrorSync struct {
+	Pulled   []string `json:"pulled,omitempty"` // tasks whose markdown edits were written back
+	Written  int      `json:"copies_written"`   // missing or stale copies rewritten from JSON
+	Failures []string `json:"failures,omitempty"`
+	Summary  string   `json:"summary"`
+}
+
+// SyncMarkdownMirror writes markdown edits back to the JSON task files and
+// rewrites copies that are missing or stale
+func (js *JournalService) SyncMarkdownMirror(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	mm, err := js.markdownMirror()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	ids, err := mm.ListTaskIDs()
+	if err != nil && !os.IsNotExist(err) {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list tasks: %v", err)), nil
+	}
+
+	result := MirrorSync{}
+	for _, id := range ids {
+		pulled, err := js.pullMarkdownEdit(mm, id)
+		if err != nil {
+			result.Failures = append(result.Failures, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+		if pulled {
+			result.Pulled = append(result.Pulled, id)
+			continue
+		}
+
+		written, err := js.refreshMarkdownCopy(mm, id)
+		if err != nil {
+			result.Failures = append(result.Failures, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+		if written {
+			result.Written++
+		}
+	}
+
+	result.Summary = fmt.Sprintf("Pulled markdown edits into %d tasks and rewrote %d markdown copies", len(result.Pulled), result.Written)
+	if len(result.Failures) > 0 {
+		result.Summary += fmt.Sprintf("; %d tasks failed", len(result.Failures))
+	}
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// refreshMarkdownCopy rewrites a task's markdown copy from JSON when it is
+// missing or differs, reporting whether it was written
+func (js *JournalService) refreshMarkdownCopy(mm *markdownMirrorStorage, taskID string) (bool, error) {
+	defer js.lockTask(taskID)()
+
+	task, err := mm.Storage.LoadTask(taskID)
+	if err != nil {
+		return false, err
+	}
+	rendered, err := js.renderTaskFile(task)
+	if err != nil {
+		return false, err
+	}
+	if data, err := js.readDataFile(filepath.Join(mm.markdown.dir, taskID+".md")); err == nil && bytes.Equal(data, rendered) {
+		return false, nil
+	}
+	return true, mm.markdown.SaveTask(task)
+}
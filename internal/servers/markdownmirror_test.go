@@ -0,0 +1,136 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestMarkdownMirror(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	config := defaultConfiguration()
+	config.General.TimeZone = "UTC"
+	config.Storage.Format = "mirror"
+	js.saveConfiguration(config)
+
+	syncMirror := func() MirrorSync {
+		result, _ := js.SyncMarkdownMirror(ctx, CreateMockRequest(map[string]interface{}{}))
+		text := result.Content[0].(mcp.TextContent).Text
+		if result.IsError {
+			t.Fatalf("Sync failed: %s", text)
+		}
+		var sync MirrorSync
+		json.Unmarshal([]byte(text), &sync)
+		return sync
+	}
+	jsonPath := filepath.Join(tempDir, "tasks", "MM-1.json")
+	mdPath := filepath.Join(tempDir, "tasks", "MM-1.md")
+	// Hand edits land after the server's writes; set the time so coarse clocks cannot tie
+	editCopy := func(path string, edit func(string) string, age time.Duration) {
+		data, _ := os.ReadFile(path)
+		os.WriteFile(path, []byte(edit(string(data))), 0644)
+		at := time.Now().Add(age)
+		os.Chtimes(path, at, at)
+	}
+
+	createTestTask(t, js, "MM-1", "Upgrade the build cache", "work")
+	if _, err := os.Stat(mdPath); err != nil {
+		t.Fatalf("Expected a markdown copy written: %v", err)
+	}
+	if sync := syncMirror(); len(sync.Pulled) != 0 || sync.Written != 0 {
+		t.Errorf("Expected nothing to sync, got %+v", sync)
+	}
+
+	// Edited in an editor: a status change, new text for the first entry and a new entry
+	original, _ := js.loadTask("MM-1")
+	editCopy(mdPath, func(text string) string {
+		text = strings.Replace(text, "status: active", "status: blocked", 1)
+		text = strings.Replace(text, original.Entries[0].Content, "Created the task for the cache upgrade", 1)
+		return text + "\n## 2026-03-04 16:00 · log\n\nWaiting on the storage quota\n"
+	}, time.Second)
+
+	task, err := js.loadTask("MM-1")
+	if err != nil || task.Status != "blocked" || len(task.Entries) != 2 {
+		t.Fatalf("Expected the edited copy loaded, got %+v (%v)", task, err)
+	}
+	if edited := task.Entries[0]; edited.Content != "Created the task for the cache upgrade" || len(edited.History) != 1 || edited.History[0].Content != original.Entries[0].Content {
+		t.Errorf("Expected the old entry text kept in history, got %+v", edited)
+	}
+	if added := task.Entries[1]; added.Content != "Waiting on the storage quota" || added.Timestamp != time.Date(2026, 3, 4, 16, 0, 0, 0, time.UTC) {
+		t.Errorf("Unexpected new entry: %+v", added)
+	}
+	if data, _ := os.ReadFile(jsonPath); strings.Contains(string(data), "blocked") {
+		t.Error("Expected the JSON file left alone until a sync or save")
+	}
+
+	if sync := syncMirror(); len(sync.Pulled) != 1 || sync.Pulled[0] != "MM-1" {
+		t.Errorf("Expected the edit pulled, got %+v", sync)
+	}
+	data, _ := os.ReadFile(jsonPath)
+	var saved Task
+	json.Unmarshal(data, &saved)
+	if saved.Status != "blocked" || len(saved.Entries) != 2 {
+		t.Errorf("Expected the edit written to JSON, got %+v", saved)
+	}
+	if markdown, _ := os.ReadFile(mdPath); !strings.Contains(string(markdown), "<!-- entry:"+saved.Entries[1].ID+" -->") {
+		t.Errorf("Expected the new entry's marker written back to the copy:\n%s", markdown)
+	}
+
+	// A copy older than its JSON file is stale and rewritten
+	editCopy(mdPath, func(text string) string { return strings.Replace(text, "status: blocked", "status: paused", 1) }, -time.Hour)
+	if task, _ := js.loadTask("MM-1"); task.Status != "blocked" {
+		t.Errorf("Expected a stale copy ignored, got status %s", task.Status)
+	}
+	if sync := syncMirror(); len(sync.Pulled) != 0 || sync.Written != 1 {
+		t.Errorf("Expected the stale copy rewritten, got %+v", sync)
+	}
+
+	// A task added as a markdown file
+	os.WriteFile(filepath.Join(tempDir, "tasks", "MM-2.md"), []byte("---\nid: MM-2\ntitle: Read the caching RFC\ntype: learning\nstatus: active\n---\n\n## 2026-03-05 09:00 · log\n\nSkimmed it\n"), 0644)
+	tasks, _ := js.loadAllTasks()
+	if len(tasks) != 2 {
+		t.Errorf("Expected the markdown-only task listed, got %d tasks", len(tasks))
+	}
+	if sync := syncMirror(); len(sync.Pulled) != 1 || sync.Pulled[0] != "MM-2" {
+		t.Errorf("Expected the new task pulled, got %+v", sync)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "tasks", "MM-2.json")); err != nil {
+		t.Errorf("Expected a JSON file for the new task: %v", err)
+	}
+
+	// The watcher writes edits back as they happen
+	secondPath := filepath.Join(tempDir, "tasks", "MM-2.md")
+	editCopy(secondPath, func(text string) string { return strings.Replace(text, "Skimmed it", "Read it twice", 1) }, 2*time.Second)
+	if event := NewWatcher(js).handleChange(secondPath); event == nil || event.Type != changeTaskChanged || event.TaskID != "MM-2" {
+		t.Errorf("Expected the watched edit applied, got %+v", event)
+	}
+	if data, _ := os.ReadFile(filepath.Join(tempDir, "tasks", "MM-2.json")); !strings.Contains(string(data), "Read it twice") {
+		t.Errorf("Expected the watched edit in JSON:\n%s", data)
+	}
+
+	// A half-finished edit is not overwritten
+	editCopy(mdPath, func(text string) string { return strings.Replace(text, "\n---\n", "\n", 1) }, time.Second)
+	task, err = js.loadTask("MM-1")
+	if err != nil || task.Status != "blocked" {
+		t.Fatalf("Expected the JSON task loaded past an unreadable copy, got %+v (%v)", task, err)
+	}
+	if err := js.saveTask(task); err == nil || !strings.Contains(err.Error(), "cannot be read") {
+		t.Errorf("Expected the save refused, got %v", err)
+	}
+	if sync := syncMirror(); len(sync.Failures) != 1 {
+		t.Errorf("Expected the unreadable copy reported, got %+v", sync)
+	}
+
+	config.Storage.Format = "both"
+	js.saveConfiguration(config)
+	if result, _ := js.SyncMarkdownMirror(ctx, CreateMockRequest(map[string]interface{}{})); !result.IsError {
+		t.Error("Expected sync refused outside mirror format")
+	}
+}
@@ -0,0 +1,318 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"gopkg.in/yaml.v3"
+)
+
+// storageFormats are the on-disk formats for task files in files mode
+var storageFormats = []string{"json", "markdown", "both", "mirror"}
+
+// markdownEntryHeading matches an entry heading in a markdown task file, e.g.
+// "## 2026-03-14 09:30 · log <!-- entry:entry_171... -->". Entries added by
+// hand may leave out the marker.
+var markdownEntryHeading = regexp.MustCompile(`^## (\d{4}-\d{2}-\d{2} \d{2}:\d{2}) · (\S+)(?: <!-- entry:(\S+) -->)?\s*$`)
+
+// markdownStorage keeps one markdown file per task under tasks/: the task's
+// fields and entry metadata in YAML frontmatter, and each entry's content as
+// a section of the body, so the journal can be read and edited in Obsidian
+// or any editor. Entry content is the only part of the body that is read
+// back; the title heading is for display.
+type markdownStorage struct {
+	dir string
+	js  *JournalService
+}
+
+func newMarkdownStorage(js *JournalService) *markdownStorage {
+	return &markdownStorage{dir: filepath.Join(js.dataDir(), "tasks"), js: js}
+}
+
+func (ms *markdownStorage) SaveTask(task *Task) error {
+	data, err := ms.js.renderTaskFile(task)
+	if err != nil {
+		return err
+	}
+	return ms.js.writeDataFile(filepath.Join(ms.dir, task.ID+".md"), data, 0644)
+}
+
+func (ms *markdownStorage) LoadTask(taskID string) (*Task, error) {
+	data, err := ms.js.readDataFile(filepath.Join(ms.dir, taskID+".md"))
+	if err != nil {
+		return nil, err
+	}
+	task, err := ms.js.parseTaskFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s.md: %w", taskID, err)
+	}
+	return task, nil
+}
+
+func (ms *markdownStorage) ListTaskIDs() ([]string, error) {
+	files, err := os.ReadDir(ms.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, file := range files {
+		if _, conflicted := conflictCopyTaskID(file.Name()); conflicted {
+			continue // left for resolve_conflicts
+		}
+		if !file.IsDir() && strings.HasSuffix(file.Name(), ".md") {
+			ids = append(ids, strings.TrimSuffix(file.Name(), ".md"))
+		}
+	}
+	return ids, nil
+}
+
+func (ms *markdownStorage) DeleteTask(taskID string) error {
+	return removeDataFile(filepath.Join(ms.dir, taskID+".md"))
+}
+
+// markdownCopyStorage keeps a markdown copy of every task next to its JSON
+// file (storage.format "both"). The JSON files stay authoritative; edits to
+// the markdown copies are overwritten on the next save.
+type markdownCopyStorage struct {
+	Storage
+	markdown *markdownStorage
+}
+
+func (mc *markdownCopyStorage) SaveTask(task *Task) error {
+	if err := mc.Storage.SaveTask(task); err != nil {
+		return err
+	}
+	return mc.markdown.SaveTask(task)
+}
+
+func (mc *markdownCopyStorage) DeleteTask(taskID string) error {
+	if err := mc.Storage.DeleteTask(taskID); err != nil {
+		return err
+	}
+	if err := mc.markdown.DeleteTask(taskID); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// taskFileStorage returns the files-mode storage for a storage.format
+func (js *JournalService) taskFileStorage(format string) Storage {
+	switch format {
+	case "markdown":
+		return newMarkdownStorage(js)
+	case "both":
+		return &markdownCopyStorage{Storage: newFileStorage(js), markdown: newMarkdownStorage(js)}
+	case "mirror":
+		return newMarkdownMirrorStorage(js)
+	}
+	return newFileStorage(js)
+}
+
+// renderTaskFile renders a task as markdown with YAML frontmatter
+func (js *JournalService) renderTaskFile(task *Task) ([]byte, error) {
+	frontmatter, err := yaml.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+
+	var md bytes.Buffer
+	md.WriteString("---\n")
+	md.Write(frontmatter)
+	md.WriteString("---\n\n")
+	md.WriteString("# " + task.Title + "\n")
+	loc := js.location()
+	for _, entry := range task.Entries {
+		entryType := entry.Type
+		if entryType == "" {
+			entryType = "log"
+		}
+		md.WriteString(fmt.Sprintf("\n## %s · %s <!-- entry:%s -->\n\n", entry.Timestamp.In(loc).Format("2006-01-02 15:04"), entryType, entry.ID))
+		if content := strings.Trim(entry.Content, "\n"); content != "" {
+			md.WriteString(content + "\n")
+		}
+	}
+	return md.Bytes(), nil
+}
+
+// parseTaskFile reads a markdown task file. Entries are taken in body order:
+// content comes from the body and the rest from the frontmatter, with
+// hashtags in the body added to the entry's tags. An entry removed from the
+// body is dropped, and a heading without an entry marker adds an entry with
+// the heading's time and type.
+func (js *JournalService) parseTaskFile(data []byte) (*Task, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		return nil, fmt.Errorf("missing YAML frontmatter")
+	}
+	frontmatter, body, ok := strings.Cut(text[len("---\n"):], "\n---\n")
+	if !ok {
+		return nil, fmt.Errorf("unterminated YAML frontmatter")
+	}
+
+	var task Task
+	if err := yaml.Unmarshal([]byte(frontmatter), &task); err != nil {
+		return nil, fmt.Errorf("invalid frontmatter: %w", err)
+	}
+	if task.ID == "" {
+		return nil, fmt.Errorf("frontmatter has no id")
+	}
+	known := make(map[string]Entry, len(task.Entries))
+	for _, entry := range task.Entries {
+		known[entry.ID] = entry
+	}
+
+	task.Entries = []Entry{}
+	var current *Entry
+	var content []string
+	finish := func() {
+		if current != nil {
+			current.Content = strings.Trim(strings.Join(content, "\n"), "\n")
+			current.Tags = normalizeEntryTags(append(current.Tags, hashtags(current.Content)...))
+			task.Entries = append(task.Entries, *current)
+		}
+		content = nil
+	}
+	for _, line := range strings.Split(body, "\n") {
+		match := markdownEntryHeading.FindStringSubmatch(line)
+		if match == nil {
+			if current != nil {
+				content = append(content, line)
+			}
+			continue
+		}
+		finish()
+		if entry, ok := known[match[3]]; ok {
+			current = &entry
+			continue
+		}
+		timestamp, err := time.ParseInLocation("2006-01-02 15:04", match[1], js.location())
+		if err != nil {
+			return nil, fmt.Errorf("invalid entry heading %q", line)
+		}
+		id := match[3]
+		if id == "" {
+			// Stable until the task is next saved with the marker
+			id = fmt.Sprintf("entry_%d", timestamp.UnixNano()+int64(len(task.Entries)))
+		}
+		current = &Entry{ID: id, Timestamp: timestamp, Type: match[2]}
+	}
+	finish()
+	return &task, nil
+}
+
+// StorageMigration reports a change of task file format
+type StorageMigration struct {
+	From     string   `json:"from"`
+	To       string   `json:"to"`
+	DryRun   bool     `json:"dry_run,omitempty"`
+	Tasks    int      `json:"tasks"`
+	Removed  int      `json:"files_removed"`
+	Failures []string `json:"failures,omitempty"`
+	Summary  string   `json:"summary"`
+}
+
+// MigrateStorageFormat rewrites every task in another file format and
+// switches storage.format to it
+func (js *JournalService) MigrateStorageFormat(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	format, err := request.RequireString("format")
+	if err != nil {
+		return mcp.NewToolResultError("format is required"), nil
+	}
+	if !slices.Contains(storageFormats, format) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid format: %s (expected json, markdown, both or mirror)", format)), nil
+	}
+	dryRun := request.GetString("dry_run", "false") == "true"
+	keepOld := request.GetString("keep_old_files", "false") == "true"
+
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load configuration: %v", err)), nil
+	}
+	if config.Storage.Mode != "" && config.Storage.Mode != "files" {
+		return mcp.NewToolResultError(fmt.Sprintf("Storage formats apply to files mode only; storage.mode is %s", config.Storage.Mode)), nil
+	}
+	from := config.Storage.Format
+	if from == "" {
+		from = "json"
+	}
+	result := StorageMigration{From: from, To: format, DryRun: dryRun}
+
+	source := js.taskFileStorage(from)
+	ids, err := source.ListTaskIDs()
+	if err != nil && !os.IsNotExist(err) {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list tasks: %v", err)), nil
+	}
+	var tasks []*Task
+	for _, id := range ids {
+		task, err := source.LoadTask(id)
+		if err != nil {
+			result.Failures = append(result.Failures, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	result.Tasks = len(tasks)
+	if len(result.Failures) > 0 {
+		// Nothing is rewritten until every task can be read
+		resultJSON, _ := json.MarshalIndent(result, "", "  ")
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read %d tasks; nothing was migrated:\n%s", len(result.Failures), resultJSON)), nil
+	}
+
+	// Files of the old format that the new one no longer writes
+	var stale []string
+	if !keepOld {
+		if format == "markdown" {
+			stale = append(stale, ".json")
+		}
+		if format == "json" {
+			stale = append(stale, ".md")
+		}
+	}
+
+	if !dryRun {
+		target := js.taskFileStorage(format)
+		for _, task := range tasks {
+			if err := target.SaveTask(task); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to write task %s: %v", task.ID, err)), nil
+			}
+		}
+		config.Storage.Format = format
+		if err := js.saveConfiguration(config); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to save configuration: %v", err)), nil
+		}
+	}
+	for _, task := range tasks {
+		for _, ext := range stale {
+			path := filepath.Join(js.dataDir(), "tasks", task.ID+ext)
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+			if !dryRun {
+				if err := os.Remove(path); err != nil {
+					result.Failures = append(result.Failures, fmt.Sprintf("%s: %v", path, err))
+					continue
+				}
+			}
+			result.Removed++
+		}
+	}
+
+	verb := "Migrated"
+	if dryRun {
+		verb = "Would migrate"
+	}
+	result.Summary = fmt.Sprintf("%s %d tasks from %s to %s storage, removing %d old task files", verb, result.Tasks, from, format, result.Removed)
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
@@ -0,0 +1,168 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestMarkdownTaskFileRoundTrip(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	started := time.Date(2026, 3, 14, 9, 30, 0, 123, time.UTC)
+	task := &Task{
+		ID:        "MD-1",
+		Title:     "Fix login timeout",
+		Type:      "work",
+		Tags:      []string{"auth", "2026"},
+		Status:    "active",
+		Created:   started,
+		Updated:   started.Add(time.Hour),
+		DueDate:   "2026-03-20",
+		Fields:    map[string]string{"severity": "2", "flaky": "true"},
+		DependsOn: []string{"MD-0"},
+		Entries: []Entry{
+			{ID: "entry_1", Timestamp: started, Type: "log", Content: "Traced it to the session cache.\n\n## Notes\n- check TTL"},
+			{ID: "entry_2", Timestamp: started.Add(time.Hour), Type: "time", Minutes: 45, Content: "Paired on the fix",
+				History: []EntryEdit{{Content: "Paired", EditedAt: started.Add(2 * time.Hour)}}},
+		},
+	}
+
+	data, err := js.renderTaskFile(task)
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	text := string(data)
+	if !strings.HasPrefix(text, "---\n") || !strings.Contains(text, "\n# Fix login timeout\n") ||
+		!strings.Contains(text, "due_date: \"2026-03-20\"") || strings.Count(text, "Traced it to the session cache.") != 1 {
+		t.Errorf("Unexpected markdown:\n%s", text)
+	}
+
+	parsed, err := js.parseTaskFile(data)
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	want, _ := json.Marshal(task)
+	got, _ := json.Marshal(parsed)
+	if string(want) != string(got) {
+		t.Errorf("Round trip changed the task:\nwant %s\ngot  %s", want, got)
+	}
+}
+
+func TestMarkdownTaskFileHandEdits(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	config := defaultConfiguration()
+	config.General.TimeZone = "UTC"
+	config.Storage.Format = "markdown"
+	js.saveConfiguration(config)
+	createTestTask(t, js, "MD-2", "Write the design doc", "work")
+
+	path := filepath.Join(js.DataDir, "tasks", "MD-2.md")
+	if _, err := os.Stat(filepath.Join(js.DataDir, "tasks", "MD-2.json")); !os.IsNotExist(err) {
+		t.Fatal("Expected no JSON file in markdown format")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected a markdown task file: %v", err)
+	}
+
+	// Edit in an editor: change the status, reword the first entry and add one by hand
+	task, _ := js.loadTask("MD-2")
+	first := task.Entries[0]
+	edited := strings.Replace(string(data), "status: active", "status: paused", 1)
+	edited = strings.Replace(edited, first.Content, "Started the outline", 1)
+	edited = strings.ReplaceAll(edited, "\n", "\r\n") + "\r\n## 2026-03-15 14:00 · meeting\r\n\r\nReviewed with the team\r\n"
+	os.WriteFile(path, []byte(edited), 0644)
+
+	task, err = js.loadTask("MD-2")
+	if err != nil {
+		t.Fatalf("Failed to load the edited task: %v", err)
+	}
+	if task.Status != "paused" || len(task.Entries) != 2 || task.Entries[0].ID != first.ID || task.Entries[0].Content != "Started the outline" {
+		t.Fatalf("Expected the edits to be read back, got %+v", task)
+	}
+	added := task.Entries[1]
+	if added.Type != "meeting" || added.Content != "Reviewed with the team" || !added.Timestamp.Equal(time.Date(2026, 3, 15, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected hand-written entry: %+v", added)
+	}
+	if again, _ := js.loadTask("MD-2"); again.Entries[1].ID != added.ID {
+		t.Error("Expected a stable ID for the hand-written entry")
+	}
+
+	// Tools work on markdown tasks and write the marker for the new entry
+	result, _ := js.AddTaskEntry(context.Background(), CreateMockRequest(map[string]interface{}{"task_id": "MD-2", "content": "Sent for review"}))
+	if result.IsError {
+		t.Fatalf("Failed to add entry: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	data, _ = os.ReadFile(path)
+	if !strings.Contains(string(data), "· meeting <!-- entry:"+added.ID+" -->") || !strings.Contains(string(data), "Sent for review") {
+		t.Errorf("Expected the saved file to mark every entry:\n%s", data)
+	}
+
+	if _, err := js.parseTaskFile([]byte("# No frontmatter\n")); err == nil {
+		t.Error("Expected a file without frontmatter to be rejected")
+	}
+}
+
+func TestMigrateStorageFormat(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "MIG-1", "First", "work")
+	createTestTask(t, js, "MIG-2", "Second", "learning")
+	tasksDir := filepath.Join(js.DataDir, "tasks")
+
+	result, _ := js.MigrateStorageFormat(ctx, CreateMockRequest(map[string]interface{}{"format": "markdown", "dry_run": "true"}))
+	var migration StorageMigration
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &migration)
+	if migration.Tasks != 2 || migration.Removed != 2 || !migration.DryRun {
+		t.Errorf("Unexpected dry run: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	if _, err := os.Stat(filepath.Join(tasksDir, "MIG-1.md")); !os.IsNotExist(err) {
+		t.Error("Expected a dry run to write nothing")
+	}
+
+	js.MigrateStorageFormat(ctx, CreateMockRequest(map[string]interface{}{"format": "markdown"}))
+	if _, err := os.Stat(filepath.Join(tasksDir, "MIG-1.md")); err != nil {
+		t.Fatalf("Expected markdown files: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tasksDir, "MIG-1.json")); !os.IsNotExist(err) {
+		t.Error("Expected the JSON files to be removed")
+	}
+	if config, _ := js.loadConfiguration(); config.Storage.Format != "markdown" {
+		t.Errorf("Expected storage.format markdown, got %q", config.Storage.Format)
+	}
+	if tasks, _ := js.loadAllTasks(); len(tasks) != 2 {
+		t.Errorf("Expected both tasks readable after migration, got %d", len(tasks))
+	}
+
+	// both keeps JSON authoritative with a markdown copy
+	js.MigrateStorageFormat(ctx, CreateMockRequest(map[string]interface{}{"format": "both"}))
+	js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": "MIG-2", "status": "completed"}))
+	copyData, _ := os.ReadFile(filepath.Join(tasksDir, "MIG-2.md"))
+	if task, _ := js.loadTask("MIG-2"); task.Status != "completed" || !strings.Contains(string(copyData), "status: completed") {
+		t.Errorf("Expected both files to be updated, got %s and\n%s", task.Status, copyData)
+	}
+
+	js.MigrateStorageFormat(ctx, CreateMockRequest(map[string]interface{}{"format": "json"}))
+	if matches, _ := filepath.Glob(filepath.Join(tasksDir, "*.md")); len(matches) != 0 {
+		t.Errorf("Expected the markdown copies removed, got %v", matches)
+	}
+	if task, err := js.loadTask("MIG-2"); err != nil || task.Status != "completed" {
+		t.Errorf("Expected the JSON task back, got %v", err)
+	}
+
+	if result, _ := js.MigrateStorageFormat(ctx, CreateMockRequest(map[string]interface{}{"format": "org"})); !result.IsError {
+		t.Error("Expected an unknown format to be rejected")
+	}
+	config := defaultConfiguration()
+	config.Storage.Mode = "events"
+	config.Storage.Format = "markdown"
+	if err := js.validateConfiguration(config); err == nil {
+		t.Error("Expected markdown storage to require files mode")
+	}
+}
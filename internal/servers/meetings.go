@@ -0,0 +1,662 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// meetingTypes are the kinds of meeting notes. One-on-ones keep their own
+// directory and tools; the other types are stored in meetings/.
+var meetingTypes = []string{"one_on_one", "team", "planning", "retro", "interview"}
+
+// meetingNouns name each meeting type in summaries and headings
+var meetingNouns = map[string]string{
+	"one_on_one": "one-on-one",
+	"team":       "team meeting",
+	"planning":   "planning meeting",
+	"retro":      "retro",
+	"interview":  "interview",
+}
+
+// Meeting is a set of meeting notes
+type Meeting struct {
+	Type      string    `json:"type,omitempty"` // one of meetingTypes; empty for one-on-ones
+	Date      string    `json:"date"`
+	Title     string    `json:"title,omitempty"`
+	Person    string    `json:"person,omitempty"`    // who a one-on-one was with
+	Attendees []string  `json:"attendees,omitempty"` // who attended other meetings
+	Agenda    []string  `json:"agenda,omitempty"`
+	Decisions []string  `json:"decisions,omitempty"`
+	Insights  []string  `json:"insights,omitempty"`
+	Todos     []string  `json:"todos,omitempty"`
+	Feedback  []string  `json:"feedback,omitempty"`
+	Notes     string    `json:"notes,omitempty"`
+	Created   time.Time `json:"created"`
+
+	TodoLinks []TodoLink      `json:"todo_links,omitempty"` // todos linked to existing tasks
+	Changes   []MeetingChange `json:"changes,omitempty"`    // update_meeting calls, oldest first
+}
+
+// OneOnOne is a meeting of type one_on_one; the name predates the other types
+type OneOnOne = Meeting
+
+// MeetingChange records what an update_meeting or update_one_on_one call
+// added to a meeting
+type MeetingChange struct {
+	ChangedAt time.Time `json:"changed_at"`
+	Attendees []string  `json:"attendees,omitempty"`
+	Agenda    []string  `json:"agenda,omitempty"`
+	Decisions []string  `json:"decisions,omitempty"`
+	Insights  []string  `json:"insights,omitempty"`
+	Todos     []string  `json:"todos,omitempty"`
+	Feedback  []string  `json:"feedback,omitempty"`
+	Notes     string    `json:"notes,omitempty"`
+}
+
+// TrashedMeeting is a deleted meeting kept in trash/one-on-ones or trash/meetings
+type TrashedMeeting struct {
+	DeletedAt time.Time `json:"deleted_at"`
+	Reason    string    `json:"reason,omitempty"`
+	Meeting   *Meeting  `json:"meeting"`
+}
+
+// meetingType is a meeting's type, one_on_one for notes saved without one
+func meetingType(meeting *Meeting) string {
+	if meeting.Type == "" {
+		return "one_on_one"
+	}
+	return meeting.Type
+}
+
+// meetingName names a meeting's file: oneOnOneName for one-on-ones, else
+// {type}-{title}-{date}, or {type}-{date} for untitled meetings
+func meetingName(meeting *Meeting) string {
+	kind := meetingType(meeting)
+	if kind == "one_on_one" {
+		return oneOnOneName(meeting)
+	}
+	if title := slugify(meeting.Title, 48); title != "" {
+		return kind + "-" + title + "-" + meeting.Date
+	}
+	return kind + "-" + meeting.Date
+}
+
+// meetingLabel describes a meeting in summaries and action item entries,
+// such as "one-on-one with Sam" or "retro \"Sprint 12\""
+func meetingLabel(meeting *Meeting) string {
+	label := meetingNouns[meetingType(meeting)]
+	if meeting.Person != "" {
+		label += " with " + meeting.Person
+	}
+	if meeting.Title != "" {
+		label += fmt.Sprintf(" %q", meeting.Title)
+	}
+	return label
+}
+
+func (js *JournalService) meetingsDir() string {
+	return filepath.Join(js.dataDir(), "meetings")
+}
+
+func (js *JournalService) meetingPath(meeting *Meeting) string {
+	if meetingType(meeting) == "one_on_one" {
+		return js.oneOnOnePath(meeting)
+	}
+	return filepath.Join(js.meetingsDir(), meetingName(meeting)+".json")
+}
+
+func (js *JournalService) saveMeeting(meeting *Meeting) error {
+	if meetingType(meeting) == "one_on_one" {
+		return js.saveOneOnOne(meeting)
+	}
+	data, err := json.MarshalIndent(meeting, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(js.meetingsDir(), 0755); err != nil {
+		return err
+	}
+	return js.writeDataFile(js.meetingPath(meeting), data, 0644)
+}
+
+// loadMeetings returns one-on-ones and other meetings, oldest first
+func (js *JournalService) loadMeetings() ([]*Meeting, error) {
+	meetings, err := js.loadOneOnOnes()
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(js.meetingsDir(), "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range files {
+		data, err := js.readDataFile(path)
+		if err != nil {
+			continue
+		}
+		var meeting Meeting
+		if err := json.Unmarshal(data, &meeting); err == nil {
+			meetings = append(meetings, &meeting)
+		}
+	}
+	sort.SliceStable(meetings, func(i, j int) bool {
+		if meetings[i].Date != meetings[j].Date {
+			return meetings[i].Date < meetings[j].Date
+		}
+		return meetingName(meetings[i]) < meetingName(meetings[j])
+	})
+	return meetings, nil
+}
+
+// meetingAttendedBy reports whether person was a one-on-one's person or one
+// of a meeting's attendees; an empty person matches every meeting
+func meetingAttendedBy(meeting *Meeting, person string) bool {
+	if meetingWith(meeting, person) {
+		return true
+	}
+	for _, attendee := range meeting.Attendees {
+		if slugify(attendee, 64) == slugify(person, 64) {
+			return true
+		}
+	}
+	return false
+}
+
+// findMeeting picks the meeting of a type on date. key is the person for
+// one-on-ones and the title for other meetings; without it the day must
+// have a single meeting of the type.
+func findMeeting(meetings []*Meeting, date, kind, key string) (*Meeting, error) {
+	oneOnOne := kind == "one_on_one"
+	var found []*Meeting
+	for _, meeting := range meetings {
+		if meeting.Date != date || meetingType(meeting) != kind {
+			continue
+		}
+		if oneOnOne && meetingWith(meeting, key) || !oneOnOne && (key == "" || slugify(meeting.Title, 48) == slugify(key, 48)) {
+			found = append(found, meeting)
+		}
+	}
+
+	noun, param := meetingNouns[kind], "title"
+	if oneOnOne {
+		param = "person"
+	}
+	switch {
+	case len(found) == 0 && key != "" && oneOnOne:
+		return nil, fmt.Errorf("no one-on-one with %s on %s", key, date)
+	case len(found) == 0 && key != "":
+		return nil, fmt.Errorf("no %s titled %q on %s", noun, key, date)
+	case len(found) == 0:
+		return nil, fmt.Errorf("no %s on %s", noun, date)
+	case len(found) > 1:
+		var keys []string
+		for _, meeting := range found {
+			if oneOnOne {
+				keys = append(keys, meeting.Person)
+			} else {
+				keys = append(keys, meeting.Title)
+			}
+		}
+		return nil, fmt.Errorf("%d %ss on %s: pass %s (one of: %s)", len(found), noun, date, param, strings.Join(keys, ", "))
+	}
+	return found[0], nil
+}
+
+// meetingKind reads the type parameter of the meeting tools
+func meetingKind(request mcp.CallToolRequest) (string, error) {
+	kind, err := request.RequireString("type")
+	if err != nil {
+		return "", fmt.Errorf("type is required (%s)", strings.Join(meetingTypes, ", "))
+	}
+	if !slices.Contains(meetingTypes, kind) {
+		return "", fmt.Errorf("invalid meeting type: %s (expected %s)", kind, strings.Join(meetingTypes, ", "))
+	}
+	return kind, nil
+}
+
+// teamMemberNames resolves names and aliases to registry names, dropping blanks and repeats
+func teamMemberNames(config *Configuration, names []string) []string {
+	var resolved []string
+	for _, name := range names {
+		if name = teamMemberName(config, name); name != "" && !slices.Contains(resolved, name) {
+			resolved = append(resolved, name)
+		}
+	}
+	return resolved
+}
+
+// recordMeeting saves new meeting notes: todos naming or mentioning a task
+// are linked to it and the feedback goes into the feedback bank
+func (js *JournalService) recordMeeting(meeting *Meeting) (*OneOnOneResult, error) {
+	linked, err := js.linkOneOnOneTodos(meeting)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link todos: %w", err)
+	}
+	if err := js.saveMeeting(meeting); err != nil {
+		return nil, fmt.Errorf("failed to save %s: %w", meetingNouns[meetingType(meeting)], err)
+	}
+	if _, err := js.addFeedbackItems(meetingFeedbackItems(meeting)); err != nil {
+		return nil, fmt.Errorf("failed to save feedback: %w", err)
+	}
+	return linked, nil
+}
+
+// CreateMeeting records notes for a meeting of any type. One-on-ones are
+// stored as create_one_on_one stores them.
+func (js *JournalService) CreateMeeting(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	kind, err := meetingKind(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	date, err := request.RequireString("date")
+	if err != nil {
+		return mcp.NewToolResultError("date is required (YYYY-MM-DD format)"), nil
+	}
+	if validationErr := js.validateDateFormat(date, "date"); validationErr != nil {
+		return mcp.NewToolResultError(validationErr.Error()), nil
+	}
+	config, _ := js.loadConfiguration()
+
+	meeting := &Meeting{
+		Date:      date,
+		Title:     strings.TrimSpace(request.GetString("title", "")),
+		Attendees: teamMemberNames(config, request.GetStringSlice("attendees", nil)),
+		Agenda:    request.GetStringSlice("agenda", nil),
+		Decisions: request.GetStringSlice("decisions", nil),
+		Insights:  request.GetStringSlice("insights", nil),
+		Todos:     request.GetStringSlice("todos", nil),
+		Feedback:  request.GetStringSlice("feedback", nil),
+		Notes:     request.GetString("notes", ""),
+		Created:   time.Now(),
+	}
+	if kind == "one_on_one" {
+		// A one-on-one has one other attendee: person, or the only attendee given
+		person := request.GetString("person", "")
+		if person == "" && len(meeting.Attendees) == 1 {
+			person = meeting.Attendees[0]
+		}
+		meeting.Person = teamMemberName(config, person)
+		meeting.Attendees = nil
+	} else {
+		meeting.Type = kind
+	}
+
+	result, err := js.recordMeeting(meeting)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to record meeting: %v", err)), nil
+	}
+	result.Summary = fmt.Sprintf("Created notes for the %s %s", date, meetingLabel(meeting))
+	if len(meeting.Todos) > 0 {
+		result.Summary += fmt.Sprintf("; linked %d of %d todos to tasks", len(result.Linked), len(meeting.Todos))
+	}
+	if len(result.Suggestions) > 0 {
+		result.Summary += ". The rest have suggested tasks: create them with create_task"
+	}
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// GetMeetings lists meeting notes, most recent first, filtered by type,
+// attendee and date range
+func (js *JournalService) GetMeetings(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	kind := request.GetString("type", "")
+	if kind != "" && !slices.Contains(meetingTypes, kind) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid meeting type: %s (expected %s)", kind, strings.Join(meetingTypes, ", "))), nil
+	}
+	dateFrom := request.GetString("date_from", "")
+	dateTo := request.GetString("date_to", "")
+	for name, value := range map[string]string{"date_from": dateFrom, "date_to": dateTo} {
+		if value == "" {
+			continue
+		}
+		if validationErr := js.validateDateFormat(value, name); validationErr != nil {
+			return mcp.NewToolResultError(validationErr.Error()), nil
+		}
+	}
+	limit := 10
+	if parsed, err := strconv.Atoi(request.GetString("limit", "")); err == nil && parsed > 0 {
+		limit = parsed
+	}
+	config, _ := js.loadConfiguration()
+	attendee := request.GetString("attendee", "")
+	if attendee != "" {
+		attendee = teamMemberName(config, attendee)
+	}
+
+	meetings, err := js.loadMeetings()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load meetings: %v", err)), nil
+	}
+	var selected []*Meeting
+	for i := len(meetings) - 1; i >= 0 && len(selected) < limit; i-- {
+		meeting := meetings[i]
+		if kind != "" && meetingType(meeting) != kind || !meetingAttendedBy(meeting, attendee) ||
+			dateFrom != "" && meeting.Date < dateFrom || dateTo != "" && meeting.Date > dateTo {
+			continue
+		}
+		selected = append(selected, meeting)
+	}
+
+	var markdown strings.Builder
+	markdown.WriteString("# Meetings\n\n")
+	if len(selected) == 0 {
+		markdown.WriteString("No meetings match.")
+		return mcp.NewToolResultText(markdown.String()), nil
+	}
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+	byID := make(map[string]*Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	for _, meeting := range selected {
+		writeMeetingMarkdown(&markdown, meeting, byID)
+	}
+	return mcp.NewToolResultText(markdown.String()), nil
+}
+
+// writeMeetingMarkdown renders one meeting for the history tools, with each
+// action item's status from the tasks in byID
+func writeMeetingMarkdown(markdown *strings.Builder, meeting *Meeting, byID map[string]*Task) {
+	switch {
+	case meetingType(meeting) != "one_on_one" && meeting.Title != "":
+		markdown.WriteString(fmt.Sprintf("## %s %s (%s)\n", meeting.Date, meeting.Title, meetingNouns[meeting.Type]))
+	case meetingType(meeting) != "one_on_one":
+		markdown.WriteString(fmt.Sprintf("## %s %s\n", meeting.Date, meetingNouns[meeting.Type]))
+	case meeting.Person != "":
+		markdown.WriteString(fmt.Sprintf("## %s with %s\n", meeting.Date, meeting.Person))
+	default:
+		markdown.WriteString(fmt.Sprintf("## %s\n", meeting.Date))
+	}
+	if changes := len(meeting.Changes); changes > 0 {
+		markdown.WriteString(fmt.Sprintf("*Updated %d time(s), last on %s*\n\n", changes, meeting.Changes[changes-1].ChangedAt.Format("2006-01-02")))
+	}
+	if len(meeting.Attendees) > 0 {
+		markdown.WriteString(fmt.Sprintf("**Attendees:** %s\n\n", strings.Join(meeting.Attendees, ", ")))
+	}
+
+	writeList := func(heading string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		markdown.WriteString(fmt.Sprintf("**%s:**\n", heading))
+		for _, item := range items {
+			markdown.WriteString(fmt.Sprintf("- %s\n", item))
+		}
+		markdown.WriteString("\n")
+	}
+	writeList("Agenda", meeting.Agenda)
+	writeList("Decisions", meeting.Decisions)
+	writeList("Insights", meeting.Insights)
+
+	if len(meeting.Todos) > 0 {
+		closed := 0
+		var items strings.Builder
+		for _, todo := range meeting.Todos {
+			box := "[ ]"
+			if todoClosed(meeting, todo, byID) {
+				box = "[x]"
+				closed++
+			}
+			if taskID := linkedTaskID(meeting, todo); taskID != "" {
+				status := "archived or deleted"
+				if task, ok := byID[taskID]; ok {
+					status = task.Status
+				}
+				items.WriteString(fmt.Sprintf("- %s %s (→ %s, %s)\n", box, todo, taskID, status))
+				continue
+			}
+			items.WriteString(fmt.Sprintf("- %s %s\n", box, todo))
+		}
+		markdown.WriteString(fmt.Sprintf("**Action Items:** %d open, %d closed\n", len(meeting.Todos)-closed, closed))
+		markdown.WriteString(items.String() + "\n")
+	}
+
+	writeList("Feedback", meeting.Feedback)
+
+	if meeting.Notes != "" {
+		markdown.WriteString("**Notes:**\n")
+		markdown.WriteString(meeting.Notes + "\n\n")
+	}
+
+	markdown.WriteString("---\n\n")
+}
+
+// appendNew adds the items not already in list, returning the ones added
+func appendNew(list *[]string, items []string) []string {
+	var added []string
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" || slices.Contains(*list, item) || slices.Contains(added, item) {
+			continue
+		}
+		added = append(added, item)
+	}
+	*list = append(*list, added...)
+	return added
+}
+
+// findMeetingFromRequest loads the meeting of a type named by the date
+// parameter and, for one-on-ones, person or, for other meetings, title
+func (js *JournalService) findMeetingFromRequest(request mcp.CallToolRequest, kind string) (*Meeting, error) {
+	date, err := request.RequireString("date")
+	if err != nil {
+		return nil, fmt.Errorf("date is required (YYYY-MM-DD format)")
+	}
+	if validationErr := js.validateDateFormat(date, "date"); validationErr != nil {
+		return nil, validationErr
+	}
+	key := request.GetString("title", "")
+	if kind == "one_on_one" {
+		config, _ := js.loadConfiguration()
+		key = teamMemberName(config, request.GetString("person", ""))
+	}
+
+	meetings, err := js.loadMeetings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load meetings: %w", err)
+	}
+	return findMeeting(meetings, date, kind, key)
+}
+
+// UpdateMeeting adds to an existing meeting of any type
+func (js *JournalService) UpdateMeeting(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	kind, err := meetingKind(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return js.updateMeeting(request, kind)
+}
+
+// UpdateOneOnOne adds insights, todos, feedback and notes to an existing one-on-one
+func (js *JournalService) UpdateOneOnOne(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return js.updateMeeting(request, "one_on_one")
+}
+
+// updateMeeting appends the new list items and notes in the request to a
+// meeting, recording the change in the meeting's changes
+func (js *JournalService) updateMeeting(request mcp.CallToolRequest, kind string) (*mcp.CallToolResult, error) {
+	meeting, err := js.findMeetingFromRequest(request, kind)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	change := MeetingChange{
+		ChangedAt: time.Now(),
+		Agenda:    appendNew(&meeting.Agenda, request.GetStringSlice("agenda", nil)),
+		Decisions: appendNew(&meeting.Decisions, request.GetStringSlice("decisions", nil)),
+		Insights:  appendNew(&meeting.Insights, request.GetStringSlice("insights", nil)),
+		Todos:     appendNew(&meeting.Todos, request.GetStringSlice("todos", nil)),
+		Feedback:  appendNew(&meeting.Feedback, request.GetStringSlice("feedback", nil)),
+		Notes:     strings.TrimSpace(request.GetString("notes", "")),
+	}
+	if kind != "one_on_one" {
+		config, _ := js.loadConfiguration()
+		change.Attendees = appendNew(&meeting.Attendees, teamMemberNames(config, request.GetStringSlice("attendees", nil)))
+	}
+	if change.Notes != "" {
+		if meeting.Notes != "" {
+			meeting.Notes += "\n\n"
+		}
+		meeting.Notes += change.Notes
+	}
+
+	var parts []string
+	for _, part := range []struct {
+		name  string
+		count int
+	}{
+		{"attendees", len(change.Attendees)}, {"agenda items", len(change.Agenda)}, {"decisions", len(change.Decisions)},
+		{"insights", len(change.Insights)}, {"todos", len(change.Todos)}, {"feedback points", len(change.Feedback)},
+	} {
+		if part.count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", part.count, part.name))
+		}
+	}
+	if change.Notes != "" {
+		parts = append(parts, "notes")
+	}
+	if len(parts) == 0 {
+		return mcp.NewToolResultError("Nothing to add: pass new list items or notes"), nil
+	}
+
+	// Only the new todos are linked; earlier links stay as they were
+	added := &Meeting{Type: meeting.Type, Date: meeting.Date, Title: meeting.Title, Person: meeting.Person, Todos: change.Todos}
+	linked, err := js.linkOneOnOneTodos(added)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to link todos: %v", err)), nil
+	}
+	meeting.TodoLinks = append(meeting.TodoLinks, added.TodoLinks...)
+	meeting.Changes = append(meeting.Changes, change)
+
+	if err := js.saveMeeting(meeting); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save %s: %v", meetingNouns[kind], err)), nil
+	}
+	if _, err := js.addFeedbackItems(meetingFeedbackItems(meeting)); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save feedback: %v", err)), nil
+	}
+
+	linked.Summary = fmt.Sprintf("Added %s to the %s %s", strings.Join(parts, ", "), meeting.Date, meetingLabel(meeting))
+	if len(change.Todos) > 0 {
+		linked.Summary += fmt.Sprintf("; linked %d of %d new todos to tasks", len(linked.Linked), len(change.Todos))
+	}
+
+	resultJSON, _ := json.MarshalIndent(linked, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// DeleteMeeting moves a meeting of any type to the trash
+func (js *JournalService) DeleteMeeting(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	kind, err := meetingKind(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return js.deleteMeeting(request, kind)
+}
+
+// DeleteOneOnOne moves a one-on-one to the trash
+func (js *JournalService) DeleteOneOnOne(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return js.deleteMeeting(request, "one_on_one")
+}
+
+// deleteMeeting moves a meeting into trash/one-on-ones or trash/meetings and
+// drops the feedback it added to the feedback bank. Action item entries on
+// linked tasks are kept as part of the tasks' history.
+func (js *JournalService) deleteMeeting(request mcp.CallToolRequest, kind string) (*mcp.CallToolResult, error) {
+	meeting, err := js.findMeetingFromRequest(request, kind)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	trashDir := filepath.Join(js.trashDir(), "meetings")
+	if kind == "one_on_one" {
+		trashDir = filepath.Join(js.trashDir(), "one-on-ones")
+	}
+	if err := os.MkdirAll(trashDir, 0755); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to create trash directory: %v", err)), nil
+	}
+	trashed := TrashedMeeting{DeletedAt: time.Now(), Reason: request.GetString("reason", ""), Meeting: meeting}
+	data, err := json.MarshalIndent(trashed, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to serialize meeting: %v", err)), nil
+	}
+	trashPath := filepath.Join(trashDir, fmt.Sprintf("%s_%d.json", meetingName(meeting), trashed.DeletedAt.UnixNano()))
+	if err := js.writeDataFile(trashPath, data, 0644); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to move meeting to trash: %v", err)), nil
+	}
+	if err := removeDataFile(js.meetingPath(meeting)); err != nil {
+		os.Remove(trashPath)
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete meeting: %v", err)), nil
+	}
+
+	removed, err := js.removeFeedbackSource(meetingFeedbackSource(meeting))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update feedback: %v", err)), nil
+	}
+
+	rel, _ := filepath.Rel(js.dataDir(), trashPath)
+	message := fmt.Sprintf("Moved the %s %s to %s", meeting.Date, meetingLabel(meeting), rel)
+	if removed > 0 {
+		message += fmt.Sprintf("; removed %d feedback item(s) it added to the feedback bank", removed)
+	}
+	return mcp.NewToolResultText(message), nil
+}
+
+// trashedMeeting is a trashed meeting together with the file it was read from
+type trashedMeeting struct {
+	path string
+	TrashedMeeting
+}
+
+// loadTrashedMeetings returns every meeting in trash/one-on-ones and trash/meetings
+func (js *JournalService) loadTrashedMeetings() ([]trashedMeeting, error) {
+	var items []trashedMeeting
+	for _, dir := range []string{"one-on-ones", "meetings"} {
+		files, err := filepath.Glob(filepath.Join(js.trashDir(), dir, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range files {
+			item := trashedMeeting{path: path}
+			data, err := js.readDataFile(path)
+			if err != nil {
+				continue
+			}
+			if err := json.Unmarshal(data, &item.TrashedMeeting); err != nil || item.Meeting == nil {
+				continue
+			}
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// removeFeedbackSource drops the feedback bank items that came from source
+func (js *JournalService) removeFeedbackSource(source string) (int, error) {
+	defer lockFile(js.feedbackPath())()
+
+	items, err := js.loadFeedback()
+	if err != nil {
+		return 0, err
+	}
+	kept := slices.DeleteFunc(items, func(item FeedbackItem) bool { return item.Source == source })
+	removed := len(items) - len(kept)
+	if removed == 0 {
+		return 0, nil
+	}
+	return removed, js.saveFeedback(kept)
+}
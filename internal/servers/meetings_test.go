@@ -0,0 +1,144 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestUpdateAndDeleteOneOnOne(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "API-7", "Ship the billing API", "work")
+
+	js.CreateOneOnOne(ctx, CreateMockRequest(map[string]interface{}{
+		"date": "2026-03-02", "person": "Sam", "todos": []interface{}{"Draft the roadmap"},
+		"feedback": []interface{}{"Great demo"}, "notes": "Talked about goals",
+	}))
+	js.CreateOneOnOne(ctx, CreateMockRequest(map[string]interface{}{"date": "2026-03-02", "person": "Alex"}))
+
+	// Two meetings share the day, so person is required
+	result, _ := js.UpdateOneOnOne(ctx, CreateMockRequest(map[string]interface{}{"date": "2026-03-02", "notes": "More"}))
+	if !result.IsError {
+		t.Error("Expected an ambiguous date rejected")
+	}
+
+	result, _ = js.UpdateOneOnOne(ctx, CreateMockRequest(map[string]interface{}{
+		"date": "2026-03-02", "person": "sam", "todos": []interface{}{"Draft the roadmap", "Follow up on API-7"},
+		"insights": []interface{}{"Wants to lead a project"}, "notes": "Agreed on next steps",
+	}))
+	var updated OneOnOneResult
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &updated)
+	if result.IsError || len(updated.Linked) != 1 || updated.Linked[0].TaskID != "API-7" {
+		t.Fatalf("Expected the new todo linked, got %+v", updated)
+	}
+
+	meetings, _ := js.loadOneOnOnes()
+	meeting, _ := findOneOnOne(meetings, "2026-03-02", "Sam")
+	if !slices.Equal(meeting.Todos, []string{"Draft the roadmap", "Follow up on API-7"}) || meeting.Notes != "Talked about goals\n\nAgreed on next steps" {
+		t.Errorf("Expected the new todo and notes appended, got %+v", meeting)
+	}
+	if len(meeting.Changes) != 1 || !slices.Equal(meeting.Changes[0].Todos, []string{"Follow up on API-7"}) || len(meeting.Changes[0].Insights) != 1 {
+		t.Errorf("Expected the change recorded without the repeated todo, got %+v", meeting.Changes)
+	}
+
+	result, _ = js.UpdateOneOnOne(ctx, CreateMockRequest(map[string]interface{}{"date": "2026-03-02", "person": "Sam", "todos": []interface{}{"Draft the roadmap"}}))
+	if !result.IsError {
+		t.Error("Expected an update adding nothing new rejected")
+	}
+
+	result, _ = js.DeleteOneOnOne(ctx, CreateMockRequest(map[string]interface{}{"date": "2026-03-02", "person": "Sam", "reason": "Duplicate"}))
+	if result.IsError {
+		t.Fatalf("Delete failed: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "one-on-ones", "sam-2026-03-02.json")); !os.IsNotExist(err) {
+		t.Error("Expected the meeting file removed")
+	}
+	trashed, _ := filepath.Glob(filepath.Join(tempDir, "trash", "one-on-ones", "sam-2026-03-02_*.json"))
+	if len(trashed) != 1 {
+		t.Errorf("Expected the meeting kept in trash, got %v", trashed)
+	}
+	if feedback, _ := js.loadFeedback(); len(feedback) != 0 {
+		t.Errorf("Expected the meeting's feedback removed from the bank, got %+v", feedback)
+	}
+	if task, _ := js.loadTask("API-7"); task.Entries[len(task.Entries)-1].Type != "action_item" {
+		t.Error("Expected the action item entry kept on the task")
+	}
+	if meetings, _ := js.loadOneOnOnes(); len(meetings) != 1 || meetings[0].Person != "Alex" {
+		t.Errorf("Expected only the meeting with Alex left, got %+v", meetings)
+	}
+}
+
+func TestMeetings(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "API-7", "Ship the billing API", "work")
+	config, _ := js.loadConfiguration()
+	config.Team.Members = []TeamMember{{Name: "Sam Lee", Aliases: []string{"@sam"}}}
+	js.saveConfiguration(config)
+
+	result, _ := js.CreateMeeting(ctx, CreateMockRequest(map[string]interface{}{
+		"type": "retro", "date": "2026-03-06", "title": "Sprint 12", "attendees": []interface{}{"@sam", "Alex"},
+		"decisions": []interface{}{"Move standup to 10:00"}, "todos": []interface{}{"Follow up on API-7"},
+		"feedback": []interface{}{"Demos were clear"},
+	}))
+	var created OneOnOneResult
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &created)
+	if result.IsError || len(created.Linked) != 1 || created.Summary != `Created notes for the 2026-03-06 retro "Sprint 12"; linked 1 of 1 todos to tasks` {
+		t.Fatalf("Unexpected result: %+v", created)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "meetings", "retro-sprint-12-2026-03-06.json")); err != nil {
+		t.Fatalf("Expected the retro stored in meetings/: %v", err)
+	}
+	if task, _ := js.loadTask("API-7"); task.Entries[len(task.Entries)-1].Content != `Action item from the 2026-03-06 retro "Sprint 12": Follow up on API-7` {
+		t.Errorf("Unexpected action item: %+v", task.Entries[len(task.Entries)-1])
+	}
+
+	// A one_on_one meeting is a one-on-one, visible to the 1-on-1 tools
+	js.CreateMeeting(ctx, CreateMockRequest(map[string]interface{}{"type": "one_on_one", "date": "2026-03-06", "attendees": []interface{}{"sam"}}))
+	if meetings, _ := js.loadOneOnOnes(); len(meetings) != 1 || meetings[0].Person != "Sam Lee" || meetings[0].Type != "" {
+		t.Errorf("Expected a one-on-one with Sam Lee, got %+v", meetings)
+	}
+	if result, _ := js.CreateMeeting(ctx, CreateMockRequest(map[string]interface{}{"type": "standup", "date": "2026-03-06"})); !result.IsError {
+		t.Error("Expected an unknown type rejected")
+	}
+
+	result, _ = js.UpdateMeeting(ctx, CreateMockRequest(map[string]interface{}{
+		"type": "retro", "date": "2026-03-06", "attendees": []interface{}{"Alex", "Kim"}, "decisions": []interface{}{"Keep the demo"},
+	}))
+	if result.IsError {
+		t.Fatalf("Update failed: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+
+	result, _ = js.GetMeetings(ctx, CreateMockRequest(map[string]interface{}{"attendee": "Kim"}))
+	history := result.Content[0].(mcp.TextContent).Text
+	for _, expected := range []string{"## 2026-03-06 Sprint 12 (retro)", "**Attendees:** Sam Lee, Alex, Kim", "- Keep the demo", "- [ ] Follow up on API-7 (→ API-7, active)"} {
+		if !contains(history, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, history)
+		}
+	}
+	if contains(history, "with Sam Lee") {
+		t.Errorf("Expected only meetings Kim attended, got:\n%s", history)
+	}
+
+	result, _ = js.SearchEntries(ctx, CreateMockRequest(map[string]interface{}{"query": "standup"}))
+	if text := result.Content[0].(mcp.TextContent).Text; !contains(text, `Meeting: retro "Sprint 12" 2026-03-06`) || !contains(text, "Move standup to 10:00") {
+		t.Errorf("Expected the retro's decision found, got %s", text)
+	}
+
+	result, _ = js.DeleteMeeting(ctx, CreateMockRequest(map[string]interface{}{"type": "retro", "date": "2026-03-06", "title": "sprint 12"}))
+	if result.IsError {
+		t.Fatalf("Delete failed: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	if trashed, _ := filepath.Glob(filepath.Join(tempDir, "trash", "meetings", "retro-sprint-12-2026-03-06_*.json")); len(trashed) != 1 {
+		t.Errorf("Expected the retro in trash/meetings, got %v", trashed)
+	}
+	if feedback, _ := js.loadFeedback(); len(feedback) != 0 {
+		t.Errorf("Expected the retro's feedback removed, got %+v", feedback)
+	}
+}
@@ -0,0 +1,258 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// VectorClock counts writes per device so concurrent edits from synced
+// machines can be told apart from ordinary sequential updates
+type VectorClock map[string]int64
+
+const (
+	clockBefore     = -1
+	clockEqual      = 0
+	clockAfter      = 1
+	clockConcurrent = 2
+)
+
+// conflictCopyPatterns match the conflicted copies left by file sync tools,
+// of JSON or markdown task files; the first group is the original file name
+// without extension
+var conflictCopyPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^(.+)\.sync-conflict-\d{8}-\d{6}(?:-[A-Za-z0-9]+)?\.(?:json|md)$`), // Syncthing
+	regexp.MustCompile(`^(.+) \([^)]*conflicted copy[^)]*\)\.(?:json|md)$`),                // Dropbox
+}
+
+// ConflictResolution describes how one conflicted copy was handled
+type ConflictResolution struct {
+	File         string `json:"file"`
+	TaskID       string `json:"task_id"`
+	Action       string `json:"action"` // merged, restored, failed
+	EntriesAdded int    `json:"entries_added"`
+	Error        string `json:"error,omitempty"`
+}
+
+// deviceID identifies this machine in vector clocks. The data directory is
+// usually what gets synced, so the ID must not be stored there.
+func deviceID() string {
+	if id := os.Getenv("JOURNAL_MCP_DEVICE_ID"); id != "" {
+		return id
+	}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		return hostname
+	}
+	return "local"
+}
+
+func (vc VectorClock) tick(device string) VectorClock {
+	ticked := make(VectorClock, len(vc)+1)
+	for id, counter := range vc {
+		ticked[id] = counter
+	}
+	ticked[device]++
+	return ticked
+}
+
+func (vc VectorClock) merge(other VectorClock) VectorClock {
+	merged := make(VectorClock, len(vc))
+	for id, counter := range vc {
+		merged[id] = counter
+	}
+	for id, counter := range other {
+		if counter > merged[id] {
+			merged[id] = counter
+		}
+	}
+	return merged
+}
+
+// compare reports whether vc happened before, after, equal to or concurrently with other
+func (vc VectorClock) compare(other VectorClock) int {
+	less, greater := false, false
+	for id := range vc.merge(other) {
+		switch {
+		case vc[id] < other[id]:
+			less = true
+		case vc[id] > other[id]:
+			greater = true
+		}
+	}
+
+	switch {
+	case less && greater:
+		return clockConcurrent
+	case less:
+		return clockBefore
+	case greater:
+		return clockAfter
+	default:
+		return clockEqual
+	}
+}
+
+// mergeTasks combines two copies of the same task. Entries are a set keyed by
+// ID that only loses entries another entry Replaces, such as one deleted,
+// moved or merged away in either copy; task fields come from the causally
+// newer copy, or for concurrent edits the copy with the later Updated time
+// (last writer wins).
+func mergeTasks(a, b *Task) *Task {
+	winner, loser := a, b
+	switch a.Clock.compare(b.Clock) {
+	case clockBefore:
+		winner, loser = b, a
+	case clockConcurrent, clockEqual:
+		if b.Updated.After(a.Updated) || (b.Updated.Equal(a.Updated) && jsonString(b) > jsonString(a)) {
+			winner, loser = b, a
+		}
+	}
+
+	merged := copyTask(winner)
+	merged.Clock = a.Clock.merge(b.Clock)
+
+	seen := make(map[string]bool)
+	for _, entry := range merged.Entries {
+		seen[entry.ID] = true
+	}
+	for _, entry := range loser.Entries {
+		if !seen[entry.ID] {
+			merged.Entries = append(merged.Entries, entry)
+			seen[entry.ID] = true
+		}
+	}
+
+	replaced := make(map[string]bool)
+	for _, entry := range merged.Entries {
+		for _, id := range entry.Replaces {
+			replaced[id] = true
+		}
+	}
+	merged.Entries = slices.DeleteFunc(merged.Entries, func(entry Entry) bool { return replaced[entry.ID] })
+
+	sort.SliceStable(merged.Entries, func(i, j int) bool {
+		return merged.Entries[i].Timestamp.Before(merged.Entries[j].Timestamp)
+	})
+
+	if loser.Updated.After(merged.Updated) {
+		merged.Updated = loser.Updated
+	}
+
+	return merged
+}
+
+func jsonString(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// conflictCopyTaskID returns the task a conflicted copy belongs to
+func conflictCopyTaskID(name string) (string, bool) {
+	for _, pattern := range conflictCopyPatterns {
+		if match := pattern.FindStringSubmatch(name); match != nil {
+			return match[1], true
+		}
+	}
+	return "", false
+}
+
+// ResolveConflicts merges conflicted task copies left by sync tools back into their tasks
+func (js *JournalService) ResolveConflicts(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	dryRun := request.GetString("dry_run", "false") == "true"
+
+	tasksDir := filepath.Join(js.dataDir(), "tasks")
+	files, err := os.ReadDir(tasksDir)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read tasks directory: %v", err)), nil
+	}
+
+	var resolutions []ConflictResolution
+	for _, file := range files {
+		taskID, ok := conflictCopyTaskID(file.Name())
+		if file.IsDir() || !ok {
+			continue
+		}
+
+		resolution := ConflictResolution{File: file.Name(), TaskID: taskID}
+		if err := js.resolveConflictCopy(filepath.Join(tasksDir, file.Name()), taskID, dryRun, &resolution); err != nil {
+			resolution.Action = "failed"
+			resolution.Error = err.Error()
+		}
+		resolutions = append(resolutions, resolution)
+	}
+
+	result := map[string]interface{}{
+		"dry_run":     dryRun,
+		"conflicts":   len(resolutions),
+		"resolutions": resolutions,
+	}
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+func (js *JournalService) resolveConflictCopy(path, taskID string, dryRun bool, resolution *ConflictResolution) error {
+	defer js.lockTask(taskID)()
+
+	data, err := js.readDataFile(path)
+	if err != nil {
+		return err
+	}
+
+	var conflicted Task
+	if filepath.Ext(path) == ".md" {
+		parsed, err := js.parseTaskFile(data)
+		if err != nil {
+			return fmt.Errorf("conflicted copy is not a valid task: %v", err)
+		}
+		conflicted = *parsed
+	} else if err := json.Unmarshal(data, &conflicted); err != nil {
+		return fmt.Errorf("conflicted copy is not a valid task: %v", err)
+	}
+	conflicted.ID = taskID
+
+	current, err := js.loadTask(taskID)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+		// The original is gone, so the conflicted copy is the only version left
+		resolution.Action = "restored"
+		resolution.EntriesAdded = len(conflicted.Entries)
+		if dryRun {
+			return nil
+		}
+		if err := js.saveTask(&conflicted); err != nil {
+			return err
+		}
+		return os.Remove(path)
+	}
+
+	merged := mergeTasks(current, &conflicted)
+	resolution.Action = "merged"
+	// Count new entries by ID; tombstones in the copy can also remove some
+	existing := make(map[string]bool, len(current.Entries))
+	for _, entry := range current.Entries {
+		existing[entry.ID] = true
+	}
+	for _, entry := range merged.Entries {
+		if !existing[entry.ID] {
+			resolution.EntriesAdded++
+		}
+	}
+	if dryRun {
+		return nil
+	}
+
+	if err := js.saveTask(merged); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
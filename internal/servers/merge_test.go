@@ -0,0 +1,226 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestVectorClockCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     VectorClock
+		expected int
+	}{
+		{"equal", VectorClock{"laptop": 2}, VectorClock{"laptop": 2}, clockEqual},
+		{"before", VectorClock{"laptop": 1}, VectorClock{"laptop": 2}, clockBefore},
+		{"after", VectorClock{"laptop": 2, "desktop": 1}, VectorClock{"laptop": 2}, clockAfter},
+		{"concurrent", VectorClock{"laptop": 2}, VectorClock{"laptop": 1, "desktop": 1}, clockConcurrent},
+		{"empty", nil, nil, clockEqual},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.compare(tt.b); got != tt.expected {
+				t.Errorf("compare() = %d, expected %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMergeTasks(t *testing.T) {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	laptop := &Task{
+		ID: "T-1", Status: "completed", Updated: base.Add(2 * time.Hour),
+		Clock:   VectorClock{"laptop": 2, "desktop": 1},
+		Entries: []Entry{{ID: "e1", Timestamp: base}, {ID: "e2", Timestamp: base.Add(time.Hour)}},
+	}
+	desktop := &Task{
+		ID: "T-1", Status: "blocked", Updated: base.Add(time.Hour),
+		Clock:   VectorClock{"laptop": 1, "desktop": 2},
+		Entries: []Entry{{ID: "e1", Timestamp: base}, {ID: "e3", Timestamp: base.Add(30 * time.Minute)}},
+	}
+
+	merged := mergeTasks(desktop, laptop)
+
+	if merged.Status != "completed" {
+		t.Errorf("Expected last writer's status completed, got %s", merged.Status)
+	}
+	if len(merged.Entries) != 3 {
+		t.Fatalf("Expected union of 3 entries, got %d", len(merged.Entries))
+	}
+	if merged.Entries[1].ID != "e3" {
+		t.Errorf("Expected entries ordered by timestamp, got %+v", merged.Entries)
+	}
+	if merged.Clock["laptop"] != 2 || merged.Clock["desktop"] != 2 {
+		t.Errorf("Expected pointwise max clock, got %v", merged.Clock)
+	}
+
+	// A causally newer copy wins even with an older Updated time
+	stale := &Task{ID: "T-1", Status: "active", Updated: base.Add(5 * time.Hour), Clock: VectorClock{"laptop": 1}}
+	if merged := mergeTasks(stale, laptop); merged.Status != "completed" {
+		t.Errorf("Expected causally newer status completed, got %s", merged.Status)
+	}
+}
+
+func TestMergeTasksDropsReplacedEntries(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "T-2", "Merged later", "work")
+	createTestTask(t, js, "T-3", "Moved to", "work")
+	for _, content := range []string{"deleted", "merged first", "merged second", "moved"} {
+		js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "T-2", "content": content}))
+	}
+
+	// An older copy still holds every entry, as a synced machine might
+	older, _ := js.loadTask("T-2")
+	older = copyTask(older)
+	ids := make(map[string]string)
+	for _, entry := range older.Entries {
+		ids[entry.Content] = entry.ID
+	}
+
+	js.DeleteTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "T-2", "entry_id": ids["deleted"]}))
+	js.MergeEntries(ctx, CreateMockRequest(map[string]interface{}{"task_id": "T-2", "entry_ids": []interface{}{ids["merged first"], ids["merged second"]}}))
+	js.MoveEntry(ctx, CreateMockRequest(map[string]interface{}{"from_task_id": "T-2", "entry_id": ids["moved"], "to_task_id": "T-3"}))
+	current, _ := js.loadTask("T-2")
+
+	for _, merged := range []*Task{mergeTasks(older, current), mergeTasks(current, older)} {
+		for _, entry := range merged.Entries {
+			if entry.ID == ids["deleted"] || entry.ID == ids["merged second"] || entry.ID == ids["moved"] {
+				t.Errorf("Expected removed entry %q to stay removed", entry.Content)
+			}
+		}
+		if len(merged.Entries) != len(current.Entries) {
+			t.Errorf("Expected the merge to match the current copy, got %d entries, want %d", len(merged.Entries), len(current.Entries))
+		}
+	}
+}
+
+func TestResolveConflicts(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	createTestTask(t, js, "SYNC-1", "Synced task", "work")
+
+	original, err := js.loadTask("SYNC-1")
+	if err != nil {
+		t.Fatalf("Failed to load task: %v", err)
+	}
+
+	conflicted := copyTask(original)
+	conflicted.Clock = VectorClock{"other-machine": 1}
+	conflicted.Entries = append(conflicted.Entries, Entry{ID: "entry_remote", Timestamp: time.Now(), Content: "Written on the other machine"})
+	data, _ := json.Marshal(conflicted)
+
+	conflictFile := filepath.Join(js.DataDir, "tasks", "SYNC-1.sync-conflict-20250101-120000-ABCDEF1.json")
+	if err := os.WriteFile(conflictFile, data, 0644); err != nil {
+		t.Fatalf("Failed to write conflict file: %v", err)
+	}
+
+	tasks, _ := js.loadAllTasks()
+	if len(tasks) != 1 {
+		t.Errorf("Expected conflicted copy to be hidden from task listing, got %d tasks", len(tasks))
+	}
+
+	result, err := js.ResolveConflicts(context.Background(), CreateMockRequest(map[string]interface{}{}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(conflictFile); !os.IsNotExist(err) {
+		t.Error("Expected conflict file to be removed after merge")
+	}
+
+	merged, err := js.loadTask("SYNC-1")
+	if err != nil {
+		t.Fatalf("Failed to load merged task: %v", err)
+	}
+	if len(merged.Entries) != len(original.Entries)+1 {
+		t.Errorf("Expected remote entry to be merged, got %d entries", len(merged.Entries))
+	}
+	if merged.Clock["other-machine"] != 1 {
+		t.Errorf("Expected merged clock to include the other machine, got %v", merged.Clock)
+	}
+}
+
+func TestResolveMarkdownConflicts(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	config := defaultConfiguration()
+	config.Storage.Format = "markdown"
+	js.saveConfiguration(config)
+	createTestTask(t, js, "SYNC-2", "Synced markdown task", "work")
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "SYNC-2", "content": "first"}))
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "SYNC-2", "content": "second"}))
+
+	// The other machine merged two entries into one, leaving fewer entries
+	original, _ := js.loadTask("SYNC-2")
+	conflicted := copyTask(original)
+	conflicted.Clock = conflicted.Clock.tick("other-machine")
+	first, second := conflicted.Entries[len(conflicted.Entries)-2], conflicted.Entries[len(conflicted.Entries)-1]
+	conflicted.Entries = append(conflicted.Entries[:len(conflicted.Entries)-2], Entry{
+		ID: "entry_remote", Timestamp: time.Now(), Content: "first and second", Replaces: []string{first.ID, second.ID},
+	})
+	data, err := js.renderTaskFile(conflicted)
+	if err != nil {
+		t.Fatalf("Failed to render conflicted copy: %v", err)
+	}
+
+	conflictFile := filepath.Join(js.DataDir, "tasks", "SYNC-2 (Alex's conflicted copy 2025-01-01).md")
+	if err := os.WriteFile(conflictFile, data, 0644); err != nil {
+		t.Fatalf("Failed to write conflict file: %v", err)
+	}
+
+	if tasks, _ := js.loadAllTasks(); len(tasks) != 1 {
+		t.Errorf("Expected conflicted copy to be hidden from task listing, got %d tasks", len(tasks))
+	}
+
+	result, err := js.ResolveConflicts(ctx, CreateMockRequest(map[string]interface{}{}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var resolved struct {
+		Resolutions []ConflictResolution `json:"resolutions"`
+	}
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resolved)
+	if len(resolved.Resolutions) != 1 || resolved.Resolutions[0].Action != "merged" {
+		t.Fatalf("Expected the markdown copy to be merged, got %+v", resolved.Resolutions)
+	}
+	if resolved.Resolutions[0].EntriesAdded != 1 {
+		t.Errorf("Expected 1 entry added, got %d", resolved.Resolutions[0].EntriesAdded)
+	}
+
+	if _, err := os.Stat(conflictFile); !os.IsNotExist(err) {
+		t.Error("Expected conflict file to be removed after merge")
+	}
+	merged, _ := js.loadTask("SYNC-2")
+	if len(merged.Entries) != len(original.Entries)-1 {
+		t.Errorf("Expected the merged entries to replace both originals, got %d entries", len(merged.Entries))
+	}
+}
+
+func TestConflictCopyTaskID(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		ok       bool
+	}{
+		{"ABC-1.sync-conflict-20250101-120000-XYZ1234.json", "ABC-1", true},
+		{"ABC-1 (Alex's conflicted copy 2025-01-01).json", "ABC-1", true},
+		{"ABC-1.sync-conflict-20250101-120000-XYZ1234.md", "ABC-1", true},
+		{"ABC-1 (Alex's conflicted copy 2025-01-01).md", "ABC-1", true},
+		{"ABC-1.json", "", false},
+		{"ABC-1.md", "", false},
+	}
+
+	for _, tt := range tests {
+		taskID, ok := conflictCopyTaskID(tt.name)
+		if taskID != tt.expected || ok != tt.ok {
+			t.Errorf("conflictCopyTaskID(%q) = %q, %v; expected %q, %v", tt.name, taskID, ok, tt.expected, tt.ok)
+		}
+	}
+}
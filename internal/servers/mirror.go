@@ -0,0 +1,346 @@
+package servers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	_ "modernc.org/sqlite"
+)
+
+const defaultSQLiteMirror = "journal.sqlite"
+
+const sqliteMirrorSchema = `
+CREATE TABLE IF NOT EXISTS tasks (
+	id        TEXT PRIMARY KEY,
+	title     TEXT NOT NULL,
+	type      TEXT NOT NULL,
+	status    TEXT NOT NULL,
+	priority  TEXT,
+	issue_url TEXT,
+	issue_id  TEXT,
+	created   TEXT NOT NULL,
+	updated   TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS entries (
+	task_id   TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	id        TEXT NOT NULL,
+	timestamp TEXT NOT NULL,
+	content   TEXT NOT NULL,
+	type      TEXT,
+	PRIMARY KEY (task_id, id)
+);
+CREATE TABLE IF NOT EXISTS task_tags (
+	task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	tag     TEXT NOT NULL,
+	PRIMARY KEY (task_id, tag)
+);
+CREATE TABLE IF NOT EXISTS entry_tags (
+	task_id  TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	entry_id TEXT NOT NULL,
+	tag      TEXT NOT NULL,
+	PRIMARY KEY (task_id, entry_id, tag)
+);
+CREATE INDEX IF NOT EXISTS entries_timestamp ON entries(timestamp);
+CREATE VIEW IF NOT EXISTS entry_log AS
+	SELECT e.timestamp, t.id AS task_id, t.title, t.type AS task_type, t.status, e.type AS entry_type, e.content
+	FROM entries e JOIN tasks t ON t.id = e.task_id;
+`
+
+// mirroredStorage copies every task write into a read-only SQLite database so
+// external analytics tools can query the journal. The primary storage stays
+// authoritative; mirror failures are logged and recorded in the mirror status
+// rather than failing the write, and mirror_to_sqlite rebuilds the copy from
+// scratch.
+type mirroredStorage struct {
+	Storage
+	js   *JournalService
+	path string
+}
+
+func (ms *mirroredStorage) SaveTask(task *Task) error {
+	if err := ms.Storage.SaveTask(task); err != nil {
+		return err
+	}
+	err := ms.js.withSQLiteMirror(ms.path, func(tx *sql.Tx) error { return mirrorTask(tx, task) })
+	if err != nil {
+		log.Printf("SQLite mirror update failed for task %s: %v", task.ID, err)
+	}
+	ms.js.recordSQLiteMirrorWrite(ms.path, task.ID, err)
+	return nil
+}
+
+func (ms *mirroredStorage) DeleteTask(taskID string) error {
+	if err := ms.Storage.DeleteTask(taskID); err != nil {
+		return err
+	}
+	err := ms.js.withSQLiteMirror(ms.path, func(tx *sql.Tx) error { return unmirrorTask(tx, taskID) })
+	if err != nil {
+		log.Printf("SQLite mirror delete failed for task %s: %v", taskID, err)
+	}
+	ms.js.recordSQLiteMirrorWrite(ms.path, taskID, err)
+	return nil
+}
+
+// SQLiteMirrorStatus records how far the SQLite mirror is behind the journal
+type SQLiteMirrorStatus struct {
+	Path        string    `json:"path,omitempty"`
+	LastRebuild time.Time `json:"last_rebuild,omitempty"`
+	LastError   string    `json:"last_error,omitempty"`
+	LastErrorAt time.Time `json:"last_error_at,omitempty"`
+	StaleTasks  []string  `json:"stale_tasks,omitempty"` // tasks whose last change did not reach the mirror
+}
+
+// sqliteMirrorStatusMu serializes status updates from concurrent task writes
+var sqliteMirrorStatusMu sync.Mutex
+
+func (js *JournalService) sqliteMirrorStatusPath() string {
+	return filepath.Join(js.dataDir(), ".journal-mcp", "sqlite_mirror_status.json")
+}
+
+func (js *JournalService) loadSQLiteMirrorStatus() SQLiteMirrorStatus {
+	var status SQLiteMirrorStatus
+	if data, err := js.readDataFile(js.sqliteMirrorStatusPath()); err == nil {
+		json.Unmarshal(data, &status)
+	}
+	return status
+}
+
+func (js *JournalService) saveSQLiteMirrorStatus(status SQLiteMirrorStatus) error {
+	if err := os.MkdirAll(filepath.Dir(js.sqliteMirrorStatusPath()), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return err
+	}
+	return js.writeDataFile(js.sqliteMirrorStatusPath(), data, 0644)
+}
+
+// recordSQLiteMirrorWrite marks a task stale when its change failed to reach
+// the mirror, and current again once a later change does. The status file is
+// only rewritten when something changes, so healthy writes cost a read.
+func (js *JournalService) recordSQLiteMirrorWrite(path, taskID string, err error) {
+	sqliteMirrorStatusMu.Lock()
+	defer sqliteMirrorStatusMu.Unlock()
+
+	status := js.loadSQLiteMirrorStatus()
+	stale := slices.Contains(status.StaleTasks, taskID)
+	switch {
+	case err != nil:
+		status.LastError = err.Error()
+		status.LastErrorAt = time.Now()
+		if !stale {
+			status.StaleTasks = append(status.StaleTasks, taskID)
+		}
+	case stale:
+		status.StaleTasks = slices.DeleteFunc(status.StaleTasks, func(id string) bool { return id == taskID })
+	default:
+		return
+	}
+	status.Path = path
+	if err := js.saveSQLiteMirrorStatus(status); err != nil {
+		log.Printf("Failed to save SQLite mirror status: %v", err)
+	}
+}
+
+// sqliteMirrorPath resolves a configured mirror path relative to the data directory
+func (js *JournalService) sqliteMirrorPath(path string) string {
+	if path == "" {
+		path = defaultSQLiteMirror
+	}
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(js.dataDir(), path)
+}
+
+// errSQLiteMirrorEncrypted refuses a mirror, which would be a plaintext copy of
+// every task, while encryption.at_rest is on
+var errSQLiteMirrorEncrypted = errors.New("the SQLite mirror cannot be used with encryption.at_rest")
+
+// withSQLiteMirror opens the mirror, ensures the schema and runs fn in a transaction
+func (js *JournalService) withSQLiteMirror(path string, fn func(tx *sql.Tx) error) error {
+	if js.atRestEncryption() {
+		return errSQLiteMirrorEncrypted
+	}
+
+	dsn := url.URL{Scheme: "file", Path: filepath.ToSlash(path), RawQuery: url.Values{
+		"_pragma": {"busy_timeout(5000)", "journal_mode(WAL)", "foreign_keys(1)"},
+	}.Encode()}
+	db, err := sql.Open("sqlite", dsn.String())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(sqliteMirrorSchema); err != nil {
+		return fmt.Errorf("failed to create schema: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func mirrorTask(tx *sql.Tx, task *Task) error {
+	if err := unmirrorTask(tx, task.ID); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(`INSERT INTO tasks (id, title, type, status, priority, issue_url, issue_id, created, updated)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		task.ID, task.Title, task.Type, task.Status, task.Priority, task.IssueURL, task.IssueID,
+		task.Created.Format(time.RFC3339), task.Updated.Format(time.RFC3339))
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range task.Entries {
+		_, err := tx.Exec(`INSERT OR REPLACE INTO entries (task_id, id, timestamp, content, type) VALUES (?, ?, ?, ?, ?)`,
+			task.ID, entry.ID, entry.Timestamp.Format(time.RFC3339), entry.Content, entry.Type)
+		if err != nil {
+			return err
+		}
+		for _, tag := range entry.Tags {
+			if _, err := tx.Exec(`INSERT OR IGNORE INTO entry_tags (task_id, entry_id, tag) VALUES (?, ?, ?)`, task.ID, entry.ID, tag); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, tag := range task.Tags {
+		if _, err := tx.Exec(`INSERT OR IGNORE INTO task_tags (task_id, tag) VALUES (?, ?)`, task.ID, tag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unmirrorTask(tx *sql.Tx, taskID string) error {
+	for _, table := range []string{"entries", "entry_tags", "task_tags"} {
+		if _, err := tx.Exec("DELETE FROM "+table+" WHERE task_id = ?", taskID); err != nil {
+			return err
+		}
+	}
+	_, err := tx.Exec(`DELETE FROM tasks WHERE id = ?`, taskID)
+	return err
+}
+
+// MirrorToSQLite rebuilds the SQLite mirror and optionally keeps it updated on every write
+func (js *JournalService) MirrorToSQLite(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+
+	path := request.GetString("path", config.Storage.SQLiteMirror)
+	if path == "" {
+		path = defaultSQLiteMirror
+	}
+	continuous := request.GetString("continuous", "true") == "true"
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+
+	entryCount := 0
+	err = js.withSQLiteMirror(js.sqliteMirrorPath(path), func(tx *sql.Tx) error {
+		for _, table := range []string{"entries", "entry_tags", "task_tags", "tasks"} {
+			if _, err := tx.Exec("DELETE FROM " + table); err != nil {
+				return err
+			}
+		}
+		for _, task := range tasks {
+			if err := mirrorTask(tx, task); err != nil {
+				return fmt.Errorf("task %s: %w", task.ID, err)
+			}
+			entryCount += len(task.Entries)
+		}
+		return nil
+	})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to build SQLite mirror: %v", err)), nil
+	}
+
+	// A full rebuild brings every task up to date
+	sqliteMirrorStatusMu.Lock()
+	err = js.saveSQLiteMirrorStatus(SQLiteMirrorStatus{Path: js.sqliteMirrorPath(path), LastRebuild: time.Now()})
+	sqliteMirrorStatusMu.Unlock()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save SQLite mirror status: %v", err)), nil
+	}
+
+	if continuous {
+		config.Storage.SQLiteMirror = path
+	} else if config.Storage.SQLiteMirror == path {
+		config.Storage.SQLiteMirror = ""
+	}
+	if err := js.saveConfiguration(config); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save config: %v", err)), nil
+	}
+
+	result := map[string]interface{}{
+		"path":       js.sqliteMirrorPath(path),
+		"tasks":      len(tasks),
+		"entries":    entryCount,
+		"continuous": continuous,
+	}
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// GetSQLiteMirrorStatus reports whether the SQLite mirror has fallen behind the journal
+func (js *JournalService) GetSQLiteMirrorStatus(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load config: %v", err)), nil
+	}
+
+	sqliteMirrorStatusMu.Lock()
+	status := js.loadSQLiteMirrorStatus()
+	sqliteMirrorStatusMu.Unlock()
+
+	result := map[string]interface{}{
+		"continuous": config.Storage.SQLiteMirror != "",
+		"status":     status,
+		"up_to_date": len(status.StaleTasks) == 0,
+	}
+	if config.Storage.SQLiteMirror != "" {
+		path := js.sqliteMirrorPath(config.Storage.SQLiteMirror)
+		result["path"] = path
+		// WAL mode commits to the -wal file first
+		for _, file := range []string{path, path + "-wal"} {
+			if info, err := os.Stat(file); err == nil {
+				if updated, ok := result["last_updated"].(time.Time); !ok || info.ModTime().After(updated) {
+					result["last_updated"] = info.ModTime()
+				}
+			}
+		}
+	}
+	if len(status.StaleTasks) > 0 {
+		result["advice"] = "Run mirror_to_sqlite to rebuild the mirror once the error is fixed"
+	}
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
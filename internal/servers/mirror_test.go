@@ -0,0 +1,92 @@
+package servers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestMirrorToSQLite(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "SQL-1", "Mirrored task", "work")
+
+	result, err := js.MirrorToSQLite(ctx, CreateMockRequest(map[string]interface{}{}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error building mirror: %v", err)
+	}
+
+	// Continuous mode keeps later writes in sync
+	createTestTask(t, js, "SQL-2", "Created after mirroring", "learning")
+
+	db, err := sql.Open("sqlite", filepath.Join(js.DataDir, defaultSQLiteMirror))
+	if err != nil {
+		t.Fatalf("Failed to open mirror: %v", err)
+	}
+	defer db.Close()
+
+	var taskCount int
+	if err := db.QueryRow("SELECT COUNT(*) FROM tasks").Scan(&taskCount); err != nil {
+		t.Fatalf("Failed to query mirror: %v", err)
+	}
+	if taskCount != 2 {
+		t.Errorf("Expected 2 mirrored tasks, got %d", taskCount)
+	}
+
+	var title string
+	if err := db.QueryRow("SELECT title FROM entry_log WHERE task_id = ? LIMIT 1", "SQL-2").Scan(&title); err != nil {
+		t.Fatalf("Expected entries for SQL-2 in entry_log view: %v", err)
+	}
+	if title != "Created after mirroring" {
+		t.Errorf("Expected title from joined view, got %s", title)
+	}
+}
+
+func TestSQLiteMirrorStatus(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "SQL-3", "Mirrored task", "work")
+
+	// Characters that mean something in a URI must stay part of the file name
+	path := filepath.Join("mirror dir", "journal #1?.sqlite")
+	status := func() map[string]interface{} {
+		result, _ := js.GetSQLiteMirrorStatus(ctx, CreateMockRequest(map[string]interface{}{}))
+		var status map[string]interface{}
+		json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &status)
+		return status
+	}
+
+	// The mirror directory does not exist yet, so continuous writes fail
+	config := defaultConfiguration()
+	config.Storage.SQLiteMirror = path
+	js.saveConfiguration(config)
+	createTestTask(t, js, "SQL-4", "Missed by the mirror", "work")
+
+	got := status()
+	if got["up_to_date"] != false {
+		t.Fatalf("Expected the mirror to be behind, got %v", got)
+	}
+	if stale := got["status"].(map[string]interface{})["stale_tasks"]; fmt.Sprint(stale) != "[SQL-4]" {
+		t.Errorf("Expected SQL-4 to be stale, got %v", stale)
+	}
+
+	os.Mkdir(filepath.Join(js.DataDir, "mirror dir"), 0755)
+	result, err := js.MirrorToSQLite(ctx, CreateMockRequest(map[string]interface{}{"path": path}))
+	if err != nil || result.IsError {
+		t.Fatalf("Unexpected error building mirror: %v", result.Content)
+	}
+	if _, err := os.Stat(filepath.Join(js.DataDir, path)); err != nil {
+		t.Errorf("Expected the mirror at the exact path: %v", err)
+	}
+
+	got = status()
+	if got["up_to_date"] != true || got["last_updated"] == nil {
+		t.Errorf("Expected the rebuilt mirror to be up to date, got %v", got)
+	}
+}
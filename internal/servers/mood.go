@@ -0,0 +1,363 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	moodScaleMax    = 5  // mood and energy run from 1 (low) to 5 (high)
+	moodTrendWeeks  = 12 // weeks of mood and energy reported in analytics
+	moodMinimumDays = 3  // rated days each side needs before comparing them
+)
+
+// parseMoodLevel reads an optional 1-5 mood or energy argument; 0 when not given
+func parseMoodLevel(request mcp.CallToolRequest, name string) (int, error) {
+	value := request.GetString(name, "")
+	if value == "" {
+		return 0, nil
+	}
+	level, err := strconv.Atoi(value)
+	if err != nil || level < 1 || level > moodScaleMax {
+		return 0, fmt.Errorf("%s must be a number from 1 (low) to %d (high)", name, moodScaleMax)
+	}
+	return level, nil
+}
+
+// moodLabel describes an entry's mood and energy, e.g. "mood 4/5, energy 2/5"
+func moodLabel(entry Entry) string {
+	var parts []string
+	if entry.Mood > 0 {
+		parts = append(parts, fmt.Sprintf("mood %d/%d", entry.Mood, moodScaleMax))
+	}
+	if entry.Energy > 0 {
+		parts = append(parts, fmt.Sprintf("energy %d/%d", entry.Energy, moodScaleMax))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// entryTimeHeading is an entry's time, with its mood and energy when rated
+// and the tags not written in its text
+// alongside other content
+func entryTimeHeading(entry Entry) string {
+	heading := entry.Timestamp.Format("15:04")
+	if label := moodLabel(entry); label != "" && entry.Type != "mood" {
+		heading += " (" + label + ")"
+	}
+	return heading + entryTagSuffix(entry)
+}
+
+// moodTaskID names the task holding a month's mood check-ins
+func moodTaskID(t time.Time) string {
+	return "MOOD-" + t.Format("2006-01")
+}
+
+// LogMood records a mood and energy check-in, on the given task or on a
+// personal task per month
+func (js *JournalService) LogMood(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	mood, err := parseMoodLevel(request, "mood")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	energy, err := parseMoodLevel(request, "energy")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if mood == 0 && energy == 0 {
+		return mcp.NewToolResultError("mood or energy is required (1 to 5)"), nil
+	}
+
+	timestamp := time.Now()
+	if value := request.GetString("timestamp", ""); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return mcp.NewToolResultError("timestamp must be RFC3339, e.g. 2026-03-04T17:30:00Z"), nil
+		}
+		timestamp = parsed
+	}
+
+	entry := Entry{ID: generateEntryID(), Timestamp: timestamp, Type: "mood", Mood: mood, Energy: energy}
+	entry.Content = capitalize(moodLabel(entry))
+	if note := strings.TrimSpace(request.GetString("note", "")); note != "" {
+		entry.Content += ": " + note
+	}
+
+	taskID := request.GetString("task_id", "")
+	if taskID == "" {
+		taskID = moodTaskID(timestamp.In(js.location()))
+	}
+	defer js.lockTask(taskID)()
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		if request.GetString("task_id", "") != "" {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to load task: %v", err)), nil
+		}
+		task = &Task{
+			ID:      taskID,
+			Title:   "Mood and energy, " + timestamp.In(js.location()).Format("January 2006"),
+			Type:    "personal",
+			Tags:    []string{"mood"},
+			Status:  "active",
+			Created: time.Now(),
+			Entries: []Entry{},
+		}
+	}
+	task.Entries = append(task.Entries, entry)
+	task.Updated = time.Now()
+	if err := js.saveTask(task); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task: %v", err)), nil
+	}
+	js.updateDailyLog(taskID, entry)
+
+	return mcp.NewToolResultText(fmt.Sprintf("Logged %s on %s at %s", moodLabel(entry), taskID, timestamp.In(js.location()).Format("15:04"))), nil
+}
+
+// WellbeingMetrics sets mood and energy beside the work done on the same days
+type WellbeingMetrics struct {
+	RatedDays     int                `json:"rated_days"`
+	AverageMood   float64            `json:"average_mood,omitempty"`
+	AverageEnergy float64            `json:"average_energy,omitempty"`
+	ByMood        []MoodProductivity `json:"by_mood,omitempty"`
+	ByEnergy      []MoodProductivity `json:"by_energy,omitempty"`
+	Weeks         []WellbeingWeek    `json:"weeks"`
+	Trend         map[string]string  `json:"trend,omitempty"` // mood and energy: up, down or stable
+	days          map[string]moodDay // by date, for insights
+}
+
+// MoodProductivity is the average day's work at one mood or energy level
+type MoodProductivity struct {
+	Level     int     `json:"level"`
+	Days      int     `json:"days"`
+	Entries   float64 `json:"entries"`   // written entries per day
+	Completed float64 `json:"completed"` // tasks completed per day
+	Minutes   float64 `json:"minutes"`   // time tracked per day
+	Score     float64 `json:"score"`     // completions x2 plus entries x0.1, as in productivity_score
+}
+
+// WellbeingWeek is a week's average mood and energy
+type WellbeingWeek struct {
+	WeekStart string  `json:"week_start"`
+	Mood      float64 `json:"mood,omitempty"`
+	Energy    float64 `json:"energy,omitempty"`
+	Ratings   int     `json:"ratings"`
+}
+
+// moodDay is one day's ratings and work
+type moodDay struct {
+	mood, energy                []int
+	entries, completed, minutes int
+}
+
+func meanLevel(levels []int) float64 {
+	if len(levels) == 0 {
+		return 0
+	}
+	total := 0
+	for _, level := range levels {
+		total += level
+	}
+	return math.Round(float64(total)/float64(len(levels))*10) / 10
+}
+
+// calculateWellbeingMetrics averages mood and energy per day and week and
+// compares the work done on days at each level, or nil without ratings
+func calculateWellbeingMetrics(tasks []*Task, first time.Weekday, loc *time.Location) *WellbeingMetrics {
+	days := make(map[string]*moodDay)
+	day := func(t time.Time) *moodDay {
+		date := t.In(loc).Format("2006-01-02")
+		if days[date] == nil {
+			days[date] = &moodDay{}
+		}
+		return days[date]
+	}
+	rated := false
+	for _, task := range tasks {
+		for _, entry := range task.Entries {
+			d := day(entry.Timestamp)
+			if entry.Mood > 0 {
+				d.mood = append(d.mood, entry.Mood)
+				rated = true
+			}
+			if entry.Energy > 0 {
+				d.energy = append(d.energy, entry.Energy)
+				rated = true
+			}
+			if isWrittenEntry(entry) {
+				d.entries++
+			}
+			d.minutes += entry.Minutes
+		}
+		if at, ok := completedAt(task); ok {
+			day(at).completed++
+		}
+	}
+	if !rated {
+		return nil
+	}
+
+	metrics := &WellbeingMetrics{Weeks: []WellbeingWeek{}, days: make(map[string]moodDay)}
+	var moods, energies []int
+	byMood := make(map[int][]*moodDay)
+	byEnergy := make(map[int][]*moodDay)
+	weeks := make(map[time.Time]*struct{ mood, energy []int })
+	for date, d := range days {
+		if len(d.mood) == 0 && len(d.energy) == 0 {
+			continue
+		}
+		metrics.RatedDays++
+		metrics.days[date] = *d
+		moods = append(moods, d.mood...)
+		energies = append(energies, d.energy...)
+		if len(d.mood) > 0 {
+			level := int(math.Round(meanLevel(d.mood)))
+			byMood[level] = append(byMood[level], d)
+		}
+		if len(d.energy) > 0 {
+			level := int(math.Round(meanLevel(d.energy)))
+			byEnergy[level] = append(byEnergy[level], d)
+		}
+		t, _ := time.ParseInLocation("2006-01-02", date, loc)
+		week := weekStartFrom(t, first)
+		if weeks[week] == nil {
+			weeks[week] = &struct{ mood, energy []int }{}
+		}
+		weeks[week].mood = append(weeks[week].mood, d.mood...)
+		weeks[week].energy = append(weeks[week].energy, d.energy...)
+	}
+	metrics.AverageMood = meanLevel(moods)
+	metrics.AverageEnergy = meanLevel(energies)
+	metrics.ByMood = moodProductivity(byMood)
+	metrics.ByEnergy = moodProductivity(byEnergy)
+
+	keys := sortedTimes(weeks)
+	if len(keys) > moodTrendWeeks {
+		keys = keys[len(keys)-moodTrendWeeks:]
+	}
+	for _, week := range keys {
+		w := weeks[week]
+		metrics.Weeks = append(metrics.Weeks, WellbeingWeek{
+			WeekStart: week.Format("2006-01-02"),
+			Mood:      meanLevel(w.mood),
+			Energy:    meanLevel(w.energy),
+			Ratings:   len(w.mood) + len(w.energy),
+		})
+	}
+	metrics.Trend = wellbeingTrend(metrics.Weeks)
+	return metrics
+}
+
+// moodProductivity averages the work of the days at each level
+func moodProductivity(byLevel map[int][]*moodDay) []MoodProductivity {
+	var levels []MoodProductivity
+	for level := 1; level <= moodScaleMax; level++ {
+		days := byLevel[level]
+		if len(days) == 0 {
+			continue
+		}
+		p := MoodProductivity{Level: level, Days: len(days)}
+		for _, d := range days {
+			p.Entries += float64(d.entries)
+			p.Completed += float64(d.completed)
+			p.Minutes += float64(d.minutes)
+		}
+		n := float64(len(days))
+		p.Entries = math.Round(p.Entries/n*10) / 10
+		p.Completed = math.Round(p.Completed/n*10) / 10
+		p.Minutes = math.Round(p.Minutes / n)
+		p.Score = math.Round((p.Completed*2+p.Entries*0.1)*100) / 100
+		levels = append(levels, p)
+	}
+	return levels
+}
+
+// wellbeingTrend compares the mean of the last half of the weeks with the
+// first half; a change of under half a point is stable
+func wellbeingTrend(weeks []WellbeingWeek) map[string]string {
+	if len(weeks) < 4 {
+		return nil
+	}
+	trend := make(map[string]string)
+	for name, level := range map[string]func(WellbeingWeek) float64{
+		"mood":   func(w WellbeingWeek) float64 { return w.Mood },
+		"energy": func(w WellbeingWeek) float64 { return w.Energy },
+	} {
+		var early, late []float64
+		for i, week := range weeks {
+			if level(week) == 0 {
+				continue
+			}
+			if i < len(weeks)/2 {
+				early = append(early, level(week))
+			} else {
+				late = append(late, level(week))
+			}
+		}
+		if len(early) == 0 || len(late) == 0 {
+			continue
+		}
+		change := mean(late) - mean(early)
+		switch {
+		case change >= 0.5:
+			trend[name] = "up"
+		case change <= -0.5:
+			trend[name] = "down"
+		default:
+			trend[name] = "stable"
+		}
+	}
+	return trend
+}
+
+func mean(values []float64) float64 {
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	return total / float64(len(values))
+}
+
+// wellbeingInsights compare work on good and bad days and call out trends
+func wellbeingInsights(metrics *WellbeingMetrics) []string {
+	if metrics == nil {
+		return nil
+	}
+	var insights []string
+	for _, scale := range []struct {
+		name  string
+		level func(moodDay) []int
+	}{
+		{"mood", func(d moodDay) []int { return d.mood }},
+		{"energy", func(d moodDay) []int { return d.energy }},
+	} {
+		var high, low []float64
+		for _, d := range metrics.days {
+			levels := scale.level(d)
+			if len(levels) == 0 {
+				continue
+			}
+			if meanLevel(levels) >= 4 {
+				high = append(high, float64(d.entries))
+			} else {
+				low = append(low, float64(d.entries))
+			}
+		}
+		if len(high) < moodMinimumDays || len(low) < moodMinimumDays {
+			continue
+		}
+		if h, l := mean(high), mean(low); math.Abs(h-l) >= 1 {
+			insights = append(insights, fmt.Sprintf("On days you rated your %s 4 or higher you wrote %.1f entries on average, against %.1f on other days.", scale.name, h, l))
+		}
+	}
+	for _, name := range []string{"mood", "energy"} {
+		if metrics.Trend[name] == "down" {
+			insights = append(insights, fmt.Sprintf("Your %s has trended down over the last %d weeks. Consider what is weighing on you.", name, len(metrics.Weeks)))
+		}
+	}
+	return insights
+}
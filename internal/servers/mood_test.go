@@ -0,0 +1,102 @@
+package servers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestLogMood(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	result, _ := js.LogMood(ctx, CreateMockRequest(map[string]interface{}{"mood": "4", "energy": "2", "note": "tired after on-call", "timestamp": "2026-03-04T17:30:00Z"}))
+	text := result.Content[0].(mcp.TextContent).Text
+	if result.IsError || !strings.HasPrefix(text, "Logged mood 4/5, energy 2/5 on MOOD-2026-03") {
+		t.Fatalf("Unexpected result: %s", text)
+	}
+	task, err := js.loadTask("MOOD-2026-03")
+	if err != nil || task.Type != "personal" || len(task.Entries) != 1 {
+		t.Fatalf("Expected a monthly mood task, got %+v (%v)", task, err)
+	}
+	if entry := task.Entries[0]; entry.Type != "mood" || entry.Mood != 4 || entry.Energy != 2 || entry.Content != "Mood 4/5, energy 2/5: tired after on-call" {
+		t.Errorf("Unexpected check-in: %+v", entry)
+	}
+
+	createTestTask(t, js, "MD-1", "Incident review", "work")
+	result, _ = js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "MD-1", "content": "Wrote the timeline", "energy": "5"}))
+	if result.IsError {
+		t.Fatalf("Expected a rated entry added: %v", result.Content)
+	}
+	task, _ = js.loadTask("MD-1")
+	last := task.Entries[len(task.Entries)-1]
+	if last.Energy != 5 || last.Mood != 0 {
+		t.Errorf("Expected energy stored on the entry, got %+v", last)
+	}
+	result, _ = js.GetDailyLog(ctx, CreateMockRequest(map[string]interface{}{"date": last.Timestamp.In(js.location()).Format("2006-01-02")}))
+	if log := result.Content[0].(mcp.TextContent).Text; !strings.Contains(log, "(energy 5/5)\nWrote the timeline") {
+		t.Errorf("Expected the rating beside the entry's time:\n%s", log)
+	}
+
+	for _, args := range []map[string]interface{}{
+		{},
+		{"mood": "6"},
+		{"energy": "high"},
+		{"mood": "3", "task_id": "MISSING"},
+	} {
+		if result, _ := js.LogMood(ctx, CreateMockRequest(args)); !result.IsError {
+			t.Errorf("Expected %v rejected", args)
+		}
+	}
+	if result, _ := js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "MD-1", "content": "x", "mood": "0"})); !result.IsError {
+		t.Error("Expected a mood of 0 rejected")
+	}
+}
+
+func TestCalculateWellbeingMetrics(t *testing.T) {
+	start := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC) // a Monday
+	work := &Task{ID: "W-1", Type: "work"}
+	moods := &Task{ID: "MOOD-2026-02", Tags: []string{"mood"}}
+	// Four weeks: good days with three entries early on, low days with one later
+	for day := 0; day < 28; day++ {
+		at := start.AddDate(0, 0, day).Add(10 * time.Hour)
+		mood, entries := 4, 3
+		if day >= 14 {
+			mood, entries = 2, 1
+		}
+		moods.Entries = append(moods.Entries, Entry{Type: "mood", Timestamp: at.Add(8 * time.Hour), Mood: mood, Energy: 3, Content: "Check-in"})
+		for i := 0; i < entries; i++ {
+			work.Entries = append(work.Entries, Entry{Timestamp: at.Add(time.Duration(i) * time.Minute), Content: "Work", Minutes: 30})
+		}
+	}
+
+	metrics := calculateWellbeingMetrics([]*Task{work, moods}, time.Monday, time.UTC)
+	if metrics == nil || metrics.RatedDays != 28 || metrics.AverageMood != 3 || metrics.AverageEnergy != 3 || len(metrics.Weeks) != 4 {
+		t.Fatalf("Unexpected metrics: %+v", metrics)
+	}
+	if len(metrics.ByMood) != 2 || metrics.ByMood[0].Level != 2 || metrics.ByMood[0].Entries != 1 || metrics.ByMood[1].Entries != 3 || metrics.ByMood[1].Minutes != 90 {
+		t.Errorf("Unexpected productivity by mood: %+v", metrics.ByMood)
+	}
+	if metrics.ByMood[1].Score != 0.3 {
+		t.Errorf("Expected a score of 0.3 on good days, got %v", metrics.ByMood[1].Score)
+	}
+	if metrics.Trend["mood"] != "down" || metrics.Trend["energy"] != "stable" {
+		t.Errorf("Unexpected trend: %v", metrics.Trend)
+	}
+
+	insights := wellbeingInsights(metrics)
+	expected := []string{
+		"On days you rated your mood 4 or higher you wrote 3.0 entries on average, against 1.0 on other days.",
+		"Your mood has trended down over the last 4 weeks. Consider what is weighing on you.",
+	}
+	if strings.Join(insights, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected insights: %v", insights)
+	}
+
+	if calculateWellbeingMetrics([]*Task{work}, time.Monday, time.UTC) != nil {
+		t.Error("Expected no metrics without ratings")
+	}
+}
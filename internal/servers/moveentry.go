@@ -0,0 +1,115 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// MoveEntry relocates a misfiled entry to another task. The entry keeps its ID
+// and timestamp, its daily-log copy moves with it, and both tasks get a
+// "moved" entry recording the correction.
+func (js *JournalService) MoveEntry(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	fromID, err := request.RequireString("from_task_id")
+	if err != nil {
+		return mcp.NewToolResultError("from_task_id is required"), nil
+	}
+	entryID, err := request.RequireString("entry_id")
+	if err != nil {
+		return mcp.NewToolResultError("entry_id is required"), nil
+	}
+	toID, err := request.RequireString("to_task_id")
+	if err != nil {
+		return mcp.NewToolResultError("to_task_id is required"), nil
+	}
+	if fromID == toID {
+		return mcp.NewToolResultError("The entry is already on that task"), nil
+	}
+
+	// Lock in a fixed order so two opposite moves cannot deadlock
+	first, second := fromID, toID
+	if second < first {
+		first, second = second, first
+	}
+	defer js.lockTask(first)()
+	defer js.lockTask(second)()
+
+	from, err := js.loadTask(fromID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", fromID)), nil
+	}
+	to, err := js.loadTask(toID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", toID)), nil
+	}
+
+	index := -1
+	for i, entry := range from.Entries {
+		if entry.ID == entryID {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return mcp.NewToolResultError("Entry not found"), nil
+	}
+	moved := from.Entries[index]
+	if moved.Type == "deleted" || moved.Type == "moved" {
+		return mcp.NewToolResultError(fmt.Sprintf("Entries of type %s record changes to this task and cannot be moved", moved.Type)), nil
+	}
+
+	now := time.Now()
+	summary := fmt.Sprintf("%s entry from %s", entryTypeLabel(moved), moved.Timestamp.Format("2006-01-02 15:04"))
+	reason := strings.TrimSpace(request.GetString("reason", ""))
+	if reason != "" {
+		reason = ": " + reason
+	}
+	outNote := Entry{ID: generateEntryID(), Timestamp: now, Type: "moved", Content: fmt.Sprintf("Moved %s to %s%s", summary, toID, reason), Replaces: append(slices.Clone(moved.Replaces), moved.ID)}
+	inNote := Entry{ID: generateEntryID(), Timestamp: now.Add(time.Nanosecond), Type: "moved", Content: fmt.Sprintf("Moved %s here from %s%s", summary, fromID, reason)}
+
+	from.Entries = append(from.Entries[:index], from.Entries[index+1:]...)
+	from.Entries = append(from.Entries, outNote)
+	from.Updated = now
+
+	// Keep the target's entries in chronological order
+	at := len(to.Entries)
+	for i, entry := range to.Entries {
+		if entry.Timestamp.After(moved.Timestamp) {
+			at = i
+			break
+		}
+	}
+	to.Entries = append(to.Entries[:at], append([]Entry{moved}, to.Entries[at:]...)...)
+	to.Entries = append(to.Entries, inNote)
+	to.Updated = now
+
+	// Save the target first: a failure in between leaves a copy, never a loss
+	if err := js.saveTask(to); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task %s: %v", toID, err)), nil
+	}
+	if err := js.saveTask(from); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task %s: %v", fromID, err)), nil
+	}
+
+	if err := js.moveDailyLogEntry(moved, fromID, toID); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Moved the entry but failed to update the daily log: %v", err)), nil
+	}
+	js.updateDailyLog(fromID, outNote)
+	js.updateDailyLog(toID, inNote)
+
+	return mcp.NewToolResultText(fmt.Sprintf("Moved entry %s (%s) from %s to %s", entryID, summary, fromID, toID)), nil
+}
+
+// moveDailyLogEntry moves an entry's copy in its day's log from one task to another
+func (js *JournalService) moveDailyLogEntry(entry Entry, fromID, toID string) error {
+	found, err := js.removeDailyLogEntry(fromID, entry)
+	if err != nil || !found {
+		return err
+	}
+	js.updateDailyLog(toID, entry)
+	return nil
+}
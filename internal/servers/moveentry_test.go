@@ -0,0 +1,83 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestMoveEntry(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "MV-1", "Billing migration", "work")
+	createTestTask(t, js, "MV-2", "Search latency", "work")
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "MV-1", "content": "p99 down to 180ms after the cache fix"}))
+
+	source, _ := js.loadTask("MV-1")
+	misfiled := source.Entries[len(source.Entries)-1]
+
+	result, _ := js.MoveEntry(ctx, CreateMockRequest(map[string]interface{}{
+		"from_task_id": "MV-1", "entry_id": misfiled.ID, "to_task_id": "MV-2", "reason": "logged on the wrong task",
+	}))
+	if result.IsError {
+		t.Fatalf("Move failed: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+
+	source, _ = js.loadTask("MV-1")
+	target, _ := js.loadTask("MV-2")
+	for _, entry := range source.Entries {
+		if entry.ID == misfiled.ID {
+			t.Fatal("Expected the entry to leave the source task")
+		}
+	}
+	var moved *Entry
+	for i := range target.Entries {
+		if target.Entries[i].ID == misfiled.ID {
+			moved = &target.Entries[i]
+		}
+	}
+	if moved == nil || !moved.Timestamp.Equal(misfiled.Timestamp) || moved.Content != misfiled.Content {
+		t.Fatalf("Expected the entry on the target with its timestamp, got %+v", target.Entries)
+	}
+
+	outNote := source.Entries[len(source.Entries)-1]
+	inNote := target.Entries[len(target.Entries)-1]
+	if outNote.Type != "moved" || !strings.Contains(outNote.Content, "to MV-2: logged on the wrong task") {
+		t.Errorf("Unexpected trace on the source: %+v", outNote)
+	}
+	if inNote.Type != "moved" || !strings.Contains(inNote.Content, "here from MV-1") {
+		t.Errorf("Unexpected trace on the target: %+v", inNote)
+	}
+
+	// The daily log copy moves with the entry
+	data, _ := js.readDataFile(filepath.Join(js.DataDir, "daily", misfiled.Timestamp.Format("2006-01-02")+".json"))
+	var activity DailyActivity
+	json.Unmarshal(data, &activity)
+	for _, entry := range activity.Tasks["MV-1"] {
+		if entry.ID == misfiled.ID {
+			t.Error("Expected the daily log entry to leave MV-1")
+		}
+	}
+	found := false
+	for _, entry := range activity.Tasks["MV-2"] {
+		found = found || entry.ID == misfiled.ID
+	}
+	if !found {
+		t.Errorf("Expected the daily log entry under MV-2, got %v", activity.Tasks)
+	}
+
+	for _, args := range []map[string]interface{}{
+		{"from_task_id": "MV-2", "entry_id": misfiled.ID, "to_task_id": "MV-2"},
+		{"from_task_id": "MV-1", "entry_id": misfiled.ID, "to_task_id": "MV-2"},
+		{"from_task_id": "MV-1", "entry_id": outNote.ID, "to_task_id": "MV-2"},
+		{"from_task_id": "MV-2", "entry_id": misfiled.ID, "to_task_id": "NOPE"},
+	} {
+		if result, _ := js.MoveEntry(ctx, CreateMockRequest(args)); !result.IsError {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package servers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// wantsNDJSON reports whether a list, search or log tool was asked for
+// NDJSON rather than markdown
+func wantsNDJSON(request mcp.CallToolRequest) (bool, error) {
+	switch format := request.GetString("format", "markdown"); format {
+	case "markdown":
+		return false, nil
+	case "ndjson":
+		return true, nil
+	default:
+		return false, fmt.Errorf("Invalid format: %s (expected markdown or ndjson)", format)
+	}
+}
+
+// ndjson encodes values as one JSON object per line, for jq, fzf and shell
+// scripts. No results is an empty string rather than a message.
+func ndjson[T any](values []T) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	for _, value := range values {
+		encoder.Encode(value)
+	}
+	return buf.String()
+}
+
+// TaskLine is a task in NDJSON output, with its entries counted rather than listed
+type TaskLine struct {
+	*Task
+	Entries int `json:"entries"` // shadows Task.Entries
+}
+
+// LogLine is an entry in NDJSON log and search output, flattened beside
+// the task and day it belongs to
+type LogLine struct {
+	Date      string  `json:"date"`
+	TaskID    string  `json:"task_id"`
+	TaskTitle string  `json:"task_title,omitempty"`
+	Context   string  `json:"context,omitempty"` // search results only: task, entry, both or a meeting type
+	Score     float64 `json:"score,omitempty"`   // search results only
+	Entry
+}
+
+func taskLines(tasks []*Task) []TaskLine {
+	lines := make([]TaskLine, 0, len(tasks))
+	for _, task := range tasks {
+		lines = append(lines, TaskLine{Task: task, Entries: len(task.Entries)})
+	}
+	return lines
+}
+
+// dayLines flattens days of activity into log lines, each day in time order
+func dayLines(days []DailyActivity, tasks map[string]*Task) []LogLine {
+	var lines []LogLine
+	for _, day := range days {
+		start := len(lines)
+		for taskID, entries := range day.Tasks {
+			title := ""
+			if task := tasks[taskID]; task != nil {
+				title = task.Title
+			}
+			for _, entry := range entries {
+				lines = append(lines, LogLine{Date: day.Date, TaskID: taskID, TaskTitle: title, Entry: entry})
+			}
+		}
+		dayOf := lines[start:]
+		sort.SliceStable(dayOf, func(i, j int) bool {
+			if !dayOf[i].Timestamp.Equal(dayOf[j].Timestamp) {
+				return dayOf[i].Timestamp.Before(dayOf[j].Timestamp)
+			}
+			return dayOf[i].TaskID < dayOf[j].TaskID
+		})
+	}
+	return lines
+}
@@ -0,0 +1,77 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestNDJSONOutput(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	call := func(handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]interface{}) []map[string]interface{} {
+		args["format"] = "ndjson"
+		result, _ := handler(ctx, CreateMockRequest(args))
+		text := result.Content[0].(mcp.TextContent).Text
+		if result.IsError {
+			t.Fatalf("Call failed: %s", text)
+		}
+		var lines []map[string]interface{}
+		for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+			if line == "" {
+				continue
+			}
+			var value map[string]interface{}
+			if err := json.Unmarshal([]byte(line), &value); err != nil {
+				t.Fatalf("Expected a JSON object per line, got %q: %v", line, err)
+			}
+			lines = append(lines, value)
+		}
+		return lines
+	}
+
+	day := time.Date(2026, 3, 4, 0, 0, 0, 0, time.Local)
+	for _, id := range []string{"ND-1", "ND-2"} {
+		createTestTask(t, js, id, "Deploy <"+id+">", "work")
+		task, _ := js.loadTask(id)
+		task.Entries = []Entry{{ID: id + "-a", Timestamp: day.Add(15 * time.Hour), Content: "Deploy went out"}}
+		if id == "ND-1" {
+			task.Entries = append(task.Entries, Entry{ID: id + "-b", Timestamp: day.Add(9 * time.Hour), Content: "Planned the rollout"})
+		}
+		js.saveTask(task)
+	}
+
+	tasks := call(js.ListTasks, map[string]interface{}{})
+	if len(tasks) != 2 || tasks[0]["entries"] == nil {
+		t.Fatalf("Expected a line per task with entries counted, got %v", tasks)
+	}
+	for _, task := range tasks {
+		if task["id"] == "ND-1" && (task["entries"] != 2.0 || task["title"] != "Deploy <ND-1>") {
+			t.Errorf("Unexpected task line: %v", task)
+		}
+	}
+
+	entries := call(js.GetDailyLog, map[string]interface{}{"date": "2026-03-04", "timezone": time.Local.String()})
+	if len(entries) != 3 || entries[0]["content"] != "Planned the rollout" || entries[0]["task_title"] != "Deploy <ND-1>" || entries[0]["date"] != "2026-03-04" {
+		t.Errorf("Expected the day's entries in time order, got %v", entries)
+	}
+	if week := call(js.GetWeeklyLog, map[string]interface{}{"week_start": "2026-03-02", "timezone": time.Local.String()}); len(week) != 3 {
+		t.Errorf("Expected the week's three entries, got %v", week)
+	}
+
+	results := call(js.SearchEntries, map[string]interface{}{"query": "rollout"})
+	if len(results) != 1 || results[0]["task_id"] != "ND-1" || results[0]["context"] != "entry" || results[0]["score"] == nil {
+		t.Errorf("Unexpected search lines: %v", results)
+	}
+	if none := call(js.SearchEntries, map[string]interface{}{"query": "nothing like this"}); len(none) != 0 {
+		t.Errorf("Expected no lines without results, got %v", none)
+	}
+
+	if result, _ := js.ListTasks(ctx, CreateMockRequest(map[string]interface{}{"format": "xml"})); !result.IsError {
+		t.Error("Expected an unknown format rejected")
+	}
+}
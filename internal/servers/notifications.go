@@ -0,0 +1,104 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Notification is a message from background work for the user to see on
+// their next visit, e.g. tasks paused by the auto-pause policy
+type Notification struct {
+	ID      string    `json:"id"`
+	Time    time.Time `json:"time"`
+	Kind    string    `json:"kind"`
+	TaskID  string    `json:"task_id,omitempty"`
+	Message string    `json:"message"`
+	Read    bool      `json:"read"`
+}
+
+func (js *JournalService) notificationsPath() string {
+	return filepath.Join(js.dataDir(), ".journal-mcp", "notifications.json")
+}
+
+func (js *JournalService) loadNotifications() ([]Notification, error) {
+	data, err := js.readDataFile(js.notificationsPath())
+	if os.IsNotExist(err) {
+		return []Notification{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var notifications []Notification
+	if err := json.Unmarshal(data, &notifications); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (js *JournalService) saveNotifications(notifications []Notification) error {
+	if err := os.MkdirAll(filepath.Dir(js.notificationsPath()), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(notifications, "", "  ")
+	if err != nil {
+		return err
+	}
+	return js.writeDataFile(js.notificationsPath(), data, 0644)
+}
+
+// notify records an unread notification
+func (js *JournalService) notify(kind, taskID, message string) error {
+	defer lockFile(js.notificationsPath())()
+
+	notifications, err := js.loadNotifications()
+	if err != nil {
+		return err
+	}
+	notifications = append(notifications, Notification{
+		ID:      generateEntryID(),
+		Time:    time.Now(),
+		Kind:    kind,
+		TaskID:  taskID,
+		Message: message,
+	})
+	return js.saveNotifications(notifications)
+}
+
+// ListNotifications returns notifications, newest first, and marks them read
+func (js *JournalService) ListNotifications(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	includeRead := request.GetString("include_read", "false") == "true"
+	defer lockFile(js.notificationsPath())()
+
+	notifications, err := js.loadNotifications()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load notifications: %v", err)), nil
+	}
+
+	result := []Notification{}
+	changed := false
+	for i := len(notifications) - 1; i >= 0; i-- {
+		if notifications[i].Read && !includeRead {
+			continue
+		}
+		result = append(result, notifications[i])
+		if !notifications[i].Read {
+			notifications[i].Read = true
+			changed = true
+		}
+	}
+
+	if changed {
+		if err := js.saveNotifications(notifications); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to save notifications: %v", err)), nil
+		}
+	}
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
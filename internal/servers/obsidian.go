@@ -0,0 +1,370 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	defaultObsidianFolder = "Journal"
+	obsidianSyncInterval  = 5 * time.Minute
+	// obsidianMarker is in the frontmatter of every exported note; only marked
+	// notes are ever rewritten or removed
+	obsidianMarker = "source: journal-mcp"
+)
+
+// ObsidianExport reports what an Obsidian export wrote
+type ObsidianExport struct {
+	Vault      string `json:"vault"`
+	Folder     string `json:"folder"`
+	Tasks      int    `json:"tasks"`
+	DailyNotes int    `json:"daily_notes"`
+	OneOnOnes  int    `json:"one_on_ones"`
+	Written    int    `json:"written"`
+	Unchanged  int    `json:"unchanged"`
+	Removed    int    `json:"removed"`
+	Continuous bool   `json:"continuous"`
+}
+
+// obsidianVault writes notes under a folder of a vault and links between them
+// with vault-relative wiki-links, so they resolve even when the vault has
+// other notes with the same names
+type obsidianVault struct {
+	folder string
+	notes  map[string][]byte // vault-relative path -> content
+}
+
+func (v *obsidianVault) taskLink(task *Task) string {
+	return fmt.Sprintf("[[%s/Tasks/%s|%s]]", v.folder, task.ID, task.ID)
+}
+
+func (v *obsidianVault) dayLink(date string) string {
+	return fmt.Sprintf("[[%s/Daily/%s|%s]]", v.folder, date, date)
+}
+
+func (v *obsidianVault) oneOnOneLink(meeting *OneOnOne) string {
+	return fmt.Sprintf("[[%s/One-on-ones/%s|%s]]", v.folder, oneOnOneName(meeting), oneOnOneTitle(meeting))
+}
+
+func oneOnOneTitle(meeting *OneOnOne) string {
+	if meeting.Person != "" {
+		return fmt.Sprintf("1-on-1 with %s %s", meeting.Person, meeting.Date)
+	}
+	return "1-on-1 " + meeting.Date
+}
+
+func (v *obsidianVault) add(dir, name string, content []byte) {
+	v.notes[path.Join(v.folder, dir, name+".md")] = content
+}
+
+// obsidianTag makes a journal tag usable as an Obsidian tag, which cannot contain spaces
+func obsidianTag(tag string) string {
+	return strings.Join(strings.Fields(tag), "-")
+}
+
+// obsidianFrontmatter renders simple frontmatter properties in order, skipping empty values
+func obsidianFrontmatter(properties [][2]string, tags []string) string {
+	var md strings.Builder
+	md.WriteString("---\n")
+	for _, property := range properties {
+		if property[1] != "" {
+			md.WriteString(fmt.Sprintf("%s: %q\n", property[0], property[1]))
+		}
+	}
+	if len(tags) > 0 {
+		md.WriteString("tags:\n")
+		for _, tag := range tags {
+			md.WriteString(fmt.Sprintf("  - %q\n", obsidianTag(tag)))
+		}
+	}
+	md.WriteString(obsidianMarker + "\n---\n\n")
+	return md.String()
+}
+
+// obsidianEntryLine renders an entry as a list item, indenting continuation lines
+func obsidianEntryLine(entry Entry, loc *time.Location) string {
+	content := strings.ReplaceAll(strings.TrimSpace(entry.Content), "\n", "\n  ")
+	return fmt.Sprintf("- %s (%s) %s\n", entry.Timestamp.In(loc).Format("15:04"), entryTypeLabel(entry), content)
+}
+
+// buildObsidianNotes renders every task, day with activity and 1-on-1 as notes
+func (js *JournalService) buildObsidianNotes(vault *obsidianVault, result *ObsidianExport) error {
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return err
+	}
+	archived, _ := js.loadArchivedTasks()
+	tasks = append(tasks, archived...)
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	meetings, _ := js.loadOneOnOnes()
+
+	loc := js.location()
+	byID := make(map[string]*Task)
+	children := make(map[string][]*Task)
+	for _, task := range tasks {
+		byID[task.ID] = task
+		if task.ParentID != "" {
+			children[task.ParentID] = append(children[task.ParentID], task)
+		}
+	}
+
+	// Day -> task -> entries, for the daily notes
+	days := make(map[string]map[string][]Entry)
+	for _, task := range tasks {
+		for _, entry := range task.Entries {
+			date := entry.Timestamp.In(loc).Format("2006-01-02")
+			if days[date] == nil {
+				days[date] = make(map[string][]Entry)
+			}
+			days[date][task.ID] = append(days[date][task.ID], entry)
+		}
+	}
+	meetingDays := make(map[string][]*OneOnOne)
+	for _, meeting := range meetings {
+		meetingDays[meeting.Date] = append(meetingDays[meeting.Date], meeting)
+		if days[meeting.Date] == nil {
+			days[meeting.Date] = make(map[string][]Entry)
+		}
+	}
+
+	for _, task := range tasks {
+		var md strings.Builder
+		archivedFlag := ""
+		if task.Archived {
+			archivedFlag = "true"
+		}
+		md.WriteString(obsidianFrontmatter([][2]string{
+			{"id", task.ID}, {"title", task.Title}, {"type", task.Type}, {"status", task.Status},
+			{"priority", task.Priority}, {"due", task.DueDate}, {"assignee", task.Assignee}, {"issue", task.IssueURL},
+			{"created", task.Created.In(loc).Format("2006-01-02")}, {"updated", task.Updated.In(loc).Format("2006-01-02")},
+			{"archived", archivedFlag},
+		}, task.Tags))
+		md.WriteString(fmt.Sprintf("# %s: %s\n\n", task.ID, task.Title))
+
+		if parent, ok := byID[task.ParentID]; ok {
+			md.WriteString(fmt.Sprintf("Parent: %s %s\n", vault.taskLink(parent), parent.Title))
+		}
+		if len(task.DependsOn) > 0 {
+			var links []string
+			for _, dep := range task.DependsOn {
+				if depTask, ok := byID[dep]; ok {
+					links = append(links, vault.taskLink(depTask))
+				} else {
+					links = append(links, dep)
+				}
+			}
+			md.WriteString("Depends on: " + strings.Join(links, ", ") + "\n")
+		}
+		if subtasks := children[task.ID]; len(subtasks) > 0 {
+			md.WriteString("\n## Subtasks\n")
+			for _, child := range subtasks {
+				md.WriteString(fmt.Sprintf("- %s %s (%s)\n", vault.taskLink(child), child.Title, child.Status))
+			}
+		}
+		if len(task.Checklist) > 0 {
+			md.WriteString("\n## Checklist\n")
+			for _, item := range task.Checklist {
+				box := " "
+				if item.Done {
+					box = "x"
+				}
+				md.WriteString(fmt.Sprintf("- [%s] %s\n", box, item.Text))
+			}
+		}
+
+		md.WriteString("\n## Log\n")
+		lastDate := ""
+		for _, entry := range task.Entries {
+			if date := entry.Timestamp.In(loc).Format("2006-01-02"); date != lastDate {
+				md.WriteString(fmt.Sprintf("\n### %s\n", vault.dayLink(date)))
+				lastDate = date
+			}
+			md.WriteString(obsidianEntryLine(entry, loc))
+		}
+		vault.add("Tasks", task.ID, []byte(md.String()))
+		result.Tasks++
+	}
+
+	dates := sortedKeys(days)
+	for i, date := range dates {
+		var md strings.Builder
+		md.WriteString(obsidianFrontmatter([][2]string{{"date", date}}, nil))
+		md.WriteString(fmt.Sprintf("# %s\n\n", date))
+		var nav []string
+		if i > 0 {
+			nav = append(nav, "← "+vault.dayLink(dates[i-1]))
+		}
+		if i < len(dates)-1 {
+			nav = append(nav, vault.dayLink(dates[i+1])+" →")
+		}
+		if len(nav) > 0 {
+			md.WriteString(strings.Join(nav, " | ") + "\n\n")
+		}
+		for _, taskID := range sortedKeys(days[date]) {
+			task := byID[taskID]
+			md.WriteString(fmt.Sprintf("## %s %s\n", vault.taskLink(task), task.Title))
+			for _, entry := range days[date][taskID] {
+				md.WriteString(obsidianEntryLine(entry, loc))
+			}
+			md.WriteString("\n")
+		}
+		if len(meetingDays[date]) > 0 {
+			md.WriteString("## 1-on-1\n")
+			for _, meeting := range meetingDays[date] {
+				md.WriteString("- " + vault.oneOnOneLink(meeting) + "\n")
+			}
+		}
+		vault.add("Daily", date, []byte(md.String()))
+		result.DailyNotes++
+	}
+
+	for _, meeting := range meetings {
+		var md strings.Builder
+		md.WriteString(obsidianFrontmatter([][2]string{{"date", meeting.Date}, {"person", meeting.Person}}, []string{"one-on-one"}))
+		md.WriteString(fmt.Sprintf("# %s\n\n", oneOnOneTitle(meeting)))
+		md.WriteString("Day: " + vault.dayLink(meeting.Date) + "\n")
+		sections := []struct {
+			heading string
+			items   []string
+			prefix  string
+		}{
+			{"Insights", meeting.Insights, "- "},
+			{"Todos", meeting.Todos, "- [ ] "},
+			{"Feedback", meeting.Feedback, "- "},
+		}
+		for _, section := range sections {
+			if len(section.items) == 0 {
+				continue
+			}
+			md.WriteString(fmt.Sprintf("\n## %s\n", section.heading))
+			for _, item := range section.items {
+				md.WriteString(section.prefix + item + "\n")
+			}
+		}
+		if notes := strings.TrimSpace(meeting.Notes); notes != "" {
+			md.WriteString("\n## Notes\n" + notes + "\n")
+		}
+		vault.add("One-on-ones", oneOnOneName(meeting), []byte(md.String()))
+		result.OneOnOnes++
+	}
+	return nil
+}
+
+// exportObsidian writes the journal into a vault folder. Unchanged notes are
+// not rewritten, so Obsidian and sync tools only see real changes, and
+// exported notes that no longer match anything in the journal are removed.
+func (js *JournalService) exportObsidian(vaultDir, folder string) (*ObsidianExport, error) {
+	if folder == "" {
+		folder = defaultObsidianFolder
+	}
+	folder = strings.Trim(filepath.ToSlash(folder), "/")
+	if !isSafeArchivePath(folder) {
+		return nil, fmt.Errorf("invalid folder %q", folder)
+	}
+	vault := &obsidianVault{folder: folder, notes: make(map[string][]byte)}
+	result := &ObsidianExport{Vault: vaultDir, Folder: folder}
+	if err := js.buildObsidianNotes(vault, result); err != nil {
+		return nil, err
+	}
+
+	for _, name := range sortedKeys(vault.notes) {
+		target := filepath.Join(vaultDir, filepath.FromSlash(name))
+		if existing, err := os.ReadFile(target); err == nil && bytes.Equal(existing, vault.notes[name]) {
+			result.Unchanged++
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create vault folder: %w", err)
+		}
+		if err := writeFileAtomic(target, vault.notes[name], 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		result.Written++
+	}
+
+	for _, dir := range []string{"Tasks", "Daily", "One-on-ones"} {
+		files, _ := filepath.Glob(filepath.Join(vaultDir, filepath.FromSlash(folder), dir, "*.md"))
+		for _, file := range files {
+			name := path.Join(folder, dir, filepath.Base(file))
+			if _, current := vault.notes[name]; current {
+				continue
+			}
+			if data, err := os.ReadFile(file); err == nil && bytes.Contains(data, []byte("\n"+obsidianMarker+"\n")) {
+				if os.Remove(file) == nil {
+					result.Removed++
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// ExportToObsidian writes tasks, daily notes and 1-on-ones into an Obsidian
+// vault, optionally keeping it in sync while the server runs
+func (js *JournalService) ExportToObsidian(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load configuration: %v", err)), nil
+	}
+
+	vaultDir := request.GetString("vault_path", config.Obsidian.Vault)
+	if vaultDir == "" {
+		return mcp.NewToolResultError("vault_path is required (or set obsidian.vault in the configuration)"), nil
+	}
+	if info, err := os.Stat(vaultDir); err != nil || !info.IsDir() {
+		return mcp.NewToolResultError(fmt.Sprintf("Vault directory not found: %s", vaultDir)), nil
+	}
+	folder := request.GetString("folder", config.Obsidian.Folder)
+
+	result, err := js.exportObsidian(vaultDir, folder)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to export to Obsidian: %v", err)), nil
+	}
+
+	if continuous := request.GetString("continuous", ""); continuous != "" {
+		config.Obsidian.Sync = continuous == "true"
+		if config.Obsidian.Sync {
+			config.Obsidian.Vault = vaultDir
+			config.Obsidian.Folder = folder
+		}
+		if err := js.saveConfiguration(config); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to save configuration: %v", err)), nil
+		}
+	}
+	result.Continuous = config.Obsidian.Sync && config.Obsidian.Vault == vaultDir
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// obsidianSyncJob re-exports to the configured vault every few minutes when obsidian.sync is on
+func (js *JournalService) obsidianSyncJob() ScheduledJob {
+	return ScheduledJob{
+		Name: "obsidian_sync",
+		Due: func(now, lastRun time.Time) bool {
+			config, err := js.loadConfiguration()
+			if err != nil || !config.Obsidian.Sync || config.Obsidian.Vault == "" {
+				return false
+			}
+			return now.Sub(lastRun) >= obsidianSyncInterval
+		},
+		Run: func(ctx context.Context) error {
+			config, err := js.loadConfiguration()
+			if err != nil {
+				return err
+			}
+			_, err = js.exportObsidian(config.Obsidian.Vault, config.Obsidian.Folder)
+			return err
+		},
+	}
+}
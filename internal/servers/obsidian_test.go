@@ -0,0 +1,119 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestExportToObsidian(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	config := defaultConfiguration()
+	config.General.TimeZone = "UTC"
+	js.saveConfiguration(config)
+
+	createTestTask(t, js, "OBS-1", "Launch plan", "work")
+	createTestTask(t, js, "OBS-2", "Write the runbook", "work")
+	task, _ := js.loadTask("OBS-2")
+	task.ParentID = "OBS-1"
+	task.Tags = []string{"on call"}
+	js.saveTask(task)
+	js.saveOneOnOne(&OneOnOne{Date: "2026-03-14", Todos: []string{"Share the runbook"}, Created: time.Now()})
+
+	vault := t.TempDir()
+	os.WriteFile(filepath.Join(vault, "Ideas.md"), []byte("my own note"), 0644)
+
+	result, _ := js.ExportToObsidian(ctx, CreateMockRequest(map[string]interface{}{"vault_path": vault}))
+	if result.IsError {
+		t.Fatalf("Export failed: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	var export ObsidianExport
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &export)
+	if export.Tasks != 2 || export.OneOnOnes != 1 || export.DailyNotes < 2 || export.Written != export.Tasks+export.DailyNotes+export.OneOnOnes {
+		t.Fatalf("Unexpected export: %+v", export)
+	}
+
+	today := time.Now().UTC().Format("2006-01-02")
+	child, err := os.ReadFile(filepath.Join(vault, "Journal", "Tasks", "OBS-2.md"))
+	if err != nil {
+		t.Fatalf("Expected a task note: %v", err)
+	}
+	for _, want := range []string{"status: \"active\"", "  - \"on-call\"", "source: journal-mcp", "Parent: [[Journal/Tasks/OBS-1|OBS-1]] Launch plan", "### [[Journal/Daily/" + today + "|" + today + "]]"} {
+		if !strings.Contains(string(child), want) {
+			t.Errorf("Expected %q in the task note:\n%s", want, child)
+		}
+	}
+	parent, _ := os.ReadFile(filepath.Join(vault, "Journal", "Tasks", "OBS-1.md"))
+	if !strings.Contains(string(parent), "- [[Journal/Tasks/OBS-2|OBS-2]] Write the runbook (active)") {
+		t.Errorf("Expected the subtask linked from the parent:\n%s", parent)
+	}
+	daily, _ := os.ReadFile(filepath.Join(vault, "Journal", "Daily", today+".md"))
+	if !strings.Contains(string(daily), "## [[Journal/Tasks/OBS-1|OBS-1]] Launch plan") || !strings.Contains(string(daily), "← [[Journal/Daily/2026-03-14|2026-03-14]]") {
+		t.Errorf("Unexpected daily note:\n%s", daily)
+	}
+	meeting, _ := os.ReadFile(filepath.Join(vault, "Journal", "One-on-ones", "2026-03-14.md"))
+	if !strings.Contains(string(meeting), "- [ ] Share the runbook") || !strings.Contains(string(meeting), "Day: [[Journal/Daily/2026-03-14|2026-03-14]]") {
+		t.Errorf("Unexpected 1-on-1 note:\n%s", meeting)
+	}
+
+	// A second export rewrites nothing; a deleted task's note is removed, other notes are left alone
+	js.storage().DeleteTask("OBS-2")
+	os.WriteFile(filepath.Join(vault, "Journal", "Tasks", "Mine.md"), []byte("---\ntitle: mine\n---\n"), 0644)
+	again, err := js.exportObsidian(vault, "")
+	if err != nil {
+		t.Fatalf("Second export failed: %v", err)
+	}
+	if again.Removed != 1 || again.Written == 0 || again.Unchanged == 0 {
+		t.Errorf("Expected only the changed notes written and the stale note removed, got %+v", again)
+	}
+	if _, err := os.Stat(filepath.Join(vault, "Journal", "Tasks", "OBS-2.md")); !os.IsNotExist(err) {
+		t.Error("Expected the deleted task's note removed")
+	}
+	for _, kept := range []string{filepath.Join(vault, "Journal", "Tasks", "Mine.md"), filepath.Join(vault, "Ideas.md")} {
+		if _, err := os.Stat(kept); err != nil {
+			t.Errorf("Expected %s to be left alone", kept)
+		}
+	}
+}
+
+func TestObsidianSyncJob(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "OBS-3", "Sync me", "work")
+	vault := t.TempDir()
+
+	job := js.obsidianSyncJob()
+	if job.Due(time.Now(), time.Time{}) {
+		t.Error("Expected no sync without a configured vault")
+	}
+
+	result, _ := js.ExportToObsidian(ctx, CreateMockRequest(map[string]interface{}{"vault_path": vault, "folder": "Work/Log", "continuous": "true"}))
+	var export ObsidianExport
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &export)
+	if !export.Continuous {
+		t.Fatalf("Expected continuous sync, got %s", result.Content[0].(mcp.TextContent).Text)
+	}
+
+	now := time.Now()
+	if !job.Due(now, now.Add(-10*time.Minute)) || job.Due(now, now.Add(-time.Minute)) {
+		t.Error("Expected the sync every five minutes")
+	}
+	createTestTask(t, js, "OBS-4", "New task", "work")
+	if err := job.Run(ctx); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(vault, "Work", "Log", "Tasks", "OBS-4.md")); err != nil {
+		t.Errorf("Expected the new task synced into the configured folder: %v", err)
+	}
+
+	if result, _ := js.ExportToObsidian(ctx, CreateMockRequest(map[string]interface{}{"vault_path": vault, "folder": "../outside"})); !result.IsError {
+		t.Error("Expected a folder outside the vault to be rejected")
+	}
+}
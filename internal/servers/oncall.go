@@ -0,0 +1,617 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	onCallSyncInterval   = time.Hour
+	onCallDefaultDays    = 7 // sync_oncall looks this far back by default
+	onCallHeavyPages     = 5 // pages in a week that make it a heavy on-call week
+	onCallHeavyAfterHour = 2 // or after-hours pages
+	onCallBaselineWeeks  = 8 // weeks before a heavy week that set the usual output
+
+	// Pages outside 09:00-18:00 on weekdays are after hours
+	workdayStartHour = 9
+	workdayEndHour   = 18
+)
+
+// onCallProviders are the supported paging services
+var onCallProviders = []string{"pagerduty", "opsgenie"}
+
+// opsgenieAPIURL is the default Opsgenie REST API; tests replace it
+var opsgenieAPIURL = "https://api.opsgenie.com/"
+
+// OnCallShift is a stretch of time the user was on call
+type OnCallShift struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Schedule string    `json:"schedule,omitempty"`
+}
+
+// OnCallPage is an incident or alert raised during a shift
+type OnCallPage struct {
+	ID      string    `json:"id"`
+	Title   string    `json:"title"`
+	Urgency string    `json:"urgency,omitempty"` // PagerDuty urgency or Opsgenie priority
+	Status  string    `json:"status,omitempty"`
+	URL     string    `json:"url,omitempty"`
+	Created time.Time `json:"created"`
+}
+
+// onCallProvider reads shifts and pages from a paging service
+type onCallProvider interface {
+	shifts(ctx context.Context, since, until time.Time) ([]OnCallShift, error)
+	pages(ctx context.Context, since, until time.Time) ([]OnCallPage, error)
+}
+
+// OnCallSyncResult reports an on-call import
+type OnCallSyncResult struct {
+	Provider     string   `json:"provider"`
+	Shifts       int      `json:"shifts"`
+	Pages        int      `json:"pages"`
+	EntriesAdded int      `json:"entries_added"`
+	Updated      int      `json:"entries_updated,omitempty"` // pages whose status changed since the last import
+	Tasks        []string `json:"tasks,omitempty"`           // weekly on-call tasks touched
+	Summary      string   `json:"summary"`
+}
+
+// pagerDutyOnCall reads on-call shifts and incidents from PagerDuty
+type pagerDutyOnCall struct {
+	client *http.Client
+	token  string
+	userID string
+	policy map[string]bool // escalation policies the user was on call for, filled in by shifts
+}
+
+func (p *pagerDutyOnCall) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pagerDutyAPIURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.pagerduty+json;version=2")
+	req.Header.Set("Authorization", "Token token="+p.token)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("PagerDuty %s: %s", path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (p *pagerDutyOnCall) shifts(ctx context.Context, since, until time.Time) ([]OnCallShift, error) {
+	params := url.Values{"user_ids[]": {p.userID}, "since": {since.Format(time.RFC3339)}, "until": {until.Format(time.RFC3339)}}
+	var page struct {
+		OnCalls []struct {
+			Start    *time.Time `json:"start"`
+			End      *time.Time `json:"end"`
+			Schedule *struct {
+				Summary string `json:"summary"`
+			} `json:"schedule"`
+			EscalationPolicy struct {
+				ID string `json:"id"`
+			} `json:"escalation_policy"`
+		} `json:"oncalls"`
+	}
+	if err := p.get(ctx, "oncalls", params, &page); err != nil {
+		return nil, err
+	}
+	p.policy = make(map[string]bool)
+	var shifts []OnCallShift
+	for _, oncall := range page.OnCalls {
+		shift := OnCallShift{Start: since, End: until} // permanent on-call has no start or end
+		if oncall.Start != nil {
+			shift.Start = *oncall.Start
+		}
+		if oncall.End != nil {
+			shift.End = *oncall.End
+		}
+		if oncall.Schedule != nil {
+			shift.Schedule = oncall.Schedule.Summary
+		}
+		p.policy[oncall.EscalationPolicy.ID] = true
+		shifts = append(shifts, shift)
+	}
+	return shifts, nil
+}
+
+func (p *pagerDutyOnCall) pages(ctx context.Context, since, until time.Time) ([]OnCallPage, error) {
+	var pages []OnCallPage
+	for offset := 0; ; {
+		params := url.Values{"since": {since.Format(time.RFC3339)}, "until": {until.Format(time.RFC3339)},
+			"limit": {"100"}, "offset": {strconv.Itoa(offset)}}
+		var page struct {
+			Incidents []struct {
+				ID               string    `json:"id"`
+				Title            string    `json:"title"`
+				Urgency          string    `json:"urgency"`
+				Status           string    `json:"status"`
+				HTMLURL          string    `json:"html_url"`
+				CreatedAt        time.Time `json:"created_at"`
+				EscalationPolicy struct {
+					ID string `json:"id"`
+				} `json:"escalation_policy"`
+			} `json:"incidents"`
+			More bool `json:"more"`
+		}
+		if err := p.get(ctx, "incidents", params, &page); err != nil {
+			return nil, err
+		}
+		for _, incident := range page.Incidents {
+			// Only incidents that escalated through a policy the user was on call for
+			if p.policy != nil && !p.policy[incident.EscalationPolicy.ID] {
+				continue
+			}
+			pages = append(pages, OnCallPage{ID: incident.ID, Title: incident.Title, Urgency: incident.Urgency,
+				Status: incident.Status, URL: incident.HTMLURL, Created: incident.CreatedAt})
+		}
+		if !page.More || len(page.Incidents) == 0 {
+			break
+		}
+		offset += len(page.Incidents)
+	}
+	return pages, nil
+}
+
+// opsgenieOnCall reads schedule rotations and alerts from Opsgenie
+type opsgenieOnCall struct {
+	client    *http.Client
+	baseURL   string
+	token     string
+	user      string // username, usually an email address
+	schedules []string
+}
+
+func (o *opsgenieOnCall) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.baseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "GenieKey "+o.token)
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Opsgenie %s: %s", path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (o *opsgenieOnCall) shifts(ctx context.Context, since, until time.Time) ([]OnCallShift, error) {
+	days := int(until.Sub(since).Hours()/24) + 1
+	var shifts []OnCallShift
+	for _, schedule := range o.schedules {
+		params := url.Values{"identifierType": {"name"}, "interval": {strconv.Itoa(days)}, "intervalUnit": {"days"},
+			"date": {since.Format(time.RFC3339)}}
+		var timeline struct {
+			Data struct {
+				FinalTimeline struct {
+					Rotations []struct {
+						Periods []struct {
+							StartDate time.Time `json:"startDate"`
+							EndDate   time.Time `json:"endDate"`
+							Recipient struct {
+								Type string `json:"type"`
+								Name string `json:"name"`
+							} `json:"recipient"`
+						} `json:"periods"`
+					} `json:"rotations"`
+				} `json:"finalTimeline"`
+			} `json:"data"`
+		}
+		if err := o.get(ctx, "v2/schedules/"+url.PathEscape(schedule)+"/timeline", params, &timeline); err != nil {
+			return nil, err
+		}
+		for _, rotation := range timeline.Data.FinalTimeline.Rotations {
+			for _, period := range rotation.Periods {
+				if period.Recipient.Type == "user" && strings.EqualFold(period.Recipient.Name, o.user) {
+					shifts = append(shifts, OnCallShift{Start: period.StartDate, End: period.EndDate, Schedule: schedule})
+				}
+			}
+		}
+	}
+	return shifts, nil
+}
+
+func (o *opsgenieOnCall) pages(ctx context.Context, since, until time.Time) ([]OnCallPage, error) {
+	query := fmt.Sprintf("createdAt >= %d AND createdAt < %d", since.UnixMilli(), until.UnixMilli())
+	var pages []OnCallPage
+	for offset := 0; ; {
+		params := url.Values{"query": {query}, "limit": {"100"}, "offset": {strconv.Itoa(offset)}, "sort": {"createdAt"}, "order": {"asc"}}
+		var page struct {
+			Data []struct {
+				ID        string    `json:"id"`
+				TinyID    string    `json:"tinyId"`
+				Message   string    `json:"message"`
+				Priority  string    `json:"priority"`
+				Status    string    `json:"status"`
+				CreatedAt time.Time `json:"createdAt"`
+			} `json:"data"`
+		}
+		if err := o.get(ctx, "v2/alerts", params, &page); err != nil {
+			return nil, err
+		}
+		for _, alert := range page.Data {
+			pages = append(pages, OnCallPage{ID: alert.ID, Title: alert.Message, Urgency: alert.Priority, Status: alert.Status, Created: alert.CreatedAt})
+		}
+		if len(page.Data) < 100 {
+			break
+		}
+		offset += len(page.Data)
+	}
+	return pages, nil
+}
+
+// onCallProvider builds the configured paging service client
+func (js *JournalService) onCallProvider(config *Configuration) (onCallProvider, error) {
+	settings := config.OnCall
+	if settings.Provider == "" {
+		return nil, fmt.Errorf("oncall.provider is not set (pagerduty or opsgenie)")
+	}
+	if settings.UserID == "" {
+		return nil, fmt.Errorf("oncall.user_id is required (your PagerDuty user ID or Opsgenie username)")
+	}
+	token := settings.Token
+	if token == "" {
+		token = secretRefPrefix + settings.Provider + "_token"
+	}
+	token, _ = js.resolveSecretRef(token)
+	if token == "" {
+		return nil, fmt.Errorf("an on-call API token is required (store it once with set_secret name=%s_token)", settings.Provider)
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	if settings.Provider == "opsgenie" {
+		if len(settings.Schedules) == 0 {
+			return nil, fmt.Errorf("oncall.schedules is required for Opsgenie")
+		}
+		baseURL := opsgenieAPIURL
+		if settings.BaseURL != "" {
+			baseURL = strings.TrimRight(settings.BaseURL, "/") + "/"
+		}
+		return &opsgenieOnCall{client: client, baseURL: baseURL, token: token, user: settings.UserID, schedules: settings.Schedules}, nil
+	}
+	return &pagerDutyOnCall{client: client, token: token, userID: settings.UserID}, nil
+}
+
+// afterHours reports whether t falls outside 09:00-18:00 on a weekday in loc
+func afterHours(t time.Time, loc *time.Location) bool {
+	t = t.In(loc)
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return true
+	}
+	return t.Hour() < workdayStartHour || t.Hour() >= workdayEndHour
+}
+
+// onCallTaskID names the on-call task of the rotation week starting weekStart
+func onCallTaskID(weekStart time.Time) string {
+	return "ONCALL-" + weekStart.Format("2006-01-02")
+}
+
+// pageEntryContent describes a page as an entry
+func pageEntryContent(page OnCallPage, loc *time.Location) string {
+	content := "Paged: " + page.Title
+	var details []string
+	if page.Urgency != "" {
+		details = append(details, page.Urgency)
+	}
+	if page.Status != "" {
+		details = append(details, page.Status)
+	}
+	if afterHours(page.Created, loc) {
+		details = append(details, "after hours")
+	}
+	if len(details) > 0 {
+		content += " (" + strings.Join(details, ", ") + ")"
+	}
+	if page.URL != "" {
+		content += "\n" + page.URL
+	}
+	return content
+}
+
+// syncOnCall imports shifts and pages in [since, until) as entries on weekly
+// on-call tasks, creating the tasks as needed; re-imports update changed pages
+func (js *JournalService) syncOnCall(ctx context.Context, since, until time.Time) (*OnCallSyncResult, error) {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	provider, err := js.onCallProvider(config)
+	if err != nil {
+		return nil, err
+	}
+	shifts, err := provider.shifts(ctx, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read on-call shifts: %w", err)
+	}
+
+	loc := js.location()
+	first := js.firstWeekday()
+	result := &OnCallSyncResult{Provider: config.OnCall.Provider}
+	entries := make(map[string][]Entry) // task ID -> entries to add or update
+	seen := make(map[string]bool)       // shifts and pages already taken from an overlapping shift
+	for _, shift := range shifts {
+		shiftID := fmt.Sprintf("oncall_%s_shift_%d_%d", config.OnCall.Provider, shift.Start.Unix(), shift.End.Unix())
+		if seen[shiftID] {
+			// The same shift on another escalation policy or level
+			continue
+		}
+		seen[shiftID] = true
+		result.Shifts++
+		start := shift.Start.In(loc)
+		content := fmt.Sprintf("On call from %s to %s", start.Format("Mon 2 Jan 15:04"), shift.End.In(loc).Format("Mon 2 Jan 15:04"))
+		if shift.Schedule != "" {
+			content += " (" + shift.Schedule + ")"
+		}
+		taskID := onCallTaskID(weekStartFrom(start, first))
+		entries[taskID] = append(entries[taskID], Entry{ID: shiftID, Timestamp: shift.Start, Content: content, Type: "oncall"})
+
+		pages, err := provider.pages(ctx, maxTime(shift.Start, since), minTime(shift.End, until))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pages: %w", err)
+		}
+		for _, page := range pages {
+			if seen[page.ID] {
+				continue
+			}
+			seen[page.ID] = true
+			result.Pages++
+			taskID := onCallTaskID(weekStartFrom(page.Created.In(loc), first))
+			entries[taskID] = append(entries[taskID], Entry{
+				ID:        fmt.Sprintf("oncall_%s_%s", config.OnCall.Provider, page.ID),
+				Timestamp: page.Created, Content: pageEntryContent(page, loc), Type: "page",
+			})
+		}
+	}
+
+	for _, taskID := range sortedKeys(entries) {
+		added, updated, err := js.addOnCallEntries(taskID, entries[taskID], first, loc)
+		if err != nil {
+			return nil, err
+		}
+		result.EntriesAdded += added
+		result.Updated += updated
+		result.Tasks = append(result.Tasks, taskID)
+	}
+	result.Summary = fmt.Sprintf("Imported %d shifts and %d pages from %s: %d entries added, %d updated", result.Shifts, result.Pages, config.OnCall.Provider, result.EntriesAdded, result.Updated)
+	return result, nil
+}
+
+// addOnCallEntries adds entries to a weekly on-call task, creating it if
+// needed, and completes the task once its week is over
+func (js *JournalService) addOnCallEntries(taskID string, entries []Entry, first time.Weekday, loc *time.Location) (int, int, error) {
+	defer js.lockTask(taskID)()
+	task, err := js.loadTask(taskID)
+	created := err != nil
+	if created {
+		weekStart, _ := time.ParseInLocation("2006-01-02", strings.TrimPrefix(taskID, "ONCALL-"), loc)
+		task = &Task{
+			ID:      taskID,
+			Title:   "On-call week of " + weekStart.Format("2006-01-02"),
+			Type:    "work",
+			Tags:    []string{"oncall"},
+			Status:  "active",
+			Created: time.Now(),
+			Entries: []Entry{},
+		}
+	}
+
+	added, updated := 0, 0
+	for _, entry := range entries {
+		if i := slices.IndexFunc(task.Entries, func(e Entry) bool { return e.ID == entry.ID }); i >= 0 {
+			if task.Entries[i].Content == entry.Content {
+				continue
+			}
+			task.Entries[i].Content = entry.Content
+			entry = task.Entries[i]
+			updated++
+		} else {
+			task.Entries = append(task.Entries, entry)
+			added++
+		}
+		js.updateDailyLog(taskID, entry)
+	}
+	sortEntriesByTime(task.Entries)
+
+	weekStart, _ := time.ParseInLocation("2006-01-02", strings.TrimPrefix(taskID, "ONCALL-"), loc)
+	weekOver := !time.Now().Before(weekStart.AddDate(0, 0, 7))
+	if task.Status == "active" && weekOver {
+		task.Status = "completed"
+	} else if !created && added+updated == 0 {
+		return 0, 0, nil
+	}
+	task.Updated = time.Now()
+	if err := js.saveTask(task); err != nil {
+		return 0, 0, fmt.Errorf("failed to save task %s: %w", taskID, err)
+	}
+	return added, updated, nil
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// onCallSyncJob imports shifts and pages every hour when oncall.auto_sync is set
+func (js *JournalService) onCallSyncJob() ScheduledJob {
+	return ScheduledJob{
+		Name: "oncall_sync",
+		Due: func(now, lastRun time.Time) bool {
+			config, err := js.loadConfiguration()
+			if err != nil || !config.OnCall.AutoSync || config.OnCall.Provider == "" {
+				return false
+			}
+			return now.Sub(lastRun) >= onCallSyncInterval
+		},
+		Run: func(ctx context.Context) error {
+			// Failures are logged rather than returned so an unreachable
+			// provider is not retried every minute; the next hour tries again
+			now := time.Now()
+			if _, err := js.syncOnCall(ctx, now.AddDate(0, 0, -1), now); err != nil {
+				log.Printf("On-call sync failed: %v", err)
+			}
+			return nil
+		},
+	}
+}
+
+// SyncOnCall imports on-call shifts and the pages raised during them from
+// PagerDuty or Opsgenie onto weekly on-call tasks
+func (js *JournalService) SyncOnCall(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	loc := js.location()
+	until := time.Now()
+	since := until.AddDate(0, 0, -onCallDefaultDays)
+	if value := request.GetString("since", ""); value != "" {
+		if validationErr := js.validateDateFormat(value, "since"); validationErr != nil {
+			return mcp.NewToolResultError(validationErr.Error()), nil
+		}
+		since, _ = time.ParseInLocation("2006-01-02", value, loc)
+	}
+	if value := request.GetString("until", ""); value != "" {
+		if validationErr := js.validateDateFormat(value, "until"); validationErr != nil {
+			return mcp.NewToolResultError(validationErr.Error()), nil
+		}
+		day, _ := time.ParseInLocation("2006-01-02", value, loc)
+		until = day.AddDate(0, 0, 1)
+	}
+	if !since.Before(until) {
+		return mcp.NewToolResultError("since must be before until"), nil
+	}
+
+	result, err := js.syncOnCall(ctx, since, until)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to sync on-call: %v", err)), nil
+	}
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// OnCallMetrics is on-call load per rotation week, to explain quieter weeks in analytics
+type OnCallMetrics struct {
+	TotalPages int          `json:"total_pages"`
+	Weeks      []OnCallWeek `json:"weeks"`
+}
+
+// OnCallWeek is one week with pages, against the other work done in it
+type OnCallWeek struct {
+	WeekStart    string  `json:"week_start"`
+	Pages        int     `json:"pages"`
+	AfterHours   int     `json:"after_hours"`
+	OtherEntries int     `json:"other_entries"` // written entries on tasks other than on-call ones
+	Usual        float64 `json:"usual_entries"` // mean of the weeks before that were not heavy on-call weeks
+	Heavy        bool    `json:"heavy"`
+}
+
+// isOnCallTask reports whether a task holds on-call shifts and pages
+func isOnCallTask(task *Task) bool {
+	return slices.Contains(task.Tags, "oncall")
+}
+
+// calculateOnCallMetrics counts pages per week on on-call tasks and sets
+// each week's other work beside the usual amount, or nil without pages
+func calculateOnCallMetrics(tasks []*Task, first time.Weekday, loc *time.Location) *OnCallMetrics {
+	pages := make(map[time.Time]*OnCallWeek)
+	other := make(map[time.Time]int)
+	for _, task := range tasks {
+		onCall := isOnCallTask(task)
+		for _, entry := range task.Entries {
+			week := weekStartFrom(entry.Timestamp.In(loc), first)
+			switch {
+			case onCall && entry.Type == "page":
+				if pages[week] == nil {
+					pages[week] = &OnCallWeek{WeekStart: week.Format("2006-01-02")}
+				}
+				pages[week].Pages++
+				if afterHours(entry.Timestamp, loc) {
+					pages[week].AfterHours++
+				}
+			case !onCall && isWrittenEntry(entry):
+				other[week]++
+			}
+		}
+	}
+	if len(pages) == 0 {
+		return nil
+	}
+
+	heavy := func(week time.Time) bool {
+		w := pages[week]
+		return w != nil && (w.Pages >= onCallHeavyPages || w.AfterHours >= onCallHeavyAfterHour)
+	}
+	metrics := &OnCallMetrics{Weeks: []OnCallWeek{}}
+	for _, week := range sortedTimes(pages) {
+		w := pages[week]
+		w.OtherEntries = other[week]
+		w.Heavy = heavy(week)
+		total, n := 0, 0
+		for i := 1; i <= onCallBaselineWeeks; i++ {
+			before := week.AddDate(0, 0, -7*i)
+			if !heavy(before) {
+				total += other[before]
+				n++
+			}
+		}
+		if n > 0 {
+			w.Usual = float64(total) / float64(n)
+		}
+		metrics.TotalPages += w.Pages
+		metrics.Weeks = append(metrics.Weeks, *w)
+	}
+	return metrics
+}
+
+// sortedTimes returns a map's time keys in order
+func sortedTimes[V any](m map[time.Time]V) []time.Time {
+	keys := make([]time.Time, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Before(keys[j]) })
+	return keys
+}
+
+// onCallInsights explains heavy on-call weeks where other work dropped
+func onCallInsights(metrics *OnCallMetrics) []string {
+	if metrics == nil {
+		return nil
+	}
+	var insights []string
+	for _, week := range metrics.Weeks {
+		if !week.Heavy {
+			continue
+		}
+		insight := fmt.Sprintf("The week of %s was a heavy on-call week (%d pages, %d after hours)", week.WeekStart, week.Pages, week.AfterHours)
+		if float64(week.OtherEntries) < week.Usual {
+			insight += fmt.Sprintf(", which explains %d entries on other work against a usual %.0f", week.OtherEntries, week.Usual)
+		}
+		insights = append(insights, insight+".")
+	}
+	return insights
+}
@@ -0,0 +1,197 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestAfterHours(t *testing.T) {
+	for when, expected := range map[time.Time]bool{
+		time.Date(2026, 3, 3, 10, 0, 0, 0, time.UTC): false, // Tuesday morning
+		time.Date(2026, 3, 3, 18, 0, 0, 0, time.UTC): true,
+		time.Date(2026, 3, 3, 8, 59, 0, 0, time.UTC): true,
+		time.Date(2026, 3, 7, 12, 0, 0, 0, time.UTC): true, // Saturday
+	} {
+		if afterHours(when, time.UTC) != expected {
+			t.Errorf("Expected afterHours(%v) = %v", when, expected)
+		}
+	}
+}
+
+func TestSyncOnCallPagerDuty(t *testing.T) {
+	status := "triggered"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token token=pd-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/pagerduty/oncalls":
+			if r.URL.Query().Get("user_ids[]") != "PUSER" {
+				t.Errorf("Unexpected on-call query: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"oncalls": [
+				{"start": "2026-03-02T09:00:00Z", "end": "2026-03-09T09:00:00Z", "schedule": {"summary": "Platform"}, "escalation_policy": {"id": "EP1"}},
+				{"start": "2026-03-02T09:00:00Z", "end": "2026-03-09T09:00:00Z", "escalation_policy": {"id": "EP2"}}]}`))
+		case "/pagerduty/incidents":
+			w.Write([]byte(`{"incidents": [
+				{"id": "P1", "title": "API 500s", "urgency": "high", "status": "` + status + `", "html_url": "https://acme.pagerduty.com/incidents/P1", "created_at": "2026-03-03T02:15:00Z", "escalation_policy": {"id": "EP1"}},
+				{"id": "P2", "title": "Disk full", "urgency": "low", "status": "resolved", "created_at": "2026-03-04T11:00:00Z", "escalation_policy": {"id": "EP2"}},
+				{"id": "P3", "title": "Someone else's page", "created_at": "2026-03-04T12:00:00Z", "escalation_policy": {"id": "EP9"}}], "more": false}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	original := pagerDutyAPIURL
+	pagerDutyAPIURL = server.URL + "/pagerduty/"
+	defer func() { pagerDutyAPIURL = original }()
+	t.Setenv("JOURNAL_MCP_SECRET_PAGERDUTY_TOKEN", "pd-token")
+
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	config := defaultConfiguration()
+	config.General.TimeZone = "UTC"
+	config.Secrets.Provider = "env"
+	config.OnCall.Provider = "pagerduty"
+	config.OnCall.UserID = "PUSER"
+	if err := js.saveConfiguration(config); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+
+	sync := func() OnCallSyncResult {
+		result, _ := js.SyncOnCall(ctx, CreateMockRequest(map[string]interface{}{"since": "2026-03-02", "until": "2026-03-08"}))
+		text := result.Content[0].(mcp.TextContent).Text
+		if result.IsError {
+			t.Fatalf("Sync failed: %s", text)
+		}
+		var synced OnCallSyncResult
+		json.Unmarshal([]byte(text), &synced)
+		return synced
+	}
+
+	// The shift on both escalation policies is imported once, with pages from either
+	synced := sync()
+	if synced.Shifts != 1 || synced.Pages != 2 || synced.EntriesAdded != 3 || len(synced.Tasks) != 1 || synced.Tasks[0] != "ONCALL-2026-03-02" {
+		t.Fatalf("Unexpected sync: %+v", synced)
+	}
+	task, err := js.loadTask("ONCALL-2026-03-02")
+	if err != nil {
+		t.Fatalf("Expected the on-call task created: %v", err)
+	}
+	if task.Status != "completed" || !isOnCallTask(task) {
+		t.Errorf("Expected a completed on-call task for a past week, got %+v", task)
+	}
+	var page *Entry
+	for i, entry := range task.Entries {
+		if entry.ID == "oncall_pagerduty_P1" {
+			page = &task.Entries[i]
+		}
+	}
+	if page == nil || page.Type != "page" || page.Content != "Paged: API 500s (high, triggered, after hours)\nhttps://acme.pagerduty.com/incidents/P1" {
+		t.Fatalf("Unexpected page entry: %+v", page)
+	}
+
+	if synced = sync(); synced.EntriesAdded != 0 || synced.Updated != 0 {
+		t.Errorf("Expected a repeat sync to change nothing, got %+v", synced)
+	}
+	status = "resolved"
+	if synced = sync(); synced.EntriesAdded != 0 || synced.Updated != 1 {
+		t.Errorf("Expected the resolved page updated, got %+v", synced)
+	}
+
+	config.OnCall.UserID = ""
+	js.saveConfiguration(config)
+	if result, _ := js.SyncOnCall(ctx, CreateMockRequest(map[string]interface{}{})); !result.IsError {
+		t.Error("Expected a sync without a user rejected")
+	}
+}
+
+func TestSyncOnCallOpsgenie(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/schedules/Platform/timeline":
+			w.Write([]byte(`{"data": {"finalTimeline": {"rotations": [{"periods": [
+				{"startDate": "2026-03-02T09:00:00Z", "endDate": "2026-03-05T09:00:00Z", "recipient": {"type": "user", "name": "me@acme.com"}},
+				{"startDate": "2026-03-05T09:00:00Z", "endDate": "2026-03-09T09:00:00Z", "recipient": {"type": "user", "name": "them@acme.com"}}]}]}}}`))
+		case "/v2/alerts":
+			if !strings.HasPrefix(r.URL.Query().Get("query"), "createdAt >= ") {
+				t.Errorf("Unexpected alert query: %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"data": [{"id": "a1", "message": "Queue backlog", "priority": "P2", "status": "open", "createdAt": "2026-03-03T14:00:00Z"}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	original := opsgenieAPIURL
+	opsgenieAPIURL = server.URL + "/"
+	defer func() { opsgenieAPIURL = original }()
+	t.Setenv("JOURNAL_MCP_SECRET_OPSGENIE_TOKEN", "og-token")
+
+	js, _ := CreateTestJournalService(t)
+	config := defaultConfiguration()
+	config.General.TimeZone = "UTC"
+	config.Secrets.Provider = "env"
+	config.OnCall.Provider = "opsgenie"
+	config.OnCall.UserID = "Me@acme.com"
+	config.OnCall.Schedules = []string{"Platform"}
+	js.saveConfiguration(config)
+
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	synced, err := js.syncOnCall(context.Background(), since, since.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+	if synced.Shifts != 1 || synced.Pages != 1 || synced.EntriesAdded != 2 {
+		t.Errorf("Expected only the user's shift imported, got %+v", synced)
+	}
+
+	// A failed hourly sync is logged, not retried every minute
+	opsgenieAPIURL = server.URL + "/missing/"
+	if err := js.onCallSyncJob().Run(context.Background()); err != nil {
+		t.Errorf("Expected the job to swallow a failed sync, got %v", err)
+	}
+}
+
+func TestCalculateOnCallMetrics(t *testing.T) {
+	week := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC) // a Monday
+	work := &Task{ID: "W-1"}
+	for i := 1; i <= 4; i++ {
+		// Ten entries in each of the four weeks before, two in the on-call week
+		for j := 0; j < 10; j++ {
+			work.Entries = append(work.Entries, Entry{Timestamp: week.AddDate(0, 0, -7*i).Add(10 * time.Hour), Content: "Work"})
+		}
+	}
+	work.Entries = append(work.Entries, Entry{Timestamp: week.Add(10 * time.Hour), Content: "Work"}, Entry{Timestamp: week.Add(11 * time.Hour), Content: "More work"})
+	onCall := &Task{ID: onCallTaskID(week), Tags: []string{"oncall"}, Entries: []Entry{
+		{Type: "oncall", Timestamp: week.Add(9 * time.Hour), Content: "On call"},
+		{Type: "page", Timestamp: week.Add(26 * time.Hour), Content: "Paged: API 500s"},
+		{Type: "page", Timestamp: week.Add(27 * time.Hour), Content: "Paged: API 500s again"},
+		{Type: "page", Timestamp: week.Add(34 * time.Hour), Content: "Paged: Disk full"},
+	}}
+
+	metrics := calculateOnCallMetrics([]*Task{work, onCall}, time.Monday, time.UTC)
+	if metrics == nil || metrics.TotalPages != 3 || len(metrics.Weeks) != 1 {
+		t.Fatalf("Unexpected metrics: %+v", metrics)
+	}
+	if w := metrics.Weeks[0]; w.AfterHours != 2 || !w.Heavy || w.OtherEntries != 2 || w.Usual != 5 {
+		t.Errorf("Expected a heavy week against a usual 5 entries, got %+v", w)
+	}
+	insights := onCallInsights(metrics)
+	if len(insights) != 1 || insights[0] != "The week of 2026-03-02 was a heavy on-call week (3 pages, 2 after hours), which explains 2 entries on other work against a usual 5." {
+		t.Errorf("Unexpected insights: %v", insights)
+	}
+	if calculateOnCallMetrics([]*Task{work}, time.Monday, time.UTC) != nil {
+		t.Error("Expected no metrics without pages")
+	}
+}
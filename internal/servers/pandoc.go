@@ -0,0 +1,360 @@
+package servers
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Structured export targets for users' own document pipelines: pandoc's JSON
+// AST (pandoc -f json -o report.docx) and DocBook 5. Both are converted from
+// the markdown the journal already generates.
+
+// pandocAPIVersion is the AST version written by pandoc 3.x
+var pandocAPIVersion = []int{1, 23, 1}
+
+// DocumentMeta is the metadata block of a structured export
+type DocumentMeta struct {
+	Title  string
+	Author string
+	Date   string // a date or range, e.g. "2026-01-01 to 2026-03-31"
+}
+
+// mdBlock is one block of generated markdown
+type mdBlock struct {
+	kind  string // heading, para, list, rule or code
+	level int    // heading level
+	lines []string
+}
+
+// mdInline is one inline span: text, strong, emph, code or link
+type mdInline struct {
+	kind string
+	text string
+	url  string
+}
+
+var (
+	mdListItemPattern = regexp.MustCompile(`^\s*[-*+] (.*)$`)
+	mdInlinePattern   = regexp.MustCompile("\\*\\*(.+?)\\*\\*|`([^`]+)`|\\[([^\\]]+)\\]\\(([^)\\s]+)\\)|_([^_]+)_")
+)
+
+// parseMarkdownBlocks splits generated markdown into headings, paragraphs,
+// bullet lists, rules and fenced code
+func parseMarkdownBlocks(md string) []mdBlock {
+	var blocks []mdBlock
+	var current *mdBlock
+	flush := func() {
+		if current != nil {
+			blocks = append(blocks, *current)
+			current = nil
+		}
+	}
+
+	lines := strings.Split(md, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+			code := mdBlock{kind: "code"}
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code.lines = append(code.lines, lines[i])
+			}
+			blocks = append(blocks, code)
+		case trimmed == "":
+			flush()
+		case trimmed == "---" || trimmed == "***":
+			flush()
+			blocks = append(blocks, mdBlock{kind: "rule"})
+		case mdHeadingPattern.MatchString(line):
+			flush()
+			m := mdHeadingPattern.FindStringSubmatch(line)
+			blocks = append(blocks, mdBlock{kind: "heading", level: len(m[1]), lines: []string{strings.TrimSpace(line[len(m[0]):])}})
+		case mdListItemPattern.MatchString(line):
+			if current == nil || current.kind != "list" {
+				flush()
+				current = &mdBlock{kind: "list"}
+			}
+			current.lines = append(current.lines, mdListItemPattern.FindStringSubmatch(line)[1])
+		default:
+			if current == nil || current.kind != "para" {
+				flush()
+				current = &mdBlock{kind: "para"}
+			}
+			current.lines = append(current.lines, trimmed)
+		}
+	}
+	flush()
+	return blocks
+}
+
+// parseMarkdownInlines splits a line into text and **strong**, _emph_, `code`
+// and [link](url) spans. Underscores inside words (snake_case) stay text.
+func parseMarkdownInlines(text string) []mdInline {
+	var inlines []mdInline
+	last := 0
+	for _, m := range mdInlinePattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := m[0], m[1]
+		var span mdInline
+		switch {
+		case m[2] >= 0:
+			span = mdInline{kind: "strong", text: text[m[2]:m[3]]}
+		case m[4] >= 0:
+			span = mdInline{kind: "code", text: text[m[4]:m[5]]}
+		case m[6] >= 0:
+			span = mdInline{kind: "link", text: text[m[6]:m[7]], url: text[m[8]:m[9]]}
+		default:
+			if isWordByte(text, start-1) || isWordByte(text, end) {
+				continue
+			}
+			span = mdInline{kind: "emph", text: text[m[10]:m[11]]}
+		}
+		if start > last {
+			inlines = append(inlines, mdInline{kind: "text", text: text[last:start]})
+		}
+		inlines = append(inlines, span)
+		last = end
+	}
+	if last < len(text) {
+		inlines = append(inlines, mdInline{kind: "text", text: text[last:]})
+	}
+	return inlines
+}
+
+// Pandoc JSON
+
+type pandocNode map[string]interface{}
+
+func pandocStr(text string) []interface{} {
+	nodes := []interface{}{}
+	for i, word := range strings.Fields(text) {
+		if i > 0 {
+			nodes = append(nodes, pandocNode{"t": "Space"})
+		}
+		nodes = append(nodes, pandocNode{"t": "Str", "c": word})
+	}
+	return nodes
+}
+
+func pandocInlines(text string) []interface{} {
+	var nodes []interface{}
+	for _, span := range parseMarkdownInlines(text) {
+		// Spaces at the edges of text spans become Space nodes between spans
+		if span.kind == "text" && strings.TrimSpace(span.text) == "" {
+			if len(nodes) > 0 {
+				nodes = append(nodes, pandocNode{"t": "Space"})
+			}
+			continue
+		}
+		if span.kind == "text" && strings.HasPrefix(span.text, " ") && len(nodes) > 0 {
+			nodes = append(nodes, pandocNode{"t": "Space"})
+		}
+		switch span.kind {
+		case "strong":
+			nodes = append(nodes, pandocNode{"t": "Strong", "c": pandocStr(span.text)})
+		case "emph":
+			nodes = append(nodes, pandocNode{"t": "Emph", "c": pandocStr(span.text)})
+		case "code":
+			nodes = append(nodes, pandocNode{"t": "Code", "c": []interface{}{pandocAttr(), span.text}})
+		case "link":
+			nodes = append(nodes, pandocNode{"t": "Link", "c": []interface{}{pandocAttr(), pandocStr(span.text), []string{span.url, ""}}})
+		default:
+			nodes = append(nodes, pandocStr(span.text)...)
+		}
+		if span.kind == "text" && strings.HasSuffix(span.text, " ") {
+			nodes = append(nodes, pandocNode{"t": "Space"})
+		}
+	}
+	if nodes == nil {
+		nodes = []interface{}{}
+	}
+	return nodes
+}
+
+func pandocAttr() []interface{} {
+	return []interface{}{"", []string{}, [][]string{}}
+}
+
+// pandocListItem renders task list markers the way pandoc reads them
+func pandocListItem(item string) []interface{} {
+	switch {
+	case strings.HasPrefix(item, "[ ] "):
+		item = "☐ " + item[4:]
+	case strings.HasPrefix(item, "[x] "), strings.HasPrefix(item, "[X] "):
+		item = "☒ " + item[4:]
+	}
+	return []interface{}{pandocNode{"t": "Plain", "c": pandocInlines(item)}}
+}
+
+func pandocMetaInlines(text string) pandocNode {
+	return pandocNode{"t": "MetaInlines", "c": pandocStr(text)}
+}
+
+// toPandocJSON converts generated markdown to a pandoc JSON document
+func toPandocJSON(meta DocumentMeta, md string) ([]byte, error) {
+	metadata := pandocNode{}
+	if meta.Title != "" {
+		metadata["title"] = pandocMetaInlines(meta.Title)
+	}
+	if meta.Author != "" {
+		metadata["author"] = pandocNode{"t": "MetaList", "c": []interface{}{pandocMetaInlines(meta.Author)}}
+	}
+	if meta.Date != "" {
+		metadata["date"] = pandocMetaInlines(meta.Date)
+	}
+
+	blocks := []interface{}{}
+	for _, block := range parseMarkdownBlocks(md) {
+		switch block.kind {
+		case "heading":
+			blocks = append(blocks, pandocNode{"t": "Header", "c": []interface{}{block.level, pandocAttr(), pandocInlines(block.lines[0])}})
+		case "para":
+			var inlines []interface{}
+			for i, line := range block.lines {
+				if i > 0 {
+					inlines = append(inlines, pandocNode{"t": "SoftBreak"})
+				}
+				inlines = append(inlines, pandocInlines(line)...)
+			}
+			blocks = append(blocks, pandocNode{"t": "Para", "c": inlines})
+		case "list":
+			var items []interface{}
+			for _, item := range block.lines {
+				items = append(items, pandocListItem(item))
+			}
+			blocks = append(blocks, pandocNode{"t": "BulletList", "c": items})
+		case "rule":
+			blocks = append(blocks, pandocNode{"t": "HorizontalRule"})
+		case "code":
+			blocks = append(blocks, pandocNode{"t": "CodeBlock", "c": []interface{}{pandocAttr(), strings.Join(block.lines, "\n")}})
+		}
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"pandoc-api-version": pandocAPIVersion,
+		"meta":               metadata,
+		"blocks":             blocks,
+	})
+}
+
+// DocBook
+
+func xmlEscape(text string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
+
+func docBookInlines(text string) string {
+	var out strings.Builder
+	for _, span := range parseMarkdownInlines(text) {
+		escaped := xmlEscape(span.text)
+		switch span.kind {
+		case "strong":
+			out.WriteString(`<emphasis role="strong">` + escaped + `</emphasis>`)
+		case "emph":
+			out.WriteString(`<emphasis>` + escaped + `</emphasis>`)
+		case "code":
+			out.WriteString(`<literal>` + escaped + `</literal>`)
+		case "link":
+			out.WriteString(fmt.Sprintf(`<link xlink:href="%s">%s</link>`, xmlEscape(span.url), escaped))
+		default:
+			out.WriteString(escaped)
+		}
+	}
+	return out.String()
+}
+
+// toDocBook converts generated markdown to a DocBook 5 article, nesting
+// sections by heading level
+func toDocBook(meta DocumentMeta, md string) string {
+	var out strings.Builder
+	out.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	out.WriteString(`<article xmlns="http://docbook.org/ns/docbook" xmlns:xlink="http://www.w3.org/1999/xlink" version="5.0">` + "\n")
+	out.WriteString("<info>\n")
+	if meta.Title != "" {
+		out.WriteString("<title>" + xmlEscape(meta.Title) + "</title>\n")
+	}
+	if meta.Author != "" {
+		out.WriteString("<author><personname>" + xmlEscape(meta.Author) + "</personname></author>\n")
+	}
+	if meta.Date != "" {
+		out.WriteString("<date>" + xmlEscape(meta.Date) + "</date>\n")
+	}
+	out.WriteString("</info>\n")
+
+	var open []int // levels of open sections
+	for _, block := range parseMarkdownBlocks(md) {
+		switch block.kind {
+		case "heading":
+			for len(open) > 0 && open[len(open)-1] >= block.level {
+				out.WriteString("</section>\n")
+				open = open[:len(open)-1]
+			}
+			out.WriteString("<section>\n<title>" + docBookInlines(block.lines[0]) + "</title>\n")
+			open = append(open, block.level)
+		case "para":
+			var lines []string
+			for _, line := range block.lines {
+				lines = append(lines, docBookInlines(line))
+			}
+			out.WriteString("<para>" + strings.Join(lines, "\n") + "</para>\n")
+		case "list":
+			out.WriteString("<itemizedlist>\n")
+			for _, item := range block.lines {
+				out.WriteString("<listitem><para>" + docBookInlines(item) + "</para></listitem>\n")
+			}
+			out.WriteString("</itemizedlist>\n")
+		case "code":
+			out.WriteString("<programlisting>" + xmlEscape(strings.Join(block.lines, "\n")) + "</programlisting>\n")
+		}
+	}
+	for range open {
+		out.WriteString("</section>\n")
+	}
+	out.WriteString("</article>\n")
+	return out.String()
+}
+
+// documentMeta builds the metadata block from the title and author parameters
+// (author defaults to github.username) and the date range of the document
+func (js *JournalService) documentMeta(request mcp.CallToolRequest, title, dateFrom, dateTo string) DocumentMeta {
+	meta := DocumentMeta{Title: request.GetString("title", title), Author: request.GetString("author", "")}
+	if meta.Author == "" {
+		if config, err := js.loadConfiguration(); err == nil {
+			meta.Author = config.GitHub.Username
+		}
+	}
+	switch {
+	case dateFrom != "" && dateTo != "":
+		meta.Date = dateFrom + " to " + dateTo
+	case dateFrom != "":
+		meta.Date = "since " + dateFrom
+	case dateTo != "":
+		meta.Date = "until " + dateTo
+	default:
+		meta.Date = time.Now().Format("2006-01-02")
+	}
+	return meta
+}
+
+// renderStructured converts markdown to the pandoc or docbook format
+func renderStructured(format string, meta DocumentMeta, md string) (string, error) {
+	switch format {
+	case "pandoc":
+		data, err := toPandocJSON(meta, md)
+		return string(data), err
+	case "docbook":
+		return toDocBook(meta, md), nil
+	}
+	return "", fmt.Errorf("unsupported structured format: %s", format)
+}
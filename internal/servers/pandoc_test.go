@@ -0,0 +1,91 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestParseMarkdownInlines(t *testing.T) {
+	inlines := parseMarkdownInlines("**Issue:** [#42](https://example.com/42) in `main.go`, _soon_ not snake_case_name")
+	var kinds []string
+	for _, inline := range inlines {
+		kinds = append(kinds, inline.kind)
+	}
+	want := []string{"strong", "text", "link", "text", "code", "text", "emph", "text"}
+	if !equalStringSlices(kinds, want) {
+		t.Errorf("Unexpected inline kinds %v, want %v", kinds, want)
+	}
+	if inlines[2].url != "https://example.com/42" || inlines[7].text != " not snake_case_name" {
+		t.Errorf("Unexpected inlines: %+v", inlines)
+	}
+}
+
+func TestToPandocJSON(t *testing.T) {
+	md := "# Report\n\nLine one\n**Bold** line\n\n- [ ] Open item\n- Done\n\n---\n"
+	data, err := toPandocJSON(DocumentMeta{Title: "Q1 Report", Author: "Dana", Date: "2026-01-01 to 2026-03-31"}, md)
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+
+	var doc struct {
+		Version []int                      `json:"pandoc-api-version"`
+		Meta    map[string]json.RawMessage `json:"meta"`
+		Blocks  []struct {
+			T string          `json:"t"`
+			C json.RawMessage `json:"c"`
+		} `json:"blocks"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(doc.Version) != 3 || doc.Meta["title"] == nil || doc.Meta["author"] == nil || doc.Meta["date"] == nil {
+		t.Errorf("Expected version and title, author and date metadata, got %s", data)
+	}
+	var types []string
+	for _, block := range doc.Blocks {
+		types = append(types, block.T)
+	}
+	if !equalStringSlices(types, []string{"Header", "Para", "BulletList", "HorizontalRule"}) {
+		t.Errorf("Unexpected blocks %v", types)
+	}
+	if !strings.Contains(string(doc.Blocks[1].C), `{"t":"SoftBreak"}`) || !strings.Contains(string(doc.Blocks[2].C), `"c":"☐"`) {
+		t.Errorf("Unexpected block content: %s", data)
+	}
+}
+
+func TestExportStructuredFormats(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "TASK-1", "Ship <reports> & docs", "work")
+
+	request := CreateMockRequest(map[string]interface{}{"format": "docbook", "author": "Dana", "date_from": "2000-01-01"})
+	result, _ := js.ExportData(ctx, request)
+	text := result.Content[0].(mcp.TextContent).Text
+	decoder := xml.NewDecoder(strings.NewReader(text))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Expected well-formed DocBook: %v\n%s", err, text)
+		}
+	}
+	for _, want := range []string{"<title>Journal Export</title>", "<personname>Dana</personname>", "<date>since 2000-01-01</date>", "Ship &lt;reports&gt; &amp; docs"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected DocBook to contain %q, got:\n%s", want, text)
+		}
+	}
+
+	result, _ = js.ExportData(ctx, CreateMockRequest(map[string]interface{}{"format": "pandoc", "title": "My Journal"}))
+	text = result.Content[0].(mcp.TextContent).Text
+	if !json.Valid([]byte(text)) || !strings.Contains(text, `"title":{"c":[{"c":"My","t":"Str"}`) {
+		t.Errorf("Expected pandoc JSON with a title, got %s", text)
+	}
+}
@@ -0,0 +1,936 @@
+package servers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// PersonDataMatch is one piece of journal content that mentions a person
+type PersonDataMatch struct {
+	Source    string    `json:"source"`             // task_title, task_assignee, task_handoff, task_entry, deletion_reason, one_on_one, meeting, feedback, brag, weekly_review, team_member or obsidian_note
+	Location  string    `json:"location,omitempty"` // archived, trash, event_log, event_snapshot, config or obsidian; empty for current data
+	TaskID    string    `json:"task_id,omitempty"`
+	EntryID   string    `json:"entry_id,omitempty"`
+	Date      string    `json:"date,omitempty"`
+	Field     string    `json:"field,omitempty"` // person, insights, todos, feedback, notes, history, changes.notes, ...
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	Content   string    `json:"content"`
+}
+
+// PersonDataExport collects everything the journal holds about a person
+type PersonDataExport struct {
+	Person    string            `json:"person"`
+	Aliases   []string          `json:"aliases,omitempty"`
+	Generated time.Time         `json:"generated"`
+	Matches   []PersonDataMatch `json:"matches"`
+	Summary   string            `json:"summary"`
+}
+
+// AuditEntry records a privacy-relevant operation in audit.jsonl. The subject
+// is stored as a hash so the audit trail does not itself retain the name.
+type AuditEntry struct {
+	Timestamp   time.Time      `json:"timestamp"`
+	Action      string         `json:"action"`
+	SubjectHash string         `json:"subject_sha256"`
+	Counts      map[string]int `json:"counts,omitempty"`
+}
+
+// ExportPersonData exports all journal content mentioning a person
+func (js *JournalService) ExportPersonData(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	person, err := request.RequireString("person")
+	if err != nil {
+		return mcp.NewToolResultError("person is required"), nil
+	}
+	aliases := request.GetStringSlice("aliases", nil)
+
+	matches, err := js.findPersonData(personPattern(person, aliases))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to search journal: %v", err)), nil
+	}
+
+	export := PersonDataExport{
+		Person:    person,
+		Aliases:   aliases,
+		Generated: time.Now(),
+		Matches:   matches,
+		Summary:   fmt.Sprintf("Found %d items mentioning %s", len(matches), person),
+	}
+
+	js.appendAudit(AuditEntry{
+		Action:      "export_person_data",
+		SubjectHash: subjectHash(person),
+		Counts:      countMatchesBySource(matches),
+	})
+
+	resultJSON, _ := json.MarshalIndent(export, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// PurgePersonData deletes all journal content mentioning a person. Without a
+// confirmation code it only previews the matches and returns the code to use.
+func (js *JournalService) PurgePersonData(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	person, err := request.RequireString("person")
+	if err != nil {
+		return mcp.NewToolResultError("person is required"), nil
+	}
+	aliases := request.GetStringSlice("aliases", nil)
+	confirm := request.GetString("confirm", "")
+
+	pattern := personPattern(person, aliases)
+	matches, err := js.findPersonData(pattern)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to search journal: %v", err)), nil
+	}
+
+	code := confirmationCode(matches)
+	if confirm == "" {
+		result := map[string]interface{}{
+			"status":            "preview",
+			"matches":           matches,
+			"counts":            countMatchesBySource(matches),
+			"confirmation_code": code,
+			"message": fmt.Sprintf("%d items would be deleted. %s Call purge_person_data again with confirm=%s to proceed.",
+				len(matches), personPurgeScope, code),
+		}
+		resultJSON, _ := json.MarshalIndent(result, "", "  ")
+		return mcp.NewToolResultText(string(resultJSON)), nil
+	}
+
+	if confirm != code {
+		return mcp.NewToolResultError("Confirmation code does not match the current data. Run purge_person_data without confirm to preview again."), nil
+	}
+
+	counts, err := js.purgePersonData(pattern)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Purge failed part way: %v", err)), nil
+	}
+
+	js.appendAudit(AuditEntry{
+		Action:      "purge_person_data",
+		SubjectHash: subjectHash(person),
+		Counts:      counts,
+	})
+
+	result := map[string]interface{}{
+		"status":  "purged",
+		"counts":  counts,
+		"message": personPurgeScope,
+	}
+	// Notes without the export marker, such as ones written by hand, are never rewritten
+	if config, err := js.loadConfiguration(); err == nil {
+		if remaining := findObsidianPersonNotes(config, pattern); len(remaining) > 0 {
+			result["not_purged"] = remaining
+		}
+	}
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// personPurgeScope tells the user what a purge reaches beyond the journal itself
+const personPurgeScope = "The person is also removed from team.members in config.yaml, and notes exported to the " +
+	"obsidian.vault folder are re-exported without them; notes you wrote there yourself are listed as not_purged. " +
+	"Exports to other vaults or files, and backups, are not purged."
+
+// personPattern matches the name or any alias as whole words, case-insensitively.
+// Handles such as @alex only get a word boundary on their word-character side.
+func personPattern(person string, aliases []string) *regexp.Regexp {
+	wordChar := regexp.MustCompile(`\w`)
+
+	var names []string
+	for _, name := range append([]string{person}, aliases...) {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		quoted := regexp.QuoteMeta(name)
+		if wordChar.MatchString(name[:1]) {
+			quoted = `\b` + quoted
+		}
+		if wordChar.MatchString(name[len(name)-1:]) {
+			quoted += `\b`
+		}
+		names = append(names, quoted)
+	}
+	return regexp.MustCompile(`(?i)(?:` + strings.Join(names, "|") + `)`)
+}
+
+func (js *JournalService) findPersonData(pattern *regexp.Regexp) ([]PersonDataMatch, error) {
+	var matches []PersonDataMatch
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	for _, task := range tasks {
+		matches = append(matches, personTaskMatches(task, "", pattern)...)
+	}
+
+	archived, err := js.loadArchivedTasks()
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range archived {
+		matches = append(matches, personTaskMatches(task, "archived", pattern)...)
+	}
+
+	trash, err := js.loadTrash()
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range trash {
+		matches = append(matches, personTaskMatches(item.Task, "trash", pattern)...)
+		if pattern.MatchString(item.Reason) {
+			matches = append(matches, PersonDataMatch{Source: "deletion_reason", Location: "trash", TaskID: item.Task.ID, Timestamp: item.DeletedAt, Content: item.Reason})
+		}
+	}
+
+	meetings, err := js.loadMeetings()
+	if err != nil {
+		return nil, err
+	}
+	for _, meeting := range meetings {
+		matches = append(matches, personMeetingMatches(meeting, "", pattern)...)
+	}
+
+	trashedMeetings, err := js.loadTrashedMeetings()
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range trashedMeetings {
+		matches = append(matches, personMeetingMatches(item.Meeting, "trash", pattern)...)
+		if pattern.MatchString(item.Reason) {
+			matches = append(matches, PersonDataMatch{Source: "deletion_reason", Location: "trash", Date: item.Meeting.Date, Timestamp: item.DeletedAt, Content: item.Reason})
+		}
+	}
+
+	feedback, err := js.loadFeedback()
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range feedback {
+		if pattern.MatchString(item.Person) {
+			matches = append(matches, PersonDataMatch{Source: "feedback", Date: item.Date, Field: "person", Content: item.Content})
+		} else if pattern.MatchString(item.Content) {
+			matches = append(matches, PersonDataMatch{Source: "feedback", Date: item.Date, Field: "content", Content: item.Content})
+		}
+	}
+
+	brag, err := js.loadBragItems()
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range brag {
+		if pattern.MatchString(item.Text) {
+			matches = append(matches, PersonDataMatch{Source: "brag", TaskID: item.TaskID, Date: item.Date, Content: item.Text})
+		}
+	}
+
+	reviews, err := js.loadWeeklyReviews()
+	if err != nil {
+		return nil, err
+	}
+	for _, review := range reviews {
+		add := func(field, content string) {
+			matches = append(matches, PersonDataMatch{Source: "weekly_review", Date: review.WeekStart, Field: field, Content: content})
+		}
+		lists := weeklyReviewLists(review)
+		for _, field := range sortedKeys(lists) {
+			for _, item := range *lists[field] {
+				if pattern.MatchString(item) {
+					add(field, item)
+				}
+			}
+		}
+		for _, checkIn := range review.CheckIns {
+			if pattern.MatchString(checkIn.Goal) {
+				add("check_ins", checkIn.Goal)
+			}
+		}
+		for _, line := range strings.Split(review.Notes, "\n") {
+			if pattern.MatchString(line) {
+				add("notes", line)
+			}
+		}
+	}
+
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return nil, err
+	}
+	for _, member := range config.Team.Members {
+		if personTeamMember(member, pattern) {
+			matches = append(matches, PersonDataMatch{Source: "team_member", Location: "config", Field: "team.members", Content: member.Name})
+		}
+	}
+	matches = append(matches, findObsidianPersonNotes(config, pattern)...)
+
+	if es, err := js.eventStore(); err == nil {
+		found, err := findEventPersonData(es, pattern)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, found...)
+	}
+
+	return matches, nil
+}
+
+// personTeamMember reports whether a team.members entry is the person
+func personTeamMember(member TeamMember, pattern *regexp.Regexp) bool {
+	return pattern.MatchString(member.Name) || slices.ContainsFunc(member.Aliases, pattern.MatchString)
+}
+
+// findObsidianPersonNotes lists the notes in the configured Obsidian export
+// folder that mention a person, with the first matching line of each. The
+// Field is the note's path inside the vault.
+func findObsidianPersonNotes(config *Configuration, pattern *regexp.Regexp) []PersonDataMatch {
+	if config.Obsidian.Vault == "" {
+		return nil
+	}
+	folder := config.Obsidian.Folder
+	if folder == "" {
+		folder = defaultObsidianFolder
+	}
+
+	var matches []PersonDataMatch
+	filepath.WalkDir(filepath.Join(config.Obsidian.Vault, filepath.FromSlash(folder)), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(path) != ".md" {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if pattern.MatchString(line) {
+				name, _ := filepath.Rel(config.Obsidian.Vault, path)
+				matches = append(matches, PersonDataMatch{Source: "obsidian_note", Location: "obsidian", Field: filepath.ToSlash(name), Content: line})
+				break
+			}
+		}
+		return nil
+	})
+	return matches
+}
+
+// personTaskMatches lists the parts of a task that mention a person. location
+// is where this copy of the task was found: empty for task storage, or
+// archived, trash, event_log or event_snapshot.
+func personTaskMatches(task *Task, location string, pattern *regexp.Regexp) []PersonDataMatch {
+	var matches []PersonDataMatch
+	if pattern.MatchString(task.Title) {
+		matches = append(matches, PersonDataMatch{Source: "task_title", Location: location, TaskID: task.ID, Timestamp: task.Created, Content: task.Title})
+	}
+	if pattern.MatchString(task.Assignee) {
+		matches = append(matches, PersonDataMatch{Source: "task_assignee", Location: location, TaskID: task.ID, Content: task.Assignee})
+	}
+	for _, handoff := range task.Handoffs {
+		if pattern.MatchString(handoff.From) || pattern.MatchString(handoff.To) {
+			matches = append(matches, PersonDataMatch{
+				Source: "task_handoff", Location: location, TaskID: task.ID, EntryID: handoff.EntryID, Timestamp: handoff.Time, Content: handoff.From + " -> " + handoff.To,
+			})
+		}
+	}
+	for _, entry := range task.Entries {
+		matches = append(matches, personEntryMatches(task.ID, entry, location, pattern)...)
+	}
+	return matches
+}
+
+// personEntryMatches lists an entry's content and earlier versions that mention a person
+func personEntryMatches(taskID string, entry Entry, location string, pattern *regexp.Regexp) []PersonDataMatch {
+	var matches []PersonDataMatch
+	if pattern.MatchString(entry.Content) {
+		matches = append(matches, PersonDataMatch{
+			Source: "task_entry", Location: location, TaskID: taskID, EntryID: entry.ID, Timestamp: entry.Timestamp, Content: entry.Content,
+		})
+	}
+	for _, edit := range entry.History {
+		if pattern.MatchString(edit.Content) {
+			matches = append(matches, PersonDataMatch{
+				Source: "task_entry", Location: location, TaskID: taskID, EntryID: entry.ID, Field: "history", Timestamp: edit.EditedAt, Content: edit.Content,
+			})
+		}
+	}
+	return matches
+}
+
+// personMeetingMatches lists the parts of a meeting that mention a person,
+// including the copies kept in its change records
+func personMeetingMatches(meeting *Meeting, location string, pattern *regexp.Regexp) []PersonDataMatch {
+	source := "one_on_one"
+	if meetingType(meeting) != "one_on_one" {
+		source = "meeting"
+	}
+
+	var matches []PersonDataMatch
+	add := func(field, content string) {
+		matches = append(matches, PersonDataMatch{Source: source, Location: location, Date: meeting.Date, Field: field, Content: content})
+	}
+	addLists := func(lists map[string]*[]string, notes, prefix string) {
+		for _, field := range sortedKeys(lists) {
+			for _, item := range *lists[field] {
+				if pattern.MatchString(item) {
+					add(prefix+field, item)
+				}
+			}
+		}
+		for _, line := range strings.Split(notes, "\n") {
+			if pattern.MatchString(line) {
+				add(prefix+"notes", line)
+			}
+		}
+	}
+
+	if pattern.MatchString(meeting.Person) {
+		add("person", meeting.Person)
+	}
+	addLists(oneOnOneLists(meeting), meeting.Notes, "")
+	for _, link := range meeting.TodoLinks {
+		if pattern.MatchString(link.Todo) {
+			add("todo_links", link.Todo)
+		}
+	}
+	for i := range meeting.Changes {
+		addLists(meetingChangeLists(&meeting.Changes[i]), meeting.Changes[i].Notes, "changes.")
+	}
+	return matches
+}
+
+// findEventPersonData searches the event log and its snapshots, which keep
+// every earlier version of the tasks
+func findEventPersonData(es *eventStorage, pattern *regexp.Regexp) ([]PersonDataMatch, error) {
+	var matches []PersonDataMatch
+
+	events, err := es.readEvents(func(TaskEvent) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if event.Task != nil {
+			matches = append(matches, personTaskMatches(event.Task, "event_log", pattern)...)
+		}
+		if event.Entry != nil {
+			matches = append(matches, personEntryMatches(event.TaskID, *event.Entry, "event_log", pattern)...)
+		}
+	}
+
+	files, err := os.ReadDir(es.snapshotDir())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, file := range files {
+		if _, _, ok := parseSnapshotName(file.Name()); !ok {
+			continue
+		}
+		data, err := es.js.readDataFile(filepath.Join(es.snapshotDir(), file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var snapshot eventSnapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			return nil, fmt.Errorf("corrupt snapshot %s: %v", file.Name(), err)
+		}
+		for _, taskID := range sortedKeys(snapshot.Tasks) {
+			if task := snapshot.Tasks[taskID]; task != nil {
+				matches = append(matches, personTaskMatches(task, "event_snapshot", pattern)...)
+			}
+		}
+	}
+
+	return matches, nil
+}
+
+func (js *JournalService) purgePersonData(pattern *regexp.Regexp) (map[string]int, error) {
+	counts := make(map[string]int)
+
+	taskIDs, err := js.storage().ListTaskIDs()
+	if err != nil {
+		return counts, err
+	}
+	for _, taskID := range taskIDs {
+		if err := js.purgeStoredTask(taskID, pattern, counts); err != nil {
+			return counts, err
+		}
+	}
+
+	archived, err := js.loadArchivedTasks()
+	if err != nil {
+		return counts, err
+	}
+	for _, task := range archived {
+		if !purgePersonTask(task, pattern, counts, "archived_") {
+			continue
+		}
+		data, err := json.MarshalIndent(task, "", "  ")
+		if err != nil {
+			return counts, err
+		}
+		if err := js.writeDataFile(js.archivedTaskPath(task.ID), data, 0644); err != nil {
+			return counts, err
+		}
+	}
+
+	trash, err := js.loadTrash()
+	if err != nil {
+		return counts, err
+	}
+	for _, item := range trash {
+		changed := purgePersonTask(item.Task, pattern, counts, "trashed_")
+		if pattern.MatchString(item.Reason) {
+			item.Reason = pattern.ReplaceAllString(item.Reason, "[redacted]")
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		data, err := json.MarshalIndent(item.TrashedTask, "", "  ")
+		if err != nil {
+			return counts, err
+		}
+		if err := js.writeDataFile(item.path, data, 0644); err != nil {
+			return counts, err
+		}
+	}
+
+	meetings, err := js.loadMeetings()
+	if err != nil {
+		return counts, err
+	}
+	for _, meeting := range meetings {
+		// A meeting held with the person is theirs as a whole
+		if pattern.MatchString(meeting.Person) {
+			if err := removeDataFile(js.meetingPath(meeting)); err != nil {
+				return counts, err
+			}
+			counts["one_on_ones_deleted"]++
+			continue
+		}
+
+		itemsDeleted := "one_on_one_items_deleted"
+		if meetingType(meeting) != "one_on_one" {
+			itemsDeleted = "meeting_items_deleted"
+		}
+		if deleted := purgePersonMeeting(meeting, pattern); deleted > 0 {
+			counts[itemsDeleted] += deleted
+			if err := js.saveMeeting(meeting); err != nil {
+				return counts, err
+			}
+		}
+	}
+
+	trashedMeetings, err := js.loadTrashedMeetings()
+	if err != nil {
+		return counts, err
+	}
+	for _, item := range trashedMeetings {
+		if pattern.MatchString(item.Meeting.Person) {
+			if err := removeDataFile(item.path); err != nil {
+				return counts, err
+			}
+			counts["trashed_meetings_deleted"]++
+			continue
+		}
+
+		deleted := purgePersonMeeting(item.Meeting, pattern)
+		counts["trashed_meeting_items_deleted"] += deleted
+		if pattern.MatchString(item.Reason) {
+			item.Reason = pattern.ReplaceAllString(item.Reason, "[redacted]")
+			deleted++
+		}
+		if deleted == 0 {
+			continue
+		}
+		data, err := json.MarshalIndent(item.TrashedMeeting, "", "  ")
+		if err != nil {
+			return counts, err
+		}
+		if err := js.writeDataFile(item.path, data, 0644); err != nil {
+			return counts, err
+		}
+	}
+
+	// Purged after the meetings, so syncing meeting feedback cannot add it back
+	if err := js.purgePersonFeedback(pattern, counts); err != nil {
+		return counts, err
+	}
+	if err := js.purgePersonBrag(pattern, counts); err != nil {
+		return counts, err
+	}
+
+	reviews, err := js.loadWeeklyReviews()
+	if err != nil {
+		return counts, err
+	}
+	for _, review := range reviews {
+		deleted := 0
+		keep := func(text string) bool {
+			if pattern.MatchString(text) {
+				deleted++
+				return false
+			}
+			return true
+		}
+		for _, items := range weeklyReviewLists(review) {
+			*items = slices.DeleteFunc(*items, func(item string) bool { return !keep(item) })
+		}
+		review.CheckIns = slices.DeleteFunc(review.CheckIns, func(checkIn GoalCheckIn) bool { return !keep(checkIn.Goal) })
+		review.Notes = strings.Join(slices.DeleteFunc(strings.Split(review.Notes, "\n"), func(line string) bool { return !keep(line) }), "\n")
+
+		if deleted > 0 {
+			counts["weekly_review_items_deleted"] += deleted
+			if err := js.saveWeeklyReview(review); err != nil {
+				return counts, err
+			}
+		}
+	}
+
+	// Daily logs keep their own copies of entries
+	dailyFiles, _ := filepath.Glob(filepath.Join(js.dataDir(), "daily", "*.json"))
+	for _, path := range dailyFiles {
+		data, err := js.readDataFile(path)
+		if err != nil {
+			continue
+		}
+		var activity DailyActivity
+		if err := json.Unmarshal(data, &activity); err != nil {
+			continue
+		}
+
+		changed := false
+		for taskID, entries := range activity.Tasks {
+			if kept, entriesChanged := purgePersonEntries(entries, pattern, counts, "daily_log_"); entriesChanged {
+				activity.Tasks[taskID] = kept
+				changed = true
+			}
+		}
+
+		if changed {
+			counts["daily_logs_updated"]++
+			if err := js.saveDailyActivity(&activity); err != nil {
+				return counts, err
+			}
+		}
+	}
+
+	// The team registry names the person outright
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return counts, err
+	}
+	members := slices.DeleteFunc(slices.Clone(config.Team.Members), func(member TeamMember) bool { return personTeamMember(member, pattern) })
+	if removed := len(config.Team.Members) - len(members); removed > 0 {
+		config.Team.Members = members
+		if err := js.saveConfiguration(config); err != nil {
+			return counts, err
+		}
+		counts["team_members_removed"] = removed
+	}
+
+	// Re-exporting from the purged journal rewrites or removes every exported
+	// note that mentioned the person
+	if len(findObsidianPersonNotes(config, pattern)) > 0 {
+		export, err := js.exportObsidian(config.Obsidian.Vault, config.Obsidian.Folder)
+		if err != nil {
+			return counts, fmt.Errorf("failed to re-export the Obsidian vault: %w", err)
+		}
+		counts["obsidian_notes_rewritten"] = export.Written
+		counts["obsidian_notes_removed"] = export.Removed
+	}
+
+	// An append-only log cannot be edited, so once the event log or its
+	// snapshots mention the person its history is compacted away
+	if es, err := js.eventStore(); err == nil {
+		found, err := findEventPersonData(es, pattern)
+		if err != nil {
+			return counts, err
+		}
+		if len(found) > 0 {
+			dropped, err := es.compact()
+			if err != nil {
+				return counts, err
+			}
+			counts["events_dropped"] = int(dropped)
+		}
+	}
+
+	return counts, nil
+}
+
+// purgeStoredTask purges a person from one task in task storage
+func (js *JournalService) purgeStoredTask(taskID string, pattern *regexp.Regexp, counts map[string]int) error {
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return err
+	}
+	if !purgePersonTask(task, pattern, counts, "") {
+		return nil
+	}
+	task.Updated = time.Now()
+	return js.saveTask(task)
+}
+
+// purgePersonTask removes a person from a task: entries mentioning them are
+// deleted, the assignee is cleared, and the title, handoffs, deletion records
+// and edit history are redacted. Counts are kept under prefix so archived and
+// trashed tasks are told apart. It reports whether the task changed.
+func purgePersonTask(task *Task, pattern *regexp.Regexp, counts map[string]int, prefix string) bool {
+	changed := false
+	if pattern.MatchString(task.Title) {
+		task.Title = pattern.ReplaceAllString(task.Title, "[redacted]")
+		counts[prefix+"task_titles_redacted"]++
+		changed = true
+	}
+	if pattern.MatchString(task.Assignee) {
+		task.Assignee = ""
+		counts[prefix+"task_assignees_cleared"]++
+		changed = true
+	}
+	for i := range task.Handoffs {
+		handoff := &task.Handoffs[i]
+		if pattern.MatchString(handoff.From) || pattern.MatchString(handoff.To) {
+			handoff.From = pattern.ReplaceAllString(handoff.From, "[redacted]")
+			handoff.To = pattern.ReplaceAllString(handoff.To, "[redacted]")
+			counts[prefix+"task_handoffs_redacted"]++
+			changed = true
+		}
+	}
+	if entries, entriesChanged := purgePersonEntries(task.Entries, pattern, counts, prefix+"task_"); entriesChanged {
+		task.Entries = entries
+		changed = true
+	}
+	return changed
+}
+
+// purgePersonEntries deletes the entries mentioning a person and redacts the
+// person from the edit history of the rest. Deletion records are redacted
+// rather than deleted, so merges still drop the entries they replaced.
+func purgePersonEntries(entries []Entry, pattern *regexp.Regexp, counts map[string]int, prefix string) ([]Entry, bool) {
+	changed := false
+	var kept []Entry
+	for _, entry := range entries {
+		if entry.Type != "deleted" && pattern.MatchString(entry.Content) {
+			counts[prefix+"entries_deleted"]++
+			changed = true
+			continue
+		}
+
+		redacted := false
+		if pattern.MatchString(entry.Content) {
+			entry.Content = pattern.ReplaceAllString(entry.Content, "[redacted]")
+			redacted = true
+		}
+		for i, edit := range entry.History {
+			if pattern.MatchString(edit.Content) {
+				entry.History[i].Content = pattern.ReplaceAllString(edit.Content, "[redacted]")
+				redacted = true
+			}
+		}
+		if redacted {
+			counts[prefix+"entries_redacted"]++
+			changed = true
+		}
+		kept = append(kept, entry)
+	}
+	return kept, changed
+}
+
+// purgePersonMeeting deletes the items and note lines of a meeting that
+// mention a person, along with their copies in its change records and todo
+// links, and returns how many were deleted
+func purgePersonMeeting(meeting *Meeting, pattern *regexp.Regexp) int {
+	deleted := 0
+	keep := func(text string) bool {
+		if pattern.MatchString(text) {
+			deleted++
+			return false
+		}
+		return true
+	}
+	purge := func(lists map[string]*[]string, notes *string) {
+		for _, items := range lists {
+			*items = slices.DeleteFunc(*items, func(item string) bool { return !keep(item) })
+		}
+		*notes = strings.Join(slices.DeleteFunc(strings.Split(*notes, "\n"), func(line string) bool { return !keep(line) }), "\n")
+	}
+
+	purge(oneOnOneLists(meeting), &meeting.Notes)
+	meeting.TodoLinks = slices.DeleteFunc(meeting.TodoLinks, func(link TodoLink) bool { return !keep(link.Todo) })
+	for i := range meeting.Changes {
+		purge(meetingChangeLists(&meeting.Changes[i]), &meeting.Changes[i].Notes)
+	}
+	return deleted
+}
+
+// purgePersonFeedback deletes the feedback bank items from or about a person
+func (js *JournalService) purgePersonFeedback(pattern *regexp.Regexp, counts map[string]int) error {
+	defer lockFile(js.feedbackPath())()
+
+	items, err := js.loadFeedback()
+	if err != nil {
+		return err
+	}
+	kept := slices.DeleteFunc(items, func(item FeedbackItem) bool {
+		return pattern.MatchString(item.Person) || pattern.MatchString(item.Content)
+	})
+	if deleted := len(items) - len(kept); deleted > 0 {
+		counts["feedback_items_deleted"] = deleted
+		return js.saveFeedback(kept)
+	}
+	return nil
+}
+
+// purgePersonBrag turns brag document items mentioning a person into redacted
+// tombstones, so praise from them is not added back from the feedback bank
+func (js *JournalService) purgePersonBrag(pattern *regexp.Regexp, counts map[string]int) error {
+	defer lockFile(js.bragPath())()
+
+	items, err := js.loadBragItems()
+	if err != nil {
+		return err
+	}
+	redacted := 0
+	for i := range items {
+		if pattern.MatchString(items[i].Text) {
+			items[i].Text = pattern.ReplaceAllString(items[i].Text, "[redacted]")
+			items[i].Category = "deleted"
+			redacted++
+		}
+	}
+	if redacted == 0 {
+		return nil
+	}
+	counts["brag_items_redacted"] = redacted
+	return js.saveBragItems(items)
+}
+
+func (js *JournalService) loadOneOnOnes() ([]*OneOnOne, error) {
+	files, err := filepath.Glob(filepath.Join(js.dataDir(), "one-on-ones", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var meetings []*OneOnOne
+	for _, path := range files {
+		data, err := js.readDataFile(path)
+		if err != nil {
+			continue
+		}
+		var meeting OneOnOne
+		if err := json.Unmarshal(data, &meeting); err == nil {
+			meetings = append(meetings, &meeting)
+		}
+	}
+	// Oldest first; file names start with the person, so sort by date
+	sort.SliceStable(meetings, func(i, j int) bool {
+		if meetings[i].Date != meetings[j].Date {
+			return meetings[i].Date < meetings[j].Date
+		}
+		return meetings[i].Person < meetings[j].Person
+	})
+	return meetings, nil
+}
+
+// oneOnOneName names a meeting's file: {person}-{date}, or {date} for
+// meetings recorded without a person
+func oneOnOneName(meeting *OneOnOne) string {
+	if person := slugify(meeting.Person, 64); person != "" {
+		return person + "-" + meeting.Date
+	}
+	return meeting.Date
+}
+
+func (js *JournalService) oneOnOnePath(meeting *OneOnOne) string {
+	return filepath.Join(js.dataDir(), "one-on-ones", oneOnOneName(meeting)+".json")
+}
+
+func (js *JournalService) saveOneOnOne(meeting *OneOnOne) error {
+	data, err := json.MarshalIndent(meeting, "", "  ")
+	if err != nil {
+		return err
+	}
+	return js.writeDataFile(js.oneOnOnePath(meeting), data, 0644)
+}
+
+// meetingWith reports whether a meeting was with person, matching names
+// case-insensitively; an empty person matches every meeting
+func meetingWith(meeting *OneOnOne, person string) bool {
+	return person == "" || slugify(meeting.Person, 64) == slugify(person, 64)
+}
+
+func oneOnOneLists(meeting *OneOnOne) map[string]*[]string {
+	return map[string]*[]string{
+		"attendees": &meeting.Attendees,
+		"agenda":    &meeting.Agenda,
+		"decisions": &meeting.Decisions,
+		"insights":  &meeting.Insights,
+		"todos":     &meeting.Todos,
+		"feedback":  &meeting.Feedback,
+	}
+}
+
+// meetingChangeLists is oneOnOneLists for a change record
+func meetingChangeLists(change *MeetingChange) map[string]*[]string {
+	return map[string]*[]string{
+		"attendees": &change.Attendees,
+		"agenda":    &change.Agenda,
+		"decisions": &change.Decisions,
+		"insights":  &change.Insights,
+		"todos":     &change.Todos,
+		"feedback":  &change.Feedback,
+	}
+}
+
+func (js *JournalService) appendAudit(entry AuditEntry) {
+	entry.Timestamp = time.Now()
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	file, err := os.OpenFile(filepath.Join(js.dataDir(), "audit.jsonl"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	file.Write(append(line, '\n'))
+}
+
+func subjectHash(person string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(person))))
+	return hex.EncodeToString(sum[:])
+}
+
+func countMatchesBySource(matches []PersonDataMatch) map[string]int {
+	counts := make(map[string]int)
+	for _, match := range matches {
+		counts[match.Source]++
+	}
+	return counts
+}
+
+// confirmationCode fingerprints the matched content so a purge only runs
+// against the data that was previewed
+func confirmationCode(matches []PersonDataMatch) string {
+	hash := sha256.New()
+	for _, match := range matches {
+		fmt.Fprintf(hash, "%s|%s|%s|%s|%s|%s|%s\n", match.Source, match.Location, match.TaskID, match.EntryID, match.Date, match.Field, match.Content)
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:8]
+}
@@ -0,0 +1,223 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestPersonDataExportAndPurge(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	createTestTask(t, js, "PD-1", "Pairing with Alex", "work")
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "PD-1", "content": "Alex suggested caching"}))
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "PD-1", "content": "Reviewed by @ajones"}))
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "PD-1", "content": "Alexander reviewed the API"}))
+	js.CreateOneOnOne(ctx, CreateMockRequest(map[string]interface{}{
+		"date":     "2025-01-10",
+		"feedback": []interface{}{"Alex is doing great on on-call", "Team morale is good"},
+		"notes":    "Discussed roadmap\nalex wants to lead the migration",
+	}))
+
+	request := map[string]interface{}{"person": "Alex", "aliases": []interface{}{"@ajones"}}
+
+	result, err := js.ExportPersonData(ctx, CreateMockRequest(request))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var export PersonDataExport
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &export); err != nil {
+		t.Fatalf("Failed to parse export: %v", err)
+	}
+	// title, creation entry, two entries, one feedback item, one notes line, and the feedback
+	// bank and brag document copies of the feedback; "Alexander" is a different word
+	if len(export.Matches) != 8 {
+		t.Errorf("Expected 8 matches, got %d: %+v", len(export.Matches), export.Matches)
+	}
+
+	// Without confirm only a preview is returned
+	result, _ = js.PurgePersonData(ctx, CreateMockRequest(request))
+	var preview map[string]interface{}
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &preview)
+	if preview["status"] != "preview" {
+		t.Fatalf("Expected preview, got %v", preview["status"])
+	}
+	code, _ := preview["confirmation_code"].(string)
+
+	wrongCode := map[string]interface{}{"person": "Alex", "aliases": []interface{}{"@ajones"}, "confirm": "nope"}
+	if result, _ := js.PurgePersonData(ctx, CreateMockRequest(wrongCode)); !result.IsError {
+		t.Error("Expected mismatched confirmation code to be rejected")
+	}
+
+	request["confirm"] = code
+	result, _ = js.PurgePersonData(ctx, CreateMockRequest(request))
+	if result.IsError {
+		t.Fatalf("Unexpected purge error: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+
+	task, _ := js.loadTask("PD-1")
+	if task.Title != "Pairing with [redacted]" {
+		t.Errorf("Expected redacted title, got %s", task.Title)
+	}
+	for _, entry := range task.Entries {
+		if strings.Contains(entry.Content, "Alex suggested") || strings.Contains(entry.Content, "@ajones") {
+			t.Errorf("Expected entry to be purged: %s", entry.Content)
+		}
+	}
+
+	meetings, _ := js.loadOneOnOnes()
+	if len(meetings[0].Feedback) != 1 || strings.Contains(meetings[0].Notes, "alex") {
+		t.Errorf("Expected 1-on-1 mentions to be purged, got %+v", meetings[0])
+	}
+
+	audit, err := os.ReadFile(filepath.Join(js.DataDir, "audit.jsonl"))
+	if err != nil {
+		t.Fatalf("Expected audit log: %v", err)
+	}
+	if strings.Contains(string(audit), "Alex") || !strings.Contains(string(audit), "purge_person_data") {
+		t.Errorf("Expected audit entries without the name, got %s", audit)
+	}
+}
+
+func TestPersonDataPurgeCoversEveryStore(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	enableEventStorage(t, js, 2)
+	pattern := personPattern("Alex", nil)
+
+	createTestTask(t, js, "PD-2", "Quarterly planning", "work")
+	task, _ := js.loadTask("PD-2")
+	now := time.Now()
+	task.Assignee = "Alex"
+	task.Handoffs = []Handoff{{Time: now, From: "Alex", To: "Sam"}}
+	task.Entries = append(task.Entries,
+		Entry{ID: generateEntryID(), Timestamp: now, Type: "note", Content: "Draft the budget", History: []EntryEdit{{Content: "Alex drafts the budget", EditedAt: now}}},
+		Entry{ID: generateEntryID(), Timestamp: now, Type: "deleted", Content: "Deleted note entry: Alex asked", History: []EntryEdit{{Content: "Alex's salary band", EditedAt: now}}, Replaces: []string{"entry_1"}},
+	)
+	js.saveTask(task)
+
+	createTestTask(t, js, "PD-3", "Onboard Alex", "work")
+	archived, _ := js.loadTask("PD-3")
+	js.archiveTask(archived, "")
+
+	createTestTask(t, js, "PD-4", "Ship the API", "work")
+	trashed, _ := js.loadTask("PD-4")
+	trashed.Entries = append(trashed.Entries, Entry{ID: generateEntryID(), Timestamp: now, Content: "Alex reviewed the schema"})
+	js.trashTask(trashed, "Alex asked to drop it")
+
+	js.addFeedbackItems([]FeedbackItem{{ID: "fb_alex", Date: "2026-10-01", Direction: "given", Person: "Alex", Content: "Shorter standups", Source: "manual"}})
+	js.addBragItem(BragItem{ID: "brag_alex", Date: "2026-10-02", Category: "manual", Text: "Mentored Alex through on-call", Source: "manual"})
+	js.saveWeeklyReview(&WeeklyReview{WeekStart: "2026-10-05", Wins: []string{"Unblocked Alex", "Shipped search"}, CheckIns: []GoalCheckIn{{Goal: "Pair with Alex"}}, Notes: "Quiet week\nAlex out sick"})
+
+	meeting := &Meeting{Type: "retro", Date: "2026-10-06", Attendees: []string{"Alex", "Sam"}, Notes: "Alex ran it"}
+	data, _ := json.Marshal(TrashedMeeting{DeletedAt: now, Meeting: meeting})
+	os.MkdirAll(filepath.Join(js.DataDir, "trash", "meetings"), 0755)
+	os.WriteFile(filepath.Join(js.DataDir, "trash", "meetings", "retro-2026-10-06_1.json"), data, 0644)
+
+	matches, err := js.findPersonData(pattern)
+	if err != nil {
+		t.Fatalf("Failed to search: %v", err)
+	}
+	found := make(map[string]bool)
+	for _, match := range matches {
+		found[match.Source+"@"+match.Location+"."+match.Field] = true
+	}
+	for _, want := range []string{
+		"task_assignee@.", "task_handoff@.", "task_entry@.history", "task_entry@.",
+		"task_title@archived.", "task_entry@trash.", "deletion_reason@trash.", "meeting@trash.attendees",
+		"feedback@.person", "brag@.", "weekly_review@.wins", "weekly_review@.check_ins", "weekly_review@.notes",
+		"task_entry@event_log.", "task_assignee@event_snapshot.",
+	} {
+		if !found[want] {
+			t.Errorf("Expected a %s match, got %v", want, found)
+		}
+	}
+
+	result, _ := js.PurgePersonData(ctx, CreateMockRequest(map[string]interface{}{"person": "Alex", "confirm": confirmationCode(matches)}))
+	if result.IsError {
+		t.Fatalf("Unexpected purge error: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+
+	if left, _ := js.findPersonData(pattern); len(left) != 0 {
+		t.Errorf("Expected nothing left mentioning Alex, got %+v", left)
+	}
+	task, _ = js.loadTask("PD-2")
+	if tombstone := task.Entries[len(task.Entries)-1]; tombstone.Type != "deleted" || len(tombstone.Replaces) != 1 || tombstone.History[0].Content != "[redacted]'s salary band" {
+		t.Errorf("Expected the deletion record kept and redacted, got %+v", tombstone)
+	}
+	if reviews, _ := js.loadWeeklyReviews(); len(reviews[0].Wins) != 1 || reviews[0].Notes != "Quiet week" {
+		t.Errorf("Expected only the review items mentioning Alex deleted, got %+v", reviews[0])
+	}
+
+	// The compacted event log keeps working
+	createTestTask(t, js, "PD-5", "Plan the offsite", "work")
+	if result, _ := js.GetTaskAsOf(ctx, CreateMockRequest(map[string]interface{}{"task_id": "PD-2", "as_of": time.Now().Add(time.Minute).Format(time.RFC3339)})); result.IsError {
+		t.Errorf("Expected history after compaction, got %v", result.Content)
+	}
+}
+
+func TestPersonDataPurgeTeamAndVault(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	vault := t.TempDir()
+
+	config := defaultConfiguration()
+	config.Team.Members = []TeamMember{{Name: "Alex Kim", Aliases: []string{"@alex"}}, {Name: "Sam"}}
+	config.Obsidian.Vault = vault
+	js.saveConfiguration(config)
+
+	createTestTask(t, js, "PD-5", "Review @alex's design", "work")
+	createTestTask(t, js, "PD-6", "Unrelated work", "work")
+	if _, err := js.exportObsidian(vault, ""); err != nil {
+		t.Fatalf("Failed to export: %v", err)
+	}
+	handWritten := filepath.Join(vault, defaultObsidianFolder, "Ideas.md")
+	os.WriteFile(handWritten, []byte("Ask @alex about the roadmap\n"), 0644)
+
+	pattern := personPattern("Alex Kim", []string{"@alex"})
+	matches, _ := js.findPersonData(pattern)
+	found := make(map[string]bool)
+	for _, match := range matches {
+		found[match.Source+"@"+match.Location+"."+match.Field] = true
+	}
+	for _, want := range []string{"team_member@config.team.members", "obsidian_note@obsidian.Journal/Tasks/PD-5.md", "obsidian_note@obsidian.Journal/Ideas.md"} {
+		if !found[want] {
+			t.Errorf("Expected a %s match, got %v", want, found)
+		}
+	}
+
+	result, _ := js.PurgePersonData(ctx, CreateMockRequest(map[string]interface{}{
+		"person": "Alex Kim", "aliases": []interface{}{"@alex"}, "confirm": confirmationCode(matches),
+	}))
+	text := result.Content[0].(mcp.TextContent).Text
+	if result.IsError {
+		t.Fatalf("Unexpected purge error: %s", text)
+	}
+
+	config, _ = js.loadConfiguration()
+	if len(config.Team.Members) != 1 || config.Team.Members[0].Name != "Sam" {
+		t.Errorf("Expected Alex removed from the team registry, got %+v", config.Team.Members)
+	}
+	if note, _ := os.ReadFile(filepath.Join(vault, defaultObsidianFolder, "Tasks", "PD-5.md")); len(note) == 0 || strings.Contains(string(note), "@alex") {
+		t.Errorf("Expected the exported note to be rewritten without Alex, got %q", note)
+	}
+
+	var purged struct {
+		Message   string            `json:"message"`
+		NotPurged []PersonDataMatch `json:"not_purged"`
+	}
+	json.Unmarshal([]byte(text), &purged)
+	if len(purged.NotPurged) != 1 || purged.NotPurged[0].Field != "Journal/Ideas.md" {
+		t.Errorf("Expected the hand-written note to be listed as not purged, got %+v", purged.NotPurged)
+	}
+	if !strings.Contains(purged.Message, "team.members") || !strings.Contains(purged.Message, "obsidian.vault") {
+		t.Errorf("Expected the summary to mention the team registry and the vault, got %q", purged.Message)
+	}
+}
@@ -0,0 +1,243 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// printPapers are the page sizes of the print view
+var printPapers = []string{"a4", "letter"}
+
+// PrintDocument is a print view: a condensed header and one page per section
+type PrintDocument struct {
+	Title     string
+	Subtitle  string
+	Generated string
+	Paper     string // CSS page size, A4 or letter
+	Sections  []PrintSection
+}
+
+// PrintSection starts on a new page
+type PrintSection struct {
+	Heading string
+	Meta    []string // short facts under the heading, such as status and due date
+	Groups  []PrintGroup
+	Empty   string // shown when there are no groups
+}
+
+// PrintGroup is a block of lines under a small heading, such as a task's
+// entries on one day
+type PrintGroup struct {
+	Heading string
+	Lines   []PrintLine
+}
+
+// PrintLine is one entry or checklist item
+type PrintLine struct {
+	Label string // time, date or checkbox
+	Kind  string // entry type, when not a plain log entry
+	Text  string
+}
+
+// printTemplate lays documents out for paper: narrow margins, a one-line
+// header, page breaks between sections and none inside a line
+var printTemplate = template.Must(template.New("print").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+@page { size: {{.Paper}}; margin: 14mm 12mm; }
+* { box-sizing: border-box; }
+body { font: 10pt/1.35 -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #111; margin: 0 auto; max-width: 190mm; }
+header { display: flex; justify-content: space-between; align-items: baseline; border-bottom: 1.5pt solid #111; padding-bottom: 2mm; margin-bottom: 4mm; }
+header h1 { font-size: 13pt; margin: 0; }
+header .meta { font-size: 8.5pt; color: #555; }
+section { break-before: page; page-break-before: always; }
+section:first-of-type { break-before: auto; page-break-before: auto; }
+h2 { font-size: 12pt; margin: 0 0 1mm; }
+.facts { font-size: 8.5pt; color: #444; margin: 0 0 3mm; }
+.facts span + span::before { content: " · "; }
+h3 { font-size: 10pt; margin: 3mm 0 1mm; border-bottom: 0.5pt solid #bbb; break-after: avoid; page-break-after: avoid; }
+table { width: 100%; border-collapse: collapse; }
+tr { break-inside: avoid; page-break-inside: avoid; }
+td { vertical-align: top; padding: 0.6mm 0; }
+td.label { width: 16mm; color: #555; font-variant-numeric: tabular-nums; white-space: nowrap; }
+td.text { white-space: pre-wrap; }
+.kind { font-size: 7.5pt; text-transform: uppercase; color: #666; border: 0.5pt solid #999; border-radius: 2pt; padding: 0 1mm; margin-right: 1mm; }
+.empty { color: #777; font-style: italic; }
+@media screen { body { padding: 10mm; background: #eee; } section, header { background: #fff; padding: 8mm; } section { margin-top: 6mm; } }
+</style>
+</head>
+<body>
+<header><h1>{{.Title}}</h1><div class="meta">{{if .Subtitle}}{{.Subtitle}} · {{end}}printed {{.Generated}}</div></header>
+{{range .Sections}}<section>
+<h2>{{.Heading}}</h2>
+{{if .Meta}}<p class="facts">{{range .Meta}}<span>{{.}}</span>{{end}}</p>{{end}}
+{{range .Groups}}{{if .Heading}}<h3>{{.Heading}}</h3>{{end}}
+<table>{{range .Lines}}<tr><td class="label">{{.Label}}</td><td class="text">{{if .Kind}}<span class="kind">{{.Kind}}</span>{{end}}{{.Text}}</td></tr>
+{{end}}</table>
+{{else}}<p class="empty">{{.Empty}}</p>
+{{end}}</section>
+{{end}}</body>
+</html>
+`))
+
+// renderPrintDocument renders a print view as a standalone HTML page
+func renderPrintDocument(doc PrintDocument) (string, error) {
+	var html strings.Builder
+	if err := printTemplate.Execute(&html, doc); err != nil {
+		return "", err
+	}
+	return html.String(), nil
+}
+
+// printEntryLine is an entry as a print line labelled with layout
+func printEntryLine(entry Entry, layout string) PrintLine {
+	line := PrintLine{Label: entry.Timestamp.Format(layout), Text: entry.Content}
+	if entry.Type != "" && entry.Type != "log" {
+		line.Kind = strings.ReplaceAll(entry.Type, "_", " ")
+	}
+	return line
+}
+
+// taskPrintSection is a task on its own page: its facts, checklist and
+// entries grouped by day
+func taskPrintSection(task *Task, loc *time.Location) PrintSection {
+	section := PrintSection{Heading: fmt.Sprintf("%s: %s", task.ID, task.Title), Empty: "No entries"}
+	section.Meta = append(section.Meta, task.Status, task.Type)
+	if task.Priority != "" {
+		section.Meta = append(section.Meta, "priority "+task.Priority)
+	}
+	if task.DueDate != "" {
+		section.Meta = append(section.Meta, "due "+task.DueDate)
+	}
+	if task.Assignee != "" {
+		section.Meta = append(section.Meta, task.Assignee)
+	}
+	if len(task.Tags) > 0 {
+		section.Meta = append(section.Meta, strings.Join(task.Tags, ", "))
+	}
+
+	if len(task.Checklist) > 0 {
+		group := PrintGroup{Heading: "Checklist"}
+		for _, item := range task.Checklist {
+			box := "☐"
+			if item.Done {
+				box = "☑"
+			}
+			group.Lines = append(group.Lines, PrintLine{Label: box, Text: item.Text})
+		}
+		section.Groups = append(section.Groups, group)
+	}
+
+	entries := slices.Clone(task.Entries)
+	for i := range entries {
+		entries[i].Timestamp = entries[i].Timestamp.In(loc)
+	}
+	sortEntriesByTime(entries)
+	for _, entry := range entries {
+		day := entry.Timestamp.Format("Monday, 2006-01-02")
+		if last := len(section.Groups) - 1; last < 0 || section.Groups[last].Heading != day {
+			section.Groups = append(section.Groups, PrintGroup{Heading: day})
+		}
+		last := len(section.Groups) - 1
+		section.Groups[last].Lines = append(section.Groups[last].Lines, printEntryLine(entry, "15:04"))
+	}
+	return section
+}
+
+// weekPrintSections puts each day of a week on its own page, with the
+// day's entries grouped by task
+func weekPrintSections(days []DailyActivity, tasks map[string]*Task, order string) []PrintSection {
+	var sections []PrintSection
+	for _, day := range days {
+		date, _ := time.Parse("2006-01-02", day.Date)
+		section := PrintSection{Heading: date.Format("Monday, 2006-01-02"), Empty: "No activity"}
+		entries := 0
+		for _, taskID := range orderTaskIDs(day.Tasks, order) {
+			group := PrintGroup{Heading: taskHeading(taskID, tasks)}
+			for _, entry := range day.Tasks[taskID] {
+				group.Lines = append(group.Lines, printEntryLine(entry, "15:04"))
+			}
+			entries += len(group.Lines)
+			section.Groups = append(section.Groups, group)
+		}
+		if entries > 0 {
+			section.Meta = []string{fmt.Sprintf("%d entries", entries), fmt.Sprintf("%d tasks", len(day.Tasks))}
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// GetPrintView renders tasks or a week as a print-ready HTML page, A4 or
+// letter, with a page per task or per day
+func (js *JournalService) GetPrintView(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	paper := strings.ToLower(request.GetString("paper", "a4"))
+	if !slices.Contains(printPapers, paper) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid paper: %s (expected %s)", paper, strings.Join(printPapers, ", "))), nil
+	}
+	loc, logged, err := js.logLocation(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	doc := PrintDocument{Paper: map[string]string{"a4": "A4", "letter": "letter"}[paper], Generated: time.Now().In(loc).Format("2006-01-02 15:04")}
+
+	view := request.GetString("view", "week")
+	switch view {
+	case "tasks":
+		taskIDs := request.GetStringSlice("task_ids", nil)
+		if len(taskIDs) == 0 {
+			return mcp.NewToolResultError("task_ids is required for the tasks view"), nil
+		}
+		for _, taskID := range taskIDs {
+			task, err := js.loadTask(taskID)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskID)), nil
+			}
+			doc.Sections = append(doc.Sections, taskPrintSection(task, loc))
+		}
+		doc.Title = doc.Sections[0].Heading
+		if len(taskIDs) > 1 {
+			doc.Title = fmt.Sprintf("%d tasks", len(taskIDs))
+			doc.Subtitle = strings.Join(taskIDs, ", ")
+		}
+	case "week":
+		start := js.weekStartIn(time.Now(), loc)
+		if weekStart := request.GetString("week_start", ""); weekStart != "" {
+			if validationErr := js.validateDateFormat(weekStart, "week_start"); validationErr != nil {
+				return mcp.NewToolResultError(validationErr.Error()), nil
+			}
+			start, _ = time.Parse("2006-01-02", weekStart)
+		}
+		order := request.GetString("order", "id")
+		if !slices.Contains(weeklyOrders, order) {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid order: %s (expected %s)", order, strings.Join(weeklyOrders, ", "))), nil
+		}
+		days, tasks := js.weekActivity(start, loc, logged)
+		doc.Title = "Weekly Report"
+		doc.Subtitle = fmt.Sprintf("%s to %s", start.Format("2006-01-02"), start.AddDate(0, 0, 6).Format("2006-01-02"))
+		doc.Sections = weekPrintSections(days, tasks, order)
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid view: %s (expected tasks or week)", view)), nil
+	}
+
+	html, err := renderPrintDocument(doc)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to render print view: %v", err)), nil
+	}
+	if path := request.GetString("output_path", ""); path != "" {
+		if err := writeFileAtomic(path, []byte(html), 0644); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write print view: %v", err)), nil
+		}
+		return mcp.NewToolResultText(fmt.Sprintf("Wrote a %d-page print view to %s", len(doc.Sections), path)), nil
+	}
+	return mcp.NewToolResultText(html), nil
+}
@@ -0,0 +1,62 @@
+package servers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestGetPrintView(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "PRN-1", "Quarterly <plan>", "work")
+	task, _ := js.loadTask("PRN-1")
+	monday := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	task.Entries = append(task.Entries,
+		Entry{ID: "e1", Timestamp: monday, Content: "Drafted <goals>"},
+		Entry{ID: "e2", Timestamp: monday.AddDate(0, 0, 2), Content: "Chose the vendor", Type: "decision"})
+	task.Checklist = []ChecklistItem{{Text: "Review budget", Done: true}}
+	js.saveTask(task)
+
+	view := func(args map[string]interface{}) string {
+		result, _ := js.GetPrintView(ctx, CreateMockRequest(args))
+		text := result.Content[0].(mcp.TextContent).Text
+		if result.IsError {
+			t.Fatalf("Print view failed: %s", text)
+		}
+		return text
+	}
+
+	html := view(map[string]interface{}{"view": "tasks", "task_ids": []interface{}{"PRN-1"}, "paper": "letter", "timezone": "UTC"})
+	for _, expected := range []string{"size: letter", "PRN-1: Quarterly &lt;plan&gt;", "Drafted &lt;goals&gt;", "☑", "Monday, 2026-03-02", "Wednesday, 2026-03-04", `<span class="kind">decision</span>`} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("Expected %q in the task view", expected)
+		}
+	}
+
+	html = view(map[string]interface{}{"week_start": "2026-03-02", "timezone": "UTC"})
+	if sections := strings.Count(html, "<section>"); sections != 7 {
+		t.Errorf("Expected a page per day, got %d", sections)
+	}
+	if !strings.Contains(html, "size: A4") || !strings.Contains(html, "2026-03-02 to 2026-03-08") || !strings.Contains(html, "No activity") {
+		t.Errorf("Unexpected week view:\n%s", html)
+	}
+
+	path := filepath.Join(tempDir, "week.html")
+	if text := view(map[string]interface{}{"week_start": "2026-03-02", "output_path": path}); !strings.Contains(text, "7-page") {
+		t.Errorf("Unexpected result: %s", text)
+	}
+	if data, err := os.ReadFile(path); err != nil || !strings.HasPrefix(string(data), "<!DOCTYPE html>") {
+		t.Errorf("Expected the HTML written, got %v", err)
+	}
+
+	result, _ := js.GetPrintView(ctx, CreateMockRequest(map[string]interface{}{"paper": "a5"}))
+	if !result.IsError {
+		t.Error("Expected an unknown paper size rejected")
+	}
+}
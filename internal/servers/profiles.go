@@ -0,0 +1,189 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const defaultProfile = "default"
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// ProfileInfo describes a journal profile
+type ProfileInfo struct {
+	Name    string `json:"name"`
+	DataDir string `json:"data_dir"`
+	Active  bool   `json:"active"`
+}
+
+// ListProfiles lists the available journal profiles
+func (js *JournalService) ListProfiles(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	profiles, err := js.listProfiles()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list profiles: %v", err)), nil
+	}
+
+	result := map[string]interface{}{
+		"active_profile": js.activeProfile(),
+		"profiles":       profiles,
+	}
+
+	resultJSON, _ := json.Marshal(result)
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// UseProfile switches the active journal profile
+func (js *JournalService) UseProfile(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError("name is required"), nil
+	}
+
+	if err := validateProfileName(name); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	create := request.GetString("create", "false") == "true"
+	profileDir := js.profileDir(name)
+
+	if _, err := os.Stat(profileDir); os.IsNotExist(err) && !create {
+		return mcp.NewToolResultError(fmt.Sprintf("Profile %s does not exist (pass create=true to create it)", name)), nil
+	}
+
+	if err := ensureDataDirs(profileDir); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to prepare profile: %v", err)), nil
+	}
+
+	previous := js.activeProfile()
+	js.switchProfile(name)
+
+	return mcp.NewToolResultText(fmt.Sprintf("Switched from profile %s to %s", previous, name)), nil
+}
+
+// ProfileFooterMiddleware appends the active profile to every tool result
+func (js *JournalService) ProfileFooterMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		if err != nil || result == nil {
+			return result, err
+		}
+
+		result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf("_Profile: %s_", js.activeProfile())))
+		return result, nil
+	}
+}
+
+func (js *JournalService) listProfiles() ([]ProfileInfo, error) {
+	active := js.activeProfile()
+	profiles := []ProfileInfo{{
+		Name:    defaultProfile,
+		DataDir: js.rootDir(),
+		Active:  active == defaultProfile,
+	}}
+
+	entries, err := os.ReadDir(filepath.Join(js.rootDir(), "profiles"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && validateProfileName(entry.Name()) == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		profiles = append(profiles, ProfileInfo{
+			Name:    name,
+			DataDir: js.profileDir(name),
+			Active:  active == name,
+		})
+	}
+
+	return profiles, nil
+}
+
+// switchProfile points the service at another profile's data directory
+func (js *JournalService) switchProfile(name string) {
+	js.profileMu.Lock()
+	defer js.profileMu.Unlock()
+
+	if js.RootDir == "" {
+		js.RootDir = js.DataDir
+	}
+
+	js.DataDir = js.RootDir
+	js.Profile = ""
+	if name != defaultProfile {
+		js.DataDir = filepath.Join(js.RootDir, "profiles", name)
+		js.Profile = name
+	}
+}
+
+// dataDir returns the active profile's data directory
+func (js *JournalService) dataDir() string {
+	js.profileMu.RLock()
+	defer js.profileMu.RUnlock()
+	return js.DataDir
+}
+
+// rootDir returns the directory holding the default profile and the profiles/ folder
+func (js *JournalService) rootDir() string {
+	js.profileMu.RLock()
+	defer js.profileMu.RUnlock()
+	if js.RootDir != "" {
+		return js.RootDir
+	}
+	return js.DataDir
+}
+
+func (js *JournalService) activeProfile() string {
+	js.profileMu.RLock()
+	defer js.profileMu.RUnlock()
+	if js.Profile == "" {
+		return defaultProfile
+	}
+	return js.Profile
+}
+
+// snapshot returns a copy of the service fixed to the active profile, for
+// background work that should not follow a later use_profile
+func (js *JournalService) snapshot() *JournalService {
+	js.profileMu.RLock()
+	defer js.profileMu.RUnlock()
+	return &JournalService{DataDir: js.DataDir, RootDir: js.RootDir, Profile: js.Profile, Tools: js.Tools}
+}
+
+func (js *JournalService) profileDir(name string) string {
+	if name == defaultProfile {
+		return js.rootDir()
+	}
+	return filepath.Join(js.rootDir(), "profiles", name)
+}
+
+func validateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("Invalid profile name %q. Use letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// ensureDataDirs creates the standard journal directory layout
+func ensureDataDirs(dataDir string) error {
+	for _, dir := range []string{"tasks", "daily", "weekly", "one-on-ones"} {
+		if err := os.MkdirAll(filepath.Join(dataDir, dir), 0755); err != nil {
+			return err
+		}
+	}
+	return nil
+}
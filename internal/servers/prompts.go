@@ -0,0 +1,248 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Prompt templates pre-fill journal context so a client can produce a standup,
+// weekly review or 1-on-1 prep from a single prompt selection.
+
+// writeRecentEntries lists written entries in [from, to) grouped by task
+func writeRecentEntries(md *strings.Builder, tasks []*Task, from, to time.Time) int {
+	count := 0
+	for _, task := range tasks {
+		var entries []Entry
+		for _, entry := range task.Entries {
+			if !entry.Timestamp.Before(from) && entry.Timestamp.Before(to) && entry.Type != "creation" {
+				entries = append(entries, entry)
+			}
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Timestamp.Before(entries[j].Timestamp) })
+
+		md.WriteString(fmt.Sprintf("### %s: %s (%s)\n", task.ID, task.Title, task.Status))
+		for _, entry := range entries {
+			md.WriteString(fmt.Sprintf("- %s %s\n", entry.Timestamp.Format("Mon 15:04"), entry.Content))
+		}
+		md.WriteString("\n")
+		count += len(entries)
+	}
+	if count == 0 {
+		md.WriteString("No entries recorded.\n\n")
+	}
+	return count
+}
+
+// writeTaskLines lists tasks with their priority and due date
+func writeTaskLines(md *strings.Builder, tasks []*Task, today string) {
+	if len(tasks) == 0 {
+		md.WriteString("None.\n\n")
+		return
+	}
+	for _, task := range tasks {
+		line := fmt.Sprintf("- %s: %s", task.ID, task.Title)
+		if task.Priority != "" {
+			line += fmt.Sprintf(" [%s]", task.Priority)
+		}
+		if task.DueDate != "" {
+			line += " (due " + task.DueDate
+			if isOverdue(task, today) {
+				line += ", overdue"
+			}
+			line += ")"
+		}
+		md.WriteString(line + "\n")
+	}
+	md.WriteString("\n")
+}
+
+// tasksWithStatus returns the tasks with one of the statuses, sorted by ID
+func tasksWithStatus(tasks []*Task, statuses ...string) []*Task {
+	var matched []*Task
+	for _, task := range tasks {
+		for _, status := range statuses {
+			if task.Status == status {
+				matched = append(matched, task)
+				break
+			}
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+	return matched
+}
+
+// promptResult wraps journal context and instructions as a single user message
+func promptResult(description, context, instructions string) *mcp.GetPromptResult {
+	return mcp.NewGetPromptResult(description, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(context+"\n---\n\n"+instructions)),
+	})
+}
+
+// previousWorkday returns the working day before date (Friday for a Monday)
+func previousWorkday(date time.Time) time.Time {
+	switch date.Weekday() {
+	case time.Monday:
+		return date.AddDate(0, 0, -3)
+	case time.Sunday:
+		return date.AddDate(0, 0, -2)
+	default:
+		return date.AddDate(0, 0, -1)
+	}
+}
+
+// DailyStandupPrompt builds a standup from the previous workday's entries and current tasks
+func (js *JournalService) DailyStandupPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	loc := js.location()
+	date := time.Now().In(loc)
+	if dateStr := request.Params.Arguments["date"]; dateStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", dateStr, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q: use YYYY-MM-DD", dateStr)
+		}
+		date = parsed
+	}
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+	since := previousWorkday(day)
+	today := day.Format("2006-01-02")
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tasks: %w", err)
+	}
+
+	var md strings.Builder
+	md.WriteString(fmt.Sprintf("# Journal context for standup on %s\n\n", today))
+	md.WriteString(fmt.Sprintf("## Work logged since %s\n\n", since.Format("Monday 2006-01-02")))
+	writeRecentEntries(&md, tasks, since, day.AddDate(0, 0, 1))
+	md.WriteString("## Active tasks\n")
+	writeTaskLines(&md, js.focusTasks(tasksWithStatus(tasks, "active"), date), today)
+	md.WriteString("## Blocked tasks\n")
+	writeTaskLines(&md, tasksWithStatus(tasks, "blocked"), today)
+
+	return promptResult("Daily standup for "+today, md.String(),
+		"Write my daily standup from the journal context above with three short sections: "+
+			"Yesterday (what I finished or moved forward), Today (what I plan to work on, "+
+			"starting with the highest priority and overdue tasks) and Blockers. Use plain "+
+			"bullet points, mention task IDs, and do not invent work that is not in the journal."), nil
+}
+
+// WeeklyReviewPrompt gathers a week's entries, completions and open work for a review or retro
+func (js *JournalService) WeeklyReviewPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	loc := js.location()
+	now := time.Now().In(loc)
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7)) // Monday of this week
+	if weekStart := request.Params.Arguments["week_start"]; weekStart != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", weekStart, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid week_start %q: use YYYY-MM-DD", weekStart)
+		}
+		start = parsed
+	}
+	end := start.AddDate(0, 0, 7)
+	today := now.Format("2006-01-02")
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tasks: %w", err)
+	}
+
+	var completed []*Task
+	for _, task := range tasksWithStatus(tasks, "completed") {
+		if doneAt, _ := completedAt(task); !doneAt.Before(start) && doneAt.Before(end) {
+			completed = append(completed, task)
+		}
+	}
+
+	var md strings.Builder
+	md.WriteString(fmt.Sprintf("# Journal context for the week of %s to %s\n\n", start.Format("2006-01-02"), end.AddDate(0, 0, -1).Format("2006-01-02")))
+	md.WriteString("## Entries this week\n\n")
+	writeRecentEntries(&md, tasks, start, end)
+	md.WriteString("## Completed this week\n")
+	writeTaskLines(&md, completed, today)
+	md.WriteString("## Still open\n")
+	writeTaskLines(&md, tasksWithStatus(tasks, "active", "blocked"), today)
+
+	return promptResult("Weekly review", md.String(),
+		"Run a short weekly review from the journal context above: summarize what got done, "+
+			"what went well and what was hard (a mini retro), call out overdue or stalled tasks, "+
+			"and propose the three most important things for next week. Keep it concise and "+
+			"reference task IDs."), nil
+}
+
+// OneOnOnePrepPrompt prepares talking points from recent work and the last 1-on-1
+func (js *JournalService) OneOnOnePrepPrompt(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	days := 14
+	if daysStr := request.Params.Arguments["days"]; daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 1 {
+			return nil, fmt.Errorf("invalid days %q: use a positive number", daysStr)
+		}
+		days = parsed
+	}
+	now := time.Now()
+	today := now.Format("2006-01-02")
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tasks: %w", err)
+	}
+	meetings, _ := js.loadOneOnOnes()
+	person := request.Params.Arguments["person"]
+	if person != "" {
+		config, _ := js.loadConfiguration()
+		person = teamMemberName(config, person)
+		// Meetings recorded before they had a person still count until one with them exists
+		if slices.ContainsFunc(meetings, func(meeting *OneOnOne) bool { return meeting.Person != "" && meetingWith(meeting, person) }) {
+			meetings = slices.DeleteFunc(meetings, func(meeting *OneOnOne) bool { return !meetingWith(meeting, person) })
+		}
+	}
+
+	since := now.AddDate(0, 0, -days)
+	var md strings.Builder
+	md.WriteString("# Journal context for 1-on-1 prep\n\n")
+
+	if len(meetings) > 0 {
+		last := meetings[len(meetings)-1]
+		md.WriteString(fmt.Sprintf("## Last 1-on-1 (%s)\n", last.Date))
+		for _, todo := range last.Todos {
+			md.WriteString(fmt.Sprintf("- Action item: %s\n", todo))
+		}
+		for _, insight := range last.Insights {
+			md.WriteString(fmt.Sprintf("- Insight: %s\n", insight))
+		}
+		for _, feedback := range last.Feedback {
+			md.WriteString(fmt.Sprintf("- Feedback: %s\n", feedback))
+		}
+		if last.Notes != "" {
+			md.WriteString(fmt.Sprintf("- Notes: %s\n", last.Notes))
+		}
+		md.WriteString("\n")
+		if lastDate, err := time.Parse("2006-01-02", last.Date); err == nil && lastDate.After(since) {
+			since = lastDate
+		}
+	}
+
+	md.WriteString(fmt.Sprintf("## Work since %s\n\n", since.Format("2006-01-02")))
+	writeRecentEntries(&md, tasks, since, now.Add(time.Minute))
+	md.WriteString("## Blocked tasks\n")
+	writeTaskLines(&md, tasksWithStatus(tasks, "blocked"), today)
+
+	instructions := "Prepare my talking points for an upcoming 1-on-1 from the journal context above: " +
+		"progress on the last meeting's action items, wins worth sharing, blockers where I need help, " +
+		"and questions or feedback to raise. Keep it to a short bulleted agenda."
+	if person != "" {
+		instructions = strings.Replace(instructions, "an upcoming 1-on-1", "my 1-on-1 with "+person, 1)
+	}
+	return promptResult("1-on-1 prep", md.String(), instructions), nil
+}
@@ -0,0 +1,75 @@
+package servers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func promptText(t *testing.T, prompt func(context.Context, mcp.GetPromptRequest) (*mcp.GetPromptResult, error), args map[string]string) string {
+	t.Helper()
+	var request mcp.GetPromptRequest
+	request.Params.Arguments = args
+	result, err := prompt(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Prompt failed: %v", err)
+	}
+	if len(result.Messages) != 1 {
+		t.Fatalf("Expected one prompt message, got %d", len(result.Messages))
+	}
+	return result.Messages[0].Content.(mcp.TextContent).Text
+}
+
+func TestDailyStandupPrompt(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+
+	// A Monday standup covers Friday's work
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	createTestTask(t, js, "API-1", "Build API", "work")
+	task, _ := js.loadTask("API-1")
+	task.Entries = append(task.Entries,
+		Entry{ID: "fri", Timestamp: monday.AddDate(0, 0, -3).Add(10 * time.Hour), Content: "Finished pagination", Type: "log"},
+		Entry{ID: "old", Timestamp: monday.AddDate(0, 0, -7), Content: "Last week's work", Type: "log"},
+	)
+	js.saveTask(task)
+
+	createTestTask(t, js, "OPS-1", "Rotate keys", "work")
+	blocked, _ := js.loadTask("OPS-1")
+	blocked.Status = "blocked"
+	js.saveTask(blocked)
+
+	text := promptText(t, js.DailyStandupPrompt, map[string]string{"date": "2026-03-02"})
+	if !strings.Contains(text, "Finished pagination") || strings.Contains(text, "Last week's work") {
+		t.Errorf("Expected only Friday's entries, got:\n%s", text)
+	}
+	if !strings.Contains(text, "## Blocked tasks\n- OPS-1: Rotate keys") {
+		t.Errorf("Expected blocked task listed, got:\n%s", text)
+	}
+	if !strings.Contains(text, "Yesterday") || !strings.Contains(text, "Blockers") {
+		t.Errorf("Expected standup instructions, got:\n%s", text)
+	}
+
+	var invalid mcp.GetPromptRequest
+	invalid.Params.Arguments = map[string]string{"date": "March 2"}
+	if _, err := js.DailyStandupPrompt(context.Background(), invalid); err == nil {
+		t.Error("Expected error for invalid date")
+	}
+}
+
+func TestOneOnOnePrepPrompt(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+
+	js.saveOneOnOne(&OneOnOne{Date: time.Now().AddDate(0, 0, -7).Format("2006-01-02"), Todos: []string{"Write design doc"}})
+	createTestTask(t, js, "DOC-1", "Design doc", "work")
+
+	text := promptText(t, js.OneOnOnePrepPrompt, map[string]string{"person": "Sam"})
+	if !strings.Contains(text, "Action item: Write design doc") {
+		t.Errorf("Expected last meeting's action items, got:\n%s", text)
+	}
+	if !strings.Contains(text, "my 1-on-1 with Sam") {
+		t.Errorf("Expected person in instructions, got:\n%s", text)
+	}
+}
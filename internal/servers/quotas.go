@@ -0,0 +1,283 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Balance is reported over the last balanceWeeks weeks for weekly quotas and
+// balanceDays days for daily ones, the current period included
+const (
+	balanceWeeks = 4
+	balanceDays  = 7
+)
+
+// TypeQuota is a soft target for one task type: at least this much tracked
+// time, or this many entries, each day or week
+type TypeQuota struct {
+	Type    string `json:"type" yaml:"type"`
+	Period  string `json:"period" yaml:"period"`                       // day or week
+	Minutes int    `json:"minutes,omitempty" yaml:"minutes,omitempty"` // tracked time at least
+	Entries int    `json:"entries,omitempty" yaml:"entries,omitempty"` // entries at least
+}
+
+// BalanceMetrics compares recent time and entries per type with the quotas
+type BalanceMetrics struct {
+	Quotas []QuotaStatus `json:"quotas"`
+}
+
+// QuotaStatus is how a quota fared over recent periods
+type QuotaStatus struct {
+	TypeQuota
+	Periods       []QuotaPeriod `json:"periods"` // oldest first; the last one is in progress
+	Met           int           `json:"met"`
+	Missed        int           `json:"missed"`
+	MissedInARow  int           `json:"missed_in_a_row"`          // finished periods missed up to the current one
+	CrowdedOutBy  string        `json:"crowded_out_by,omitempty"` // the type with the most time or entries over those periods
+	CrowdingShare float64       `json:"crowding_share,omitempty"` // that type's share of all time or entries then
+}
+
+// QuotaPeriod is the time and entries on a type in one day or week
+type QuotaPeriod struct {
+	Start      string         `json:"start"`
+	Minutes    int            `json:"minutes"`
+	Entries    int            `json:"entries"`
+	Met        bool           `json:"met"`
+	InProgress bool           `json:"in_progress,omitempty"`
+	byType     map[string]int // minutes, or entries when no time was tracked, per type
+}
+
+// validateQuotas checks quota periods and targets, one quota per type and period
+func validateQuotas(quotas []TypeQuota) error {
+	seen := make(map[string]bool)
+	for _, quota := range quotas {
+		if quota.Type == "" {
+			return fmt.Errorf("quotas need a task type")
+		}
+		if quota.Period != "day" && quota.Period != "week" {
+			return fmt.Errorf("invalid period for the %s quota: %s (expected day or week)", quota.Type, quota.Period)
+		}
+		if quota.Minutes <= 0 && quota.Entries <= 0 {
+			return fmt.Errorf("the %s quota needs minutes or entries", quota.Type)
+		}
+		key := quota.Type + "/" + quota.Period
+		if seen[key] {
+			return fmt.Errorf("more than one %s quota for %s", periodAdjective(quota.Period), quota.Type)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// quotaTarget describes a quota's target, e.g. "2h00m and 3 entries a week"
+func quotaTarget(quota TypeQuota) string {
+	var parts []string
+	if quota.Minutes > 0 {
+		parts = append(parts, formatMinutes(quota.Minutes))
+	}
+	if quota.Entries > 0 {
+		parts = append(parts, fmt.Sprintf("%d entries", quota.Entries))
+	}
+	return strings.Join(parts, " and ") + " a " + quota.Period
+}
+
+// calculateBalanceMetrics measures each quota over recent periods, or returns
+// nil when no quotas are set
+func calculateBalanceMetrics(tasks []*Task, quotas []TypeQuota, now time.Time, loc *time.Location) *BalanceMetrics {
+	if len(quotas) == 0 {
+		return nil
+	}
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+
+	metrics := &BalanceMetrics{Quotas: []QuotaStatus{}}
+	for _, quota := range quotas {
+		count, step, current := balanceDays, 1, today
+		if quota.Period == "week" {
+			count, step, current = balanceWeeks, 7, monday
+		}
+
+		status := QuotaStatus{TypeQuota: quota}
+		for i := count - 1; i >= 0; i-- {
+			start := current.AddDate(0, 0, -step*i)
+			period := measurePeriod(tasks, quota.Type, start, start.AddDate(0, 0, step))
+			period.Met = (quota.Minutes == 0 || period.Minutes >= quota.Minutes) && (quota.Entries == 0 || period.Entries >= quota.Entries)
+			period.InProgress = i == 0
+			status.Periods = append(status.Periods, period)
+		}
+
+		for _, period := range status.Periods[:len(status.Periods)-1] {
+			if period.Met {
+				status.Met++
+			} else {
+				status.Missed++
+			}
+		}
+		for i := len(status.Periods) - 2; i >= 0 && !status.Periods[i].Met; i-- {
+			status.MissedInARow++
+		}
+		status.CrowdedOutBy, status.CrowdingShare = crowdingType(status.Periods[len(status.Periods)-1-status.MissedInARow:len(status.Periods)-1], quota.Type)
+		metrics.Quotas = append(metrics.Quotas, status)
+	}
+	return metrics
+}
+
+// measurePeriod totals the time and entries in [from, to) for a type, and
+// per type for every task
+func measurePeriod(tasks []*Task, taskType string, from, to time.Time) QuotaPeriod {
+	period := QuotaPeriod{Start: from.Format("2006-01-02")}
+	minutes := make(map[string]int)
+	entries := make(map[string]int)
+	for _, task := range tasks {
+		for _, entry := range task.Entries {
+			if systemEntryTypes[entry.Type] || entry.Timestamp.Before(from) || !entry.Timestamp.Before(to) {
+				continue
+			}
+			minutes[task.Type] += entry.Minutes
+			entries[task.Type]++
+		}
+	}
+	period.Minutes, period.Entries = minutes[taskType], entries[taskType]
+
+	period.byType = entries
+	for _, total := range minutes {
+		if total > 0 {
+			period.byType = minutes
+			break
+		}
+	}
+	return period
+}
+
+// crowdingType returns the other type with the most time (or entries) over
+// the periods, and its share of the total, when it took at least half
+func crowdingType(periods []QuotaPeriod, taskType string) (string, float64) {
+	totals := make(map[string]int)
+	all := 0
+	for _, period := range periods {
+		for t, n := range period.byType {
+			totals[t] += n
+			all += n
+		}
+	}
+	top := ""
+	for _, t := range sortedKeys(totals) {
+		if t != taskType && (top == "" || totals[t] > totals[top]) {
+			top = t
+		}
+	}
+	if top == "" || all == 0 || totals[top]*2 < all {
+		return "", 0
+	}
+	return top, float64(totals[top]*100/all) / 100
+}
+
+// balanceInsights flags quotas missed for several periods running, naming the
+// type that crowded them out
+func balanceInsights(metrics *BalanceMetrics) []string {
+	if metrics == nil {
+		return nil
+	}
+	var insights []string
+	for _, status := range metrics.Quotas {
+		threshold := 2
+		if status.Period == "day" {
+			threshold = 3
+		}
+		if status.MissedInARow < threshold {
+			continue
+		}
+		last := status.Periods[len(status.Periods)-2]
+		if status.CrowdedOutBy != "" {
+			insights = append(insights, fmt.Sprintf("%s has crowded out %s for %d %ss running (%.0f%% of everything logged). Last %s %s got %s and %d entries against a target of %s.",
+				capitalize(status.CrowdedOutBy), status.Type, status.MissedInARow, status.Period, status.CrowdingShare*100,
+				status.Period, status.Type, formatMinutes(last.Minutes), last.Entries, quotaTarget(status.TypeQuota)))
+			continue
+		}
+		insights = append(insights, fmt.Sprintf("%s has missed its target of %s for %d %ss running.",
+			capitalize(status.Type), quotaTarget(status.TypeQuota), status.MissedInARow, status.Period))
+	}
+	return insights
+}
+
+// periodAdjective returns "daily" or "weekly"
+func periodAdjective(period string) string {
+	if period == "day" {
+		return "daily"
+	}
+	return "weekly"
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// SetQuota adds or replaces the soft daily or weekly quota for a task type
+func (js *JournalService) SetQuota(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskType, err := request.RequireString("type")
+	if err != nil {
+		return mcp.NewToolResultError("type is required"), nil
+	}
+	quota := TypeQuota{Type: taskType, Period: request.GetString("period", "week")}
+	if value := request.GetString("time", ""); value != "" {
+		if quota.Minutes, err = parseEstimate(value); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
+	if value := request.GetString("entries", ""); value != "" {
+		if quota.Entries, err = strconv.Atoi(value); err != nil || quota.Entries < 1 {
+			return mcp.NewToolResultError("entries must be a positive number"), nil
+		}
+	}
+
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load configuration: %v", err)), nil
+	}
+	quotas := slices.DeleteFunc(slices.Clone(config.Quotas), func(q TypeQuota) bool {
+		return q.Type == quota.Type && q.Period == quota.Period
+	})
+	quotas = append(quotas, quota)
+	if err := validateQuotas(quotas); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	config.Quotas = quotas
+	if err := js.saveConfiguration(config); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save configuration: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Set the %s quota to at least %s. get_analytics_report shows the balance against it.", quota.Type, quotaTarget(quota))), nil
+}
+
+// RemoveQuota removes a type's daily or weekly quota
+func (js *JournalService) RemoveQuota(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskType, err := request.RequireString("type")
+	if err != nil {
+		return mcp.NewToolResultError("type is required"), nil
+	}
+	period := request.GetString("period", "week")
+
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load configuration: %v", err)), nil
+	}
+	index := slices.IndexFunc(config.Quotas, func(q TypeQuota) bool { return q.Type == taskType && q.Period == period })
+	if index < 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("No %s quota for %s", periodAdjective(period), taskType)), nil
+	}
+	config.Quotas = slices.Delete(config.Quotas, index, index+1)
+	if err := js.saveConfiguration(config); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save configuration: %v", err)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Removed the %s %s quota", periodAdjective(period), taskType)), nil
+}
@@ -0,0 +1,97 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestBalanceMetrics(t *testing.T) {
+	loc := time.UTC
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, loc) // Wednesday; weeks start 2026-02-23, 03-02, 03-09, 03-16
+	at := func(day string) time.Time {
+		d, _ := time.ParseInLocation("2006-01-02", day, loc)
+		return d.Add(10 * time.Hour)
+	}
+	learning := &Task{ID: "L-1", Type: "learning", Entries: []Entry{
+		{Timestamp: at("2026-02-24"), Type: "time", Minutes: 150},
+		{Timestamp: at("2026-03-03"), Type: "time", Minutes: 30},
+		{Timestamp: at("2026-03-17"), Type: "time", Minutes: 60},
+	}}
+	work := &Task{ID: "W-1", Type: "work", Entries: []Entry{
+		{Timestamp: at("2026-03-04"), Type: "time", Minutes: 600},
+		{Timestamp: at("2026-03-10"), Type: "time", Minutes: 900},
+		{Timestamp: at("2026-03-11"), Type: "deleted", Minutes: 0},
+	}}
+	quotas := []TypeQuota{{Type: "learning", Period: "week", Minutes: 120}, {Type: "personal", Period: "day", Entries: 1}}
+
+	metrics := calculateBalanceMetrics([]*Task{learning, work}, quotas, now, loc)
+	weekly := metrics.Quotas[0]
+	if len(weekly.Periods) != balanceWeeks || weekly.Periods[0].Start != "2026-02-23" || !weekly.Periods[3].InProgress {
+		t.Fatalf("Unexpected periods: %+v", weekly.Periods)
+	}
+	if weekly.Met != 1 || weekly.Missed != 2 || weekly.MissedInARow != 2 || weekly.Periods[3].Minutes != 60 {
+		t.Errorf("Unexpected quota status: %+v", weekly)
+	}
+	if weekly.CrowdedOutBy != "work" || weekly.CrowdingShare != 0.98 {
+		t.Errorf("Expected work to crowd out learning, got %q %v", weekly.CrowdedOutBy, weekly.CrowdingShare)
+	}
+	daily := metrics.Quotas[1]
+	if len(daily.Periods) != balanceDays || daily.MissedInARow != 6 || daily.CrowdedOutBy != "learning" {
+		t.Errorf("Unexpected daily status: %+v", daily)
+	}
+
+	insights := balanceInsights(metrics)
+	if len(insights) != 2 || !strings.HasPrefix(insights[0], "Work has crowded out learning for 2 weeks running") {
+		t.Errorf("Unexpected insights: %v", insights)
+	}
+
+	if calculateBalanceMetrics(nil, nil, now, loc) != nil {
+		t.Error("Expected no balance without quotas")
+	}
+}
+
+func TestSetQuota(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	result, _ := js.SetQuota(ctx, CreateMockRequest(map[string]interface{}{"type": "learning", "time": "2h"}))
+	if result.IsError {
+		t.Fatalf("Failed to set quota: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	js.SetQuota(ctx, CreateMockRequest(map[string]interface{}{"type": "learning", "time": "3h", "entries": "2"}))
+	config, _ := js.loadConfiguration()
+	if len(config.Quotas) != 1 || config.Quotas[0].Minutes != 180 || config.Quotas[0].Entries != 2 {
+		t.Errorf("Expected the quota replaced, got %+v", config.Quotas)
+	}
+
+	for _, args := range []map[string]interface{}{
+		{"type": "learning"},
+		{"type": "learning", "period": "month", "time": "2h"},
+		{"type": "learning", "entries": "-1"},
+	} {
+		if result, _ := js.SetQuota(ctx, CreateMockRequest(args)); !result.IsError {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+
+	createTestTask(t, js, "Q-1", "Read the Go spec", "learning")
+	result, _ = js.GetAnalyticsReport(ctx, CreateMockRequest(map[string]interface{}{"task_type": "work"}))
+	var report AnalyticsReport
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report)
+	if report.Balance == nil || len(report.Balance.Quotas) != 1 || report.Balance.Quotas[0].Periods[balanceWeeks-1].Entries == 0 {
+		t.Errorf("Expected the balance across all types, got %+v", report.Balance)
+	}
+
+	js.RemoveQuota(ctx, CreateMockRequest(map[string]interface{}{"type": "learning"}))
+	if config, _ := js.loadConfiguration(); len(config.Quotas) != 0 {
+		t.Errorf("Expected the quota removed, got %+v", config.Quotas)
+	}
+	if result, _ := js.RemoveQuota(ctx, CreateMockRequest(map[string]interface{}{"type": "learning", "period": "day"})); !result.IsError {
+		t.Error("Expected removing a missing quota to fail")
+	}
+}
@@ -0,0 +1,171 @@
+package servers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TaskDayRecord is one row of the raw analytics table: a task on a day it saw
+// activity. Rows are tidy (one observation per row, one value per column) so
+// they load straight into a pandas DataFrame.
+type TaskDayRecord struct {
+	Date          string  `json:"date"`
+	Weekday       string  `json:"weekday"`
+	ISOWeek       string  `json:"iso_week"`
+	TaskID        string  `json:"task_id"`
+	TaskType      string  `json:"task_type"`
+	Status        string  `json:"status"` // current status, not the status on that day
+	Priority      string  `json:"priority"`
+	Tags          string  `json:"tags"` // semicolon-separated
+	Entries       int     `json:"entries"`
+	Words         int     `json:"words"`
+	Minutes       int     `json:"minutes"`
+	StatusChanges int     `json:"status_changes"`
+	Created       bool    `json:"created"`
+	Completed     bool    `json:"completed"`
+	AgeDays       int     `json:"age_days"`
+	Sentiment     float64 `json:"sentiment"` // mean score of scored entries, 0 when none
+}
+
+var taskDayColumns = []string{"date", "weekday", "iso_week", "task_id", "task_type", "status", "priority", "tags",
+	"entries", "words", "minutes", "status_changes", "created", "completed", "age_days", "sentiment"}
+
+// taskDayRecords builds one record per task per local day with activity
+// between from and to (inclusive, YYYY-MM-DD; empty means unbounded)
+func taskDayRecords(tasks []*Task, loc *time.Location, from, to string) []TaskDayRecord {
+	var records []TaskDayRecord
+	for _, task := range tasks {
+		days := make(map[string]*TaskDayRecord)
+		sentiment := make(map[string][]float64)
+		created := task.Created.In(loc)
+		day := func(t time.Time) *TaskDayRecord {
+			local := t.In(loc)
+			key := local.Format("2006-01-02")
+			if days[key] == nil {
+				start := time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, loc)
+				days[key] = &TaskDayRecord{
+					Date:     key,
+					Weekday:  local.Weekday().String(),
+					ISOWeek:  isoWeek(local),
+					TaskID:   task.ID,
+					TaskType: task.Type,
+					Status:   task.Status,
+					Priority: task.Priority,
+					Tags:     strings.Join(task.Tags, ";"),
+					AgeDays:  int(local.Sub(start).Hours() / 24),
+				}
+			}
+			return days[key]
+		}
+
+		day(task.Created).Created = true
+		for _, entry := range task.Entries {
+			record := day(entry.Timestamp)
+			record.Minutes += entry.Minutes
+			if entry.Type == "status_change" {
+				record.StatusChanges++
+			}
+			if isWrittenEntry(entry) {
+				record.Entries++
+				record.Words += len(strings.Fields(entry.Content))
+				if score, ok := sentimentScore(entry.Content); ok {
+					sentiment[record.Date] = append(sentiment[record.Date], score)
+				}
+			}
+		}
+		if doneAt, ok := completedAt(task); ok {
+			day(doneAt).Completed = true
+		}
+
+		for key, record := range days {
+			if from != "" && key < from || to != "" && key > to {
+				continue
+			}
+			if scores := sentiment[key]; len(scores) > 0 {
+				total := 0.0
+				for _, score := range scores {
+					total += score
+				}
+				record.Sentiment = total / float64(len(scores))
+			}
+			records = append(records, *record)
+		}
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Date != records[j].Date {
+			return records[i].Date < records[j].Date
+		}
+		return records[i].TaskID < records[j].TaskID
+	})
+	return records
+}
+
+// taskDayCSV renders records as CSV with a header row
+func taskDayCSV(records []TaskDayRecord) (string, error) {
+	var out strings.Builder
+	writer := csv.NewWriter(&out)
+	writer.Write(taskDayColumns)
+	for _, r := range records {
+		writer.Write([]string{r.Date, r.Weekday, r.ISOWeek, r.TaskID, r.TaskType, r.Status, r.Priority, r.Tags,
+			strconv.Itoa(r.Entries), strconv.Itoa(r.Words), strconv.Itoa(r.Minutes), strconv.Itoa(r.StatusChanges),
+			strconv.FormatBool(r.Created), strconv.FormatBool(r.Completed), strconv.Itoa(r.AgeDays),
+			strconv.FormatFloat(r.Sentiment, 'f', 3, 64)})
+	}
+	writer.Flush()
+	return out.String(), writer.Error()
+}
+
+// GetRawAnalytics dumps tidy task-day records (JSON records or CSV) for custom
+// analysis in pandas or a notebook
+func (js *JournalService) GetRawAnalytics(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	dateFrom := request.GetString("date_from", "")
+	dateTo := request.GetString("date_to", "")
+	taskType := request.GetString("task_type", "")
+	format := request.GetString("format", "json")
+
+	if dateFrom != "" {
+		if validationErr := js.validateDateFormat(dateFrom, "date_from"); validationErr != nil {
+			return mcp.NewToolResultError(validationErr.Error()), nil
+		}
+	}
+	if dateTo != "" {
+		if validationErr := js.validateDateFormat(dateTo, "date_to"); validationErr != nil {
+			return mcp.NewToolResultError(validationErr.Error()), nil
+		}
+	}
+	if format != "json" && format != "csv" {
+		return mcp.NewToolResultError("format must be json or csv"), nil
+	}
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+	if taskType != "" {
+		tasks = js.getTasksByType(tasks, taskType)
+	}
+
+	records := taskDayRecords(tasks, js.location(), dateFrom, dateTo)
+	if format == "csv" {
+		output, err := taskDayCSV(records)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to write CSV: %v", err)), nil
+		}
+		return mcp.NewToolResultText(output), nil
+	}
+
+	if records == nil {
+		records = []TaskDayRecord{}
+	}
+	resultJSON, _ := json.Marshal(records)
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
@@ -0,0 +1,70 @@
+package servers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func TestTaskDayRecords(t *testing.T) {
+	day := func(d, hour int) time.Time { return time.Date(2026, 3, d, hour, 0, 0, 0, time.UTC) }
+	tasks := []*Task{{
+		ID: "TASK-1", Type: "work", Status: "completed", Priority: "high", Tags: []string{"api", "infra"},
+		Created: day(2, 9),
+		Entries: []Entry{
+			{Timestamp: day(2, 9), Content: "Task created: TASK-1", Type: "log"},
+			{Timestamp: day(2, 10), Content: "Great progress on the parser", Type: "log"},
+			{Timestamp: day(4, 11), Content: "Worked", Type: "time", Minutes: 45},
+			{Timestamp: day(4, 12), Content: "Status changed from active to completed", Type: "status_change"},
+		},
+	}}
+
+	records := taskDayRecords(tasks, time.UTC, "", "")
+	if len(records) != 2 {
+		t.Fatalf("Expected one record per active day, got %+v", records)
+	}
+	first, second := records[0], records[1]
+	if first.Date != "2026-03-02" || !first.Created || first.Entries != 1 || first.Words != 5 || first.AgeDays != 0 || first.Tags != "api;infra" {
+		t.Errorf("Unexpected first record: %+v", first)
+	}
+	if second.Weekday != "Wednesday" || second.Minutes != 45 || second.StatusChanges != 1 || !second.Completed || second.AgeDays != 2 {
+		t.Errorf("Unexpected second record: %+v", second)
+	}
+
+	if filtered := taskDayRecords(tasks, time.UTC, "2026-03-03", ""); len(filtered) != 1 || filtered[0].Date != "2026-03-04" {
+		t.Errorf("Expected date_from to drop earlier days, got %+v", filtered)
+	}
+}
+
+func TestRawAnalyticsEndpoint(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	createTestTask(t, js, "TASK-1", "Notebook task", "work")
+	createTestTask(t, js, "TASK-2", "Other task", "learning")
+
+	ws := &WebServer{journalService: js}
+	router := mux.NewRouter()
+	ws.setupRoutes(router)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/analytics/raw?format=csv&task_type=work", nil))
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != "text/csv" {
+		t.Fatalf("Unexpected response %d %s", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+	rows, err := csv.NewReader(strings.NewReader(recorder.Body.String())).ReadAll()
+	if err != nil || len(rows) != 2 || strings.Join(rows[0], ",") != strings.Join(taskDayColumns, ",") || rows[1][3] != "TASK-1" {
+		t.Errorf("Unexpected CSV (%v):\n%s", err, recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/analytics/raw", nil))
+	var records []TaskDayRecord
+	if err := json.Unmarshal(recorder.Body.Bytes(), &records); err != nil || len(records) != 2 {
+		t.Errorf("Expected two JSON records, got %s (%v)", recorder.Body.String(), err)
+	}
+}
@@ -0,0 +1,296 @@
+package servers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TaskMerge describes how a task from a backup was merged into live data
+type TaskMerge struct {
+	TaskID       string   `json:"task_id"`
+	Action       string   `json:"action"` // created, merged, fast_forward, unchanged, deleted, archived
+	EntriesAdded int      `json:"entries_added,omitempty"`
+	Conflicts    []string `json:"conflicts,omitempty"` // fields both sides changed, marked in a merge_conflict entry
+}
+
+// restoreMerge merges a backup into live data, so restoring without
+// overwrite_existing leaves nothing in a directory of its own
+type restoreMerge struct {
+	js       *JournalService
+	label    string // backup file name, used in conflict markers
+	dryRun   bool
+	archived map[string]*Task
+	trashed  map[string]*Task
+	merged   map[string]bool // task IDs already merged, as "both" backups hold two files per task
+}
+
+func (js *JournalService) newRestoreMerge(backupPath string, dryRun bool) *restoreMerge {
+	rm := &restoreMerge{
+		js:       js,
+		label:    filepath.Base(backupPath),
+		dryRun:   dryRun,
+		archived: make(map[string]*Task),
+		trashed:  make(map[string]*Task),
+		merged:   make(map[string]bool),
+	}
+	if tasks, err := js.loadArchivedTasks(); err == nil {
+		for _, task := range tasks {
+			rm.archived[task.ID] = task
+		}
+	}
+	if items, err := js.loadTrash(); err == nil {
+		// Most recently deleted first, so the newest copy of each task wins
+		for _, item := range items {
+			if _, ok := rm.trashed[item.Task.ID]; !ok {
+				rm.trashed[item.Task.ID] = item.Task
+			}
+		}
+	}
+	return rm
+}
+
+// mergeTaskFile merges a tasks/ file from the backup into its live task
+func (rm *restoreMerge) mergeTaskFile(file *zip.File, hasJSON map[string]bool) (*TaskMerge, error) {
+	name := strings.TrimPrefix(file.Name, "tasks/")
+	ext := filepath.Ext(name)
+	// A "both" backup's markdown copies are rewritten when the JSON is saved
+	if ext == ".md" && hasJSON[strings.TrimSuffix(name, ext)] {
+		return nil, nil
+	}
+
+	data, err := rm.js.readZipData(file)
+	if err != nil {
+		return nil, err
+	}
+	var restored *Task
+	switch ext {
+	case ".json":
+		restored = &Task{}
+		err = json.Unmarshal(data, restored)
+	case ".md":
+		restored, err = rm.js.parseTaskFile(data)
+	default:
+		return nil, fmt.Errorf("not a task file")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if restored.ID == "" {
+		restored.ID = strings.TrimSuffix(name, ext)
+	}
+	if rm.merged[restored.ID] {
+		return nil, nil
+	}
+	rm.merged[restored.ID] = true
+	return rm.mergeTask(restored)
+}
+
+func (rm *restoreMerge) mergeTask(restored *Task) (*TaskMerge, error) {
+	defer rm.js.lockTask(restored.ID)()
+
+	live, err := rm.js.loadTask(restored.ID)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		// Tasks deleted or archived since the backup stay that way;
+		// restore_task and unarchive_task bring them back
+		if gone, ok := rm.trashed[restored.ID]; ok && restored.Clock.compare(gone.Clock) != clockAfter {
+			return &TaskMerge{TaskID: restored.ID, Action: "deleted"}, nil
+		}
+		if gone, ok := rm.archived[restored.ID]; ok && restored.Clock.compare(gone.Clock) != clockAfter {
+			return &TaskMerge{TaskID: restored.ID, Action: "archived"}, nil
+		}
+		merge := &TaskMerge{TaskID: restored.ID, Action: "created", EntriesAdded: len(restored.Entries)}
+		if rm.dryRun {
+			return merge, nil
+		}
+		return merge, rm.js.saveTask(restored)
+	}
+
+	merged, merge := mergeRestoredTask(live, restored, rm.label, time.Now())
+	if merged == nil || rm.dryRun {
+		return &merge, nil
+	}
+	return &merge, rm.js.saveTask(merged)
+}
+
+// mergeRestoredTask three-way merges a task from a backup into its live copy,
+// using the vector clocks to find out which side changed since they parted.
+// When only one side changed that side is taken; when both did, entries are
+// united and task fields come from the newer copy, with the other side's
+// values kept in git-style conflict markers. It returns nil when live data
+// already has everything in the backup.
+func mergeRestoredTask(live, restored *Task, label string, now time.Time) (*Task, TaskMerge) {
+	merge := TaskMerge{TaskID: live.ID}
+	switch restored.Clock.compare(live.Clock) {
+	case clockBefore:
+		merge.Action = "unchanged"
+		return nil, merge
+	case clockEqual:
+		if jsonString(restored) == jsonString(live) {
+			merge.Action = "unchanged"
+			return nil, merge
+		}
+	case clockAfter:
+		merge.Action = "fast_forward"
+		merge.EntriesAdded = len(newEntries(live, restored))
+		return copyTask(restored), merge
+	}
+
+	merged := mergeTasks(live, restored)
+	merge.Action = "merged"
+	merge.EntriesAdded = len(merged.Entries) - len(live.Entries)
+
+	var markers strings.Builder
+	kept := "live"
+	for _, field := range mergeFields {
+		ours, theirs := field.value(live), field.value(restored)
+		if ours == theirs {
+			continue
+		}
+		if field.value(merged) == theirs {
+			kept = "backup"
+		}
+		merge.Conflicts = append(merge.Conflicts, field.name)
+		markers.WriteString(fmt.Sprintf("<<<<<<< live\n%s: %s\n=======\n%s: %s\n>>>>>>> %s\n", field.name, ours, field.name, theirs, label))
+	}
+	restoredEntries := make(map[string]Entry)
+	for _, entry := range restored.Entries {
+		restoredEntries[entry.ID] = entry
+	}
+	for _, entry := range live.Entries {
+		if theirs, ok := restoredEntries[entry.ID]; ok && theirs.Content != entry.Content {
+			name := "entry " + entry.ID
+			merge.Conflicts = append(merge.Conflicts, name)
+			markers.WriteString(fmt.Sprintf("<<<<<<< live\n%s: %s\n=======\n%s: %s\n>>>>>>> %s\n", name, entry.Content, name, theirs.Content, label))
+		}
+	}
+
+	if len(merge.Conflicts) > 0 {
+		merged.Entries = append(merged.Entries, Entry{
+			ID:        generateEntryID(),
+			Timestamp: now,
+			Type:      "merge_conflict",
+			Content: fmt.Sprintf("Restoring %s conflicted with changes made since; the task keeps the %s side of: %s\n\n%s",
+				label, kept, strings.Join(merge.Conflicts, ", "), markers.String()),
+		})
+		merged.Updated = now
+	}
+	return merged, merge
+}
+
+// mergeFields are the task fields compared for conflicts when merging
+var mergeFields = []struct {
+	name  string
+	value func(*Task) string
+}{
+	{"title", func(t *Task) string { return t.Title }},
+	{"type", func(t *Task) string { return t.Type }},
+	{"status", func(t *Task) string { return t.Status }},
+	{"priority", func(t *Task) string { return t.Priority }},
+	{"tags", func(t *Task) string { return strings.Join(t.Tags, ", ") }},
+	{"due_date", func(t *Task) string { return t.DueDate }},
+	{"assignee", func(t *Task) string { return t.Assignee }},
+	{"parent_id", func(t *Task) string { return t.ParentID }},
+	{"estimate", func(t *Task) string { return formatMinutes(t.EstimateMinutes) }},
+}
+
+// newEntries returns the entries of other that task does not have
+func newEntries(task, other *Task) []Entry {
+	seen := make(map[string]bool)
+	for _, entry := range task.Entries {
+		seen[entry.ID] = true
+	}
+	var added []Entry
+	for _, entry := range other.Entries {
+		if !seen[entry.ID] {
+			added = append(added, entry)
+		}
+	}
+	return added
+}
+
+// mergeDailyLog adds the backup's daily log entries missing from the live log,
+// reporting whether the live log changed
+func (rm *restoreMerge) mergeDailyLog(file *zip.File) (bool, error) {
+	data, err := rm.js.readZipData(file)
+	if err != nil {
+		return false, err
+	}
+	var restored DailyActivity
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return false, err
+	}
+	if restored.Date == "" {
+		restored.Date = strings.TrimSuffix(filepath.Base(file.Name), ".json")
+	}
+
+	dailyPath := filepath.Join(rm.js.dataDir(), "daily", restored.Date+".json")
+	defer lockFile(dailyPath)()
+
+	live := DailyActivity{Date: restored.Date, Tasks: make(map[string][]Entry)}
+	if data, err := rm.js.readDataFile(dailyPath); err == nil {
+		if err := json.Unmarshal(data, &live); err != nil {
+			return false, err
+		}
+		if live.Tasks == nil {
+			live.Tasks = make(map[string][]Entry)
+		}
+	}
+	if live.Snapshot == nil {
+		live.Snapshot = restored.Snapshot
+	}
+
+	changed := false
+	for _, taskID := range sortedKeys(restored.Tasks) {
+		seen := make(map[string]bool)
+		for _, entry := range live.Tasks[taskID] {
+			seen[entry.ID] = true
+		}
+		for _, entry := range restored.Tasks[taskID] {
+			if !seen[entry.ID] {
+				live.Tasks[taskID] = append(live.Tasks[taskID], entry)
+				changed = true
+			}
+		}
+		sort.SliceStable(live.Tasks[taskID], func(i, j int) bool {
+			return live.Tasks[taskID][i].Timestamp.Before(live.Tasks[taskID][j].Timestamp)
+		})
+	}
+	if !changed || rm.dryRun {
+		return changed, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dailyPath), 0755); err != nil {
+		return false, err
+	}
+	return true, rm.js.saveDailyActivity(&live)
+}
+
+// mergeFile restores a file that is not a task or daily log when it is
+// missing, and reports whether the live file differs from the backup's
+func (rm *restoreMerge) mergeFile(file *zip.File) (created, differs bool, err error) {
+	livePath := filepath.Join(rm.js.dataDir(), file.Name)
+	liveData, err := rm.js.readDataFile(livePath)
+	if os.IsNotExist(err) {
+		if rm.dryRun {
+			return true, false, nil
+		}
+		return true, false, extractFileFromZip(file, rm.js.dataDir())
+	}
+	if err != nil {
+		return false, false, err
+	}
+	data, err := rm.js.readZipData(file)
+	if err != nil {
+		return false, false, err
+	}
+	return false, !bytes.Equal(data, liveData), nil
+}
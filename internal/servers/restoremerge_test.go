@@ -0,0 +1,116 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestMergeRestoredTask(t *testing.T) {
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	now := base.Add(48 * time.Hour)
+	live := &Task{
+		ID: "T-1", Title: "Migrate", Status: "completed", Updated: base.Add(2 * time.Hour),
+		Clock:   VectorClock{"laptop": 3},
+		Entries: []Entry{{ID: "e1", Timestamp: base, Content: "Started"}, {ID: "e2", Timestamp: base.Add(time.Hour), Content: "Done"}},
+	}
+
+	older := copyTask(live)
+	older.Clock = VectorClock{"laptop": 2}
+	if merged, merge := mergeRestoredTask(live, older, "backup.zip", now); merged != nil || merge.Action != "unchanged" {
+		t.Errorf("Expected a backup the journal descends from to change nothing, got %+v", merge)
+	}
+
+	newer := copyTask(live)
+	newer.Clock = VectorClock{"laptop": 4}
+	newer.Entries = append(newer.Entries, Entry{ID: "e3", Timestamp: base.Add(3 * time.Hour), Content: "Follow-up"})
+	if merged, merge := mergeRestoredTask(live, newer, "backup.zip", now); merged == nil || merge.Action != "fast_forward" || merge.EntriesAdded != 1 {
+		t.Errorf("Expected a fast-forward, got %+v", merge)
+	}
+
+	diverged := &Task{
+		ID: "T-1", Title: "Migrate", Status: "blocked", Priority: "high", Updated: base.Add(time.Hour),
+		Clock: VectorClock{"laptop": 2, "desktop": 1},
+		Entries: []Entry{
+			{ID: "e1", Timestamp: base, Content: "Started the migration"},
+			{ID: "e4", Timestamp: base.Add(30 * time.Minute), Content: "Waiting on DBA"},
+		},
+	}
+	merged, merge := mergeRestoredTask(live, diverged, "backup.zip", now)
+	if merge.Action != "merged" || merge.EntriesAdded != 1 || !slices.Equal(merge.Conflicts, []string{"status", "priority", "entry e1"}) {
+		t.Fatalf("Unexpected merge: %+v", merge)
+	}
+	if merged.Status != "completed" || len(merged.Entries) != 4 {
+		t.Errorf("Expected the newer fields and all entries, got %+v", merged)
+	}
+	marker := merged.Entries[3]
+	if marker.Type != "merge_conflict" || !strings.Contains(marker.Content, "keeps the live side") ||
+		!strings.Contains(marker.Content, "<<<<<<< live\nstatus: completed\n=======\nstatus: blocked\n>>>>>>> backup.zip") {
+		t.Errorf("Unexpected conflict markers: %+v", marker)
+	}
+}
+
+func TestRestoreDataBackupMerges(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "RM-1", "Kept", "work")
+	createTestTask(t, js, "RM-2", "Lost", "work")
+	createTestTask(t, js, "RM-3", "Deleted", "work")
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "RM-2", "content": "Only in the backup"}))
+
+	backup, err := js.writeBackup(filepath.Join(tempDir, "backups", "merge.zip"), true, "default", "")
+	if err != nil {
+		t.Fatalf("Failed to write backup: %v", err)
+	}
+
+	// Since the backup: RM-1 moved on, RM-2's file and daily log were lost and RM-3 was deleted
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "RM-1", "content": "Newer work"}))
+	os.Remove(filepath.Join(tempDir, "tasks", "RM-2.json"))
+	os.RemoveAll(filepath.Join(tempDir, "daily"))
+	js.DeleteTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "RM-3"}))
+
+	result, _ := js.RestoreDataBackup(ctx, CreateMockRequest(map[string]interface{}{"backup_path": backup.BackupPath, "dry_run": "true"}))
+	var restore RestoreResult
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &restore)
+	if _, err := os.Stat(filepath.Join(tempDir, "tasks", "RM-2.json")); !os.IsNotExist(err) || len(restore.Merged) != 3 {
+		t.Fatalf("Expected a dry run to only report, got %+v", restore)
+	}
+
+	result, _ = js.RestoreDataBackup(ctx, CreateMockRequest(map[string]interface{}{"backup_path": backup.BackupPath}))
+	restore = RestoreResult{}
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &restore)
+	if restore.TargetDir != tempDir {
+		t.Errorf("Expected the backup merged into the data directory, got %s", restore.TargetDir)
+	}
+	actions := make(map[string]string)
+	for _, merge := range restore.Merged {
+		actions[merge.TaskID] = merge.Action
+	}
+	if actions["RM-1"] != "unchanged" || actions["RM-2"] != "created" || actions["RM-3"] != "deleted" {
+		t.Errorf("Unexpected merge actions: %+v", restore.Merged)
+	}
+
+	if task, err := js.loadTask("RM-1"); err != nil || len(task.Entries) != 2 {
+		t.Errorf("Expected the newer RM-1 left alone, got %+v", task)
+	}
+	if task, err := js.loadTask("RM-2"); err != nil || len(task.Entries) != 2 {
+		t.Errorf("Expected RM-2 restored, got %+v", task)
+	}
+	if _, err := js.loadTask("RM-3"); err == nil {
+		t.Error("Expected RM-3 to stay deleted")
+	}
+	today := time.Now().Format("2006-01-02")
+	if _, err := os.Stat(filepath.Join(tempDir, "daily", today+".json")); err != nil {
+		t.Errorf("Expected the daily log restored: %v", err)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(tempDir, "restore-*")); len(matches) != 0 {
+		t.Errorf("Expected no restore directory, got %v", matches)
+	}
+}
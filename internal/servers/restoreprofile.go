@@ -0,0 +1,194 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// A backup restored into a new profile can be inspected, and compared with
+// the live journal, before promote_profile swaps it in as the default profile.
+
+// RestoreReport describes a journal restored into a profile and how its tasks
+// differ from the profile that was active during the restore
+type RestoreReport struct {
+	Profile       string   `json:"profile"`
+	ComparedWith  string   `json:"compared_with"`
+	Tasks         int      `json:"tasks"`
+	Entries       int      `json:"entries"`
+	DailyLogs     int      `json:"daily_logs"`
+	OneOnOnes     int      `json:"one_on_ones"`
+	OnlyInRestore []string `json:"only_in_restore,omitempty"` // task IDs missing from the compared profile
+	OnlyInCurrent []string `json:"only_in_current,omitempty"` // task IDs the backup does not have
+	Differing     []string `json:"differing,omitempty"`       // task IDs whose status, entries or update time differ
+	NextStep      string   `json:"next_step"`
+}
+
+// PromoteResult summarizes a promote_profile call
+type PromoteResult struct {
+	Promoted      string   `json:"promoted"`
+	PreviousSaved string   `json:"previous_saved_as"`
+	Moved         []string `json:"moved"`
+	LeftBehind    []string `json:"left_behind,omitempty"` // names that already existed in the default profile
+	Summary       string   `json:"summary"`
+}
+
+// promoteKeep are the names in the root directory that belong to every profile and are never swapped
+var promoteKeep = map[string]bool{"profiles": true, "backups": true}
+
+// loadTasksIn reads every task from the tasks/ directory of a data directory,
+// preferring JSON files over markdown ones
+func (js *JournalService) loadTasksIn(dataDir string) map[string]*Task {
+	dir := &JournalService{DataDir: dataDir, RootDir: js.rootDir()}
+	tasks := make(map[string]*Task)
+	for _, store := range []Storage{newFileStorage(dir), newMarkdownStorage(dir)} {
+		ids, _ := store.ListTaskIDs()
+		for _, id := range ids {
+			if _, loaded := tasks[id]; loaded {
+				continue
+			}
+			if task, err := store.LoadTask(id); err == nil {
+				tasks[id] = task
+			}
+		}
+	}
+	return tasks
+}
+
+// buildRestoreReport counts what a restored profile holds and compares its tasks with the active profile
+func (js *JournalService) buildRestoreReport(profile string) *RestoreReport {
+	dir := js.profileDir(profile)
+	report := &RestoreReport{
+		Profile:      profile,
+		ComparedWith: js.activeProfile(),
+		NextStep:     fmt.Sprintf("Inspect it with use_profile name=%s, then run promote_profile name=%s to make it the default profile", profile, profile),
+	}
+
+	restored := js.loadTasksIn(dir)
+	current := js.loadTasksIn(js.dataDir())
+	for id, task := range restored {
+		report.Tasks++
+		report.Entries += len(task.Entries)
+		live, ok := current[id]
+		switch {
+		case !ok:
+			report.OnlyInRestore = append(report.OnlyInRestore, id)
+		case live.Status != task.Status || len(live.Entries) != len(task.Entries) || !live.Updated.Equal(task.Updated):
+			report.Differing = append(report.Differing, id)
+		}
+	}
+	for id := range current {
+		if _, ok := restored[id]; !ok {
+			report.OnlyInCurrent = append(report.OnlyInCurrent, id)
+		}
+	}
+	sort.Strings(report.OnlyInRestore)
+	sort.Strings(report.OnlyInCurrent)
+	sort.Strings(report.Differing)
+
+	daily, _ := filepath.Glob(filepath.Join(dir, "daily", "*.json"))
+	meetings, _ := filepath.Glob(filepath.Join(dir, "one-on-ones", "*.json"))
+	report.DailyLogs, report.OneOnOnes = len(daily), len(meetings)
+	return report
+}
+
+// PromoteProfile makes a profile the default profile. The default profile's
+// data is first moved into a new profile so nothing is lost.
+func (js *JournalService) PromoteProfile(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError("name is required"), nil
+	}
+	if err := validateProfileName(name); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if name == defaultProfile {
+		return mcp.NewToolResultError("The default profile is already the default"), nil
+	}
+	source := js.profileDir(name)
+	if info, err := os.Stat(source); err != nil || !info.IsDir() {
+		return mcp.NewToolResultError(fmt.Sprintf("Profile %s does not exist", name)), nil
+	}
+
+	previous := request.GetString("save_previous_as", "pre-promote-"+time.Now().Format("20060102-150405"))
+	if err := validateProfileName(previous); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	saved := js.profileDir(previous)
+	if previous == defaultProfile || previous == name {
+		return mcp.NewToolResultError("save_previous_as must name a new profile"), nil
+	}
+	if _, err := os.Stat(saved); err == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Profile %s already exists; choose another save_previous_as", previous)), nil
+	}
+
+	result, err := js.promoteProfile(name, previous)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to promote profile: %v", err)), nil
+	}
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// promoteProfile moves the default profile's data (everything in the root
+// except profiles/ and backups/) into profile previous, then moves profile
+// name's data into the root and switches to the default profile
+func (js *JournalService) promoteProfile(name, previous string) (*PromoteResult, error) {
+	root := js.rootDir()
+	source := js.profileDir(name)
+	saved := js.profileDir(previous)
+	result := &PromoteResult{Promoted: name, PreviousSaved: previous, Moved: []string{}}
+
+	if err := os.MkdirAll(saved, 0755); err != nil {
+		return nil, err
+	}
+	rootEntries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range rootEntries {
+		path := filepath.Join(root, entry.Name())
+		if promoteKeep[entry.Name()] || path == js.backupDir() {
+			continue
+		}
+		if err := os.Rename(path, filepath.Join(saved, entry.Name())); err != nil {
+			return nil, fmt.Errorf("failed to move %s aside (already moved items are in profile %s): %w", entry.Name(), previous, err)
+		}
+	}
+
+	sourceEntries, err := os.ReadDir(source)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range sourceEntries {
+		target := filepath.Join(root, entry.Name())
+		if _, err := os.Stat(target); err == nil {
+			result.LeftBehind = append(result.LeftBehind, entry.Name())
+			continue
+		}
+		if err := os.Rename(filepath.Join(source, entry.Name()), target); err != nil {
+			return nil, fmt.Errorf("failed to move %s into place (the previous default profile is in %s): %w", entry.Name(), previous, err)
+		}
+		result.Moved = append(result.Moved, entry.Name())
+	}
+	if len(result.LeftBehind) == 0 {
+		os.Remove(source)
+	}
+
+	ensureDataDirs(root)
+	js.switchProfile(defaultProfile)
+
+	result.Summary = fmt.Sprintf("Promoted profile %s to the default profile; the previous default data is in profile %s", name, previous)
+	if len(result.LeftBehind) > 0 {
+		result.Summary += fmt.Sprintf(". Left in profile %s because the default profile keeps its own: %s", name, strings.Join(result.LeftBehind, ", "))
+	}
+	return result, nil
+}
@@ -0,0 +1,75 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestRestoreToProfileAndPromote(t *testing.T) {
+	js, rootDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "KEEP-1", "In both", "work")
+	createTestTask(t, js, "GONE-1", "Deleted after the backup", "work")
+
+	backup, err := js.writeBackup(filepath.Join(rootDir, "backups", backupPrefix+"2026-03-02_10-00-00.zip"), true, "default", "")
+	if err != nil {
+		t.Fatalf("Failed to write backup: %v", err)
+	}
+
+	js.DeleteTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "GONE-1"}))
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "KEEP-1", "content": "Later work"}))
+	createTestTask(t, js, "NEW-1", "Created after the backup", "work")
+
+	result, _ := js.RestoreDataBackup(ctx, CreateMockRequest(map[string]interface{}{"backup_path": backup.BackupPath, "profile": "restored"}))
+	var restore RestoreResult
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &restore)
+	if restore.Profile != "restored" || restore.TargetDir != filepath.Join(rootDir, "profiles", "restored") || restore.Report == nil {
+		t.Fatalf("Expected a restore into the new profile with a report, got %+v", restore)
+	}
+	report := restore.Report
+	if report.Tasks != 2 || !slices.Equal(report.OnlyInRestore, []string{"GONE-1"}) ||
+		!slices.Equal(report.OnlyInCurrent, []string{"NEW-1"}) || !slices.Equal(report.Differing, []string{"KEEP-1"}) {
+		t.Errorf("Unexpected restore report: %+v", report)
+	}
+	if js.activeProfile() != defaultProfile {
+		t.Error("Restoring into a profile must not switch profiles")
+	}
+
+	// The profile must be new
+	if result, _ := js.RestoreDataBackup(ctx, CreateMockRequest(map[string]interface{}{"backup_path": backup.BackupPath, "profile": "restored"})); !result.IsError {
+		t.Error("Expected an error restoring into an existing profile")
+	}
+
+	js.switchProfile("restored")
+	result, _ = js.PromoteProfile(ctx, CreateMockRequest(map[string]interface{}{"name": "restored", "save_previous_as": "before-restore"}))
+	var promote PromoteResult
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &promote)
+	if promote.Promoted != "restored" || len(promote.LeftBehind) != 0 {
+		t.Fatalf("Unexpected promotion: %+v", promote)
+	}
+	if js.activeProfile() != defaultProfile {
+		t.Errorf("Expected to be switched to the default profile, got %s", js.activeProfile())
+	}
+
+	if _, err := js.loadTask("GONE-1"); err != nil {
+		t.Error("Expected the restored task in the default profile")
+	}
+	if _, err := js.loadTask("NEW-1"); err == nil {
+		t.Error("Expected the previous default tasks moved out")
+	}
+	if _, err := os.Stat(filepath.Join(rootDir, "profiles", "before-restore", "tasks", "NEW-1.json")); err != nil {
+		t.Errorf("Expected the previous default data kept in a profile: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(rootDir, "profiles", "restored")); !os.IsNotExist(err) {
+		t.Error("Expected the promoted profile directory to be removed")
+	}
+	if _, err := os.Stat(backup.BackupPath); err != nil {
+		t.Errorf("Expected backups to stay in place: %v", err)
+	}
+}
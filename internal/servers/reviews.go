@@ -0,0 +1,290 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// reviewTaskID collects reviews that are not tied to an existing task
+const reviewTaskID = "code-reviews"
+
+// ReviewRequest is one item in the review queue, from GitHub, the journal, or both
+type ReviewRequest struct {
+	Source      string    `json:"source"` // github, journal or both
+	TaskID      string    `json:"task_id,omitempty"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url,omitempty"`
+	Repository  string    `json:"repository,omitempty"`
+	Author      string    `json:"author,omitempty"`
+	Priority    string    `json:"priority,omitempty"`
+	RequestedAt time.Time `json:"requested_at"`
+	AgeDays     int       `json:"age_days"`
+}
+
+// ReviewQueue is the result of list_review_requests
+type ReviewQueue struct {
+	Requests []ReviewRequest `json:"requests"`
+	Warnings []string        `json:"warnings,omitempty"`
+	Summary  string          `json:"summary"`
+}
+
+// priorityRank orders priorities for sorting; unset sorts with medium
+func priorityRank(priority string) int {
+	switch priority {
+	case "urgent":
+		return 4
+	case "high":
+		return 3
+	case "low":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// getReviewRequests returns open pull requests awaiting a review from username
+func (gs *GitHubService) getReviewRequests(ctx context.Context, username string) ([]*github.Issue, error) {
+	query := fmt.Sprintf("is:open is:pr archived:false review-requested:%s", username)
+	opts := &github.SearchOptions{ListOptions: github.ListOptions{PerPage: 100}}
+
+	var prs []*github.Issue
+	for {
+		result, resp, err := gs.client.Search.Issues(ctx, query, opts)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, result.Issues...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return prs, nil
+}
+
+// ListReviewRequests combines GitHub review requests with open journal tasks tagged "review"
+func (js *JournalService) ListReviewRequests(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	queue := ReviewQueue{Requests: []ReviewRequest{}}
+	now := time.Now()
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+
+	byURL := make(map[string]int)
+	for _, task := range tasks {
+		if task.Status == "completed" || !slices.Contains(task.Tags, "review") {
+			continue
+		}
+		if task.IssueURL != "" {
+			byURL[task.IssueURL] = len(queue.Requests)
+		}
+		queue.Requests = append(queue.Requests, ReviewRequest{
+			Source:      "journal",
+			TaskID:      task.ID,
+			Title:       task.Title,
+			URL:         task.IssueURL,
+			Priority:    task.Priority,
+			RequestedAt: task.Created,
+		})
+	}
+
+	if request.GetString("include_github", "true") == "true" {
+		config, _ := js.loadConfiguration()
+		username := request.GetString("username", "")
+		if username == "" && config != nil {
+			username = config.GitHub.Username
+		}
+
+		githubService, err := js.githubService(request)
+		switch {
+		case err != nil:
+			queue.Warnings = append(queue.Warnings, fmt.Sprintf("Skipped GitHub: %v", err))
+		case username == "":
+			queue.Warnings = append(queue.Warnings, "Skipped GitHub: username is required (or set github.username in config)")
+		default:
+			prs, err := githubService.getReviewRequests(ctx, username)
+			if err != nil {
+				queue.Warnings = append(queue.Warnings, fmt.Sprintf("Failed to fetch GitHub review requests: %v", err))
+			}
+			for _, pr := range prs {
+				owner, repo, _, _ := parseGitHubURL(pr.GetHTMLURL())
+				if i, tracked := byURL[pr.GetHTMLURL()]; tracked {
+					queue.Requests[i].Source = "both"
+					queue.Requests[i].Repository = owner + "/" + repo
+					queue.Requests[i].Author = pr.GetUser().GetLogin()
+					continue
+				}
+				queue.Requests = append(queue.Requests, ReviewRequest{
+					Source:      "github",
+					Title:       pr.GetTitle(),
+					URL:         pr.GetHTMLURL(),
+					Repository:  owner + "/" + repo,
+					Author:      pr.GetUser().GetLogin(),
+					RequestedAt: pr.GetCreatedAt().Time,
+				})
+			}
+		}
+	}
+
+	oldest := 0
+	for i := range queue.Requests {
+		queue.Requests[i].AgeDays = int(now.Sub(queue.Requests[i].RequestedAt).Hours() / 24)
+		oldest = max(oldest, queue.Requests[i].AgeDays)
+	}
+
+	// Highest priority first, then oldest first
+	sort.SliceStable(queue.Requests, func(i, j int) bool {
+		a, b := queue.Requests[i], queue.Requests[j]
+		if priorityRank(a.Priority) != priorityRank(b.Priority) {
+			return priorityRank(a.Priority) > priorityRank(b.Priority)
+		}
+		return a.RequestedAt.Before(b.RequestedAt)
+	})
+
+	queue.Summary = fmt.Sprintf("%d reviews waiting", len(queue.Requests))
+	if len(queue.Requests) > 0 {
+		queue.Summary += fmt.Sprintf(", oldest %d days", oldest)
+	}
+
+	resultJSON, _ := json.MarshalIndent(queue, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// LogReview records a completed review as a journal entry and optionally submits it on the pull request
+func (js *JournalService) LogReview(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	summary, err := request.RequireString("summary")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	taskID := request.GetString("task_id", "")
+	prURL := request.GetString("pr_url", "")
+	if taskID == "" && prURL == "" {
+		return mcp.NewToolResultError("task_id or pr_url is required"), nil
+	}
+
+	outcome := request.GetString("outcome", "commented")
+	events := map[string]string{"approved": "APPROVE", "changes_requested": "REQUEST_CHANGES", "commented": "COMMENT"}
+	event, ok := events[outcome]
+	if !ok {
+		return mcp.NewToolResultError("outcome must be one of: approved, changes_requested, commented"), nil
+	}
+
+	var task *Task
+	if taskID != "" {
+		if task, err = js.loadTask(taskID); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskID)), nil
+		}
+		if prURL == "" {
+			prURL = task.IssueURL
+		}
+	} else {
+		task = js.findTaskByIssueURL(prURL)
+	}
+
+	if task == nil {
+		now := time.Now()
+		if task, err = js.loadTask(reviewTaskID); err != nil {
+			task = &Task{
+				ID:      reviewTaskID,
+				Title:   "Code reviews",
+				Type:    "work",
+				Tags:    []string{"code-review"},
+				Status:  "active",
+				Created: now,
+				Updated: now,
+				Entries: []Entry{},
+			}
+		}
+	}
+
+	// Submit on GitHub first so a failed submission is not logged as done
+	submitted := false
+	if request.GetString("submit_review", "false") == "true" {
+		if prURL == "" {
+			return mcp.NewToolResultError("submit_review needs a pr_url or a task linked to a pull request"), nil
+		}
+		owner, repo, number, err := parseGitHubURL(prURL)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid pull request URL: %v", err)), nil
+		}
+		githubService, err := js.githubService(request)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		review := &github.PullRequestReviewRequest{Body: github.String(summary), Event: github.String(event)}
+		if _, _, err := githubService.client.PullRequests.CreateReview(ctx, owner, repo, number, review); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to submit review: %v", err)), nil
+		}
+		submitted = true
+	}
+
+	// Reload under the task's lock, since submitting can take a while; a new
+	// review task has nothing to reload
+	defer js.lockTask(task.ID)()
+	if reloaded, err := js.loadTask(task.ID); err == nil {
+		task = reloaded
+	}
+
+	content := fmt.Sprintf("Reviewed (%s): %s", strings.ReplaceAll(outcome, "_", " "), summary)
+	if prURL != "" {
+		content += fmt.Sprintf(" (%s)", prURL)
+	}
+	entry := Entry{
+		ID:        generateEntryID(),
+		Timestamp: time.Now(),
+		Content:   content,
+		Type:      "review",
+	}
+	task.Entries = append(task.Entries, entry)
+
+	// A review task is done once the review is in, unless changes were requested
+	if slices.Contains(task.Tags, "review") && task.Status != "completed" && outcome != "changes_requested" && request.GetString("complete_task", "true") == "true" {
+		task.Status = "completed"
+	}
+	task.Updated = time.Now()
+
+	if err := js.saveTask(task); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task: %v", err)), nil
+	}
+	js.updateDailyLog(task.ID, entry)
+
+	result := map[string]interface{}{
+		"task_id":   task.ID,
+		"entry_id":  entry.ID,
+		"status":    task.Status,
+		"submitted": submitted,
+		"summary":   fmt.Sprintf("Logged review on %s", task.ID),
+	}
+	if submitted {
+		result["summary"] = fmt.Sprintf("Logged review on %s and submitted it to %s", task.ID, prURL)
+	}
+
+	resultJSON, _ := json.Marshal(result)
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// findTaskByIssueURL returns the task linked to the given issue or pull request URL, if any
+func (js *JournalService) findTaskByIssueURL(url string) *Task {
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return nil
+	}
+	for _, task := range tasks {
+		if task.IssueURL == url {
+			return task
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestListReviewRequestsJournalOnly(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+
+	old := &Task{ID: "REV-1", Title: "Review auth refactor", Type: "work", Tags: []string{"review"}, Status: "active", Priority: "low", Created: time.Now().AddDate(0, 0, -10)}
+	urgent := &Task{ID: "REV-2", Title: "Review hotfix", Type: "work", Tags: []string{"review"}, Status: "active", Priority: "urgent", Created: time.Now()}
+	done := &Task{ID: "REV-3", Title: "Old review", Type: "work", Tags: []string{"review"}, Status: "completed", Created: time.Now()}
+	for _, task := range []*Task{old, urgent, done} {
+		js.saveTask(task)
+	}
+
+	result, _ := js.ListReviewRequests(context.Background(), CreateMockRequest(map[string]interface{}{"include_github": "false"}))
+	var queue ReviewQueue
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &queue)
+
+	if len(queue.Requests) != 2 {
+		t.Fatalf("Expected 2 open review tasks, got %+v", queue.Requests)
+	}
+	if queue.Requests[0].TaskID != "REV-2" || queue.Requests[1].AgeDays != 10 {
+		t.Errorf("Expected urgent first and age computed, got %+v", queue.Requests)
+	}
+}
+
+func TestLogReviewSubmitsToGitHub(t *testing.T) {
+	var submitted map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/acme/widgets/pulls/7/reviews" {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&submitted)
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+
+	js, _ := CreateTestJournalService(t)
+	task := &Task{ID: "REV-7", Title: "Review widgets PR", Type: "work", Tags: []string{"review"}, Status: "active",
+		IssueURL: "https://github.com/acme/widgets/pull/7", Created: time.Now()}
+	js.saveTask(task)
+
+	original := githubAPIURL
+	githubAPIURL = server.URL + "/"
+	defer func() { githubAPIURL = original }()
+
+	result, _ := js.LogReview(context.Background(), CreateMockRequest(map[string]interface{}{
+		"task_id":       "REV-7",
+		"summary":       "LGTM, nice cleanup",
+		"outcome":       "approved",
+		"submit_review": "true",
+		"github_token":  "test-token",
+	}))
+	if result.IsError {
+		t.Fatalf("Unexpected error: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+
+	if submitted["event"] != "APPROVE" || submitted["body"] != "LGTM, nice cleanup" {
+		t.Errorf("Expected approval submitted to GitHub, got %v", submitted)
+	}
+
+	task, _ = js.loadTask("REV-7")
+	if task.Status != "completed" {
+		t.Errorf("Expected review task to be completed, got %s", task.Status)
+	}
+	last := task.Entries[len(task.Entries)-1]
+	if last.Type != "review" || !strings.Contains(last.Content, "Reviewed (approved): LGTM") {
+		t.Errorf("Unexpected review entry: %+v", last)
+	}
+}
@@ -0,0 +1,366 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// s3ManifestMu serializes manifest read-modify-write cycles within this process.
+// Run a single writer per bucket prefix; the manifest is not safe for
+// concurrent writers on different machines.
+var s3ManifestMu sync.Mutex
+
+// s3Manifest indexes the task objects so listing needs a single GET
+type s3Manifest struct {
+	Updated time.Time                  `json:"updated"`
+	Tasks   map[string]s3ManifestEntry `json:"tasks"`
+}
+
+type s3ManifestEntry struct {
+	Key     string    `json:"key"`
+	Updated time.Time `json:"updated"`
+	Size    int       `json:"size"`
+}
+
+// s3Storage keeps tasks as JSON objects in an S3-compatible bucket
+// (AWS S3, MinIO, R2...) alongside a manifest.json index. Only tasks live in
+// the bucket; derived files such as daily logs stay in the local data directory.
+type s3Storage struct {
+	endpoint  *url.URL
+	bucket    string
+	region    string
+	prefix    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	client    *http.Client
+}
+
+// unavailableStorage reports a configuration problem on every call instead
+// of silently falling back to local files
+type unavailableStorage struct {
+	err error
+}
+
+func (us unavailableStorage) SaveTask(task *Task) error             { return us.err }
+func (us unavailableStorage) LoadTask(taskID string) (*Task, error) { return nil, us.err }
+func (us unavailableStorage) ListTaskIDs() ([]string, error)        { return nil, us.err }
+func (us unavailableStorage) DeleteTask(taskID string) error        { return us.err }
+
+func newS3Storage(config *Configuration) (*s3Storage, error) {
+	s3 := config.Storage.S3
+	if s3.Bucket == "" {
+		return nil, fmt.Errorf("storage.s3.bucket is required for s3 storage")
+	}
+
+	endpoint := s3.Endpoint
+	region := s3.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil || endpointURL.Host == "" {
+		return nil, fmt.Errorf("invalid storage.s3.endpoint: %s", endpoint)
+	}
+
+	accessKey := s3.AccessKeyID
+	if accessKey == "" {
+		accessKey = os.Getenv("AWS_ACCESS_KEY_ID")
+	}
+	secretKey := s3.SecretAccessKey
+	if secretKey == "" {
+		secretKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	}
+	if accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("S3 credentials missing: set storage.s3.access_key_id/secret_access_key or AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY")
+	}
+
+	return newS3Client(endpointURL, region, s3.Bucket, s3.Prefix, accessKey, secretKey, s3.PathStyle), nil
+}
+
+// newS3Client returns a client for objects under prefix in bucket
+func newS3Client(endpoint *url.URL, region, bucket, prefix, accessKey, secretKey string, pathStyle bool) *s3Storage {
+	prefix = strings.Trim(prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	return &s3Storage{
+		endpoint:  endpoint,
+		bucket:    bucket,
+		region:    region,
+		prefix:    prefix,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		pathStyle: pathStyle,
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (ss *s3Storage) taskKey(taskID string) string {
+	return ss.prefix + "tasks/" + taskID + ".json"
+}
+
+func (ss *s3Storage) manifestKey() string {
+	return ss.prefix + "manifest.json"
+}
+
+func (ss *s3Storage) SaveTask(task *Task) error {
+	data, err := json.MarshalIndent(task, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	s3ManifestMu.Lock()
+	defer s3ManifestMu.Unlock()
+
+	key := ss.taskKey(task.ID)
+	if err := ss.putObject(key, data); err != nil {
+		return err
+	}
+
+	manifest, err := ss.loadManifest()
+	if err != nil {
+		return err
+	}
+	manifest.Tasks[task.ID] = s3ManifestEntry{Key: key, Updated: task.Updated, Size: len(data)}
+	return ss.saveManifest(manifest)
+}
+
+func (ss *s3Storage) LoadTask(taskID string) (*Task, error) {
+	data, err := ss.getObject(ss.taskKey(taskID))
+	if err != nil {
+		return nil, err
+	}
+
+	var task Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (ss *s3Storage) ListTaskIDs() ([]string, error) {
+	manifest, err := ss.loadManifest()
+	if err != nil {
+		return nil, err
+	}
+	return sortedKeys(manifest.Tasks), nil
+}
+
+func (ss *s3Storage) DeleteTask(taskID string) error {
+	s3ManifestMu.Lock()
+	defer s3ManifestMu.Unlock()
+
+	manifest, err := ss.loadManifest()
+	if err != nil {
+		return err
+	}
+	if _, ok := manifest.Tasks[taskID]; !ok {
+		return &os.PathError{Op: "delete", Path: ss.taskKey(taskID), Err: os.ErrNotExist}
+	}
+
+	if err := ss.deleteObject(ss.taskKey(taskID)); err != nil {
+		return err
+	}
+	delete(manifest.Tasks, taskID)
+	return ss.saveManifest(manifest)
+}
+
+func (ss *s3Storage) loadManifest() (*s3Manifest, error) {
+	manifest := &s3Manifest{Tasks: make(map[string]s3ManifestEntry)}
+
+	data, err := ss.getObject(ss.manifestKey())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return manifest, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("corrupt manifest: %v", err)
+	}
+	if manifest.Tasks == nil {
+		manifest.Tasks = make(map[string]s3ManifestEntry)
+	}
+	return manifest, nil
+}
+
+func (ss *s3Storage) saveManifest(manifest *s3Manifest) error {
+	manifest.Updated = time.Now()
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ss.putObject(ss.manifestKey(), data)
+}
+
+func (ss *s3Storage) putObject(key string, data []byte) error {
+	_, err := ss.do(http.MethodPut, key, data)
+	return err
+}
+
+// getObject returns an os.ErrNotExist-wrapping error for missing keys so
+// callers can treat S3 like the file storage
+func (ss *s3Storage) getObject(key string) ([]byte, error) {
+	return ss.do(http.MethodGet, key, nil)
+}
+
+func (ss *s3Storage) deleteObject(key string) error {
+	_, err := ss.do(http.MethodDelete, key, nil)
+	return err
+}
+
+func (ss *s3Storage) objectURL(key string) *url.URL {
+	u := *ss.endpoint
+	if ss.pathStyle {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + ss.bucket + "/" + key
+	} else {
+		u.Host = ss.bucket + "." + u.Host
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/" + key
+	}
+	return &u
+}
+
+func (ss *s3Storage) do(method, key string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(context.Background(), method, ss.objectURL(key).String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if method == http.MethodPut {
+		contentType := "application/octet-stream"
+		if strings.HasSuffix(key, ".json") {
+			contentType = "application/json"
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+	signS3Request(req, body, ss.accessKey, ss.secretKey, ss.region, time.Now())
+
+	resp, err := ss.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, &os.PathError{Op: strings.ToLower(method), Path: key, Err: os.ErrNotExist}
+	case resp.StatusCode >= 300:
+		return nil, fmt.Errorf("S3 %s %s failed: %s: %s", method, key, resp.Status, strings.TrimSpace(string(data)))
+	}
+	return data, nil
+}
+
+// signS3Request adds AWS Signature Version 4 headers to req
+func signS3Request(req *http.Request, body []byte, accessKey, secretKey, region string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	host := req.URL.Host
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretKey), day)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature))
+}
+
+func s3EscapePath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = s3Escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, s3Escape(key)+"="+s3Escape(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3Escape percent-encodes everything except RFC 3986 unreserved characters
+func s3Escape(s string) string {
+	var buf strings.Builder
+	for _, b := range []byte(s) {
+		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') ||
+			b == '-' || b == '_' || b == '.' || b == '~' {
+			buf.WriteByte(b)
+		} else {
+			fmt.Fprintf(&buf, "%%%02X", b)
+		}
+	}
+	return buf.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
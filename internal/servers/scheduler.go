@@ -0,0 +1,168 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScheduledJob is background work run by the Scheduler whenever Due reports it
+// should run. lastRun is the zero time if the job has never run.
+type ScheduledJob struct {
+	Name string
+	Due  func(now, lastRun time.Time) bool
+	Run  func(ctx context.Context) error
+}
+
+// Scheduler runs jobs for a JournalService while the server is up. Last-run
+// times are kept in the data directory, so a job missed while the server was
+// stopped runs once at the next start. Jobs run against the profile active
+// when the scheduler was created, like the Watcher.
+type Scheduler struct {
+	js   *JournalService
+	jobs []ScheduledJob
+	mu   sync.Mutex
+}
+
+// NewScheduler creates a scheduler with the built-in jobs registered
+func NewScheduler(js *JournalService) *Scheduler {
+	js = js.snapshot()
+	s := &Scheduler{js: js}
+	s.Add(js.dailySnapshotJob())
+	s.Add(js.autoPauseJob())
+	s.Add(js.somedayReviewJob())
+	s.Add(js.autoBackupJob())
+	s.Add(js.digestJob())
+	s.Add(js.digestDeliveryJob())
+	s.Add(js.obsidianSyncJob())
+	s.Add(js.streakNudgeJob())
+	s.Add(js.connectorsJob())
+	s.Add(js.onCallSyncJob())
+	s.Add(js.focusSessionJob())
+	return s
+}
+
+// Add registers a job
+func (s *Scheduler) Add(job ScheduledJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs due jobs immediately and then once a minute until ctx is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		s.RunDue(ctx, time.Now())
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				s.RunDue(ctx, now)
+			}
+		}
+	}()
+}
+
+// RunDue runs every job that is due at now and records the jobs that succeed
+func (s *Scheduler) RunDue(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.loadState()
+	changed := false
+
+	for _, job := range s.jobs {
+		if !job.Due(now, state[job.Name]) {
+			continue
+		}
+		if err := job.Run(ctx); err != nil {
+			log.Printf("Scheduled job %s failed: %v", job.Name, err)
+			continue
+		}
+		state[job.Name] = now
+		changed = true
+	}
+
+	if changed {
+		s.saveState(state)
+	}
+}
+
+func (s *Scheduler) statePath() string {
+	return filepath.Join(s.js.dataDir(), ".journal-mcp", "scheduler.json")
+}
+
+func (s *Scheduler) loadState() map[string]time.Time {
+	state := make(map[string]time.Time)
+	if data, err := os.ReadFile(s.statePath()); err == nil {
+		json.Unmarshal(data, &state)
+	}
+	return state
+}
+
+func (s *Scheduler) saveState(state map[string]time.Time) {
+	if err := os.MkdirAll(filepath.Dir(s.statePath()), 0755); err != nil {
+		log.Printf("Failed to save scheduler state: %v", err)
+		return
+	}
+	data, _ := json.MarshalIndent(state, "", "  ")
+	if err := writeFileAtomic(s.statePath(), data, 0644); err != nil {
+		log.Printf("Failed to save scheduler state: %v", err)
+	}
+}
+
+// dueDailyAt reports whether a daily job scheduled at clock (HH:MM in loc)
+// should run, catching up later the same day if the server was not running at
+// the time
+func dueDailyAt(clock string, loc *time.Location, now, lastRun time.Time) bool {
+	at, err := time.Parse("15:04", clock)
+	if err != nil {
+		return false
+	}
+	now = now.In(loc)
+	scheduled := time.Date(now.Year(), now.Month(), now.Day(), at.Hour(), at.Minute(), 0, 0, loc)
+	return !now.Before(scheduled) && lastRun.Before(scheduled)
+}
+
+// dueWeeklyOn reports whether a weekly job that runs on day should run,
+// catching up later in the week if the server was not running that day
+func dueWeeklyOn(day time.Weekday, loc *time.Location, now, lastRun time.Time) bool {
+	now = now.In(loc)
+	daysSince := (int(now.Weekday()) - int(day) + 7) % 7
+	scheduled := time.Date(now.Year(), now.Month(), now.Day()-daysSince, 0, 0, 0, 0, loc)
+	return lastRun.Before(scheduled)
+}
+
+// parseWeekday parses a weekday name such as "monday" or "Mon"
+func parseWeekday(name string) (time.Weekday, bool) {
+	name = strings.ToLower(name)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || len(name) >= 3 && strings.HasPrefix(full, name) {
+			return day, true
+		}
+	}
+	return time.Sunday, false
+}
+
+// location returns the configured time zone, falling back to the local zone
+func (js *JournalService) location() *time.Location {
+	config, err := js.loadConfiguration()
+	if err != nil || config.General.TimeZone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(config.General.TimeZone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
@@ -0,0 +1,82 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestDueDailyAt(t *testing.T) {
+	loc := time.UTC
+	day := func(hour, minute int) time.Time { return time.Date(2025, 4, 2, hour, minute, 0, 0, loc) }
+
+	cases := []struct {
+		now, lastRun time.Time
+		due          bool
+	}{
+		{day(6, 59), time.Time{}, false},
+		{day(7, 0), time.Time{}, true},
+		{day(15, 30), day(7, 0).AddDate(0, 0, -1), true}, // catch up after a missed morning
+		{day(15, 30), day(7, 5), false},
+	}
+	for _, c := range cases {
+		if got := dueDailyAt("07:00", loc, c.now, c.lastRun); got != c.due {
+			t.Errorf("dueDailyAt(now=%s, lastRun=%s) = %v, expected %v", c.now, c.lastRun, got, c.due)
+		}
+	}
+}
+
+func TestDailySnapshotJob(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	createTestTask(t, js, "WIP-1", "Active work", "work")
+	createTestTask(t, js, "WIP-2", "Stuck work", "work")
+	createTestTask(t, js, "WIP-3", "Finished work", "work")
+	js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": "WIP-2", "status": "blocked"}))
+	js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": "WIP-3", "status": "completed"}))
+
+	runs := 0
+	scheduler := &Scheduler{js: js}
+	job := js.dailySnapshotJob()
+	scheduler.Add(ScheduledJob{Name: job.Name, Due: job.Due, Run: func(ctx context.Context) error {
+		runs++
+		return job.Run(ctx)
+	}})
+
+	morning := time.Now().In(js.location())
+	morning = time.Date(morning.Year(), morning.Month(), morning.Day(), 23, 59, 0, 0, morning.Location())
+	scheduler.RunDue(ctx, morning)
+	scheduler.RunDue(ctx, morning)
+	if runs != 1 {
+		t.Errorf("Expected the snapshot to run once per day, ran %d times", runs)
+	}
+
+	result, _ := js.GetWIPHistory(ctx, CreateMockRequest(map[string]interface{}{"include_tasks": "true"}))
+	var history []WIPPoint
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &history)
+	if len(history) != 1 || history[0].Active != 1 || history[0].Blocked != 1 || strings.Join(history[0].TaskIDs, ",") != "WIP-1,WIP-2" {
+		t.Fatalf("Unexpected WIP history: %+v", history)
+	}
+
+	result, _ = js.GetDailyLog(ctx, CreateMockRequest(map[string]interface{}{"date": history[0].Date}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "Work in Progress") || !strings.Contains(text, "WIP-2: Stuck work (blocked)") {
+		t.Errorf("Expected snapshot in daily log, got:\n%s", text)
+	}
+}
+
+func TestSchedulerKeepsItsProfile(t *testing.T) {
+	js, rootDir := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	scheduler := NewScheduler(js)
+	js.UseProfile(ctx, CreateMockRequest(map[string]interface{}{"name": "personal", "create": "true"}))
+	if got, want := scheduler.statePath(), filepath.Join(rootDir, ".journal-mcp", "scheduler.json"); got != want {
+		t.Errorf("Expected the scheduler to stay on the default profile, got %s", got)
+	}
+}
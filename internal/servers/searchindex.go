@@ -0,0 +1,419 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// searchIndexVersion is bumped whenever tokenization or the index layout
+// changes so old indexes are rebuilt
+const searchIndexVersion = 2
+
+var searchTokenPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// searchIndexFlushDelay batches index writes: saves update the index in
+// memory, and it is written out at most this often
+const searchIndexFlushDelay = 2 * time.Second
+
+// searchIndexMu guards the cached index and serializes index reads and writes
+// within the process
+var searchIndexMu sync.Mutex
+
+// searchIndexCache keeps the index of the data directory in use in memory
+// between searches and saves. Unflushed changes are not lost for good if the
+// process exits: currentSearchIndex re-indexes tasks whose files changed since
+// the index on disk was written.
+var searchIndexCache struct {
+	js      *JournalService
+	dataDir string
+	path    string
+	index   *searchIndex
+	dirty   bool
+	timer   *time.Timer
+}
+
+// searchIndex maps each distinct token in task titles and entries to the tasks
+// containing it. Search narrows tasks by token before the exact substring
+// check, so only candidate tasks are loaded from storage. Each task's tokens
+// are kept too, so re-indexing a saved task touches only its own postings.
+type searchIndex struct {
+	Version  int                    `json:"version"`
+	BuiltAt  time.Time              `json:"built_at"`
+	Tasks    map[string]indexedTask `json:"tasks"`
+	Postings map[string][]string    `json:"postings"` // token -> sorted task IDs
+
+	vocabulary []string // sorted tokens, filled in on first use
+}
+
+type indexedTask struct {
+	Updated  time.Time `json:"updated"`
+	Modified time.Time `json:"modified,omitempty"` // the task file's modification time when indexed
+	Tokens   []string  `json:"tokens"`
+}
+
+// SearchIndexResult summarizes a rebuild_search_index run
+type SearchIndexResult struct {
+	TasksIndexed int    `json:"tasks_indexed"`
+	Tokens       int    `json:"distinct_tokens"`
+	Path         string `json:"path"`
+	Summary      string `json:"summary"`
+}
+
+func (js *JournalService) searchIndexPath() string {
+	return filepath.Join(js.dataDir(), ".journal-mcp", "index", "search-index.json")
+}
+
+// searchTokens returns the distinct lowercase words in text, sorted
+func searchTokens(text string) []string {
+	seen := make(map[string]bool)
+	for _, token := range searchTokenPattern.FindAllString(strings.ToLower(text), -1) {
+		seen[token] = true
+	}
+	return sortedKeys(seen)
+}
+
+func taskSearchTokens(task *Task) []string {
+	var text strings.Builder
+	text.WriteString(task.Title)
+	for _, entry := range task.Entries {
+		text.WriteString("\n" + entry.Content)
+	}
+	return searchTokens(text.String())
+}
+
+// taskModified returns when a task's file in tasks/ last changed, the later of
+// the JSON and markdown copies, or the zero time when storage keeps no local
+// file for it
+func (js *JournalService) taskModified(taskID string) time.Time {
+	var modified time.Time
+	for _, ext := range []string{".json", ".md"} {
+		if info, err := os.Stat(filepath.Join(js.dataDir(), "tasks", taskID+ext)); err == nil && info.ModTime().After(modified) {
+			modified = info.ModTime()
+		}
+	}
+	return modified
+}
+
+// put indexes a task, replacing its earlier postings
+func (index *searchIndex) put(js *JournalService, task *Task) {
+	index.remove(task.ID)
+	tokens := taskSearchTokens(task)
+	index.Tasks[task.ID] = indexedTask{Updated: task.Updated, Modified: js.taskModified(task.ID), Tokens: tokens}
+	for _, token := range tokens {
+		ids := index.Postings[token]
+		if i, found := slices.BinarySearch(ids, task.ID); !found {
+			index.Postings[token] = slices.Insert(ids, i, task.ID)
+		}
+	}
+	index.vocabulary = nil
+}
+
+// remove drops a task and its postings, reporting whether it was indexed
+func (index *searchIndex) remove(taskID string) bool {
+	indexed, ok := index.Tasks[taskID]
+	if !ok {
+		return false
+	}
+	for _, token := range indexed.Tokens {
+		ids := index.Postings[token]
+		if i, found := slices.BinarySearch(ids, taskID); found {
+			ids = slices.Delete(ids, i, i+1)
+		}
+		if len(ids) == 0 {
+			delete(index.Postings, token)
+		} else {
+			index.Postings[token] = ids
+		}
+	}
+	delete(index.Tasks, taskID)
+	index.vocabulary = nil
+	return true
+}
+
+func (js *JournalService) loadSearchIndex() (*searchIndex, error) {
+	data, err := js.readDataFile(js.searchIndexPath())
+	if err != nil {
+		return nil, err
+	}
+
+	var index searchIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, err
+	}
+	if index.Version != searchIndexVersion || index.Tasks == nil || index.Postings == nil {
+		return nil, fmt.Errorf("search index version %d is out of date", index.Version)
+	}
+	return &index, nil
+}
+
+func (js *JournalService) saveSearchIndex(index *searchIndex) error {
+	return js.writeSearchIndex(js.searchIndexPath(), index)
+}
+
+func (js *JournalService) writeSearchIndex(path string, index *searchIndex) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+
+	return js.writeDataFile(path, data, 0644)
+}
+
+// cachedSearchIndex returns the in-memory index of the current data
+// directory, loading it from disk on first use or after a profile switch.
+// The caller must hold searchIndexMu.
+func (js *JournalService) cachedSearchIndex() (*searchIndex, error) {
+	path := js.searchIndexPath()
+	if searchIndexCache.index != nil && searchIndexCache.path == path {
+		return searchIndexCache.index, nil
+	}
+
+	flushSearchIndexLocked()
+	index, err := js.loadSearchIndex()
+	if err != nil {
+		return nil, err
+	}
+	searchIndexCache.js, searchIndexCache.dataDir, searchIndexCache.path, searchIndexCache.index = js, js.dataDir(), path, index
+	return index, nil
+}
+
+// markSearchIndexDirty schedules a write of the cached index. The caller must
+// hold searchIndexMu.
+func markSearchIndexDirty() {
+	searchIndexCache.dirty = true
+	if searchIndexCache.timer == nil {
+		searchIndexCache.timer = time.AfterFunc(searchIndexFlushDelay, flushSearchIndex)
+	}
+}
+
+// flushSearchIndex writes the cached index out if it has unsaved changes
+func flushSearchIndex() {
+	searchIndexMu.Lock()
+	defer searchIndexMu.Unlock()
+	flushSearchIndexLocked()
+}
+
+func flushSearchIndexLocked() {
+	if searchIndexCache.timer != nil {
+		searchIndexCache.timer.Stop()
+		searchIndexCache.timer = nil
+	}
+	if !searchIndexCache.dirty {
+		return
+	}
+	searchIndexCache.dirty = false
+
+	// Never recreate a data directory that has since been removed
+	js := searchIndexCache.js
+	if _, err := os.Stat(searchIndexCache.dataDir); err != nil {
+		return
+	}
+	if err := js.writeSearchIndex(searchIndexCache.path, searchIndexCache.index); err != nil {
+		log.Printf("Failed to save search index: %v", err)
+	}
+}
+
+// buildSearchIndex indexes every task in storage and saves the index right away
+func (js *JournalService) buildSearchIndex() (*searchIndex, error) {
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	index := &searchIndex{Version: searchIndexVersion, BuiltAt: time.Now(), Tasks: make(map[string]indexedTask), Postings: make(map[string][]string)}
+	for _, task := range tasks {
+		index.put(js, task)
+	}
+
+	if searchIndexCache.path != js.searchIndexPath() {
+		flushSearchIndexLocked()
+	} else if searchIndexCache.timer != nil {
+		searchIndexCache.timer.Stop()
+		searchIndexCache.timer = nil
+	}
+	searchIndexCache.js, searchIndexCache.dataDir, searchIndexCache.path, searchIndexCache.index = js, js.dataDir(), js.searchIndexPath(), index
+	searchIndexCache.dirty = false
+	return index, js.saveSearchIndex(index)
+}
+
+// indexTask updates the task's postings after a save. An index that does not
+// exist yet is left for the next search to build.
+func (js *JournalService) indexTask(task *Task) {
+	searchIndexMu.Lock()
+	defer searchIndexMu.Unlock()
+
+	index, err := js.cachedSearchIndex()
+	if err != nil {
+		return
+	}
+	index.put(js, task)
+	markSearchIndexDirty()
+}
+
+// unindexTask drops the postings of a task whose file was removed
+func (js *JournalService) unindexTask(taskID string) {
+	searchIndexMu.Lock()
+	defer searchIndexMu.Unlock()
+
+	index, err := js.cachedSearchIndex()
+	if err != nil {
+		return
+	}
+	if index.remove(taskID) {
+		markSearchIndexDirty()
+	}
+}
+
+// currentSearchIndex loads the index, rebuilding it when missing or corrupt and
+// reconciling tasks changed outside saveTask (restores, synced copies, trash):
+// tasks added or removed, and tasks whose file changed since they were
+// indexed, which are reloaded and re-indexed when their Updated time differs
+func (js *JournalService) currentSearchIndex() (*searchIndex, error) {
+	searchIndexMu.Lock()
+	defer searchIndexMu.Unlock()
+	return js.currentSearchIndexLocked()
+}
+
+func (js *JournalService) currentSearchIndexLocked() (*searchIndex, error) {
+	index, err := js.cachedSearchIndex()
+	if err != nil {
+		return js.buildSearchIndex()
+	}
+
+	store := js.storage()
+	taskIDs, err := store.ListTaskIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	changed := false
+	present := make(map[string]bool, len(taskIDs))
+	for _, taskID := range taskIDs {
+		present[taskID] = true
+		indexed, ok := index.Tasks[taskID]
+		if ok {
+			modified := js.taskModified(taskID)
+			if modified.IsZero() || modified.Equal(indexed.Modified) {
+				continue
+			}
+		}
+		task, err := store.LoadTask(taskID)
+		if err != nil {
+			continue
+		}
+		if ok && task.Updated.Equal(indexed.Updated) {
+			// Touched but not changed, e.g. rewritten by a sync tool
+			indexed.Modified = js.taskModified(taskID)
+			index.Tasks[taskID] = indexed
+		} else {
+			index.put(js, task)
+		}
+		changed = true
+	}
+	for taskID := range index.Tasks {
+		if !present[taskID] {
+			index.remove(taskID)
+			changed = true
+		}
+	}
+
+	if changed {
+		markSearchIndexDirty()
+	}
+	return index, nil
+}
+
+// candidates returns the IDs of tasks that may contain query as a substring.
+// Every word of the query must appear inside some indexed word of the task,
+// or with fuzzy be within a few typos of one.
+// ok is false when the query has no words, in which case nothing can be ruled out.
+func (index *searchIndex) candidates(query string, fuzzy bool) (ids []string, ok bool) {
+	queryTokens := searchTokens(query)
+	if len(queryTokens) == 0 {
+		return nil, false
+	}
+	if index.vocabulary == nil {
+		index.vocabulary = sortedKeys(index.Postings)
+	}
+
+	var matching map[string]bool
+	for _, queryToken := range queryTokens {
+		found := make(map[string]bool)
+		for _, token := range index.vocabulary {
+			if !strings.Contains(token, queryToken) && !(fuzzy && wordSimilarity(queryToken, token) > 0) {
+				continue
+			}
+			for _, taskID := range index.Postings[token] {
+				if matching == nil || matching[taskID] {
+					found[taskID] = true
+				}
+			}
+		}
+		matching = found
+		if len(matching) == 0 {
+			return nil, true
+		}
+	}
+
+	return sortedKeys(matching), true
+}
+
+// searchCandidateTasks returns the tasks worth scanning for query, using the
+// index when possible and falling back to every task
+func (js *JournalService) searchCandidateTasks(query string, fuzzy bool) ([]*Task, error) {
+	// The cached index changes under saves, so it is only read under the lock
+	searchIndexMu.Lock()
+	var ids []string
+	index, err := js.currentSearchIndexLocked()
+	ok := err == nil
+	if ok {
+		ids, ok = index.candidates(query, fuzzy)
+	}
+	searchIndexMu.Unlock()
+	if !ok {
+		return js.loadAllTasks()
+	}
+
+	store := js.storage()
+	var tasks []*Task
+	for _, taskID := range ids {
+		if task, err := store.LoadTask(taskID); err == nil {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// RebuildSearchIndex rebuilds the full-text search index from storage
+func (js *JournalService) RebuildSearchIndex(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	searchIndexMu.Lock()
+	index, err := js.buildSearchIndex()
+	searchIndexMu.Unlock()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to rebuild search index: %v", err)), nil
+	}
+
+	result := SearchIndexResult{
+		TasksIndexed: len(index.Tasks),
+		Tokens:       len(index.Postings),
+		Path:         js.searchIndexPath(),
+	}
+	result.Summary = fmt.Sprintf("Indexed %d tasks (%d distinct words)", result.TasksIndexed, result.Tokens)
+
+	resultJSON, _ := json.Marshal(result)
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
@@ -0,0 +1,144 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestSearchIndexCandidates(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	createTestTask(t, js, "IDX-1", "Rate limiting", "work")
+	createTestTask(t, js, "IDX-2", "Onboarding docs", "work")
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "IDX-2", "content": "Pair with the new hire on deployments"}))
+
+	index, err := js.currentSearchIndex()
+	if err != nil {
+		t.Fatalf("Failed to build index: %v", err)
+	}
+
+	cases := map[string][]string{
+		"limit":       {"IDX-1"},
+		"ate limi":    {"IDX-1"},
+		"new hire":    {"IDX-2"},
+		"kubernetes":  nil,
+		"task create": {"IDX-1", "IDX-2"},
+	}
+	for query, expected := range cases {
+		ids, _ := index.candidates(query, false)
+		if strings.Join(ids, ",") != strings.Join(expected, ",") {
+			t.Errorf("candidates(%q) = %v, expected %v", query, ids, expected)
+		}
+	}
+
+	// Entries added after the index exists are indexed incrementally
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "IDX-1", "content": "Tried a kubernetes sidecar"}))
+	result, _ := js.SearchEntries(ctx, CreateMockRequest(map[string]interface{}{"query": "kubernetes"}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "Found 1 matching entries") {
+		t.Errorf("Expected the new entry to be found, got:\n%s", text)
+	}
+}
+
+func TestSearchIndexRecovers(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "IDX-3", "Flaky test triage", "work")
+
+	js.RebuildSearchIndex(ctx, CreateMockRequest(map[string]interface{}{}))
+	os.WriteFile(js.searchIndexPath(), []byte("{not json"), 0644)
+
+	result, _ := js.SearchEntries(ctx, CreateMockRequest(map[string]interface{}{"query": "flaky"}))
+	if !strings.Contains(result.Content[0].(mcp.TextContent).Text, "IDX-3") {
+		t.Error("Expected a corrupt index to be rebuilt")
+	}
+
+	// Tasks removed outside saveTask drop out of the index
+	js.storage().DeleteTask("IDX-3")
+	index, _ := js.currentSearchIndex()
+	if _, ok := index.Tasks["IDX-3"]; ok {
+		t.Error("Expected deleted task to be removed from the index")
+	}
+}
+
+func TestSearchIndexPostings(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "IDX-4", "Rotate the staging certificates", "work")
+	createTestTask(t, js, "IDX-5", "Plan the staging cutover", "work")
+	js.RebuildSearchIndex(ctx, CreateMockRequest(map[string]interface{}{}))
+
+	// Saving a task moves only its own postings, written out in a batch later
+	task, _ := js.loadTask("IDX-4")
+	task.Title = "Rotate the production certificates"
+	task.Entries[0].Content = "Task created"
+	js.saveTask(task)
+	if index, _ := js.loadSearchIndex(); len(index.Postings["production"]) != 0 {
+		t.Errorf("Expected the save not to rewrite the index file, got %v", index.Postings["production"])
+	}
+	flushSearchIndex()
+	index, _ := js.loadSearchIndex()
+	if ids := index.Postings["staging"]; strings.Join(ids, ",") != "IDX-5" {
+		t.Errorf("Expected the old token to map to IDX-5 only, got %v", ids)
+	}
+	if ids := index.Postings["production"]; strings.Join(ids, ",") != "IDX-4" {
+		t.Errorf("Expected the new token to map to IDX-4, got %v", ids)
+	}
+
+	// A task file rewritten outside saveTask is re-indexed on the next search
+	task, _ = js.loadTask("IDX-5")
+	task.Title = "Plan the database cutover"
+	task.Entries[0].Content = "Task created"
+	task.Updated = task.Updated.Add(time.Minute)
+	js.storage().SaveTask(task)
+	at := time.Now().Add(time.Second)
+	os.Chtimes(filepath.Join(js.DataDir, "tasks", "IDX-5.json"), at, at)
+
+	result, _ := js.SearchEntries(ctx, CreateMockRequest(map[string]interface{}{"query": "database"}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "IDX-5") {
+		t.Errorf("Expected the rewritten task to be found, got:\n%s", text)
+	}
+	flushSearchIndex()
+	index, _ = js.loadSearchIndex()
+	if _, ok := index.Postings["staging"]; ok {
+		t.Errorf("Expected the stale token dropped, got %v", index.Postings["staging"])
+	}
+}
+
+func TestSearchIndexConcurrentSaves(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	for i := 0; i < 8; i++ {
+		createTestTask(t, js, fmt.Sprintf("IDX-C%d", i), "Concurrent task", "work")
+	}
+	js.RebuildSearchIndex(ctx, CreateMockRequest(map[string]interface{}{}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			task, _ := js.loadTask(fmt.Sprintf("IDX-C%d", i))
+			task.Title = fmt.Sprintf("Concurrent word%d", i)
+			js.saveTask(task)
+			js.searchCandidateTasks("concurrent", false)
+		}(i)
+	}
+	wg.Wait()
+
+	flushSearchIndex()
+	index, _ := js.loadSearchIndex()
+	for i := 0; i < 8; i++ {
+		if ids := index.Postings[fmt.Sprintf("word%d", i)]; len(ids) != 1 {
+			t.Errorf("Expected word%d indexed for one task, got %v", i, ids)
+		}
+	}
+}
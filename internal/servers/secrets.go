@@ -0,0 +1,347 @@
+package servers
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	// secretRefPrefix marks a config value that names a secret instead of holding it
+	secretRefPrefix = "secret:"
+
+	secretEnvPrefix       = "JOURNAL_MCP_SECRET_"
+	secretsPassphraseEnv  = "JOURNAL_MCP_SECRETS_PASSPHRASE"
+	keyringService        = "journal-mcp"
+	secretsFileIterations = 600000
+)
+
+var (
+	errSecretNotFound = errors.New("secret not found")
+	secretNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+)
+
+// SecretProvider stores integration credentials outside config.yaml
+type SecretProvider interface {
+	Name() string
+	Get(name string) (string, error)
+	Set(name, value string) error
+	List() ([]string, error)
+}
+
+// envSecrets reads JOURNAL_MCP_SECRET_<NAME> variables; it is read-only
+type envSecrets struct{}
+
+func secretEnvName(name string) string {
+	return secretEnvPrefix + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
+
+func (envSecrets) Name() string { return "env" }
+
+func (envSecrets) Get(name string) (string, error) {
+	if value := os.Getenv(secretEnvName(name)); value != "" {
+		return value, nil
+	}
+	return "", errSecretNotFound
+}
+
+func (envSecrets) Set(name, value string) error {
+	return fmt.Errorf("the env secret provider is read-only; export %s instead", secretEnvName(name))
+}
+
+func (envSecrets) List() ([]string, error) {
+	var names []string
+	for _, env := range os.Environ() {
+		key, value, _ := strings.Cut(env, "=")
+		if strings.HasPrefix(key, secretEnvPrefix) && value != "" {
+			names = append(names, strings.ToLower(strings.TrimPrefix(key, secretEnvPrefix)))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// keyringSecrets keeps values in the OS keyring (Keychain, Secret Service,
+// Credential Manager). Keyrings cannot be enumerated portably, so the names
+// are tracked in secret-names.json in the data directory.
+type keyringSecrets struct {
+	indexPath string
+}
+
+func (ks keyringSecrets) Name() string { return "keyring" }
+
+func (ks keyringSecrets) Get(name string) (string, error) {
+	value, err := keyring.Get(keyringService, name)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", errSecretNotFound
+	}
+	return value, err
+}
+
+func (ks keyringSecrets) Set(name, value string) error {
+	if err := keyring.Set(keyringService, name, value); err != nil {
+		return err
+	}
+
+	names, _ := ks.List()
+	for _, existing := range names {
+		if existing == name {
+			return nil
+		}
+	}
+	names = append(names, name)
+	sort.Strings(names)
+
+	data, err := json.MarshalIndent(names, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(ks.indexPath, data, 0644)
+}
+
+func (ks keyringSecrets) List() ([]string, error) {
+	data, err := os.ReadFile(ks.indexPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// fileSecrets keeps all secrets in secrets.enc, encrypted with AES-256-GCM
+// under a key derived from JOURNAL_MCP_SECRETS_PASSPHRASE
+type fileSecrets struct {
+	path       string
+	passphrase string
+}
+
+type encryptedSecrets struct {
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func (fs fileSecrets) Name() string { return "file" }
+
+func (fs fileSecrets) Get(name string) (string, error) {
+	secrets, err := fs.load()
+	if err != nil {
+		return "", err
+	}
+	value, ok := secrets[name]
+	if !ok {
+		return "", errSecretNotFound
+	}
+	return value, nil
+}
+
+func (fs fileSecrets) Set(name, value string) error {
+	secrets, err := fs.load()
+	if err != nil {
+		return err
+	}
+	secrets[name] = value
+	return fs.save(secrets)
+}
+
+func (fs fileSecrets) List() ([]string, error) {
+	secrets, err := fs.load()
+	if err != nil {
+		return nil, err
+	}
+	return sortedKeys(secrets), nil
+}
+
+func (fs fileSecrets) load() (map[string]string, error) {
+	secrets := make(map[string]string)
+	if fs.passphrase == "" {
+		return nil, fmt.Errorf("%s must be set to use the file secret provider", secretsPassphraseEnv)
+	}
+
+	data, err := os.ReadFile(fs.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return secrets, nil
+		}
+		return nil, err
+	}
+
+	var sealed encryptedSecrets
+	if err := json.Unmarshal(data, &sealed); err != nil {
+		return nil, fmt.Errorf("corrupt secrets file: %v", err)
+	}
+
+	gcm, err := fs.cipher(sealed.Salt)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, sealed.Nonce, sealed.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secrets file (wrong passphrase?)")
+	}
+
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("corrupt secrets file: %v", err)
+	}
+	return secrets, nil
+}
+
+func (fs fileSecrets) save(secrets map[string]string) error {
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+
+	sealed := encryptedSecrets{Salt: make([]byte, 16)}
+	if _, err := rand.Read(sealed.Salt); err != nil {
+		return err
+	}
+	gcm, err := fs.cipher(sealed.Salt)
+	if err != nil {
+		return err
+	}
+	sealed.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(sealed.Nonce); err != nil {
+		return err
+	}
+	sealed.Ciphertext = gcm.Seal(nil, sealed.Nonce, plaintext, nil)
+
+	data, err := json.MarshalIndent(sealed, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fs.path, data, 0600)
+}
+
+func (fs fileSecrets) cipher(salt []byte) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, fs.passphrase, salt, secretsFileIterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// secretProvider returns the provider configured in secrets.provider (default: keyring)
+func (js *JournalService) secretProvider() (SecretProvider, error) {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return nil, err
+	}
+
+	switch config.Secrets.Provider {
+	case "", "keyring":
+		return keyringSecrets{indexPath: filepath.Join(js.dataDir(), "secret-names.json")}, nil
+	case "file":
+		return fileSecrets{path: filepath.Join(js.dataDir(), "secrets.enc"), passphrase: os.Getenv(secretsPassphraseEnv)}, nil
+	case "env":
+		return envSecrets{}, nil
+	default:
+		return nil, fmt.Errorf("unknown secret provider: %s", config.Secrets.Provider)
+	}
+}
+
+// getSecret looks a secret up in the configured provider, falling back to
+// JOURNAL_MCP_SECRET_<NAME> so containers can inject credentials
+func (js *JournalService) getSecret(name string) (string, error) {
+	if provider, err := js.secretProvider(); err == nil {
+		if value, err := provider.Get(name); err == nil {
+			return value, nil
+		}
+	}
+	return envSecrets{}.Get(name)
+}
+
+// resolveSecretRef returns the secret named by a secret: reference, or value
+// itself when it is not a reference
+func (js *JournalService) resolveSecretRef(value string) (string, error) {
+	name, ok := strings.CutPrefix(value, secretRefPrefix)
+	if !ok {
+		return value, nil
+	}
+	return js.getSecret(name)
+}
+
+func validateSecretName(name string) error {
+	if !secretNamePattern.MatchString(name) {
+		return fmt.Errorf("Invalid secret name %q. Use lowercase letters, digits, '.', '-' and '_'", name)
+	}
+	return nil
+}
+
+// SetSecret stores a credential with the configured secret provider
+func (js *JournalService) SetSecret(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError("name is required"), nil
+	}
+	if err := validateSecretName(name); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	value, err := request.RequireString("value")
+	if err != nil || value == "" {
+		return mcp.NewToolResultError("value is required"), nil
+	}
+
+	provider, err := js.secretProvider()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := provider.Set(name, value); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to store secret: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Stored secret %s in the %s provider. Reference it in config.yaml as %s%s",
+		name, provider.Name(), secretRefPrefix, name)), nil
+}
+
+// ListSecretNames lists stored secret names without revealing values
+func (js *JournalService) ListSecretNames(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	provider, err := js.secretProvider()
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	names, err := provider.List()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list secrets: %v", err)), nil
+	}
+	if names == nil {
+		names = []string{}
+	}
+	envNames, _ := envSecrets{}.List()
+
+	result := map[string]interface{}{
+		"provider":  provider.Name(),
+		"names":     names,
+		"env_names": envNames,
+	}
+
+	resultJSON, _ := json.Marshal(result)
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
@@ -0,0 +1,103 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/zalando/go-keyring"
+)
+
+func useSecretProvider(t *testing.T, js *JournalService, provider string) {
+	t.Helper()
+
+	config := defaultConfiguration()
+	config.Secrets.Provider = provider
+	if err := js.saveConfiguration(config); err != nil {
+		t.Fatalf("Failed to save config: %v", err)
+	}
+}
+
+func TestFileSecrets(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	useSecretProvider(t, js, "file")
+	t.Setenv(secretsPassphraseEnv, "correct horse battery staple")
+	ctx := context.Background()
+
+	result, _ := js.SetSecret(ctx, CreateMockRequest(map[string]interface{}{"name": "github_token", "value": "ghp_example"}))
+	if result.IsError {
+		t.Fatalf("Unexpected error: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+
+	data, err := os.ReadFile(filepath.Join(js.DataDir, "secrets.enc"))
+	if err != nil {
+		t.Fatalf("Expected secrets file: %v", err)
+	}
+	if strings.Contains(string(data), "ghp_example") {
+		t.Error("Expected secret to be encrypted at rest")
+	}
+
+	if value, err := js.getSecret("github_token"); err != nil || value != "ghp_example" {
+		t.Errorf("Expected stored secret, got %q (err: %v)", value, err)
+	}
+
+	result, _ = js.ListSecretNames(ctx, CreateMockRequest(map[string]interface{}{}))
+	text := result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "github_token") || strings.Contains(text, "ghp_example") {
+		t.Errorf("Expected names without values, got %s", text)
+	}
+
+	t.Setenv(secretsPassphraseEnv, "wrong passphrase")
+	if _, err := js.getSecret("github_token"); err == nil {
+		t.Error("Expected wrong passphrase to fail")
+	}
+}
+
+func TestKeyringSecretsAndConfigToken(t *testing.T) {
+	keyring.MockInit()
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	config := defaultConfiguration()
+	config.GitHub.Token = "ghp_from_config"
+	configJSON, _ := json.Marshal(config)
+
+	result, _ := js.UpdateConfiguration(ctx, CreateMockRequest(map[string]interface{}{"config": string(configJSON)}))
+	if result.IsError {
+		t.Fatalf("Unexpected error: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(js.DataDir, "config.yaml"))
+	if strings.Contains(string(data), "ghp_from_config") {
+		t.Error("Expected raw token to be kept out of config.yaml")
+	}
+	if !strings.Contains(string(data), "secret:github_token") {
+		t.Errorf("Expected secret reference in config.yaml, got %s", data)
+	}
+
+	if service, err := js.githubService(CreateMockRequest(map[string]interface{}{})); err != nil || service.token != "ghp_from_config" {
+		t.Errorf("Expected GitHub token from keyring (err: %v)", err)
+	}
+	if service, _ := js.githubService(CreateMockRequest(map[string]interface{}{"github_token": "override"})); service.token != "override" {
+		t.Errorf("Expected explicit token to win, got %q", service.token)
+	}
+}
+
+func TestEnvSecrets(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	useSecretProvider(t, js, "env")
+	t.Setenv("JOURNAL_MCP_SECRET_SLACK_WEBHOOK", "https://hooks.example.com/x")
+
+	if value, err := js.getSecret("slack_webhook"); err != nil || value != "https://hooks.example.com/x" {
+		t.Errorf("Expected env secret, got %q (err: %v)", value, err)
+	}
+
+	result, _ := js.SetSecret(context.Background(), CreateMockRequest(map[string]interface{}{"name": "slack_webhook", "value": "x"}))
+	if !result.IsError {
+		t.Error("Expected env provider to be read-only")
+	}
+}
@@ -0,0 +1,278 @@
+package servers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+)
+
+const (
+	releaseOwner       = "cpuchip"
+	releaseRepo        = "journal-mcp"
+	checksumAssetName  = "checksums.txt"
+	signatureAssetName = checksumAssetName + ".minisig"
+)
+
+// ReleasePublicKey is the minisign public key that signs each release's
+// checksums.txt. Release builds set it with
+// -ldflags "-X github.com/cpuchip/journal-mcp/internal/servers.ReleasePublicKey=RW...";
+// builds without it can only check downloads against the release's own checksums.
+var ReleasePublicKey = ""
+
+// SelfUpdateResult represents the result of a self-update run
+type SelfUpdateResult struct {
+	CurrentVersion string `json:"current_version"`
+	LatestVersion  string `json:"latest_version"`
+	AssetName      string `json:"asset_name,omitempty"`
+	Updated        bool   `json:"updated"`
+	Authenticated  bool   `json:"authenticated"` // checksums.txt carried a valid release signature
+	Summary        string `json:"summary"`
+}
+
+// SelfUpdate checks the latest GitHub release and, unless checkOnly is set,
+// replaces the running binary after verifying its SHA-256 checksum. The
+// checksums are authenticated against ReleasePublicKey when the binary has one;
+// otherwise they only prove the download is intact, not who published it.
+func SelfUpdate(ctx context.Context, checkOnly bool) (*SelfUpdateResult, error) {
+	client := github.NewClient(nil)
+
+	release, _, err := client.Repositories.GetLatestRelease(ctx, releaseOwner, releaseRepo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest release: %w", err)
+	}
+
+	result := &SelfUpdateResult{
+		CurrentVersion: Version,
+		LatestVersion:  release.GetTagName(),
+	}
+
+	if compareVersions(result.LatestVersion, result.CurrentVersion) <= 0 {
+		result.Summary = fmt.Sprintf("journal-mcp %s is already up to date", result.CurrentVersion)
+		return result, nil
+	}
+
+	if checkOnly {
+		result.Summary = fmt.Sprintf("journal-mcp %s is available (current: %s)", result.LatestVersion, result.CurrentVersion)
+		return result, nil
+	}
+
+	assetName := releaseAssetName(runtime.GOOS, runtime.GOARCH)
+	result.AssetName = assetName
+
+	var binaryURL, checksumURL, signatureURL string
+	for _, asset := range release.Assets {
+		switch asset.GetName() {
+		case assetName:
+			binaryURL = asset.GetBrowserDownloadURL()
+		case checksumAssetName:
+			checksumURL = asset.GetBrowserDownloadURL()
+		case signatureAssetName:
+			signatureURL = asset.GetBrowserDownloadURL()
+		}
+	}
+
+	if binaryURL == "" {
+		return nil, fmt.Errorf("release %s has no asset for %s/%s", result.LatestVersion, runtime.GOOS, runtime.GOARCH)
+	}
+	if checksumURL == "" {
+		return nil, fmt.Errorf("release %s has no %s, refusing to install an unverified binary", result.LatestVersion, checksumAssetName)
+	}
+
+	checksums, err := downloadReleaseFile(ctx, checksumURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download checksums: %w", err)
+	}
+
+	if ReleasePublicKey != "" {
+		if signatureURL == "" {
+			return nil, fmt.Errorf("release %s has no %s, refusing to install an unsigned binary", result.LatestVersion, signatureAssetName)
+		}
+		signature, err := downloadReleaseFile(ctx, signatureURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download checksum signature: %w", err)
+		}
+		if err := verifyMinisign(ReleasePublicKey, checksums, signature); err != nil {
+			return nil, fmt.Errorf("%s of release %s is not signed by the release key: %w", checksumAssetName, result.LatestVersion, err)
+		}
+		result.Authenticated = true
+	}
+
+	expected, err := findChecksum(checksums, assetName)
+	if err != nil {
+		return nil, err
+	}
+
+	binary, err := downloadReleaseFile(ctx, binaryURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", assetName, err)
+	}
+
+	sum := sha256.Sum256(binary)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return nil, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", assetName, expected, actual)
+	}
+
+	if err := replaceExecutable(binary); err != nil {
+		return nil, fmt.Errorf("failed to replace binary: %w", err)
+	}
+
+	result.Updated = true
+	result.Summary = fmt.Sprintf("Updated journal-mcp from %s to %s", result.CurrentVersion, result.LatestVersion)
+	if !result.Authenticated {
+		result.Summary += fmt.Sprintf(". This build has no release signing key, so the download was only checked against the release's %s, not authenticated", checksumAssetName)
+	}
+	return result, nil
+}
+
+// verifyMinisign checks a minisign signature of message made with the legacy
+// (non-prehashed) Ed25519 algorithm, including the signed trusted comment
+func verifyMinisign(publicKey string, message, signature []byte) error {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil || len(key) != 2+8+ed25519.PublicKeySize || string(key[:2]) != "Ed" {
+		return errors.New("invalid minisign public key")
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(signature), "\r\n", "\n"), "\n")
+	if len(lines) < 4 || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return errors.New("malformed signature file")
+	}
+	sig, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(sig) != 2+8+ed25519.SignatureSize {
+		return errors.New("malformed signature")
+	}
+	switch {
+	case string(sig[:2]) == "ED":
+		return errors.New("prehashed signatures are not supported; sign with minisign -l")
+	case string(sig[:2]) != "Ed":
+		return fmt.Errorf("unknown signature algorithm %q", sig[:2])
+	case !bytes.Equal(sig[2:10], key[2:10]):
+		return errors.New("signed with a different key")
+	}
+
+	verifier := ed25519.PublicKey(key[10:])
+	if !ed25519.Verify(verifier, message, sig[10:]) {
+		return errors.New("signature does not match")
+	}
+	globalSig, err := base64.StdEncoding.DecodeString(lines[3])
+	trustedComment := strings.TrimPrefix(lines[2], "trusted comment: ")
+	if err != nil || !ed25519.Verify(verifier, append(slices.Clone(sig[10:]), trustedComment...), globalSig) {
+		return errors.New("trusted comment signature does not match")
+	}
+	return nil
+}
+
+// releaseAssetName mirrors the artifact names produced by the CI build matrix
+func releaseAssetName(goos, goarch string) string {
+	name := fmt.Sprintf("journal-mcp-%s-%s", goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+func downloadReleaseFile(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// findChecksum looks up an asset in sha256sum-formatted output
+func findChecksum(checksums []byte, assetName string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if strings.TrimPrefix(fields[1], "*") == assetName {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("no checksum listed for %s", assetName)
+}
+
+func replaceExecutable(binary []byte) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+
+	tempPath := executable + ".new"
+	if err := os.WriteFile(tempPath, binary, 0755); err != nil {
+		return err
+	}
+
+	// Windows cannot overwrite a running executable, but it can rename it
+	oldPath := executable + ".old"
+	os.Remove(oldPath)
+	if err := os.Rename(executable, oldPath); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+
+	if err := os.Rename(tempPath, executable); err != nil {
+		os.Rename(oldPath, executable)
+		return err
+	}
+
+	if runtime.GOOS != "windows" {
+		os.Remove(oldPath)
+	}
+	return nil
+}
+
+// compareVersions compares dotted versions like v1.2.3, returning -1, 0 or 1
+func compareVersions(a, b string) int {
+	partsA := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	partsB := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var numA, numB int
+		if i < len(partsA) {
+			numA, _ = strconv.Atoi(strings.SplitN(partsA[i], "-", 2)[0])
+		}
+		if i < len(partsB) {
+			numB, _ = strconv.Atoi(strings.SplitN(partsB[i], "-", 2)[0])
+		}
+		if numA != numB {
+			if numA < numB {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,103 @@
+package servers
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"v1.2.0", "1.1.9", 1},
+		{"v1.0.0", "1.0.0", 0},
+		{"1.0", "v1.0.1", -1},
+		{"v2.0.0-rc1", "v1.9.9", 1},
+	}
+
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.expected {
+			t.Errorf("compareVersions(%s, %s) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestFindChecksum(t *testing.T) {
+	checksums := []byte("abc123  journal-mcp-linux-amd64\nDEF456 *journal-mcp-windows-amd64.exe\n")
+
+	sum, err := findChecksum(checksums, releaseAssetName("windows", "amd64"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sum != "def456" {
+		t.Errorf("Expected checksum def456, got %s", sum)
+	}
+
+	if _, err := findChecksum(checksums, releaseAssetName("darwin", "arm64")); err == nil {
+		t.Error("Expected error for missing asset checksum")
+	}
+}
+
+func TestGetVersion(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+
+	result, err := js.GetVersion(context.Background(), CreateMockRequest(map[string]interface{}{}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var info BuildInfo
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &info); err != nil {
+		t.Fatalf("Failed to parse build info: %v", err)
+	}
+	if info.Version != Version {
+		t.Errorf("Expected version %s, got %s", Version, info.Version)
+	}
+	if info.GoVersion == "" {
+		t.Error("Expected Go version to be set")
+	}
+}
+
+func TestVerifyMinisign(t *testing.T) {
+	public, private, _ := ed25519.GenerateKey(nil)
+	keyID := []byte("12345678")
+	publicKey := base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), public...))
+
+	sign := func(message []byte, trustedComment string) []byte {
+		sig := ed25519.Sign(private, message)
+		global := ed25519.Sign(private, append(slices.Clone(sig), trustedComment...))
+		return []byte(fmt.Sprintf("untrusted comment: signature from minisign secret key\n%s\ntrusted comment: %s\n%s\n",
+			base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), sig...)), trustedComment,
+			base64.StdEncoding.EncodeToString(global)))
+	}
+
+	checksums := []byte("abc123  journal-mcp-linux-amd64\n")
+	signature := sign(checksums, "journal-mcp v1.2.3")
+	if err := verifyMinisign(publicKey, checksums, signature); err != nil {
+		t.Fatalf("Expected a valid signature, got %v", err)
+	}
+
+	if err := verifyMinisign(publicKey, []byte("evil123  journal-mcp-linux-amd64\n"), signature); err == nil {
+		t.Error("Expected tampered checksums to fail")
+	}
+
+	tampered := strings.Replace(string(signature), "v1.2.3", "v9.9.9", 1)
+	if err := verifyMinisign(publicKey, checksums, []byte(tampered)); err == nil {
+		t.Error("Expected a tampered trusted comment to fail")
+	}
+
+	otherPublic, _, _ := ed25519.GenerateKey(nil)
+	otherKey := base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), otherPublic...))
+	if err := verifyMinisign(otherKey, checksums, signature); err == nil {
+		t.Error("Expected a signature by another key to fail")
+	}
+}
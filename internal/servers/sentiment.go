@@ -0,0 +1,150 @@
+package servers
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ToneMetrics aggregates entry sentiment. Scores range from -1 (negative) to 1 (positive).
+type ToneMetrics struct {
+	AverageScore      float64            `json:"average_score"`
+	EntriesScored     int                `json:"entries_scored"`
+	ByWeek            map[string]float64 `json:"by_week"` // ISO week, e.g. 2026-W09
+	ByType            map[string]float64 `json:"by_type"`
+	NegativeStretches []string           `json:"negative_stretches,omitempty"`
+}
+
+// sentimentLexicon is a small offline word list tuned for work journal language
+var sentimentLexicon = map[string]float64{
+	"great": 2, "excellent": 2, "awesome": 2, "love": 2, "thrilled": 2, "shipped": 2, "solved": 2,
+	"good": 1, "nice": 1, "happy": 1, "progress": 1, "fixed": 1, "done": 1, "finished": 1, "works": 1,
+	"working": 1, "clean": 1, "easy": 1, "smooth": 1, "productive": 1, "learned": 1, "success": 1,
+	"successful": 1, "win": 1, "improved": 1, "resolved": 1, "helpful": 1, "glad": 1, "excited": 1,
+	"bad": -1, "slow": -1, "stuck": -1, "blocked": -1, "confusing": -1, "confused": -1, "hard": -1,
+	"tired": -1, "annoying": -1, "annoyed": -1, "problem": -1, "issue": -1, "bug": -1, "broken": -1,
+	"failed": -1, "failing": -1, "error": -1, "delay": -1, "delayed": -1, "worried": -1, "struggling": -1,
+	"struggle": -1, "messy": -1, "flaky": -1, "regression": -1, "waiting": -1,
+	"terrible": -2, "awful": -2, "hate": -2, "frustrated": -2, "frustrating": -2, "exhausted": -2,
+	"burnout": -2, "disaster": -2, "angry": -2, "miserable": -2, "stressed": -2, "overwhelmed": -2,
+}
+
+var sentimentNegations = map[string]bool{"not": true, "no": true, "never": true, "isn't": true, "wasn't": true, "didn't": true, "don't": true, "can't": true}
+
+// sentimentScore scores text from -1 to 1, flipping a word after a negation.
+// It reports false when no lexicon word was found.
+func sentimentScore(text string) (float64, bool) {
+	total, matched := 0.0, 0
+	negate := false
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		word = strings.Trim(word, ".,;:!?()[]\"")
+		if sentimentNegations[word] {
+			negate = true
+			continue
+		}
+		if score, ok := sentimentLexicon[word]; ok {
+			if negate {
+				score = -score
+			}
+			total += score
+			matched++
+		}
+		negate = false
+	}
+	if matched == 0 {
+		return 0, false
+	}
+	return math.Max(-1, math.Min(1, total/float64(matched)/2)), true
+}
+
+// isWrittenEntry reports whether an entry was written by the user rather than
+// generated by a tool (status changes, syncs, timers)
+func isWrittenEntry(entry Entry) bool {
+	switch entry.Type {
+	case "", "log", "decision", "review":
+		return !strings.HasPrefix(entry.Content, "Task created:")
+	}
+	return false
+}
+
+// isoWeek formats the ISO week of t, e.g. 2026-W09
+func isoWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// calculateToneMetrics scores written entries and flags runs of two or more
+// consecutive weeks with a clearly negative average
+func (js *JournalService) calculateToneMetrics(tasks []*Task) *ToneMetrics {
+	weekTotals, weekCounts := make(map[string]float64), make(map[string]int)
+	typeTotals, typeCounts := make(map[string]float64), make(map[string]int)
+	metrics := &ToneMetrics{ByWeek: make(map[string]float64), ByType: make(map[string]float64)}
+	total := 0.0
+
+	for _, task := range tasks {
+		for _, entry := range task.Entries {
+			if !isWrittenEntry(entry) {
+				continue
+			}
+			score, ok := sentimentScore(entry.Content)
+			if !ok {
+				continue
+			}
+			week := isoWeek(entry.Timestamp)
+			weekTotals[week] += score
+			weekCounts[week]++
+			typeTotals[task.Type] += score
+			typeCounts[task.Type]++
+			total += score
+			metrics.EntriesScored++
+		}
+	}
+	if metrics.EntriesScored == 0 {
+		return nil
+	}
+
+	round := func(v float64) float64 { return math.Round(v*100) / 100 }
+	metrics.AverageScore = round(total / float64(metrics.EntriesScored))
+	for week, sum := range weekTotals {
+		metrics.ByWeek[week] = round(sum / float64(weekCounts[week]))
+	}
+	for taskType, sum := range typeTotals {
+		metrics.ByType[taskType] = round(sum / float64(typeCounts[taskType]))
+	}
+
+	// Weeks without scored entries break a stretch
+	weeks := sortedKeys(metrics.ByWeek)
+	var stretch []string
+	flush := func() {
+		if len(stretch) >= 2 {
+			metrics.NegativeStretches = append(metrics.NegativeStretches, fmt.Sprintf("%s to %s", stretch[0], stretch[len(stretch)-1]))
+		}
+		stretch = nil
+	}
+	for i, week := range weeks {
+		if i > 0 && !consecutiveWeeks(weeks[i-1], week) {
+			flush()
+		}
+		if metrics.ByWeek[week] <= -0.2 {
+			stretch = append(stretch, week)
+		} else {
+			flush()
+		}
+	}
+	flush()
+
+	return metrics
+}
+
+// consecutiveWeeks reports whether ISO week b directly follows week a
+func consecutiveWeeks(a, b string) bool {
+	var year, week int
+	if _, err := fmt.Sscanf(a, "%d-W%d", &year, &week); err != nil {
+		return false
+	}
+	// January 4th always falls in ISO week 1
+	jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, time.UTC)
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday())+6)%7)+(week-1)*7)
+	return isoWeek(monday.AddDate(0, 0, 7)) == b
+}
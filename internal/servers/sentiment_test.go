@@ -0,0 +1,54 @@
+package servers
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSentimentScore(t *testing.T) {
+	if score, ok := sentimentScore("Great progress, the fix works!"); !ok || score <= 0 {
+		t.Errorf("Expected positive score, got %v %v", score, ok)
+	}
+	if score, ok := sentimentScore("Stuck again and frustrated by flaky tests."); !ok || score >= 0 {
+		t.Errorf("Expected negative score, got %v %v", score, ok)
+	}
+	if score, _ := sentimentScore("This is not good"); score >= 0 {
+		t.Errorf("Expected negation to flip the score, got %v", score)
+	}
+	if _, ok := sentimentScore("Met with the platform team"); ok {
+		t.Error("Expected no score for neutral text")
+	}
+}
+
+func TestToneMetricsFlagNegativeStretch(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	monday := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	task := &Task{ID: "TONE-1", Type: "work", Entries: []Entry{
+		{Timestamp: monday, Content: "Stuck on the migration, frustrated", Type: "log"},
+		{Timestamp: monday.AddDate(0, 0, 7), Content: "Still blocked, exhausted", Type: "log"},
+		{Timestamp: monday.AddDate(0, 0, 8), Content: "Status changed from active to blocked", Type: "status_change"},
+		{Timestamp: monday.AddDate(0, 0, 21), Content: "Shipped it, great week", Type: "log"},
+	}}
+
+	tone := js.calculateToneMetrics([]*Task{task})
+	if tone == nil || tone.EntriesScored != 3 {
+		t.Fatalf("Expected 3 written entries scored, got %+v", tone)
+	}
+	if len(tone.NegativeStretches) != 1 || tone.NegativeStretches[0] != "2026-W10 to 2026-W11" {
+		t.Errorf("Expected one negative stretch, got %v", tone.NegativeStretches)
+	}
+	if tone.ByWeek["2026-W13"] <= 0 {
+		t.Errorf("Expected a positive later week, got %v", tone.ByWeek)
+	}
+
+	report := js.generateAnalyticsReport([]*Task{task}, "overview", "all")
+	found := false
+	for _, insight := range report.Insights {
+		found = found || strings.Contains(insight, "2026-W10 to 2026-W11")
+	}
+	if !found {
+		t.Errorf("Expected negative stretch in insights, got %v", report.Insights)
+	}
+}
@@ -3,7 +3,11 @@ package servers
 import (
 	"context"
 	"encoding/json"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 )
@@ -460,3 +464,118 @@ func createTestTask(t *testing.T, js *JournalService, id, title, taskType string
 		t.Fatalf("Failed to create test task: %v", err)
 	}
 }
+
+func TestProfiles(t *testing.T) {
+	js, rootDir := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	createTestTask(t, js, "default-task", "Default profile task", "work")
+
+	// Switching to a missing profile without create fails
+	result, _ := js.UseProfile(ctx, CreateMockRequest(map[string]interface{}{"name": "personal"}))
+	if !result.IsError {
+		t.Fatal("Expected error when switching to a missing profile")
+	}
+
+	result, _ = js.UseProfile(ctx, CreateMockRequest(map[string]interface{}{"name": "../escape", "create": "true"}))
+	if !result.IsError {
+		t.Fatal("Expected error for invalid profile name")
+	}
+
+	result, _ = js.UseProfile(ctx, CreateMockRequest(map[string]interface{}{"name": "personal", "create": "true"}))
+	if result.IsError {
+		t.Fatalf("Failed to create profile: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+
+	if js.DataDir != filepath.Join(rootDir, "profiles", "personal") {
+		t.Errorf("Expected data dir inside profiles/, got %s", js.DataDir)
+	}
+	if _, err := js.loadTask("default-task"); err == nil {
+		t.Error("Expected default profile task to be invisible from the personal profile")
+	}
+
+	// Footer reflects the active profile
+	handler := js.ProfileFooterMiddleware(js.ListProfiles)
+	result, _ = handler(ctx, CreateMockRequest(map[string]interface{}{}))
+	footer := result.Content[len(result.Content)-1].(mcp.TextContent).Text
+	if footer != "_Profile: personal_" {
+		t.Errorf("Expected profile footer, got %q", footer)
+	}
+
+	var listing struct {
+		ActiveProfile string        `json:"active_profile"`
+		Profiles      []ProfileInfo `json:"profiles"`
+	}
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &listing)
+	if listing.ActiveProfile != "personal" || len(listing.Profiles) != 2 {
+		t.Errorf("Unexpected profile listing: %+v", listing)
+	}
+
+	js.UseProfile(ctx, CreateMockRequest(map[string]interface{}{"name": "default"}))
+	if _, err := js.loadTask("default-task"); err != nil {
+		t.Errorf("Expected default profile task after switching back: %v", err)
+	}
+}
+
+func TestConcurrentProfileSwitch(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	js.UseProfile(ctx, CreateMockRequest(map[string]interface{}{"name": "personal", "create": "true"}))
+
+	// Tool calls keep reading the data directory while use_profile changes it;
+	// run with -race to check
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			name := []string{"default", "personal"}[i%2]
+			js.UseProfile(ctx, CreateMockRequest(map[string]interface{}{"name": name}))
+		}(i)
+		go func() {
+			defer wg.Done()
+			js.ListTasks(ctx, CreateMockRequest(map[string]interface{}{}))
+		}()
+	}
+	wg.Wait()
+
+	if profile := js.activeProfile(); profile != "default" && profile != "personal" {
+		t.Errorf("Unexpected active profile %q", profile)
+	}
+}
+
+func TestAggregateReport(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	createTestTask(t, js, "work-1", "Work task", "work")
+	js.UseProfile(ctx, CreateMockRequest(map[string]interface{}{"name": "client", "create": "true"}))
+	createTestTask(t, js, "client-1", "Client task", "work")
+	createTestTask(t, js, "client-2", "Client research", "learning")
+
+	result, _ := js.GetAggregateReport(ctx, CreateMockRequest(map[string]interface{}{"time_period": "all"}))
+	if result.IsError {
+		t.Fatalf("Unexpected error: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+
+	var report AggregateReport
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report); err != nil {
+		t.Fatalf("Failed to parse report: %v", err)
+	}
+	if report.Combined.TaskMetrics.TotalTasks != 3 {
+		t.Errorf("Expected 3 combined tasks, got %d", report.Combined.TaskMetrics.TotalTasks)
+	}
+	if report.PerProfile["client"].TaskMetrics.TotalTasks != 2 || report.PerProfile["default"].TaskMetrics.TotalTasks != 1 {
+		t.Errorf("Unexpected per-profile breakdown: %+v", report.PerProfile)
+	}
+	if js.activeProfile() != "client" {
+		t.Errorf("Aggregate report should not change the active profile, got %s", js.activeProfile())
+	}
+
+	today := time.Now().Format("2006-01-02")
+	result, _ = js.GetAggregateLog(ctx, CreateMockRequest(map[string]interface{}{"date_from": today}))
+	text := result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "### [client]") || !strings.Contains(text, "### [default]") {
+		t.Errorf("Expected both profiles in aggregate log, got:\n%s", text)
+	}
+}
@@ -0,0 +1,236 @@
+package servers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// RunSetupWizard interactively creates the data directory and a starter config.yaml,
+// optionally importing an existing journal file
+func RunSetupWizard(in io.Reader, out io.Writer, defaultDataDir string) error {
+	reader := bufio.NewReader(in)
+
+	fmt.Fprintln(out, "Welcome to journal-mcp! Press Enter to accept the default shown in brackets.")
+	fmt.Fprintln(out)
+
+	dataDir := prompt(reader, out, "Data directory", defaultDataDir)
+
+	js := &JournalService{DataDir: dataDir}
+	if _, err := os.Stat(filepath.Join(dataDir, "config.yaml")); err == nil {
+		if !promptYesNo(reader, out, "A config.yaml already exists there. Overwrite it?", false) {
+			fmt.Fprintln(out, "Setup cancelled, existing configuration left untouched.")
+			return nil
+		}
+	}
+
+	if err := ensureDataDirs(dataDir); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	config := defaultConfiguration()
+
+	detected, source := localTimeZone()
+	if source != "" {
+		fmt.Fprintf(out, "Detected time zone %s from %s.\n", detected, source)
+	} else {
+		fmt.Fprintln(out, "Could not detect your time zone.")
+	}
+	for {
+		timezone := prompt(reader, out, "Time zone (IANA name)", detected)
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			fmt.Fprintf(out, "Unknown time zone %q, please try again.\n", timezone)
+			continue
+		}
+		// Dates in reports follow this zone, so have the user check it against their clock
+		now := time.Now().In(location).Format("15:04 on Mon Jan 2")
+		if !promptYesNo(reader, out, fmt.Sprintf("It is %s in %s. Is that your local time?", now, timezone), true) {
+			continue
+		}
+		config.General.TimeZone = timezone
+		break
+	}
+
+	for {
+		taskType := prompt(reader, out, "Default task type (work, learning, personal, investigation)", config.General.DefaultTaskType)
+		config.General.DefaultTaskType = taskType
+		if js.validateConfiguration(config) == nil {
+			break
+		}
+		fmt.Fprintf(out, "Invalid task type %q, please try again.\n", taskType)
+	}
+
+	config.Web.Enabled = promptYesNo(reader, out, "Enable the web interface?", false)
+	if config.Web.Enabled {
+		for {
+			portStr := prompt(reader, out, "Web port", strconv.Itoa(config.Web.Port))
+			port, err := strconv.Atoi(portStr)
+			if err == nil && port >= 1 && port <= 65535 {
+				config.Web.Port = port
+				break
+			}
+			fmt.Fprintf(out, "Invalid port %q, please try again.\n", portStr)
+		}
+	}
+
+	config.GitHub.Username = prompt(reader, out, "GitHub username (optional)", "")
+	token := prompt(reader, out, "GitHub personal access token (optional)", "")
+
+	if err := js.saveConfiguration(config); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	fmt.Fprintf(out, "\nWrote %s\n", filepath.Join(dataDir, "config.yaml"))
+
+	// The token goes to the secret provider so config.yaml never holds it
+	if token != "" {
+		provider, err := js.secretProvider()
+		if err == nil {
+			err = provider.Set("github_token", token)
+		}
+		if err != nil {
+			fmt.Fprintf(out, "Could not store the GitHub token (%v).\nSet %s in your MCP client configuration instead.\n",
+				err, secretEnvName("github_token"))
+		} else {
+			fmt.Fprintf(out, "Stored the GitHub token in the %s secret provider.\n", provider.Name())
+		}
+	}
+
+	if importPath := prompt(reader, out, "Import an existing journal file (txt, md, json, csv; optional)", ""); importPath != "" {
+		summary, err := js.importFile(importPath, config.General.DefaultTaskType)
+		if err != nil {
+			fmt.Fprintf(out, "Import failed: %v\n", err)
+		} else {
+			fmt.Fprintln(out, summary)
+		}
+	}
+
+	if dataDir != defaultDataDir {
+		fmt.Fprintf(out, "\nSet JOURNAL_MCP_DATA_DIR=%s in your MCP client configuration to use this directory.\n", dataDir)
+	}
+	fmt.Fprintln(out, "Setup complete.")
+	return nil
+}
+
+// importFile imports a journal file, detecting the format from its extension
+func (js *JournalService) importFile(path, defaultType string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	formats := map[string]string{
+		".txt":      "txt",
+		".md":       "markdown",
+		".markdown": "markdown",
+		".json":     "json",
+		".csv":      "csv",
+	}
+	format, ok := formats[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return "", fmt.Errorf("unsupported file type: %s", filepath.Ext(path))
+	}
+
+	request := createMCPRequest(map[string]interface{}{
+		"content":      string(content),
+		"format":       format,
+		"default_type": defaultType,
+		"source":       filepath.Base(path),
+	})
+	result, err := js.ImportData(context.Background(), request)
+	if err != nil {
+		return "", err
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+	if result.IsError {
+		return "", fmt.Errorf("%s", text)
+	}
+
+	var importResult ImportResult
+	if err := json.Unmarshal([]byte(text), &importResult); err != nil {
+		return text, nil
+	}
+	return importResult.Summary, nil
+}
+
+func prompt(reader *bufio.Reader, out io.Writer, question, defaultValue string) string {
+	if defaultValue != "" {
+		fmt.Fprintf(out, "%s [%s]: ", question, defaultValue)
+	} else {
+		fmt.Fprintf(out, "%s: ", question)
+	}
+
+	answer, _ := reader.ReadString('\n')
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return defaultValue
+	}
+	return answer
+}
+
+func promptYesNo(reader *bufio.Reader, out io.Writer, question string, defaultValue bool) bool {
+	defaultAnswer := "y/N"
+	if defaultValue {
+		defaultAnswer = "Y/n"
+	}
+
+	answer := strings.ToLower(prompt(reader, out, question+" ("+defaultAnswer+")", ""))
+	switch answer {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	default:
+		return defaultValue
+	}
+}
+
+// localTimeZone guesses the machine's IANA time zone and says where the guess
+// came from, or returns UTC and no source when there is nothing to go on
+func localTimeZone() (name, source string) {
+	return localTimeZoneFrom(os.Getenv("TZ"), "/etc/localtime", "/etc/timezone")
+}
+
+// localTimeZoneFrom checks $TZ, then the zoneinfo file the localtime symlink
+// points at, then the name in the timezone file (Debian and Ubuntu)
+func localTimeZoneFrom(tz, localtime, timezoneFile string) (name, source string) {
+	valid := func(name string) bool {
+		_, err := time.LoadLocation(name)
+		return name != "" && name != "Local" && err == nil
+	}
+
+	// TZ may be a name, ":name" or a path into the zoneinfo database
+	tz = strings.TrimPrefix(tz, ":")
+	if _, zone, ok := strings.Cut(tz, "zoneinfo/"); ok {
+		tz = zone
+	}
+	if valid(tz) {
+		return tz, "$TZ"
+	}
+
+	if target, err := os.Readlink(localtime); err == nil {
+		if _, zone, ok := strings.Cut(filepath.ToSlash(target), "zoneinfo/"); ok && valid(zone) {
+			return zone, localtime
+		}
+	}
+	if data, err := os.ReadFile(timezoneFile); err == nil {
+		if zone := strings.TrimSpace(string(data)); valid(zone) {
+			return zone, timezoneFile
+		}
+	}
+
+	if name := time.Local.String(); valid(name) {
+		return name, "the system settings"
+	}
+	return "UTC", ""
+}
@@ -0,0 +1,91 @@
+package servers
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunSetupWizard(t *testing.T) {
+	tempDir := t.TempDir()
+	dataDir := filepath.Join(tempDir, "journal")
+
+	journalFile := filepath.Join(tempDir, "old-journal.md")
+	os.WriteFile(journalFile, []byte("# Old project\nWrote the first draft\n"), 0644)
+
+	input := strings.Join([]string{
+		dataDir,         // data directory
+		"Not/AZone",     // invalid time zone
+		"Europe/Berlin", // time zone
+		"n",             // not the local time
+		"Europe/Paris",  // time zone
+		"",              // confirmed
+		"chores",        // invalid task type
+		"learning",      // default task type
+		"y",             // enable web
+		"9090",          // web port
+		"octocat",       // GitHub username
+		"",              // GitHub token
+		journalFile,     // import
+	}, "\n") + "\n"
+
+	var out bytes.Buffer
+	if err := RunSetupWizard(strings.NewReader(input), &out, filepath.Join(tempDir, "default")); err != nil {
+		t.Fatalf("Setup wizard failed: %v", err)
+	}
+
+	js := &JournalService{DataDir: dataDir}
+	config, err := js.loadConfiguration()
+	if err != nil {
+		t.Fatalf("Failed to load written config: %v", err)
+	}
+
+	if config.General.TimeZone != "Europe/Paris" {
+		t.Errorf("Expected time zone Europe/Paris, got %s", config.General.TimeZone)
+	}
+	if config.General.DefaultTaskType != "learning" {
+		t.Errorf("Expected default task type learning, got %s", config.General.DefaultTaskType)
+	}
+	if !config.Web.Enabled || config.Web.Port != 9090 {
+		t.Errorf("Expected web enabled on 9090, got %v on %d", config.Web.Enabled, config.Web.Port)
+	}
+	if config.GitHub.Username != "octocat" {
+		t.Errorf("Expected GitHub username octocat, got %s", config.GitHub.Username)
+	}
+
+	tasks, err := js.loadAllTasks()
+	if err != nil || len(tasks) != 1 {
+		t.Errorf("Expected 1 imported task, got %d (err: %v)", len(tasks), err)
+	}
+
+	if !strings.Contains(out.String(), "JOURNAL_MCP_DATA_DIR") {
+		t.Error("Expected hint about JOURNAL_MCP_DATA_DIR for a non-default data directory")
+	}
+}
+
+func TestLocalTimeZoneFrom(t *testing.T) {
+	dir := t.TempDir()
+	localtime := filepath.Join(dir, "localtime")
+	os.Symlink("/usr/share/zoneinfo/America/Chicago", localtime)
+	timezoneFile := filepath.Join(dir, "timezone")
+	os.WriteFile(timezoneFile, []byte("Asia/Tokyo\n"), 0644)
+	missing := filepath.Join(dir, "missing")
+
+	tests := []struct {
+		tz, localtime, timezoneFile string
+		name, source                string
+	}{
+		{"Europe/Paris", localtime, timezoneFile, "Europe/Paris", "$TZ"},
+		{":/usr/share/zoneinfo/Europe/Oslo", localtime, timezoneFile, "Europe/Oslo", "$TZ"},
+		{"Not/AZone", localtime, timezoneFile, "America/Chicago", localtime},
+		{"", missing, timezoneFile, "Asia/Tokyo", timezoneFile},
+	}
+	for _, tt := range tests {
+		name, source := localTimeZoneFrom(tt.tz, tt.localtime, tt.timezoneFile)
+		if name != tt.name || source != tt.source {
+			t.Errorf("localTimeZoneFrom(%q, %q, %q) = %q, %q; expected %q, %q", tt.tz, tt.localtime, tt.timezoneFile, name, source, tt.name, tt.source)
+		}
+	}
+}
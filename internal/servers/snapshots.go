@@ -0,0 +1,164 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const defaultSnapshotTime = "07:00"
+
+// TaskSnapshot records the tasks in progress at the start of a day
+type TaskSnapshot struct {
+	TakenAt time.Time      `json:"taken_at"`
+	Tasks   []SnapshotTask `json:"tasks"`
+}
+
+// SnapshotTask is one active or blocked task in a TaskSnapshot
+type SnapshotTask struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Priority string `json:"priority,omitempty"`
+}
+
+// WIPPoint is one day of work-in-progress history
+type WIPPoint struct {
+	Date    string   `json:"date"`
+	Active  int      `json:"active"`
+	Blocked int      `json:"blocked"`
+	TaskIDs []string `json:"task_ids,omitempty"`
+}
+
+// dailySnapshotJob snapshots active and blocked tasks each morning
+func (js *JournalService) dailySnapshotJob() ScheduledJob {
+	return ScheduledJob{
+		Name: "daily_snapshot",
+		Due: func(now, lastRun time.Time) bool {
+			config, err := js.loadConfiguration()
+			if err != nil {
+				return false
+			}
+			at := config.Schedule.DailySnapshot
+			if at == "off" {
+				return false
+			}
+			if at == "" {
+				at = defaultSnapshotTime
+			}
+			return dueDailyAt(at, js.location(), now, lastRun)
+		},
+		Run: func(ctx context.Context) error {
+			return js.snapshotActiveTasks(time.Now().In(js.location()))
+		},
+	}
+}
+
+// snapshotActiveTasks stores the active and blocked tasks in the daily file for now's date
+func (js *JournalService) snapshotActiveTasks(now time.Time) error {
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return err
+	}
+
+	snapshot := &TaskSnapshot{TakenAt: now, Tasks: []SnapshotTask{}}
+	for _, task := range tasks {
+		if task.Status != "active" && task.Status != "blocked" {
+			continue
+		}
+		snapshot.Tasks = append(snapshot.Tasks, SnapshotTask{
+			ID:       task.ID,
+			Title:    task.Title,
+			Status:   task.Status,
+			Priority: task.Priority,
+		})
+	}
+	sort.Slice(snapshot.Tasks, func(i, j int) bool { return snapshot.Tasks[i].ID < snapshot.Tasks[j].ID })
+
+	date := now.Format("2006-01-02")
+	dailyPath := filepath.Join(js.dataDir(), "daily", date+".json")
+
+	activity := DailyActivity{Date: date}
+	if data, err := js.readDataFile(dailyPath); err == nil {
+		json.Unmarshal(data, &activity)
+	}
+	if activity.Tasks == nil {
+		activity.Tasks = make(map[string][]Entry)
+	}
+	activity.Snapshot = snapshot
+
+	return js.saveDailyActivity(&activity)
+}
+
+// GetWIPHistory returns the daily snapshots of active and blocked tasks for a date range
+func (js *JournalService) GetWIPHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	now := time.Now().In(js.location())
+	dateTo := request.GetString("date_to", now.Format("2006-01-02"))
+	dateFrom := request.GetString("date_from", now.AddDate(0, 0, -29).Format("2006-01-02"))
+
+	if err := js.validateDateFormat(dateFrom, "date_from"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := js.validateDateFormat(dateTo, "date_to"); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	includeTasks := request.GetString("include_tasks", "false") == "true"
+
+	files, err := os.ReadDir(filepath.Join(js.dataDir(), "daily"))
+	if err != nil && !os.IsNotExist(err) {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read daily logs: %v", err)), nil
+	}
+
+	history := []WIPPoint{}
+	for _, file := range files {
+		date := strings.TrimSuffix(file.Name(), ".json")
+		if !strings.HasSuffix(file.Name(), ".json") || date < dateFrom || date > dateTo {
+			continue
+		}
+
+		data, err := js.readDataFile(filepath.Join(js.dataDir(), "daily", file.Name()))
+		if err != nil {
+			continue
+		}
+		var activity DailyActivity
+		if json.Unmarshal(data, &activity) != nil || activity.Snapshot == nil {
+			continue
+		}
+
+		point := WIPPoint{Date: date}
+		for _, task := range activity.Snapshot.Tasks {
+			if task.Status == "blocked" {
+				point.Blocked++
+			} else {
+				point.Active++
+			}
+			if includeTasks {
+				point.TaskIDs = append(point.TaskIDs, task.ID)
+			}
+		}
+		history = append(history, point)
+	}
+
+	sort.Slice(history, func(i, j int) bool { return history[i].Date < history[j].Date })
+
+	resultJSON, _ := json.MarshalIndent(history, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+func writeSnapshotMarkdown(md *strings.Builder, snapshot *TaskSnapshot) {
+	md.WriteString(fmt.Sprintf("## Work in Progress (as of %s)\n", snapshot.TakenAt.Format("15:04")))
+	if len(snapshot.Tasks) == 0 {
+		md.WriteString("No active or blocked tasks.\n")
+		return
+	}
+	for _, task := range snapshot.Tasks {
+		md.WriteString(fmt.Sprintf("- %s: %s (%s)\n", task.ID, task.Title, task.Status))
+	}
+}
@@ -0,0 +1,89 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// somedayReviewCount is how many someday tasks each weekly review resurfaces
+const somedayReviewCount = 3
+
+// somedayReviewJob resurfaces a few someday tasks once a week (schedule.someday_review)
+func (js *JournalService) somedayReviewJob() ScheduledJob {
+	return ScheduledJob{
+		Name: "someday_review",
+		Due: func(now, lastRun time.Time) bool {
+			config, err := js.loadConfiguration()
+			if err != nil || config.Schedule.SomedayReview == "off" {
+				return false
+			}
+			day := time.Monday
+			if config.Schedule.SomedayReview != "" {
+				var ok bool
+				if day, ok = parseWeekday(config.Schedule.SomedayReview); !ok {
+					return false
+				}
+			}
+			return dueWeeklyOn(day, js.location(), now, lastRun)
+		},
+		Run: func(ctx context.Context) error {
+			_, err := js.resurfaceSomedayTasks(time.Now(), somedayReviewCount)
+			return err
+		},
+	}
+}
+
+// resurfaceSomedayTasks picks the count someday tasks that have gone longest
+// without attention, adds a review entry to each and a notification listing
+// them. The review entry counts as activity, so the next review moves on to
+// other ideas.
+func (js *JournalService) resurfaceSomedayTasks(now time.Time, count int) ([]string, error) {
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	var someday []*Task
+	for _, task := range tasks {
+		if task.Status == "someday" {
+			someday = append(someday, task)
+		}
+	}
+	if len(someday) == 0 {
+		return nil, nil
+	}
+
+	sort.SliceStable(someday, func(i, j int) bool {
+		return lastActivity(someday[i]).Before(lastActivity(someday[j]))
+	})
+	if len(someday) > count {
+		someday = someday[:count]
+	}
+
+	var ids, titles []string
+	for _, task := range someday {
+		unlock := js.lockTask(task.ID)
+		entry := Entry{
+			ID:        generateEntryID(),
+			Timestamp: now,
+			Content:   "Resurfaced in the weekly someday review: keep, activate, or drop this idea?",
+			Type:      "someday_review",
+		}
+		task.Entries = append(task.Entries, entry)
+		err := js.saveTask(task)
+		unlock()
+		if err != nil {
+			return ids, err
+		}
+		js.updateDailyLog(task.ID, entry)
+
+		ids = append(ids, task.ID)
+		titles = append(titles, fmt.Sprintf("%s (%s)", task.ID, task.Title))
+	}
+
+	message := "Review these ideas: " + strings.Join(titles, ", ")
+	return ids, js.notify("someday_review", "", message)
+}
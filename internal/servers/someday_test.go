@@ -0,0 +1,68 @@
+package servers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestSomedayTasks(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	createTestTask(t, js, "NOW-1", "Current work", "work")
+	for i, id := range []string{"IDEA-1", "IDEA-2", "IDEA-3", "IDEA-4"} {
+		js.CreateTask(ctx, CreateMockRequest(map[string]interface{}{
+			"id": id, "title": "Idea " + id, "type": "personal", "someday": "true",
+		}))
+		task, _ := js.loadTask(id)
+		task.Updated = time.Now().AddDate(0, 0, -30+i)
+		js.saveTask(task)
+	}
+
+	result, _ := js.ListTasks(ctx, CreateMockRequest(map[string]interface{}{}))
+	if text := result.Content[0].(mcp.TextContent).Text; strings.Contains(text, "IDEA-") || !strings.Contains(text, "NOW-1") {
+		t.Errorf("Expected someday tasks hidden from the default list, got:\n%s", text)
+	}
+	result, _ = js.ListTasks(ctx, CreateMockRequest(map[string]interface{}{"status": "someday"}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "of 4 total") {
+		t.Errorf("Expected someday tasks with status=someday, got:\n%s", text)
+	}
+
+	resurfaced, err := js.resurfaceSomedayTasks(time.Now(), 3)
+	if err != nil {
+		t.Fatalf("resurfaceSomedayTasks failed: %v", err)
+	}
+	if strings.Join(resurfaced, ",") != "IDEA-1,IDEA-2,IDEA-3" {
+		t.Errorf("Expected the three oldest ideas, got %v", resurfaced)
+	}
+
+	// The next review moves on to the idea that was not shown
+	resurfaced, _ = js.resurfaceSomedayTasks(time.Now().Add(time.Hour), 1)
+	if len(resurfaced) != 1 || resurfaced[0] != "IDEA-4" {
+		t.Errorf("Expected IDEA-4 next, got %v", resurfaced)
+	}
+
+	notifications, _ := js.loadNotifications()
+	if len(notifications) != 2 || !strings.HasPrefix(notifications[0].Message, "Review these ideas:") {
+		t.Errorf("Expected review notifications, got %+v", notifications)
+	}
+}
+
+func TestDueWeeklyOn(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	wednesday := monday.AddDate(0, 0, 2)
+
+	if !dueWeeklyOn(time.Monday, time.UTC, monday, time.Time{}) {
+		t.Error("Expected a never-run job to be due")
+	}
+	if dueWeeklyOn(time.Monday, time.UTC, wednesday, monday) {
+		t.Error("Expected no second run in the same week")
+	}
+	if !dueWeeklyOn(time.Monday, time.UTC, wednesday, monday.AddDate(0, 0, -7)) {
+		t.Error("Expected a missed Monday to catch up on Wednesday")
+	}
+}
@@ -0,0 +1,216 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxStandupLookback caps how many working days a standup looks back
+const maxStandupLookback = 10
+
+// standupNoteLength is how much of an entry a standup line quotes
+const standupNoteLength = 120
+
+// Standup is a yesterday / today / blockers summary
+type Standup struct {
+	Date      string        `json:"date"`
+	Since     string        `json:"since"` // first working day Yesterday covers
+	Yesterday []StandupItem `json:"yesterday"`
+	Today     []StandupItem `json:"today"`
+	MoreToday int           `json:"more_today,omitempty"` // active tasks beyond general.focus_limit
+	Blockers  []StandupItem `json:"blockers"`
+}
+
+// StandupItem is one task in a standup section
+type StandupItem struct {
+	TaskID    string   `json:"task_id"`
+	Title     string   `json:"title"`
+	Completed bool     `json:"completed,omitempty"`
+	Notes     []string `json:"notes,omitempty"`
+}
+
+// standupSince returns the start of the working day lookback working days
+// before day; weekends are skipped, so a Monday standup covers Friday
+func standupSince(day time.Time, lookback int) time.Time {
+	since := day
+	for i := 0; i < lookback; i++ {
+		since = previousWorkday(since)
+	}
+	return since
+}
+
+// buildStandup summarises entries in [since, day) as Yesterday, the focus
+// ranked active tasks as Today, and blocked tasks or tasks waiting on open
+// dependencies as Blockers
+func (js *JournalService) buildStandup(tasks []*Task, day, since time.Time, limit int) Standup {
+	standup := Standup{
+		Date:      day.Format("2006-01-02"),
+		Since:     since.Format("2006-01-02"),
+		Yesterday: []StandupItem{},
+		Today:     []StandupItem{},
+		Blockers:  []StandupItem{},
+	}
+	sorted := append([]*Task(nil), tasks...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	for _, task := range sorted {
+		item := StandupItem{TaskID: task.ID, Title: task.Title}
+		for _, entry := range task.Entries {
+			if entry.Timestamp.Before(since) || !entry.Timestamp.Before(day) || systemEntryTypes[entry.Type] || entry.Type == "creation" {
+				continue
+			}
+			if entry.Type == "completion" {
+				item.Completed = true
+			}
+			if note := standupNote(entry.Content); note != "" {
+				item.Notes = append(item.Notes, note)
+			}
+		}
+		if item.Completed || len(item.Notes) > 0 {
+			standup.Yesterday = append(standup.Yesterday, item)
+		}
+	}
+
+	today := standup.Date
+	var active []*Task
+	for _, task := range tasksWithStatus(sorted, "active") {
+		if open := js.openDependencies(task); len(open) > 0 {
+			var ids []string
+			for _, dep := range open {
+				ids = append(ids, dep.ID)
+			}
+			standup.Blockers = append(standup.Blockers, StandupItem{TaskID: task.ID, Title: task.Title,
+				Notes: []string{"waiting on " + strings.Join(ids, ", ")}})
+			continue
+		}
+		active = append(active, task)
+	}
+	active = js.focusTasks(active, day)
+	if len(active) > limit {
+		standup.MoreToday = len(active) - limit
+		active = active[:limit]
+	}
+	for _, task := range active {
+		item := StandupItem{TaskID: task.ID, Title: task.Title}
+		if task.Priority != "" {
+			item.Notes = append(item.Notes, task.Priority+" priority")
+		}
+		if task.DueDate != "" {
+			due := "due " + task.DueDate
+			if isOverdue(task, today) {
+				due += ", overdue"
+			}
+			item.Notes = append(item.Notes, due)
+		}
+		standup.Today = append(standup.Today, item)
+	}
+
+	for _, task := range tasksWithStatus(sorted, "blocked") {
+		item := StandupItem{TaskID: task.ID, Title: task.Title}
+		// The latest written entry usually says what the task is blocked on
+		for i := len(task.Entries) - 1; i >= 0; i-- {
+			entry := task.Entries[i]
+			if !systemEntryTypes[entry.Type] && entry.Type != "creation" {
+				if note := standupNote(entry.Content); note != "" {
+					item.Notes = append(item.Notes, note)
+				}
+				break
+			}
+		}
+		standup.Blockers = append(standup.Blockers, item)
+	}
+	sort.SliceStable(standup.Blockers, func(i, j int) bool { return standup.Blockers[i].TaskID < standup.Blockers[j].TaskID })
+	return standup
+}
+
+// standupNote shortens an entry to its first line
+func standupNote(content string) string {
+	note, _, _ := strings.Cut(strings.TrimSpace(content), "\n")
+	if len(note) > standupNoteLength {
+		note = strings.TrimSpace(note[:standupNoteLength]) + "..."
+	}
+	return note
+}
+
+// formatStandup renders a standup as markdown ready to paste into chat
+func formatStandup(standup Standup) string {
+	var md strings.Builder
+	md.WriteString(fmt.Sprintf("# Standup %s\n\n", standup.Date))
+
+	heading := "Yesterday"
+	if since, err := time.Parse("2006-01-02", standup.Since); err == nil {
+		heading = fmt.Sprintf("Yesterday (since %s)", since.Format("Monday Jan 2"))
+	}
+	writeStandupSection(&md, heading, standup.Yesterday, "Nothing logged.")
+	writeStandupSection(&md, "Today", standup.Today, "No active tasks.")
+	if standup.MoreToday > 0 {
+		md.WriteString(fmt.Sprintf("- and %d more active tasks\n\n", standup.MoreToday))
+	}
+	writeStandupSection(&md, "Blockers", standup.Blockers, "None.")
+	return md.String()
+}
+
+func writeStandupSection(md *strings.Builder, heading string, items []StandupItem, empty string) {
+	md.WriteString(fmt.Sprintf("## %s\n", heading))
+	if len(items) == 0 {
+		md.WriteString(empty + "\n\n")
+		return
+	}
+	for _, item := range items {
+		line := fmt.Sprintf("- %s: %s", item.TaskID, item.Title)
+		if item.Completed {
+			line += " (completed)"
+		}
+		if len(item.Notes) > 0 {
+			line += " - " + strings.Join(item.Notes, "; ")
+		}
+		md.WriteString(line + "\n")
+	}
+	md.WriteString("\n")
+}
+
+// GenerateStandup writes a yesterday / today / blockers summary from the last
+// working days' entries and the active and blocked tasks
+func (js *JournalService) GenerateStandup(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	loc := js.location()
+	date := time.Now().In(loc)
+	if dateStr := request.GetString("date", ""); dateStr != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", dateStr, loc)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid date %q: use YYYY-MM-DD", dateStr)), nil
+		}
+		date = parsed
+	}
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+
+	lookback := 1
+	if config, err := js.loadConfiguration(); err == nil && config.General.StandupLookback > 0 {
+		lookback = config.General.StandupLookback
+	}
+	if value := request.GetString("lookback", ""); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > maxStandupLookback {
+			return mcp.NewToolResultError(fmt.Sprintf("lookback must be between 1 and %d working days", maxStandupLookback)), nil
+		}
+		lookback = n
+	}
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+	standup := js.buildStandup(tasks, day, standupSince(day, lookback), js.focusLimit())
+
+	if request.GetString("format", "markdown") == "json" {
+		resultJSON, _ := json.MarshalIndent(standup, "", "  ")
+		return mcp.NewToolResultText(string(resultJSON)), nil
+	}
+	return mcp.NewToolResultText(formatStandup(standup)), nil
+}
@@ -0,0 +1,81 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestStandupSince(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		day      time.Time
+		lookback int
+		expected string
+	}{
+		{monday, 1, "2026-02-27"},
+		{monday.AddDate(0, 0, 2), 1, "2026-03-03"},
+		{monday.AddDate(0, 0, 1), 2, "2026-02-27"},
+		{monday, 5, "2026-02-23"},
+	}
+	for _, c := range cases {
+		if got := standupSince(c.day, c.lookback).Format("2006-01-02"); got != c.expected {
+			t.Errorf("standupSince(%s, %d) = %s, expected %s", c.day.Format("2006-01-02"), c.lookback, got, c.expected)
+		}
+	}
+}
+
+func TestGenerateStandup(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	createTestTask(t, js, "API-1", "Build API", "work")
+	task, _ := js.loadTask("API-1")
+	task.Priority = "high"
+	task.Entries = append(task.Entries,
+		Entry{ID: "fri", Timestamp: monday.AddDate(0, 0, -3).Add(10 * time.Hour), Content: "Finished pagination\nDetails follow", Type: "log"},
+		Entry{ID: "thu", Timestamp: monday.AddDate(0, 0, -4).Add(10 * time.Hour), Content: "Started pagination", Type: "log"},
+	)
+	js.saveTask(task)
+
+	createTestTask(t, js, "OPS-1", "Rotate keys", "work")
+	blocked, _ := js.loadTask("OPS-1")
+	blocked.Status = "blocked"
+	blocked.Entries = append(blocked.Entries, Entry{ID: "why", Timestamp: monday.AddDate(0, 0, -5), Content: "Waiting for security sign-off", Type: "log"})
+	js.saveTask(blocked)
+
+	createTestTask(t, js, "UI-1", "Build UI", "work")
+	waiting, _ := js.loadTask("UI-1")
+	waiting.DependsOn = []string{"API-1"}
+	js.saveTask(waiting)
+
+	result, _ := js.GenerateStandup(ctx, CreateMockRequest(map[string]interface{}{"date": "2026-03-02"}))
+	text := result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "## Yesterday (since Friday Feb 27)\n- API-1: Build API - Finished pagination\n") || strings.Contains(text, "Started pagination") {
+		t.Errorf("Expected only Friday's entries, got:\n%s", text)
+	}
+	if !strings.Contains(text, "## Today\n- API-1: Build API - high priority\n") {
+		t.Errorf("Expected the active task under Today, got:\n%s", text)
+	}
+	if !strings.Contains(text, "- OPS-1: Rotate keys - Waiting for security sign-off") || !strings.Contains(text, "- UI-1: Build UI - waiting on API-1") {
+		t.Errorf("Expected blocked and waiting tasks under Blockers, got:\n%s", text)
+	}
+
+	result, _ = js.GenerateStandup(ctx, CreateMockRequest(map[string]interface{}{"date": "2026-03-02", "lookback": "2", "format": "json"}))
+	var standup Standup
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &standup)
+	if standup.Since != "2026-02-26" || len(standup.Yesterday) != 1 || len(standup.Yesterday[0].Notes) != 2 {
+		t.Errorf("Expected two working days of entries, got %+v", standup)
+	}
+
+	for _, args := range []map[string]interface{}{{"date": "March 2"}, {"lookback": "0"}, {"lookback": "11"}} {
+		if result, _ := js.GenerateStandup(ctx, CreateMockRequest(args)); !result.IsError {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package servers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Storage persists tasks. JournalService resolves the implementation from the
+// storage section of config.yaml on each call.
+type Storage interface {
+	SaveTask(task *Task) error
+	LoadTask(taskID string) (*Task, error)
+	ListTaskIDs() ([]string, error)
+	DeleteTask(taskID string) error
+}
+
+// fileStorage keeps one JSON file per task under tasks/, encrypted when
+// encryption.at_rest is on
+type fileStorage struct {
+	dir string
+	js  *JournalService
+}
+
+func newFileStorage(js *JournalService) *fileStorage {
+	return &fileStorage{dir: filepath.Join(js.dataDir(), "tasks"), js: js}
+}
+
+func (fs *fileStorage) SaveTask(task *Task) error {
+	data, err := json.MarshalIndent(task, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fs.js.writeDataFile(filepath.Join(fs.dir, task.ID+".json"), data, 0644)
+}
+
+func (fs *fileStorage) LoadTask(taskID string) (*Task, error) {
+	data, err := fs.js.readDataFile(filepath.Join(fs.dir, taskID+".json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var task Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+func (fs *fileStorage) ListTaskIDs() ([]string, error) {
+	files, err := os.ReadDir(fs.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, file := range files {
+		if _, conflicted := conflictCopyTaskID(file.Name()); conflicted {
+			continue // left for resolve_conflicts
+		}
+		if !file.IsDir() && strings.HasSuffix(file.Name(), ".json") {
+			ids = append(ids, strings.TrimSuffix(file.Name(), ".json"))
+		}
+	}
+	return ids, nil
+}
+
+func (fs *fileStorage) DeleteTask(taskID string) error {
+	return removeDataFile(filepath.Join(fs.dir, taskID+".json"))
+}
+
+// storage returns the task storage configured for the active data directory
+func (js *JournalService) storage() Storage {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		// Falling back to local files would split tasks from an s3 or events store
+		return unavailableStorage{err: fmt.Errorf("failed to load config: %w", err)}
+	}
+
+	// The storage format only applies to files mode; events keeps JSON projections
+	store := js.taskFileStorage(config.Storage.Format)
+	switch config.Storage.Mode {
+	case "events":
+		store = newEventStorage(js.dataDir(), newFileStorage(js), config.Storage.SnapshotInterval)
+	case "s3":
+		if s3, err := newS3Storage(config); err != nil {
+			store = unavailableStorage{err: err}
+		} else {
+			store = s3
+		}
+	}
+
+	if config.Storage.SQLiteMirror != "" {
+		store = &mirroredStorage{Storage: store, js: js, path: js.sqliteMirrorPath(config.Storage.SQLiteMirror)}
+	}
+
+	return store
+}
@@ -0,0 +1,232 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strconv"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultStreakWeeks is how many weeks get_streaks reports consistency for
+const defaultStreakWeeks = 8
+
+// systemEntryTypes are entries written by the journal itself, which do not
+// count as journaling for streaks and quotas
+var systemEntryTypes = map[string]bool{
+	"deleted":        true,
+	"moved":          true,
+	"someday_review": true,
+	"merge_conflict": true,
+}
+
+// WeekConsistency is how many days of a week had journal entries
+type WeekConsistency struct {
+	Week string `json:"week"` // Monday of the week
+	Days int    `json:"days"`
+}
+
+// Streaks summarises journaling streaks: consecutive days with at least one entry
+type Streaks struct {
+	Current        int               `json:"current"`
+	Longest        int               `json:"longest"`
+	LongestFrom    string            `json:"longest_from,omitempty"`
+	LongestTo      string            `json:"longest_to,omitempty"`
+	JournaledToday bool              `json:"journaled_today"`
+	LastEntry      string            `json:"last_entry,omitempty"`
+	ActiveDays     int               `json:"active_days"`
+	Weeks          []WeekConsistency `json:"weeks"`
+	Consistency    float64           `json:"consistency"` // share of days journaled over the reported weeks
+	Message        string            `json:"message"`
+}
+
+// journaledDays returns the days (YYYY-MM-DD in loc) with at least one entry
+func journaledDays(tasks []*Task, loc *time.Location) map[string]bool {
+	days := make(map[string]bool)
+	for _, task := range tasks {
+		for _, entry := range task.Entries {
+			if !systemEntryTypes[entry.Type] {
+				days[entry.Timestamp.In(loc).Format("2006-01-02")] = true
+			}
+		}
+	}
+	return days
+}
+
+// computeStreaks works out streaks as of now. A streak still counts as current
+// until the end of the day after its last entry, so it is not broken by a
+// morning without entries yet.
+func computeStreaks(days map[string]bool, now time.Time, loc *time.Location, weeks int) Streaks {
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	day := func(t time.Time) string { return t.Format("2006-01-02") }
+
+	streaks := Streaks{JournaledToday: days[day(today)], ActiveDays: len(days), Weeks: []WeekConsistency{}}
+
+	start := today
+	if !streaks.JournaledToday {
+		start = today.AddDate(0, 0, -1)
+	}
+	for d := start; days[day(d)]; d = d.AddDate(0, 0, -1) {
+		streaks.Current++
+	}
+
+	for _, key := range sortedKeys(days) {
+		if key > streaks.LastEntry {
+			streaks.LastEntry = key
+		}
+		d, _ := time.ParseInLocation("2006-01-02", key, loc)
+		if days[day(d.AddDate(0, 0, -1))] {
+			continue
+		}
+		length := 1
+		for days[day(d.AddDate(0, 0, length))] {
+			length++
+		}
+		if length > streaks.Longest {
+			streaks.Longest = length
+			streaks.LongestFrom = key
+			streaks.LongestTo = day(d.AddDate(0, 0, length-1))
+		}
+	}
+
+	monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	journaled, elapsed := 0, 0
+	for w := weeks - 1; w >= 0; w-- {
+		week := monday.AddDate(0, 0, -7*w)
+		consistency := WeekConsistency{Week: day(week)}
+		for i := 0; i < 7; i++ {
+			d := week.AddDate(0, 0, i)
+			if d.After(today) {
+				break
+			}
+			elapsed++
+			if days[day(d)] {
+				consistency.Days++
+			}
+		}
+		journaled += consistency.Days
+		streaks.Weeks = append(streaks.Weeks, consistency)
+	}
+	if elapsed > 0 {
+		streaks.Consistency = float64(journaled*100/elapsed) / 100
+	}
+
+	streaks.Message = streakMessage(streaks)
+	return streaks
+}
+
+// streakMessage is a short, encouraging description of the streaks
+func streakMessage(streaks Streaks) string {
+	switch {
+	case streaks.Current == 0 && streaks.ActiveDays == 0:
+		return "No entries yet. Your first one starts a streak."
+	case streaks.Current == 0:
+		return fmt.Sprintf("No current streak. Your longest was %s; one entry today starts a new one.", pluralDays(streaks.Longest))
+	case !streaks.JournaledToday:
+		return fmt.Sprintf("You're on a %s streak. A quick entry today keeps it going.", pluralDays(streaks.Current))
+	case streaks.Current >= streaks.Longest && streaks.Current > 1:
+		return fmt.Sprintf("%s in a row, your longest streak yet.", pluralDays(streaks.Current))
+	}
+	return fmt.Sprintf("%s in a row. Your longest streak is %s.", pluralDays(streaks.Current), pluralDays(streaks.Longest))
+}
+
+func pluralDays(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
+
+// streaks computes the journaling streaks as of now
+func (js *JournalService) streaks(now time.Time, weeks int) (Streaks, error) {
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return Streaks{}, err
+	}
+	loc := js.location()
+	return computeStreaks(journaledDays(tasks, loc), now, loc, weeks), nil
+}
+
+// GetStreaks reports the current and longest journaling streaks and weekly consistency
+func (js *JournalService) GetStreaks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	weeks := defaultStreakWeeks
+	if value := request.GetString("weeks", ""); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > 52 {
+			return mcp.NewToolResultError("weeks must be between 1 and 52"), nil
+		}
+		weeks = n
+	}
+
+	streaks, err := js.streaks(time.Now(), weeks)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+
+	resultJSON, _ := json.MarshalIndent(streaks, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// streakNudgeJob reminds once a day (schedule.streak_nudge) to journal when a
+// streak would otherwise end at midnight
+func (js *JournalService) streakNudgeJob() ScheduledJob {
+	return ScheduledJob{
+		Name: "streak_nudge",
+		Due: func(now, lastRun time.Time) bool {
+			config, err := js.loadConfiguration()
+			if err != nil || config.Schedule.StreakNudge == "off" {
+				return false
+			}
+			at := config.Schedule.StreakNudge
+			if at == "" {
+				at = "17:00"
+			}
+			return dueDailyAt(at, js.location(), now, lastRun)
+		},
+		Run: func(ctx context.Context) error {
+			_, err := js.nudgeStreak(time.Now())
+			return err
+		},
+	}
+}
+
+// nudgeStreak adds a gentle reminder notification when there is a streak to
+// keep and nothing has been journaled today, and reports whether it did
+func (js *JournalService) nudgeStreak(now time.Time) (bool, error) {
+	streaks, err := js.streaks(now, 1)
+	if err != nil {
+		return false, err
+	}
+	if streaks.JournaledToday || streaks.Current == 0 {
+		return false, nil
+	}
+	return true, js.notify("streak", "", streaks.Message)
+}
+
+// streakBadge renders a shields-style SVG badge showing the current streak
+func streakBadge(streaks Streaks) string {
+	label := "journal streak"
+	value := pluralDays(streaks.Current)
+	color := "#9f9f9f"
+	switch {
+	case streaks.Current >= 7:
+		color = "#4c1"
+	case streaks.Current > 0:
+		color = "#dfb317"
+	}
+	// Roughly 7px per character in Verdana 11px, plus padding
+	labelWidth := 7*len(label) + 10
+	valueWidth := 7*len(value) + 10
+	width := labelWidth + valueWidth
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`+
+		`<rect width="%d" height="20" fill="#555"/><rect x="%d" width="%d" height="20" fill="%s"/>`+
+		`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,sans-serif" font-size="11">`+
+		`<text x="%d" y="14">%s</text><text x="%d" y="14">%s</text></g></svg>`,
+		width, label, html.EscapeString(value),
+		labelWidth, labelWidth, valueWidth, color,
+		labelWidth/2, label, labelWidth+valueWidth/2, html.EscapeString(value))
+}
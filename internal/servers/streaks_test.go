@@ -0,0 +1,91 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestComputeStreaks(t *testing.T) {
+	loc := time.UTC
+	now := time.Date(2026, 3, 18, 20, 0, 0, 0, loc) // a Wednesday
+	days := map[string]bool{}
+	for _, d := range []string{"2026-03-01", "2026-03-02", "2026-03-03", "2026-03-04", "2026-03-10", "2026-03-16", "2026-03-17"} {
+		days[d] = true
+	}
+
+	streaks := computeStreaks(days, now, loc, 2)
+	if streaks.Current != 2 || streaks.JournaledToday {
+		t.Errorf("Expected a 2-day streak still alive, got %+v", streaks)
+	}
+	if streaks.Longest != 4 || streaks.LongestFrom != "2026-03-01" || streaks.LongestTo != "2026-03-04" {
+		t.Errorf("Expected the longest streak in early March, got %+v", streaks)
+	}
+	if len(streaks.Weeks) != 2 || streaks.Weeks[0].Week != "2026-03-09" || streaks.Weeks[0].Days != 1 || streaks.Weeks[1].Days != 2 {
+		t.Errorf("Unexpected weekly consistency: %+v", streaks.Weeks)
+	}
+	if streaks.Consistency != 0.3 {
+		t.Errorf("Expected 3 of 10 days, got %v", streaks.Consistency)
+	}
+	if !strings.Contains(streaks.Message, "keeps it going") {
+		t.Errorf("Expected a reminder, got %q", streaks.Message)
+	}
+
+	days["2026-03-18"] = true
+	if streaks := computeStreaks(days, now, loc, 1); streaks.Current != 3 || !streaks.JournaledToday {
+		t.Errorf("Expected today to extend the streak, got %+v", streaks)
+	}
+	if streaks := computeStreaks(days, now.AddDate(0, 0, 2), loc, 1); streaks.Current != 0 || streaks.Longest != 4 {
+		t.Errorf("Expected the streak broken after a missed day, got %+v", streaks)
+	}
+}
+
+func TestGetStreaksAndNudge(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	config := defaultConfiguration()
+	config.General.TimeZone = "UTC"
+	js.saveConfiguration(config)
+
+	createTestTask(t, js, "STK-1", "Daily notes", "work")
+	task, _ := js.loadTask("STK-1")
+	yesterday := time.Now().UTC().AddDate(0, 0, -1)
+	for i := range task.Entries {
+		task.Entries[i].Timestamp = yesterday
+	}
+	task.Entries = append(task.Entries, Entry{ID: "entry_old", Timestamp: yesterday.AddDate(0, 0, -1), Type: "log", Content: "Earlier"})
+	task.Entries = append(task.Entries, Entry{ID: "entry_moved", Timestamp: time.Now(), Type: "moved", Content: "Moved an entry here"})
+	js.saveTask(task)
+
+	result, _ := js.GetStreaks(ctx, CreateMockRequest(map[string]interface{}{"weeks": "4"}))
+	var streaks Streaks
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &streaks)
+	if streaks.Current != 2 || streaks.JournaledToday || len(streaks.Weeks) != 4 {
+		t.Fatalf("Unexpected streaks: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	if result, _ := js.GetStreaks(ctx, CreateMockRequest(map[string]interface{}{"weeks": "0"})); !result.IsError {
+		t.Error("Expected weeks=0 to be rejected")
+	}
+
+	if nudged, err := js.nudgeStreak(time.Now()); err != nil || !nudged {
+		t.Fatalf("Expected a streak nudge, got %v, %v", nudged, err)
+	}
+	notifications, _ := js.loadNotifications()
+	if len(notifications) != 1 || notifications[0].Kind != "streak" || !strings.Contains(notifications[0].Message, "2 days") {
+		t.Errorf("Unexpected notifications: %+v", notifications)
+	}
+
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "STK-1", "content": "Today's entry"}))
+	if nudged, _ := js.nudgeStreak(time.Now()); nudged {
+		t.Error("Expected no nudge after journaling today")
+	}
+
+	badge := streakBadge(streaks)
+	if !strings.HasPrefix(badge, "<svg") || !strings.Contains(badge, "2 days") {
+		t.Errorf("Unexpected badge: %s", badge)
+	}
+}
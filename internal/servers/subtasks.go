@@ -0,0 +1,184 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// CreateSubtask creates a task under an existing parent task
+func (js *JournalService) CreateSubtask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	parentID, err := request.RequireString("parent_id")
+	if err != nil {
+		return mcp.NewToolResultError("parent_id is required"), nil
+	}
+	id, err := request.RequireString("id")
+	if err != nil {
+		return mcp.NewToolResultError("id is required"), nil
+	}
+	title, err := request.RequireString("title")
+	if err != nil {
+		return mcp.NewToolResultError("title is required"), nil
+	}
+
+	if id == parentID {
+		return mcp.NewToolResultError("A task cannot be its own subtask"), nil
+	}
+
+	defer js.lockTask(parentID)()
+	defer js.lockTask(id)()
+	parent, err := js.loadTask(parentID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Parent task not found: %s", parentID)), nil
+	}
+	if _, err := js.loadTask(id); err == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Task %s already exists", id)), nil
+	}
+
+	now := time.Now()
+	task := Task{
+		ID:       id,
+		Title:    title,
+		Type:     request.GetString("type", parent.Type),
+		Tags:     request.GetStringSlice("tags", nil),
+		Status:   "active",
+		Priority: request.GetString("priority", parent.Priority),
+		ParentID: parentID,
+		Created:  now,
+		Updated:  now,
+		Entries: []Entry{{
+			ID:        generateEntryID(),
+			Timestamp: now,
+			Content:   fmt.Sprintf("Task created: %s (subtask of %s)", title, parentID),
+			Type:      "creation",
+		}},
+	}
+	if err := js.saveTask(&task); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task: %v", err)), nil
+	}
+
+	entry := Entry{
+		ID:        generateEntryID(),
+		Timestamp: now,
+		Content:   fmt.Sprintf("Subtask created: %s: %s", id, title),
+		Type:      "subtask",
+	}
+	parent.Entries = append(parent.Entries, entry)
+	parent.Updated = now
+	if err := js.saveTask(parent); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Created subtask %s but failed to update parent: %v", id, err)), nil
+	}
+	js.updateDailyLog(parentID, entry)
+
+	return mcp.NewToolResultText(fmt.Sprintf("Created subtask %s: %s (parent: %s)", id, title, parentID)), nil
+}
+
+// childrenByParent groups tasks by parent ID, each group sorted by creation time
+func childrenByParent(tasks []*Task) map[string][]*Task {
+	children := make(map[string][]*Task)
+	for _, task := range tasks {
+		if task.ParentID != "" {
+			children[task.ParentID] = append(children[task.ParentID], task)
+		}
+	}
+	for _, group := range children {
+		sort.Slice(group, func(i, j int) bool { return group[i].Created.Before(group[j].Created) })
+	}
+	return children
+}
+
+// formatTaskHierarchy renders the parent chain and subtasks of a task for get_task
+func (js *JournalService) formatTaskHierarchy(task *Task) string {
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return ""
+	}
+	byID := make(map[string]*Task, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+	}
+
+	var md strings.Builder
+
+	// Walk up to the root, guarding against cycles in hand-edited files
+	var ancestors []*Task
+	seen := map[string]bool{task.ID: true}
+	for parentID := task.ParentID; parentID != "" && !seen[parentID]; {
+		seen[parentID] = true
+		parent, ok := byID[parentID]
+		if !ok {
+			ancestors = append(ancestors, &Task{ID: parentID, Title: "(missing)"})
+			break
+		}
+		ancestors = append(ancestors, parent)
+		parentID = parent.ParentID
+	}
+	if len(ancestors) > 0 {
+		md.WriteString("\n## Parent\n")
+		for i := len(ancestors) - 1; i >= 0; i-- {
+			md.WriteString(fmt.Sprintf("%s- %s: %s\n", strings.Repeat("  ", len(ancestors)-1-i), ancestors[i].ID, ancestors[i].Title))
+		}
+	}
+
+	children := childrenByParent(tasks)
+	if len(children[task.ID]) > 0 {
+		completed := 0
+		for _, child := range children[task.ID] {
+			if child.Status == "completed" {
+				completed++
+			}
+		}
+		md.WriteString(fmt.Sprintf("\n## Subtasks (%d/%d completed)\n", completed, len(children[task.ID])))
+		writeTaskTree(&md, children, task.ID, 0, map[string]bool{task.ID: true})
+	}
+
+	return md.String()
+}
+
+// writeTaskTree writes the subtasks of parentID as a nested markdown list
+func writeTaskTree(md *strings.Builder, children map[string][]*Task, parentID string, depth int, seen map[string]bool) {
+	for _, child := range children[parentID] {
+		if seen[child.ID] {
+			continue
+		}
+		seen[child.ID] = true
+
+		box := " "
+		if child.Status == "completed" {
+			box = "x"
+		}
+		md.WriteString(fmt.Sprintf("%s- [%s] %s: %s (%s)\n", strings.Repeat("  ", depth), box, child.ID, child.Title, child.Status))
+		writeTaskTree(md, children, child.ID, depth+1, seen)
+	}
+}
+
+// formatTaskTree renders tasks as a tree: tasks whose parent is not in the set
+// are roots, and each root is followed by its descendants from the set
+func formatTaskTree(tasks []*Task) string {
+	inSet := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		inSet[task.ID] = true
+	}
+
+	var roots []*Task
+	for _, task := range tasks {
+		if task.ParentID == "" || !inSet[task.ParentID] {
+			roots = append(roots, task)
+		}
+	}
+
+	children := childrenByParent(tasks)
+	seen := make(map[string]bool)
+
+	var md strings.Builder
+	for _, root := range roots {
+		seen[root.ID] = true
+		md.WriteString(fmt.Sprintf("- %s: %s (%s)\n", root.ID, root.Title, root.Status))
+		writeTaskTree(&md, children, root.ID, 1, seen)
+	}
+	return md.String()
+}
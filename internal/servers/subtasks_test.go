@@ -0,0 +1,56 @@
+package servers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestSubtaskHierarchy(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	createTestTask(t, js, "EPIC-1", "Payments rewrite", "work")
+	for _, args := range []map[string]interface{}{
+		{"parent_id": "EPIC-1", "id": "EPIC-1a", "title": "Design API"},
+		{"parent_id": "EPIC-1", "id": "EPIC-1b", "title": "Migrate data"},
+		{"parent_id": "EPIC-1b", "id": "EPIC-1b1", "title": "Backfill script"},
+	} {
+		if result, _ := js.CreateSubtask(ctx, CreateMockRequest(args)); result.IsError {
+			t.Fatalf("Unexpected error: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+	}
+
+	if result, _ := js.CreateSubtask(ctx, CreateMockRequest(map[string]interface{}{"parent_id": "EPIC-1", "id": "EPIC-1a", "title": "Dup"})); !result.IsError {
+		t.Error("Expected duplicate subtask ID to be rejected")
+	}
+
+	child, _ := js.loadTask("EPIC-1b1")
+	if child.ParentID != "EPIC-1b" || child.Type != "work" {
+		t.Errorf("Expected subtask to inherit type and record parent, got %+v", child)
+	}
+
+	js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": "EPIC-1a", "status": "completed"}))
+	result, _ := js.GetTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "EPIC-1"}))
+	text := result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "## Subtasks (1/2 completed)") || !strings.Contains(text, "  - [ ] EPIC-1b1: Backfill script") {
+		t.Errorf("Expected nested subtasks in get_task, got:\n%s", text)
+	}
+
+	result, _ = js.GetTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "EPIC-1b1"}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "## Parent\n- EPIC-1: Payments rewrite\n  - EPIC-1b: Migrate data") {
+		t.Errorf("Expected parent chain in get_task, got:\n%s", text)
+	}
+
+	result, _ = js.ListTasks(ctx, CreateMockRequest(map[string]interface{}{"parent": "EPIC-1"}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "of 2 total") {
+		t.Errorf("Expected parent filter to return direct children, got:\n%s", text)
+	}
+
+	result, _ = js.ListTasks(ctx, CreateMockRequest(map[string]interface{}{"view": "tree"}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "- EPIC-1: Payments rewrite (active)\n") || !strings.Contains(text, "    - [ ] EPIC-1b1") {
+		t.Errorf("Expected tree view, got:\n%s", text)
+	}
+}
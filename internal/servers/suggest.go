@@ -0,0 +1,189 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const maxBranchSlugLength = 40
+
+var slugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugify lowercases text and joins its words with dashes, cutting at a word
+// boundary once maxLength is reached
+func slugify(text string, maxLength int) string {
+	slug := strings.Trim(slugPattern.ReplaceAllString(strings.ToLower(text), "-"), "-")
+	if len(slug) <= maxLength {
+		return slug
+	}
+	slug = slug[:maxLength]
+	if cut := strings.LastIndex(slug, "-"); cut > 0 {
+		slug = slug[:cut]
+	}
+	return strings.Trim(slug, "-")
+}
+
+// conventionalType picks a Conventional Commits type from a task's tags and type
+func conventionalType(task *Task) string {
+	for _, tag := range task.Tags {
+		switch strings.ToLower(tag) {
+		case "bug", "bugfix", "fix", "hotfix", "incident":
+			return "fix"
+		case "docs", "documentation":
+			return "docs"
+		case "refactor", "cleanup", "tech-debt":
+			return "refactor"
+		case "test", "tests", "testing":
+			return "test"
+		case "perf", "performance":
+			return "perf"
+		case "ci", "build":
+			return "ci"
+		case "chore", "maintenance", "dependencies":
+			return "chore"
+		}
+	}
+
+	switch task.Type {
+	case "investigation":
+		return "spike"
+	case "learning", "personal":
+		return "chore"
+	default:
+		return "feat"
+	}
+}
+
+// issueReference returns "#123" for a task linked to a GitHub issue, or "" when it has none
+func issueReference(task *Task) string {
+	_, _, number, err := parseGitHubURL(task.IssueURL)
+	if err != nil {
+		if task.IssueID != "" {
+			return "#" + strings.TrimPrefix(task.IssueID, "#")
+		}
+		return ""
+	}
+	return fmt.Sprintf("#%d", number)
+}
+
+// SuggestBranchName derives a conventional branch name from a task
+func (js *JournalService) SuggestBranchName(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskID)), nil
+	}
+
+	prefix := request.GetString("prefix", conventionalType(task))
+	if prefix == "feat" {
+		prefix = "feature"
+	}
+
+	id := strings.ToLower(task.ID)
+	if ref := issueReference(task); ref != "" && !strings.Contains(id, strings.TrimPrefix(ref, "#")) {
+		id = id + "-" + strings.TrimPrefix(ref, "#")
+	}
+
+	name := fmt.Sprintf("%s/%s-%s", prefix, slugify(id, maxBranchSlugLength), slugify(task.Title, maxBranchSlugLength))
+	if user := request.GetString("user", ""); user != "" {
+		name = slugify(user, maxBranchSlugLength) + "/" + name
+	}
+
+	result := map[string]interface{}{
+		"task_id":     task.ID,
+		"branch_name": name,
+		"command":     "git switch -c " + name,
+	}
+
+	resultJSON, _ := json.Marshal(result)
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// SuggestCommitMessage scaffolds a Conventional Commits message from a task's title and latest entries
+func (js *JournalService) SuggestCommitMessage(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskID)), nil
+	}
+
+	entryCount := 3
+	if countStr := request.GetString("entries", ""); countStr != "" {
+		if _, err := fmt.Sscanf(countStr, "%d", &entryCount); err != nil || entryCount < 0 {
+			return mcp.NewToolResultError("entries must be a non-negative number"), nil
+		}
+	}
+
+	commitType := request.GetString("type", conventionalType(task))
+	if commitType == "spike" {
+		commitType = "chore"
+	}
+	scope := request.GetString("scope", "")
+
+	subject := strings.TrimSpace(task.Title)
+	if subject != "" {
+		subject = strings.ToLower(subject[:1]) + subject[1:]
+	}
+	subject = strings.TrimSuffix(subject, ".")
+
+	header := commitType
+	if scope != "" {
+		header += "(" + scope + ")"
+	}
+	header += ": " + subject
+
+	// Latest hand-written entries make the body; automatic entries add nothing
+	var bullets []string
+	for i := len(task.Entries) - 1; i >= 0 && len(bullets) < entryCount; i-- {
+		entry := task.Entries[i]
+		if slices.Contains([]string{"status_change", "completion", "github_comment", "github_event"}, entry.Type) ||
+			strings.HasPrefix(entry.Content, "Task created:") {
+			continue
+		}
+		line := strings.Join(strings.Fields(entry.Content), " ")
+		bullets = append(bullets, "- "+line)
+	}
+	slices.Reverse(bullets)
+
+	var message strings.Builder
+	message.WriteString(header)
+	if len(bullets) > 0 {
+		message.WriteString("\n\n" + strings.Join(bullets, "\n"))
+	}
+
+	if ref := issueReference(task); ref != "" {
+		footer := "Refs"
+		if task.Status == "completed" || request.GetString("closes", "false") == "true" {
+			footer = "Closes"
+		}
+		message.WriteString(fmt.Sprintf("\n\n%s: %s", footer, ref))
+	} else {
+		message.WriteString(fmt.Sprintf("\n\nTask: %s", task.ID))
+	}
+
+	result := map[string]interface{}{
+		"task_id": task.ID,
+		"header":  header,
+		"message": message.String(),
+	}
+	if len(header) > 72 {
+		result["warning"] = fmt.Sprintf("Header is %d characters; consider shortening it to 72 or fewer", len(header))
+	}
+
+	resultJSON, _ := json.Marshal(result)
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
@@ -0,0 +1,54 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestSuggestBranchAndCommit(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	js.saveTask(&Task{
+		ID:       "API-42",
+		Title:    "Handle GitHub rate limits gracefully when syncing many repositories at once",
+		Type:     "work",
+		Tags:     []string{"bug"},
+		Status:   "active",
+		IssueURL: "https://github.com/acme/api/issues/42",
+		Created:  now,
+		Updated:  now,
+		Entries: []Entry{
+			{ID: "e1", Timestamp: now, Content: "Task created: Handle GitHub rate limits", Type: "log"},
+			{ID: "e2", Timestamp: now, Content: "Back off using the Retry-After header", Type: "log"},
+			{ID: "e3", Timestamp: now, Content: "Status changed to active", Type: "status_change"},
+			{ID: "e4", Timestamp: now, Content: "Cache ETags between syncs", Type: "log"},
+		},
+	})
+
+	result, _ := js.SuggestBranchName(ctx, CreateMockRequest(map[string]interface{}{"task_id": "API-42"}))
+	var branch map[string]string
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &branch)
+	if branch["branch_name"] != "fix/api-42-handle-github-rate-limits-gracefully" {
+		t.Errorf("Unexpected branch name: %s", branch["branch_name"])
+	}
+
+	result, _ = js.SuggestCommitMessage(ctx, CreateMockRequest(map[string]interface{}{"task_id": "API-42", "scope": "github"}))
+	var commit map[string]string
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &commit)
+
+	expected := "fix(github): handle GitHub rate limits gracefully when syncing many repositories at once\n\n" +
+		"- Back off using the Retry-After header\n- Cache ETags between syncs\n\nRefs: #42"
+	if commit["message"] != expected {
+		t.Errorf("Unexpected commit message:\n%s", commit["message"])
+	}
+	if !strings.Contains(commit["warning"], "72") {
+		t.Error("Expected a warning for a long header")
+	}
+}
@@ -0,0 +1,282 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TagRule adds a tag to tasks whose title or entries match a pattern. Rules
+// run on new tasks and entries, and apply_tag_rules backfills them.
+type TagRule struct {
+	Name    string `json:"name" yaml:"name"`
+	Pattern string `json:"pattern" yaml:"pattern"`                 // regular expression, matched case-insensitively
+	Field   string `json:"field,omitempty" yaml:"field,omitempty"` // content, title or any (default)
+	Tag     string `json:"tag" yaml:"tag"`
+}
+
+var tagRuleFields = []string{"any", "content", "title"}
+
+// compileTagRule compiles a rule's pattern, case-insensitively
+func compileTagRule(rule TagRule) (*regexp.Regexp, error) {
+	pattern, err := regexp.Compile("(?i)" + rule.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern for tag rule %s: %w", rule.Name, err)
+	}
+	return pattern, nil
+}
+
+// validateTagRules checks that rules have unique names, a tag, a known field and a valid pattern
+func validateTagRules(rules []TagRule) error {
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		if rule.Name == "" || seen[rule.Name] {
+			return fmt.Errorf("tag rules need unique names")
+		}
+		seen[rule.Name] = true
+		if strings.TrimSpace(rule.Tag) == "" {
+			return fmt.Errorf("tag rule %s has no tag", rule.Name)
+		}
+		if rule.Field != "" && !slices.Contains(tagRuleFields, rule.Field) {
+			return fmt.Errorf("invalid field for tag rule %s: %s (expected content, title or any)", rule.Name, rule.Field)
+		}
+		if _, err := compileTagRule(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchTagRules returns the rule tags the task does not have yet whose
+// pattern matches the title (when checkTitle) or one of the entries
+func matchTagRules(rules []TagRule, task *Task, checkTitle bool, entries []Entry) []string {
+	var tags []string
+	for _, rule := range rules {
+		if slices.Contains(task.Tags, rule.Tag) || slices.Contains(tags, rule.Tag) {
+			continue
+		}
+		pattern, err := compileTagRule(rule)
+		if err != nil {
+			continue
+		}
+		matched := false
+		if checkTitle && rule.Field != "content" {
+			matched = pattern.MatchString(task.Title)
+		}
+		if rule.Field != "title" {
+			for _, entry := range entries {
+				if matched {
+					break
+				}
+				matched = entry.Type != "deleted" && pattern.MatchString(entry.Content)
+			}
+		}
+		if matched {
+			tags = append(tags, rule.Tag)
+		}
+	}
+	return tags
+}
+
+// applyTagRulesTo tags a task from the configured rules, before it is saved,
+// and returns the tags added. New tasks check their title; later entries only
+// their own content, so a tag removed by hand is not re-added for the title.
+func (js *JournalService) applyTagRulesTo(task *Task, checkTitle bool, entries ...Entry) []string {
+	config, err := js.loadConfiguration()
+	if err != nil || len(config.TagRules) == 0 {
+		return nil
+	}
+	added := matchTagRules(config.TagRules, task, checkTitle, entries)
+	task.Tags = append(task.Tags, added...)
+	return added
+}
+
+// taggedSuffix describes tags added by rules for a tool result
+func taggedSuffix(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (tagged by rules: %s)", strings.Join(tags, ", "))
+}
+
+// AddTagRule adds a rule to the configuration
+func (js *JournalService) AddTagRule(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	pattern, err := request.RequireString("pattern")
+	if err != nil {
+		return mcp.NewToolResultError("pattern is required"), nil
+	}
+	tag, err := request.RequireString("tag")
+	if err != nil {
+		return mcp.NewToolResultError("tag is required"), nil
+	}
+	rule := TagRule{
+		Name:    request.GetString("name", tag),
+		Pattern: pattern,
+		Field:   request.GetString("field", ""),
+		Tag:     strings.TrimSpace(tag),
+	}
+	if rule.Field == "any" {
+		rule.Field = ""
+	}
+
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load configuration: %v", err)), nil
+	}
+	if slices.ContainsFunc(config.TagRules, func(r TagRule) bool { return r.Name == rule.Name }) {
+		return mcp.NewToolResultError(fmt.Sprintf("A tag rule named %s already exists; pass a different name or remove it first", rule.Name)), nil
+	}
+	rules := append(config.TagRules, rule)
+	if err := validateTagRules(rules); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	config.TagRules = rules
+	if err := js.saveConfiguration(config); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save configuration: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Added tag rule %s: %s matching /%s/ gets tag %s. New tasks and entries are tagged from now on; run apply_tag_rules rule=%s dry_run=true to preview tagging existing tasks.",
+		rule.Name, tagRuleFieldLabel(rule), rule.Pattern, rule.Tag, rule.Name)), nil
+}
+
+func tagRuleFieldLabel(rule TagRule) string {
+	switch rule.Field {
+	case "title":
+		return "a title"
+	case "content":
+		return "an entry"
+	}
+	return "a title or entry"
+}
+
+// ListTagRules lists the configured tag rules
+func (js *JournalService) ListTagRules(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load configuration: %v", err)), nil
+	}
+	if len(config.TagRules) == 0 {
+		return mcp.NewToolResultText("No tag rules configured. Add one with add_tag_rule."), nil
+	}
+
+	var md strings.Builder
+	md.WriteString("# Tag Rules\n\n")
+	for _, rule := range config.TagRules {
+		md.WriteString(fmt.Sprintf("- **%s**: %s matching `%s` → `%s`\n", rule.Name, tagRuleFieldLabel(rule), rule.Pattern, rule.Tag))
+	}
+	return mcp.NewToolResultText(md.String()), nil
+}
+
+// RemoveTagRule removes a rule by name; tags it already added stay
+func (js *JournalService) RemoveTagRule(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, err := request.RequireString("name")
+	if err != nil {
+		return mcp.NewToolResultError("name is required"), nil
+	}
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load configuration: %v", err)), nil
+	}
+	index := slices.IndexFunc(config.TagRules, func(r TagRule) bool { return r.Name == name })
+	if index < 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("Tag rule not found: %s", name)), nil
+	}
+	config.TagRules = slices.Delete(config.TagRules, index, index+1)
+	if err := js.saveConfiguration(config); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save configuration: %v", err)), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Removed tag rule %s; tags it already added are kept", name)), nil
+}
+
+// TagRuleChange is a task that a backfill tags
+type TagRuleChange struct {
+	TaskID string   `json:"task_id"`
+	Title  string   `json:"title"`
+	Tags   []string `json:"tags_added"`
+}
+
+// TagRuleBackfill reports a run of apply_tag_rules
+type TagRuleBackfill struct {
+	Rules   []string        `json:"rules"`
+	DryRun  bool            `json:"dry_run,omitempty"`
+	Tasks   int             `json:"tasks_checked"`
+	Tagged  int             `json:"tasks_tagged"`
+	Changes []TagRuleChange `json:"changes"`
+	Summary string          `json:"summary"`
+}
+
+// ApplyTagRules backfills tag rules over existing tasks, checking titles and
+// every entry; dry_run previews the tasks that would be tagged
+func (js *JournalService) ApplyTagRules(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load configuration: %v", err)), nil
+	}
+	rules := config.TagRules
+	if name := request.GetString("rule", ""); name != "" {
+		index := slices.IndexFunc(rules, func(r TagRule) bool { return r.Name == name })
+		if index < 0 {
+			return mcp.NewToolResultError(fmt.Sprintf("Tag rule not found: %s", name)), nil
+		}
+		rules = rules[index : index+1]
+	}
+	if len(rules) == 0 {
+		return mcp.NewToolResultError("No tag rules configured. Add one with add_tag_rule."), nil
+	}
+	dryRun := request.GetString("dry_run", "false") == "true"
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+
+	result := TagRuleBackfill{DryRun: dryRun, Tasks: len(tasks), Changes: []TagRuleChange{}}
+	for _, rule := range rules {
+		result.Rules = append(result.Rules, rule.Name)
+	}
+	for _, task := range tasks {
+		if len(matchTagRules(rules, task, true, task.Entries)) == 0 {
+			continue
+		}
+		change, err := js.tagTaskFromRules(task.ID, rules, dryRun)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to tag task %s: %v", task.ID, err)), nil
+		}
+		if len(change.Tags) > 0 {
+			result.Changes = append(result.Changes, change)
+		}
+	}
+	result.Tagged = len(result.Changes)
+
+	verb := "Tagged"
+	if dryRun {
+		verb = "Would tag"
+	}
+	result.Summary = fmt.Sprintf("%s %d of %d tasks using %d rules", verb, result.Tagged, result.Tasks, len(rules))
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// tagTaskFromRules re-checks a task under its lock and saves the tags the rules add
+func (js *JournalService) tagTaskFromRules(taskID string, rules []TagRule, dryRun bool) (TagRuleChange, error) {
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return TagRuleChange{}, err
+	}
+	change := TagRuleChange{TaskID: task.ID, Title: task.Title, Tags: matchTagRules(rules, task, true, task.Entries)}
+	if dryRun || len(change.Tags) == 0 {
+		return change, nil
+	}
+	task.Tags = append(task.Tags, change.Tags...)
+	task.Updated = time.Now()
+	return change, js.saveTask(task)
+}
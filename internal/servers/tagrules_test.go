@@ -0,0 +1,85 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestTagRules(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	// Existing tasks from before the rules
+	createTestTask(t, js, "TR-1", "Postgres upgrade", "work")
+	createTestTask(t, js, "TR-2", "Quarterly planning", "work")
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "TR-2", "content": "Ran PG_DUMP before the migration"}))
+
+	result, _ := js.AddTagRule(ctx, CreateMockRequest(map[string]interface{}{"pattern": `postgres|pg_dump`, "tag": "database"}))
+	if result.IsError {
+		t.Fatalf("Failed to add rule: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	js.AddTagRule(ctx, CreateMockRequest(map[string]interface{}{"pattern": `^incident`, "tag": "incident", "field": "title"}))
+
+	for _, args := range []map[string]interface{}{
+		{"pattern": "x", "tag": "database"},
+		{"pattern": "([", "tag": "broken"},
+		{"pattern": "x", "tag": "other", "field": "body"},
+	} {
+		if result, _ := js.AddTagRule(ctx, CreateMockRequest(args)); !result.IsError {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+
+	list, _ := js.ListTagRules(ctx, CreateMockRequest(map[string]interface{}{}))
+	if text := list.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "**database**") || !strings.Contains(text, "**incident**: a title matching") {
+		t.Errorf("Unexpected rule list:\n%s", text)
+	}
+
+	// Rules apply to new tasks and entries
+	created, _ := js.CreateTask(ctx, CreateMockRequest(map[string]interface{}{"id": "TR-4", "title": "Incident: login outage", "type": "work"}))
+	if !strings.Contains(created.Content[0].(mcp.TextContent).Text, "tagged by rules: incident") {
+		t.Errorf("Expected the new task tagged, got %s", created.Content[0].(mcp.TextContent).Text)
+	}
+	createTestTask(t, js, "TR-3", "Cache warmup", "work")
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "TR-3", "content": "Moved the cache into postgres"}))
+	if task, _ := js.loadTask("TR-3"); !slices.Contains(task.Tags, "database") {
+		t.Errorf("Expected the entry to tag the task, got %v", task.Tags)
+	}
+
+	// Backfill previews, then tags the older tasks
+	result, _ = js.ApplyTagRules(ctx, CreateMockRequest(map[string]interface{}{"dry_run": "true"}))
+	var preview TagRuleBackfill
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &preview)
+	if preview.Tagged != 2 || !preview.DryRun {
+		t.Fatalf("Expected TR-1 and TR-2 in the preview, got %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	if task, _ := js.loadTask("TR-1"); slices.Contains(task.Tags, "database") {
+		t.Error("Expected the preview to change nothing")
+	}
+
+	js.ApplyTagRules(ctx, CreateMockRequest(map[string]interface{}{"rule": "database"}))
+	for _, id := range []string{"TR-1", "TR-2"} {
+		if task, _ := js.loadTask(id); !slices.Contains(task.Tags, "database") {
+			t.Errorf("Expected %s tagged database, got %v", id, task.Tags)
+		}
+	}
+	result, _ = js.ApplyTagRules(ctx, CreateMockRequest(map[string]interface{}{}))
+	var again TagRuleBackfill
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &again)
+	if again.Tagged != 0 {
+		t.Errorf("Expected a second backfill to change nothing, got %+v", again.Changes)
+	}
+
+	js.RemoveTagRule(ctx, CreateMockRequest(map[string]interface{}{"name": "database"}))
+	if config, _ := js.loadConfiguration(); len(config.TagRules) != 1 {
+		t.Errorf("Expected one rule left, got %+v", config.TagRules)
+	}
+	if result, _ := js.RemoveTagRule(ctx, CreateMockRequest(map[string]interface{}{"name": "database"})); !result.IsError {
+		t.Error("Expected removing a missing rule to fail")
+	}
+}
@@ -0,0 +1,195 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TeamRollup summarizes each team member's activity over a period
+type TeamRollup struct {
+	DateFrom string         `json:"date_from"`
+	DateTo   string         `json:"date_to"`
+	Redact   string         `json:"redact"`
+	People   []PersonRollup `json:"people"`
+	Summary  string         `json:"summary"`
+}
+
+// PersonRollup is one person's part of a team rollup
+type PersonRollup struct {
+	Person          string   `json:"person"`
+	Role            string   `json:"role,omitempty"`
+	OpenTasks       int      `json:"open_tasks"`
+	CompletedTasks  int      `json:"completed_tasks"`
+	Entries         int      `json:"entries"` // entries on their tasks or mentioning them
+	Blocked         []string `json:"blocked,omitempty"`
+	ActionItems     int      `json:"action_items"`
+	ActionItemsOpen int      `json:"action_items_open"`
+	FollowThrough   float64  `json:"follow_through_pct,omitempty"` // share of 1-on-1 action items closed
+	Highlights      []string `json:"highlights,omitempty"`
+}
+
+// teamMembers resolves the people to roll up: the named ones (matched against
+// the registry for aliases and roles) or the whole registry
+func teamMembers(config *Configuration, names []string) []TeamMember {
+	if len(names) == 0 {
+		return config.Team.Members
+	}
+	var members []TeamMember
+	for _, name := range names {
+		member := TeamMember{Name: name}
+		for _, known := range config.Team.Members {
+			if strings.EqualFold(known.Name, name) {
+				member = known
+			}
+		}
+		members = append(members, member)
+	}
+	return members
+}
+
+// teamMemberName returns the registry name for a person given by name or
+// alias, or the name as given when they are not in team.members
+func teamMemberName(config *Configuration, name string) string {
+	name = strings.TrimSpace(name)
+	if config == nil {
+		return name
+	}
+	for _, member := range config.Team.Members {
+		if strings.EqualFold(member.Name, name) {
+			return member.Name
+		}
+		for _, alias := range member.Aliases {
+			if strings.EqualFold(strings.TrimPrefix(alias, "@"), strings.TrimPrefix(name, "@")) {
+				return member.Name
+			}
+		}
+	}
+	return name
+}
+
+// GetTeamRollup summarizes per-person activity, blocked work and 1-on-1
+// action-item follow-through for a manager's team
+func (js *JournalService) GetTeamRollup(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load configuration: %v", err)), nil
+	}
+
+	members := teamMembers(config, request.GetStringSlice("people", nil))
+	if len(members) == 0 {
+		return mcp.NewToolResultError("people is required (or add team.members to config)"), nil
+	}
+
+	redact := request.GetString("redact", config.Team.Redact)
+	if redact == "" {
+		redact = "none"
+	}
+	if redact != "none" && redact != "content" && redact != "names" {
+		return mcp.NewToolResultError("redact must be one of: none, content, names"), nil
+	}
+
+	now := time.Now()
+	dateFrom := request.GetString("date_from", now.AddDate(0, 0, -14).Format("2006-01-02"))
+	dateTo := request.GetString("date_to", now.Format("2006-01-02"))
+	if validationErr := js.validateDateFormat(dateFrom, "date_from"); validationErr != nil {
+		return mcp.NewToolResultError(validationErr.Error()), nil
+	}
+	if validationErr := js.validateDateFormat(dateTo, "date_to"); validationErr != nil {
+		return mcp.NewToolResultError(validationErr.Error()), nil
+	}
+	from, until := js.parseDateSafely(dateFrom), js.parseDateSafely(dateTo).AddDate(0, 0, 1)
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+	meetings, _ := js.loadOneOnOnes()
+
+	rollup := TeamRollup{DateFrom: dateFrom, DateTo: dateTo, Redact: redact}
+	for i, member := range members {
+		pattern := personPattern(member.Name, member.Aliases)
+		person := PersonRollup{Person: member.Name, Role: member.Role}
+		if redact == "names" {
+			person.Person = fmt.Sprintf("Person %c", 'A'+i%26)
+		}
+
+		for _, task := range tasks {
+			assigned := strings.EqualFold(task.Assignee, member.Name)
+			mentioned := pattern.MatchString(task.Title)
+
+			if assigned {
+				if doneAt, ok := completedAt(task); ok {
+					if !doneAt.Before(from) && doneAt.Before(until) {
+						person.CompletedTasks++
+					}
+				} else if task.Status != "someday" {
+					person.OpenTasks++
+				}
+			}
+			if task.Status == "blocked" && (assigned || mentioned) {
+				person.Blocked = append(person.Blocked, task.ID)
+			}
+
+			for _, entry := range task.Entries {
+				if entry.Timestamp.Before(from) || !entry.Timestamp.Before(until) || entry.Type == "creation" {
+					continue
+				}
+				if assigned || pattern.MatchString(entry.Content) {
+					person.Entries++
+					if isWrittenEntry(entry) {
+						person.Highlights = append(person.Highlights, fmt.Sprintf("%s %s: %s", entry.Timestamp.Format("2006-01-02"), task.ID, entry.Content))
+					}
+				}
+			}
+		}
+
+		// Action items from 1-on-1s in the period held with or mentioning the person
+		var theirMeetings []*OneOnOne
+		for _, meeting := range meetings {
+			if meeting.Date < dateFrom || meeting.Date > dateTo {
+				continue
+			}
+			filtered := &OneOnOne{Date: meeting.Date, TodoLinks: meeting.TodoLinks}
+			heldWith := meeting.Person != "" && meetingWith(meeting, member.Name)
+			for _, todo := range meeting.Todos {
+				if heldWith || pattern.MatchString(todo) {
+					filtered.Todos = append(filtered.Todos, todo)
+				}
+			}
+			person.ActionItems += len(filtered.Todos)
+			theirMeetings = append(theirMeetings, filtered)
+		}
+		person.ActionItemsOpen = len(openOneOnOneTodos(theirMeetings, tasks, "9999-12-31"))
+		if person.ActionItems > 0 {
+			closed := person.ActionItems - person.ActionItemsOpen
+			person.FollowThrough = math.Round(float64(closed) / float64(person.ActionItems) * 100)
+		}
+
+		sort.Sort(sort.Reverse(sort.StringSlice(person.Highlights)))
+		if len(person.Highlights) > 5 {
+			person.Highlights = person.Highlights[:5]
+		}
+		if redact != "none" {
+			// Entry text can name other people and carry private detail
+			person.Highlights = nil
+		}
+
+		rollup.People = append(rollup.People, person)
+	}
+
+	blocked := 0
+	for _, person := range rollup.People {
+		blocked += len(person.Blocked)
+	}
+	rollup.Summary = fmt.Sprintf("%d people, %d blocked items between %s and %s", len(rollup.People), blocked, dateFrom, dateTo)
+
+	resultJSON, _ := json.MarshalIndent(rollup, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
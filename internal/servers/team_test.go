@@ -0,0 +1,58 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestGetTeamRollup(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	config := defaultConfiguration()
+	config.Team.Members = []TeamMember{
+		{Name: "Alex Kim", Aliases: []string{"@akim"}, Role: "report"},
+		{Name: "Sam Lee", Role: "report"},
+	}
+	js.saveConfiguration(config)
+
+	js.CreateTask(ctx, CreateMockRequest(map[string]interface{}{"id": "API-1", "title": "Build API", "type": "work", "assignee": "Alex Kim"}))
+	js.CreateTask(ctx, CreateMockRequest(map[string]interface{}{"id": "API-2", "title": "Deploy API", "type": "work", "assignee": "Alex Kim"}))
+	js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": "API-1", "status": "completed"}))
+	js.UpdateTaskStatus(ctx, CreateMockRequest(map[string]interface{}{"task_id": "API-2", "status": "blocked"}))
+
+	createTestTask(t, js, "OPS-1", "On-call handoff", "work")
+	js.AddTaskEntry(ctx, CreateMockRequest(map[string]interface{}{"task_id": "OPS-1", "content": "Paired with @akim on the runbook"}))
+
+	js.saveOneOnOne(&OneOnOne{Date: time.Now().Format("2006-01-02"), Todos: []string{"Alex Kim: Build API", "@akim to book training", "Sam Lee to update docs"}})
+
+	result, _ := js.GetTeamRollup(ctx, CreateMockRequest(map[string]interface{}{}))
+	var rollup TeamRollup
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &rollup); err != nil {
+		t.Fatalf("Failed to parse rollup: %v", err)
+	}
+	if len(rollup.People) != 2 {
+		t.Fatalf("Expected both team members, got %+v", rollup.People)
+	}
+	alex := rollup.People[0]
+	if alex.CompletedTasks != 1 || alex.OpenTasks != 1 || len(alex.Blocked) != 1 || alex.Blocked[0] != "API-2" {
+		t.Errorf("Expected Alex with 1 completed, 1 open and API-2 blocked, got %+v", alex)
+	}
+	if alex.ActionItems != 2 || alex.ActionItemsOpen != 1 || alex.FollowThrough != 50 {
+		t.Errorf("Expected 1 of 2 action items closed, got %+v", alex)
+	}
+	if !strings.Contains(strings.Join(alex.Highlights, "\n"), "Paired with @akim") {
+		t.Errorf("Expected alias mention in highlights, got %v", alex.Highlights)
+	}
+
+	result, _ = js.GetTeamRollup(ctx, CreateMockRequest(map[string]interface{}{"redact": "names"}))
+	text := result.Content[0].(mcp.TextContent).Text
+	if strings.Contains(text, "Alex") || strings.Contains(text, "runbook") || !strings.Contains(text, "Person A") {
+		t.Errorf("Expected names and entry text redacted, got:\n%s", text)
+	}
+}
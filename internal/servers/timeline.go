@@ -0,0 +1,161 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TimelineEvent is one entry on a cross-task timeline
+type TimelineEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	TaskID    string    `json:"task_id"`
+	TaskTitle string    `json:"task_title"`
+	Kind      string    `json:"kind"` // entry, status or github
+	Type      string    `json:"type"`
+	Content   string    `json:"content"`
+}
+
+// timelineKind groups entry types into the kinds shown on a timeline
+func timelineKind(entryType string) string {
+	switch {
+	case entryType == "status_change" || entryType == "completion":
+		return "status"
+	case strings.HasPrefix(entryType, "github"):
+		return "github"
+	default:
+		return "entry"
+	}
+}
+
+// timelineEvents merges the entries of tasks into one chronological list,
+// keeping entries between from and to (inclusive dates) of the given kinds
+func timelineEvents(tasks []*Task, from, to time.Time, kinds []string) []TimelineEvent {
+	end := to.AddDate(0, 0, 1)
+	var events []TimelineEvent
+	for _, task := range tasks {
+		for _, entry := range task.Entries {
+			if entry.Timestamp.Before(from) || !entry.Timestamp.Before(end) {
+				continue
+			}
+			kind := timelineKind(entry.Type)
+			if len(kinds) > 0 && !slices.Contains(kinds, kind) {
+				continue
+			}
+			events = append(events, TimelineEvent{
+				Timestamp: entry.Timestamp,
+				TaskID:    task.ID,
+				TaskTitle: task.Title,
+				Kind:      kind,
+				Type:      entry.Type,
+				Content:   entry.Content,
+			})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	return events
+}
+
+// formatTimeline renders events as markdown grouped by day
+func formatTimeline(events []TimelineEvent, from, to string) string {
+	var md strings.Builder
+	md.WriteString(fmt.Sprintf("# Timeline: %s to %s\n\n", from, to))
+
+	if len(events) == 0 {
+		md.WriteString("No activity recorded in this range.")
+		return md.String()
+	}
+
+	tasks := make(map[string]bool)
+	for _, event := range events {
+		tasks[event.TaskID] = true
+	}
+	md.WriteString(fmt.Sprintf("%d events across %d tasks\n", len(events), len(tasks)))
+
+	currentDay := ""
+	for _, event := range events {
+		day := event.Timestamp.Format("2006-01-02")
+		if day != currentDay {
+			currentDay = day
+			md.WriteString(fmt.Sprintf("\n## %s (%s)\n", day, event.Timestamp.Weekday()))
+		}
+		marker := ""
+		if event.Kind != "entry" {
+			marker = fmt.Sprintf(" [%s]", event.Kind)
+		}
+		md.WriteString(fmt.Sprintf("- %s **%s**%s %s\n", event.Timestamp.Format("15:04"), event.TaskID, marker, event.Content))
+	}
+
+	return md.String()
+}
+
+// GetTimeline renders a merged chronological timeline of entries, status changes
+// and GitHub events across the selected tasks and tags
+func (js *JournalService) GetTimeline(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskIDs := request.GetStringSlice("task_ids", nil)
+	tags := request.GetStringSlice("tags", nil)
+	if len(taskIDs) == 0 && len(tags) == 0 {
+		return mcp.NewToolResultError("task_ids or tags is required"), nil
+	}
+
+	now := time.Now()
+	dateFrom := request.GetString("date_from", now.AddDate(0, 0, -30).Format("2006-01-02"))
+	dateTo := request.GetString("date_to", now.Format("2006-01-02"))
+	if validationErr := js.validateDateFormat(dateFrom, "date_from"); validationErr != nil {
+		return mcp.NewToolResultError(validationErr.Error()), nil
+	}
+	if validationErr := js.validateDateFormat(dateTo, "date_to"); validationErr != nil {
+		return mcp.NewToolResultError(validationErr.Error()), nil
+	}
+	from, to := js.parseDateSafely(dateFrom), js.parseDateSafely(dateTo)
+	if to.Before(from) {
+		return mcp.NewToolResultError("date_to must not be before date_from"), nil
+	}
+
+	kinds := request.GetStringSlice("kinds", nil)
+	for _, kind := range kinds {
+		if kind != "entry" && kind != "status" && kind != "github" {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid kind %q. Use entry, status or github", kind)), nil
+		}
+	}
+
+	allTasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+
+	var selected []*Task
+	for _, task := range allTasks {
+		if slices.Contains(taskIDs, task.ID) || slices.ContainsFunc(task.Tags, func(tag string) bool { return slices.Contains(tags, tag) }) {
+			selected = append(selected, task)
+		}
+	}
+	if len(selected) == 0 {
+		return mcp.NewToolResultError("No tasks match the given task_ids or tags"), nil
+	}
+
+	events := timelineEvents(selected, from, to, kinds)
+
+	if request.GetString("format", "markdown") == "json" {
+		if events == nil {
+			events = []TimelineEvent{}
+		}
+		resultJSON, _ := json.MarshalIndent(events, "", "  ")
+		return mcp.NewToolResultText(string(resultJSON)), nil
+	}
+
+	markdown, err := js.styleMarkdown(request.GetString("style", ""), formatTimeline(events, dateFrom, dateTo))
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(markdown), nil
+}
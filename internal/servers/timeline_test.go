@@ -0,0 +1,75 @@
+package servers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestGetTimeline(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	day := time.Now().AddDate(0, 0, -3)
+	at := func(hour int) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, time.UTC)
+	}
+
+	createTestTask(t, js, "API-1", "Build API", "work")
+	api, _ := js.loadTask("API-1")
+	api.Tags = []string{"launch"}
+	api.Entries = []Entry{
+		{ID: "a1", Timestamp: at(9), Content: "Started endpoint", Type: "log"},
+		{ID: "a2", Timestamp: at(15), Content: "Status changed from active to completed", Type: "status_change"},
+	}
+	js.saveTask(api)
+
+	createTestTask(t, js, "WEB-1", "Build UI", "work")
+	web, _ := js.loadTask("WEB-1")
+	web.Entries = []Entry{
+		{ID: "w1", Timestamp: at(11), Content: "Commented on PR #4", Type: "github_comment"},
+		{ID: "w2", Timestamp: at(12).AddDate(0, 0, -60), Content: "Too old", Type: "log"},
+	}
+	js.saveTask(web)
+
+	createTestTask(t, js, "OTHER-1", "Unrelated", "work")
+
+	result, _ := js.GetTimeline(ctx, CreateMockRequest(map[string]interface{}{
+		"tags":     []interface{}{"launch"},
+		"task_ids": []interface{}{"WEB-1"},
+	}))
+	if result.IsError {
+		t.Fatalf("Expected timeline, got error: %v", result.Content)
+	}
+	text := result.Content[0].(mcp.TextContent).Text
+
+	started := strings.Index(text, "Started endpoint")
+	commented := strings.Index(text, "**WEB-1** [github] Commented on PR #4")
+	completed := strings.Index(text, "**API-1** [status]")
+	if started < 0 || commented < 0 || completed < 0 || !(started < commented && commented < completed) {
+		t.Errorf("Expected interleaved chronological events, got:\n%s", text)
+	}
+	if strings.Contains(text, "Too old") || strings.Contains(text, "OTHER-1") {
+		t.Errorf("Expected only in-range events for selected tasks, got:\n%s", text)
+	}
+	if !strings.Contains(text, "3 events across 2 tasks") {
+		t.Errorf("Expected event summary, got:\n%s", text)
+	}
+
+	result, _ = js.GetTimeline(ctx, CreateMockRequest(map[string]interface{}{
+		"task_ids": []interface{}{"API-1", "WEB-1"},
+		"kinds":    []interface{}{"status"},
+	}))
+	text = result.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "1 events across 1 tasks") {
+		t.Errorf("Expected only the status change, got:\n%s", text)
+	}
+
+	result, _ = js.GetTimeline(ctx, CreateMockRequest(map[string]interface{}{}))
+	if !result.IsError {
+		t.Error("Expected error without task_ids or tags")
+	}
+}
@@ -0,0 +1,261 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TimeReport summarizes tracked time over a date range
+type TimeReport struct {
+	DateFrom   string             `json:"date_from"`
+	DateTo     string             `json:"date_to"`
+	TotalHours float64            `json:"total_hours"`
+	ByTask     map[string]float64 `json:"by_task"`
+	ByType     map[string]float64 `json:"by_type"`
+	ByDay      map[string]float64 `json:"by_day"`
+	Running    []string           `json:"running_timers,omitempty"`
+	Summary    string             `json:"summary"`
+}
+
+// roundHours converts minutes to hours rounded to two decimals
+func roundHours(minutes int) float64 {
+	return math.Round(float64(minutes)/60*100) / 100
+}
+
+// formatMinutes renders a duration such as "1h25m" or "40m"
+func formatMinutes(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
+
+// stopTaskTimer ends a task's running timer and records the time as a "time" entry.
+// The caller saves the task.
+func (js *JournalService) stopTaskTimer(task *Task, now time.Time, note string) Entry {
+	minutes := int(math.Round(now.Sub(*task.TimerStarted).Minutes()))
+	if minutes < 1 {
+		minutes = 1
+	}
+
+	content := fmt.Sprintf("Worked %s (%s-%s)", formatMinutes(minutes), task.TimerStarted.Format("15:04"), now.Format("15:04"))
+	if note != "" {
+		content += ": " + note
+	}
+
+	entry := Entry{
+		ID:        generateEntryID(),
+		Timestamp: now,
+		Content:   content,
+		Type:      "time",
+		Minutes:   minutes,
+	}
+	task.Entries = append(task.Entries, entry)
+	task.TimerStarted = nil
+	task.Updated = now
+	js.checkEstimate(task, now)
+	return entry
+}
+
+// stopRunningTimer stops the timer on a task under its lock, reloading the
+// task first so an edit made since it was listed is kept, and logs the time.
+// The entry is nil when no timer is running by then.
+func (js *JournalService) stopRunningTimer(taskID string, now time.Time, note string) (*Entry, *Task, error) {
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if task.TimerStarted == nil {
+		return nil, task, nil
+	}
+	entry := js.stopTaskTimer(task, now, note)
+	if err := js.saveTask(task); err != nil {
+		return nil, nil, err
+	}
+	js.updateDailyLog(task.ID, entry)
+	return &entry, task, nil
+}
+
+// runningTimers returns the tasks that have a timer running
+func (js *JournalService) runningTimers() ([]*Task, error) {
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return nil, err
+	}
+	var running []*Task
+	for _, task := range tasks {
+		if task.TimerStarted != nil {
+			running = append(running, task)
+		}
+	}
+	return running, nil
+}
+
+// StartTimer starts tracking time on a task, stopping any timer running on another task
+func (js *JournalService) StartTimer(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError("task_id is required"), nil
+	}
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load task: %v", err)), nil
+	}
+	if task.TimerStarted != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("A timer is already running on %s since %s", taskID, task.TimerStarted.Format("15:04"))), nil
+	}
+	// A focus session already tracks its time
+	if session, err := js.loadFocusSession(); err == nil && session != nil && time.Now().Before(session.Ends()) {
+		return mcp.NewToolResultError(fmt.Sprintf("A focus session is running on %s until %s; end it with end_focus_session first", session.TaskID, session.Ends().Format("15:04"))), nil
+	}
+
+	running, err := js.runningTimers()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+
+	now := time.Now()
+	var stopped []string
+	for _, other := range running {
+		entry, _, err := js.stopRunningTimer(other.ID, now, "")
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to stop timer on %s: %v", other.ID, err)), nil
+		}
+		if entry != nil {
+			stopped = append(stopped, fmt.Sprintf("%s (%s)", other.ID, formatMinutes(entry.Minutes)))
+		}
+	}
+
+	// Other timers are stopped before taking this task's lock, so two starts
+	// never wait on each other's tasks
+	defer js.lockTask(taskID)()
+	task, err = js.loadTask(taskID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load task: %v", err)), nil
+	}
+	if task.TimerStarted != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("A timer is already running on %s since %s", taskID, task.TimerStarted.Format("15:04"))), nil
+	}
+	task.TimerStarted = &now
+	if err := js.saveTask(task); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task: %v", err)), nil
+	}
+
+	message := fmt.Sprintf("Started timer on %s at %s", taskID, now.Format("15:04"))
+	if len(stopped) > 0 {
+		message += fmt.Sprintf(". Stopped: %s", strings.Join(stopped, ", "))
+	}
+	return mcp.NewToolResultText(message), nil
+}
+
+// StopTimer stops a running timer and records the time spent as a duration entry
+func (js *JournalService) StopTimer(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID := request.GetString("task_id", "")
+	note := request.GetString("note", "")
+
+	var task *Task
+	if taskID != "" {
+		loaded, err := js.loadTask(taskID)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to load task: %v", err)), nil
+		}
+		task = loaded
+	} else {
+		running, err := js.runningTimers()
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+		}
+		if len(running) > 1 {
+			return mcp.NewToolResultError("Several timers are running; pass task_id"), nil
+		}
+		if len(running) == 1 {
+			task = running[0]
+		}
+	}
+	if task == nil || task.TimerStarted == nil {
+		return mcp.NewToolResultError("No timer is running"), nil
+	}
+
+	entry, task, err := js.stopRunningTimer(task.ID, time.Now(), note)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save task: %v", err)), nil
+	}
+	if entry == nil {
+		return mcp.NewToolResultError("No timer is running"), nil
+	}
+
+	message := fmt.Sprintf("Stopped timer on %s: %s", task.ID, entry.Content)
+	if last := task.Entries[len(task.Entries)-1]; last.Type == "reestimate" {
+		message += ". " + last.Content
+	}
+	return mcp.NewToolResultText(message), nil
+}
+
+// GetTimeReport summarizes tracked time per task, task type and day
+func (js *JournalService) GetTimeReport(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	loc := js.location()
+	now := time.Now().In(loc)
+	dateFrom := request.GetString("date_from", now.AddDate(0, 0, -6).Format("2006-01-02"))
+	dateTo := request.GetString("date_to", now.Format("2006-01-02"))
+	if validationErr := js.validateDateFormat(dateFrom, "date_from"); validationErr != nil {
+		return mcp.NewToolResultError(validationErr.Error()), nil
+	}
+	if validationErr := js.validateDateFormat(dateTo, "date_to"); validationErr != nil {
+		return mcp.NewToolResultError(validationErr.Error()), nil
+	}
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+
+	byTask := make(map[string]int)
+	byType := make(map[string]int)
+	byDay := make(map[string]int)
+	total := 0
+	report := TimeReport{DateFrom: dateFrom, DateTo: dateTo}
+
+	for _, task := range tasks {
+		if task.TimerStarted != nil {
+			report.Running = append(report.Running, task.ID)
+		}
+		for _, entry := range task.Entries {
+			if entry.Minutes == 0 {
+				continue
+			}
+			day := entryDate(entry, loc)
+			if day < dateFrom || day > dateTo {
+				continue
+			}
+			byTask[task.ID] += entry.Minutes
+			byType[task.Type] += entry.Minutes
+			byDay[day] += entry.Minutes
+			total += entry.Minutes
+		}
+	}
+
+	toHours := func(minutes map[string]int) map[string]float64 {
+		hours := make(map[string]float64, len(minutes))
+		for key, value := range minutes {
+			hours[key] = roundHours(value)
+		}
+		return hours
+	}
+	report.ByTask = toHours(byTask)
+	report.ByType = toHours(byType)
+	report.ByDay = toHours(byDay)
+	report.TotalHours = roundHours(total)
+	report.Summary = fmt.Sprintf("Tracked %s across %d tasks between %s and %s", formatMinutes(total), len(byTask), dateFrom, dateTo)
+
+	resultJSON, _ := json.MarshalIndent(report, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
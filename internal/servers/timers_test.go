@@ -0,0 +1,81 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestTimers(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	createTestTask(t, js, "TIME-1", "Write report", "work")
+	createTestTask(t, js, "TIME-2", "Read book", "learning")
+
+	result, _ := js.StartTimer(ctx, CreateMockRequest(map[string]interface{}{"task_id": "TIME-1"}))
+	if result.IsError {
+		t.Fatalf("StartTimer failed: %v", result.Content)
+	}
+	result, _ = js.StartTimer(ctx, CreateMockRequest(map[string]interface{}{"task_id": "TIME-1"}))
+	if !result.IsError {
+		t.Error("Expected error starting a timer twice")
+	}
+
+	// Backdate the running timer, then switch tasks
+	task, _ := js.loadTask("TIME-1")
+	started := time.Now().Add(-90 * time.Minute)
+	task.TimerStarted = &started
+	js.saveTask(task)
+
+	result, _ = js.StartTimer(ctx, CreateMockRequest(map[string]interface{}{"task_id": "TIME-2"}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "Stopped: TIME-1 (1h30m)") {
+		t.Errorf("Expected switching to stop TIME-1, got %s", text)
+	}
+
+	task, _ = js.loadTask("TIME-1")
+	last := task.Entries[len(task.Entries)-1]
+	if task.TimerStarted != nil || last.Type != "time" || last.Minutes != 90 {
+		t.Errorf("Expected a 90 minute time entry, got %+v", last)
+	}
+
+	result, _ = js.StopTimer(ctx, CreateMockRequest(map[string]interface{}{"note": "chapter 3"}))
+	if result.IsError {
+		t.Fatalf("StopTimer failed: %v", result.Content)
+	}
+	result, _ = js.StopTimer(ctx, CreateMockRequest(map[string]interface{}{}))
+	if !result.IsError {
+		t.Error("Expected error with no timer running")
+	}
+
+	result, _ = js.GetTimeReport(ctx, CreateMockRequest(map[string]interface{}{}))
+	var report TimeReport
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report); err != nil {
+		t.Fatalf("Failed to parse report: %v", err)
+	}
+	if report.ByTask["TIME-1"] != 1.5 || report.ByType["work"] != 1.5 || report.ByTask["TIME-2"] == 0 {
+		t.Errorf("Expected tracked hours per task and type, got %+v", report)
+	}
+
+	metrics := js.calculateProductivityMetrics([]*Task{task}, "week")
+	if metrics.HoursTrackedPeriod != 1.5 {
+		t.Errorf("Expected 1.5 tracked hours in analytics, got %v", metrics.HoursTrackedPeriod)
+	}
+
+	// Late-evening time counts on the local day, not the UTC one
+	config := defaultConfiguration()
+	config.General.TimeZone = "America/New_York"
+	js.saveConfiguration(config)
+	task.Entries = append(task.Entries, Entry{ID: generateEntryID(), Timestamp: time.Date(2026, 3, 3, 2, 0, 0, 0, time.UTC), Type: "time", Minutes: 60})
+	js.saveTask(task)
+	result, _ = js.GetTimeReport(ctx, CreateMockRequest(map[string]interface{}{"date_from": "2026-03-01", "date_to": "2026-03-05"}))
+	report = TimeReport{}
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report)
+	if report.ByDay["2026-03-02"] != 1 || report.ByDay["2026-03-03"] != 0 {
+		t.Errorf("Expected the hour on 2026-03-02 in New York, got %+v", report.ByDay)
+	}
+}
@@ -0,0 +1,341 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TodoLink ties a one-on-one todo to the task it refers to
+type TodoLink struct {
+	Todo   string `json:"todo"`
+	TaskID string `json:"task_id"`
+	Match  string `json:"match"` // id or title when matched by task ID or title words, created when converted to a new task
+}
+
+// TodoSuggestion offers a task for a todo that matched none
+type TodoSuggestion struct {
+	Todo  string `json:"todo"`
+	ID    string `json:"suggested_id"`
+	Title string `json:"suggested_title"`
+	Type  string `json:"suggested_type"`
+}
+
+// OneOnOneResult reports a saved one-on-one and what its todos were linked to
+type OneOnOneResult struct {
+	Date        string           `json:"date"`
+	Linked      []TodoLink       `json:"linked,omitempty"`
+	Suggestions []TodoSuggestion `json:"suggested_tasks,omitempty"` // create_task arguments for unlinked todos
+	Summary     string           `json:"summary"`
+}
+
+// TodoConversion reports a convert_one_on_one_todos run
+type TodoConversion struct {
+	Date          string     `json:"date"`
+	Person        string     `json:"person,omitempty"`
+	Created       []TodoLink `json:"created,omitempty"` // todos that became new tasks
+	Linked        []TodoLink `json:"linked,omitempty"`  // todos recorded on existing tasks
+	AlreadyLinked int        `json:"already_linked"`
+	DryRun        bool       `json:"dry_run"`
+	Summary       string     `json:"summary"`
+}
+
+// minTitleWords is how many words a title needs before a todo mentioning them
+// all is linked to it; one-word titles match too much
+const minTitleWords = 2
+
+// matchTodoTask finds the task a todo refers to: the first task ID it names,
+// else the open task whose title words it contains, preferring longer titles
+func matchTodoTask(todo string, tasks []*Task) (*Task, string) {
+	var byID *Task
+	first := -1
+	for _, task := range tasks {
+		// IDs without digits, like MISC, could be ordinary words, so only
+		// IDs such as proj-12 match regardless of case
+		flags := ""
+		if strings.ContainsAny(task.ID, "0123456789") {
+			flags = "(?i)"
+		}
+		pattern := regexp.MustCompile(flags + `(^|[^\w-])` + regexp.QuoteMeta(task.ID) + `($|[^\w-])`)
+		if loc := pattern.FindStringIndex(todo); loc != nil && (first < 0 || loc[0] < first) {
+			byID, first = task, loc[0]
+		}
+	}
+	if byID != nil {
+		return byID, "id"
+	}
+
+	words := make(map[string]bool)
+	for _, word := range searchTokens(todo) {
+		words[word] = true
+	}
+	var byTitle *Task
+	best := 0
+	for _, task := range tasks {
+		if task.Status == "completed" {
+			continue
+		}
+		titleWords := searchTokens(task.Title)
+		if len(titleWords) < minTitleWords || len(titleWords) <= best {
+			continue
+		}
+		all := true
+		for _, word := range titleWords {
+			if !words[word] {
+				all = false
+				break
+			}
+		}
+		if all {
+			byTitle, best = task, len(titleWords)
+		}
+	}
+	if byTitle != nil {
+		return byTitle, "title"
+	}
+	return nil, ""
+}
+
+// linkedTaskID returns the task a meeting's todo was linked to, if any
+func linkedTaskID(meeting *OneOnOne, todo string) string {
+	for _, link := range meeting.TodoLinks {
+		if link.Todo == todo {
+			return link.TaskID
+		}
+	}
+	return ""
+}
+
+// linkOneOnOneTodos links each todo to the task it refers to, adding an
+// action item entry to the task, and suggests tasks for the rest
+func (js *JournalService) linkOneOnOneTodos(meeting *OneOnOne) (*OneOnOneResult, error) {
+	result := &OneOnOneResult{Date: meeting.Date}
+	meeting.TodoLinks = nil
+	if len(meeting.Todos) == 0 {
+		return result, nil
+	}
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return nil, err
+	}
+	// Stable order, so equally good title matches resolve the same way every time
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+
+	defaultType := "work"
+	if config, err := js.loadConfiguration(); err == nil && config.General.DefaultTaskType != "" {
+		defaultType = config.General.DefaultTaskType
+	}
+
+	for _, todo := range meeting.Todos {
+		task, match := matchTodoTask(todo, tasks)
+		if task == nil {
+			result.Suggestions = append(result.Suggestions, TodoSuggestion{
+				Todo:  todo,
+				ID:    strings.ToUpper(slugify(todo, 24)),
+				Title: todo,
+				Type:  defaultType,
+			})
+			continue
+		}
+		if err := js.addActionItemEntry(task.ID, meeting, todo); err != nil {
+			return nil, fmt.Errorf("failed to link todo to task %s: %w", task.ID, err)
+		}
+		link := TodoLink{Todo: todo, TaskID: task.ID, Match: match}
+		meeting.TodoLinks = append(meeting.TodoLinks, link)
+		result.Linked = append(result.Linked, link)
+	}
+	return result, nil
+}
+
+// addActionItemEntry records a meeting todo on the task it was linked to
+func (js *JournalService) addActionItemEntry(taskID string, meeting *OneOnOne, todo string) error {
+	defer js.lockTask(taskID)()
+
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return err
+	}
+	content := fmt.Sprintf("Action item from the %s %s: %s", meeting.Date, meetingLabel(meeting), todo)
+	for _, entry := range task.Entries {
+		// Re-saving a meeting does not repeat its action items
+		if entry.Type == "action_item" && entry.Content == content {
+			return nil
+		}
+	}
+	entry := Entry{
+		ID:        generateEntryID(),
+		Timestamp: time.Now(),
+		Content:   content,
+		Type:      "action_item",
+	}
+	task.Entries = append(task.Entries, entry)
+	task.Updated = entry.Timestamp
+	if err := js.saveTask(task); err != nil {
+		return err
+	}
+	js.updateDailyLog(taskID, entry)
+	return nil
+}
+
+// findOneOnOne picks the meeting on date with person. Without a person the
+// day must have a single meeting.
+func findOneOnOne(meetings []*OneOnOne, date, person string) (*OneOnOne, error) {
+	return findMeeting(meetings, date, "one_on_one", person)
+}
+
+// todoClosed reports whether a meeting's todo is done: its linked task is
+// completed or no longer a live task (archived or deleted), or, when it is not
+// linked, a completed task's title covers it
+func todoClosed(meeting *OneOnOne, todo string, byID map[string]*Task) bool {
+	if taskID := linkedTaskID(meeting, todo); taskID != "" {
+		task, ok := byID[taskID]
+		return !ok || task.Status == "completed"
+	}
+	text := strings.ToLower(strings.TrimSpace(todo))
+	for _, task := range byID {
+		if task.Status != "completed" {
+			continue
+		}
+		title := strings.ToLower(task.Title)
+		if strings.Contains(title, text) || strings.Contains(text, title) {
+			return true
+		}
+	}
+	return false
+}
+
+// unusedTaskID returns id, or id with the first free numeric suffix
+func (js *JournalService) unusedTaskID(id string) string {
+	candidate := id
+	for n := 2; ; n++ {
+		if _, err := js.loadTask(candidate); err != nil {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s-%d", id, n)
+	}
+}
+
+// ConvertOneOnOneTodos turns a meeting's action items into tracked work: each
+// todo not linked yet is recorded on the task it refers to, or becomes a new task
+func (js *JournalService) ConvertOneOnOneTodos(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	date, err := request.RequireString("date")
+	if err != nil {
+		return mcp.NewToolResultError("date is required (YYYY-MM-DD format)"), nil
+	}
+	if validationErr := js.validateDateFormat(date, "date"); validationErr != nil {
+		return mcp.NewToolResultError(validationErr.Error()), nil
+	}
+	config, err := js.loadConfiguration()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load configuration: %v", err)), nil
+	}
+	person := request.GetString("person", "")
+	if person != "" {
+		person = teamMemberName(config, person)
+	}
+	taskType := request.GetString("type", config.General.DefaultTaskType)
+	if taskType == "" {
+		taskType = "work"
+	}
+	dryRun := request.GetString("dry_run", "false") == "true"
+
+	meetings, err := js.loadOneOnOnes()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load one-on-ones: %v", err)), nil
+	}
+	meeting, err := findOneOnOne(meetings, date, person)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	todos := meeting.Todos
+	if selected := request.GetStringSlice("todos", nil); selected != nil {
+		for _, todo := range selected {
+			if !slices.Contains(meeting.Todos, todo) {
+				return mcp.NewToolResultError(fmt.Sprintf("The %s one-on-one has no todo %q", date, todo)), nil
+			}
+		}
+		todos = selected
+	}
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+
+	result := TodoConversion{Date: meeting.Date, Person: meeting.Person, DryRun: dryRun}
+	for _, todo := range todos {
+		if linkedTaskID(meeting, todo) != "" {
+			result.AlreadyLinked++
+			continue
+		}
+
+		if task, match := matchTodoTask(todo, tasks); task != nil {
+			link := TodoLink{Todo: todo, TaskID: task.ID, Match: match}
+			if !dryRun {
+				if err := js.addActionItemEntry(task.ID, meeting, todo); err != nil {
+					return mcp.NewToolResultError(fmt.Sprintf("Failed to add action item to %s: %v", task.ID, err)), nil
+				}
+				meeting.TodoLinks = append(meeting.TodoLinks, link)
+			}
+			result.Linked = append(result.Linked, link)
+			continue
+		}
+
+		id := js.unusedTaskID(strings.ToUpper(slugify(todo, 24)))
+		link := TodoLink{Todo: todo, TaskID: id, Match: "created"}
+		result.Created = append(result.Created, link)
+		if dryRun {
+			continue
+		}
+		now := time.Now()
+		task := &Task{
+			ID:      id,
+			Title:   todo,
+			Type:    taskType,
+			Status:  "active",
+			Created: now,
+			Updated: now,
+			Entries: []Entry{{
+				ID:        generateEntryID(),
+				Timestamp: now,
+				Content:   fmt.Sprintf("Task created: %s", todo),
+				Type:      "creation",
+			}},
+		}
+		js.applyTagRulesTo(task, true)
+		if err := js.saveTask(task); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to save task: %v", err)), nil
+		}
+		if err := js.addActionItemEntry(id, meeting, todo); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to add action item to %s: %v", id, err)), nil
+		}
+		meeting.TodoLinks = append(meeting.TodoLinks, link)
+		tasks = append(tasks, task)
+	}
+
+	if !dryRun && len(result.Created)+len(result.Linked) > 0 {
+		if err := js.saveOneOnOne(meeting); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to save one-on-one: %v", err)), nil
+		}
+	}
+
+	verb := "Created"
+	if dryRun {
+		verb = "Would create"
+	}
+	result.Summary = fmt.Sprintf("%s %d tasks and linked %d todos to existing tasks; %d were already linked",
+		verb, len(result.Created), len(result.Linked), result.AlreadyLinked)
+
+	resultJSON, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
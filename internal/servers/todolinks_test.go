@@ -0,0 +1,134 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestMatchTodoTask(t *testing.T) {
+	tasks := []*Task{
+		{ID: "PROJ-12", Title: "Rate limiter", Status: "active"},
+		{ID: "PROJ-120", Title: "Onboarding docs refresh", Status: "active"},
+		{ID: "OLD-1", Title: "Quarterly planning deck", Status: "completed"},
+		{ID: "MISC", Title: "Misc", Status: "active"},
+	}
+	cases := []struct {
+		todo, taskID, match string
+	}{
+		{"Follow up on proj-12 with the SRE team", "PROJ-12", "id"},
+		{"Review PROJ-120 before Friday", "PROJ-120", "id"},
+		{"Share the rate limiter design", "PROJ-12", "title"},
+		{"Refresh the onboarding docs for new hires", "PROJ-120", "title"},
+		{"Update the quarterly planning deck", "", ""},
+		{"Sort out misc expenses", "", ""},
+		{"Book the offsite", "", ""},
+	}
+	for _, c := range cases {
+		task, match := matchTodoTask(c.todo, tasks)
+		got := ""
+		if task != nil {
+			got = task.ID
+		}
+		if got != c.taskID || match != c.match {
+			t.Errorf("matchTodoTask(%q) = %s/%s, expected %s/%s", c.todo, got, match, c.taskID, c.match)
+		}
+	}
+}
+
+func TestCreateOneOnOneLinksTodos(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "API-7", "Pagination for the search API", "work")
+
+	args := map[string]interface{}{
+		"date":  "2026-03-14",
+		"todos": []interface{}{"Check API-7 edge cases", "Book the team offsite"},
+	}
+	result, _ := js.CreateOneOnOne(ctx, CreateMockRequest(args))
+	var created OneOnOneResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &created); err != nil {
+		t.Fatalf("Expected a JSON result: %v", err)
+	}
+	if len(created.Linked) != 1 || created.Linked[0].TaskID != "API-7" {
+		t.Errorf("Expected the first todo linked, got %+v", created.Linked)
+	}
+	if len(created.Suggestions) != 1 || created.Suggestions[0].ID != "BOOK-THE-TEAM-OFFSITE" || created.Suggestions[0].Type != "work" {
+		t.Errorf("Expected a task suggested for the second todo, got %+v", created.Suggestions)
+	}
+
+	// Saving the meeting again does not repeat the action item
+	js.CreateOneOnOne(ctx, CreateMockRequest(args))
+	task, _ := js.loadTask("API-7")
+	count := 0
+	for _, entry := range task.Entries {
+		if entry.Type == "action_item" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected one action item entry, got %d", count)
+	}
+
+	history, _ := js.GetOneOnOneHistory(ctx, CreateMockRequest(map[string]interface{}{}))
+	if text := history.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "- [ ] Check API-7 edge cases (→ API-7, active)") {
+		t.Errorf("Expected the link in the history:\n%s", text)
+	}
+}
+
+func TestConvertOneOnOneTodos(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "API-7", "Pagination for the search API", "work")
+	createTestTask(t, js, "SHARE-THE-ROADMAP", "Share the roadmap", "work")
+	task, _ := js.loadTask("SHARE-THE-ROADMAP")
+	task.Status = "completed"
+	js.saveTask(task)
+
+	// Saved before the task existed, so nothing was linked
+	js.saveOneOnOne(&OneOnOne{Date: "2026-03-14", Person: "Sam", Todos: []string{"Check API-7 edge cases", "Share the roadmap", "Book the team offsite"}})
+	js.saveOneOnOne(&OneOnOne{Date: "2026-03-14", Person: "Dana"})
+
+	convert := func(args map[string]interface{}) (TodoConversion, string) {
+		result, _ := js.ConvertOneOnOneTodos(ctx, CreateMockRequest(args))
+		text := result.Content[0].(mcp.TextContent).Text
+		var conversion TodoConversion
+		json.Unmarshal([]byte(text), &conversion)
+		return conversion, text
+	}
+	if _, text := convert(map[string]interface{}{"date": "2026-03-14"}); !strings.Contains(text, "2 one-on-ones on 2026-03-14: pass person (one of: Dana, Sam)") {
+		t.Errorf("Expected the person asked for, got %s", text)
+	}
+
+	dry, _ := convert(map[string]interface{}{"date": "2026-03-14", "person": "sam", "dry_run": "true"})
+	if len(dry.Linked) != 1 || len(dry.Created) != 2 || dry.Created[1].TaskID != "BOOK-THE-TEAM-OFFSITE" {
+		t.Fatalf("Unexpected dry run: %+v", dry)
+	}
+	if _, err := js.loadTask("BOOK-THE-TEAM-OFFSITE"); err == nil {
+		t.Fatal("Expected a dry run to create nothing")
+	}
+
+	// The completed task's title only covers the todo, it is not linked
+	convert(map[string]interface{}{"date": "2026-03-14", "person": "Sam"})
+	created, err := js.loadTask("SHARE-THE-ROADMAP-2")
+	if err != nil || created.Title != "Share the roadmap" || created.Entries[1].Type != "action_item" ||
+		created.Entries[1].Content != "Action item from the 2026-03-14 one-on-one with Sam: Share the roadmap" {
+		t.Fatalf("Expected a task for the todo, got %+v, %v", created, err)
+	}
+	if again, _ := convert(map[string]interface{}{"date": "2026-03-14", "person": "Sam"}); again.AlreadyLinked != 3 || len(again.Created)+len(again.Linked) != 0 {
+		t.Errorf("Expected every todo linked after converting, got %+v", again)
+	}
+
+	offsite, _ := js.loadTask("BOOK-THE-TEAM-OFFSITE")
+	offsite.Status = "completed"
+	js.saveTask(offsite)
+	history, _ := js.GetOneOnOneHistory(ctx, CreateMockRequest(map[string]interface{}{"person": "Sam"}))
+	text := history.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(text, "**Action Items:** 2 open, 1 closed") || !strings.Contains(text, "- [x] Book the team offsite (→ BOOK-THE-TEAM-OFFSITE, completed)") ||
+		!strings.Contains(text, "- [ ] Share the roadmap (→ SHARE-THE-ROADMAP-2, active)") {
+		t.Errorf("Expected action item status in the history:\n%s", text)
+	}
+}
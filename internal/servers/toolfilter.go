@@ -0,0 +1,49 @@
+package servers
+
+import (
+	"log"
+	"path/filepath"
+)
+
+// ToolFilter decides which MCP tools are registered, from tools.disabled in
+// config. It remembers which patterns matched so typos can be reported.
+type ToolFilter struct {
+	patterns []string
+	matched  map[string]bool
+}
+
+// ToolFilter reads tools.disabled. An unreadable config disables nothing, so a
+// broken file never hides tools.
+func (js *JournalService) ToolFilter() *ToolFilter {
+	filter := &ToolFilter{matched: make(map[string]bool)}
+	config, err := js.loadConfiguration()
+	if err != nil {
+		log.Printf("Failed to load configuration, no tools disabled: %v", err)
+		return filter
+	}
+	filter.patterns = config.Tools.Disabled
+	return filter
+}
+
+// Enabled reports whether a tool should be registered
+func (f *ToolFilter) Enabled(name string) bool {
+	enabled := true
+	for _, pattern := range f.patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			f.matched[pattern] = true
+			enabled = false
+		}
+	}
+	return enabled
+}
+
+// Unmatched lists the patterns that have not matched any tool
+func (f *ToolFilter) Unmatched() []string {
+	var unmatched []string
+	for _, pattern := range f.patterns {
+		if !f.matched[pattern] {
+			unmatched = append(unmatched, pattern)
+		}
+	}
+	return unmatched
+}
@@ -0,0 +1,32 @@
+package servers
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestToolFilter(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	if filter := js.ToolFilter(); !filter.Enabled("restore_data_backup") {
+		t.Error("Expected every tool enabled without tools.disabled")
+	}
+
+	config := defaultConfiguration()
+	config.Tools.Disabled = []string{"*github*", "restore_data_backup", "import_notion"}
+	js.saveConfiguration(config)
+
+	filter := js.ToolFilter()
+	for name, enabled := range map[string]bool{"sync_github_issues": false, "restore_data_backup": false, "create_task": true} {
+		if filter.Enabled(name) != enabled {
+			t.Errorf("Expected %s enabled=%v", name, enabled)
+		}
+	}
+	if unmatched := filter.Unmatched(); !slices.Equal(unmatched, []string{"import_notion"}) {
+		t.Errorf("Expected the unused pattern reported, got %v", unmatched)
+	}
+
+	config.Tools.Disabled = []string{"import_[a-"}
+	if err := js.validateConfiguration(config); err == nil {
+		t.Error("Expected a malformed pattern to fail validation")
+	}
+}
@@ -0,0 +1,182 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TrashedTask is a deleted task kept in trash/ so it can be restored
+type TrashedTask struct {
+	DeletedAt time.Time `json:"deleted_at"`
+	Reason    string    `json:"reason,omitempty"`
+	Task      *Task     `json:"task"`
+}
+
+// DeletedTaskSummary describes a trashed task in list_deleted_tasks output
+type DeletedTaskSummary struct {
+	TaskID    string    `json:"task_id"`
+	Title     string    `json:"title"`
+	Type      string    `json:"type"`
+	Status    string    `json:"status"`
+	Entries   int       `json:"entries"`
+	DeletedAt time.Time `json:"deleted_at"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+func (js *JournalService) trashDir() string {
+	return filepath.Join(js.dataDir(), "trash")
+}
+
+// trashItem is a trashed task together with the file it was read from
+type trashItem struct {
+	path string
+	TrashedTask
+}
+
+// loadTrash returns every trashed task, most recently deleted first
+func (js *JournalService) loadTrash() ([]trashItem, error) {
+	files, err := os.ReadDir(js.trashDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var items []trashItem
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		item := trashItem{path: filepath.Join(js.trashDir(), file.Name())}
+		data, err := js.readDataFile(item.path)
+		if err != nil {
+			continue
+		}
+		if err := json.Unmarshal(data, &item.TrashedTask); err != nil || item.Task == nil {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+	return items, nil
+}
+
+// DeleteTask moves a task into trash/ so it no longer appears in the journal but can be restored
+func (js *JournalService) DeleteTask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	defer js.lockTask(taskID)()
+	task, err := js.loadTask(taskID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Task not found: %s", taskID)), nil
+	}
+
+	if err := js.trashTask(task, request.GetString("reason", "")); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to delete task: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Moved task %s (%s) to trash. Use restore_task to recover it.", taskID, task.Title)), nil
+}
+
+// trashTask moves a task into trash/ and removes it from task storage
+func (js *JournalService) trashTask(task *Task, reason string) error {
+	if err := os.MkdirAll(js.trashDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create trash directory: %w", err)
+	}
+
+	trashed := TrashedTask{
+		DeletedAt: time.Now(),
+		Reason:    reason,
+		Task:      task,
+	}
+	data, err := json.MarshalIndent(trashed, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize task: %w", err)
+	}
+
+	trashPath := filepath.Join(js.trashDir(), fmt.Sprintf("%s_%d.json", task.ID, trashed.DeletedAt.UnixNano()))
+	if err := js.writeDataFile(trashPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to move task to trash: %w", err)
+	}
+
+	if err := js.storage().DeleteTask(task.ID); err != nil {
+		os.Remove(trashPath)
+		return err
+	}
+	return nil
+}
+
+// ListDeletedTasks lists tasks in the trash, most recently deleted first
+func (js *JournalService) ListDeletedTasks(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	trashed, err := js.loadTrash()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read trash: %v", err)), nil
+	}
+
+	summaries := []DeletedTaskSummary{}
+	for _, item := range trashed {
+		summaries = append(summaries, DeletedTaskSummary{
+			TaskID:    item.Task.ID,
+			Title:     item.Task.Title,
+			Type:      item.Task.Type,
+			Status:    item.Task.Status,
+			Entries:   len(item.Task.Entries),
+			DeletedAt: item.DeletedAt,
+			Reason:    item.Reason,
+		})
+	}
+
+	resultJSON, _ := json.MarshalIndent(summaries, "", "  ")
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+// RestoreTask moves the most recently deleted copy of a task back out of the trash
+func (js *JournalService) RestoreTask(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	taskID, err := request.RequireString("task_id")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	defer js.lockTask(taskID)()
+	trashed, err := js.loadTrash()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to read trash: %v", err)), nil
+	}
+
+	for _, item := range trashed {
+		if item.Task.ID != taskID {
+			continue
+		}
+
+		if _, err := js.loadTask(taskID); err == nil {
+			return mcp.NewToolResultError(fmt.Sprintf("A task with ID %s already exists; delete or rename it before restoring", taskID)), nil
+		}
+
+		item.Task.Updated = time.Now()
+		if err := js.saveTask(item.Task); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to restore task: %v", err)), nil
+		}
+		if err := os.Remove(item.path); err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Task restored but could not be removed from trash: %v", err)), nil
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Restored task %s (%s) with %d entries", taskID, item.Task.Title, len(item.Task.Entries))), nil
+	}
+
+	return mcp.NewToolResultError(fmt.Sprintf("No deleted task found with ID: %s", taskID)), nil
+}
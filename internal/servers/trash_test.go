@@ -0,0 +1,64 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestDeleteAndRestoreTask(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	createTestTask(t, js, "TRASH-1", "Throwaway spike", "investigation")
+
+	result, _ := js.DeleteTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "TRASH-1", "reason": "duplicate"}))
+	if result.IsError {
+		t.Fatalf("Unexpected error: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	if _, err := js.loadTask("TRASH-1"); err == nil {
+		t.Error("Expected task to be removed from the journal")
+	}
+
+	result, _ = js.ListDeletedTasks(ctx, CreateMockRequest(map[string]interface{}{}))
+	var deleted []DeletedTaskSummary
+	json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &deleted)
+	if len(deleted) != 1 || deleted[0].TaskID != "TRASH-1" || deleted[0].Reason != "duplicate" {
+		t.Fatalf("Unexpected trash listing: %+v", deleted)
+	}
+
+	result, _ = js.RestoreTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "TRASH-1"}))
+	if result.IsError {
+		t.Fatalf("Unexpected restore error: %s", result.Content[0].(mcp.TextContent).Text)
+	}
+	task, err := js.loadTask("TRASH-1")
+	if err != nil || len(task.Entries) != 1 {
+		t.Fatalf("Expected restored task with its entries (err: %v)", err)
+	}
+
+	result, _ = js.RestoreTask(ctx, CreateMockRequest(map[string]interface{}{"task_id": "TRASH-1"}))
+	if !result.IsError {
+		t.Error("Expected restore to fail once the trash is empty")
+	}
+}
+
+func TestWebDeleteTask(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	createTestTask(t, js, "WEB-1", "Web task", "work")
+
+	ws := &WebServer{journalService: js}
+	router := mux.NewRouter()
+	ws.setupRoutes(router)
+
+	for _, expected := range []int{http.StatusOK, http.StatusBadRequest} {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, "/api/tasks/WEB-1", nil))
+		if recorder.Code != expected {
+			t.Errorf("Expected status %d, got %d: %s", expected, recorder.Code, recorder.Body.String())
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Usage statistics stay in the data directory. Nothing here is ever sent anywhere;
+// they only feed generate_usage_report.
+
+// UsageStats counts tool calls and samples storage size once a day
+type UsageStats struct {
+	Since   time.Time             `json:"since"`
+	Tools   map[string]*ToolUsage `json:"tools"`
+	Storage []StorageSample       `json:"storage,omitempty"`
+}
+
+// ToolUsage counts calls to one tool
+type ToolUsage struct {
+	Calls    int       `json:"calls"`
+	Errors   int       `json:"errors"`
+	LastUsed time.Time `json:"last_used"`
+}
+
+// StorageSample is the size of the data directory on one day
+type StorageSample struct {
+	Date  string `json:"date"`
+	Bytes int64  `json:"bytes"`
+	Tasks int    `json:"tasks"`
+}
+
+func (js *JournalService) usagePath() string {
+	return filepath.Join(js.dataDir(), ".journal-mcp", "usage.json")
+}
+
+func (js *JournalService) loadUsage() (*UsageStats, error) {
+	stats := &UsageStats{Tools: make(map[string]*ToolUsage)}
+	data, err := os.ReadFile(js.usagePath())
+	if os.IsNotExist(err) {
+		return stats, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, stats); err != nil {
+		return nil, err
+	}
+	if stats.Tools == nil {
+		stats.Tools = make(map[string]*ToolUsage)
+	}
+	return stats, nil
+}
+
+func (js *JournalService) saveUsage(stats *UsageStats) error {
+	if err := os.MkdirAll(filepath.Dir(js.usagePath()), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(js.usagePath(), data, 0644)
+}
+
+// recordToolCall counts a call and takes the day's storage sample on the first call of a day
+func (js *JournalService) recordToolCall(name string, failed bool, now time.Time) error {
+	defer lockFile(js.usagePath())()
+
+	stats, err := js.loadUsage()
+	if err != nil {
+		return err
+	}
+	if stats.Since.IsZero() {
+		stats.Since = now
+	}
+	usage := stats.Tools[name]
+	if usage == nil {
+		usage = &ToolUsage{}
+		stats.Tools[name] = usage
+	}
+	usage.Calls++
+	if failed {
+		usage.Errors++
+	}
+	usage.LastUsed = now
+
+	today := now.Format("2006-01-02")
+	if len(stats.Storage) == 0 || stats.Storage[len(stats.Storage)-1].Date != today {
+		bytes, _ := dirSizes(js.dataDir())
+		stats.Storage = append(stats.Storage, StorageSample{Date: today, Bytes: sumSizes(bytes), Tasks: js.countTaskFiles()})
+	}
+
+	return js.saveUsage(stats)
+}
+
+// UsageMiddleware records each tool call for the local usage report
+func (js *JournalService) UsageMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, request)
+		failed := err != nil || (result != nil && result.IsError)
+		js.recordToolCall(request.Params.Name, failed, time.Now())
+		return result, err
+	}
+}
+
+// dirSizes returns the bytes under each top-level entry of dir
+func dirSizes(dir string) (map[string]int64, error) {
+	sizes := make(map[string]int64)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		top, _, _ := strings.Cut(filepath.ToSlash(rel), "/")
+		sizes[top] += info.Size()
+		return nil
+	})
+	return sizes, err
+}
+
+func sumSizes(sizes map[string]int64) int64 {
+	var total int64
+	for _, size := range sizes {
+		total += size
+	}
+	return total
+}
+
+func (js *JournalService) countTaskFiles() int {
+	ids := make(map[string]bool)
+	for _, pattern := range []string{"*.json", "*.md"} {
+		files, _ := filepath.Glob(filepath.Join(js.dataDir(), "tasks", pattern))
+		for _, file := range files {
+			ids[strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))] = true
+		}
+	}
+	return len(ids)
+}
+
+// formatBytes renders a size as B, KB, MB or GB
+func formatBytes(bytes int64) string {
+	switch {
+	case bytes >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(bytes)/(1<<30))
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/(1<<20))
+	case bytes >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", bytes)
+	}
+}
+
+// GenerateUsageReport summarizes locally recorded tool usage, untouched tools
+// and storage growth to help tune the configuration. It never leaves the machine.
+func (js *JournalService) GenerateUsageReport(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	stats, err := js.loadUsage()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load usage statistics: %v", err)), nil
+	}
+	sizes, _ := dirSizes(js.dataDir())
+	total := sumSizes(sizes)
+
+	var md strings.Builder
+	md.WriteString("# Journal Usage Report\n\n")
+	md.WriteString("_Generated locally from `.journal-mcp/usage.json`. These statistics never leave this machine and are never transmitted anywhere._\n\n")
+
+	calls := 0
+	names := sortedKeys(stats.Tools)
+	for _, name := range names {
+		calls += stats.Tools[name].Calls
+	}
+	if !stats.Since.IsZero() {
+		md.WriteString(fmt.Sprintf("Tracking since %s: %d calls across %d tools.\n\n", stats.Since.Format("2006-01-02"), calls, len(names)))
+	}
+
+	// Tools used, most called first
+	sort.SliceStable(names, func(i, j int) bool { return stats.Tools[names[i]].Calls > stats.Tools[names[j]].Calls })
+	md.WriteString("## Tools used\n")
+	if len(names) == 0 {
+		md.WriteString("No tool calls recorded yet.\n")
+	} else {
+		md.WriteString("| Tool | Calls | Errors | Last used |\n|------|-------|--------|-----------|\n")
+		for _, name := range names {
+			usage := stats.Tools[name]
+			md.WriteString(fmt.Sprintf("| %s | %d | %d | %s |\n", name, usage.Calls, usage.Errors, usage.LastUsed.Format("2006-01-02")))
+		}
+	}
+
+	if len(js.Tools) > 0 {
+		var unused []string
+		for _, name := range js.Tools {
+			if stats.Tools[name] == nil {
+				unused = append(unused, name)
+			}
+		}
+		sort.Strings(unused)
+		md.WriteString(fmt.Sprintf("\n## Never used (%d of %d tools)\n", len(unused), len(js.Tools)))
+		if len(unused) == 0 {
+			md.WriteString("Every tool has been used at least once.\n")
+		} else {
+			md.WriteString(strings.Join(unused, ", ") + "\n")
+		}
+	}
+
+	md.WriteString(fmt.Sprintf("\n## Storage (%s)\n", formatBytes(total)))
+	dirs := sortedKeys(sizes)
+	sort.SliceStable(dirs, func(i, j int) bool { return sizes[dirs[i]] > sizes[dirs[j]] })
+	for _, dir := range dirs {
+		md.WriteString(fmt.Sprintf("- %s: %s\n", dir, formatBytes(sizes[dir])))
+	}
+	if len(stats.Storage) >= 2 {
+		first, last := stats.Storage[0], stats.Storage[len(stats.Storage)-1]
+		days := js.parseDateSafely(last.Date).Sub(js.parseDateSafely(first.Date)).Hours() / 24
+		md.WriteString(fmt.Sprintf("\nGrowth: %s on %s to %s on %s (%+d tasks)", formatBytes(first.Bytes), first.Date, formatBytes(last.Bytes), last.Date, last.Tasks-first.Tasks))
+		if days >= 1 {
+			md.WriteString(fmt.Sprintf(", about %s per day", formatBytes(int64(float64(last.Bytes-first.Bytes)/days))))
+		}
+		md.WriteString("\n")
+	}
+
+	var suggestions []string
+	if total > 0 && sizes["backups"]*2 > total {
+		suggestions = append(suggestions, "Backups take more than half the storage; lower backup.max_backups or move backup.backup_location elsewhere.")
+	}
+	for _, name := range names {
+		if usage := stats.Tools[name]; usage.Calls >= 5 && usage.Errors*2 >= usage.Calls {
+			suggestions = append(suggestions, fmt.Sprintf("%s fails on %d of %d calls; check its configuration or credentials.", name, usage.Errors, usage.Calls))
+		}
+	}
+	if len(suggestions) > 0 {
+		md.WriteString("\n## Suggestions\n")
+		for _, suggestion := range suggestions {
+			md.WriteString("- " + suggestion + "\n")
+		}
+	}
+
+	markdown, err := js.styleMarkdown(request.GetString("style", ""), md.String())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(markdown), nil
+}
@@ -0,0 +1,80 @@
+package servers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestUsageMiddleware(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+
+	handler := js.UsageMiddleware(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if request.GetString("fail", "") == "true" {
+			return mcp.NewToolResultError("boom"), nil
+		}
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	request := CreateMockRequest(map[string]interface{}{})
+	request.Params.Name = "list_tasks"
+	handler(ctx, request)
+	handler(ctx, request)
+	failing := CreateMockRequest(map[string]interface{}{"fail": "true"})
+	failing.Params.Name = "sync_with_github"
+	handler(ctx, failing)
+
+	stats, err := js.loadUsage()
+	if err != nil {
+		t.Fatalf("Failed to load usage: %v", err)
+	}
+	if stats.Tools["list_tasks"].Calls != 2 || stats.Tools["list_tasks"].Errors != 0 {
+		t.Errorf("Unexpected list_tasks usage: %+v", stats.Tools["list_tasks"])
+	}
+	if stats.Tools["sync_with_github"].Errors != 1 {
+		t.Errorf("Expected the failed call to be counted, got %+v", stats.Tools["sync_with_github"])
+	}
+	if len(stats.Storage) != 1 {
+		t.Errorf("Expected one storage sample per day, got %d", len(stats.Storage))
+	}
+}
+
+func TestGenerateUsageReport(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	ctx := context.Background()
+	js.Tools = []string{"create_task", "list_tasks", "get_brag_doc"}
+
+	createTestTask(t, js, "TASK-1", "Usage task", "feature")
+	os.MkdirAll(filepath.Join(tempDir, "backups"), 0755)
+	os.WriteFile(filepath.Join(tempDir, "backups", "old.zip"), make([]byte, 64*1024), 0644)
+
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	js.recordToolCall("create_task", false, start)
+	for i := 0; i < 5; i++ {
+		js.recordToolCall("list_tasks", i < 3, start.AddDate(0, 0, 10))
+	}
+
+	result, _ := js.GenerateUsageReport(ctx, CreateMockRequest(map[string]interface{}{}))
+	text := result.Content[0].(mcp.TextContent).Text
+
+	for _, want := range []string{
+		"Tracking since 2026-03-01: 6 calls across 2 tools",
+		"| list_tasks | 5 | 3 | 2026-03-11 |",
+		"## Never used (1 of 3 tools)\nget_brag_doc",
+		"- backups: 64.0 KB",
+		"Growth:",
+		"lower backup.max_backups",
+		"list_tasks fails on 3 of 5 calls",
+		"never transmitted",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected report to contain %q, got:\n%s", want, text)
+		}
+	}
+}
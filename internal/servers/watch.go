@@ -0,0 +1,406 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	changeTaskChanged  = "task_changed"
+	changeTaskRemoved  = "task_removed"
+	changeFileChanged  = "file_changed"
+	changeFileRemoved  = "file_removed"
+	changeInvalidFile  = "invalid_file"
+	changeConflictCopy = "conflict_copy"
+
+	// watchDebounce lets an editor or sync tool finish writing before a file is read
+	watchDebounce = 500 * time.Millisecond
+)
+
+// watchedDirs are the data directories edited by hand or synced from other
+// machines. Internal state under .journal-mcp, backups and the event log are
+// only written by the server.
+var watchedDirs = []string{"tasks", "daily", "one-on-ones", "meetings", "weekly-reviews", "goals", "habits"}
+
+// ChangeEvent reports a journal file changed outside the server
+type ChangeEvent struct {
+	Time   time.Time `json:"time"`
+	Type   string    `json:"type"`
+	Path   string    `json:"path"` // relative to the data directory
+	TaskID string    `json:"task_id,omitempty"`
+	Error  string    `json:"error,omitempty"` // invalid_file only
+}
+
+// ownWrites holds a digest of the last bytes the server wrote to each journal
+// file, so the watcher can tell its own writes from external edits. A removal
+// is recorded as an empty digest.
+var ownWrites sync.Map
+
+func fileDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// noteOwnWrite records data as written by the server; call it before writing
+func noteOwnWrite(path string, data []byte) {
+	ownWrites.Store(filepath.Clean(path), fileDigest(data))
+}
+
+// removeDataFile removes a journal file, recording the removal as the server's own
+func removeDataFile(path string) error {
+	ownWrites.Store(filepath.Clean(path), "")
+	return os.Remove(path)
+}
+
+// isOwnChange reports whether a file's current state is the server's last write
+func isOwnChange(path string, data []byte, exists bool) bool {
+	recorded, ok := ownWrites.Load(filepath.Clean(path))
+	if !ok {
+		return false
+	}
+	if !exists {
+		return recorded == ""
+	}
+	return recorded == fileDigest(data)
+}
+
+// changeHub fans change events out to WebSocket subscribers
+type changeHub struct {
+	mu          sync.Mutex
+	subscribers map[chan ChangeEvent]bool
+}
+
+var changes = &changeHub{subscribers: make(map[chan ChangeEvent]bool)}
+
+// subscribe returns a channel of change events and the function that ends the subscription
+func (h *changeHub) subscribe() (<-chan ChangeEvent, func()) {
+	events := make(chan ChangeEvent, 64)
+	h.mu.Lock()
+	h.subscribers[events] = true
+	h.mu.Unlock()
+
+	return events, func() {
+		h.mu.Lock()
+		delete(h.subscribers, events)
+		h.mu.Unlock()
+	}
+}
+
+// publish delivers event to every subscriber. A subscriber too slow to keep up
+// misses events rather than stalling the watcher.
+func (h *changeHub) publish(event ChangeEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for events := range h.subscribers {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
+
+// Watcher follows the data directory for edits made outside the server, by
+// hand or by sync tools such as Dropbox and Syncthing. Each changed file is
+// validated; valid task files refresh the search index and the SQLite mirror,
+// and invalid ones are left alone and reported as a notification. Every change
+// is published to WebSocket subscribers and posted to the watch.webhooks URLs.
+//
+// The watcher follows the profile active when it starts.
+type Watcher struct {
+	js       *JournalService
+	debounce time.Duration
+}
+
+// NewWatcher creates a watcher for the active data directory
+func NewWatcher(js *JournalService) *Watcher {
+	return &Watcher{js: js.snapshot(), debounce: watchDebounce}
+}
+
+// Start watches the data directory until ctx is cancelled. It does nothing
+// unless watch.enabled is on.
+func (w *Watcher) Start(ctx context.Context) error {
+	config, err := w.js.loadConfiguration()
+	if err != nil {
+		return err
+	}
+	if !config.Watch.Enabled {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	// The data directory itself is watched to pick up watched directories created later
+	if err := watcher.Add(w.js.dataDir()); err != nil {
+		watcher.Close()
+		return err
+	}
+	for _, dir := range watchedDirs {
+		path := filepath.Join(w.js.dataDir(), dir)
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			if err := watcher.Add(path); err != nil {
+				watcher.Close()
+				return err
+			}
+		}
+	}
+
+	go w.run(ctx, watcher)
+	return nil
+}
+
+func (w *Watcher) run(ctx context.Context, watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+
+	pending := make(map[string]bool)
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Dir(event.Name) == filepath.Clean(w.js.dataDir()) {
+				if event.Has(fsnotify.Create) && slices.Contains(watchedDirs, filepath.Base(event.Name)) {
+					if err := watcher.Add(event.Name); err != nil {
+						log.Printf("Failed to watch %s: %v", event.Name, err)
+					}
+				}
+				continue
+			}
+			if !watchedFile(event.Name) {
+				continue
+			}
+			pending[event.Name] = true
+			timer.Reset(w.debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("Data directory watcher error: %v", err)
+		case <-timer.C:
+			for _, path := range sortedKeys(pending) {
+				if event := w.handleChange(path); event != nil {
+					w.publish(*event)
+				}
+			}
+			clear(pending)
+		}
+	}
+}
+
+// watchedFile reports whether a file in a watched directory holds journal
+// data, as opposed to an editor swap file or an atomic write's temp file
+func watchedFile(path string) bool {
+	name := filepath.Base(path)
+	if strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") {
+		return false
+	}
+	if filepath.Base(filepath.Dir(path)) == "tasks" && strings.HasSuffix(name, ".md") {
+		return true
+	}
+	return strings.HasSuffix(name, ".json")
+}
+
+// handleChange validates a changed file and refreshes what depends on it. It
+// returns nil for the server's own writes.
+func (w *Watcher) handleChange(path string) *ChangeEvent {
+	raw, err := os.ReadFile(path)
+	exists := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		log.Printf("Failed to read changed file %s: %v", path, err)
+		return nil
+	}
+	if isOwnChange(path, raw, exists) {
+		return nil
+	}
+
+	rel, _ := filepath.Rel(w.js.dataDir(), path)
+	event := &ChangeEvent{Time: time.Now(), Path: filepath.ToSlash(rel)}
+	if filepath.Base(filepath.Dir(path)) != "tasks" {
+		event.Type = changeFileRemoved
+		if exists {
+			event.Type = changeFileChanged
+			if err := w.validateJSON(raw); err != nil {
+				w.reject(event, err)
+			}
+		}
+		return event
+	}
+
+	name := filepath.Base(path)
+	if taskID, conflicted := conflictCopyTaskID(name); conflicted {
+		event.Type, event.TaskID = changeConflictCopy, taskID
+		if exists {
+			w.js.notify("conflict_copy", taskID, fmt.Sprintf("A sync tool left a conflicted copy of %s (%s); run resolve_conflicts to merge it", taskID, name))
+		}
+		return event
+	}
+
+	event.TaskID = strings.TrimSuffix(name, filepath.Ext(name))
+	if !exists {
+		w.refreshTask(event)
+		return event
+	}
+
+	task, err := w.parseTask(name, raw)
+	if err == nil && task.ID != event.TaskID {
+		err = fmt.Errorf("task ID %s does not match the file name", task.ID)
+	}
+	if err == nil && !slices.Contains([]string{"active", "completed", "paused", "blocked", "someday"}, task.Status) {
+		err = fmt.Errorf("invalid status %q", task.Status)
+	}
+	if err != nil {
+		w.reject(event, err)
+		return event
+	}
+
+	w.refreshTask(event)
+	return event
+}
+
+// validateJSON checks a changed file outside tasks/ is readable JSON
+func (w *Watcher) validateJSON(raw []byte) error {
+	data, err := w.js.decryptData(raw)
+	if err != nil {
+		return err
+	}
+	if !json.Valid(data) {
+		return fmt.Errorf("not valid JSON")
+	}
+	return nil
+}
+
+func (w *Watcher) parseTask(name string, raw []byte) (*Task, error) {
+	data, err := w.js.decryptData(raw)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(name, ".md") {
+		return w.js.parseTaskFile(data)
+	}
+	var task Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// reject marks an event invalid and leaves a notification for the user
+func (w *Watcher) reject(event *ChangeEvent, err error) {
+	event.Type, event.Error = changeInvalidFile, err.Error()
+	w.js.notify("invalid_file", event.TaskID, fmt.Sprintf("%s was changed outside the server and could not be read: %v", event.Path, err))
+}
+
+// refreshTask re-indexes and re-mirrors a task from storage after its file
+// changed. An edited markdown copy under storage.format "mirror" is written
+// back to the JSON file first. A change to a copy storage does not read from,
+// such as the markdown copy under storage.format "both", is reported as a file
+// change.
+func (w *Watcher) refreshTask(event *ChangeEvent) {
+	config, err := w.js.loadConfiguration()
+	if err != nil {
+		config = defaultConfiguration()
+	}
+
+	if config.Storage.Format == "mirror" && filepath.Ext(event.Path) == ".md" {
+		if mm, err := w.js.markdownMirror(); err == nil {
+			if _, err := w.js.pullMarkdownEdit(mm, event.TaskID); err != nil {
+				log.Printf("Failed to pull markdown edits into task %s: %v", event.TaskID, err)
+			}
+		}
+	}
+
+	task, err := w.js.loadTask(event.TaskID)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Failed to reload task %s: %v", event.TaskID, err)
+		}
+		// The storage file is gone, so the task is
+		event.Type = changeTaskRemoved
+		w.js.unindexTask(event.TaskID)
+		if config.Storage.SQLiteMirror != "" {
+			if err := w.js.withSQLiteMirror(w.js.sqliteMirrorPath(config.Storage.SQLiteMirror), func(tx *sql.Tx) error { return unmirrorTask(tx, event.TaskID) }); err != nil {
+				log.Printf("SQLite mirror delete failed for task %s: %v", event.TaskID, err)
+			}
+		}
+		return
+	}
+
+	authoritative := []string{".json"}
+	switch config.Storage.Format {
+	case "markdown":
+		authoritative = []string{".md"}
+	case "mirror":
+		authoritative = append(authoritative, ".md")
+	}
+	if !slices.Contains(authoritative, filepath.Ext(event.Path)) {
+		event.Type = changeFileChanged
+		if _, err := os.Stat(filepath.Join(w.js.dataDir(), event.Path)); os.IsNotExist(err) {
+			event.Type = changeFileRemoved
+		}
+		return
+	}
+
+	event.Type = changeTaskChanged
+	w.js.indexTask(task)
+	if config.Storage.SQLiteMirror != "" {
+		if err := w.js.withSQLiteMirror(w.js.sqliteMirrorPath(config.Storage.SQLiteMirror), func(tx *sql.Tx) error { return mirrorTask(tx, task) }); err != nil {
+			log.Printf("SQLite mirror update failed for task %s: %v", task.ID, err)
+		}
+	}
+}
+
+// publish sends an event to WebSocket subscribers and the configured webhooks
+func (w *Watcher) publish(event ChangeEvent) {
+	changes.publish(event)
+
+	config, err := w.js.loadConfiguration()
+	if err != nil {
+		return
+	}
+	for _, webhook := range config.Watch.Webhooks {
+		if err := w.js.postChangeEvent(webhook, event); err != nil {
+			log.Printf("Change webhook failed: %v", err)
+		}
+	}
+}
+
+// postChangeEvent posts an event as JSON to a webhook URL or secret: reference
+func (js *JournalService) postChangeEvent(webhook string, event ChangeEvent) error {
+	url, err := js.resolveSecretRef(webhook)
+	if err != nil || url == "" {
+		return fmt.Errorf("no URL for webhook %s", webhook)
+	}
+	data, _ := json.Marshal(event)
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchedFile(t *testing.T) {
+	for path, expected := range map[string]bool{
+		"/data/tasks/API-1.json":                true,
+		"/data/tasks/API-1.md":                  true,
+		"/data/daily/2026-03-04.json":           true,
+		"/data/daily/2026-03-04.md":             false,
+		"/data/tasks/.API-1.json.123.tmp":       false,
+		"/data/tasks/.API-1.json.swp":           false,
+		"/data/tasks/API-1.json~":               false,
+		"/data/one-on-ones/2026-03-04-sam.json": true,
+	} {
+		if watchedFile(path) != expected {
+			t.Errorf("Expected watchedFile(%s) = %v", path, expected)
+		}
+	}
+}
+
+func TestWatcherHandleChange(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+	w := NewWatcher(js)
+	taskPath := filepath.Join(tempDir, "tasks", "WA-1.json")
+	writeTask := func(task Task) {
+		data, _ := json.Marshal(task)
+		os.WriteFile(taskPath, data, 0644)
+	}
+	indexed := func(taskID, token string) bool {
+		index, err := js.currentSearchIndex()
+		return err == nil && slices.Contains(index.Tasks[taskID].Tokens, token)
+	}
+
+	createTestTask(t, js, "WA-1", "Rotate certificates", "work")
+	if event := w.handleChange(taskPath); event != nil {
+		t.Errorf("Expected the server's own write ignored, got %+v", event)
+	}
+	if !indexed("WA-1", "rotate") {
+		t.Fatal("Expected the task indexed")
+	}
+
+	// Edited by hand: the new word is searchable
+	task, _ := js.loadTask("WA-1")
+	task.Entries = append(task.Entries, Entry{ID: "wa-edit", Timestamp: time.Now(), Content: "Renewed the wildcard cert"})
+	writeTask(*task)
+	event := w.handleChange(taskPath)
+	if event == nil || event.Type != changeTaskChanged || event.TaskID != "WA-1" || event.Path != "tasks/WA-1.json" {
+		t.Fatalf("Unexpected event: %+v", event)
+	}
+	if !indexed("WA-1", "wildcard") {
+		t.Error("Expected the hand edit re-indexed")
+	}
+
+	// Invalid files are left alone and reported
+	os.WriteFile(taskPath, []byte(`{"id": "WA-1", "entries": [`), 0644)
+	if event := w.handleChange(taskPath); event == nil || event.Type != changeInvalidFile || event.Error == "" {
+		t.Errorf("Expected truncated JSON rejected, got %+v", event)
+	}
+	task.ID = "WA-2"
+	writeTask(*task)
+	if event := w.handleChange(taskPath); event == nil || event.Type != changeInvalidFile || !strings.Contains(event.Error, "does not match the file name") {
+		t.Errorf("Expected a mismatched ID rejected, got %+v", event)
+	}
+	task.ID, task.Status = "WA-1", "done"
+	writeTask(*task)
+	if event := w.handleChange(taskPath); event == nil || event.Type != changeInvalidFile || event.Error != `invalid status "done"` {
+		t.Errorf("Expected an unknown status rejected, got %+v", event)
+	}
+	notifications, _ := js.loadNotifications()
+	if len(notifications) != 3 || notifications[0].Kind != "invalid_file" || !strings.HasPrefix(notifications[0].Message, "tasks/WA-1.json was changed outside the server") {
+		t.Errorf("Expected a notification per invalid file, got %+v", notifications)
+	}
+
+	// Removed by a sync tool
+	os.Remove(taskPath)
+	if event := w.handleChange(taskPath); event == nil || event.Type != changeTaskRemoved {
+		t.Errorf("Expected the task removed, got %+v", event)
+	}
+	flushSearchIndex()
+	if index, _ := js.loadSearchIndex(); index != nil {
+		if _, ok := index.Tasks["WA-1"]; ok {
+			t.Error("Expected the removed task dropped from the index")
+		}
+	}
+
+	createTestTask(t, js, "WA-3", "Own delete", "work")
+	js.storage().DeleteTask("WA-3")
+	if event := w.handleChange(filepath.Join(tempDir, "tasks", "WA-3.json")); event != nil {
+		t.Errorf("Expected the server's own delete ignored, got %+v", event)
+	}
+
+	conflict := filepath.Join(tempDir, "tasks", "WA-3 (laptop's conflicted copy).json")
+	os.WriteFile(conflict, []byte(`{}`), 0644)
+	if event := w.handleChange(conflict); event == nil || event.Type != changeConflictCopy || event.TaskID != "WA-3" {
+		t.Errorf("Expected a conflicted copy reported, got %+v", event)
+	}
+
+	dailyPath := filepath.Join(tempDir, "daily", "2026-03-04.json")
+	os.WriteFile(dailyPath, []byte(`{"date": "2026-03-04"}`), 0644)
+	if event := w.handleChange(dailyPath); event == nil || event.Type != changeFileChanged || event.Path != "daily/2026-03-04.json" {
+		t.Errorf("Expected a daily log change, got %+v", event)
+	}
+}
+
+func TestWatcherStart(t *testing.T) {
+	js, tempDir := CreateTestJournalService(t)
+
+	posted := make(chan ChangeEvent, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event ChangeEvent
+		json.NewDecoder(r.Body).Decode(&event)
+		posted <- event
+	}))
+	defer server.Close()
+
+	config, _ := js.loadConfiguration()
+	config.Watch.Enabled = true
+	config.Watch.Webhooks = []string{server.URL}
+	js.saveConfiguration(config)
+
+	events, unsubscribe := changes.subscribe()
+	defer unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	w := NewWatcher(js)
+	w.debounce = 50 * time.Millisecond
+	if err := w.Start(ctx); err != nil {
+		t.Fatalf("Failed to start watcher: %v", err)
+	}
+
+	// A watched directory created after start is picked up
+	os.MkdirAll(filepath.Join(tempDir, "habits"), 0755)
+	time.Sleep(100 * time.Millisecond)
+	os.WriteFile(filepath.Join(tempDir, "habits", "stretch.json"), []byte(`{"id": "stretch"}`), 0644)
+
+	select {
+	case event := <-events:
+		if event.Type != changeFileChanged || event.Path != "habits/stretch.json" {
+			t.Errorf("Unexpected event: %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected a change event")
+	}
+	select {
+	case event := <-posted:
+		if event.Path != "habits/stretch.json" {
+			t.Errorf("Unexpected webhook event: %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the change posted to the webhook")
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"mime"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -83,12 +85,16 @@ func (ws *WebServer) setupRoutes(router *mux.Router) {
 	api.HandleFunc("/tasks/{id}/entries", ws.handleCreateTaskEntry).Methods("POST")
 	api.HandleFunc("/tasks/{id}/status", ws.handleUpdateTaskStatus).Methods("PUT")
 
+	// Entry attachments
+	api.HandleFunc("/attachments/{path:.+}", ws.handleGetAttachment).Methods("GET")
+
 	// Search endpoints
 	api.HandleFunc("/search", ws.handleSearch).Methods("GET")
 
 	// Analytics endpoints
 	api.HandleFunc("/analytics/overview", ws.handleAnalyticsOverview).Methods("GET")
 	api.HandleFunc("/analytics/report", ws.handleAnalyticsReport).Methods("GET")
+	api.HandleFunc("/analytics/raw", ws.handleAnalyticsRaw).Methods("GET")
 
 	// Export endpoints
 	api.HandleFunc("/export", ws.handleExport).Methods("GET")
@@ -97,6 +103,10 @@ func (ws *WebServer) setupRoutes(router *mux.Router) {
 	api.HandleFunc("/logs/daily/{date}", ws.handleGetDailyLog).Methods("GET")
 	api.HandleFunc("/logs/weekly/{date}", ws.handleGetWeeklyLog).Methods("GET")
 
+	// Print views
+	api.HandleFunc("/print/tasks", ws.handlePrintTasks).Methods("GET")
+	api.HandleFunc("/print/week/{date}", ws.handlePrintWeek).Methods("GET")
+
 	// One-on-One endpoints
 	api.HandleFunc("/one-on-ones", ws.handleGetOneOnOnes).Methods("GET")
 	api.HandleFunc("/one-on-ones", ws.handleCreateOneOnOne).Methods("POST")
@@ -118,6 +128,10 @@ func (ws *WebServer) setupRoutes(router *mux.Router) {
 	// Serve OpenAPI documentation
 	api.HandleFunc("/docs", ws.handleAPIDocs).Methods("GET")
 
+	// Journaling streaks and a README-friendly badge
+	api.HandleFunc("/streaks", ws.handleGetStreaks).Methods("GET")
+	api.HandleFunc("/badges/streak.svg", ws.handleStreakBadge).Methods("GET")
+
 	// Health check
 	api.HandleFunc("/health", ws.handleHealth).Methods("GET")
 }
@@ -206,15 +220,35 @@ func (ws *WebServer) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
 
 	updateData["task_id"] = taskID
 
-	// This would need a new MCP tool for updating task metadata
-	// For now, we'll return a not implemented error
-	http.Error(w, "Task update not implemented yet", http.StatusNotImplemented)
+	request := createMCPRequest(updateData)
+	result, err := ws.journalService.UpdateTask(r.Context(), request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ws.writeJSONResponse(w, result)
 }
 
 func (ws *WebServer) handleDeleteTask(w http.ResponseWriter, r *http.Request) {
-	// This would need a new MCP tool for deleting tasks
-	// For now, we'll return a not implemented error
-	http.Error(w, "Task deletion not implemented yet", http.StatusNotImplemented)
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	args := map[string]interface{}{
+		"task_id": taskID,
+	}
+	if reason := r.URL.Query().Get("reason"); reason != "" {
+		args["reason"] = reason
+	}
+
+	request := createMCPRequest(args)
+	result, err := ws.journalService.DeleteTask(r.Context(), request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ws.writeJSONResponse(w, result)
 }
 
 func (ws *WebServer) handleCreateTaskEntry(w http.ResponseWriter, r *http.Request) {
@@ -279,6 +313,11 @@ func (ws *WebServer) handleSearch(w http.ResponseWriter, r *http.Request) {
 	if dateTo := query.Get("date_to"); dateTo != "" {
 		args["date_to"] = dateTo
 	}
+	for _, name := range []string{"person", "fuzzy", "limit", "offset", "group_by_task"} {
+		if value := query.Get(name); value != "" {
+			args[name] = value
+		}
+	}
 
 	request := createMCPRequest(args)
 	result, err := ws.journalService.SearchEntries(r.Context(), request)
@@ -331,6 +370,35 @@ func (ws *WebServer) handleAnalyticsReport(w http.ResponseWriter, r *http.Reques
 	ws.writeJSONResponse(w, result)
 }
 
+// handleAnalyticsRaw serves tidy task-day records, e.g.
+// pd.read_csv("http://localhost:8080/api/analytics/raw?format=csv")
+func (ws *WebServer) handleAnalyticsRaw(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	args := map[string]interface{}{}
+	for _, name := range []string{"date_from", "date_to", "task_type", "format"} {
+		if value := query.Get(name); value != "" {
+			args[name] = value
+		}
+	}
+
+	request := createMCPRequest(args)
+	result, err := ws.journalService.GetRawAnalytics(r.Context(), request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if query.Get("format") == "csv" && !result.IsError {
+		w.Header().Set("Content-Type", "text/csv")
+		if textContent, ok := mcp.AsTextContent(result.Content[0]); ok {
+			w.Write([]byte(textContent.Text))
+		}
+		return
+	}
+	ws.writeJSONResponse(w, result)
+}
+
 // Export Handler
 
 func (ws *WebServer) handleExport(w http.ResponseWriter, r *http.Request) {
@@ -348,6 +416,20 @@ func (ws *WebServer) handleExport(w http.ResponseWriter, r *http.Request) {
 	if taskFilter := query.Get("task_filter"); taskFilter != "" {
 		args["task_filter"] = taskFilter
 	}
+	// Repeated or comma-separated, e.g. ?task_ids=PROJ-1,PROJ-2&tags=handoff
+	for _, name := range []string{"task_ids", "tags"} {
+		var values []interface{}
+		for _, value := range query[name] {
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					values = append(values, item)
+				}
+			}
+		}
+		if len(values) > 0 {
+			args[name] = values
+		}
+	}
 
 	request := createMCPRequest(args)
 	result, err := ws.journalService.ExportData(r.Context(), request)
@@ -377,6 +459,29 @@ func (ws *WebServer) handleExport(w http.ResponseWriter, r *http.Request) {
 
 // Log Handlers
 
+func (ws *WebServer) handleGetAttachment(w http.ResponseWriter, r *http.Request) {
+	relPath := mux.Vars(r)["path"]
+
+	data, err := ws.journalService.readAttachment(relPath)
+	if err != nil {
+		http.Error(w, "Attachment not found", http.StatusNotFound)
+		return
+	}
+
+	name := attachmentDownloadName(relPath, r.URL.Query().Get("name"))
+	// Only images, PDFs and plain text are shown in the browser; anything else, HTML included, is downloaded
+	mediaType := attachmentMediaType(name, data)
+	disposition := "attachment"
+	if strings.HasPrefix(mediaType, "image/") && !strings.HasPrefix(mediaType, "image/svg") ||
+		strings.HasPrefix(mediaType, "application/pdf") || strings.HasPrefix(mediaType, "text/plain") {
+		disposition = "inline"
+	}
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": name}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Write(data)
+}
+
 func (ws *WebServer) handleGetDailyLog(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	date := vars["date"]
@@ -384,6 +489,9 @@ func (ws *WebServer) handleGetDailyLog(w http.ResponseWriter, r *http.Request) {
 	args := map[string]interface{}{
 		"date": date,
 	}
+	if timezone := r.URL.Query().Get("timezone"); timezone != "" {
+		args["timezone"] = timezone
+	}
 
 	request := createMCPRequest(args)
 	result, err := ws.journalService.GetDailyLog(r.Context(), request)
@@ -402,6 +510,11 @@ func (ws *WebServer) handleGetWeeklyLog(w http.ResponseWriter, r *http.Request)
 	args := map[string]interface{}{
 		"week_start": date,
 	}
+	for _, name := range []string{"group_by", "order", "collapse_empty_days", "timezone"} {
+		if value := r.URL.Query().Get(name); value != "" {
+			args[name] = value
+		}
+	}
 
 	request := createMCPRequest(args)
 	result, err := ws.journalService.GetWeeklyLog(r.Context(), request)
@@ -413,6 +526,47 @@ func (ws *WebServer) handleGetWeeklyLog(w http.ResponseWriter, r *http.Request)
 	ws.writeJSONResponse(w, result)
 }
 
+// Print Handlers
+
+func (ws *WebServer) handlePrintTasks(w http.ResponseWriter, r *http.Request) {
+	var taskIDs []interface{}
+	for _, value := range r.URL.Query()["task_ids"] {
+		for _, id := range strings.Split(value, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				taskIDs = append(taskIDs, id)
+			}
+		}
+	}
+	ws.writePrintView(w, r, map[string]interface{}{"view": "tasks", "task_ids": taskIDs})
+}
+
+func (ws *WebServer) handlePrintWeek(w http.ResponseWriter, r *http.Request) {
+	ws.writePrintView(w, r, map[string]interface{}{"view": "week", "week_start": mux.Vars(r)["date"]})
+}
+
+// writePrintView serves a print view as an HTML page to print from the browser
+func (ws *WebServer) writePrintView(w http.ResponseWriter, r *http.Request, args map[string]interface{}) {
+	for _, name := range []string{"paper", "order", "timezone"} {
+		if value := r.URL.Query().Get(name); value != "" {
+			args[name] = value
+		}
+	}
+
+	result, err := ws.journalService.GetPrintView(r.Context(), createMCPRequest(args))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if result.IsError {
+		ws.writeJSONResponse(w, result)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if textContent, ok := mcp.AsTextContent(result.Content[0]); ok {
+		w.Write([]byte(textContent.Text))
+	}
+}
+
 // One-on-One Handlers
 
 func (ws *WebServer) handleGetOneOnOnes(w http.ResponseWriter, r *http.Request) {
@@ -422,6 +576,9 @@ func (ws *WebServer) handleGetOneOnOnes(w http.ResponseWriter, r *http.Request)
 	if limit := query.Get("limit"); limit != "" {
 		args["limit"] = limit
 	}
+	if person := query.Get("person"); person != "" {
+		args["person"] = person
+	}
 
 	request := createMCPRequest(args)
 	result, err := ws.journalService.GetOneOnOneHistory(r.Context(), request)
@@ -529,6 +686,8 @@ func (ws *WebServer) handleUpdateConfig(w http.ResponseWriter, r *http.Request)
 
 // WebSocket Handler for real-time updates
 
+// handleWebSocket streams a JSON ChangeEvent for each file changed outside the
+// server while the data directory watcher runs (watch.enabled)
 func (ws *WebServer) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	conn, err := ws.upgrader.Upgrade(w, r, nil)
 	if err != nil {
@@ -537,20 +696,29 @@ func (ws *WebServer) handleWebSocket(w http.ResponseWriter, r *http.Request) {
 	}
 	defer conn.Close()
 
-	// Handle WebSocket connection for real-time updates
-	// This is a basic implementation - in production you'd want
-	// to track connections and broadcast updates when tasks change
-	for {
-		messageType, message, err := conn.ReadMessage()
-		if err != nil {
-			log.Printf("WebSocket read error: %v", err)
-			break
+	events, unsubscribe := changes.subscribe()
+	defer unsubscribe()
+
+	// Messages from the client are ignored; reading only notices it going away
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
 		}
+	}()
 
-		// Echo back for now - implement real-time update logic here
-		if err := conn.WriteMessage(messageType, message); err != nil {
-			log.Printf("WebSocket write error: %v", err)
-			break
+	for {
+		select {
+		case <-closed:
+			return
+		case event := <-events:
+			if err := conn.WriteJSON(event); err != nil {
+				log.Printf("WebSocket write error: %v", err)
+				return
+			}
 		}
 	}
 }
@@ -564,7 +732,7 @@ func (ws *WebServer) handleAPIDocs(w http.ResponseWriter, r *http.Request) {
 		"info": map[string]interface{}{
 			"title":       "Journal MCP REST API",
 			"description": "REST API for the Journal MCP task management system",
-			"version":     "1.0.0",
+			"version":     Version,
 		},
 		"servers": []map[string]interface{}{
 			{"url": "/api", "description": "API server"},
@@ -576,8 +744,13 @@ func (ws *WebServer) handleAPIDocs(w http.ResponseWriter, r *http.Request) {
 				"post": map[string]interface{}{"summary": "Create a new task"},
 			},
 			"/tasks/{id}":         map[string]interface{}{"get": map[string]interface{}{"summary": "Get task by ID"}},
+			"/attachments/{path}": map[string]interface{}{"get": map[string]interface{}{"summary": "Download an entry attachment by its path"}},
 			"/search":             map[string]interface{}{"get": map[string]interface{}{"summary": "Search journal entries"}},
 			"/analytics/overview": map[string]interface{}{"get": map[string]interface{}{"summary": "Get analytics overview"}},
+			"/analytics/raw":      map[string]interface{}{"get": map[string]interface{}{"summary": "Get tidy task-day records (format=json or csv)"}},
+			"/print/tasks":        map[string]interface{}{"get": map[string]interface{}{"summary": "Print view of tasks, a page each (task_ids, paper=a4 or letter)"}},
+			"/print/week/{date}":  map[string]interface{}{"get": map[string]interface{}{"summary": "Print view of the week from date, a page per day (paper=a4 or letter)"}},
+			"/ws":                 map[string]interface{}{"get": map[string]interface{}{"summary": "WebSocket stream of files changed outside the server (watch.enabled)"}},
 		},
 	}
 
@@ -585,11 +758,41 @@ func (ws *WebServer) handleAPIDocs(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(docs)
 }
 
+// Streak Handlers
+
+func (ws *WebServer) handleGetStreaks(w http.ResponseWriter, r *http.Request) {
+	args := map[string]interface{}{}
+	if weeks := r.URL.Query().Get("weeks"); weeks != "" {
+		args["weeks"] = weeks
+	}
+
+	request := createMCPRequest(args)
+	result, err := ws.journalService.GetStreaks(r.Context(), request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ws.writeJSONResponse(w, result)
+}
+
+func (ws *WebServer) handleStreakBadge(w http.ResponseWriter, r *http.Request) {
+	streaks, err := ws.journalService.streaks(time.Now(), 1)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "no-cache, max-age=300")
+	w.Write([]byte(streakBadge(streaks)))
+}
+
 func (ws *WebServer) handleHealth(w http.ResponseWriter, r *http.Request) {
 	health := map[string]interface{}{
 		"status":    "healthy",
 		"timestamp": time.Now(),
-		"version":   "1.0.0",
+		"version":   Version,
 		"service":   "journal-mcp",
 	}
 
@@ -604,8 +807,14 @@ func (ws *WebServer) writeJSONResponse(w http.ResponseWriter, result *mcp.CallTo
 
 	if result.IsError {
 		w.WriteHeader(http.StatusBadRequest)
+		message := "request failed"
+		if len(result.Content) > 0 {
+			if textContent, ok := mcp.AsTextContent(result.Content[0]); ok {
+				message = textContent.Text
+			}
+		}
 		json.NewEncoder(w).Encode(map[string]interface{}{
-			"error": result.Content[0].(*mcp.TextContent).Text,
+			"error": message,
 		})
 		return
 	}
@@ -613,7 +822,7 @@ func (ws *WebServer) writeJSONResponse(w http.ResponseWriter, result *mcp.CallTo
 	// Parse the MCP result content
 	var responseData interface{}
 	if len(result.Content) > 0 {
-		if textContent, ok := result.Content[0].(*mcp.TextContent); ok {
+		if textContent, ok := mcp.AsTextContent(result.Content[0]); ok {
 			if err := json.Unmarshal([]byte(textContent.Text), &responseData); err != nil {
 				// If it's not valid JSON, return as plain text
 				responseData = map[string]interface{}{
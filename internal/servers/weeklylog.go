@@ -0,0 +1,251 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// weeklyGroupings are the group_by modes of get_weekly_log
+var weeklyGroupings = []string{"day", "task", "type"}
+
+// weeklyOrders are the ways get_weekly_log orders tasks within a group: by ID,
+// by their first entry, or most entries first
+var weeklyOrders = []string{"id", "time", "entries"}
+
+// weekActivity returns the daily activity for the seven days from start in loc,
+// read from the daily logs (when logged, i.e. loc is the zone they are bucketed
+// in) or gathered from the tasks, and the tasks by ID. Entry times are in loc.
+func (js *JournalService) weekActivity(start time.Time, loc *time.Location, logged bool) ([]DailyActivity, map[string]*Task) {
+	tasks := make(map[string]*Task)
+	if all, err := js.loadAllTasks(); err == nil {
+		for _, task := range all {
+			tasks[task.ID] = task
+		}
+	}
+
+	days := make([]DailyActivity, 0, 7)
+	for i := 0; i < 7; i++ {
+		date := start.AddDate(0, 0, i).Format("2006-01-02")
+		day := DailyActivity{Date: date, Tasks: make(map[string][]Entry)}
+		data, err := js.readDataFile(filepath.Join(js.dataDir(), "daily", date+".json"))
+		if logged && err == nil {
+			var stored DailyActivity
+			if json.Unmarshal(data, &stored) == nil && stored.Tasks != nil {
+				day.Tasks = stored.Tasks
+			}
+		} else {
+			for _, task := range tasks {
+				for _, entry := range task.Entries {
+					if entryDate(entry, loc) == date {
+						day.Tasks[task.ID] = append(day.Tasks[task.ID], entry)
+					}
+				}
+			}
+		}
+		for taskID, entries := range day.Tasks {
+			if len(entries) == 0 {
+				delete(day.Tasks, taskID)
+				continue
+			}
+			for i := range entries {
+				entries[i].Timestamp = entries[i].Timestamp.In(loc)
+			}
+			sortEntriesByTime(entries)
+		}
+		days = append(days, day)
+	}
+	return days, tasks
+}
+
+func sortEntriesByTime(entries []Entry) {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Timestamp.Before(entries[j].Timestamp) })
+}
+
+// orderTaskIDs orders the tasks of a group by ID, first entry or entry count
+func orderTaskIDs(entries map[string][]Entry, order string) []string {
+	ids := sortedKeys(entries)
+	switch order {
+	case "time":
+		sort.SliceStable(ids, func(i, j int) bool {
+			return entries[ids[i]][0].Timestamp.Before(entries[ids[j]][0].Timestamp)
+		})
+	case "entries":
+		sort.SliceStable(ids, func(i, j int) bool { return len(entries[ids[i]]) > len(entries[ids[j]]) })
+	}
+	return ids
+}
+
+// taskHeading is "ID: Title", or just the ID for tasks that no longer exist
+func taskHeading(taskID string, tasks map[string]*Task) string {
+	if task, ok := tasks[taskID]; ok {
+		return fmt.Sprintf("%s: %s", taskID, task.Title)
+	}
+	return taskID
+}
+
+// writeWeekByDay lists each day's entries by task. With collapse, runs of days
+// without activity share one heading.
+func writeWeekByDay(md *strings.Builder, days []DailyActivity, tasks map[string]*Task, order string, collapse bool) {
+	for i := 0; i < len(days); i++ {
+		day := days[i]
+		date, _ := time.Parse("2006-01-02", day.Date)
+		if len(day.Tasks) == 0 {
+			last := i
+			for collapse && last+1 < len(days) && len(days[last+1].Tasks) == 0 {
+				last++
+			}
+			if last > i {
+				lastDate, _ := time.Parse("2006-01-02", days[last].Date)
+				md.WriteString(fmt.Sprintf("## %s to %s (%s to %s)\n_No activity_\n\n",
+					day.Date, days[last].Date, date.Format("Monday"), lastDate.Format("Monday")))
+				i = last
+				continue
+			}
+			md.WriteString(fmt.Sprintf("## %s (%s)\n_No activity_\n\n", day.Date, date.Format("Monday")))
+			continue
+		}
+
+		md.WriteString(fmt.Sprintf("## %s (%s)\n", day.Date, date.Format("Monday")))
+		for _, taskID := range orderTaskIDs(day.Tasks, order) {
+			md.WriteString(fmt.Sprintf("### %s\n", taskHeading(taskID, tasks)))
+			for _, entry := range day.Tasks[taskID] {
+				md.WriteString(fmt.Sprintf("- %s: %s\n", entry.Timestamp.Format("15:04"), entry.Content))
+			}
+			md.WriteString("\n")
+		}
+	}
+}
+
+// weekEntriesByTask gathers the week's entries per task, in time order
+func weekEntriesByTask(days []DailyActivity) map[string][]Entry {
+	byTask := make(map[string][]Entry)
+	for _, day := range days {
+		for taskID, entries := range day.Tasks {
+			byTask[taskID] = append(byTask[taskID], entries...)
+		}
+	}
+	for taskID := range byTask {
+		sortEntriesByTime(byTask[taskID])
+	}
+	return byTask
+}
+
+func writeWeekTaskEntries(md *strings.Builder, entries []Entry) {
+	for _, entry := range entries {
+		md.WriteString(fmt.Sprintf("- %s: %s\n", entry.Timestamp.Format("Mon 15:04"), entry.Content))
+	}
+	md.WriteString("\n")
+}
+
+// writeWeekByTask lists the week's entries under each task
+func writeWeekByTask(md *strings.Builder, days []DailyActivity, tasks map[string]*Task, order string) {
+	byTask := weekEntriesByTask(days)
+	if len(byTask) == 0 {
+		md.WriteString("_No activity_\n\n")
+		return
+	}
+	for _, taskID := range orderTaskIDs(byTask, order) {
+		heading := taskHeading(taskID, tasks)
+		if task, ok := tasks[taskID]; ok {
+			heading += fmt.Sprintf(" (%s)", task.Type)
+		}
+		md.WriteString(fmt.Sprintf("## %s\n", heading))
+		writeWeekTaskEntries(md, byTask[taskID])
+	}
+}
+
+// writeWeekByType lists the week's entries by task type, then task. Types are
+// in name order, or most entries first with order=entries.
+func writeWeekByType(md *strings.Builder, days []DailyActivity, tasks map[string]*Task, order string) {
+	byType := make(map[string]map[string][]Entry)
+	totals := make(map[string]int)
+	for taskID, entries := range weekEntriesByTask(days) {
+		taskType := "unknown"
+		if task, ok := tasks[taskID]; ok && task.Type != "" {
+			taskType = task.Type
+		}
+		if byType[taskType] == nil {
+			byType[taskType] = make(map[string][]Entry)
+		}
+		byType[taskType][taskID] = entries
+		totals[taskType] += len(entries)
+	}
+	if len(byType) == 0 {
+		md.WriteString("_No activity_\n\n")
+		return
+	}
+
+	types := sortedKeys(byType)
+	if order == "entries" {
+		sort.SliceStable(types, func(i, j int) bool { return totals[types[i]] > totals[types[j]] })
+	}
+	for _, taskType := range types {
+		md.WriteString(fmt.Sprintf("## %s (%d entries)\n", capitalize(taskType), totals[taskType]))
+		for _, taskID := range orderTaskIDs(byType[taskType], order) {
+			md.WriteString(fmt.Sprintf("### %s\n", taskHeading(taskID, tasks)))
+			writeWeekTaskEntries(md, byType[taskType][taskID])
+		}
+	}
+}
+
+// weekStartOf returns the first day of the week (general.week_start, default
+// Monday) containing now, in the configured time zone
+func (js *JournalService) weekStartOf(now time.Time) time.Time {
+	return js.weekStartIn(now, js.location())
+}
+
+// weekStartIn returns the first day of the week containing now in loc
+func (js *JournalService) weekStartIn(now time.Time, loc *time.Location) time.Time {
+	return weekStartFrom(now.In(loc), js.firstWeekday())
+}
+
+// firstWeekday is general.week_start, default Monday
+func (js *JournalService) firstWeekday() time.Weekday {
+	if config, err := js.loadConfiguration(); err == nil && config.General.WeekStart != "" {
+		if day, ok := parseWeekday(config.General.WeekStart); ok {
+			return day
+		}
+	}
+	return time.Monday
+}
+
+// weekStartFrom returns midnight on the first day of t's week, for weeks starting on first
+func weekStartFrom(t time.Time, first time.Weekday) time.Time {
+	today := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return today.AddDate(0, 0, -((int(today.Weekday()) - int(first) + 7) % 7))
+}
+
+// weekLogFrom shows the weekly log for the week starting weeksAgo weeks before
+// the current one, passing the other get_weekly_log options through
+func (js *JournalService) weekLogFrom(ctx context.Context, request mcp.CallToolRequest, weeksAgo int) (*mcp.CallToolResult, error) {
+	loc, _, err := js.logLocation(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	args := map[string]interface{}{
+		"week_start": js.weekStartIn(time.Now(), loc).AddDate(0, 0, -7*weeksAgo).Format("2006-01-02"),
+	}
+	for _, name := range []string{"group_by", "order", "collapse_empty_days", "style", "timezone", "format"} {
+		if value := request.GetString(name, ""); value != "" {
+			args[name] = value
+		}
+	}
+	return js.GetWeeklyLog(ctx, createMCPRequest(args))
+}
+
+// GetCurrentWeekLog shows the weekly log for this week
+func (js *JournalService) GetCurrentWeekLog(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return js.weekLogFrom(ctx, request, 0)
+}
+
+// GetPreviousWeekLog shows the weekly log for last week
+func (js *JournalService) GetPreviousWeekLog(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return js.weekLogFrom(ctx, request, 1)
+}
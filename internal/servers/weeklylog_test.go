@@ -0,0 +1,158 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestWeeklyLogGrouping(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	monday := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	addEntries := func(id, title, taskType string, entries ...Entry) {
+		createTestTask(t, js, id, title, taskType)
+		task, _ := js.loadTask(id)
+		task.Entries = entries
+		js.saveTask(task)
+	}
+	addEntries("B-1", "Reading", "learning",
+		Entry{ID: "b1", Timestamp: monday.Add(time.Hour), Content: "Chapter one"},
+		Entry{ID: "b2", Timestamp: monday.AddDate(0, 0, 4), Content: "Chapter two"},
+	)
+	addEntries("A-1", "Deploy", "work",
+		Entry{ID: "a1", Timestamp: monday.Add(2 * time.Hour), Content: "Canary"},
+	)
+	addEntries("C-1", "Review", "work",
+		Entry{ID: "c1", Timestamp: monday, Content: "First look"},
+		Entry{ID: "c2", Timestamp: monday.Add(3 * time.Hour), Content: "Second look"},
+	)
+
+	weekly := func(args map[string]interface{}) string {
+		args["week_start"] = "2026-03-02"
+		result, _ := js.GetWeeklyLog(ctx, CreateMockRequest(args))
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	// The same log every time, tasks in ID order within each day
+	text := weekly(map[string]interface{}{})
+	for i := 0; i < 5; i++ {
+		if again := weekly(map[string]interface{}{}); again != text {
+			t.Fatal("Expected the weekly log to be stable")
+		}
+	}
+	if !(strings.Index(text, "### A-1") < strings.Index(text, "### B-1") && strings.Index(text, "### B-1") < strings.Index(text, "### C-1")) {
+		t.Errorf("Expected tasks in ID order:\n%s", text)
+	}
+
+	text = weekly(map[string]interface{}{"order": "time"})
+	if !(strings.Index(text, "### C-1") < strings.Index(text, "### B-1") && strings.Index(text, "### B-1") < strings.Index(text, "### A-1")) {
+		t.Errorf("Expected tasks in order of their first entry:\n%s", text)
+	}
+
+	text = weekly(map[string]interface{}{"collapse_empty_days": "true"})
+	if !strings.Contains(text, "## 2026-03-03 to 2026-03-05 (Tuesday to Thursday)\n_No activity_") ||
+		!strings.Contains(text, "## 2026-03-07 to 2026-03-08 (Saturday to Sunday)") {
+		t.Errorf("Expected quiet days collapsed:\n%s", text)
+	}
+
+	text = weekly(map[string]interface{}{"group_by": "task", "order": "entries"})
+	if !strings.Contains(text, "## B-1: Reading (learning)\n- Mon 10:00: Chapter one\n- Fri 09:00: Chapter two\n") ||
+		strings.Index(text, "## A-1") < strings.Index(text, "## C-1") {
+		t.Errorf("Expected the week's entries under each task, busiest first:\n%s", text)
+	}
+
+	text = weekly(map[string]interface{}{"group_by": "type"})
+	if !strings.Contains(text, "## Learning (2 entries)\n### B-1: Reading") || !strings.Contains(text, "## Work (3 entries)\n### A-1: Deploy") {
+		t.Errorf("Expected entries grouped by type:\n%s", text)
+	}
+	if !strings.Contains(text, "**Total entries:** 5") {
+		t.Errorf("Expected the weekly summary:\n%s", text)
+	}
+
+	for _, args := range []map[string]interface{}{{"group_by": "month"}, {"order": "title"}} {
+		args["week_start"] = "2026-03-02"
+		if result, _ := js.GetWeeklyLog(ctx, CreateMockRequest(args)); !result.IsError {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
+
+func TestWeekNavigation(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	thursday := time.Date(2026, 3, 5, 23, 30, 0, 0, time.UTC)
+
+	if got := js.weekStartOf(thursday).Format("2006-01-02"); got != "2026-03-02" {
+		t.Errorf("Expected the week to start on Monday 2026-03-02, got %s", got)
+	}
+	config := defaultConfiguration()
+	config.General.WeekStart = "sunday"
+	config.General.TimeZone = "Pacific/Auckland" // already Friday there
+	js.saveConfiguration(config)
+	if got := js.weekStartOf(thursday).Format("2006-01-02"); got != "2026-03-01" {
+		t.Errorf("Expected the week to start on Sunday 2026-03-01, got %s", got)
+	}
+
+	start := js.weekStartOf(time.Now())
+	result, _ := js.GetPreviousWeekLog(ctx, CreateMockRequest(map[string]interface{}{"group_by": "task"}))
+	expected := fmt.Sprintf("# Weekly Log: %s to %s", start.AddDate(0, 0, -7).Format("2006-01-02"), start.AddDate(0, 0, -1).Format("2006-01-02"))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, expected) {
+		t.Errorf("Expected last week's log, got:\n%s", text)
+	}
+	result, _ = js.GetCurrentWeekLog(ctx, CreateMockRequest(map[string]interface{}{}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "# Weekly Log: "+start.Format("2006-01-02")) {
+		t.Errorf("Expected this week's log, got:\n%s", text)
+	}
+
+	config.General.WeekStart = "someday"
+	if err := js.validateConfiguration(config); err == nil {
+		t.Error("Expected an invalid week start to fail validation")
+	}
+}
+
+func TestLogTimeZones(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	config := defaultConfiguration()
+	config.General.TimeZone = "America/Denver"
+	js.saveConfiguration(config)
+
+	// 21:30 on Monday evening in Denver is already Tuesday in UTC
+	createTestTask(t, js, "TZ-1", "Late night", "work")
+	task, _ := js.loadTask("TZ-1")
+	entry := Entry{ID: "late", Timestamp: time.Date(2026, 3, 3, 4, 30, 0, 0, time.UTC), Content: "Evening fix"}
+	task.Entries = append(task.Entries, entry)
+	js.saveTask(task)
+	js.updateDailyLog("TZ-1", entry)
+
+	daily := func(args map[string]interface{}) string {
+		result, _ := js.GetDailyLog(ctx, CreateMockRequest(args))
+		return result.Content[0].(mcp.TextContent).Text
+	}
+	if text := daily(map[string]interface{}{"date": "2026-03-02"}); !strings.Contains(text, "21:30") {
+		t.Errorf("Expected the entry on Monday evening, got:\n%s", text)
+	}
+	if text := daily(map[string]interface{}{"date": "2026-03-03"}); strings.Contains(text, "Evening fix") {
+		t.Errorf("Expected nothing on Tuesday, got:\n%s", text)
+	}
+	if text := daily(map[string]interface{}{"date": "2026-03-03", "timezone": "UTC"}); !strings.Contains(text, "04:30") {
+		t.Errorf("Expected the entry on Tuesday in UTC, got:\n%s", text)
+	}
+
+	result, _ := js.GetWeeklyLog(ctx, CreateMockRequest(map[string]interface{}{"week_start": "2026-03-02", "group_by": "task"}))
+	if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "- Mon 21:30: Evening fix") {
+		t.Errorf("Expected the entry on Monday in the weekly log, got:\n%s", text)
+	}
+	for _, tool := range []func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error){js.GetDailyLog, js.GetWeeklyLog, js.GetCurrentWeekLog} {
+		args := map[string]interface{}{"date": "2026-03-02", "week_start": "2026-03-02", "timezone": "Mars/Olympus"}
+		if result, _ := tool(ctx, CreateMockRequest(args)); !result.IsError {
+			t.Error("Expected an unknown time zone to fail")
+		}
+	}
+}
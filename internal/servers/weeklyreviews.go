@@ -0,0 +1,394 @@
+package servers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const defaultReviewHistoryWeeks = 8
+
+// WeeklyReview is an end-of-week reflection, stored as weekly-reviews/{week_start}.json
+type WeeklyReview struct {
+	WeekStart string        `json:"week_start"` // YYYY-MM-DD
+	Wins      []string      `json:"wins,omitempty"`
+	Misses    []string      `json:"misses,omitempty"`
+	Learnings []string      `json:"learnings,omitempty"`
+	Goals     []string      `json:"goals,omitempty"`     // for the week after
+	CheckIns  []GoalCheckIn `json:"check_ins,omitempty"` // the previous review's goals, met or not
+	Notes     string        `json:"notes,omitempty"`
+	Created   time.Time     `json:"created"`
+	Updated   time.Time     `json:"updated"`
+}
+
+// GoalCheckIn records whether a goal from the previous review was met
+type GoalCheckIn struct {
+	Goal string `json:"goal"`
+	Met  bool   `json:"met"`
+}
+
+// goalsMet counts the met goals among check-ins
+func goalsMet(checkIns []GoalCheckIn) int {
+	met := 0
+	for _, checkIn := range checkIns {
+		if checkIn.Met {
+			met++
+		}
+	}
+	return met
+}
+
+// WeekStats is the activity of one week, set beside its review
+type WeekStats struct {
+	Completed int `json:"completed"`
+	Entries   int `json:"entries"`
+	Minutes   int `json:"minutes"`
+}
+
+func (js *JournalService) weeklyReviewPath(weekStart string) string {
+	return filepath.Join(js.dataDir(), "weekly-reviews", weekStart+".json")
+}
+
+// loadWeeklyReview loads the review of the week starting weekStart, or nil if there is none
+func (js *JournalService) loadWeeklyReview(weekStart string) (*WeeklyReview, error) {
+	data, err := js.readDataFile(js.weeklyReviewPath(weekStart))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var review WeeklyReview
+	if err := json.Unmarshal(data, &review); err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// loadWeeklyReviews returns every weekly review, oldest first
+func (js *JournalService) loadWeeklyReviews() ([]*WeeklyReview, error) {
+	files, err := filepath.Glob(filepath.Join(js.dataDir(), "weekly-reviews", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var reviews []*WeeklyReview
+	for _, path := range files {
+		if review, err := js.loadWeeklyReview(strings.TrimSuffix(filepath.Base(path), ".json")); err == nil && review != nil {
+			reviews = append(reviews, review)
+		}
+	}
+	return reviews, nil
+}
+
+// weeklyReviewLists returns a review's item lists by field name
+func weeklyReviewLists(review *WeeklyReview) map[string]*[]string {
+	return map[string]*[]string{
+		"wins":      &review.Wins,
+		"misses":    &review.Misses,
+		"learnings": &review.Learnings,
+		"goals":     &review.Goals,
+	}
+}
+
+func (js *JournalService) saveWeeklyReview(review *WeeklyReview) error {
+	path := js.weeklyReviewPath(review.WeekStart)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(review, "", "  ")
+	if err != nil {
+		return err
+	}
+	return js.writeDataFile(path, data, 0644)
+}
+
+// checkInGoals marks each goal met when met names it by number (1-based) or
+// text; the rest were missed
+func checkInGoals(goals, met []string) ([]GoalCheckIn, error) {
+	checkIns := make([]GoalCheckIn, len(goals))
+	for i, goal := range goals {
+		checkIns[i] = GoalCheckIn{Goal: goal}
+	}
+	for _, ref := range met {
+		ref = strings.TrimSpace(ref)
+		i := -1
+		if n, err := strconv.Atoi(ref); err == nil && n >= 1 && n <= len(goals) {
+			i = n - 1
+		} else {
+			for j, goal := range goals {
+				if strings.EqualFold(goal, ref) {
+					i = j
+					break
+				}
+			}
+		}
+		if i < 0 {
+			return nil, fmt.Errorf("goals_met %q is not one of last week's goals (use its number or text)", ref)
+		}
+		checkIns[i].Met = true
+	}
+	return checkIns, nil
+}
+
+// weekStats counts completions, written entries and tracked time in [start, end)
+func weekStats(tasks []*Task, start, end time.Time) WeekStats {
+	var stats WeekStats
+	within := func(t time.Time) bool { return !t.Before(start) && t.Before(end) }
+	for _, task := range tasks {
+		if doneAt, ok := completedAt(task); ok && within(doneAt) {
+			stats.Completed++
+		}
+		for _, entry := range task.Entries {
+			if !within(entry.Timestamp) {
+				continue
+			}
+			stats.Minutes += entry.Minutes
+			if isWrittenEntry(entry) {
+				stats.Entries++
+			}
+		}
+	}
+	return stats
+}
+
+// CreateWeeklyReview records wins, misses, learnings and next week's goals,
+// checking in on the goals set the week before
+func (js *JournalService) CreateWeeklyReview(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	loc := js.location()
+	day := time.Now().In(loc)
+	if week := request.GetString("week", ""); week != "" {
+		if validationErr := js.validateDateFormat(week, "week"); validationErr != nil {
+			return mcp.NewToolResultError(validationErr.Error()), nil
+		}
+		day, _ = time.ParseInLocation("2006-01-02", week, loc)
+	}
+	start := js.weekStartIn(day, loc)
+	weekStart := start.Format("2006-01-02")
+
+	defer lockFile(js.weeklyReviewPath(weekStart))()
+	review, err := js.loadWeeklyReview(weekStart)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load weekly review: %v", err)), nil
+	}
+	updated := review != nil
+	if review == nil {
+		review = &WeeklyReview{WeekStart: weekStart, Created: time.Now()}
+	}
+
+	// Fields given replace the stored ones, so a review can be filled in over several calls
+	for name, field := range map[string]*[]string{"wins": &review.Wins, "misses": &review.Misses, "learnings": &review.Learnings, "goals": &review.Goals} {
+		if values := request.GetStringSlice(name, nil); values != nil {
+			*field = nonEmpty(values)
+		}
+	}
+	if notes := request.GetString("notes", ""); notes != "" {
+		review.Notes = notes
+	}
+
+	previous, err := js.loadWeeklyReview(start.AddDate(0, 0, -7).Format("2006-01-02"))
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load last week's review: %v", err)), nil
+	}
+	if met := request.GetStringSlice("goals_met", nil); met != nil {
+		if previous == nil || len(previous.Goals) == 0 {
+			return mcp.NewToolResultError("goals_met needs last week's review to have set goals"), nil
+		}
+		checkIns, err := checkInGoals(previous.Goals, met)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		review.CheckIns = checkIns
+	}
+
+	if len(review.Wins)+len(review.Misses)+len(review.Learnings)+len(review.Goals)+len(review.CheckIns) == 0 && review.Notes == "" {
+		return mcp.NewToolResultError("A weekly review needs at least one of wins, misses, learnings, goals, goals_met or notes"), nil
+	}
+	review.Updated = time.Now()
+	if err := js.saveWeeklyReview(review); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to save weekly review: %v", err)), nil
+	}
+
+	var result strings.Builder
+	verb := "Saved"
+	if updated {
+		verb = "Updated"
+	}
+	result.WriteString(fmt.Sprintf("%s the weekly review for the week of %s: %d wins, %d misses, %d learnings, %d goals for next week",
+		verb, weekStart, len(review.Wins), len(review.Misses), len(review.Learnings), len(review.Goals)))
+	switch {
+	case len(review.CheckIns) > 0:
+		result.WriteString(fmt.Sprintf("\nLast week's goals: %d of %d met", goalsMet(review.CheckIns), len(review.CheckIns)))
+	case previous != nil && len(previous.Goals) > 0:
+		result.WriteString("\nLast week's goals are not checked in yet; pass goals_met with the ones you met:")
+		for i, goal := range previous.Goals {
+			result.WriteString(fmt.Sprintf("\n%d. %s", i+1, goal))
+		}
+	}
+	return mcp.NewToolResultText(result.String()), nil
+}
+
+// nonEmpty drops blank values
+func nonEmpty(values []string) []string {
+	kept := []string{}
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			kept = append(kept, value)
+		}
+	}
+	return kept
+}
+
+// GetReviewHistory lists recent weeks with their reviews beside the week's
+// completions, entries and tracked time, and compares reviewed weeks with the rest
+func (js *JournalService) GetReviewHistory(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	weeks := defaultReviewHistoryWeeks
+	if weeksStr := request.GetString("weeks", ""); weeksStr != "" {
+		parsed, err := strconv.Atoi(weeksStr)
+		if err != nil || parsed < 1 || parsed > 104 {
+			return mcp.NewToolResultError("weeks must be a number from 1 to 104"), nil
+		}
+		weeks = parsed
+	}
+
+	tasks, err := js.loadAllTasks()
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to load tasks: %v", err)), nil
+	}
+
+	type reviewedWeek struct {
+		start  time.Time
+		review *WeeklyReview
+		stats  WeekStats
+	}
+	loc := js.location()
+	current := js.weekStartIn(time.Now(), loc)
+	var history []reviewedWeek
+	for i := 0; i < weeks; i++ {
+		start := current.AddDate(0, 0, -7*i)
+		review, err := js.loadWeeklyReview(start.Format("2006-01-02"))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to load weekly review for %s: %v", start.Format("2006-01-02"), err)), nil
+		}
+		history = append(history, reviewedWeek{start: start, review: review, stats: weekStats(tasks, start, start.AddDate(0, 0, 7))})
+	}
+
+	var md strings.Builder
+	md.WriteString("# Weekly Review History\n\n")
+
+	reviewed, met, checked := 0, 0, 0
+	for _, week := range history {
+		if week.review != nil {
+			reviewed++
+			met += goalsMet(week.review.CheckIns)
+			checked += len(week.review.CheckIns)
+		}
+	}
+	md.WriteString(fmt.Sprintf("**%d of the last %d weeks reviewed", reviewed, weeks))
+	if checked > 0 {
+		md.WriteString(fmt.Sprintf(", %d of %d goals met (%d%%)", met, checked, met*100/checked))
+	}
+	md.WriteString("**\n\n")
+
+	md.WriteString("| Week | Completed | Entries | Tracked | Wins | Misses | Goals met |\n")
+	md.WriteString("|------|-----------|---------|---------|------|--------|-----------|\n")
+	for _, week := range history {
+		tracked := "-"
+		if week.stats.Minutes > 0 {
+			tracked = formatMinutes(week.stats.Minutes)
+		}
+		wins, misses, goals := "-", "-", "-"
+		if week.review != nil {
+			wins, misses = strconv.Itoa(len(week.review.Wins)), strconv.Itoa(len(week.review.Misses))
+			if len(week.review.CheckIns) > 0 {
+				goals = fmt.Sprintf("%d/%d", goalsMet(week.review.CheckIns), len(week.review.CheckIns))
+			}
+		}
+		md.WriteString(fmt.Sprintf("| %s | %d | %d | %s | %s | %s | %s |\n",
+			week.start.Format("2006-01-02"), week.stats.Completed, week.stats.Entries, tracked, wins, misses, goals))
+	}
+	md.WriteString("\n")
+
+	// Set goal-hitting and reviewed weeks against the others
+	average := func(keep func(reviewedWeek) bool) (completed, entries float64, n int) {
+		for _, week := range history {
+			if keep(week) {
+				completed += float64(week.stats.Completed)
+				entries += float64(week.stats.Entries)
+				n++
+			}
+		}
+		if n > 0 {
+			completed, entries = completed/float64(n), entries/float64(n)
+		}
+		return completed, entries, n
+	}
+	var comparisons []string
+	compare := func(label, otherLabel string, keep func(reviewedWeek) bool) {
+		completed, entries, n := average(keep)
+		otherCompleted, otherEntries, others := average(func(week reviewedWeek) bool { return !keep(week) })
+		if n > 0 && others > 0 {
+			comparisons = append(comparisons, fmt.Sprintf("- %s averaged %.1f tasks completed and %.1f entries; %s averaged %.1f and %.1f",
+				label, completed, entries, otherLabel, otherCompleted, otherEntries))
+		}
+	}
+	compare("Reviewed weeks", "unreviewed weeks", func(week reviewedWeek) bool { return week.review != nil })
+	compare("Weeks that met most of their goals", "other weeks", func(week reviewedWeek) bool {
+		return week.review != nil && len(week.review.CheckIns) > 0 && goalsMet(week.review.CheckIns)*2 >= len(week.review.CheckIns)
+	})
+	if len(comparisons) > 0 {
+		md.WriteString("## Patterns\n")
+		md.WriteString(strings.Join(comparisons, "\n") + "\n\n")
+	}
+
+	for _, week := range history {
+		if week.review == nil {
+			continue
+		}
+		md.WriteString(fmt.Sprintf("## Week of %s\n", week.review.WeekStart))
+		md.WriteString(fmt.Sprintf("*%d tasks completed, %d entries written*\n\n", week.stats.Completed, week.stats.Entries))
+		writeReviewList(&md, "Wins", week.review.Wins)
+		writeReviewList(&md, "Misses", week.review.Misses)
+		writeReviewList(&md, "Learnings", week.review.Learnings)
+		if len(week.review.CheckIns) > 0 {
+			md.WriteString("**Last week's goals:**\n")
+			for _, checkIn := range week.review.CheckIns {
+				box := "[ ]"
+				if checkIn.Met {
+					box = "[x]"
+				}
+				md.WriteString(fmt.Sprintf("- %s %s\n", box, checkIn.Goal))
+			}
+			md.WriteString("\n")
+		}
+		writeReviewList(&md, "Goals for next week", week.review.Goals)
+		if week.review.Notes != "" {
+			md.WriteString(week.review.Notes + "\n\n")
+		}
+	}
+
+	markdown, err := js.styleMarkdown(request.GetString("style", ""), md.String())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(markdown), nil
+}
+
+// writeReviewList writes a bold heading and a bullet per item, if there are any
+func writeReviewList(md *strings.Builder, heading string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	md.WriteString(fmt.Sprintf("**%s:**\n", heading))
+	for _, item := range items {
+		md.WriteString("- " + item + "\n")
+	}
+	md.WriteString("\n")
+}
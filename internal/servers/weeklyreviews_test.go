@@ -0,0 +1,93 @@
+package servers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func TestCheckInGoals(t *testing.T) {
+	goals := []string{"Ship the importer", "Write the RFC", "Inbox zero"}
+	checkIns, err := checkInGoals(goals, []string{"2", "inbox ZERO"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if checkIns[0].Met || !checkIns[1].Met || !checkIns[2].Met || goalsMet(checkIns) != 2 {
+		t.Errorf("Expected goals 2 and 3 met, got %+v", checkIns)
+	}
+	if _, err := checkInGoals(goals, []string{"4"}); err == nil {
+		t.Error("Expected an unknown goal rejected")
+	}
+}
+
+func TestWeeklyReviews(t *testing.T) {
+	js, _ := CreateTestJournalService(t)
+	ctx := context.Background()
+	loc := js.location()
+	thisWeek := js.weekStartIn(time.Now(), loc)
+	lastWeek := thisWeek.AddDate(0, 0, -7)
+
+	review := func(args map[string]interface{}) string {
+		result, _ := js.CreateWeeklyReview(ctx, CreateMockRequest(args))
+		text := result.Content[0].(mcp.TextContent).Text
+		if result.IsError {
+			t.Fatalf("Review failed: %s", text)
+		}
+		return text
+	}
+
+	// Last week: a review with goals, and a completed task
+	text := review(map[string]interface{}{
+		"week":   lastWeek.AddDate(0, 0, 2).Format("2006-01-02"),
+		"wins":   []interface{}{"Shipped the importer"},
+		"misses": []interface{}{"Skipped the RFC", " "},
+		"goals":  []interface{}{"Write the RFC", "Pair with Sam"},
+	})
+	if !strings.Contains(text, "Saved the weekly review for the week of "+lastWeek.Format("2006-01-02")) || !strings.Contains(text, "1 misses") {
+		t.Errorf("Unexpected result: %s", text)
+	}
+	createTestTask(t, js, "WR-1", "Importer", "work")
+	task, _ := js.loadTask("WR-1")
+	doneAt := lastWeek.Add(30 * time.Hour)
+	task.Status = "completed"
+	task.Entries = append(task.Entries, Entry{ID: "wr1", Timestamp: doneAt, Type: "status_change", Content: "Status changed from active to completed"},
+		Entry{ID: "wr2", Timestamp: doneAt, Content: "Merged", Minutes: 90})
+	js.saveTask(task)
+
+	// This week: the review asks for a check-in until goals_met is given
+	text = review(map[string]interface{}{"wins": []interface{}{"RFC drafted"}})
+	if !strings.Contains(text, "not checked in yet") || !strings.Contains(text, "2. Pair with Sam") {
+		t.Errorf("Expected last week's goals listed, got: %s", text)
+	}
+	text = review(map[string]interface{}{"goals_met": []interface{}{"1"}, "learnings": []interface{}{"Write first, polish later"}})
+	if !strings.HasPrefix(text, "Updated") || !strings.Contains(text, "1 of 2 met") || !strings.Contains(text, "1 wins") {
+		t.Errorf("Expected the check-in recorded and earlier fields kept, got: %s", text)
+	}
+
+	result, _ := js.GetReviewHistory(ctx, CreateMockRequest(map[string]interface{}{"weeks": "3"}))
+	history := result.Content[0].(mcp.TextContent).Text
+	for _, expected := range []string{
+		"**2 of the last 3 weeks reviewed, 1 of 2 goals met (50%)**",
+		"| " + lastWeek.Format("2006-01-02") + " | 1 | 1 | 1h30m | 1 | 1 | - |",
+		"Reviewed weeks averaged",
+		"- [x] Write the RFC",
+		"- [ ] Pair with Sam",
+		"**Learnings:**\n- Write first, polish later",
+	} {
+		if !strings.Contains(history, expected) {
+			t.Errorf("Expected %q in history:\n%s", expected, history)
+		}
+	}
+
+	result, _ = js.CreateWeeklyReview(ctx, CreateMockRequest(map[string]interface{}{"week": lastWeek.Format("2006-01-02"), "goals_met": []interface{}{"1"}}))
+	if !result.IsError {
+		t.Error("Expected goals_met rejected when the week before set no goals")
+	}
+	result, _ = js.CreateWeeklyReview(ctx, CreateMockRequest(map[string]interface{}{"week": "2020-01-01"}))
+	if !result.IsError {
+		t.Error("Expected an empty review rejected")
+	}
+}